@@ -3,8 +3,11 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
@@ -19,6 +22,7 @@ import (
 	errs "github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterprise "github.com/bytebase/bytebase/backend/enterprise/api"
 	"github.com/bytebase/bytebase/backend/store"
@@ -46,11 +50,30 @@ const (
 	GatewayMetadataAccessTokenKey = "bytebase-access-token"
 	// GatewayMetadataUserIDKey is the gateway metadata key for user ID.
 	GatewayMetadataUserIDKey = "bytebase-user"
+
+	// PersonalAccessTokenPrefix is the prefix of personal access tokens.
+	// It distinguishes personal access tokens from JWT access tokens.
+	PersonalAccessTokenPrefix = "bbp_"
+	personalAccessTokenLength = 40
+	// The last used time of a personal access token is recorded at most once per interval.
+	personalAccessTokenLastUsedInterval = 1 * time.Minute
+
+	// DefaultWorkloadIdentityTokenDuration is the default duration of the access tokens exchanged from workload identity tokens.
+	DefaultWorkloadIdentityTokenDuration = 1 * time.Hour
+	// MaxWorkloadIdentityTokenDuration is the max duration of the access tokens exchanged from workload identity tokens.
+	MaxWorkloadIdentityTokenDuration = 12 * time.Hour
 )
 
+// authStore is the store used by the auth interceptor.
+type authStore interface {
+	GetPersonalAccessToken(ctx context.Context, find *store.FindPersonalAccessTokenMessage) (*store.PersonalAccessTokenMessage, error)
+	UpdatePersonalAccessTokenLastUsedTime(ctx context.Context, uid int, lastUsedTime time.Time) error
+	GetUserByID(ctx context.Context, id int) (*store.UserMessage, error)
+}
+
 // APIAuthInterceptor is the auth interceptor for gRPC server.
 type APIAuthInterceptor struct {
-	store          *store.Store
+	store          authStore
 	secret         string
 	tokenDuration  time.Duration
 	licenseService enterprise.LicenseService
@@ -81,7 +104,7 @@ func (in *APIAuthInterceptor) AuthenticationInterceptor(ctx context.Context, req
 		return nil, status.Errorf(codes.Unauthenticated, err.Error())
 	}
 
-	principalID, err := in.authenticate(ctx, accessTokenStr)
+	principalID, personalAccessToken, err := in.authenticate(ctx, accessTokenStr)
 	if err != nil {
 		if IsAuthenticationAllowed(serverInfo.FullMethod) {
 			return handler(ctx, request)
@@ -91,6 +114,9 @@ func (in *APIAuthInterceptor) AuthenticationInterceptor(ctx context.Context, req
 
 	// Stores principalID into context.
 	childCtx := context.WithValue(ctx, common.PrincipalIDContextKey, principalID)
+	if personalAccessToken != nil {
+		childCtx = context.WithValue(childCtx, common.PersonalAccessTokenContextKey, personalAccessToken)
+	}
 	return handler(childCtx, request)
 }
 
@@ -106,7 +132,7 @@ func (in *APIAuthInterceptor) AuthenticationStreamInterceptor(request any, ss gr
 		return status.Errorf(codes.Unauthenticated, err.Error())
 	}
 
-	principalID, err := in.authenticate(ctx, accessTokenStr)
+	principalID, personalAccessToken, err := in.authenticate(ctx, accessTokenStr)
 	if err != nil {
 		if IsAuthenticationAllowed(serverInfo.FullMethod) {
 			return handler(request, ss)
@@ -116,6 +142,9 @@ func (in *APIAuthInterceptor) AuthenticationStreamInterceptor(request any, ss gr
 
 	// Stores principalID into context.
 	childCtx := context.WithValue(ctx, common.PrincipalIDContextKey, principalID)
+	if personalAccessToken != nil {
+		childCtx = context.WithValue(childCtx, common.PersonalAccessTokenContextKey, personalAccessToken)
+	}
 	sss := overrideStream{ServerStream: ss, childCtx: childCtx}
 	return handler(request, sss)
 }
@@ -129,12 +158,15 @@ func (s overrideStream) Context() context.Context {
 	return s.childCtx
 }
 
-func (in *APIAuthInterceptor) authenticate(ctx context.Context, accessTokenStr string) (int, error) {
+func (in *APIAuthInterceptor) authenticate(ctx context.Context, accessTokenStr string) (int, *store.PersonalAccessTokenMessage, error) {
 	if accessTokenStr == "" {
-		return 0, status.Errorf(codes.Unauthenticated, "access token not found")
+		return 0, nil, status.Errorf(codes.Unauthenticated, "access token not found")
+	}
+	if strings.HasPrefix(accessTokenStr, PersonalAccessTokenPrefix) {
+		return in.authenticatePersonalAccessToken(ctx, accessTokenStr)
 	}
 	if _, ok := in.stateCfg.ExpireCache.Get(accessTokenStr); ok {
-		return 0, status.Errorf(codes.Unauthenticated, "access token expired")
+		return 0, nil, status.Errorf(codes.Unauthenticated, "access token expired")
 	}
 	claims := &claimsMessage{}
 	if _, err := jwt.ParseWithClaims(accessTokenStr, claims, func(t *jwt.Token) (any, error) {
@@ -150,12 +182,12 @@ func (in *APIAuthInterceptor) authenticate(ctx context.Context, accessTokenStr s
 	}); err != nil {
 		var ve *jwt.ValidationError
 		if errors.As(err, &ve) && ve.Errors == jwt.ValidationErrorExpired {
-			return 0, status.Errorf(codes.Unauthenticated, "access token expired")
+			return 0, nil, status.Errorf(codes.Unauthenticated, "access token expired")
 		}
-		return 0, status.Errorf(codes.Unauthenticated, "failed to parse claim")
+		return 0, nil, status.Errorf(codes.Unauthenticated, "failed to parse claim")
 	}
 	if !audienceContains(claims.Audience, fmt.Sprintf(AccessTokenAudienceFmt, in.mode)) {
-		return 0, status.Errorf(codes.Unauthenticated,
+		return 0, nil, status.Errorf(codes.Unauthenticated,
 			"invalid access token, audience mismatch, got %q, expected %q. you may send request to the wrong environment",
 			claims.Audience,
 			fmt.Sprintf(AccessTokenAudienceFmt, in.mode),
@@ -164,20 +196,52 @@ func (in *APIAuthInterceptor) authenticate(ctx context.Context, accessTokenStr s
 
 	principalID, err := strconv.Atoi(claims.Subject)
 	if err != nil {
-		return 0, status.Errorf(codes.Unauthenticated, "malformed ID %q in the access token", claims.Subject)
+		return 0, nil, status.Errorf(codes.Unauthenticated, "malformed ID %q in the access token", claims.Subject)
+	}
+	if err := in.validateUser(ctx, principalID); err != nil {
+		return 0, nil, err
+	}
+
+	return principalID, nil, nil
+}
+
+func (in *APIAuthInterceptor) authenticatePersonalAccessToken(ctx context.Context, accessTokenStr string) (int, *store.PersonalAccessTokenMessage, error) {
+	tokenHash := HashPersonalAccessToken(accessTokenStr)
+	personalAccessToken, err := in.store.GetPersonalAccessToken(ctx, &store.FindPersonalAccessTokenMessage{TokenHash: &tokenHash})
+	if err != nil {
+		return 0, nil, status.Errorf(codes.Unauthenticated, "failed to find personal access token")
+	}
+	if personalAccessToken == nil {
+		return 0, nil, status.Errorf(codes.Unauthenticated, "personal access token not found or revoked")
 	}
+	if personalAccessToken.IsExpired() {
+		return 0, nil, status.Errorf(codes.Unauthenticated, "personal access token expired")
+	}
+	if err := in.validateUser(ctx, personalAccessToken.PrincipalID); err != nil {
+		return 0, nil, err
+	}
+
+	now := time.Now()
+	if personalAccessToken.LastUsedTime == nil || now.Sub(*personalAccessToken.LastUsedTime) > personalAccessTokenLastUsedInterval {
+		if err := in.store.UpdatePersonalAccessTokenLastUsedTime(ctx, personalAccessToken.UID, now); err != nil {
+			slog.Warn("failed to update the last used time of personal access token", slog.Int("token", personalAccessToken.UID), log.BBError(err))
+		}
+	}
+	return personalAccessToken.PrincipalID, personalAccessToken, nil
+}
+
+func (in *APIAuthInterceptor) validateUser(ctx context.Context, principalID int) error {
 	user, err := in.store.GetUserByID(ctx, principalID)
 	if err != nil {
-		return 0, status.Errorf(codes.Unauthenticated, "failed to find user ID %q in the access token", principalID)
+		return status.Errorf(codes.Unauthenticated, "failed to find user ID %q in the access token", principalID)
 	}
 	if user == nil {
-		return 0, status.Errorf(codes.Unauthenticated, "user ID %q not exists in the access token", principalID)
+		return status.Errorf(codes.Unauthenticated, "user ID %q not exists in the access token", principalID)
 	}
 	if user.MemberDeleted {
-		return 0, status.Errorf(codes.Unauthenticated, "user ID %q has been deactivated by administrators", principalID)
+		return status.Errorf(codes.Unauthenticated, "user ID %q has been deactivated by administrators", principalID)
 	}
-
-	return principalID, nil
+	return nil
 }
 
 // GetUserIDFromMFATempToken returns the user ID from the MFA temp token.
@@ -261,6 +325,23 @@ func GenerateMFATempToken(userName string, userID int, mode common.ReleaseMode,
 	return generateToken(userName, userID, fmt.Sprintf(MFATempTokenAudienceFmt, mode), expirationTime, []byte(secret))
 }
 
+// GeneratePersonalAccessToken generates a personal access token and returns the token and its hash.
+// Only the hash is persisted, the token is shown to the user once.
+func GeneratePersonalAccessToken() (string, string, error) {
+	val, err := common.RandomString(personalAccessTokenLength)
+	if err != nil {
+		return "", "", err
+	}
+	token := PersonalAccessTokenPrefix + val
+	return token, HashPersonalAccessToken(token), nil
+}
+
+// HashPersonalAccessToken returns the hex-encoded SHA-256 hash of the personal access token.
+func HashPersonalAccessToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
 // Pay attention to this function. It holds the main JWT token generation logic.
 func generateToken(userName string, userID int, aud string, expirationTime time.Time, secret []byte) (string, error) {
 	// Create the JWT claims, which includes the username and expiry time.
@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+type fakeAuthStore struct {
+	tokens   []*store.PersonalAccessTokenMessage
+	users    []*store.UserMessage
+	lastUsed map[int]time.Time
+}
+
+func (s *fakeAuthStore) GetPersonalAccessToken(_ context.Context, find *store.FindPersonalAccessTokenMessage) (*store.PersonalAccessTokenMessage, error) {
+	for _, token := range s.tokens {
+		if token.TokenHash == *find.TokenHash {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeAuthStore) UpdatePersonalAccessTokenLastUsedTime(_ context.Context, uid int, lastUsedTime time.Time) error {
+	s.lastUsed[uid] = lastUsedTime
+	return nil
+}
+
+func (s *fakeAuthStore) GetUserByID(_ context.Context, id int) (*store.UserMessage, error) {
+	for _, user := range s.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func newFakeAuthStore(t *testing.T) (*fakeAuthStore, map[string]string) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	recent := now.Add(-time.Second)
+	s := &fakeAuthStore{
+		users: []*store.UserMessage{
+			{ID: 101},
+			{ID: 102, MemberDeleted: true},
+		},
+		lastUsed: make(map[int]time.Time),
+	}
+	tokens := make(map[string]string)
+	for _, token := range []struct {
+		name string
+		*store.PersonalAccessTokenMessage
+	}{
+		{"valid", &store.PersonalAccessTokenMessage{UID: 1, PrincipalID: 101, ExpireTime: &future}},
+		{"never-expires", &store.PersonalAccessTokenMessage{UID: 2, PrincipalID: 101, LastUsedTime: &recent}},
+		{"expired", &store.PersonalAccessTokenMessage{UID: 3, PrincipalID: 101, ExpireTime: &past}},
+		{"deactivated-user", &store.PersonalAccessTokenMessage{UID: 4, PrincipalID: 102}},
+		{"deleted-user", &store.PersonalAccessTokenMessage{UID: 5, PrincipalID: 103}},
+		{"scoped", &store.PersonalAccessTokenMessage{UID: 6, PrincipalID: 101, Payload: &storepb.PersonalAccessTokenPayload{
+			Permissions: []string{"bb.projects.get"},
+			Projects:    []string{"p1"},
+		}}},
+	} {
+		value, hash, err := GeneratePersonalAccessToken()
+		require.NoError(t, err)
+		token.TokenHash = hash
+		s.tokens = append(s.tokens, token.PersonalAccessTokenMessage)
+		tokens[token.name] = value
+	}
+	// The revoked token is deleted from the store.
+	revoked, _, err := GeneratePersonalAccessToken()
+	require.NoError(t, err)
+	tokens["revoked"] = revoked
+	return s, tokens
+}
+
+func TestAuthenticatePersonalAccessToken(t *testing.T) {
+	a := require.New(t)
+	s, tokens := newFakeAuthStore(t)
+	in := &APIAuthInterceptor{store: s}
+
+	tests := []struct {
+		token           string
+		wantPrincipalID int
+		wantTokenUID    int
+		wantErr         string
+	}{
+		{token: "valid", wantPrincipalID: 101, wantTokenUID: 1},
+		{token: "never-expires", wantPrincipalID: 101, wantTokenUID: 2},
+		{token: "scoped", wantPrincipalID: 101, wantTokenUID: 6},
+		{token: "expired", wantErr: "personal access token expired"},
+		{token: "revoked", wantErr: "personal access token not found or revoked"},
+		{token: "deactivated-user", wantErr: "has been deactivated by administrators"},
+		{token: "deleted-user", wantErr: "not exists in the access token"},
+	}
+	for _, test := range tests {
+		principalID, token, err := in.authenticate(context.Background(), tokens[test.token])
+		if test.wantErr != "" {
+			a.Equal(codes.Unauthenticated, status.Code(err), test.token)
+			a.Contains(status.Convert(err).Message(), test.wantErr, test.token)
+			continue
+		}
+		a.NoError(err, test.token)
+		a.Equal(test.wantPrincipalID, principalID, test.token)
+		a.Equal(test.wantTokenUID, token.UID, test.token)
+	}
+
+	// The last used time is recorded at most once per interval.
+	a.Contains(s.lastUsed, 1)
+	a.NotContains(s.lastUsed, 2)
+}
+
+func TestAuthenticationInterceptorPersonalAccessToken(t *testing.T) {
+	a := require.New(t)
+	s, tokens := newFakeAuthStore(t)
+	in := &APIAuthInterceptor{store: s}
+	serverInfo := &grpc.UnaryServerInfo{FullMethod: "/bytebase.v1.ProjectService/GetProject"}
+	streamServerInfo := &grpc.StreamServerInfo{FullMethod: "/bytebase.v1.SQLService/AdminExecute"}
+
+	tests := []struct {
+		token   string
+		wantErr bool
+	}{
+		{token: "scoped"},
+		{token: "expired", wantErr: true},
+		{token: "revoked", wantErr: true},
+		{token: "deactivated-user", wantErr: true},
+	}
+	for _, test := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("Authorization", "Bearer "+tokens[test.token]))
+		var unaryCtx, streamCtx context.Context
+		_, unaryErr := in.AuthenticationInterceptor(ctx, nil, serverInfo, func(ctx context.Context, _ any) (any, error) {
+			unaryCtx = ctx
+			return nil, nil
+		})
+		streamErr := in.AuthenticationStreamInterceptor(nil, fakeServerStream{ctx: ctx}, streamServerInfo, func(_ any, ss grpc.ServerStream) error {
+			streamCtx = ss.Context()
+			return nil
+		})
+		if test.wantErr {
+			a.Equal(codes.Unauthenticated, status.Code(unaryErr), test.token)
+			a.Equal(codes.Unauthenticated, status.Code(streamErr), test.token)
+			continue
+		}
+		a.NoError(unaryErr, test.token)
+		a.NoError(streamErr, test.token)
+		// The handlers get the token to check its scope.
+		for _, handlerCtx := range []context.Context{unaryCtx, streamCtx} {
+			a.Equal(101, handlerCtx.Value(common.PrincipalIDContextKey))
+			token, ok := handlerCtx.Value(common.PersonalAccessTokenContextKey).(*store.PersonalAccessTokenMessage)
+			a.True(ok)
+			a.Equal([]string{"p1"}, token.Payload.GetProjects())
+		}
+	}
+}
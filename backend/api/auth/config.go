@@ -10,6 +10,7 @@ var authenticationAllowlistMethods = map[string]bool{
 	"/bytebase.v1.AuthService/Login":                             true,
 	"/bytebase.v1.AuthService/Logout":                            true,
 	"/bytebase.v1.AuthService/CreateUser":                        true,
+	"/bytebase.v1.AuthService/ExchangeWorkloadIdentityToken":     true,
 	"/bytebase.v1.IdentityProviderService/ListIdentityProviders": true,
 }
 
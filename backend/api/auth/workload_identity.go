@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"context"
+	"sync"
+
+	"github.com/coreos/go-oidc"
+	"github.com/google/cel-go/cel"
+	"github.com/pkg/errors"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+
+	"github.com/bytebase/bytebase/backend/common"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	// keySets caches the remote JSON Web Key Sets by issuer and JWKS URL.
+	// The remote key set refreshes the keys by itself when it sees an unknown key ID.
+	keySetsLock sync.Mutex
+	keySets     = make(map[string]oidc.KeySet)
+
+	// workloadIdentityClaims are the claims identifying the workload, e.g. the repository of the CI job.
+	// The condition must bind one of them, otherwise any workflow of a public issuer such as GitHub Actions
+	// requesting the audience would be accepted.
+	workloadIdentityClaims = map[string]bool{
+		"sub":        true,
+		"repository": true,
+	}
+)
+
+// VerifyWorkloadIdentityToken verifies the OIDC ID token against the workload identity provider,
+// and returns the claims of the token.
+func VerifyWorkloadIdentityToken(ctx context.Context, provider *storepb.WorkloadIdentitySetting_Provider, token string) (map[string]any, error) {
+	keySet, err := getKeySet(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	verifier := oidc.NewVerifier(provider.IssuerUrl, keySet, &oidc.Config{
+		// The audience is checked against all the accepted audiences below.
+		SkipClientIDCheck: true,
+	})
+	idToken, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to verify ID token")
+	}
+	if !audienceMatches(idToken.Audience, provider.Audiences) {
+		return nil, errors.Errorf("ID token audience %v is not accepted", idToken.Audience)
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse ID token claims")
+	}
+	ok, err := EvalWorkloadIdentityCondition(provider.Condition, claims)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to evaluate condition %q", provider.Condition)
+	}
+	if !ok {
+		return nil, errors.Errorf("ID token claims do not satisfy the condition of provider %q", provider.Id)
+	}
+	return claims, nil
+}
+
+// ValidateWorkloadIdentityCondition validates that the condition is a bool CEL expression binding the "sub" or "repository" claim.
+func ValidateWorkloadIdentityCondition(expression string) error {
+	if expression == "" {
+		return errors.New("condition cannot be empty")
+	}
+	env, err := cel.NewEnv(common.WorkloadIdentityCELAttributes...)
+	if err != nil {
+		return err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType {
+		return errors.Errorf("condition must return bool, but got %s", ast.OutputType())
+	}
+	parsedExpr, err := cel.AstToParsedExpr(ast)
+	if err != nil {
+		return err
+	}
+	if !bindsWorkloadIdentityClaim(parsedExpr.Expr) {
+		return errors.Errorf(`condition must match the "sub" or "repository" claim against a literal`)
+	}
+	return nil
+}
+
+// bindsWorkloadIdentityClaim returns true if the expression is only satisfied when the "sub" or "repository" claim
+// matches a literal, e.g. claims.sub == "...", claims.repository in ["..."] or claims.sub.startsWith("...").
+// The binding must hold on every branch: it may be AND-ed with other checks, and both operands of an OR must bind.
+func bindsWorkloadIdentityClaim(expr *exprpb.Expr) bool {
+	call := expr.GetCallExpr()
+	if call == nil {
+		return false
+	}
+	args := call.Args
+	switch call.Function {
+	case "_&&_":
+		return len(args) == 2 && (bindsWorkloadIdentityClaim(args[0]) || bindsWorkloadIdentityClaim(args[1]))
+	case "_||_":
+		return len(args) == 2 && bindsWorkloadIdentityClaim(args[0]) && bindsWorkloadIdentityClaim(args[1])
+	case "_==_":
+		return len(args) == 2 && ((isWorkloadIdentityClaim(args[0]) && isNonEmptyString(args[1])) || (isNonEmptyString(args[0]) && isWorkloadIdentityClaim(args[1])))
+	case "@in":
+		if len(args) != 2 || !isWorkloadIdentityClaim(args[0]) {
+			return false
+		}
+		elements := args[1].GetListExpr().GetElements()
+		if len(elements) == 0 {
+			return false
+		}
+		for _, element := range elements {
+			if !isNonEmptyString(element) {
+				return false
+			}
+		}
+		return true
+	case "startsWith":
+		return len(args) == 1 && isWorkloadIdentityClaim(call.Target) && isNonEmptyString(args[0])
+	}
+	return false
+}
+
+// isWorkloadIdentityClaim returns true if the expression is claims.sub, claims["sub"], claims.repository or claims["repository"].
+func isWorkloadIdentityClaim(expr *exprpb.Expr) bool {
+	if sel := expr.GetSelectExpr(); sel != nil {
+		return !sel.TestOnly && sel.Operand.GetIdentExpr().GetName() == "claims" && workloadIdentityClaims[sel.Field]
+	}
+	if call := expr.GetCallExpr(); call != nil && call.Function == "_[_]" && len(call.Args) == 2 {
+		return call.Args[0].GetIdentExpr().GetName() == "claims" && workloadIdentityClaims[call.Args[1].GetConstExpr().GetStringValue()]
+	}
+	return false
+}
+
+func isNonEmptyString(expr *exprpb.Expr) bool {
+	return expr.GetConstExpr().GetStringValue() != ""
+}
+
+// EvalWorkloadIdentityCondition evaluates the CEL condition on the ID token claims.
+// An empty condition is never satisfied.
+func EvalWorkloadIdentityCondition(expression string, claims map[string]any) (bool, error) {
+	if expression == "" {
+		return false, errors.New("condition cannot be empty")
+	}
+	env, err := cel.NewEnv(common.WorkloadIdentityCELAttributes...)
+	if err != nil {
+		return false, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return false, issues.Err()
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(map[string]any{"claims": claims})
+	if err != nil {
+		return false, err
+	}
+	val, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.Errorf("expect bool result, got %v", out.Value())
+	}
+	return val, nil
+}
+
+func getKeySet(ctx context.Context, provider *storepb.WorkloadIdentitySetting_Provider) (oidc.KeySet, error) {
+	key := provider.IssuerUrl + "|" + provider.JwksUrl
+	keySetsLock.Lock()
+	keySet, ok := keySets[key]
+	keySetsLock.Unlock()
+	if ok {
+		return keySet, nil
+	}
+
+	// The discovery is a network round trip, so it runs outside the lock to not block the other issuers.
+	jwksURL := provider.JwksUrl
+	if jwksURL == "" {
+		p, err := oidc.NewProvider(ctx, provider.IssuerUrl)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to discover the OpenID configuration of issuer %q", provider.IssuerUrl)
+		}
+		var discovery struct {
+			JWKSURL string `json:"jwks_uri"`
+		}
+		if err := p.Claims(&discovery); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the OpenID configuration of issuer %q", provider.IssuerUrl)
+		}
+		if discovery.JWKSURL == "" {
+			return nil, errors.Errorf("jwks_uri is missing in the OpenID configuration of issuer %q", provider.IssuerUrl)
+		}
+		jwksURL = discovery.JWKSURL
+	}
+
+	keySetsLock.Lock()
+	defer keySetsLock.Unlock()
+	// Keep the key set of the concurrent request that won the race, so that the cached keys are shared.
+	if keySet, ok := keySets[key]; ok {
+		return keySet, nil
+	}
+	// The key set outlives the request, so it must not be bound to the request context.
+	keySet = oidc.NewRemoteKeySet(context.Background(), jwksURL)
+	keySets[key] = keySet
+	return keySet, nil
+}
+
+func audienceMatches(audience []string, accepted []string) bool {
+	for _, a := range audience {
+		for _, b := range accepted {
+			if a == b {
+				return true
+			}
+		}
+	}
+	return false
+}
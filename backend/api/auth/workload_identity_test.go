@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestEvalWorkloadIdentityCondition(t *testing.T) {
+	a := require.New(t)
+	claims := map[string]any{
+		"sub":        "repo:bytebase/bytebase:ref:refs/heads/main",
+		"repository": "bytebase/bytebase",
+		"ref":        "refs/heads/main",
+	}
+
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		{
+			condition: `claims.repository == "bytebase/bytebase" && claims.ref == "refs/heads/main"`,
+			want:      true,
+		},
+		{
+			condition: `claims.sub.startsWith("repo:bytebase/bytebase:")`,
+			want:      true,
+		},
+		{
+			condition: `claims.ref == "refs/heads/release"`,
+			want:      false,
+		},
+	}
+	for _, test := range tests {
+		got, err := EvalWorkloadIdentityCondition(test.condition, claims)
+		a.NoError(err)
+		a.Equal(test.want, got, test.condition)
+	}
+
+	// Missing claims fail the evaluation.
+	_, err := EvalWorkloadIdentityCondition(`claims.environment == "prod"`, claims)
+	a.Error(err)
+	// The empty condition is never satisfied.
+	ok, err := EvalWorkloadIdentityCondition("", claims)
+	a.Error(err)
+	a.False(ok)
+}
+
+func TestValidateWorkloadIdentityCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		wantErr   bool
+	}{
+		{condition: "", wantErr: true},
+		{condition: `claims.repository == "bytebase/bytebase" && claims.ref == "refs/heads/main"`},
+		{condition: `claims.sub.startsWith("repo:bytebase/bytebase:")`},
+		{condition: `claims["sub"] == "repo:bytebase/bytebase:ref:refs/heads/main"`},
+		{condition: `claims.repository in ["bytebase/bytebase", "bytebase/docs"]`},
+		{condition: `"bytebase/bytebase" == claims.repository || claims.sub.startsWith("repo:bytebase/docs:")`},
+		// Not checking the workload.
+		{condition: `claims.ref == "refs/heads/main"`, wantErr: true},
+		{condition: `has(claims.sub)`, wantErr: true},
+		{condition: "true", wantErr: true},
+		// Not binding the workload on every branch.
+		{condition: `claims.sub != "" || true`, wantErr: true},
+		{condition: `claims.sub == "repo:bytebase/bytebase" || true`, wantErr: true},
+		{condition: `claims.repository == "bytebase/bytebase" || claims.ref == "refs/heads/main"`, wantErr: true},
+		{condition: `!(claims.repository == "bytebase/bytebase")`, wantErr: true},
+		{condition: `claims.repository == claims.ref`, wantErr: true},
+		{condition: `claims.repository == ""`, wantErr: true},
+		{condition: `claims.sub.startsWith("")`, wantErr: true},
+		{condition: `claims.repository in []`, wantErr: true},
+		{condition: `[claims.repository == "bytebase/bytebase", true].exists(x, x)`, wantErr: true},
+		// Not returning bool.
+		{condition: "claims.sub", wantErr: true},
+		{condition: `claims.repository + "/main"`, wantErr: true},
+		// Invalid expression.
+		{condition: "claims.sub ==", wantErr: true},
+	}
+	a := require.New(t)
+	for _, test := range tests {
+		err := ValidateWorkloadIdentityCondition(test.condition)
+		if test.wantErr {
+			a.Error(err, test.condition)
+		} else {
+			a.NoError(err, test.condition)
+		}
+	}
+}
+
+func TestVerifyWorkloadIdentityToken(t *testing.T) {
+	a := require.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	a.NoError(err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "k1",
+					"alg": "RS256",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	provider := &storepb.WorkloadIdentitySetting_Provider{
+		Id:             "github",
+		IssuerUrl:      "https://token.actions.githubusercontent.com",
+		JwksUrl:        server.URL,
+		Audiences:      []string{"bytebase"},
+		Condition:      `claims.repository == "bytebase/bytebase"`,
+		ServiceAccount: "ci@service.bytebase.com",
+	}
+	sign := func(issuer, audience, repository string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":        issuer,
+			"aud":        audience,
+			"sub":        "repo:" + repository,
+			"repository": repository,
+			"iat":        time.Now().Unix(),
+			"exp":        time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = "k1"
+		s, err := token.SignedString(key)
+		a.NoError(err)
+		return s
+	}
+
+	claims, err := VerifyWorkloadIdentityToken(context.Background(), provider, sign(provider.IssuerUrl, "bytebase", "bytebase/bytebase"))
+	a.NoError(err)
+	a.Equal("repo:bytebase/bytebase", claims["sub"])
+
+	_, err = VerifyWorkloadIdentityToken(context.Background(), provider, sign("https://gitlab.com", "bytebase", "bytebase/bytebase"))
+	a.Error(err)
+	_, err = VerifyWorkloadIdentityToken(context.Background(), provider, sign(provider.IssuerUrl, "others", "bytebase/bytebase"))
+	a.Error(err)
+	_, err = VerifyWorkloadIdentityToken(context.Background(), provider, sign(provider.IssuerUrl, "bytebase", "bytebase/others"))
+	a.Error(err)
+}
+
+func TestGetKeySetDiscoveryOutsideLock(t *testing.T) {
+	a := require.New(t)
+
+	// The discovery of the slow issuer hangs until the test ends.
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	slow := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		once.Do(func() { close(started) })
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_, _ = getKeySet(ctx, &storepb.WorkloadIdentitySetting_Provider{IssuerUrl: slow.URL})
+	}()
+	<-started
+
+	// The other issuers are not blocked by the pending discovery.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		provider := &storepb.WorkloadIdentitySetting_Provider{IssuerUrl: "https://gitlab.com", JwksUrl: "https://gitlab.com/oauth/discovery/keys"}
+		keySet, err := getKeySet(context.Background(), provider)
+		a.NoError(err)
+		again, err := getKeySet(context.Background(), provider)
+		a.NoError(err)
+		a.Same(keySet, again)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("getKeySet is blocked by the discovery of another issuer")
+	}
+}
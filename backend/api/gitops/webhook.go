@@ -7,6 +7,7 @@ import (
 	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"log/slog"
@@ -661,6 +662,25 @@ func (s *Service) RegisterWebhookRoutes(g *echo.Group) {
 		}
 
 		sqlFileName2Advice := s.sqlAdviceForSQLFiles(ctx, oauthContext, repositoryList, prFiles, setting.ExternalUrl)
+		// The fixes of the SQL files are posted as the suggested changes, which the CI output cannot render.
+		switch repo.vcs.Type {
+		case vcs.GitHub, vcs.GitLab:
+			if suggestions := getPullRequestSuggestions(sqlFileName2Advice, prFiles); len(suggestions) > 0 {
+				if err := vcs.Get(repo.vcs.Type, vcs.ProviderConfig{}).CreatePullRequestSuggestions(
+					ctx,
+					oauthContext,
+					repo.vcs.InstanceURL,
+					repo.repository.ExternalID,
+					request.PullRequestID,
+					suggestions,
+				); err != nil {
+					slog.Warn("failed to create the suggested changes in the pull request",
+						slog.String("pull_request", request.PullRequestID),
+						log.BBError(err),
+					)
+				}
+			}
+		}
 
 		if s.licenseService.IsFeatureEnabled(api.FeatureMybatisSQLReview) == nil {
 			// If the commit file list contains the file which extension is xml and the content
@@ -843,6 +863,8 @@ func (s *Service) sqlAdviceForMybatisMapperFile(ctx context.Context, datum *myba
 				}
 				// Remap the line number to the original file.
 				for _, advice := range adviceList {
+					// The fix range is in the extracted SQL, which cannot be suggested in the mapper file.
+					advice.Fix = nil
 					for _, line := range lineMapping {
 						if advice.Line <= line.SQLLastLine {
 							advice.Line = line.OriginalEleLine
@@ -1043,6 +1065,12 @@ func (s *Service) sqlAdviceForFile(
 		if err != nil {
 			return nil, errors.Errorf("Failed to exec the SQL check for database %v with error: %v", database.UID, err)
 		}
+		// The suggested changes in the pull request replace the whole lines.
+		for i, advice := range adviceList {
+			if advice.Fix != nil {
+				adviceList[i].Fix, _ = advice.Fix.ExpandToLines(fileContent)
+			}
+		}
 
 		return adviceList, nil
 	}
@@ -2294,6 +2322,9 @@ func convertSQLAdviceToGitLabCIResult(adviceMap map[string][]advisor.Advice) *ap
 				sqlReviewDocs,
 				advice.Code,
 			)
+			if advice.Fix != nil {
+				content = fmt.Sprintf("%s\n%s", content, escapeXMLText(formatSuggestedChange(advice.Fix)))
+			}
 
 			testcase := fmt.Sprintf(
 				"<testcase name=\"%s\" classname=\"%s\" file=\"%s#L%d\">\n<failure>\n%s\n</failure>\n</testcase>",
@@ -2367,6 +2398,9 @@ func convertSQLAdviceToGitHubActionResult(adviceMap map[string][]advisor.Advice)
 				sqlReviewDocs,
 				advice.Code,
 			)
+			if advice.Fix != nil {
+				msg = fmt.Sprintf("%s\n%s", msg, formatSuggestedChange(advice.Fix))
+			}
 			// To indent the output message in action
 			messageList = append(messageList, strings.ReplaceAll(msg, "\n", "%0A"))
 		}
@@ -2377,6 +2411,52 @@ func convertSQLAdviceToGitHubActionResult(adviceMap map[string][]advisor.Advice)
 	}
 }
 
+// formatSuggestedChange formats the line based fix in the plain text of the CI output.
+// The fix is posted as the suggested change in the pull request by createPullRequestSuggestions.
+func formatSuggestedChange(fix *advisor.Fix) string {
+	lines := fmt.Sprintf("line %d", fix.StartLine)
+	if fix.EndLine != fix.StartLine {
+		lines = fmt.Sprintf("lines %d-%d", fix.StartLine, fix.EndLine)
+	}
+	return fmt.Sprintf("Suggested change (%s) for %s:\n%s", fix.Description, lines, fix.NewText)
+}
+
+func escapeXMLText(s string) string {
+	var buf strings.Builder
+	// The strings.Builder never fails to write.
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// getPullRequestSuggestions returns the suggested changes of the line based fixes of the SQL files in the pull request.
+func getPullRequestSuggestions(adviceMap map[string][]advisor.Advice, prFiles []*vcs.PullRequestFile) []*vcs.PullRequestSuggestion {
+	commitIDs := map[string]string{}
+	for _, prFile := range prFiles {
+		commitIDs[prFile.Path] = prFile.LastCommitID
+	}
+	var suggestions []*vcs.PullRequestSuggestion
+	for _, filePath := range getSQLAdviceFileList(adviceMap) {
+		commitID, ok := commitIDs[filePath]
+		if !ok {
+			continue
+		}
+		for _, advice := range adviceMap[filePath] {
+			if advice.Fix == nil {
+				continue
+			}
+			suggestions = append(suggestions, &vcs.PullRequestSuggestion{
+				Path:      filePath,
+				CommitID:  commitID,
+				StartLine: advice.Fix.StartLine,
+				EndLine:   advice.Fix.EndLine,
+				Comment:   fmt.Sprintf("%s (%s): %s", advice.Title, advice.Fix.Description, advice.Content),
+				NewText:   advice.Fix.NewText,
+			})
+		}
+	}
+	return suggestions
+}
+
 func getSQLAdviceFileList(adviceMap map[string][]advisor.Advice) []string {
 	fileList := []string{}
 	fileToErrorCount := map[string]int{}
@@ -109,6 +109,14 @@ var mockSQLAdviceMap = map[string][]advisor.Advice{
 			Title:   "naming.index.idx",
 			Content: "Index in table \"tech_book\" mismatches the naming convention, expect \"^$|^idx_tech_book_id_name$\" but found \"tech_book_id_name\"",
 			Line:    2,
+			Fix: &advisor.Fix{
+				Description: "Rename to \"idx_tech_book_id_name\"",
+				StartLine:   2,
+				StartColumn: 0,
+				EndLine:     2,
+				EndColumn:   52,
+				NewText:     "CREATE INDEX idx_tech_book_id_name ON tech_book(id, name) WHERE id > 0;",
+			},
 		},
 	},
 	"file2.sql": {
@@ -150,6 +158,7 @@ Please check the docs at https://www.bytebase.com/docs/reference/error-code/advi
 <failure>
 Error: Index in table "tech_book" mismatches the naming convention, expect "^$|^idx_tech_book_id_name$" but found "tech_book_id_name".
 Please check the docs at https://www.bytebase.com/docs/reference/error-code/advisor#303
+Suggested change (Rename to &#34;idx_tech_book_id_name&#34;) for line 2:&#xA;CREATE INDEX idx_tech_book_id_name ON tech_book(id, name) WHERE id &gt; 0;
 </failure>
 </testcase>
 </testsuite>
@@ -177,7 +186,7 @@ Please check the docs at https://www.bytebase.com/docs/reference/error-code/advi
 func TestVCSSQLReview_ConvertSQLAdviceToGitHubActionResult(t *testing.T) {
 	expect := []string{
 		"::warning file=file1.sql,line=1,col=1,endColumn=2,title=column.no-null (402)::Column \"id\" in \"public\".\"book\" cannot have NULL value%0ADoc: https://www.bytebase.com/docs/reference/error-code/advisor#402",
-		"::error file=file1.sql,line=2,col=1,endColumn=2,title=naming.index.idx (303)::Index in table \"tech_book\" mismatches the naming convention, expect \"^$|^idx_tech_book_id_name$\" but found \"tech_book_id_name\"%0ADoc: https://www.bytebase.com/docs/reference/error-code/advisor#303",
+		"::error file=file1.sql,line=2,col=1,endColumn=2,title=naming.index.idx (303)::Index in table \"tech_book\" mismatches the naming convention, expect \"^$|^idx_tech_book_id_name$\" but found \"tech_book_id_name\"%0ADoc: https://www.bytebase.com/docs/reference/error-code/advisor#303%0ASuggested change (Rename to \"idx_tech_book_id_name\") for line 2:%0ACREATE INDEX idx_tech_book_id_name ON tech_book(id, name) WHERE id > 0;",
 		"::warning file=file2.sql,line=1,col=1,endColumn=2,title=naming.table (301)::\"techBook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"%0ADoc: https://www.bytebase.com/docs/reference/error-code/advisor#301",
 		"::error file=file2.sql,line=4,col=1,endColumn=2,title=naming.index.uk (304)::Unique key in table \"tech_book\" mismatches the naming convention, expect \"^$|^uk_tech_book_id_name$\" but found \"tech_book_id_name\"%0ADoc: https://www.bytebase.com/docs/reference/error-code/advisor#304",
 	}
@@ -187,6 +196,26 @@ func TestVCSSQLReview_ConvertSQLAdviceToGitHubActionResult(t *testing.T) {
 	assert.Equal(t, expect, res.Content)
 }
 
+func TestGetPullRequestSuggestions(t *testing.T) {
+	prFiles := []*vcs.PullRequestFile{
+		{Path: "file1.sql", LastCommitID: "c1"},
+		{Path: "file2.sql", LastCommitID: "c1"},
+	}
+	expect := []*vcs.PullRequestSuggestion{
+		{
+			Path:      "file1.sql",
+			CommitID:  "c1",
+			StartLine: 2,
+			EndLine:   2,
+			Comment:   "naming.index.idx (Rename to \"idx_tech_book_id_name\"): Index in table \"tech_book\" mismatches the naming convention, expect \"^$|^idx_tech_book_id_name$\" but found \"tech_book_id_name\"",
+			NewText:   "CREATE INDEX idx_tech_book_id_name ON tech_book(id, name) WHERE id > 0;",
+		},
+	}
+	assert.Equal(t, expect, getPullRequestSuggestions(mockSQLAdviceMap, prFiles))
+	// The files not in the pull request, e.g. the mybatis mapper files, have no suggestions.
+	assert.Empty(t, getPullRequestSuggestions(mockSQLAdviceMap, nil))
+}
+
 func TestGetFileInfo(t *testing.T) {
 	t.Run("a SQL format DDL", func(t *testing.T) {
 		mi, fileType, repoInfo, err := getFileInfo(
@@ -0,0 +1,163 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+// CodeAction is the code action returned by textDocument/codeAction.
+// https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeAction
+type CodeAction struct {
+	Title       string             `json:"title"`
+	Kind        lsp.CodeActionKind `json:"kind,omitempty"`
+	Diagnostics []lsp.Diagnostic   `json:"diagnostics,omitempty"`
+	Edit        *lsp.WorkspaceEdit `json:"edit,omitempty"`
+}
+
+func (h *Handler) handleTextDocumentCodeAction(ctx context.Context, _ *jsonrpc2.Conn, _ *jsonrpc2.Request, params lsp.CodeActionParams) ([]CodeAction, error) {
+	if !IsURI(params.TextDocument.URI) {
+		return nil, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeInvalidParams,
+			Message: fmt.Sprintf("textDocument/codeAction not yet supported for out-of-workspace URI (%q)", params.TextDocument.URI),
+		}
+	}
+	content, err := h.readFile(ctx, params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	adviceList, err := h.sqlReviewCheck(ctx, string(content))
+	if err != nil {
+		// return errors will close the websocket connection, so we just log the error and return empty code actions.
+		slog.Error("Failed to check SQL review", log.BBError(err))
+		return []CodeAction{}, nil
+	}
+
+	actions := []CodeAction{}
+	for _, advice := range adviceList {
+		if advice.Fix == nil {
+			continue
+		}
+		editRange := convertFixRange(advice.Fix)
+		if !isRangeOverlapped(editRange, params.Range) {
+			continue
+		}
+		actions = append(actions, CodeAction{
+			Title: fmt.Sprintf("%s (%s)", advice.Fix.Description, advice.Title),
+			Kind:  lsp.CAKQuickFix,
+			Diagnostics: []lsp.Diagnostic{
+				{
+					Range:    editRange,
+					Severity: convertDiagnosticSeverity(advice.Status),
+					Code:     fmt.Sprintf("%d", advice.Code),
+					Source:   advice.Title,
+					Message:  advice.Content,
+				},
+			},
+			Edit: &lsp.WorkspaceEdit{
+				Changes: map[string][]lsp.TextEdit{
+					string(params.TextDocument.URI): {
+						{
+							Range:   editRange,
+							NewText: advice.Fix.NewText,
+						},
+					},
+				},
+			},
+		})
+	}
+	return actions, nil
+}
+
+// sqlReviewCheck checks the statement with the SQL review policy of the database environment.
+func (h *Handler) sqlReviewCheck(ctx context.Context, statement string) ([]advisor.Advice, error) {
+	instanceID := h.getInstanceID()
+	if instanceID == "" {
+		return nil, errors.Errorf("instance is not specified")
+	}
+	databaseName := h.getDefaultDatabase()
+	if databaseName == "" {
+		return nil, nil
+	}
+
+	instance, err := h.store.GetInstanceV2(ctx, &store.FindInstanceMessage{ResourceID: &instanceID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance")
+	}
+	if instance == nil {
+		return nil, errors.Errorf("instance %s not found", instanceID)
+	}
+	database, err := h.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{
+		InstanceID:   &instanceID,
+		DatabaseName: &databaseName,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database")
+	}
+	if database == nil {
+		return nil, errors.Errorf("database %s for instance %s not found", databaseName, instanceID)
+	}
+	environment, err := h.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{ResourceID: &database.EffectiveEnvironmentID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get environment")
+	}
+	if environment == nil {
+		return nil, errors.Errorf("environment %s not found", database.EffectiveEnvironmentID)
+	}
+	policy, err := h.store.GetSQLReviewPolicy(ctx, environment.UID)
+	if err != nil {
+		if e, ok := err.(*common.Error); ok && e.Code == common.NotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get SQL review policy")
+	}
+	catalog, err := h.store.NewCatalog(ctx, database.UID, instance.Engine, store.IgnoreDatabaseAndTableCaseSensitive(instance), advisor.SyntaxModeNormal)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create catalog")
+	}
+
+	// The rules requiring the database connection are skipped without the driver.
+	return advisor.SQLReviewCheck(statement, policy.RuleList, advisor.SQLReviewCheckContext{
+		DbType:          instance.Engine,
+		Catalog:         catalog,
+		Context:         ctx,
+		CurrentDatabase: database.DatabaseName,
+	})
+}
+
+// convertFixRange converts the fix range to the LSP range, the LSP lines are 0-based.
+func convertFixRange(fix *advisor.Fix) lsp.Range {
+	return lsp.Range{
+		Start: lsp.Position{Line: fix.StartLine - 1, Character: fix.StartColumn},
+		End:   lsp.Position{Line: fix.EndLine - 1, Character: fix.EndColumn},
+	}
+}
+
+func isRangeOverlapped(a, b lsp.Range) bool {
+	return !isPositionBefore(a.End, b.Start) && !isPositionBefore(b.End, a.Start)
+}
+
+func isPositionBefore(a, b lsp.Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
+
+func convertDiagnosticSeverity(status advisor.Status) lsp.DiagnosticSeverity {
+	switch status {
+	case advisor.Error:
+		return lsp.Error
+	case advisor.Warn:
+		return lsp.Warning
+	default:
+		return lsp.Information
+	}
+}
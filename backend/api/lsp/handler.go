@@ -28,6 +28,7 @@ const (
 	LSPMethodSetTrace       Method = "$/setTrace"
 	LSPMethodExecuteCommand Method = "workspace/executeCommand"
 	LSPMethodCompletion     Method = "textDocument/completion"
+	LSPMethodCodeAction     Method = "textDocument/codeAction"
 
 	LSPMethodTextDocumentDidOpen   Method = "textDocument/didOpen"
 	LSPMethodTextDocumentDidChange Method = "textDocument/didChange"
@@ -195,6 +196,7 @@ func (h *Handler) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2
 				CompletionProvider: &lsp.CompletionOptions{
 					TriggerCharacters: []string{"."},
 				},
+				CodeActionProvider: true,
 				ExecuteCommandProvider: &lsp.ExecuteCommandOptions{
 					Commands: []string{string(CommandNameSetMetadata)},
 				},
@@ -248,6 +250,15 @@ func (h *Handler) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2
 			return nil, err
 		}
 		return h.handleTextDocumentCompletion(ctx, conn, req, params)
+	case LSPMethodCodeAction:
+		if req.Params == nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams}
+		}
+		var params lsp.CodeActionParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return h.handleTextDocumentCodeAction(ctx, conn, req, params)
 	default:
 		if isFileSystemRequest(req.Method) {
 			_, _, err := h.handleFileSystemRequest(ctx, req)
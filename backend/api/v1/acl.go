@@ -52,6 +52,11 @@ func (in *ACLInterceptor) ACLInterceptor(ctx context.Context, request any, serve
 		ctx = context.WithValue(ctx, common.UserContextKey, user)
 	}
 
+	// The restricted personal access tokens are checked on the methods exempted from authentication too,
+	// otherwise the token could call such as CreateUser beyond its scope.
+	if err := in.checkPersonalAccessTokenScope(ctx, serverInfo.FullMethod, request); err != nil {
+		return nil, err
+	}
 	if auth.IsAuthenticationAllowed(serverInfo.FullMethod) {
 		return handler(ctx, request)
 	}
@@ -84,6 +89,9 @@ func (in *ACLInterceptor) ACLStreamInterceptor(request any, ss grpc.ServerStream
 		ss = overrideStream{ServerStream: ss, childCtx: ctx}
 	}
 
+	if err := in.checkPersonalAccessTokenScope(ctx, serverInfo.FullMethod, request); err != nil {
+		return err
+	}
 	if auth.IsAuthenticationAllowed(serverInfo.FullMethod) {
 		return handler(request, ss)
 	}
@@ -285,3 +293,53 @@ func hasPath(fieldMask *fieldmaskpb.FieldMask, want string) bool {
 	}
 	return false
 }
+
+// checkPersonalAccessTokenScope checks that the request is within the permissions and projects
+// that the personal access token is restricted to.
+func (in *ACLInterceptor) checkPersonalAccessTokenScope(ctx context.Context, fullMethod string, request any) error {
+	token, ok := ctx.Value(common.PersonalAccessTokenContextKey).(*store.PersonalAccessTokenMessage)
+	if !ok {
+		return nil
+	}
+	permissions, projects := token.Payload.GetPermissions(), token.Payload.GetProjects()
+	if len(permissions) == 0 && len(projects) == 0 {
+		return nil
+	}
+
+	if len(permissions) > 0 {
+		p, ok := methodPermissionMap[fullMethod]
+		if !ok {
+			return status.Errorf(codes.PermissionDenied, "method %q is not allowed for the restricted personal access token", fullMethod)
+		}
+		allowed := false
+		for _, permission := range permissions {
+			if iam.Permission(permission) == p {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return status.Errorf(codes.PermissionDenied, "personal access token does not have permission %q for method %q", p, fullMethod)
+		}
+	}
+
+	if len(projects) > 0 {
+		projectIDs, err := in.getProjectIDsForMethod(ctx, fullMethod, request)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to check personal access token, err %v", err)
+		}
+		if len(projectIDs) == 0 {
+			return status.Errorf(codes.PermissionDenied, "method %q is not allowed for the project-restricted personal access token", fullMethod)
+		}
+		allowedProjects := make(map[string]bool)
+		for _, project := range projects {
+			allowedProjects[project] = true
+		}
+		for _, projectID := range projectIDs {
+			if !allowedProjects[projectID] {
+				return status.Errorf(codes.PermissionDenied, "personal access token is not allowed to access project %q", projectID)
+			}
+		}
+	}
+	return nil
+}
@@ -7,27 +7,29 @@ import (
 )
 
 var ownerAndDBAMethods = map[string]bool{
-	v1pb.EnvironmentService_CreateEnvironment_FullMethodName:   true,
-	v1pb.EnvironmentService_UpdateEnvironment_FullMethodName:   true,
-	v1pb.EnvironmentService_DeleteEnvironment_FullMethodName:   true,
-	v1pb.EnvironmentService_UndeleteEnvironment_FullMethodName: true,
-	v1pb.EnvironmentService_UpdateBackupSetting_FullMethodName: true,
-	v1pb.InstanceService_CreateInstance_FullMethodName:         true,
-	v1pb.InstanceService_UpdateInstance_FullMethodName:         true,
-	v1pb.InstanceService_DeleteInstance_FullMethodName:         true,
-	v1pb.InstanceService_UndeleteInstance_FullMethodName:       true,
-	v1pb.InstanceService_AddDataSource_FullMethodName:          true,
-	v1pb.InstanceService_RemoveDataSource_FullMethodName:       true,
-	v1pb.InstanceService_UpdateDataSource_FullMethodName:       true,
-	v1pb.RiskService_CreateRisk_FullMethodName:                 true,
-	v1pb.RiskService_UpdateRisk_FullMethodName:                 true,
-	v1pb.RiskService_DeleteRisk_FullMethodName:                 true,
-	v1pb.SettingService_SetSetting_FullMethodName:              true,
-	v1pb.RoleService_CreateRole_FullMethodName:                 true,
-	v1pb.RoleService_UpdateRole_FullMethodName:                 true,
-	v1pb.RoleService_DeleteRole_FullMethodName:                 true,
-	v1pb.ActuatorService_UpdateActuatorInfo_FullMethodName:     true,
-	v1pb.ActuatorService_ListDebugLog_FullMethodName:           true,
+	v1pb.EnvironmentService_CreateEnvironment_FullMethodName:        true,
+	v1pb.EnvironmentService_UpdateEnvironment_FullMethodName:        true,
+	v1pb.EnvironmentService_DeleteEnvironment_FullMethodName:        true,
+	v1pb.EnvironmentService_UndeleteEnvironment_FullMethodName:      true,
+	v1pb.EnvironmentService_UpdateBackupSetting_FullMethodName:      true,
+	v1pb.InstanceService_CreateInstance_FullMethodName:              true,
+	v1pb.InstanceService_UpdateInstance_FullMethodName:              true,
+	v1pb.InstanceService_DeleteInstance_FullMethodName:              true,
+	v1pb.InstanceService_UndeleteInstance_FullMethodName:            true,
+	v1pb.InstanceService_AddDataSource_FullMethodName:               true,
+	v1pb.InstanceService_RemoveDataSource_FullMethodName:            true,
+	v1pb.InstanceService_UpdateDataSource_FullMethodName:            true,
+	v1pb.RiskService_CreateRisk_FullMethodName:                      true,
+	v1pb.RiskService_UpdateRisk_FullMethodName:                      true,
+	v1pb.RiskService_DeleteRisk_FullMethodName:                      true,
+	v1pb.SettingService_SetSetting_FullMethodName:                   true,
+	v1pb.RoleService_CreateRole_FullMethodName:                      true,
+	v1pb.RoleService_UpdateRole_FullMethodName:                      true,
+	v1pb.RoleService_DeleteRole_FullMethodName:                      true,
+	v1pb.ActuatorService_UpdateActuatorInfo_FullMethodName:          true,
+	v1pb.ActuatorService_ListDebugLog_FullMethodName:                true,
+	v1pb.WorkspaceConfigService_PlanWorkspaceConfig_FullMethodName:  true,
+	v1pb.WorkspaceConfigService_ApplyWorkspaceConfig_FullMethodName: true,
 }
 
 var projectOwnerMethods = map[string]bool{
@@ -65,6 +67,7 @@ var methodPermissionMap = map[string]iam.Permission{
 	v1pb.DatabaseService_GetDatabaseMetadata_FullMethodName:    iam.PermissionDatabasesGetMetadata,
 	v1pb.DatabaseService_UpdateDatabaseMetadata_FullMethodName: iam.PermissionDatabasesUpdateMetadata,
 	v1pb.DatabaseService_GetDatabaseSchema_FullMethodName:      iam.PermissionDatabasesGetSchema,
+	v1pb.DatabaseService_ExportDatabaseSchema_FullMethodName:   iam.PermissionDatabasesGetSchema,
 	v1pb.DatabaseService_DiffSchema_FullMethodName:             "", // handled in the method.
 	v1pb.DatabaseService_GetBackupSetting_FullMethodName:       iam.PermissionDatabasesGetBackupSetting,
 	v1pb.DatabaseService_UpdateBackupSetting_FullMethodName:    iam.PermissionDatabasesUpdateBackupSetting,
@@ -149,19 +152,24 @@ var methodPermissionMap = map[string]iam.Permission{
 	v1pb.InstanceRoleService_DeleteInstanceRole_FullMethodName:   iam.PermissionInstanceRolesDelete,
 	v1pb.InstanceRoleService_UndeleteInstanceRole_FullMethodName: iam.PermissionInstanceRolesUndelete,
 
-	v1pb.RolloutService_ListPlans_FullMethodName:           iam.PermissionPlansList,
-	v1pb.RolloutService_GetPlan_FullMethodName:             iam.PermissionPlansGet,
-	v1pb.RolloutService_CreatePlan_FullMethodName:          iam.PermissionPlansCreate,
-	v1pb.RolloutService_UpdatePlan_FullMethodName:          iam.PermissionPlansUpdate,
-	v1pb.RolloutService_GetRollout_FullMethodName:          iam.PermissionRolloutsGet,
-	v1pb.RolloutService_CreateRollout_FullMethodName:       iam.PermissionRolloutsCreate,
-	v1pb.RolloutService_PreviewRollout_FullMethodName:      iam.PermissionRolloutsPreview,
-	v1pb.RolloutService_ListTaskRuns_FullMethodName:        iam.PermissionTaskRunsList,
-	v1pb.RolloutService_ListPlanCheckRuns_FullMethodName:   iam.PermissionPlanCheckRunsList,
-	v1pb.RolloutService_RunPlanChecks_FullMethodName:       iam.PermissionPlanCheckRunsRun,
-	v1pb.RolloutService_BatchRunTasks_FullMethodName:       iam.PermissionTasksRun,
-	v1pb.RolloutService_BatchSkipTasks_FullMethodName:      iam.PermissionTasksSkip,
-	v1pb.RolloutService_BatchCancelTaskRuns_FullMethodName: iam.PermissionTaskRunsCancel,
+	v1pb.RolloutService_ListPlans_FullMethodName:               iam.PermissionPlansList,
+	v1pb.RolloutService_GetPlan_FullMethodName:                 iam.PermissionPlansGet,
+	v1pb.RolloutService_CreatePlan_FullMethodName:              iam.PermissionPlansCreate,
+	v1pb.RolloutService_UpdatePlan_FullMethodName:              iam.PermissionPlansUpdate,
+	v1pb.RolloutService_GetRollout_FullMethodName:              iam.PermissionRolloutsGet,
+	v1pb.RolloutService_CreateRollout_FullMethodName:           iam.PermissionRolloutsCreate,
+	v1pb.RolloutService_PreviewRollout_FullMethodName:          iam.PermissionRolloutsPreview,
+	v1pb.RolloutService_ListTaskRuns_FullMethodName:            iam.PermissionTaskRunsList,
+	v1pb.RolloutService_ListPlanCheckRuns_FullMethodName:       iam.PermissionPlanCheckRunsList,
+	v1pb.RolloutService_RunPlanChecks_FullMethodName:           iam.PermissionPlanCheckRunsRun,
+	v1pb.RolloutService_BatchRunTasks_FullMethodName:           iam.PermissionTasksRun,
+	v1pb.RolloutService_BatchSkipTasks_FullMethodName:          iam.PermissionTasksSkip,
+	v1pb.RolloutService_BatchCancelTaskRuns_FullMethodName:     iam.PermissionTaskRunsCancel,
+	v1pb.RolloutService_ControlGhostMigration_FullMethodName:   iam.PermissionTasksRun,
+	v1pb.RolloutService_ControlBatchDataUpdate_FullMethodName:  iam.PermissionTasksRun,
+	v1pb.RolloutService_TailTaskRunLogs_FullMethodName:         iam.PermissionTaskRunsList,
+	v1pb.RolloutService_RollbackTask_FullMethodName:            iam.PermissionTasksRun,
+	v1pb.RolloutService_OverridePostDeployCheck_FullMethodName: iam.PermissionTasksRun,
 }
 
 func isOwnerAndDBAMethod(methodName string) bool {
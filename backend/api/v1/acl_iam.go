@@ -25,6 +25,7 @@ func (in *ACLInterceptor) checkIAMPermission(ctx context.Context, fullMethod str
 	// handled in the method because checking is complex.
 	case
 		v1pb.DatabaseService_ListSlowQueries_FullMethodName,
+		v1pb.RolloutService_TailTaskRunLogs_FullMethodName,
 		v1pb.DatabaseService_ListDatabases_FullMethodName,     // TODO(p0ny): implement
 		v1pb.DatabaseService_DiffSchema_FullMethodName,        // TODO(p0ny): implement
 		v1pb.IssueService_ListIssues_FullMethodName,           // TODO(p0ny): implement
@@ -39,8 +40,40 @@ func (in *ACLInterceptor) checkIAMPermission(ctx context.Context, fullMethod str
 	if !ok {
 		return nil
 	}
-	var projectIDsGetter func(context.Context, any) ([]string, error)
+	projectIDsGetter, databasesGetter := in.getResourceGetters(fullMethod)
+	var databases []*store.DatabaseMessage
+	var projectIDs []string
+	var err error
+	if databasesGetter != nil {
+		databases, projectIDs, err = databasesGetter(ctx, req)
+	} else if projectIDsGetter != nil {
+		projectIDs, err = projectIDsGetter(ctx, req)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check permission, err %v", err)
+	}
+
+	if databasesGetter != nil {
+		ok, err = in.iamManager.CheckDatabasePermission(ctx, p, user, databases...)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to check permission for method %q, err: %v", fullMethod, err)
+		}
+	}
+	if databasesGetter == nil || (ok && len(projectIDs) > 0) {
+		ok, err = in.iamManager.CheckPermission(ctx, p, user, projectIDs...)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to check permission for method %q, err: %v", fullMethod, err)
+		}
+	}
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "permission denied for method %q, user does not have permission %q", fullMethod, p)
+	}
+
+	return nil
+}
 
+// getResourceGetters returns the getter of the projects or the databases that the request of the method touches.
+func (in *ACLInterceptor) getResourceGetters(fullMethod string) (func(context.Context, any) ([]string, error), func(context.Context, any) ([]*store.DatabaseMessage, []string, error)) {
 	switch fullMethod {
 	// below are "workspace-level" permissions.
 	// we don't have to go down to the project level.
@@ -82,9 +115,9 @@ func (in *ACLInterceptor) checkIAMPermission(ctx context.Context, fullMethod str
 		v1pb.RoleService_UpdateRole_FullMethodName,
 		v1pb.RoleService_DeleteRole_FullMethodName:
 
-		projectIDsGetter = func(context.Context, any) ([]string, error) {
+		return func(context.Context, any) ([]string, error) {
 			return nil, nil
-		}
+		}, nil
 	case
 		v1pb.DatabaseService_GetDatabase_FullMethodName,
 		v1pb.DatabaseService_UpdateDatabase_FullMethodName,
@@ -93,6 +126,7 @@ func (in *ACLInterceptor) checkIAMPermission(ctx context.Context, fullMethod str
 		v1pb.DatabaseService_GetDatabaseMetadata_FullMethodName,
 		v1pb.DatabaseService_UpdateDatabaseMetadata_FullMethodName,
 		v1pb.DatabaseService_GetDatabaseSchema_FullMethodName,
+		v1pb.DatabaseService_ExportDatabaseSchema_FullMethodName,
 		v1pb.DatabaseService_GetBackupSetting_FullMethodName,
 		v1pb.DatabaseService_UpdateBackupSetting_FullMethodName,
 		v1pb.DatabaseService_CreateBackup_FullMethodName,
@@ -104,7 +138,7 @@ func (in *ACLInterceptor) checkIAMPermission(ctx context.Context, fullMethod str
 		v1pb.DatabaseService_ListChangeHistories_FullMethodName,
 		v1pb.DatabaseService_GetChangeHistory_FullMethodName:
 
-		projectIDsGetter = in.getProjectIDsForDatabaseService
+		return nil, in.getDatabasesForDatabaseService
 	case
 		v1pb.IssueService_GetIssue_FullMethodName,
 		v1pb.IssueService_CreateIssue_FullMethodName,
@@ -113,14 +147,14 @@ func (in *ACLInterceptor) checkIAMPermission(ctx context.Context, fullMethod str
 		v1pb.IssueService_UpdateIssueComment_FullMethodName,
 		v1pb.IssueService_BatchUpdateIssuesStatus_FullMethodName:
 
-		projectIDsGetter = in.getProjectIDsForIssueService
+		return in.getProjectIDsForIssueService, nil
 	case
 		v1pb.ChangelistService_CreateChangelist_FullMethodName,
 		v1pb.ChangelistService_UpdateChangelist_FullMethodName,
 		v1pb.ChangelistService_GetChangelist_FullMethodName,
 		v1pb.ChangelistService_DeleteChangelist_FullMethodName:
 
-		projectIDsGetter = in.getProjectIDsForChangelistService
+		return in.getProjectIDsForChangelistService, nil
 	case
 		v1pb.RolloutService_GetRollout_FullMethodName,
 		v1pb.RolloutService_CreateRollout_FullMethodName,
@@ -133,9 +167,13 @@ func (in *ACLInterceptor) checkIAMPermission(ctx context.Context, fullMethod str
 		v1pb.RolloutService_RunPlanChecks_FullMethodName,
 		v1pb.RolloutService_BatchRunTasks_FullMethodName,
 		v1pb.RolloutService_BatchSkipTasks_FullMethodName,
-		v1pb.RolloutService_BatchCancelTaskRuns_FullMethodName:
+		v1pb.RolloutService_BatchCancelTaskRuns_FullMethodName,
+		v1pb.RolloutService_ControlGhostMigration_FullMethodName,
+		v1pb.RolloutService_ControlBatchDataUpdate_FullMethodName,
+		v1pb.RolloutService_RollbackTask_FullMethodName,
+		v1pb.RolloutService_OverridePostDeployCheck_FullMethodName:
 
-		projectIDsGetter = in.getProjectIDsForRolloutService
+		return in.getProjectIDsForRolloutService, nil
 	case
 		v1pb.ProjectService_GetProject_FullMethodName,
 		v1pb.ProjectService_UpdateProject_FullMethodName,
@@ -165,22 +203,28 @@ func (in *ACLInterceptor) checkIAMPermission(ctx context.Context, fullMethod str
 		v1pb.ProjectService_GetProjectProtectionRules_FullMethodName,
 		v1pb.ProjectService_UpdateProjectProtectionRules_FullMethodName:
 
-		projectIDsGetter = in.getProjectIDsForProjectService
+		return in.getProjectIDsForProjectService, nil
 	}
+	return nil, nil
+}
 
-	projectIDs, err := projectIDsGetter(ctx, req)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to check permission, err %v", err)
-	}
-	ok, err = in.iamManager.CheckPermission(ctx, p, user, projectIDs...)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to check permission for method %q, err: %v", fullMethod, err)
+// getProjectIDsForMethod returns the IDs of the projects that the request of the method touches.
+func (in *ACLInterceptor) getProjectIDsForMethod(ctx context.Context, fullMethod string, req any) ([]string, error) {
+	projectIDsGetter, databasesGetter := in.getResourceGetters(fullMethod)
+	if databasesGetter != nil {
+		databases, projectIDs, err := databasesGetter(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, database := range databases {
+			projectIDs = append(projectIDs, database.ProjectID)
+		}
+		return uniq(projectIDs), nil
 	}
-	if !ok {
-		return status.Errorf(codes.PermissionDenied, "permission denied for method %q, user does not have permission %q", fullMethod, p)
+	if projectIDsGetter != nil {
+		return projectIDsGetter(ctx, req)
 	}
-
-	return nil
+	return nil, nil
 }
 
 func getDatabaseMessage(ctx context.Context, s *store.Store, databaseResourceName string) (*store.DatabaseMessage, error) {
@@ -277,6 +321,14 @@ func (*ACLInterceptor) getProjectIDsForRolloutService(_ context.Context, req any
 		stages = append(stages, r.GetParent())
 	case *v1pb.BatchCancelTaskRunsRequest:
 		tasks = append(tasks, r.GetParent())
+	case *v1pb.ControlGhostMigrationRequest:
+		tasks = append(tasks, r.GetTask())
+	case *v1pb.ControlBatchDataUpdateRequest:
+		tasks = append(tasks, r.GetTask())
+	case *v1pb.RollbackTaskRequest:
+		tasks = append(tasks, r.GetTask())
+	case *v1pb.OverridePostDeployCheckRequest:
+		tasks = append(tasks, r.GetTask())
 	}
 
 	var projectIDs []string
@@ -459,7 +511,9 @@ func (*ACLInterceptor) getProjectIDsForIssueService(_ context.Context, req any)
 	return uniq(projectIDs), nil
 }
 
-func (in *ACLInterceptor) getProjectIDsForDatabaseService(ctx context.Context, req any) ([]string, error) {
+// getDatabasesForDatabaseService returns the databases in the request,
+// and the projects that databases are transferred to, if any.
+func (in *ACLInterceptor) getDatabasesForDatabaseService(ctx context.Context, req any) ([]*store.DatabaseMessage, []string, error) {
 	var projectIDs []string
 
 	var databaseNames []string
@@ -471,13 +525,13 @@ func (in *ACLInterceptor) getProjectIDsForDatabaseService(ctx context.Context, r
 	case *v1pb.GetDatabaseMetadataRequest:
 		databaseName, err := common.TrimSuffix(r.GetName(), "/metadata")
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
+			return nil, nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
 		}
 		databaseNames = append(databaseNames, databaseName)
 	case *v1pb.UpdateDatabaseMetadataRequest:
 		databaseName, err := common.TrimSuffix(r.GetDatabaseMetadata().GetName(), "/metadata")
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetDatabaseMetadata().GetName())
+			return nil, nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetDatabaseMetadata().GetName())
 		}
 		databaseNames = append(databaseNames, databaseName)
 	case *v1pb.UpdateDatabaseRequest:
@@ -485,7 +539,7 @@ func (in *ACLInterceptor) getProjectIDsForDatabaseService(ctx context.Context, r
 		if hasPath(r.GetUpdateMask(), "project") {
 			projectID, err := common.GetProjectID(r.GetDatabase().GetProject())
 			if err != nil {
-				return nil, errors.Wrapf(err, "failed to get projectID from %q", r.GetDatabase().GetProject())
+				return nil, nil, errors.Wrapf(err, "failed to get projectID from %q", r.GetDatabase().GetProject())
 			}
 			projectIDs = append(projectIDs, projectID)
 		}
@@ -495,7 +549,7 @@ func (in *ACLInterceptor) getProjectIDsForDatabaseService(ctx context.Context, r
 			if hasPath(request.GetUpdateMask(), "project") {
 				projectID, err := common.GetProjectID(request.GetDatabase().GetProject())
 				if err != nil {
-					return nil, errors.Wrapf(err, "failed to get projectID from %q", request.GetDatabase().GetProject())
+					return nil, nil, errors.Wrapf(err, "failed to get projectID from %q", request.GetDatabase().GetProject())
 				}
 				projectIDs = append(projectIDs, projectID)
 			}
@@ -503,19 +557,25 @@ func (in *ACLInterceptor) getProjectIDsForDatabaseService(ctx context.Context, r
 	case *v1pb.GetDatabaseSchemaRequest:
 		databaseName, err := common.TrimSuffix(r.GetName(), "/schema")
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
+			return nil, nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
+		}
+		databaseNames = append(databaseNames, databaseName)
+	case *v1pb.ExportDatabaseSchemaRequest:
+		databaseName, err := common.TrimSuffix(r.GetName(), "/schema")
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
 		}
 		databaseNames = append(databaseNames, databaseName)
 	case *v1pb.GetBackupSettingRequest:
 		databaseName, err := common.TrimSuffix(r.GetName(), "/backupSetting")
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
+			return nil, nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
 		}
 		databaseNames = append(databaseNames, databaseName)
 	case *v1pb.UpdateBackupSettingRequest:
 		databaseName, err := common.TrimSuffix(r.GetSetting().GetName(), "/backupSetting")
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetSetting().GetName())
+			return nil, nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetSetting().GetName())
 		}
 		databaseNames = append(databaseNames, databaseName)
 	case *v1pb.CreateBackupRequest:
@@ -527,13 +587,13 @@ func (in *ACLInterceptor) getProjectIDsForDatabaseService(ctx context.Context, r
 	case *v1pb.UpdateSecretRequest:
 		instance, database, _, err := common.GetInstanceDatabaseIDSecretName(r.GetSecret().GetName())
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetSecret().GetName())
+			return nil, nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetSecret().GetName())
 		}
 		databaseNames = append(databaseNames, common.FormatDatabase(instance, database))
 	case *v1pb.DeleteSecretRequest:
 		instance, database, _, err := common.GetInstanceDatabaseIDSecretName(r.GetName())
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
+			return nil, nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
 		}
 		databaseNames = append(databaseNames, common.FormatDatabase(instance, database))
 	case *v1pb.ListChangeHistoriesRequest:
@@ -541,20 +601,21 @@ func (in *ACLInterceptor) getProjectIDsForDatabaseService(ctx context.Context, r
 	case *v1pb.GetChangeHistoryRequest:
 		instance, database, _, err := common.GetInstanceDatabaseIDChangeHistory(r.GetName())
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
+			return nil, nil, errors.Wrapf(err, "failed to get databaseName from %q", r.GetName())
 		}
 		databaseNames = append(databaseNames, common.FormatDatabase(instance, database))
 	}
 
-	for _, databaseName := range databaseNames {
+	var databases []*store.DatabaseMessage
+	for _, databaseName := range uniq(databaseNames) {
 		database, err := getDatabaseMessage(ctx, in.store, databaseName)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get database %q", databaseName)
+			return nil, nil, errors.Wrapf(err, "failed to get database %q", databaseName)
 		}
-		projectIDs = append(projectIDs, database.ProjectID)
+		databases = append(databases, database)
 	}
 
-	return uniq(projectIDs), nil
+	return databases, uniq(projectIDs), nil
 }
 
 func uniq[T comparable](array []T) []T {
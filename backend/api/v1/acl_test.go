@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestCheckPersonalAccessTokenScope(t *testing.T) {
+	getChangelist := &v1pb.GetChangelistRequest{Name: "projects/p1/changelists/c1"}
+	getOtherChangelist := &v1pb.GetChangelistRequest{Name: "projects/p2/changelists/c1"}
+	createChangelist := &v1pb.CreateChangelistRequest{Parent: "projects/p1"}
+
+	tests := []struct {
+		description string
+		payload     *storepb.PersonalAccessTokenPayload
+		method      string
+		request     any
+		wantErr     string
+	}{
+		{
+			description: "unrestricted token",
+			payload:     nil,
+			method:      v1pb.ChangelistService_DeleteChangelist_FullMethodName,
+			request:     getOtherChangelist,
+		},
+		{
+			description: "permission in scope",
+			payload:     &storepb.PersonalAccessTokenPayload{Permissions: []string{"bb.changelists.get"}},
+			method:      v1pb.ChangelistService_GetChangelist_FullMethodName,
+			request:     getChangelist,
+		},
+		{
+			description: "permission not in scope",
+			payload:     &storepb.PersonalAccessTokenPayload{Permissions: []string{"bb.changelists.get"}},
+			method:      v1pb.ChangelistService_CreateChangelist_FullMethodName,
+			request:     createChangelist,
+			wantErr:     `personal access token does not have permission "bb.changelists.create"`,
+		},
+		{
+			description: "method without permission",
+			payload:     &storepb.PersonalAccessTokenPayload{Permissions: []string{"bb.changelists.get"}},
+			method:      v1pb.AuthService_GetUser_FullMethodName,
+			request:     &v1pb.GetUserRequest{Name: "users/1"},
+			wantErr:     "is not allowed for the restricted personal access token",
+		},
+		{
+			description: "project in scope",
+			payload:     &storepb.PersonalAccessTokenPayload{Projects: []string{"p1"}},
+			method:      v1pb.ChangelistService_CreateChangelist_FullMethodName,
+			request:     createChangelist,
+		},
+		{
+			description: "another project",
+			payload:     &storepb.PersonalAccessTokenPayload{Projects: []string{"p1"}},
+			method:      v1pb.ChangelistService_GetChangelist_FullMethodName,
+			request:     getOtherChangelist,
+			wantErr:     `personal access token is not allowed to access project "p2"`,
+		},
+		{
+			description: "method without project",
+			payload:     &storepb.PersonalAccessTokenPayload{Projects: []string{"p1"}},
+			method:      v1pb.AuthService_GetUser_FullMethodName,
+			request:     &v1pb.GetUserRequest{Name: "users/1"},
+			wantErr:     "is not allowed for the project-restricted personal access token",
+		},
+		{
+			description: "permission and project in scope",
+			payload:     &storepb.PersonalAccessTokenPayload{Permissions: []string{"bb.changelists.get"}, Projects: []string{"p1", "p2"}},
+			method:      v1pb.ChangelistService_GetChangelist_FullMethodName,
+			request:     getOtherChangelist,
+		},
+		{
+			description: "permission in scope but another project",
+			payload:     &storepb.PersonalAccessTokenPayload{Permissions: []string{"bb.changelists.get"}, Projects: []string{"p1"}},
+			method:      v1pb.ChangelistService_GetChangelist_FullMethodName,
+			request:     getOtherChangelist,
+			wantErr:     `personal access token is not allowed to access project "p2"`,
+		},
+	}
+
+	a := require.New(t)
+	in := &ACLInterceptor{}
+	// The requests authenticated by the other access tokens are not restricted.
+	a.NoError(in.checkPersonalAccessTokenScope(context.Background(), v1pb.ChangelistService_DeleteChangelist_FullMethodName, getOtherChangelist))
+	for _, test := range tests {
+		token := &store.PersonalAccessTokenMessage{PrincipalID: 101, Payload: test.payload}
+		ctx := context.WithValue(context.Background(), common.PersonalAccessTokenContextKey, token)
+		err := in.checkPersonalAccessTokenScope(ctx, test.method, test.request)
+		if test.wantErr == "" {
+			a.NoError(err, test.description)
+			continue
+		}
+		a.Equal(codes.PermissionDenied, status.Code(err), test.description)
+		a.Contains(status.Convert(err).Message(), test.wantErr, test.description)
+	}
+}
+
+func TestACLInterceptorRestrictedPersonalAccessTokenOnAllowlistedMethod(t *testing.T) {
+	a := require.New(t)
+	in := &ACLInterceptor{}
+	serverInfo := &grpc.UnaryServerInfo{FullMethod: v1pb.AuthService_CreateUser_FullMethodName}
+	request := &v1pb.CreateUserRequest{User: &v1pb.User{Email: "admin@example.com", UserRole: v1pb.UserRole_OWNER}}
+
+	for _, payload := range []*storepb.PersonalAccessTokenPayload{
+		{Permissions: []string{"bb.changelists.get"}},
+		{Projects: []string{"p1"}},
+	} {
+		called := false
+		handler := func(context.Context, any) (any, error) {
+			called = true
+			return nil, nil
+		}
+		token := &store.PersonalAccessTokenMessage{PrincipalID: 101, Payload: payload}
+		ctx := context.WithValue(context.Background(), common.PersonalAccessTokenContextKey, token)
+		_, err := in.ACLInterceptor(ctx, request, serverInfo, handler)
+		a.Equal(codes.PermissionDenied, status.Code(err), payload.String())
+		a.False(called, payload.String())
+	}
+
+	// The requests without restricted personal access tokens are still exempted from authentication.
+	called := false
+	_, err := in.ACLInterceptor(context.Background(), request, serverInfo, func(context.Context, any) (any, error) {
+		called = true
+		return nil, nil
+	})
+	a.NoError(err)
+	a.True(called)
+}
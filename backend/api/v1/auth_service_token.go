@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/api/auth"
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// ListPersonalAccessTokens lists the personal access tokens of a user.
+func (s *AuthService) ListPersonalAccessTokens(ctx context.Context, request *v1pb.ListPersonalAccessTokensRequest) (*v1pb.ListPersonalAccessTokensResponse, error) {
+	userID, err := common.GetUserID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := checkPersonalAccessTokenOwner(ctx, userID, true /* allowWorkspaceOwner */); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.store.ListPersonalAccessTokens(ctx, &store.FindPersonalAccessTokenMessage{PrincipalID: &userID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list personal access tokens, error: %v", err)
+	}
+	response := &v1pb.ListPersonalAccessTokensResponse{}
+	for _, token := range tokens {
+		response.PersonalAccessTokens = append(response.PersonalAccessTokens, convertToPersonalAccessToken(token))
+	}
+	return response, nil
+}
+
+// CreatePersonalAccessToken creates a personal access token for the user.
+func (s *AuthService) CreatePersonalAccessToken(ctx context.Context, request *v1pb.CreatePersonalAccessTokenRequest) (*v1pb.PersonalAccessToken, error) {
+	userID, err := common.GetUserID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := checkPersonalAccessTokenOwner(ctx, userID, false /* allowWorkspaceOwner */); err != nil {
+		return nil, err
+	}
+	// Tokens cannot be used to mint other tokens, otherwise the restrictions could be escaped.
+	if _, ok := ctx.Value(common.PersonalAccessTokenContextKey).(*store.PersonalAccessTokenMessage); ok {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot create personal access tokens with a personal access token")
+	}
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user, error: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user %d not found", userID)
+	}
+	if user.Type != api.EndUser {
+		return nil, status.Errorf(codes.InvalidArgument, "personal access tokens can be created for end users only")
+	}
+
+	create, err := s.convertToPersonalAccessTokenMessage(ctx, userID, request.PersonalAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	token, tokenHash, err := auth.GeneratePersonalAccessToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate personal access token, error: %v", err)
+	}
+	create.TokenHash = tokenHash
+	personalAccessToken, err := s.store.CreatePersonalAccessToken(ctx, create)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create personal access token, error: %v", err)
+	}
+
+	response := convertToPersonalAccessToken(personalAccessToken)
+	response.Token = token
+	return response, nil
+}
+
+// DeletePersonalAccessToken revokes a personal access token.
+func (s *AuthService) DeletePersonalAccessToken(ctx context.Context, request *v1pb.DeletePersonalAccessTokenRequest) (*emptypb.Empty, error) {
+	userID, tokenID, err := common.GetUserIDPersonalAccessTokenID(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := checkPersonalAccessTokenOwner(ctx, userID, true /* allowWorkspaceOwner */); err != nil {
+		return nil, err
+	}
+	token, err := s.store.GetPersonalAccessToken(ctx, &store.FindPersonalAccessTokenMessage{UID: &tokenID, PrincipalID: &userID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get personal access token, error: %v", err)
+	}
+	if token == nil {
+		return nil, status.Errorf(codes.NotFound, "personal access token %q not found", request.Name)
+	}
+	if err := s.store.DeletePersonalAccessToken(ctx, token.UID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete personal access token, error: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) convertToPersonalAccessTokenMessage(ctx context.Context, userID int, token *v1pb.PersonalAccessToken) (*store.PersonalAccessTokenMessage, error) {
+	if token == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "personal access token is required")
+	}
+	if token.Title == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "personal access token title is required")
+	}
+	existing, err := s.store.ListPersonalAccessTokens(ctx, &store.FindPersonalAccessTokenMessage{PrincipalID: &userID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list personal access tokens, error: %v", err)
+	}
+	for _, e := range existing {
+		if e.Name == token.Title {
+			return nil, status.Errorf(codes.AlreadyExists, "personal access token %q already exists", token.Title)
+		}
+	}
+
+	payload := &storepb.PersonalAccessTokenPayload{}
+	if err := validatePermissions(token.Permissions); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	payload.Permissions = token.Permissions
+	for _, name := range token.Projects {
+		projectID, err := common.GetProjectID(name)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &projectID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get project %q, error: %v", projectID, err)
+		}
+		if project == nil || project.Deleted {
+			return nil, status.Errorf(codes.NotFound, "project %q not found", name)
+		}
+		payload.Projects = append(payload.Projects, projectID)
+	}
+
+	create := &store.PersonalAccessTokenMessage{
+		PrincipalID: userID,
+		Name:        token.Title,
+		Payload:     payload,
+	}
+	if token.ExpireTime != nil {
+		if err := token.ExpireTime.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid expire time, error: %v", err)
+		}
+		expireTime := token.ExpireTime.AsTime()
+		if expireTime.Before(time.Now()) {
+			return nil, status.Errorf(codes.InvalidArgument, "expire time must be in the future")
+		}
+		create.ExpireTime = &expireTime
+	}
+	return create, nil
+}
+
+// checkPersonalAccessTokenOwner checks that the caller is the user, or the workspace owner if allowWorkspaceOwner is true.
+func checkPersonalAccessTokenOwner(ctx context.Context, userID int, allowWorkspaceOwner bool) error {
+	principalID, ok := ctx.Value(common.PrincipalIDContextKey).(int)
+	if !ok {
+		return status.Errorf(codes.Internal, "principal ID not found")
+	}
+	if principalID == userID {
+		return nil
+	}
+	role, ok := ctx.Value(common.RoleContextKey).(api.Role)
+	if !ok {
+		return status.Errorf(codes.Internal, "role not found")
+	}
+	if allowWorkspaceOwner && role == api.Owner {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "cannot access the personal access tokens of user %d", userID)
+}
+
+func convertToPersonalAccessToken(token *store.PersonalAccessTokenMessage) *v1pb.PersonalAccessToken {
+	personalAccessToken := &v1pb.PersonalAccessToken{
+		Name:        fmt.Sprintf("%s%d/%s%d", common.UserNamePrefix, token.PrincipalID, common.PersonalAccessTokenPrefix, token.UID),
+		Title:       token.Name,
+		Permissions: token.Payload.GetPermissions(),
+		CreateTime:  timestamppb.New(token.CreatedTime),
+	}
+	for _, projectID := range token.Payload.GetProjects() {
+		personalAccessToken.Projects = append(personalAccessToken.Projects, fmt.Sprintf("%s%s", common.ProjectNamePrefix, projectID))
+	}
+	if token.ExpireTime != nil {
+		personalAccessToken.ExpireTime = timestamppb.New(*token.ExpireTime)
+	}
+	if token.LastUsedTime != nil {
+		personalAccessToken.LastUsedTime = timestamppb.New(*token.LastUsedTime)
+	}
+	return personalAccessToken
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/api/auth"
+	"github.com/bytebase/bytebase/backend/common/log"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// ExchangeWorkloadIdentityToken exchanges an OIDC ID token issued by a trusted workload identity provider
+// for a short-lived access token of the service account mapped by the provider.
+func (s *AuthService) ExchangeWorkloadIdentityToken(ctx context.Context, request *v1pb.ExchangeWorkloadIdentityTokenRequest) (*v1pb.ExchangeWorkloadIdentityTokenResponse, error) {
+	if request.Token == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "token is required")
+	}
+	setting, err := s.store.GetWorkloadIdentitySetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workload identity setting, error: %v", err)
+	}
+	var provider *storepb.WorkloadIdentitySetting_Provider
+	for _, p := range setting.Providers {
+		if p.Id == request.Provider {
+			provider = p
+			break
+		}
+	}
+	if provider == nil {
+		return nil, status.Errorf(codes.NotFound, "workload identity provider %q not found", request.Provider)
+	}
+
+	claims, err := auth.VerifyWorkloadIdentityToken(ctx, provider, request.Token)
+	if err != nil {
+		slog.Warn("failed to verify workload identity token", slog.String("provider", provider.Id), log.BBError(err))
+		return nil, status.Errorf(codes.Unauthenticated, "invalid workload identity token: %v", err)
+	}
+
+	user, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: &provider.ServiceAccount})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user %q, error: %v", provider.ServiceAccount, err)
+	}
+	if user == nil || user.Type != api.ServiceAccount {
+		return nil, status.Errorf(codes.FailedPrecondition, "service account %q of workload identity provider %q not found", provider.ServiceAccount, provider.Id)
+	}
+	if user.MemberDeleted {
+		return nil, status.Errorf(codes.Unauthenticated, "user has been deactivated by administrators")
+	}
+
+	tokenDuration := auth.DefaultWorkloadIdentityTokenDuration
+	if provider.TokenDuration != nil && provider.TokenDuration.AsDuration() > 0 {
+		tokenDuration = provider.TokenDuration.AsDuration()
+	}
+	expireTime := time.Now().Add(tokenDuration)
+	accessToken, err := auth.GenerateAccessToken(user.Name, user.ID, s.profile.Mode, s.secret, tokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate access token")
+	}
+	slog.Info("exchanged workload identity token",
+		slog.String("provider", provider.Id),
+		slog.Any("subject", claims["sub"]),
+		slog.String("serviceAccount", user.Email),
+	)
+
+	return &v1pb.ExchangeWorkloadIdentityTokenResponse{
+		AccessToken: accessToken,
+		ExpireTime:  timestamppb.New(expireTime),
+	}, nil
+}
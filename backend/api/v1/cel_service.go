@@ -9,6 +9,7 @@ import (
 	"github.com/google/cel-go/cel"
 
 	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
@@ -56,3 +57,23 @@ func (*CelService) BatchDeparse(_ context.Context, request *v1pb.BatchDeparseReq
 	}
 	return resp, nil
 }
+
+// BatchValidate validates CEL expressions in the given environment.
+func (*CelService) BatchValidate(_ context.Context, request *v1pb.BatchValidateRequest) (*v1pb.BatchValidateResponse, error) {
+	resp := &v1pb.BatchValidateResponse{}
+	for _, expression := range request.Expressions {
+		var err error
+		switch request.Environment {
+		case v1pb.BatchValidateRequest_SQL_REVIEW_CUSTOM_RULE:
+			_, err = advisor.CompileCustomRuleExpression(expression)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported environment %v", request.Environment)
+		}
+		if err != nil {
+			resp.Errors = append(resp.Errors, err.Error())
+		} else {
+			resp.Errors = append(resp.Errors, "")
+		}
+	}
+	return resp, nil
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 	pgrawparser "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/schematree"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/transform"
 	"github.com/bytebase/bytebase/backend/runner/backuprun"
 	"github.com/bytebase/bytebase/backend/runner/schemasync"
@@ -646,16 +647,61 @@ func (s *DatabaseService) UpdateDatabaseMetadata(ctx context.Context, request *v
 
 // GetDatabaseSchema gets the schema of a database.
 func (s *DatabaseService) GetDatabaseSchema(ctx context.Context, request *v1pb.GetDatabaseSchemaRequest) (*v1pb.DatabaseSchema, error) {
-	instanceID, databaseName, err := common.TrimSuffixAndGetInstanceDatabaseID(request.Name, common.SchemaSuffix)
+	instance, dbSchema, err := s.getDBSchema(ctx, request.Name)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, err
+	}
+	// We only support MySQL engine for now.
+	schema := string(dbSchema.GetSchema())
+	if request.SdlFormat {
+		switch instance.Engine {
+		case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
+			sdlSchema, err := transform.SchemaTransform(storepb.Engine_MYSQL, schema)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to convert schema to sdl format, error %v", err.Error())
+			}
+			schema = sdlSchema
+		}
+	}
+	return &v1pb.DatabaseSchema{Schema: schema}, nil
+}
+
+// ExportDatabaseSchema exports the schema of a database into the files of the schema objects.
+func (s *DatabaseService) ExportDatabaseSchema(ctx context.Context, request *v1pb.ExportDatabaseSchemaRequest) (*v1pb.ExportDatabaseSchemaResponse, error) {
+	instance, dbSchema, err := s.getDBSchema(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	engine := instance.Engine
+	if engine == storepb.Engine_RISINGWAVE {
+		engine = storepb.Engine_POSTGRES
+	}
+	files, err := schematree.Export(engine, string(dbSchema.GetSchema()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to export schema, error: %v", err)
+	}
+	response := &v1pb.ExportDatabaseSchemaResponse{}
+	for _, file := range files {
+		response.Files = append(response.Files, &v1pb.SchemaFile{
+			Path:    file.Path,
+			Content: file.Content,
+		})
+	}
+	return response, nil
+}
+
+// getDBSchema gets the synced schema of the database by the schema name, the database is synced if it hasn't been synced yet.
+func (s *DatabaseService) getDBSchema(ctx context.Context, name string) (*store.InstanceMessage, *model.DBSchema, error) {
+	instanceID, databaseName, err := common.TrimSuffixAndGetInstanceDatabaseID(name, common.SchemaSuffix)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 	instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{ResourceID: &instanceID})
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get instance %s", instanceID)
+		return nil, nil, errors.Wrapf(err, "failed to get instance %s", instanceID)
 	}
 	if instance == nil {
-		return nil, status.Errorf(codes.NotFound, "instance %q not found", instanceID)
+		return nil, nil, status.Errorf(codes.NotFound, "instance %q not found", instanceID)
 	}
 	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{
 		InstanceID:          &instanceID,
@@ -663,44 +709,32 @@ func (s *DatabaseService) GetDatabaseSchema(ctx context.Context, request *v1pb.G
 		IgnoreCaseSensitive: store.IgnoreDatabaseAndTableCaseSensitive(instance),
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, nil, status.Errorf(codes.Internal, err.Error())
 	}
 	if database == nil {
-		return nil, status.Errorf(codes.NotFound, "database %q not found", databaseName)
+		return nil, nil, status.Errorf(codes.NotFound, "database %q not found", databaseName)
 	}
 	if err := s.checkDatabasePermission(ctx, database.ProjectID, api.ProjectPermissionManageGeneral); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	dbSchema, err := s.store.GetDBSchema(ctx, database.UID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, nil, status.Errorf(codes.Internal, err.Error())
 	}
 	if dbSchema == nil {
 		if err := s.schemaSyncer.SyncDatabaseSchema(ctx, database, true /* force */); err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to sync database schema for database %q, error %v", databaseName, err)
+			return nil, nil, status.Errorf(codes.Internal, "failed to sync database schema for database %q, error %v", databaseName, err)
 		}
 		newDBSchema, err := s.store.GetDBSchema(ctx, database.UID)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, err.Error())
+			return nil, nil, status.Errorf(codes.Internal, err.Error())
 		}
 		if newDBSchema == nil {
-			return nil, status.Errorf(codes.NotFound, "database schema %q not found", databaseName)
+			return nil, nil, status.Errorf(codes.NotFound, "database schema %q not found", databaseName)
 		}
 		dbSchema = newDBSchema
 	}
-	// We only support MySQL engine for now.
-	schema := string(dbSchema.GetSchema())
-	if request.SdlFormat {
-		switch instance.Engine {
-		case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
-			sdlSchema, err := transform.SchemaTransform(storepb.Engine_MYSQL, schema)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to convert schema to sdl format, error %v", err.Error())
-			}
-			schema = sdlSchema
-		}
-	}
-	return &v1pb.DatabaseSchema{Schema: schema}, nil
+	return instance, dbSchema, nil
 }
 
 // GetBackupSetting gets the backup setting of a database.
@@ -233,6 +233,8 @@ func (s *IssueService) ListIssues(ctx context.Context, request *v1pb.ListIssuesR
 					api.TaskDatabaseSchemaUpdateSDL,
 					api.TaskDatabaseSchemaUpdateGhostSync,
 					api.TaskDatabaseSchemaUpdateGhostCutover,
+					api.TaskDatabaseSchemaUpdatePGOSCSync,
+					api.TaskDatabaseSchemaUpdatePGOSCCutover,
 				}
 			case "DML":
 				issueFind.TaskTypes = &[]api.TaskType{
@@ -1354,6 +1356,13 @@ func (s *IssueService) BatchUpdateIssuesStatus(ctx context.Context, request *v1p
 	if err := s.store.BatchUpdateIssueStatuses(ctx, issueIDs, newStatus, principalID); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to batch update issues, err: %v", err)
 	}
+	if newStatus != api.IssueOpen {
+		for _, issue := range issues {
+			if issue.PipelineUID != nil {
+				s.stateCfg.PGOSCCleanupChan <- *issue.PipelineUID
+			}
+		}
+	}
 
 	if err := func() error {
 		var errs error
@@ -17,6 +17,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/deploymentwindow"
 	enterprise "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
@@ -673,6 +674,26 @@ func (s *OrgPolicyService) convertPolicyPayloadToString(policy *v1pb.Policy) (st
 			return "", status.Errorf(codes.InvalidArgument, err.Error())
 		}
 		return payload.String()
+	case v1pb.PolicyType_DEPLOYMENT_WINDOW:
+		deploymentWindowPolicy := convertToStorePBDeploymentWindowPolicy(policy.GetDeploymentWindowPolicy())
+		if err := deploymentwindow.Validate(deploymentWindowPolicy); err != nil {
+			return "", status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		payloadBytes, err := protojson.Marshal(deploymentWindowPolicy)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal deployment window policy")
+		}
+		return string(payloadBytes), nil
+	case v1pb.PolicyType_PRODUCTION_SAFETY:
+		productionSafetyPolicy := convertToStorePBProductionSafetyPolicy(policy.GetProductionSafetyPolicy())
+		if err := validateProductionSafetyPolicy(productionSafetyPolicy); err != nil {
+			return "", status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		payloadBytes, err := protojson.Marshal(productionSafetyPolicy)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal production safety policy")
+		}
+		return string(payloadBytes), nil
 	}
 
 	return "", status.Errorf(codes.InvalidArgument, "invalid policy %v", policy.Type)
@@ -796,6 +817,20 @@ func convertToPolicy(parentPath string, policyMessage *store.PolicyMessage) (*v1
 			return nil, err
 		}
 		policy.Policy = payload
+	case api.PolicyTypeDeploymentWindow:
+		pType = v1pb.PolicyType_DEPLOYMENT_WINDOW
+		payload, err := convertToV1DeploymentWindowPolicyPayload(policyMessage.Payload)
+		if err != nil {
+			return nil, err
+		}
+		policy.Policy = payload
+	case api.PolicyTypeProductionSafety:
+		pType = v1pb.PolicyType_PRODUCTION_SAFETY
+		payload, err := convertToV1ProductionSafetyPolicyPayload(policyMessage.Payload)
+		if err != nil {
+			return nil, err
+		}
+		policy.Policy = payload
 	}
 
 	policy.Type = pType
@@ -1124,6 +1159,88 @@ func convertToStorePBRolloutPolicy(policy *v1pb.RolloutPolicy) *storepb.RolloutP
 	}
 }
 
+func convertToV1DeploymentWindowPolicyPayload(payloadStr string) (*v1pb.Policy_DeploymentWindowPolicy, error) {
+	p := &v1pb.DeploymentWindowPolicy{}
+	if err := protojson.Unmarshal([]byte(payloadStr), p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal deployment window policy payload")
+	}
+	return &v1pb.Policy_DeploymentWindowPolicy{
+		DeploymentWindowPolicy: p,
+	}, nil
+}
+
+func convertToStorePBDeploymentWindowPolicy(policy *v1pb.DeploymentWindowPolicy) *storepb.DeploymentWindowPolicy {
+	p := &storepb.DeploymentWindowPolicy{
+		TimeZone: policy.GetTimeZone(),
+	}
+	for _, window := range policy.GetMaintenanceWindows() {
+		p.MaintenanceWindows = append(p.MaintenanceWindows, &storepb.DeploymentWindowPolicy_MaintenanceWindow{
+			Title:    window.Title,
+			Cron:     window.Cron,
+			Duration: window.Duration,
+		})
+	}
+	for _, window := range policy.GetFreezeWindows() {
+		p.FreezeWindows = append(p.FreezeWindows, &storepb.DeploymentWindowPolicy_FreezeWindow{
+			Title:     window.Title,
+			StartTime: window.StartTime,
+			EndTime:   window.EndTime,
+		})
+	}
+	return p
+}
+
+func convertToV1ProductionSafetyPolicyPayload(payloadStr string) (*v1pb.Policy_ProductionSafetyPolicy, error) {
+	p := &v1pb.ProductionSafetyPolicy{}
+	if err := protojson.Unmarshal([]byte(payloadStr), p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal production safety policy payload")
+	}
+	return &v1pb.Policy_ProductionSafetyPolicy{
+		ProductionSafetyPolicy: p,
+	}, nil
+}
+
+func convertToStorePBProductionSafetyPolicy(policy *v1pb.ProductionSafetyPolicy) *storepb.ProductionSafetyPolicy {
+	return &storepb.ProductionSafetyPolicy{
+		LockCheckLevel:           convertToStorePBProductionSafetyLevel(policy.GetLockCheckLevel()),
+		LongTransactionThreshold: policy.GetLongTransactionThreshold(),
+		ReplicaLagCheckLevel:     convertToStorePBProductionSafetyLevel(policy.GetReplicaLagCheckLevel()),
+		MaxReplicaLag:            policy.GetMaxReplicaLag(),
+		DiskSpaceCheckLevel:      convertToStorePBProductionSafetyLevel(policy.GetDiskSpaceCheckLevel()),
+		MaxRewriteSize:           policy.GetMaxRewriteSize(),
+	}
+}
+
+func convertToStorePBProductionSafetyLevel(level v1pb.ProductionSafetyPolicy_Level) storepb.ProductionSafetyPolicy_Level {
+	switch level {
+	case v1pb.ProductionSafetyPolicy_WARNING:
+		return storepb.ProductionSafetyPolicy_WARNING
+	case v1pb.ProductionSafetyPolicy_ERROR:
+		return storepb.ProductionSafetyPolicy_ERROR
+	case v1pb.ProductionSafetyPolicy_DISABLED:
+		return storepb.ProductionSafetyPolicy_DISABLED
+	default:
+		return storepb.ProductionSafetyPolicy_LEVEL_UNSPECIFIED
+	}
+}
+
+func validateProductionSafetyPolicy(policy *storepb.ProductionSafetyPolicy) error {
+	if policy.LongTransactionThreshold != nil && policy.LongTransactionThreshold.AsDuration() < 0 {
+		return errors.Errorf("long transaction threshold must not be negative")
+	}
+	if policy.MaxReplicaLag != nil && policy.MaxReplicaLag.AsDuration() < 0 {
+		return errors.Errorf("max replica lag must not be negative")
+	}
+	if policy.MaxRewriteSize < 0 {
+		return errors.Errorf("max rewrite size must not be negative")
+	}
+	// Only MariaDB reports the free disk space, so the disk space check needs the max rewrite size for the other databases.
+	if policy.DiskSpaceCheckLevel != storepb.ProductionSafetyPolicy_DISABLED && policy.MaxRewriteSize == 0 {
+		return errors.Errorf("max rewrite size is required if the disk space check is enabled")
+	}
+	return nil
+}
+
 func convertToPipelineApprovalPolicyPayload(policy *v1pb.DeploymentApprovalPolicy) (*api.PipelineApprovalPolicy, error) {
 	var strategy api.PipelineApprovalValue
 	switch policy.DefaultStrategy {
@@ -1386,6 +1503,10 @@ func convertPolicyType(pType string) (api.PolicyType, error) {
 		return api.PolicyTypeDisableCopyData, nil
 	case v1pb.PolicyType_RESTRICT_ISSUE_CREATION_FOR_SQL_REVIEW.String():
 		return api.PolicyTypeRestrictIssueCreationForSQLReview, nil
+	case v1pb.PolicyType_DEPLOYMENT_WINDOW.String():
+		return api.PolicyTypeDeploymentWindow, nil
+	case v1pb.PolicyType_PRODUCTION_SAFETY.String():
+		return api.PolicyTypeProductionSafety, nil
 	}
 	return policyType, errors.Errorf("invalid policy type %v", pType)
 }
@@ -1428,6 +1549,10 @@ func validateSQLReviewPolicy(policy *v1pb.SQLReviewPolicy) error {
 			if _, err := advisor.UnmarshalNamingCaseRulePayload(rule.Payload); err != nil {
 				return err
 			}
+		case advisor.SchemaRuleCustom:
+			if _, err := advisor.UnmarshalCustomRulePayload(rule.Payload); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
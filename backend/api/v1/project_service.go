@@ -22,6 +22,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
+	"github.com/bytebase/bytebase/backend/component/rolloutstrategy"
 	enterprise "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	vcsplugin "github.com/bytebase/bytebase/backend/plugin/vcs"
@@ -2568,6 +2569,9 @@ func validateAndConvertToStoreDeploymentSchedule(deployment *v1pb.DeploymentConf
 		if !hasEnv {
 			return nil, common.Errorf(common.Invalid, "deployment should contain %q label", api.EnvironmentLabelKey)
 		}
+		if err := rolloutstrategy.Validate(convertToStoreRolloutStrategy(d.Spec.RolloutStrategy)); err != nil {
+			return nil, common.Errorf(common.Invalid, "deployment %q has invalid rollout strategy: %v", d.Title, err)
+		}
 	}
 	return convertToStoreDeploymentConfig(deployment)
 }
@@ -2879,7 +2883,8 @@ func convertToStoreDeployment(deployment *v1pb.ScheduleDeployment) (*store.Deplo
 
 func convertToSpec(spec *store.DeploymentSpec) *v1pb.DeploymentSpec {
 	return &v1pb.DeploymentSpec{
-		LabelSelector: convertToLabelSelector(spec.Selector),
+		LabelSelector:   convertToLabelSelector(spec.Selector),
+		RolloutStrategy: convertToRolloutStrategy(spec.Rollout),
 	}
 }
 
@@ -2890,9 +2895,38 @@ func convertToStoreSpec(spec *v1pb.DeploymentSpec) (*store.DeploymentSpec, error
 	}
 	return &store.DeploymentSpec{
 		Selector: selector,
+		Rollout:  convertToStoreRolloutStrategy(spec.RolloutStrategy),
 	}, nil
 }
 
+func convertToRolloutStrategy(strategy *storepb.RolloutStrategy) *v1pb.RolloutStrategy {
+	if strategy == nil {
+		return nil
+	}
+	return &v1pb.RolloutStrategy{
+		CanaryCount:           strategy.CanaryCount,
+		CanaryPercent:         strategy.CanaryPercent,
+		WavePercents:          strategy.WavePercents,
+		MaxConcurrency:        strategy.MaxConcurrency,
+		MaxFailurePercent:     strategy.MaxFailurePercent,
+		VerificationStatement: strategy.VerificationStatement,
+	}
+}
+
+func convertToStoreRolloutStrategy(strategy *v1pb.RolloutStrategy) *storepb.RolloutStrategy {
+	if strategy == nil {
+		return nil
+	}
+	return &storepb.RolloutStrategy{
+		CanaryCount:           strategy.CanaryCount,
+		CanaryPercent:         strategy.CanaryPercent,
+		WavePercents:          strategy.WavePercents,
+		MaxConcurrency:        strategy.MaxConcurrency,
+		MaxFailurePercent:     strategy.MaxFailurePercent,
+		VerificationStatement: strategy.VerificationStatement,
+	}
+}
+
 func convertToLabelSelector(selector *store.LabelSelector) *v1pb.LabelSelector {
 	var exprs []*v1pb.LabelSelectorRequirement
 	for _, expr := range selector.MatchExpressions {
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/iam"
 	enterprise "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
@@ -19,13 +21,15 @@ import (
 type RoleService struct {
 	v1pb.UnimplementedRoleServiceServer
 	store          *store.Store
+	iamManager     *iam.Manager
 	licenseService enterprise.LicenseService
 }
 
 // NewRoleService returns a new instance of the role service.
-func NewRoleService(store *store.Store, licenseService enterprise.LicenseService) *RoleService {
+func NewRoleService(store *store.Store, iamManager *iam.Manager, licenseService enterprise.LicenseService) *RoleService {
 	return &RoleService{
 		store:          store,
+		iamManager:     iamManager,
 		licenseService: licenseService,
 	}
 }
@@ -37,8 +41,16 @@ func (s *RoleService) ListRoles(ctx context.Context, _ *v1pb.ListRolesRequest) (
 		return nil, status.Errorf(codes.Internal, "failed to list roles: %v", err)
 	}
 
+	roles := convertToRoles(roleMessages)
+	for i, roleMessage := range roleMessages {
+		if s.iamManager.IsPredefinedRole(roleMessage.ResourceID) {
+			for _, permission := range s.iamManager.GetPermissions(roleMessage.ResourceID) {
+				roles[i].Permissions = append(roles[i].Permissions, string(permission))
+			}
+		}
+	}
 	return &v1pb.ListRolesResponse{
-		Roles: convertToRoles(roleMessages),
+		Roles: roles,
 	}, nil
 }
 
@@ -51,15 +63,25 @@ func (s *RoleService) CreateRole(ctx context.Context, request *v1pb.CreateRoleRe
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "principal ID not found")
 	}
+	if s.iamManager.IsPredefinedRole(request.RoleId) {
+		return nil, status.Errorf(codes.AlreadyExists, "role %s is a built-in role", request.RoleId)
+	}
+	if err := validatePermissions(request.Role.Permissions); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
 	create := &store.RoleMessage{
 		ResourceID:  request.RoleId,
 		Name:        request.Role.Title,
 		Description: request.Role.Description,
+		Permissions: request.Role.Permissions,
 	}
 	roleMessage, err := s.store.CreateRole(ctx, create, principalID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create role: %v", err)
 	}
+	if err := s.iamManager.ReloadCache(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reload roles: %v", err)
+	}
 	return convertToRole(roleMessage), nil
 }
 
@@ -79,13 +101,6 @@ func (s *RoleService) UpdateRole(ctx context.Context, request *v1pb.UpdateRoleRe
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
-	role, err := s.store.GetRole(ctx, roleID)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get role: %v", err)
-	}
-	if role == nil {
-		return nil, status.Errorf(codes.NotFound, "role not found: %s", roleID)
-	}
 	patch := &store.UpdateRoleMessage{
 		UpdaterID:  principalID,
 		ResourceID: roleID,
@@ -96,15 +111,35 @@ func (s *RoleService) UpdateRole(ctx context.Context, request *v1pb.UpdateRoleRe
 			patch.Name = &request.Role.Title
 		case "description":
 			patch.Description = &request.Role.Description
+		case "permissions":
+			// The built-in roles always keep their permissions in acl.yaml.
+			if s.iamManager.IsPredefinedRole(roleID) {
+				return nil, status.Errorf(codes.InvalidArgument, "cannot update the permissions of the built-in role %s", roleID)
+			}
+			if err := validatePermissions(request.Role.Permissions); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			patch.Permissions = &request.Role.Permissions
 		default:
 			return nil, status.Errorf(codes.InvalidArgument, "invalid update mask path: %s", path)
 		}
 	}
 
+	role, err := s.store.GetRole(ctx, roleID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get role: %v", err)
+	}
+	if role == nil {
+		return nil, status.Errorf(codes.NotFound, "role not found: %s", roleID)
+	}
+
 	roleMessage, err := s.store.UpdateRole(ctx, patch)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update role: %v", err)
 	}
+	if err := s.iamManager.ReloadCache(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reload roles: %v", err)
+	}
 	return convertToRole(roleMessage), nil
 }
 
@@ -131,9 +166,26 @@ func (s *RoleService) DeleteRole(ctx context.Context, request *v1pb.DeleteRoleRe
 	if err := s.store.DeleteRole(ctx, roleID); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete role: %v", err)
 	}
+	if err := s.iamManager.ReloadCache(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reload roles: %v", err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
+func validatePermissions(permissions []string) error {
+	seen := make(map[string]bool)
+	for _, permission := range permissions {
+		if !iam.PermissionExist(iam.Permission(permission)) {
+			return errors.Errorf("invalid permission %q", permission)
+		}
+		if seen[permission] {
+			return errors.Errorf("duplicate permission %q", permission)
+		}
+		seen[permission] = true
+	}
+	return nil
+}
+
 func convertToRoles(roleMessages []*store.RoleMessage) []*v1pb.Role {
 	var roles []*v1pb.Role
 	for _, roleMessage := range roleMessages {
@@ -147,6 +199,7 @@ func convertToRole(role *store.RoleMessage) *v1pb.Role {
 		Name:        convertToRoleName(role.ResourceID),
 		Title:       role.Name,
 		Description: role.Description,
+		Permissions: role.Permissions,
 	}
 }
 
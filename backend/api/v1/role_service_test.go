@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/iam"
+	enterprise "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+type fakeLicenseService struct {
+	enterprise.LicenseService
+}
+
+func (fakeLicenseService) IsFeatureEnabled(api.FeatureType) error {
+	return nil
+}
+
+func TestUpdatePredefinedRolePermissions(t *testing.T) {
+	a := require.New(t)
+	iamManager, err := iam.NewManager(nil)
+	a.NoError(err)
+	s := NewRoleService(nil, iamManager, fakeLicenseService{})
+
+	ctx := context.WithValue(context.Background(), common.PrincipalIDContextKey, 101)
+	for _, role := range []string{"roles/OWNER", "roles/projectOwner", "roles/projectDeveloper", "roles/workspaceAdmin"} {
+		_, err := s.UpdateRole(ctx, &v1pb.UpdateRoleRequest{
+			Role: &v1pb.Role{
+				Name:        role,
+				Permissions: []string{"bb.projects.get"},
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"permissions"}},
+		})
+		a.ErrorContains(err, "cannot update the permissions of the built-in role", role)
+		a.Equal(codes.InvalidArgument, status.Code(err), role)
+	}
+}
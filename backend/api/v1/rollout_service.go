@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,13 +14,18 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
+	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/ghost"
+	"github.com/bytebase/bytebase/backend/component/iam"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterprise "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
@@ -39,10 +45,12 @@ type RolloutService struct {
 	planCheckScheduler *plancheck.Scheduler
 	stateCfg           *state.State
 	activityManager    *activity.Manager
+	profile            *config.Profile
+	iamManager         *iam.Manager
 }
 
 // NewRolloutService returns a rollout service instance.
-func NewRolloutService(store *store.Store, licenseService enterprise.LicenseService, dbFactory *dbfactory.DBFactory, planCheckScheduler *plancheck.Scheduler, stateCfg *state.State, activityManager *activity.Manager) *RolloutService {
+func NewRolloutService(store *store.Store, licenseService enterprise.LicenseService, dbFactory *dbfactory.DBFactory, planCheckScheduler *plancheck.Scheduler, stateCfg *state.State, activityManager *activity.Manager, profile *config.Profile, iamManager *iam.Manager) *RolloutService {
 	return &RolloutService{
 		store:              store,
 		licenseService:     licenseService,
@@ -50,6 +58,8 @@ func NewRolloutService(store *store.Store, licenseService enterprise.LicenseServ
 		planCheckScheduler: planCheckScheduler,
 		stateCfg:           stateCfg,
 		activityManager:    activityManager,
+		profile:            profile,
+		iamManager:         iamManager,
 	}
 }
 
@@ -513,6 +523,14 @@ func (s *RolloutService) BatchRunTasks(ctx context.Context, request *v1pb.BatchR
 	if !approved && !(user.Role == api.Owner || user.Role == api.DBA) {
 		return nil, status.Errorf(codes.FailedPrecondition, "cannot run the tasks because the issue is not approved")
 	}
+	if request.OverrideDeploymentWindow {
+		if request.Reason == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "reason is required to override the deployment window")
+		}
+		if user.Role != api.Owner && user.Role != api.DBA {
+			return nil, status.Errorf(codes.PermissionDenied, "only the workspace owner and DBA can override the deployment window")
+		}
+	}
 
 	var taskRunCreates []*store.TaskRunMessage
 	var tasksToRun []*store.TaskMessage
@@ -520,6 +538,9 @@ func (s *RolloutService) BatchRunTasks(ctx context.Context, request *v1pb.BatchR
 		if !taskIDsToRunMap[task.ID] {
 			continue
 		}
+		if request.SkipFailedStatement && task.LatestTaskRunStatus != api.TaskRunFailed {
+			return nil, status.Errorf(codes.InvalidArgument, "cannot skip the failed statement of task %q because its latest task run didn't fail", task.Name)
+		}
 		tasksToRun = append(tasksToRun, task)
 		create := &store.TaskRunMessage{
 			TaskUID:   task.ID,
@@ -532,11 +553,33 @@ func (s *RolloutService) BatchRunTasks(ctx context.Context, request *v1pb.BatchR
 		return taskRunCreates[i].TaskUID < taskRunCreates[j].TaskUID
 	})
 
-	if err := s.store.CreatePendingTaskRuns(ctx, taskRunCreates...); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create pending task runs, error %v", err)
+	if request.SkipFailedStatement {
+		var taskIDs []int
+		for _, task := range tasksToRun {
+			taskIDs = append(taskIDs, task.ID)
+		}
+		if err := s.store.BatchSkipFailedStatement(ctx, taskIDs, user.ID); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to skip failed statement, error: %v", err)
+		}
 	}
 
-	if err := s.activityManager.BatchCreateActivitiesForRunTasks(ctx, tasksToRun, issue, request.Reason, user.ID); err != nil {
+	comment := request.Reason
+	if request.OverrideDeploymentWindow {
+		// The override only applies to the task runs just created.
+		if err := s.store.CreatePendingTaskRunsOverridingDeploymentWindow(ctx, &store.DeploymentWindowOverride{
+			Reason:    request.Reason,
+			UpdaterID: user.ID,
+		}, taskRunCreates...); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create pending task runs overriding the deployment window, error %v", err)
+		}
+		comment = fmt.Sprintf("Overrode the deployment window: %s", request.Reason)
+	} else {
+		if err := s.store.CreatePendingTaskRuns(ctx, taskRunCreates...); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create pending task runs, error %v", err)
+		}
+	}
+
+	if err := s.activityManager.BatchCreateActivitiesForRunTasks(ctx, tasksToRun, issue, comment, user.ID); err != nil {
 		slog.Error("failed to batch create activities for running tasks", log.BBError(err))
 	}
 
@@ -613,6 +656,9 @@ func (s *RolloutService) BatchSkipTasks(ctx context.Context, request *v1pb.Batch
 	for _, task := range tasksToSkip {
 		s.stateCfg.TaskSkippedOrDoneChan <- task.ID
 	}
+	if hasPGOSCTask(tasksToSkip) {
+		s.stateCfg.PGOSCCleanupChan <- rolloutID
+	}
 
 	if err := s.activityManager.BatchCreateActivitiesForSkipTasks(ctx, tasksToSkip, issue, request.Reason, principalID); err != nil {
 		slog.Error("failed to batch create activities for skipping tasks", log.BBError(err))
@@ -741,6 +787,9 @@ func (s *RolloutService) BatchCancelTaskRuns(ctx context.Context, request *v1pb.
 	if err := s.store.BatchCancelTaskRuns(ctx, taskRunIDs, principalID); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to batch patch task run status to canceled, error: %v", err)
 	}
+	if hasPGOSCTask(tasks) {
+		s.stateCfg.PGOSCCleanupChan <- rolloutID
+	}
 
 	if err := s.activityManager.BatchCreateActivitiesForCancelTaskRuns(ctx, tasks, issue, request.Reason, principalID); err != nil {
 		slog.Error("failed to batch create activities for cancel task runs", log.BBError(err))
@@ -749,6 +798,566 @@ func (s *RolloutService) BatchCancelTaskRuns(ctx context.Context, request *v1pb.
 	return &v1pb.BatchCancelTaskRunsResponse{}, nil
 }
 
+// hasPGOSCTask returns whether the tasks have the PostgreSQL online schema change tasks,
+// whose shadow tables and triggers are cleaned up if the change is abandoned.
+func hasPGOSCTask(tasks []*store.TaskMessage) bool {
+	for _, task := range tasks {
+		if task.Type == api.TaskDatabaseSchemaUpdatePGOSCSync || task.Type == api.TaskDatabaseSchemaUpdatePGOSCCutover {
+			return true
+		}
+	}
+	return false
+}
+
+// getTaskToControl gets the task to control, and checks if the user can run the tasks of its stage.
+func (s *RolloutService) getTaskToControl(ctx context.Context, taskName string) (*store.TaskMessage, error) {
+	_, rolloutID, stageID, taskID, err := common.GetProjectIDRolloutIDStageIDTaskID(taskName)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	task, err := s.store.GetTaskV2ByID(ctx, taskID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get task, error: %v", err)
+	}
+	if task == nil || task.PipelineID != rolloutID || task.StageID != stageID {
+		return nil, status.Errorf(codes.NotFound, "task %v not found", taskName)
+	}
+	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &rolloutID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find issue, error: %v", err)
+	}
+	if issue == nil {
+		return nil, status.Errorf(codes.NotFound, "issue not found for rollout %v", rolloutID)
+	}
+	stages, err := s.store.ListStageV2(ctx, rolloutID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list stages, error: %v", err)
+	}
+	var stage *store.StageMessage
+	for i := range stages {
+		if stages[i].ID == stageID {
+			stage = stages[i]
+			break
+		}
+	}
+	if stage == nil {
+		return nil, status.Errorf(codes.NotFound, "stage %v not found in rollout %v", stageID, rolloutID)
+	}
+	principalID, ok := ctx.Value(common.PrincipalIDContextKey).(int)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "principal ID not found")
+	}
+	user, err := s.store.GetUserByID(ctx, principalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find user, error: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user %v not found", principalID)
+	}
+	ok, err = canUserRunStageTasks(ctx, s.store, user, issue, stage.EnvironmentID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check if the user can run tasks, error: %v", err)
+	}
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "Not allowed to run tasks")
+	}
+
+	return task, nil
+}
+
+// ControlGhostMigration adjusts a running gh-ost migration through its interactive socket.
+func (s *RolloutService) ControlGhostMigration(ctx context.Context, request *v1pb.ControlGhostMigrationRequest) (*v1pb.ControlGhostMigrationResponse, error) {
+	var commands []string
+	if request.Throttle != nil {
+		if request.GetThrottle() {
+			commands = append(commands, "throttle")
+		} else {
+			commands = append(commands, "no-throttle")
+		}
+	}
+	if request.ChunkSize != nil {
+		if request.GetChunkSize() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "chunk_size must be positive")
+		}
+		commands = append(commands, fmt.Sprintf("chunk-size=%d", request.GetChunkSize()))
+	}
+	if request.MaxLagMillis != nil {
+		if request.GetMaxLagMillis() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "max_lag_millis must be positive")
+		}
+		commands = append(commands, fmt.Sprintf("max-lag-millis=%d", request.GetMaxLagMillis()))
+	}
+	if request.NiceRatio != nil {
+		if request.GetNiceRatio() < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "nice_ratio must not be negative")
+		}
+		commands = append(commands, fmt.Sprintf("nice-ratio=%v", request.GetNiceRatio()))
+	}
+
+	task, err := s.getTaskToControl(ctx, request.Task)
+	if err != nil {
+		return nil, err
+	}
+	if task.Type != api.TaskDatabaseSchemaUpdateGhostSync {
+		return nil, status.Errorf(codes.InvalidArgument, "task %v is not a gh-ost sync task", request.Task)
+	}
+
+	payload := &api.TaskDatabaseSchemaUpdateGhostSyncPayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid database schema update gh-ost sync payload, error: %v", err)
+	}
+	statement, err := s.store.GetSheetStatementByID(ctx, payload.SheetID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get sheet statement %d, error: %v", payload.SheetID, err)
+	}
+	tableName, err := ghost.GetTableNameFromStatement(strings.TrimSpace(statement))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get database, error: %v", err)
+	}
+	if database == nil {
+		return nil, status.Errorf(codes.NotFound, "database not found for task %v", request.Task)
+	}
+
+	socketFile := ghost.GetSocketFilename(task.ID, database.UID, database.DatabaseName, tableName)
+	for _, command := range commands {
+		if _, err := ghost.SendCommand(socketFile, command); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+		}
+	}
+	// Query the status after all the adjustments are applied.
+	response, err := ghost.SendCommand(socketFile, "status")
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+	return &v1pb.ControlGhostMigrationResponse{Status: response}, nil
+}
+
+// ControlBatchDataUpdate pauses, resumes or adjusts a running batched data update.
+func (s *RolloutService) ControlBatchDataUpdate(ctx context.Context, request *v1pb.ControlBatchDataUpdateRequest) (*v1pb.ControlBatchDataUpdateResponse, error) {
+	if request.BatchSize != nil && request.GetBatchSize() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "batch_size must be positive")
+	}
+	if request.SleepInterval != nil && request.SleepInterval.AsDuration() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "sleep_interval must not be negative")
+	}
+
+	task, err := s.getTaskToControl(ctx, request.Task)
+	if err != nil {
+		return nil, err
+	}
+	if task.Type != api.TaskDatabaseDataUpdate {
+		return nil, status.Errorf(codes.InvalidArgument, "task %v is not a data update task", request.Task)
+	}
+	value, ok := s.stateCfg.BatchDataUpdateControls.Load(task.ID)
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "task %v is not running in batches", request.Task)
+	}
+	control := value.(*state.BatchDataUpdateControl)
+	if request.Paused != nil {
+		control.SetPaused(request.GetPaused())
+	}
+	if request.BatchSize != nil {
+		control.SetBatchSize(request.GetBatchSize())
+	}
+	if request.SleepInterval != nil {
+		control.SetSleepInterval(request.SleepInterval.AsDuration())
+	}
+	return &v1pb.ControlBatchDataUpdateResponse{Progress: control.GetProgress()}, nil
+}
+
+// RollbackTask creates the tasks rolling back the change of a task in the same stage, and runs them.
+func (s *RolloutService) RollbackTask(ctx context.Context, request *v1pb.RollbackTaskRequest) (*v1pb.RollbackTaskResponse, error) {
+	task, err := s.getTaskToControl(ctx, request.Task)
+	if err != nil {
+		return nil, err
+	}
+	if task.LatestTaskRunStatus != api.TaskRunDone && task.LatestTaskRunStatus != api.TaskRunFailed {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot roll back task %v because it is %q", request.Task, task.LatestTaskRunStatus)
+	}
+	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &task.PipelineID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find issue, error: %v", err)
+	}
+	if issue == nil {
+		return nil, status.Errorf(codes.NotFound, "issue not found for rollout %v", task.PipelineID)
+	}
+	principalID, ok := ctx.Value(common.PrincipalIDContextKey).(int)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "principal ID not found")
+	}
+
+	spec, err := s.getRollbackSpec(ctx, request.Task, task, issue)
+	if err != nil {
+		return nil, err
+	}
+	taskCreates, taskIndexDAGs, err := getTaskCreatesFromSpec(ctx, s.store, s.licenseService, s.dbFactory, spec, issue.Project, func(string) error { return nil })
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get rollback tasks, error: %v", err)
+	}
+	for _, taskCreate := range taskCreates {
+		taskCreate.CreatorID = principalID
+		taskCreate.PipelineID = task.PipelineID
+		taskCreate.StageID = task.StageID
+		if err := setRestoreRollbackFromTaskID(taskCreate, task.ID); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set the rolled back task, error: %v", err)
+		}
+	}
+	tasks, err := s.store.CreateRollbackTasks(ctx, task.ID, taskCreates, taskIndexDAGs, principalID)
+	if err != nil {
+		if common.ErrorCode(err) == common.Conflict {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v, run or skip the rollback task instead", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create rollback tasks, error: %v", err)
+	}
+	if err := s.activityManager.BatchCreateActivitiesForRunTasks(ctx, tasks, issue, fmt.Sprintf("Roll back task %q", task.Name), principalID); err != nil {
+		slog.Error("failed to batch create activities for running rollback tasks", log.BBError(err))
+	}
+
+	response := &v1pb.RollbackTaskResponse{}
+	for _, rollbackTask := range tasks {
+		v1Task, err := convertToTask(ctx, s.store, issue.Project, rollbackTask)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert task, error: %v", err)
+		}
+		response.Tasks = append(response.Tasks, v1Task)
+	}
+	return response, nil
+}
+
+// OverridePostDeployCheck reruns the failed post-deployment check of the latest task run of a task, or passes the running or failed check.
+func (s *RolloutService) OverridePostDeployCheck(ctx context.Context, request *v1pb.OverridePostDeployCheckRequest) (*v1pb.TaskRun, error) {
+	if request.Pass && strings.TrimSpace(request.Reason) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "reason is required to pass the post-deployment check")
+	}
+	task, err := s.getTaskToControl(ctx, request.Task)
+	if err != nil {
+		return nil, err
+	}
+	principalID, ok := ctx.Value(common.PrincipalIDContextKey).(int)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "principal ID not found")
+	}
+	user, err := s.store.GetUserByID(ctx, principalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user, error: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user %d not found", principalID)
+	}
+	taskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{TaskUID: &task.ID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list task runs, error: %v", err)
+	}
+	var taskRun *store.TaskRunMessage
+	for _, tr := range taskRuns {
+		if taskRun == nil || taskRun.ID < tr.ID {
+			taskRun = tr
+		}
+	}
+	if taskRun == nil || taskRun.Status != api.TaskRunDone || taskRun.ResultProto.GetPostDeployCheck() == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "task %v has no post-deployment check", request.Task)
+	}
+
+	fromStatuses := []storepb.TaskRunResult_CheckResult_Status{storepb.TaskRunResult_CheckResult_FAILED}
+	// The rerun check starts a new anomaly window.
+	check := &storepb.TaskRunResult_CheckResult{
+		Status:    storepb.TaskRunResult_CheckResult_RUNNING,
+		StartTime: timestamppb.Now(),
+	}
+	if request.Pass {
+		fromStatuses = append(fromStatuses, storepb.TaskRunResult_CheckResult_RUNNING)
+		check = &storepb.TaskRunResult_CheckResult{
+			Status:    storepb.TaskRunResult_CheckResult_PASSED,
+			Detail:    fmt.Sprintf("Passed by %s: %s", user.Email, request.Reason),
+			StartTime: taskRun.ResultProto.GetPostDeployCheck().GetStartTime(),
+		}
+	}
+	if err := s.store.UpdateTaskRunPostDeployCheck(ctx, taskRun.ID, fromStatuses, check); err != nil {
+		if common.ErrorCode(err) == common.Conflict {
+			return nil, status.Errorf(codes.FailedPrecondition, "cannot override the post-deployment check of task %v because it is %q", request.Task, taskRun.ResultProto.GetPostDeployCheck().GetStatus())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update the post-deployment check, error: %v", err)
+	}
+	taskRun.ResultProto.PostDeployCheck = check
+	return convertToTaskRun(s.stateCfg, taskRun), nil
+}
+
+// getRollbackSpec gets the spec of the tasks rolling back the change of the task.
+// The DATA change is rolled back by its generated rollback statement,
+// and the other changes by restoring the backup of the database taken for the change.
+func (s *RolloutService) getRollbackSpec(ctx context.Context, taskName string, task *store.TaskMessage, issue *store.IssueMessage) (*storepb.PlanConfig_Spec, error) {
+	if task.DatabaseID == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "task %v has no database to roll back", taskName)
+	}
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get database, error: %v", err)
+	}
+	if database == nil {
+		return nil, status.Errorf(codes.NotFound, "database %d not found", *task.DatabaseID)
+	}
+	target := common.FormatDatabase(database.InstanceID, database.DatabaseName)
+
+	if task.Type == api.TaskDatabaseDataUpdate {
+		payload := &api.TaskDatabaseDataUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to unmarshal task payload, error: %v", err)
+		}
+		if payload.RollbackEnabled && payload.RollbackSQLStatus == api.RollbackSQLStatusPending {
+			return nil, status.Errorf(codes.FailedPrecondition, "the rollback statement of task %v is being generated", taskName)
+		}
+		if payload.RollbackSheetID != 0 {
+			return &storepb.PlanConfig_Spec{
+				Config: &storepb.PlanConfig_Spec_ChangeDatabaseConfig{
+					ChangeDatabaseConfig: &storepb.PlanConfig_ChangeDatabaseConfig{
+						Type:   storepb.PlanConfig_ChangeDatabaseConfig_DATA,
+						Target: target,
+						Sheet:  getResourceNameForSheet(issue.Project, payload.RollbackSheetID),
+						RollbackDetail: &storepb.PlanConfig_ChangeDatabaseConfig_RollbackDetail{
+							RollbackFromIssue: fmt.Sprintf("%s%s/%s%d", common.ProjectNamePrefix, issue.Project.ResourceID, common.IssuePrefix, issue.UID),
+							RollbackFromTask:  taskName,
+						},
+					},
+				},
+			}, nil
+		}
+	}
+
+	taskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{TaskUID: &task.ID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list task runs, error: %v", err)
+	}
+	var changedTs int64
+	for _, taskRun := range taskRuns {
+		if taskRun.StartedTs > changedTs {
+			changedTs = taskRun.StartedTs
+		}
+	}
+	rolloutBackupIDs, err := s.getRolloutBackupIDs(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	backupStatus := api.BackupStatusDone
+	backups, err := s.store.ListBackupV2(ctx, &store.FindBackupMessage{
+		DatabaseUID: &database.UID,
+		Status:      &backupStatus,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list backups, error: %v", err)
+	}
+	backup := getRollbackBackup(backups, rolloutBackupIDs, changedTs)
+	if backup == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "task %v has neither the rollback statement nor a backup of the database taken by the rollout or within %v before the change", taskName, maxRollbackBackupAge)
+	}
+	return &storepb.PlanConfig_Spec{
+		Config: &storepb.PlanConfig_Spec_RestoreDatabaseConfig{
+			RestoreDatabaseConfig: &storepb.PlanConfig_RestoreDatabaseConfig{
+				Target: target,
+				Source: &storepb.PlanConfig_RestoreDatabaseConfig_Backup{
+					Backup: fmt.Sprintf("%s%s/%s%s/%s%s", common.InstanceNamePrefix, database.InstanceID, common.DatabaseIDPrefix, database.DatabaseName, common.BackupPrefix, backup.Name),
+				},
+			},
+		},
+	}, nil
+}
+
+// maxRollbackBackupAge is the maximum age of the backup restored to roll back a change if the backup isn't taken by the rollout.
+// The changes made to the database between the backup and the change are lost by the restore.
+const maxRollbackBackupAge = time.Hour
+
+// getRolloutBackupIDs returns the IDs of the backups of the task database taken by the done backup tasks of the rollout.
+func (s *RolloutService) getRolloutBackupIDs(ctx context.Context, task *store.TaskMessage) (map[int]bool, error) {
+	tasks, err := s.store.ListTasks(ctx, &api.TaskFind{
+		PipelineID:              &task.PipelineID,
+		DatabaseID:              task.DatabaseID,
+		TypeList:                &[]api.TaskType{api.TaskDatabaseBackup},
+		LatestTaskRunStatusList: &[]api.TaskRunStatus{api.TaskRunDone},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list backup tasks, error: %v", err)
+	}
+	backupIDs := map[int]bool{}
+	for _, t := range tasks {
+		payload := &api.TaskDatabaseBackupPayload{}
+		if err := json.Unmarshal([]byte(t.Payload), payload); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to unmarshal task payload, error: %v", err)
+		}
+		if payload.BackupID != 0 {
+			backupIDs[payload.BackupID] = true
+		}
+	}
+	return backupIDs, nil
+}
+
+// getRollbackBackup returns the latest backup taken before the change at changedTs by the backup tasks of the rollout,
+// or else the latest backup taken within maxRollbackBackupAge before the change.
+func getRollbackBackup(backups []*store.BackupMessage, rolloutBackupIDs map[int]bool, changedTs int64) *store.BackupMessage {
+	var backup *store.BackupMessage
+	for _, b := range backups {
+		if b.CreatedTs > changedTs {
+			continue
+		}
+		if !rolloutBackupIDs[b.UID] && b.CreatedTs < changedTs-int64(maxRollbackBackupAge.Seconds()) {
+			continue
+		}
+		if backup == nil {
+			backup = b
+			continue
+		}
+		// The backup taken by the rollout is preferred.
+		if rolloutBackupIDs[b.UID] != rolloutBackupIDs[backup.UID] {
+			if rolloutBackupIDs[b.UID] {
+				backup = b
+			}
+			continue
+		}
+		if backup.CreatedTs < b.CreatedTs {
+			backup = b
+		}
+	}
+	return backup
+}
+
+// setRestoreRollbackFromTaskID records the task rolled back by the restore task in its payload.
+// The DATA task records it by the rollback detail of the spec.
+func setRestoreRollbackFromTaskID(taskCreate *store.TaskMessage, taskID int) error {
+	var payloadBytes []byte
+	switch taskCreate.Type {
+	case api.TaskDatabaseRestorePITRRestore:
+		payload := &api.TaskDatabasePITRRestorePayload{}
+		if err := json.Unmarshal([]byte(taskCreate.Payload), payload); err != nil {
+			return err
+		}
+		payload.RollbackFromTaskID = taskID
+		bytes, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		payloadBytes = bytes
+	case api.TaskDatabaseRestorePITRCutover:
+		payload := &api.TaskDatabasePITRCutoverPayload{}
+		if err := json.Unmarshal([]byte(taskCreate.Payload), payload); err != nil {
+			return err
+		}
+		payload.RollbackFromTaskID = taskID
+		bytes, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		payloadBytes = bytes
+	default:
+		return nil
+	}
+	taskCreate.Payload = string(payloadBytes)
+	return nil
+}
+
+// taskRunLogPollInterval is the interval of polling the new log entries of a running task run.
+const taskRunLogPollInterval = 1 * time.Second
+
+// TailTaskRunLogs streams the log entries of a task run.
+func (s *RolloutService) TailTaskRunLogs(request *v1pb.TailTaskRunLogsRequest, server v1pb.RolloutService_TailTaskRunLogsServer) error {
+	ctx := server.Context()
+	projectID, rolloutID, stageID, taskID, taskRunID, err := common.GetProjectIDRolloutIDStageIDTaskIDTaskRunID(request.Parent)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	var afterUID *int64
+	if request.AfterUid != "" {
+		uid, err := strconv.ParseInt(request.AfterUid, 10, 64)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid after_uid %q", request.AfterUid)
+		}
+		afterUID = &uid
+	}
+
+	// The permission is checked here because the stream interceptor doesn't pass the request.
+	user, ok := ctx.Value(common.UserContextKey).(*store.UserMessage)
+	if !ok {
+		return status.Errorf(codes.Internal, "user not found")
+	}
+	if err := s.checkTaskRunLogsPermission(ctx, user, projectID); err != nil {
+		return err
+	}
+
+	pipeline, err := s.store.GetPipelineV2ByID(ctx, rolloutID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get rollout, error: %v", err)
+	}
+	if pipeline == nil || pipeline.ProjectID != projectID {
+		return status.Errorf(codes.NotFound, "rollout %d not found in project %q", rolloutID, projectID)
+	}
+	task, err := s.store.GetTaskV2ByID(ctx, taskID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get task, error: %v", err)
+	}
+	if task == nil || task.PipelineID != rolloutID || task.StageID != stageID {
+		return status.Errorf(codes.NotFound, "task %d not found in rollout %d", taskID, rolloutID)
+	}
+
+	ticker := time.NewTicker(taskRunLogPollInterval)
+	defer ticker.Stop()
+	for {
+		// Get the task run status before listing the log entries so that the entries recorded before the task run finishes are all sent.
+		taskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{UID: &taskRunID})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get task run, error: %v", err)
+		}
+		if len(taskRuns) == 0 || taskRuns[0].TaskUID != task.ID {
+			return status.Errorf(codes.NotFound, "task run %d not found in task %d", taskRunID, taskID)
+		}
+		finished := taskRuns[0].Status != api.TaskRunPending && taskRuns[0].Status != api.TaskRunRunning
+
+		logs, err := s.store.ListTaskRunLogs(ctx, &store.FindTaskRunLogMessage{TaskRunUID: taskRunID, AfterUID: afterUID})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list task run logs, error: %v", err)
+		}
+		for _, taskRunLog := range logs {
+			if err := server.Send(convertToTaskRunLogEntry(taskRunLog)); err != nil {
+				return err
+			}
+			afterUID = &taskRunLog.UID
+		}
+		if !request.Follow || finished {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *RolloutService) checkTaskRunLogsPermission(ctx context.Context, user *store.UserMessage, projectID string) error {
+	if s.profile.DevelopmentIAM {
+		ok, err := s.iamManager.CheckPermission(ctx, iam.PermissionTaskRunsList, user, projectID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to check permission, err: %v", err)
+		}
+		if !ok {
+			return status.Errorf(codes.PermissionDenied, "permission denied to list task run logs in project %q", projectID)
+		}
+		return nil
+	}
+	if isOwnerOrDBA(user.Role) {
+		return nil
+	}
+	policy, err := s.store.GetProjectPolicy(ctx, &store.GetProjectPolicyMessage{ProjectID: &projectID})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to find project policy, error: %v", err)
+	}
+	if !isProjectMember(user.ID, policy) {
+		return status.Errorf(codes.PermissionDenied, "only the members of project %q can list task run logs", projectID)
+	}
+	return nil
+}
+
 // UpdatePlan updates a plan.
 func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePlanRequest) (*v1pb.Plan, error) {
 	if request.UpdateMask == nil {
@@ -757,6 +1366,9 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 	for _, path := range request.UpdateMask.Paths {
 		switch path {
 		case "steps":
+			if err := validateSteps(request.Plan.Steps); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "failed to validate plan steps, error: %v", err)
+			}
 		default:
 			return nil, status.Errorf(codes.InvalidArgument, "invalid update_mask path %q", path)
 		}
@@ -779,7 +1391,8 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 		return nil, status.Errorf(codes.Internal, "failed to get issue: %v", err)
 	}
 
-	removed, added, updated := diffSpecs(oldSteps, request.Plan.Steps)
+	// The specs are compared with the earliest allowed time inherited from their steps, so that updating the earliest allowed time of a step updates the tasks of its specs.
+	removed, added, updated := diffSpecs(inheritPlanStepEarliestAllowedTime(oldSteps), inheritPlanStepEarliestAllowedTime(request.Plan.Steps))
 	if len(removed) > 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "cannot remove specs from plan")
 	}
@@ -808,6 +1421,14 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to list tasks: %v", err)
 		}
+		stages, err := s.store.ListStageV2(ctx, *oldPlan.PipelineUID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list stages: %v", err)
+		}
+		stageDeployments := map[int]string{}
+		for _, stage := range stages {
+			stageDeployments[stage.ID] = stage.Payload.GetDeployment()
+		}
 		for _, task := range tasks {
 			doUpdate := false
 			taskPatch := &api.TaskPatch{
@@ -827,9 +1448,9 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 				continue
 			}
 
-			// Flags for gh-ost.
+			// Flags for gh-ost and the PostgreSQL online schema change.
 			if err := func() error {
-				if task.Type != api.TaskDatabaseSchemaUpdateGhostSync {
+				if task.Type != api.TaskDatabaseSchemaUpdateGhostSync && task.Type != api.TaskDatabaseSchemaUpdatePGOSCSync {
 					return nil
 				}
 				payload := &api.TaskDatabaseSchemaUpdateGhostSyncPayload{}
@@ -837,7 +1458,11 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 					return status.Errorf(codes.Internal, "failed to unmarshal task payload: %v", err)
 				}
 				newFlags := spec.GetChangeDatabaseConfig().GetGhostFlags()
-				if _, err := ghost.GetUserFlags(newFlags); err != nil {
+				if task.Type == api.TaskDatabaseSchemaUpdatePGOSCSync {
+					if _, err := pgosc.GetUserFlags(newFlags); err != nil {
+						return status.Errorf(codes.InvalidArgument, "invalid online schema change flags %q, error %v", newFlags, err)
+					}
+				} else if _, err := ghost.GetUserFlags(newFlags); err != nil {
 					return status.Errorf(codes.InvalidArgument, "invalid ghost flags %q, error %v", newFlags, err)
 				}
 				oldFlags := payload.Flags
@@ -852,8 +1477,7 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 			}
 
 			// EarliestAllowedTs
-			if spec.EarliestAllowedTime.GetSeconds() != task.EarliestAllowedTs {
-				seconds := spec.EarliestAllowedTime.GetSeconds()
+			if seconds := getSpecEarliestAllowedTime(spec, stageDeployments[task.StageID]).GetSeconds(); seconds != task.EarliestAllowedTs {
 				taskPatch.EarliestAllowedTs = &seconds
 				doUpdate = true
 				earliestUpdates = append(earliestUpdates, api.ActivityPipelineTaskEarliestAllowedTimeUpdatePayload{
@@ -882,6 +1506,12 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 					taskPatch.RollbackEnabled = &config.ChangeDatabaseConfig.RollbackEnabled
 					doUpdate = true
 				}
+				// BatchConfig
+				batchConfig := convertToDataUpdateBatchConfig(convertPlanSpecChangeDatabaseConfigBatchConfig(config.ChangeDatabaseConfig.BatchConfig))
+				if !cmp.Equal(batchConfig, payload.BatchConfig) {
+					taskPatch.BatchConfig = &batchConfig
+					doUpdate = true
+				}
 				return nil
 			}(); err != nil {
 				return nil, err
@@ -890,7 +1520,7 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 			// Sheet
 			if err := func() error {
 				switch task.Type {
-				case api.TaskDatabaseSchemaUpdate, api.TaskDatabaseSchemaUpdateSDL, api.TaskDatabaseSchemaUpdateGhostSync, api.TaskDatabaseDataUpdate:
+				case api.TaskDatabaseSchemaUpdate, api.TaskDatabaseSchemaUpdateSDL, api.TaskDatabaseSchemaUpdateGhostSync, api.TaskDatabaseSchemaUpdatePGOSCSync, api.TaskDatabaseDataUpdate:
 					var taskPayload struct {
 						SpecID  string `json:"specId"`
 						SheetID int    `json:"sheetId"`
@@ -944,12 +1574,18 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 	}
 
 	for _, taskPatch := range taskPatchList {
-		if taskPatch.SheetID != nil || taskPatch.EarliestAllowedTs != nil {
-			task := tasksMap[taskPatch.ID]
+		task := tasksMap[taskPatch.ID]
+		if taskPatch.SheetID != nil {
 			if task.LatestTaskRunStatus == api.TaskRunPending || task.LatestTaskRunStatus == api.TaskRunRunning {
 				return nil, status.Errorf(codes.FailedPrecondition, "cannot update plan because task %q is %s", task.Name, task.LatestTaskRunStatus)
 			}
 		}
+		// The pending task run waits for the earliest allowed time, so it can be rescheduled.
+		if taskPatch.EarliestAllowedTs != nil {
+			if task.LatestTaskRunStatus == api.TaskRunRunning {
+				return nil, status.Errorf(codes.FailedPrecondition, "cannot update plan because task %q is %s", task.Name, task.LatestTaskRunStatus)
+			}
+		}
 	}
 
 	var doUpdateSheet bool
@@ -1123,6 +1759,50 @@ func diffSpecs(oldSteps []*v1pb.Plan_Step, newSteps []*v1pb.Plan_Step) ([]*v1pb.
 	return removed, added, updated
 }
 
+// inheritStepEarliestAllowedTime returns the steps where the specs without the earliest allowed time inherit it from their steps.
+// The input steps are not modified.
+func inheritStepEarliestAllowedTime(steps []*storepb.PlanConfig_Step) []*storepb.PlanConfig_Step {
+	var result []*storepb.PlanConfig_Step
+	for _, step := range steps {
+		if step.EarliestAllowedTime.GetSeconds() != 0 {
+			step = proto.Clone(step).(*storepb.PlanConfig_Step)
+			for _, spec := range step.Specs {
+				if spec.EarliestAllowedTime.GetSeconds() == 0 {
+					spec.EarliestAllowedTime = step.EarliestAllowedTime
+				}
+			}
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// inheritPlanStepEarliestAllowedTime is the same as inheritStepEarliestAllowedTime for the v1 plan steps.
+func inheritPlanStepEarliestAllowedTime(steps []*v1pb.Plan_Step) []*v1pb.Plan_Step {
+	var result []*v1pb.Plan_Step
+	for _, step := range steps {
+		if step.EarliestAllowedTime.GetSeconds() != 0 {
+			step = proto.Clone(step).(*v1pb.Plan_Step)
+			for _, spec := range step.Specs {
+				if spec.EarliestAllowedTime.GetSeconds() == 0 {
+					spec.EarliestAllowedTime = step.EarliestAllowedTime
+				}
+			}
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// getSpecEarliestAllowedTime returns the earliest allowed time of the spec for the stage created from the deployment.
+// The deployment is empty for the stages not created from the deployment config.
+func getSpecEarliestAllowedTime(spec *v1pb.Plan_Spec, deployment string) *timestamppb.Timestamp {
+	if t, ok := spec.DeploymentEarliestAllowedTimes[deployment]; deployment != "" && ok {
+		return t
+	}
+	return spec.EarliestAllowedTime
+}
+
 func validateSteps(steps []*v1pb.Plan_Step) error {
 	var databaseTarget, databaseGroupTarget, deploymentConfigTarget int
 	for _, step := range steps {
@@ -1137,6 +1817,17 @@ func validateSteps(steps []*v1pb.Plan_Step) error {
 				} else {
 					return errors.Errorf("unknown target %q", config.Target)
 				}
+				if len(spec.DeploymentEarliestAllowedTimes) > 0 {
+					if _, _, err := common.GetProjectIDDeploymentConfigID(config.Target); err != nil {
+						return errors.Errorf("deployment earliest allowed times are only supported for the deploymentConfig target, got %q", config.Target)
+					}
+				}
+				if err := validateBatchConfig(config); err != nil {
+					return err
+				}
+				if err := validatePostDeployCheck(config); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -1149,15 +1840,56 @@ func validateSteps(steps []*v1pb.Plan_Step) error {
 	return nil
 }
 
+func validateBatchConfig(config *v1pb.Plan_ChangeDatabaseConfig) error {
+	c := config.BatchConfig
+	if c == nil {
+		return nil
+	}
+	if config.Type != v1pb.Plan_ChangeDatabaseConfig_DATA {
+		return errors.Errorf("batch config is only supported for DATA change, got %v", config.Type)
+	}
+	// The batches commit separately, so the binlog of a single transaction for the rollback SQL doesn't exist.
+	if config.RollbackEnabled {
+		return errors.Errorf("rollback is not supported for the DATA change running in batches")
+	}
+	if c.BatchSize < 0 {
+		return errors.Errorf("batch size must not be negative, got %d", c.BatchSize)
+	}
+	if c.SleepInterval.AsDuration() < 0 {
+		return errors.Errorf("sleep interval must not be negative")
+	}
+	if c.MaxReplicaLag.AsDuration() < 0 {
+		return errors.Errorf("max replica lag must not be negative")
+	}
+	return nil
+}
+
+func validatePostDeployCheck(config *v1pb.Plan_ChangeDatabaseConfig) error {
+	c := config.PostDeployCheck
+	if c == nil {
+		return nil
+	}
+	if config.Type == v1pb.Plan_ChangeDatabaseConfig_BASELINE || config.Type == v1pb.Plan_ChangeDatabaseConfig_BRANCH {
+		return errors.Errorf("post-deployment check is not supported for %v change", config.Type)
+	}
+	if c.ExpectedValue != "" && c.VerificationStatement == "" {
+		return errors.Errorf("expected value requires the verification statement")
+	}
+	if c.AnomalyWindow.AsDuration() < 0 {
+		return errors.Errorf("anomaly window must not be negative")
+	}
+	return nil
+}
+
 // GetPipelineCreate gets a pipeline create message from a plan.
 func GetPipelineCreate(ctx context.Context, s *store.Store, licenseService enterprise.LicenseService, dbFactory *dbfactory.DBFactory, steps []*storepb.PlanConfig_Step, project *store.ProjectMessage) (*store.PipelineMessage, error) {
 	pipelineCreate := &store.PipelineMessage{
 		Name: "Rollout Pipeline",
 	}
 
-	transformedSteps := steps
-	if len(steps) == 1 && len(steps[0].Specs) == 1 {
-		spec := steps[0].Specs[0]
+	transformedSteps := inheritStepEarliestAllowedTime(steps)
+	if len(transformedSteps) == 1 && len(transformedSteps[0].Specs) == 1 {
+		spec := transformedSteps[0].Specs[0]
 		if config := spec.GetChangeDatabaseConfig(); config != nil {
 			if _, _, err := common.GetProjectIDDeploymentConfigID(config.Target); err == nil {
 				stepsFromDeploymentConfig, err := transformDeploymentConfigTargetToSteps(ctx, s, spec, config, project)
@@ -1170,7 +1902,12 @@ func GetPipelineCreate(ctx context.Context, s *store.Store, licenseService enter
 	}
 
 	for _, step := range transformedSteps {
-		stageCreate := &store.StageMessage{}
+		stageCreate := &store.StageMessage{
+			Payload: &storepb.StagePayload{
+				RolloutStrategy: step.RolloutStrategy,
+				Deployment:      step.Deployment,
+			},
+		}
 
 		var stageEnvironmentID string
 		registerEnvironmentID := func(environmentID string) error {
@@ -1228,6 +1965,7 @@ func (s *RolloutService) createPipeline(ctx context.Context, project *store.Proj
 			Name:          stage.Name,
 			EnvironmentID: stage.EnvironmentID,
 			PipelineID:    pipelineCreated.ID,
+			Payload:       stage.Payload,
 		})
 	}
 	createdStages, err := s.store.CreateStageV2(ctx, stageCreates, creatorID)
@@ -48,7 +48,8 @@ func convertToPlanSteps(steps []*storepb.PlanConfig_Step) []*v1pb.Plan_Step {
 
 func convertToPlanStep(step *storepb.PlanConfig_Step) *v1pb.Plan_Step {
 	return &v1pb.Plan_Step{
-		Specs: convertToPlanSpecs(step.Specs),
+		Specs:               convertToPlanSpecs(step.Specs),
+		EarliestAllowedTime: step.EarliestAllowedTime,
 	}
 }
 
@@ -62,8 +63,9 @@ func convertToPlanSpecs(specs []*storepb.PlanConfig_Spec) []*v1pb.Plan_Spec {
 
 func convertToPlanSpec(spec *storepb.PlanConfig_Spec) *v1pb.Plan_Spec {
 	v1Spec := &v1pb.Plan_Spec{
-		EarliestAllowedTime: spec.EarliestAllowedTime,
-		Id:                  spec.Id,
+		EarliestAllowedTime:            spec.EarliestAllowedTime,
+		Id:                             spec.Id,
+		DeploymentEarliestAllowedTimes: spec.DeploymentEarliestAllowedTimes,
 	}
 
 	switch v := spec.Config.(type) {
@@ -127,10 +129,35 @@ func convertToPlanSpecChangeDatabaseConfig(config *storepb.PlanConfig_Spec_Chang
 			RollbackEnabled: c.RollbackEnabled,
 			RollbackDetail:  convertToPlanSpecChangeDatabaseConfigRollbackDetail(c.RollbackDetail),
 			GhostFlags:      c.GhostFlags,
+			BatchConfig:     convertToPlanSpecChangeDatabaseConfigBatchConfig(c.BatchConfig),
+			PostDeployCheck: convertToPlanSpecChangeDatabaseConfigPostDeployCheck(c.PostDeployCheck),
 		},
 	}
 }
 
+func convertToPlanSpecChangeDatabaseConfigPostDeployCheck(c *storepb.PlanConfig_ChangeDatabaseConfig_PostDeployCheck) *v1pb.Plan_ChangeDatabaseConfig_PostDeployCheck {
+	if c == nil {
+		return nil
+	}
+	return &v1pb.Plan_ChangeDatabaseConfig_PostDeployCheck{
+		VerificationStatement: c.VerificationStatement,
+		ExpectedValue:         c.ExpectedValue,
+		ExpectedSdl:           c.ExpectedSdl,
+		AnomalyWindow:         c.AnomalyWindow,
+	}
+}
+
+func convertToPlanSpecChangeDatabaseConfigBatchConfig(c *storepb.PlanConfig_ChangeDatabaseConfig_BatchConfig) *v1pb.Plan_ChangeDatabaseConfig_BatchConfig {
+	if c == nil {
+		return nil
+	}
+	return &v1pb.Plan_ChangeDatabaseConfig_BatchConfig{
+		BatchSize:     c.BatchSize,
+		SleepInterval: c.SleepInterval,
+		MaxReplicaLag: c.MaxReplicaLag,
+	}
+}
+
 func convertToPlanSpecChangeDatabaseConfigRollbackDetail(d *storepb.PlanConfig_ChangeDatabaseConfig_RollbackDetail) *v1pb.Plan_ChangeDatabaseConfig_RollbackDetail {
 	if d == nil {
 		return nil
@@ -194,7 +221,8 @@ func convertPlanSteps(steps []*v1pb.Plan_Step) []*storepb.PlanConfig_Step {
 
 func convertPlanStep(step *v1pb.Plan_Step) *storepb.PlanConfig_Step {
 	return &storepb.PlanConfig_Step{
-		Specs: convertPlanSpecs(step.Specs),
+		Specs:               convertPlanSpecs(step.Specs),
+		EarliestAllowedTime: step.EarliestAllowedTime,
 	}
 }
 
@@ -208,8 +236,9 @@ func convertPlanSpecs(specs []*v1pb.Plan_Spec) []*storepb.PlanConfig_Spec {
 
 func convertPlanSpec(spec *v1pb.Plan_Spec) *storepb.PlanConfig_Spec {
 	storeSpec := &storepb.PlanConfig_Spec{
-		EarliestAllowedTime: spec.EarliestAllowedTime,
-		Id:                  spec.Id,
+		EarliestAllowedTime:            spec.EarliestAllowedTime,
+		Id:                             spec.Id,
+		DeploymentEarliestAllowedTimes: spec.DeploymentEarliestAllowedTimes,
 	}
 
 	switch v := spec.Config.(type) {
@@ -255,10 +284,35 @@ func convertPlanSpecChangeDatabaseConfig(config *v1pb.Plan_Spec_ChangeDatabaseCo
 			SchemaVersion:   c.SchemaVersion,
 			RollbackEnabled: c.RollbackEnabled,
 			GhostFlags:      c.GhostFlags,
+			BatchConfig:     convertPlanSpecChangeDatabaseConfigBatchConfig(c.BatchConfig),
+			PostDeployCheck: convertPlanSpecChangeDatabaseConfigPostDeployCheck(c.PostDeployCheck),
 		},
 	}
 }
 
+func convertPlanSpecChangeDatabaseConfigPostDeployCheck(c *v1pb.Plan_ChangeDatabaseConfig_PostDeployCheck) *storepb.PlanConfig_ChangeDatabaseConfig_PostDeployCheck {
+	if c == nil {
+		return nil
+	}
+	return &storepb.PlanConfig_ChangeDatabaseConfig_PostDeployCheck{
+		VerificationStatement: c.VerificationStatement,
+		ExpectedValue:         c.ExpectedValue,
+		ExpectedSdl:           c.ExpectedSdl,
+		AnomalyWindow:         c.AnomalyWindow,
+	}
+}
+
+func convertPlanSpecChangeDatabaseConfigBatchConfig(c *v1pb.Plan_ChangeDatabaseConfig_BatchConfig) *storepb.PlanConfig_ChangeDatabaseConfig_BatchConfig {
+	if c == nil {
+		return nil
+	}
+	return &storepb.PlanConfig_ChangeDatabaseConfig_BatchConfig{
+		BatchSize:     c.BatchSize,
+		SleepInterval: c.SleepInterval,
+		MaxReplicaLag: c.MaxReplicaLag,
+	}
+}
+
 func convertPlanSpecRestoreDatabaseConfig(config *v1pb.Plan_Spec_RestoreDatabaseConfig) *storepb.PlanConfig_Spec_RestoreDatabaseConfig {
 	c := config.RestoreDatabaseConfig
 	storeConfig := &storepb.PlanConfig_Spec_RestoreDatabaseConfig{
@@ -370,8 +424,14 @@ func convertToPlanCheckRunType(t store.PlanCheckRunType) v1pb.PlanCheckRun_Type
 		return v1pb.PlanCheckRun_DATABASE_CONNECT
 	case store.PlanCheckDatabaseGhostSync:
 		return v1pb.PlanCheckRun_DATABASE_GHOST_SYNC
+	case store.PlanCheckDatabasePGOSCSync:
+		return v1pb.PlanCheckRun_DATABASE_PGOSC_SYNC
 	case store.PlanCheckDatabasePITRMySQL:
 		return v1pb.PlanCheckRun_DATABASE_PITR_MYSQL
+	case store.PlanCheckDatabaseDeploymentWindow:
+		return v1pb.PlanCheckRun_DATABASE_DEPLOYMENT_WINDOW
+	case store.PlanCheckDatabaseProductionSafety:
+		return v1pb.PlanCheckRun_DATABASE_PRODUCTION_SAFETY
 	}
 	return v1pb.PlanCheckRun_TYPE_UNSPECIFIED
 }
@@ -484,6 +544,12 @@ func convertToTaskRun(stateCfg *state.State, taskRun *store.TaskRunMessage) *v1p
 		Detail:        taskRun.ResultProto.Detail,
 		ChangeHistory: taskRun.ResultProto.ChangeHistory,
 		SchemaVersion: taskRun.ResultProto.Version,
+
+		RolloutVerification: convertToTaskRunCheckResult(taskRun.ResultProto.RolloutVerification),
+		PostDeployCheck:     convertToTaskRunCheckResult(taskRun.ResultProto.PostDeployCheck),
+	}
+	for _, result := range taskRun.ResultProto.StatementResults {
+		t.StatementResults = append(t.StatementResults, convertToTaskRunStatementResult(result))
 	}
 
 	if v, ok := stateCfg.TaskRunExecutionStatuses.Load(taskRun.ID); ok {
@@ -510,6 +576,82 @@ func convertToTaskRun(stateCfg *state.State, taskRun *store.TaskRunMessage) *v1p
 	return t
 }
 
+func convertToTaskRunCheckResult(result *storepb.TaskRunResult_CheckResult) *v1pb.TaskRun_CheckResult {
+	if result == nil {
+		return nil
+	}
+	r := &v1pb.TaskRun_CheckResult{
+		Detail: result.Detail,
+	}
+	switch result.Status {
+	case storepb.TaskRunResult_CheckResult_PASSED:
+		r.Status = v1pb.TaskRun_CheckResult_PASSED
+	case storepb.TaskRunResult_CheckResult_FAILED:
+		r.Status = v1pb.TaskRun_CheckResult_FAILED
+	case storepb.TaskRunResult_CheckResult_RUNNING:
+		r.Status = v1pb.TaskRun_CheckResult_RUNNING
+	}
+	return r
+}
+
+func convertToTaskRunStatementResult(result *storepb.TaskRunResult_StatementResult) *v1pb.TaskRun_StatementResult {
+	r := &v1pb.TaskRun_StatementResult{
+		Index:    result.Index,
+		Checksum: result.Checksum,
+		Resumed:  result.Resumed,
+		Error:    result.Error,
+	}
+	switch result.Status {
+	case storepb.TaskRunResult_StatementResult_PENDING:
+		r.Status = v1pb.TaskRun_StatementResult_PENDING
+	case storepb.TaskRunResult_StatementResult_DONE:
+		r.Status = v1pb.TaskRun_StatementResult_DONE
+	case storepb.TaskRunResult_StatementResult_FAILED:
+		r.Status = v1pb.TaskRun_StatementResult_FAILED
+	case storepb.TaskRunResult_StatementResult_SKIPPED:
+		r.Status = v1pb.TaskRun_StatementResult_SKIPPED
+	}
+	if result.StartPosition != nil {
+		r.StartPosition = &v1pb.TaskRun_ExecutionDetail_Position{
+			Line:   result.StartPosition.Line,
+			Column: result.StartPosition.Column,
+		}
+	}
+	if result.EndPosition != nil {
+		r.EndPosition = &v1pb.TaskRun_ExecutionDetail_Position{
+			Line:   result.EndPosition.Line,
+			Column: result.EndPosition.Column,
+		}
+	}
+	return r
+}
+
+func convertToTaskRunLogEntry(taskRunLog *store.TaskRunLogMessage) *v1pb.TaskRunLogEntry {
+	entry := &v1pb.TaskRunLogEntry{
+		Uid:            fmt.Sprintf("%d", taskRunLog.UID),
+		LogTime:        timestamppb.New(taskRunLog.CreatedTime),
+		StatementIndex: taskRunLog.Payload.StatementIndex,
+		Statement:      taskRunLog.Payload.Statement,
+		Duration:       taskRunLog.Payload.Duration,
+		AffectedRows:   taskRunLog.Payload.AffectedRows,
+		Error:          taskRunLog.Payload.Error,
+		Message:        taskRunLog.Payload.Message,
+	}
+	switch taskRunLog.Payload.Type {
+	case storepb.TaskRunLog_STATEMENT_START:
+		entry.Type = v1pb.TaskRunLogEntry_STATEMENT_START
+	case storepb.TaskRunLog_STATEMENT_END:
+		entry.Type = v1pb.TaskRunLogEntry_STATEMENT_END
+	case storepb.TaskRunLog_DATABASE_NOTICE:
+		entry.Type = v1pb.TaskRunLogEntry_DATABASE_NOTICE
+	case storepb.TaskRunLog_GHOST_STATUS:
+		entry.Type = v1pb.TaskRunLogEntry_GHOST_STATUS
+	case storepb.TaskRunLog_BATCH_STATUS:
+		entry.Type = v1pb.TaskRunLogEntry_BATCH_STATUS
+	}
+	return entry
+}
+
 func convertToRollout(ctx context.Context, s *store.Store, project *store.ProjectMessage, rollout *store.PipelineMessage) (*v1pb.Rollout, error) {
 	rolloutV1 := &v1pb.Rollout{
 		Name:   fmt.Sprintf("%s%s/%s%d", common.ProjectNamePrefix, project.ResourceID, common.RolloutPrefix, rollout.ID),
@@ -531,16 +673,20 @@ func convertToRollout(ctx context.Context, s *store.Store, project *store.Projec
 			return nil, errors.Errorf("environment %d not found", stage.EnvironmentID)
 		}
 		rolloutStage := &v1pb.Stage{
-			Name:        fmt.Sprintf("%s%s/%s%d/%s%d", common.ProjectNamePrefix, project.ResourceID, common.RolloutPrefix, rollout.ID, common.StagePrefix, stage.ID),
-			Uid:         fmt.Sprintf("%d", stage.ID),
-			Environment: fmt.Sprintf("%s%s", common.EnvironmentNamePrefix, environment.ResourceID),
-			Title:       stage.Name,
+			Name:            fmt.Sprintf("%s%s/%s%d/%s%d", common.ProjectNamePrefix, project.ResourceID, common.RolloutPrefix, rollout.ID, common.StagePrefix, stage.ID),
+			Uid:             fmt.Sprintf("%d", stage.ID),
+			Environment:     fmt.Sprintf("%s%s", common.EnvironmentNamePrefix, environment.ResourceID),
+			Title:           stage.Name,
+			RolloutStrategy: convertToRolloutStrategy(stage.Payload.GetRolloutStrategy()),
 		}
 		for _, task := range stage.TaskList {
 			rolloutTask, err := convertToTask(ctx, s, project, task)
 			if err != nil {
 				return nil, status.Errorf(codes.Internal, "failed to convert task, error: %v", err)
 			}
+			if task.EarliestAllowedTs != 0 {
+				rolloutTask.EarliestAllowedTime = timestamppb.New(time.Unix(task.EarliestAllowedTs, 0))
+			}
 			taskIDToName[task.ID] = rolloutTask.Name
 			rolloutStage.Tasks = append(rolloutStage.Tasks, rolloutTask)
 		}
@@ -566,9 +712,9 @@ func convertToTask(ctx context.Context, s *store.Store, project *store.ProjectMe
 		return convertToTaskFromDatabaseCreate(ctx, s, project, task)
 	case api.TaskDatabaseSchemaBaseline:
 		return convertToTaskFromSchemaBaseline(ctx, s, project, task)
-	case api.TaskDatabaseSchemaUpdate, api.TaskDatabaseSchemaUpdateSDL, api.TaskDatabaseSchemaUpdateGhostSync:
+	case api.TaskDatabaseSchemaUpdate, api.TaskDatabaseSchemaUpdateSDL, api.TaskDatabaseSchemaUpdateGhostSync, api.TaskDatabaseSchemaUpdatePGOSCSync:
 		return convertToTaskFromSchemaUpdate(ctx, s, project, task)
-	case api.TaskDatabaseSchemaUpdateGhostCutover:
+	case api.TaskDatabaseSchemaUpdateGhostCutover, api.TaskDatabaseSchemaUpdatePGOSCCutover:
 		return convertToTaskFromSchemaUpdateGhostCutover(ctx, s, project, task)
 	case api.TaskDatabaseDataUpdate:
 		return convertToTaskFromDataUpdate(ctx, s, project, task)
@@ -1021,6 +1167,10 @@ func convertToTaskType(taskType api.TaskType) v1pb.Task_Type {
 		return v1pb.Task_DATABASE_SCHEMA_UPDATE_GHOST_SYNC
 	case api.TaskDatabaseSchemaUpdateGhostCutover:
 		return v1pb.Task_DATABASE_SCHEMA_UPDATE_GHOST_CUTOVER
+	case api.TaskDatabaseSchemaUpdatePGOSCSync:
+		return v1pb.Task_DATABASE_SCHEMA_UPDATE_PGOSC_SYNC
+	case api.TaskDatabaseSchemaUpdatePGOSCCutover:
+		return v1pb.Task_DATABASE_SCHEMA_UPDATE_PGOSC_CUTOVER
 	case api.TaskDatabaseDataUpdate:
 		return v1pb.Task_DATABASE_DATA_UPDATE
 	case api.TaskDatabaseBackup:
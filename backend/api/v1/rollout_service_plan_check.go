@@ -307,11 +307,40 @@ func getPlanCheckRunsFromChangeDatabaseConfigForDatabase(ctx context.Context, s
 			DatabaseGroupUid:   nil,
 		},
 	})
+	planCheckRuns = append(planCheckRuns, &store.PlanCheckRunMessage{
+		CreatorUID: api.SystemBotID,
+		UpdaterUID: api.SystemBotID,
+		PlanUID:    plan.UID,
+		Status:     store.PlanCheckRunStatusRunning,
+		Type:       store.PlanCheckDatabaseDeploymentWindow,
+		Config: &storepb.PlanCheckRunConfig{
+			SheetUid:           int32(sheetUID),
+			ChangeDatabaseType: storepb.PlanCheckRunConfig_CHANGE_DATABASE_TYPE_UNSPECIFIED,
+			InstanceUid:        int32(instance.UID),
+			DatabaseName:       database.DatabaseName,
+			DatabaseGroupUid:   nil,
+		},
+	})
 
 	if config.Type == storepb.PlanConfig_ChangeDatabaseConfig_BASELINE || config.Type == storepb.PlanConfig_ChangeDatabaseConfig_BRANCH {
 		return planCheckRuns, nil
 	}
 
+	planCheckRuns = append(planCheckRuns, &store.PlanCheckRunMessage{
+		CreatorUID: api.SystemBotID,
+		UpdaterUID: api.SystemBotID,
+		PlanUID:    plan.UID,
+		Status:     store.PlanCheckRunStatusRunning,
+		Type:       store.PlanCheckDatabaseProductionSafety,
+		Config: &storepb.PlanCheckRunConfig{
+			SheetUid:           int32(sheetUID),
+			ChangeDatabaseType: convertToChangeDatabaseType(config.Type),
+			InstanceUid:        int32(instance.UID),
+			DatabaseName:       database.DatabaseName,
+			DatabaseGroupUid:   nil,
+		},
+	})
+
 	planCheckRuns = append(planCheckRuns, &store.PlanCheckRunMessage{
 		CreatorUID: api.SystemBotID,
 		UpdaterUID: api.SystemBotID,
@@ -355,12 +384,16 @@ func getPlanCheckRunsFromChangeDatabaseConfigForDatabase(ctx context.Context, s
 		},
 	})
 	if databaseGroupUID == nil && config.Type == storepb.PlanConfig_ChangeDatabaseConfig_MIGRATE_GHOST {
+		planCheckType := store.PlanCheckDatabaseGhostSync
+		if instance.Engine == storepb.Engine_POSTGRES {
+			planCheckType = store.PlanCheckDatabasePGOSCSync
+		}
 		planCheckRuns = append(planCheckRuns, &store.PlanCheckRunMessage{
 			CreatorUID: api.SystemBotID,
 			UpdaterUID: api.SystemBotID,
 			PlanUID:    plan.UID,
 			Status:     store.PlanCheckRunStatusRunning,
-			Type:       store.PlanCheckDatabaseGhostSync,
+			Type:       planCheckType,
 			Config: &storepb.PlanCheckRunConfig{
 				SheetUid:           int32(sheetUID),
 				ChangeDatabaseType: convertToChangeDatabaseType(config.Type),
@@ -14,6 +14,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/ghost"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
 	enterprise "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
@@ -60,17 +61,31 @@ func transformDeploymentConfigTargetToSteps(ctx context.Context, s *store.Store,
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get database matrix from deployment schedule")
 	}
+	return getStepsFromDatabaseMatrix(spec, c, deploySchedule, matrix), nil
+}
 
+// getStepsFromDatabaseMatrix returns a step for each deployment with databases in the matrix.
+// The stage of a deployment runs at the deployment's time in the spec deployment_earliest_allowed_times, or the spec earliest_allowed_time otherwise.
+func getStepsFromDatabaseMatrix(spec *storepb.PlanConfig_Spec, c *storepb.PlanConfig_ChangeDatabaseConfig, deploySchedule *api.DeploymentSchedule, matrix [][]*store.DatabaseMessage) []*storepb.PlanConfig_Step {
 	var steps []*storepb.PlanConfig_Step
-	for _, databases := range matrix {
+	for i, databases := range matrix {
 		if len(databases) == 0 {
 			continue
 		}
 
-		step := &storepb.PlanConfig_Step{}
+		deployment := deploySchedule.Deployments[i]
+		earliestAllowedTime := spec.EarliestAllowedTime
+		if t, ok := spec.DeploymentEarliestAllowedTimes[deployment.Name]; ok {
+			earliestAllowedTime = t
+		}
+		step := &storepb.PlanConfig_Step{
+			RolloutStrategy:     deployment.Spec.Rollout,
+			EarliestAllowedTime: earliestAllowedTime,
+			Deployment:          deployment.Name,
+		}
 		for _, database := range databases {
 			step.Specs = append(step.Specs, &storepb.PlanConfig_Spec{
-				EarliestAllowedTime: spec.EarliestAllowedTime,
+				EarliestAllowedTime: earliestAllowedTime,
 				Id:                  spec.Id,
 				Config: &storepb.PlanConfig_Spec_ChangeDatabaseConfig{
 					ChangeDatabaseConfig: &storepb.PlanConfig_ChangeDatabaseConfig{
@@ -80,13 +95,15 @@ func transformDeploymentConfigTargetToSteps(ctx context.Context, s *store.Store,
 						SchemaVersion:   getOrDefaultSchemaVersion(c.SchemaVersion),
 						RollbackEnabled: c.RollbackEnabled,
 						RollbackDetail:  c.RollbackDetail,
+						BatchConfig:     c.BatchConfig,
+						PostDeployCheck: c.PostDeployCheck,
 					},
 				},
 			})
 		}
 		steps = append(steps, step)
 	}
-	return steps, nil
+	return steps
 }
 
 func getTaskCreatesFromSpec(ctx context.Context, s *store.Store, licenseService enterprise.LicenseService, dbFactory *dbfactory.DBFactory, spec *storepb.PlanConfig_Spec, project *store.ProjectMessage, registerEnvironmentID func(string) error) ([]*store.TaskMessage, []store.TaskIndexDAG, error) {
@@ -385,6 +402,9 @@ func getTaskCreatesFromChangeDatabaseConfigDatabaseTarget(ctx context.Context, s
 		return []*store.TaskMessage{taskCreate}, nil, nil
 
 	case storepb.PlanConfig_ChangeDatabaseConfig_MIGRATE_GHOST:
+		if instance.Engine == storepb.Engine_POSTGRES {
+			return getTaskCreatesForPGOSC(spec, c, instance, database)
+		}
 		_, sheetUID, err := common.GetProjectResourceIDSheetUID(c.Sheet)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "failed to get sheet id from sheet %q", c.Sheet)
@@ -450,6 +470,7 @@ func getTaskCreatesFromChangeDatabaseConfigDatabaseTarget(ctx context.Context, s
 			SchemaVersion:     getOrDefaultSchemaVersion(c.SchemaVersion),
 			RollbackEnabled:   c.RollbackEnabled,
 			RollbackSQLStatus: api.RollbackSQLStatusPending,
+			BatchConfig:       convertToDataUpdateBatchConfig(c.BatchConfig),
 		}
 		if c.RollbackDetail != nil {
 			issueID, err := common.GetIssueID(c.RollbackDetail.RollbackFromIssue)
@@ -483,6 +504,60 @@ func getTaskCreatesFromChangeDatabaseConfigDatabaseTarget(ctx context.Context, s
 	}
 }
 
+// getTaskCreatesForPGOSC gets the tasks of the online schema change for PostgreSQL.
+// The sync task copies the table to the shadow table kept in sync by the triggers, and the cutover task replaces the table with it.
+func getTaskCreatesForPGOSC(spec *storepb.PlanConfig_Spec, c *storepb.PlanConfig_ChangeDatabaseConfig, instance *store.InstanceMessage, database *store.DatabaseMessage) ([]*store.TaskMessage, []store.TaskIndexDAG, error) {
+	_, sheetUID, err := common.GetProjectResourceIDSheetUID(c.Sheet)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get sheet id from sheet %q", c.Sheet)
+	}
+	if _, err := pgosc.GetUserFlags(c.GhostFlags); err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid online schema change flags %q", c.GhostFlags)
+	}
+	payloadSync := api.TaskDatabaseSchemaUpdatePGOSCSyncPayload{
+		SpecID:        spec.Id,
+		SheetID:       sheetUID,
+		SchemaVersion: getOrDefaultSchemaVersion(c.SchemaVersion),
+		Flags:         c.GhostFlags,
+	}
+	bytesSync, err := json.Marshal(payloadSync)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to marshal database schema update online schema change sync payload")
+	}
+	payloadCutover := api.TaskDatabaseSchemaUpdatePGOSCCutoverPayload{
+		SpecID: spec.Id,
+	}
+	bytesCutover, err := json.Marshal(payloadCutover)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to marshal database schema update online schema change cutover payload")
+	}
+	taskCreateList := []*store.TaskMessage{
+		{
+			Name:              fmt.Sprintf("Update schema online sync for database %q", database.DatabaseName),
+			InstanceID:        instance.UID,
+			DatabaseID:        &database.UID,
+			Status:            api.TaskPendingApproval,
+			Type:              api.TaskDatabaseSchemaUpdatePGOSCSync,
+			EarliestAllowedTs: spec.EarliestAllowedTime.GetSeconds(),
+			Payload:           string(bytesSync),
+		},
+		{
+			Name:              fmt.Sprintf("Update schema online cutover for database %q", database.DatabaseName),
+			InstanceID:        instance.UID,
+			DatabaseID:        &database.UID,
+			Status:            api.TaskPendingApproval,
+			Type:              api.TaskDatabaseSchemaUpdatePGOSCCutover,
+			EarliestAllowedTs: spec.EarliestAllowedTime.GetSeconds(),
+			Payload:           string(bytesCutover),
+		},
+	}
+	// Task "sync" blocks task "cutover".
+	taskIndexDAGList := []store.TaskIndexDAG{
+		{FromIndex: 0, ToIndex: 1},
+	}
+	return taskCreateList, taskIndexDAGList, nil
+}
+
 func getTaskCreatesFromChangeDatabaseConfigDatabaseGroupTarget(ctx context.Context, s *store.Store, spec *storepb.PlanConfig_Spec, c *storepb.PlanConfig_ChangeDatabaseConfig, project *store.ProjectMessage, registerEnvironmentID func(string) error) ([]*store.TaskMessage, []store.TaskIndexDAG, error) {
 	switch c.Type {
 	case storepb.PlanConfig_ChangeDatabaseConfig_MIGRATE:
@@ -640,6 +715,7 @@ func getTaskCreatesFromChangeDatabaseConfigDatabaseGroupStatements(db *store.Dat
 				RollbackEnabled:   c.RollbackEnabled,
 				RollbackSQLStatus: api.RollbackSQLStatusPending,
 				SchemaGroupName:   schemaGroupName,
+				BatchConfig:       convertToDataUpdateBatchConfig(c.BatchConfig),
 			}
 
 			bytes, err := json.Marshal(payload)
@@ -1001,6 +1077,25 @@ func getCreateDatabaseStatement(dbType storepb.Engine, c *storepb.PlanConfig_Cre
 	return "", errors.Errorf("unsupported database type %s", dbType)
 }
 
+// defaultDataUpdateBatchSize is the number of rows in each batch of the batched data update if not specified.
+const defaultDataUpdateBatchSize = 1000
+
+// convertToDataUpdateBatchConfig converts the batch config of the plan spec to the task payload, filling in the default batch size.
+func convertToDataUpdateBatchConfig(c *storepb.PlanConfig_ChangeDatabaseConfig_BatchConfig) *api.DataUpdateBatchConfig {
+	if c == nil {
+		return nil
+	}
+	batchSize := c.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultDataUpdateBatchSize
+	}
+	return &api.DataUpdateBatchConfig{
+		BatchSize:       batchSize,
+		SleepIntervalMs: c.SleepInterval.AsDuration().Milliseconds(),
+		MaxReplicaLagMs: c.MaxReplicaLag.AsDuration().Milliseconds(),
+	}
+}
+
 func getOrDefaultSchemaVersion(v string) string {
 	if v != "" {
 		return v
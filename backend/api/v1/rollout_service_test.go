@@ -2,12 +2,16 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
 func TestGetStatementsFromSchemaGroups(t *testing.T) {
@@ -136,3 +140,137 @@ ALTER TABLE singleton ADD COLUMN num INT;`,
 		a.Equal(tc.expectedSchemaGroupNames, schemaGroupNames, tc.name)
 	}
 }
+
+func TestInheritStepEarliestAllowedTime(t *testing.T) {
+	a := require.New(t)
+
+	stepTime := timestamppb.New(time.Unix(1700000000, 0))
+	specTime := timestamppb.New(time.Unix(1800000000, 0))
+	steps := []*storepb.PlanConfig_Step{
+		{
+			EarliestAllowedTime: stepTime,
+			Specs: []*storepb.PlanConfig_Spec{
+				{Id: "a"},
+				{Id: "b", EarliestAllowedTime: specTime},
+			},
+		},
+		{
+			Specs: []*storepb.PlanConfig_Spec{
+				{Id: "c"},
+			},
+		},
+	}
+
+	got := inheritStepEarliestAllowedTime(steps)
+	a.Equal(int64(1700000000), got[0].Specs[0].EarliestAllowedTime.GetSeconds())
+	a.Equal(int64(1800000000), got[0].Specs[1].EarliestAllowedTime.GetSeconds())
+	a.Nil(got[1].Specs[0].EarliestAllowedTime)
+	// The input steps are not modified.
+	a.Nil(steps[0].Specs[0].EarliestAllowedTime)
+}
+
+func TestGetStepsFromDatabaseMatrix(t *testing.T) {
+	a := require.New(t)
+
+	specTime := timestamppb.New(time.Unix(1700000000, 0))
+	prodTime := timestamppb.New(time.Unix(1800000000, 0))
+	spec := &storepb.PlanConfig_Spec{
+		Id:                  "spec",
+		EarliestAllowedTime: specTime,
+		DeploymentEarliestAllowedTimes: map[string]*timestamppb.Timestamp{
+			"Prod Stage": prodTime,
+		},
+	}
+	c := &storepb.PlanConfig_ChangeDatabaseConfig{
+		Target: "projects/p1/deploymentConfigs/default",
+		Type:   storepb.PlanConfig_ChangeDatabaseConfig_MIGRATE,
+	}
+	deploySchedule := &api.DeploymentSchedule{
+		Deployments: []*api.Deployment{
+			{Name: "Test Stage", Spec: &api.DeploymentSpec{}},
+			{Name: "Staging Stage", Spec: &api.DeploymentSpec{}},
+			{Name: "Prod Stage", Spec: &api.DeploymentSpec{}},
+		},
+	}
+	matrix := [][]*store.DatabaseMessage{
+		{{InstanceID: "test", DatabaseName: "db"}},
+		{},
+		{{InstanceID: "prod1", DatabaseName: "db"}, {InstanceID: "prod2", DatabaseName: "db"}},
+	}
+
+	steps := getStepsFromDatabaseMatrix(spec, c, deploySchedule, matrix)
+	a.Len(steps, 2)
+	a.Equal("Test Stage", steps[0].Deployment)
+	a.Equal(int64(1700000000), steps[0].EarliestAllowedTime.GetSeconds())
+	a.Len(steps[0].Specs, 1)
+	a.Equal(int64(1700000000), steps[0].Specs[0].EarliestAllowedTime.GetSeconds())
+	a.Equal("Prod Stage", steps[1].Deployment)
+	a.Equal(int64(1800000000), steps[1].EarliestAllowedTime.GetSeconds())
+	a.Len(steps[1].Specs, 2)
+	for _, spec := range steps[1].Specs {
+		a.Equal(int64(1800000000), spec.EarliestAllowedTime.GetSeconds())
+		a.Equal("spec", spec.Id)
+	}
+	a.Equal("instances/prod2/databases/db", steps[1].Specs[1].GetChangeDatabaseConfig().Target)
+}
+
+func TestGetSpecEarliestAllowedTime(t *testing.T) {
+	a := require.New(t)
+
+	spec := &v1pb.Plan_Spec{
+		EarliestAllowedTime: timestamppb.New(time.Unix(1700000000, 0)),
+		DeploymentEarliestAllowedTimes: map[string]*timestamppb.Timestamp{
+			"Prod Stage": timestamppb.New(time.Unix(1800000000, 0)),
+		},
+	}
+	a.Equal(int64(1800000000), getSpecEarliestAllowedTime(spec, "Prod Stage").GetSeconds())
+	a.Equal(int64(1700000000), getSpecEarliestAllowedTime(spec, "Test Stage").GetSeconds())
+	a.Equal(int64(1700000000), getSpecEarliestAllowedTime(spec, "").GetSeconds())
+}
+
+func TestGetRollbackBackup(t *testing.T) {
+	changedTs := int64(1700000000)
+	rolloutBackup := &store.BackupMessage{UID: 1, Name: "rollout", CreatedTs: changedTs - 2*3600}
+	recentBackup := &store.BackupMessage{UID: 2, Name: "recent", CreatedTs: changedTs - 60}
+	staleBackup := &store.BackupMessage{UID: 3, Name: "stale", CreatedTs: changedTs - 2*3600}
+	laterBackup := &store.BackupMessage{UID: 4, Name: "later", CreatedTs: changedTs + 60}
+
+	tests := []struct {
+		description      string
+		backups          []*store.BackupMessage
+		rolloutBackupIDs map[int]bool
+		want             string
+	}{
+		{
+			description:      "the backup taken by the rollout is preferred",
+			backups:          []*store.BackupMessage{recentBackup, rolloutBackup},
+			rolloutBackupIDs: map[int]bool{1: true},
+			want:             "rollout",
+		},
+		{
+			description: "the recent backup",
+			backups:     []*store.BackupMessage{staleBackup, recentBackup},
+			want:        "recent",
+		},
+		{
+			description: "the stale backup isn't restored",
+			backups:     []*store.BackupMessage{staleBackup},
+		},
+		{
+			description:      "the backup taken after the change isn't restored",
+			backups:          []*store.BackupMessage{laterBackup},
+			rolloutBackupIDs: map[int]bool{4: true},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		backup := getRollbackBackup(test.backups, test.rolloutBackupIDs, changedTs)
+		if test.want == "" {
+			a.Nil(backup, test.description)
+			continue
+		}
+		a.NotNil(backup, test.description)
+		a.Equal(test.want, backup.Name, test.description)
+	}
+}
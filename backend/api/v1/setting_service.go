@@ -6,6 +6,7 @@ import (
 	"embed"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	"github.com/bytebase/bytebase/backend/api/auth"
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/state"
@@ -68,6 +70,7 @@ var whitelistSettings = []api.SettingName{
 	api.SettingDataClassification,
 	api.SettingSemanticTypes,
 	api.SettingMaskingAlgorithm,
+	api.SettingWorkloadIdentity,
 }
 
 var preservedMaskingAlgorithmIDMatcher = regexp.MustCompile("^[0]{8}-[0]{4}-[0]{4}-[0]{4}-[0]{9}[0-9a-fA-F]{3}$")
@@ -450,6 +453,26 @@ func (s *SettingService) SetSetting(ctx context.Context, request *v1pb.SetSettin
 			return nil, status.Errorf(codes.Internal, "failed to marshal setting for %s with error: %v", apiSettingName, err)
 		}
 		storeSettingValue = string(bytes)
+	case api.SettingWorkloadIdentity:
+		storeWorkloadIdentitySetting := new(storepb.WorkloadIdentitySetting)
+		if err := convertV1PbToStorePb(request.Setting.Value.GetWorkloadIdentitySettingValue(), storeWorkloadIdentitySetting); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to unmarshal setting value for %s with error: %v", apiSettingName, err)
+		}
+		idMap := make(map[string]struct{})
+		for _, provider := range storeWorkloadIdentitySetting.Providers {
+			if err := s.validateWorkloadIdentityProvider(ctx, provider); err != nil {
+				return nil, err
+			}
+			if _, ok := idMap[provider.Id]; ok {
+				return nil, status.Errorf(codes.InvalidArgument, "duplicate workload identity provider id: %s", provider.Id)
+			}
+			idMap[provider.Id] = struct{}{}
+		}
+		bytes, err := protojson.Marshal(storeWorkloadIdentitySetting)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal setting for %s with error: %v", apiSettingName, err)
+		}
+		storeSettingValue = string(bytes)
 	default:
 		storeSettingValue = request.Setting.Value.GetStringValue()
 	}
@@ -676,6 +699,19 @@ func (s *SettingService) convertToSettingMessage(ctx context.Context, setting *s
 				},
 			},
 		}, nil
+	case api.SettingWorkloadIdentity:
+		v1Value := new(v1pb.WorkloadIdentitySetting)
+		if err := protojson.Unmarshal([]byte(setting.Value), v1Value); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to unmarshal setting value for %s with error: %v", setting.Name, err)
+		}
+		return &v1pb.Setting{
+			Name: settingName,
+			Value: &v1pb.Value{
+				Value: &v1pb.Value_WorkloadIdentitySettingValue{
+					WorkloadIdentitySettingValue: v1Value,
+				},
+			},
+		}, nil
 
 	default:
 		return &v1pb.Setting{
@@ -1154,3 +1190,39 @@ func validateMaskingAlgorithm(algorithm *v1pb.MaskingAlgorithmSetting_Algorithm)
 
 	return nil
 }
+
+func (s *SettingService) validateWorkloadIdentityProvider(ctx context.Context, provider *storepb.WorkloadIdentitySetting_Provider) error {
+	if !isValidResourceID(provider.Id) {
+		return status.Errorf(codes.InvalidArgument, "invalid workload identity provider id format: %s", provider.Id)
+	}
+	if provider.Title == "" {
+		return status.Errorf(codes.InvalidArgument, "workload identity provider title cannot be empty: %s", provider.Id)
+	}
+	if u, err := url.Parse(provider.IssuerUrl); err != nil || u.Scheme != "https" || u.Host == "" {
+		return status.Errorf(codes.InvalidArgument, "invalid issuer url %q, must be an https url", provider.IssuerUrl)
+	}
+	if provider.JwksUrl != "" {
+		if u, err := url.Parse(provider.JwksUrl); err != nil || u.Scheme != "https" || u.Host == "" {
+			return status.Errorf(codes.InvalidArgument, "invalid jwks url %q, must be an https url", provider.JwksUrl)
+		}
+	}
+	if len(provider.Audiences) == 0 {
+		return status.Errorf(codes.InvalidArgument, "workload identity provider %s must accept at least one audience", provider.Id)
+	}
+	if err := auth.ValidateWorkloadIdentityCondition(provider.Condition); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid condition %q: %v", provider.Condition, err)
+	}
+	if provider.TokenDuration != nil {
+		if d := provider.TokenDuration.AsDuration(); d <= 0 || d > auth.MaxWorkloadIdentityTokenDuration {
+			return status.Errorf(codes.InvalidArgument, "token duration must be positive and at most %v", auth.MaxWorkloadIdentityTokenDuration)
+		}
+	}
+	user, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: &provider.ServiceAccount})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get user %q: %v", provider.ServiceAccount, err)
+	}
+	if user == nil || user.MemberDeleted || user.Type != api.ServiceAccount {
+		return status.Errorf(codes.InvalidArgument, "service account %q not found", provider.ServiceAccount)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestValidateWorkloadIdentityProviderCondition(t *testing.T) {
+	a := require.New(t)
+	s := &SettingService{}
+	for _, condition := range []string{
+		"",
+		`claims.ref == "refs/heads/main"`,
+		"claims.sub",
+	} {
+		provider := &storepb.WorkloadIdentitySetting_Provider{
+			Id:             "github",
+			Title:          "GitHub Actions",
+			IssuerUrl:      "https://token.actions.githubusercontent.com",
+			Audiences:      []string{"bytebase"},
+			Condition:      condition,
+			ServiceAccount: "ci@service.bytebase.com",
+		}
+		// The condition is rejected before looking up the service account.
+		err := s.validateWorkloadIdentityProvider(context.Background(), provider)
+		a.Error(err, condition)
+		a.Equal(codes.InvalidArgument, status.Code(err), condition)
+	}
+}
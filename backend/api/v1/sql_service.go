@@ -1689,11 +1689,26 @@ func convertAdviceList(list []advisor.Advice) []*v1pb.Advice {
 			Line:    int32(advice.Line),
 			Column:  int32(advice.Column),
 			Detail:  advice.Details,
+			Fix:     convertAdviceFix(advice.Fix),
 		})
 	}
 	return result
 }
 
+func convertAdviceFix(fix *advisor.Fix) *v1pb.Advice_Fix {
+	if fix == nil {
+		return nil
+	}
+	return &v1pb.Advice_Fix{
+		Description: fix.Description,
+		StartLine:   int32(fix.StartLine),
+		StartColumn: int32(fix.StartColumn),
+		EndLine:     int32(fix.EndLine),
+		EndColumn:   int32(fix.EndColumn),
+		NewText:     fix.NewText,
+	}
+}
+
 func convertAdviceStatus(status advisor.Status) v1pb.Advice_Status {
 	switch status {
 	case advisor.Success:
@@ -2538,7 +2553,7 @@ func (s *SQLService) getInstanceMessage(ctx context.Context, name string) (*stor
 // IsSQLReviewSupported checks the engine type if SQL review supports it.
 func IsSQLReviewSupported(dbType storepb.Engine) bool {
 	switch dbType {
-	case storepb.Engine_POSTGRES, storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_OCEANBASE, storepb.Engine_SNOWFLAKE, storepb.Engine_DM, storepb.Engine_MSSQL:
+	case storepb.Engine_POSTGRES, storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_OCEANBASE, storepb.Engine_SNOWFLAKE, storepb.Engine_DM, storepb.Engine_MSSQL, storepb.Engine_CLICKHOUSE, storepb.Engine_SPANNER:
 		return true
 	default:
 		return false
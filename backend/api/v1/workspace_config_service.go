@@ -0,0 +1,719 @@
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// WorkspaceConfigService implements the workspace config service.
+// The changes are applied through the other services, so they are validated the same way as the changes from the UI.
+type WorkspaceConfigService struct {
+	v1pb.UnimplementedWorkspaceConfigServiceServer
+	environmentService *EnvironmentService
+	instanceService    *InstanceService
+	projectService     *ProjectService
+	orgPolicyService   *OrgPolicyService
+	settingService     *SettingService
+}
+
+// NewWorkspaceConfigService creates a new WorkspaceConfigService.
+func NewWorkspaceConfigService(environmentService *EnvironmentService, instanceService *InstanceService, projectService *ProjectService, orgPolicyService *OrgPolicyService, settingService *SettingService) *WorkspaceConfigService {
+	return &WorkspaceConfigService{
+		environmentService: environmentService,
+		instanceService:    instanceService,
+		projectService:     projectService,
+		orgPolicyService:   orgPolicyService,
+		settingService:     settingService,
+	}
+}
+
+// PlanWorkspaceConfig computes the changes to sync the workspace to the configuration.
+func (s *WorkspaceConfigService) PlanWorkspaceConfig(ctx context.Context, request *v1pb.PlanWorkspaceConfigRequest) (*v1pb.PlanWorkspaceConfigResponse, error) {
+	changes, err := s.plan(ctx, request.Config)
+	if err != nil {
+		return nil, err
+	}
+	response := &v1pb.PlanWorkspaceConfigResponse{
+		Fingerprint: getConfigPlanFingerprint(request.Config, changes),
+	}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, change.change)
+	}
+	return response, nil
+}
+
+// ApplyWorkspaceConfig applies the changes to sync the workspace to the configuration.
+// The config is rejected if its plan no longer matches the fingerprint of PlanWorkspaceConfig,
+// or the plan deletes any resource without allow_deletes.
+func (s *WorkspaceConfigService) ApplyWorkspaceConfig(ctx context.Context, request *v1pb.ApplyWorkspaceConfigRequest) (*v1pb.ApplyWorkspaceConfigResponse, error) {
+	if request.Fingerprint == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "fingerprint is required, plan the workspace config first")
+	}
+	changes, err := s.plan(ctx, request.Config)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateConfigPlan(request, changes); err != nil {
+		return nil, err
+	}
+	response := &v1pb.ApplyWorkspaceConfigResponse{}
+	for _, change := range changes {
+		if err := change.apply(ctx); err != nil {
+			return nil, status.Errorf(status.Code(err), "failed to %s %q, error: %v", strings.ToLower(change.change.Action.String()), change.change.Resource, err)
+		}
+		response.Changes = append(response.Changes, change.change)
+	}
+	return response, nil
+}
+
+// getConfigPlanFingerprint returns the fingerprint of the config and its planned changes.
+func getConfigPlanFingerprint(content string, changes []*configChange) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d:%s\n", len(content), content)
+	for _, change := range changes {
+		_, _ = fmt.Fprintf(h, "%s %s %s\n", change.change.Action, change.change.Resource, strings.Join(change.change.Fields, ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// validateConfigPlan checks the planned changes against the fingerprint and allow_deletes of the apply request.
+func validateConfigPlan(request *v1pb.ApplyWorkspaceConfigRequest, changes []*configChange) error {
+	if getConfigPlanFingerprint(request.Config, changes) != request.Fingerprint {
+		return status.Errorf(codes.FailedPrecondition, "the workspace has changed since the plan or the config differs from the planned one, plan the workspace config again")
+	}
+	if request.AllowDeletes {
+		return nil
+	}
+	for _, change := range changes {
+		if change.change.Action == v1pb.WorkspaceConfigChange_DELETE {
+			return status.Errorf(codes.InvalidArgument, "the plan deletes %q, set allow_deletes to apply the deletes", change.change.Resource)
+		}
+	}
+	return nil
+}
+
+// workspaceConfig is the declarative workspace configuration.
+// A nil section is not managed, while an empty section deletes all the resources of the section.
+type workspaceConfig struct {
+	Environments []map[string]any `yaml:"environments"`
+	Instances    []map[string]any `yaml:"instances"`
+	Projects     []map[string]any `yaml:"projects"`
+	Policies     []map[string]any `yaml:"policies"`
+	Settings     []map[string]any `yaml:"settings"`
+}
+
+// configChange is a planned change with the function applying it.
+type configChange struct {
+	change *v1pb.WorkspaceConfigChange
+	apply  func(ctx context.Context) error
+}
+
+// configPlanner collects the changes of the configuration.
+// The deletes are applied after the other changes in the reverse order, e.g. the instances are deleted before the environments.
+type configPlanner struct {
+	changes []*configChange
+	deletes []*configChange
+}
+
+func (p *configPlanner) add(resource string, action v1pb.WorkspaceConfigChange_Action, fields []string, apply func(ctx context.Context) error) {
+	change := &configChange{
+		change: &v1pb.WorkspaceConfigChange{
+			Resource: resource,
+			Action:   action,
+			Fields:   fields,
+		},
+		apply: apply,
+	}
+	if action == v1pb.WorkspaceConfigChange_DELETE {
+		p.deletes = append(p.deletes, change)
+		return
+	}
+	p.changes = append(p.changes, change)
+}
+
+func (p *configPlanner) result() []*configChange {
+	changes := p.changes
+	for i := len(p.deletes) - 1; i >= 0; i-- {
+		changes = append(changes, p.deletes[i])
+	}
+	return changes
+}
+
+func (s *WorkspaceConfigService) plan(ctx context.Context, content string) ([]*configChange, error) {
+	config, err := parseWorkspaceConfig(content)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid workspace config, error: %v", err)
+	}
+	p := &configPlanner{}
+	if config.Environments != nil {
+		if err := s.planEnvironments(ctx, p, config.Environments); err != nil {
+			return nil, err
+		}
+	}
+	if config.Instances != nil {
+		if err := s.planInstances(ctx, p, config.Instances); err != nil {
+			return nil, err
+		}
+	}
+	if config.Projects != nil {
+		if err := s.planProjects(ctx, p, config.Projects); err != nil {
+			return nil, err
+		}
+	}
+	for _, entry := range config.Policies {
+		if err := s.planPolicy(ctx, p, entry); err != nil {
+			return nil, err
+		}
+	}
+	for _, entry := range config.Settings {
+		if err := s.planSetting(ctx, p, entry); err != nil {
+			return nil, err
+		}
+	}
+	return p.result(), nil
+}
+
+func parseWorkspaceConfig(content string) (*workspaceConfig, error) {
+	config := &workspaceConfig{}
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (s *WorkspaceConfigService) planEnvironments(ctx context.Context, p *configPlanner, entries []map[string]any) error {
+	resp, err := s.environmentService.ListEnvironments(ctx, &v1pb.ListEnvironmentsRequest{ShowDeleted: true})
+	if err != nil {
+		return err
+	}
+	current := make(map[string]*v1pb.Environment)
+	for _, environment := range resp.Environments {
+		current[environment.Name] = environment
+	}
+
+	desired := make(map[string]bool)
+	for _, entry := range entries {
+		environment := &v1pb.Environment{}
+		fields, err := unmarshalConfigEntry(entry, environment)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid environment %v, error: %v", entry["name"], err)
+		}
+		id, err := common.GetEnvironmentID(environment.Name)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid environment name %q, error: %v", environment.Name, err)
+		}
+		if desired[environment.Name] {
+			return status.Errorf(codes.InvalidArgument, "duplicate environment %q", environment.Name)
+		}
+		desired[environment.Name] = true
+
+		old, ok := current[environment.Name]
+		if !ok {
+			p.add(environment.Name, v1pb.WorkspaceConfigChange_CREATE, nil, func(ctx context.Context) error {
+				_, err := s.environmentService.CreateEnvironment(ctx, &v1pb.CreateEnvironmentRequest{Environment: environment, EnvironmentId: id})
+				return err
+			})
+			continue
+		}
+		paths, err := getUpdatePaths("environment", environment, old, fields, map[string]string{
+			"title": "title",
+			"order": "order",
+			"tier":  "tier",
+		})
+		if err != nil {
+			return err
+		}
+		update := func(ctx context.Context) error {
+			if len(paths) == 0 {
+				return nil
+			}
+			_, err := s.environmentService.UpdateEnvironment(ctx, &v1pb.UpdateEnvironmentRequest{Environment: environment, UpdateMask: &fieldmaskpb.FieldMask{Paths: paths}})
+			return err
+		}
+		if old.State == v1pb.State_DELETED {
+			p.add(environment.Name, v1pb.WorkspaceConfigChange_UNDELETE, paths, func(ctx context.Context) error {
+				if _, err := s.environmentService.UndeleteEnvironment(ctx, &v1pb.UndeleteEnvironmentRequest{Name: environment.Name}); err != nil {
+					return err
+				}
+				return update(ctx)
+			})
+		} else if len(paths) > 0 {
+			p.add(environment.Name, v1pb.WorkspaceConfigChange_UPDATE, paths, update)
+		}
+	}
+
+	for _, environment := range resp.Environments {
+		name := environment.Name
+		if desired[name] || environment.State == v1pb.State_DELETED {
+			continue
+		}
+		p.add(name, v1pb.WorkspaceConfigChange_DELETE, nil, func(ctx context.Context) error {
+			_, err := s.environmentService.DeleteEnvironment(ctx, &v1pb.DeleteEnvironmentRequest{Name: name})
+			return err
+		})
+	}
+	return nil
+}
+
+func (s *WorkspaceConfigService) planInstances(ctx context.Context, p *configPlanner, entries []map[string]any) error {
+	resp, err := s.instanceService.ListInstances(ctx, &v1pb.ListInstancesRequest{ShowDeleted: true})
+	if err != nil {
+		return err
+	}
+	current := make(map[string]*v1pb.Instance)
+	for _, instance := range resp.Instances {
+		current[instance.Name] = instance
+	}
+
+	desired := make(map[string]bool)
+	for _, entry := range entries {
+		instance := &v1pb.Instance{}
+		fields, err := unmarshalConfigEntry(entry, instance)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid instance %v, error: %v", entry["name"], err)
+		}
+		id, err := common.GetInstanceID(instance.Name)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid instance name %q, error: %v", instance.Name, err)
+		}
+		if desired[instance.Name] {
+			return status.Errorf(codes.InvalidArgument, "duplicate instance %q", instance.Name)
+		}
+		desired[instance.Name] = true
+
+		old, ok := current[instance.Name]
+		if !ok {
+			p.add(instance.Name, v1pb.WorkspaceConfigChange_CREATE, nil, func(ctx context.Context) error {
+				_, err := s.instanceService.CreateInstance(ctx, &v1pb.CreateInstanceRequest{Instance: instance, InstanceId: id})
+				return err
+			})
+			continue
+		}
+		// The data sources are synced one by one, since the passwords are not returned on reads.
+		var dataSourceEntries []any
+		if i := slices.IndexFunc(fields, func(fd protoreflect.FieldDescriptor) bool { return fd.Name() == "data_sources" }); i >= 0 {
+			fields = slices.Delete(fields, i, i+1)
+			dataSourceEntries, _ = getConfigEntryValue(entry, "dataSources", "data_sources").([]any)
+		}
+		paths, err := getUpdatePaths("instance", instance, old, fields, map[string]string{
+			"title":         "title",
+			"external_link": "external_link",
+			"activation":    "activation",
+		})
+		if err != nil {
+			return err
+		}
+		update := func(ctx context.Context) error {
+			if len(paths) == 0 {
+				return nil
+			}
+			_, err := s.instanceService.UpdateInstance(ctx, &v1pb.UpdateInstanceRequest{Instance: instance, UpdateMask: &fieldmaskpb.FieldMask{Paths: paths}})
+			return err
+		}
+		if old.State == v1pb.State_DELETED {
+			p.add(instance.Name, v1pb.WorkspaceConfigChange_UNDELETE, paths, func(ctx context.Context) error {
+				if _, err := s.instanceService.UndeleteInstance(ctx, &v1pb.UndeleteInstanceRequest{Name: instance.Name}); err != nil {
+					return err
+				}
+				return update(ctx)
+			})
+		} else if len(paths) > 0 {
+			p.add(instance.Name, v1pb.WorkspaceConfigChange_UPDATE, paths, update)
+		}
+		if dataSourceEntries != nil {
+			if err := s.planDataSources(p, instance, old, dataSourceEntries); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, instance := range resp.Instances {
+		name := instance.Name
+		if desired[name] || instance.State == v1pb.State_DELETED {
+			continue
+		}
+		p.add(name, v1pb.WorkspaceConfigChange_DELETE, nil, func(ctx context.Context) error {
+			_, err := s.instanceService.DeleteInstance(ctx, &v1pb.DeleteInstanceRequest{Name: name})
+			return err
+		})
+	}
+	return nil
+}
+
+// dataSourceUpdatePaths are the fields of the data source returned on reads and the update paths of them.
+// The other fields, e.g. the password and the SSL certificates, are only set when the data source is created.
+var dataSourceUpdatePaths = map[string]string{
+	"username":                "username",
+	"host":                    "host",
+	"port":                    "port",
+	"database":                "database",
+	"srv":                     "srv",
+	"authentication_database": "authentication_database",
+	"sid":                     "sid",
+	"service_name":            "service_name",
+}
+
+func (s *WorkspaceConfigService) planDataSources(p *configPlanner, instance, old *v1pb.Instance, entries []any) error {
+	current := make(map[string]*v1pb.DataSource)
+	for _, dataSource := range old.DataSources {
+		current[dataSource.Id] = dataSource
+	}
+	desired := make(map[string]bool)
+	for _, e := range entries {
+		entry, ok := e.(map[string]any)
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "invalid data source of instance %q", instance.Name)
+		}
+		dataSource := &v1pb.DataSource{}
+		fields, err := unmarshalConfigEntry(entry, dataSource)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid data source of instance %q, error: %v", instance.Name, err)
+		}
+		if dataSource.Id == "" {
+			return status.Errorf(codes.InvalidArgument, "data source id of instance %q is required", instance.Name)
+		}
+		desired[dataSource.Id] = true
+		resource := fmt.Sprintf("%s/dataSources/%s", instance.Name, dataSource.Id)
+
+		old, ok := current[dataSource.Id]
+		if !ok {
+			p.add(resource, v1pb.WorkspaceConfigChange_CREATE, nil, func(ctx context.Context) error {
+				_, err := s.instanceService.AddDataSource(ctx, &v1pb.AddDataSourceRequest{Instance: instance.Name, DataSource: dataSource})
+				return err
+			})
+			continue
+		}
+		var readableFields []protoreflect.FieldDescriptor
+		for _, fd := range fields {
+			if _, ok := dataSourceUpdatePaths[string(fd.Name())]; ok || fd.Name() == "id" || fd.Name() == "type" {
+				readableFields = append(readableFields, fd)
+			}
+		}
+		paths, err := getUpdatePaths("data source", dataSource, old, readableFields, dataSourceUpdatePaths)
+		if err != nil {
+			return err
+		}
+		if len(paths) > 0 {
+			p.add(resource, v1pb.WorkspaceConfigChange_UPDATE, paths, func(ctx context.Context) error {
+				_, err := s.instanceService.UpdateDataSource(ctx, &v1pb.UpdateDataSourceRequest{Instance: instance.Name, DataSource: dataSource, UpdateMask: &fieldmaskpb.FieldMask{Paths: paths}})
+				return err
+			})
+		}
+	}
+	for _, dataSource := range old.DataSources {
+		if desired[dataSource.Id] {
+			continue
+		}
+		dataSource := dataSource
+		p.add(fmt.Sprintf("%s/dataSources/%s", instance.Name, dataSource.Id), v1pb.WorkspaceConfigChange_DELETE, nil, func(ctx context.Context) error {
+			_, err := s.instanceService.RemoveDataSource(ctx, &v1pb.RemoveDataSourceRequest{Instance: instance.Name, DataSource: dataSource})
+			return err
+		})
+	}
+	return nil
+}
+
+func (s *WorkspaceConfigService) planProjects(ctx context.Context, p *configPlanner, entries []map[string]any) error {
+	resp, err := s.projectService.ListProjects(ctx, &v1pb.ListProjectsRequest{ShowDeleted: true})
+	if err != nil {
+		return err
+	}
+	current := make(map[string]*v1pb.Project)
+	for _, project := range resp.Projects {
+		current[project.Name] = project
+	}
+
+	desired := make(map[string]bool)
+	for _, entry := range entries {
+		// The iamPolicy is not a field of the project, it's set by SetIamPolicy.
+		var iamPolicy *v1pb.IamPolicy
+		if value, ok := entry["iamPolicy"]; ok {
+			iamPolicy = &v1pb.IamPolicy{}
+			if err := unmarshalConfigValue(value, iamPolicy); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid IAM policy of project %v, error: %v", entry["name"], err)
+			}
+			entry = copyConfigEntryWithout(entry, "iamPolicy")
+		}
+		project := &v1pb.Project{}
+		fields, err := unmarshalConfigEntry(entry, project)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid project %v, error: %v", entry["name"], err)
+		}
+		id, err := common.GetProjectID(project.Name)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid project name %q, error: %v", project.Name, err)
+		}
+		if id == api.DefaultProjectID {
+			return status.Errorf(codes.InvalidArgument, "the default project %q cannot be managed", project.Name)
+		}
+		if desired[project.Name] {
+			return status.Errorf(codes.InvalidArgument, "duplicate project %q", project.Name)
+		}
+		desired[project.Name] = true
+
+		old, ok := current[project.Name]
+		if !ok {
+			p.add(project.Name, v1pb.WorkspaceConfigChange_CREATE, nil, func(ctx context.Context) error {
+				_, err := s.projectService.CreateProject(ctx, &v1pb.CreateProjectRequest{Project: project, ProjectId: id})
+				return err
+			})
+		} else {
+			paths, err := getUpdatePaths("project", project, old, fields, map[string]string{
+				"title":                         "title",
+				"key":                           "key",
+				"workflow":                      "workflow",
+				"tenant_mode":                   "tenant_mode",
+				"schema_change":                 "schema_change",
+				"data_classification_config_id": "data_classification_config_id",
+			})
+			if err != nil {
+				return err
+			}
+			update := func(ctx context.Context) error {
+				if len(paths) == 0 {
+					return nil
+				}
+				_, err := s.projectService.UpdateProject(ctx, &v1pb.UpdateProjectRequest{Project: project, UpdateMask: &fieldmaskpb.FieldMask{Paths: paths}})
+				return err
+			}
+			if old.State == v1pb.State_DELETED {
+				p.add(project.Name, v1pb.WorkspaceConfigChange_UNDELETE, paths, func(ctx context.Context) error {
+					if _, err := s.projectService.UndeleteProject(ctx, &v1pb.UndeleteProjectRequest{Name: project.Name}); err != nil {
+						return err
+					}
+					return update(ctx)
+				})
+			} else if len(paths) > 0 {
+				p.add(project.Name, v1pb.WorkspaceConfigChange_UPDATE, paths, update)
+			}
+		}
+
+		if iamPolicy != nil {
+			changed := true
+			if ok {
+				oldPolicy, err := s.projectService.GetIamPolicy(ctx, &v1pb.GetIamPolicyRequest{Project: project.Name})
+				if err != nil {
+					return err
+				}
+				changed = !proto.Equal(normalizeIamPolicy(iamPolicy), normalizeIamPolicy(oldPolicy))
+			}
+			if changed {
+				p.add(fmt.Sprintf("%s/iamPolicy", project.Name), v1pb.WorkspaceConfigChange_UPDATE, []string{"bindings"}, func(ctx context.Context) error {
+					_, err := s.projectService.SetIamPolicy(ctx, &v1pb.SetIamPolicyRequest{Project: project.Name, Policy: iamPolicy})
+					return err
+				})
+			}
+		}
+	}
+
+	for _, project := range resp.Projects {
+		name := project.Name
+		if desired[name] || project.State == v1pb.State_DELETED || name == fmt.Sprintf("%s%s", common.ProjectNamePrefix, api.DefaultProjectID) {
+			continue
+		}
+		p.add(name, v1pb.WorkspaceConfigChange_DELETE, nil, func(ctx context.Context) error {
+			_, err := s.projectService.DeleteProject(ctx, &v1pb.DeleteProjectRequest{Name: name})
+			return err
+		})
+	}
+	return nil
+}
+
+func (s *WorkspaceConfigService) planPolicy(ctx context.Context, p *configPlanner, entry map[string]any) error {
+	policy := &v1pb.Policy{}
+	fields, err := unmarshalConfigEntry(entry, policy)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid policy %v, error: %v", entry["name"], err)
+	}
+	if !strings.Contains(policy.Name, common.PolicyNamePrefix) {
+		return status.Errorf(codes.InvalidArgument, "invalid policy name %q", policy.Name)
+	}
+
+	old, err := s.orgPolicyService.GetPolicy(ctx, &v1pb.GetPolicyRequest{Name: policy.Name})
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			return err
+		}
+		p.add(policy.Name, v1pb.WorkspaceConfigChange_CREATE, nil, func(ctx context.Context) error {
+			_, err := s.orgPolicyService.UpdatePolicy(ctx, &v1pb.UpdatePolicyRequest{Policy: policy, AllowMissing: true})
+			return err
+		})
+		return nil
+	}
+	updatePaths := map[string]string{
+		"inherit_from_parent": "inherit_from_parent",
+		"enforce":             "enforce",
+	}
+	// The policy payload is one of the fields of the policy oneof.
+	oneof := policy.ProtoReflect().Descriptor().Oneofs().ByName("policy")
+	for i := 0; i < oneof.Fields().Len(); i++ {
+		updatePaths[string(oneof.Fields().Get(i).Name())] = "payload"
+	}
+	paths, err := getUpdatePaths("policy", policy, old, fields, updatePaths)
+	if err != nil {
+		return err
+	}
+	if len(paths) > 0 {
+		p.add(policy.Name, v1pb.WorkspaceConfigChange_UPDATE, paths, func(ctx context.Context) error {
+			_, err := s.orgPolicyService.UpdatePolicy(ctx, &v1pb.UpdatePolicyRequest{Policy: policy, UpdateMask: &fieldmaskpb.FieldMask{Paths: paths}})
+			return err
+		})
+	}
+	return nil
+}
+
+func (s *WorkspaceConfigService) planSetting(ctx context.Context, p *configPlanner, entry map[string]any) error {
+	setting := &v1pb.Setting{}
+	fields, err := unmarshalConfigEntry(entry, setting)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid setting %v, error: %v", entry["name"], err)
+	}
+	if _, err := common.GetSettingName(setting.Name); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid setting name %q, error: %v", setting.Name, err)
+	}
+
+	action := v1pb.WorkspaceConfigChange_UPDATE
+	var paths []string
+	old, err := s.settingService.GetSetting(ctx, &v1pb.GetSettingRequest{Name: setting.Name})
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			return err
+		}
+		action = v1pb.WorkspaceConfigChange_CREATE
+	} else {
+		paths, err = getUpdatePaths("setting", setting, old, fields, map[string]string{"value": "value"})
+		if err != nil {
+			return err
+		}
+		if len(paths) == 0 {
+			return nil
+		}
+	}
+	p.add(setting.Name, action, paths, func(ctx context.Context) error {
+		_, err := s.settingService.SetSetting(ctx, &v1pb.SetSettingRequest{Setting: setting})
+		return err
+	})
+	return nil
+}
+
+// unmarshalConfigEntry converts the entry of the configuration to the message in the JSON form of the v1 API,
+// and returns the fields specified in the entry in the order of the field numbers.
+func unmarshalConfigEntry(entry map[string]any, message proto.Message) ([]protoreflect.FieldDescriptor, error) {
+	if err := unmarshalConfigValue(entry, message); err != nil {
+		return nil, err
+	}
+	descriptors := message.ProtoReflect().Descriptor().Fields()
+	var fields []protoreflect.FieldDescriptor
+	for key := range entry {
+		fd := descriptors.ByJSONName(key)
+		if fd == nil {
+			fd = descriptors.ByTextName(key)
+		}
+		if fd == nil {
+			return nil, errors.Errorf("unknown field %q", key)
+		}
+		fields = append(fields, fd)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Number() < fields[j].Number()
+	})
+	return fields, nil
+}
+
+func unmarshalConfigValue(value any, message proto.Message) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(b, message)
+}
+
+// getConfigEntryValue returns the value of the field by the JSON name or the proto name.
+func getConfigEntryValue(entry map[string]any, jsonName, name string) any {
+	if value, ok := entry[jsonName]; ok {
+		return value
+	}
+	return entry[name]
+}
+
+func copyConfigEntryWithout(entry map[string]any, key string) map[string]any {
+	result := make(map[string]any)
+	for k, v := range entry {
+		if k != key {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// getUpdatePaths returns the update paths of the specified fields which differ from the current resource.
+// The name is the identifier and the fields not in updatePaths must be the same as the current resource.
+func getUpdatePaths(resourceType string, desired, current proto.Message, fields []protoreflect.FieldDescriptor, updatePaths map[string]string) ([]string, error) {
+	var paths []string
+	for _, fd := range fields {
+		if fd.Name() == "name" || isConfigFieldEqual(desired.ProtoReflect(), current.ProtoReflect(), fd) {
+			continue
+		}
+		path, ok := updatePaths[string(fd.Name())]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "field %q of %s cannot be updated", fd.JSONName(), resourceType)
+		}
+		if !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func isConfigFieldEqual(a, b protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	x, y := a.New(), b.New()
+	if a.Has(fd) {
+		x.Set(fd, a.Get(fd))
+	}
+	if b.Has(fd) {
+		y.Set(fd, b.Get(fd))
+	}
+	return proto.Equal(x.Interface(), y.Interface())
+}
+
+// normalizeIamPolicy returns the IAM policy with the sorted bindings and members for comparison.
+func normalizeIamPolicy(policy *v1pb.IamPolicy) *v1pb.IamPolicy {
+	result := &v1pb.IamPolicy{}
+	for _, binding := range policy.Bindings {
+		members := slices.Clone(binding.Members)
+		slices.Sort(members)
+		b := &v1pb.Binding{Role: binding.Role, Members: members}
+		if binding.Condition.GetExpression() != "" || binding.Condition.GetTitle() != "" || binding.Condition.GetDescription() != "" {
+			b.Condition = binding.Condition
+		}
+		result.Bindings = append(result.Bindings, b)
+	}
+	key := func(b *v1pb.Binding) string {
+		return fmt.Sprintf("%s\x00%s\x00%s", b.Role, b.Condition.GetExpression(), strings.Join(b.Members, ","))
+	}
+	sort.Slice(result.Bindings, func(i, j int) bool {
+		return key(result.Bindings[i]) < key(result.Bindings[j])
+	})
+	return result
+}
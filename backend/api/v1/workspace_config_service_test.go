@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestParseWorkspaceConfig(t *testing.T) {
+	a := require.New(t)
+	config, err := parseWorkspaceConfig(`
+environments:
+  - name: environments/prod
+    title: Prod
+    tier: PROTECTED
+projects: []
+`)
+	a.NoError(err)
+	a.Len(config.Environments, 1)
+	a.NotNil(config.Projects)
+	a.Empty(config.Projects)
+	a.Nil(config.Instances)
+
+	_, err = parseWorkspaceConfig("users: []\n")
+	a.Error(err)
+}
+
+func TestGetUpdatePaths(t *testing.T) {
+	a := require.New(t)
+	config, err := parseWorkspaceConfig(`
+environments:
+  - name: environments/prod
+    title: Production
+    tier: PROTECTED
+  - name: environments/test
+    uid: "100"
+`)
+	a.NoError(err)
+	current := &v1pb.Environment{Name: "environments/prod", Uid: "101", Title: "Prod", Order: 1, Tier: v1pb.EnvironmentTier_PROTECTED}
+	updatePaths := map[string]string{"title": "title", "order": "order", "tier": "tier"}
+
+	environment := &v1pb.Environment{}
+	fields, err := unmarshalConfigEntry(config.Environments[0], environment)
+	a.NoError(err)
+	paths, err := getUpdatePaths("environment", environment, current, fields, updatePaths)
+	a.NoError(err)
+	// The order isn't specified so it's not managed.
+	a.Equal([]string{"title"}, paths)
+
+	environment = &v1pb.Environment{}
+	fields, err = unmarshalConfigEntry(config.Environments[1], environment)
+	a.NoError(err)
+	_, err = getUpdatePaths("environment", environment, current, fields, updatePaths)
+	a.ErrorContains(err, `field "uid" of environment cannot be updated`)
+
+	_, err = unmarshalConfigEntry(map[string]any{"name": "environments/prod", "color": "red"}, &v1pb.Environment{})
+	a.Error(err)
+}
+
+func TestNormalizeIamPolicy(t *testing.T) {
+	a := require.New(t)
+	desired := &v1pb.IamPolicy{
+		Bindings: []*v1pb.Binding{
+			{Role: "roles/DEVELOPER", Members: []string{"user:b@example.com", "user:a@example.com"}},
+			{Role: "roles/OWNER", Members: []string{"user:a@example.com"}},
+		},
+	}
+	current := &v1pb.IamPolicy{
+		Bindings: []*v1pb.Binding{
+			{Role: "roles/OWNER", Members: []string{"user:a@example.com"}},
+			{Role: "roles/DEVELOPER", Members: []string{"user:a@example.com", "user:b@example.com"}},
+		},
+	}
+	a.True(proto.Equal(normalizeIamPolicy(desired), normalizeIamPolicy(current)))
+
+	current.Bindings[1].Members = []string{"user:a@example.com"}
+	a.False(proto.Equal(normalizeIamPolicy(desired), normalizeIamPolicy(current)))
+}
+
+func TestConfigPlannerOrder(t *testing.T) {
+	a := require.New(t)
+	p := &configPlanner{}
+	noop := func(context.Context) error { return nil }
+	p.add("environments/staging", v1pb.WorkspaceConfigChange_DELETE, nil, noop)
+	p.add("environments/prod", v1pb.WorkspaceConfigChange_CREATE, nil, noop)
+	p.add("instances/mysql", v1pb.WorkspaceConfigChange_DELETE, nil, noop)
+	p.add("projects/hr", v1pb.WorkspaceConfigChange_UPDATE, []string{"title"}, noop)
+
+	var resources []string
+	for _, change := range p.result() {
+		resources = append(resources, change.change.Resource)
+	}
+	a.Equal([]string{"environments/prod", "projects/hr", "instances/mysql", "environments/staging"}, resources)
+}
+
+func TestValidateConfigPlan(t *testing.T) {
+	a := require.New(t)
+	noop := func(context.Context) error { return nil }
+	p := &configPlanner{}
+	p.add("environments/prod", v1pb.WorkspaceConfigChange_UPDATE, []string{"title"}, noop)
+	changes := p.result()
+	config := "environments:\n  - name: environments/prod\n    title: Prod\n"
+	fingerprint := getConfigPlanFingerprint(config, changes)
+	a.NoError(validateConfigPlan(&v1pb.ApplyWorkspaceConfigRequest{Config: config, Fingerprint: fingerprint}, changes))
+
+	// The config differs from the planned one.
+	err := validateConfigPlan(&v1pb.ApplyWorkspaceConfigRequest{Config: config + "instances: []\n", Fingerprint: fingerprint}, changes)
+	a.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// The workspace has changed since the plan.
+	p.add("instances/mysql", v1pb.WorkspaceConfigChange_DELETE, nil, noop)
+	changes = p.result()
+	err = validateConfigPlan(&v1pb.ApplyWorkspaceConfigRequest{Config: config, Fingerprint: fingerprint}, changes)
+	a.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// The deletes require allow_deletes.
+	fingerprint = getConfigPlanFingerprint(config, changes)
+	err = validateConfigPlan(&v1pb.ApplyWorkspaceConfigRequest{Config: config, Fingerprint: fingerprint}, changes)
+	a.Equal(codes.InvalidArgument, status.Code(err))
+	a.NoError(validateConfigPlan(&v1pb.ApplyWorkspaceConfigRequest{Config: config, Fingerprint: fingerprint, AllowDeletes: true}, changes))
+}
@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const (
+	// The exit codes of bb apply, the failure of running the command exits with 1.
+	applyExitCodeRejected   = 4
+	applyExitCodeTaskFailed = 5
+)
+
+// maxLogStatementLength is the maximum length of the statement printed in the task run logs.
+const maxLogStatementLength = 80
+
+const applyUsage = `Apply a plan on the Bytebase server.
+
+The issue and the rollout of the plan are created if not exist, then the stages are rolled out in order
+after the issue is approved. The task runs are printed as they progress, and the logs of the running task runs are streamed.
+The progress is printed to stderr and the rollout is printed to stdout in the json format.
+
+Exit codes:
+  0  the rollout is done
+  1  failed to apply the plan
+  4  the issue is rejected
+  5  a task failed or is canceled
+
+Examples:
+  bb apply --url https://bytebase.example.com --plan projects/hr/plans/101
+  bb apply --plan projects/hr/plans/101 --timeout 1h --format json
+`
+
+func newApplyCmd() *cobra.Command {
+	var (
+		server  serverFlags
+		plan    string
+		format  string
+		timeout time.Duration
+	)
+	applyCmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Applies a plan on a Bytebase server.",
+		Long:  applyUsage,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := validateOutputFormat(format); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			client, err := newAPIClient(ctx, &server)
+			if err != nil {
+				return err
+			}
+
+			progress := cmd.OutOrStdout()
+			if format == outputFormatJSON {
+				progress = cmd.ErrOrStderr()
+			}
+			a := &applier{client: client, progress: progress, taskRunStates: make(map[string]string), tailedTaskRuns: make(map[string]bool)}
+			rollout, err := a.apply(ctx, plan)
+			if err != nil {
+				var exitErr *ExitError
+				if errors.As(err, &exitErr) {
+					cmd.SilenceUsage = true
+				}
+				return err
+			}
+			if format == outputFormatJSON {
+				return writeMessage(cmd.OutOrStdout(), rollout)
+			}
+			return nil
+		},
+	}
+
+	server.register(applyCmd)
+	applyCmd.Flags().StringVar(&plan, "plan", "", "Plan to apply, e.g. projects/hr/plans/101.")
+	applyCmd.Flags().StringVar(&format, "format", outputFormatText, "Output format, text or json.")
+	applyCmd.Flags().DurationVar(&timeout, "timeout", 0, "Timeout to wait for the approval and the rollout, e.g. 1h. No timeout if unspecified.")
+	_ = applyCmd.MarkFlagRequired("plan")
+	return applyCmd
+}
+
+// applier applies a plan and prints the progress.
+type applier struct {
+	client   *apiClient
+	progress io.Writer
+	// taskRunStates is the map from the task run name to the last printed state.
+	taskRunStates map[string]string
+	// tailedTaskRuns are the names of the task runs whose logs are tailed.
+	tailedTaskRuns map[string]bool
+	tails          sync.WaitGroup
+	// mu guards the progress printed by the log tails.
+	mu sync.Mutex
+}
+
+func (a *applier) apply(ctx context.Context, planName string) (*v1pb.Rollout, error) {
+	plan, err := a.client.getPlan(ctx, planName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get plan %s", planName)
+	}
+	project, err := getProjectName(plan.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	issueName := plan.Issue
+	if issueName == "" {
+		issue, err := a.client.createIssue(ctx, project, &v1pb.Issue{
+			Type:        v1pb.Issue_DATABASE_CHANGE,
+			Title:       plan.Title,
+			Description: plan.Description,
+			Plan:        plan.Name,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create issue for plan %s", plan.Name)
+		}
+		issueName = issue.Name
+		a.printf("Created issue %s\n", issueName)
+	}
+	issue, err := a.client.getIssue(ctx, issueName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get issue %s", issueName)
+	}
+	rolloutName := issue.Rollout
+	if rolloutName == "" {
+		rollout, err := a.client.createRollout(ctx, project, plan.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create rollout for plan %s", plan.Name)
+		}
+		rolloutName = rollout.Name
+		a.printf("Created rollout %s\n", rolloutName)
+	}
+
+	if err := a.waitApproval(ctx, issueName); err != nil {
+		return nil, err
+	}
+
+	rollout, err := a.client.getRollout(ctx, rolloutName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get rollout %s", rolloutName)
+	}
+	for _, stage := range rollout.Stages {
+		if err := a.rolloutStage(ctx, rolloutName, stage.Name); err != nil {
+			return nil, err
+		}
+	}
+	rollout, err = a.client.getRollout(ctx, rolloutName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get rollout %s", rolloutName)
+	}
+	a.printf("Rollout %s is done\n", rolloutName)
+	return rollout, nil
+}
+
+// waitApproval waits for the issue to be approved, the issue without approval template is approved once the approval finding is done.
+func (a *applier) waitApproval(ctx context.Context, issueName string) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	waiting := false
+	for {
+		issue, err := a.client.getIssue(ctx, issueName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get issue %s", issueName)
+		}
+		if issue.ApprovalFindingError != "" {
+			return errors.Errorf("failed to find the approval template for issue %s: %s", issueName, issue.ApprovalFindingError)
+		}
+		if issue.ApprovalFindingDone {
+			approved := 0
+			for _, approver := range issue.Approvers {
+				switch approver.Status {
+				case v1pb.Issue_Approver_REJECTED:
+					return &ExitError{Code: applyExitCodeRejected, Err: errors.Errorf("issue %s is rejected by %s", issueName, approver.Principal)}
+				case v1pb.Issue_Approver_APPROVED:
+					approved++
+				}
+			}
+			steps := 0
+			if len(issue.ApprovalTemplates) > 0 && issue.ApprovalTemplates[0].Flow != nil {
+				steps = len(issue.ApprovalTemplates[0].Flow.Steps)
+			}
+			if approved >= steps {
+				if waiting {
+					a.printf("Issue %s is approved\n", issueName)
+				}
+				return nil
+			}
+		}
+		if !waiting {
+			a.printf("Waiting for the approval of issue %s\n", issueName)
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "failed to wait for the approval of issue %s", issueName)
+		case <-ticker.C:
+		}
+	}
+}
+
+// rolloutStage runs the tasks not started in the stage and waits for all tasks to finish.
+func (a *applier) rolloutStage(ctx context.Context, rolloutName, stageName string) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	started := false
+	for {
+		rollout, err := a.client.getRollout(ctx, rolloutName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get rollout %s", rolloutName)
+		}
+		stage := findStage(rollout, stageName)
+		if stage == nil {
+			return errors.Errorf("stage %s not found in rollout %s", stageName, rolloutName)
+		}
+		if !started {
+			a.printf("Rolling out stage %s\n", stage.Title)
+			started = true
+		}
+
+		var runTasks []string
+		completed := true
+		for _, task := range stage.Tasks {
+			if err := a.printTaskRuns(ctx, task); err != nil {
+				return err
+			}
+			switch task.Status {
+			case v1pb.Task_DONE, v1pb.Task_SKIPPED:
+			case v1pb.Task_FAILED, v1pb.Task_CANCELED:
+				// Print the remaining logs of the task runs, e.g. the error of the failed statement.
+				a.tails.Wait()
+				return &ExitError{Code: applyExitCodeTaskFailed, Err: errors.Errorf("task %s is %s", task.Title, strings.ToLower(task.Status.String()))}
+			case v1pb.Task_NOT_STARTED:
+				runTasks = append(runTasks, task.Name)
+				completed = false
+			default:
+				completed = false
+			}
+		}
+		if completed {
+			// Print the remaining logs of the finished task runs.
+			a.tails.Wait()
+			return nil
+		}
+		if len(runTasks) > 0 {
+			if err := a.client.batchRunTasks(ctx, stage.Name, runTasks); err != nil {
+				return errors.Wrapf(err, "failed to run tasks in stage %s", stage.Title)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "failed to wait for stage %s", stage.Title)
+		case <-ticker.C:
+		}
+	}
+}
+
+// printTaskRuns prints the task runs of the task whose state changed since the last print.
+func (a *applier) printTaskRuns(ctx context.Context, task *v1pb.Task) error {
+	if task.Status == v1pb.Task_NOT_STARTED || task.Status == v1pb.Task_SKIPPED {
+		return nil
+	}
+	taskRuns, err := a.client.listTaskRuns(ctx, task.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list task runs for task %s", task.Title)
+	}
+	// The task runs are listed in the creation order.
+	for _, taskRun := range taskRuns {
+		state := taskRunState(taskRun)
+		if a.taskRunStates[taskRun.Name] == state {
+			continue
+		}
+		a.taskRunStates[taskRun.Name] = state
+		a.printf("[%s] %s %s\n", time.Now().Format(time.TimeOnly), task.Title, state)
+		// The task run finished between the polls is tailed too, which prints its logs and returns.
+		if taskRun.Status != v1pb.TaskRun_PENDING && !a.tailedTaskRuns[taskRun.Name] {
+			a.tailedTaskRuns[taskRun.Name] = true
+			a.tails.Add(1)
+			go a.tailTaskRun(ctx, task.Title, taskRun.Name)
+		}
+	}
+	return nil
+}
+
+// tailTaskRun prints the log entries of the task run until it finishes.
+// The broken stream is resumed after the last printed entry.
+func (a *applier) tailTaskRun(ctx context.Context, taskTitle, taskRunName string) {
+	defer a.tails.Done()
+	const maxAttempts = 3
+	lastUID := ""
+	for attempt := 1; ; attempt++ {
+		err := a.client.tailTaskRunLogs(ctx, taskRunName, lastUID, func(entry *v1pb.TaskRunLogEntry) {
+			lastUID = entry.Uid
+			a.printf("[%s] %s %s\n", entry.LogTime.AsTime().Local().Format(time.TimeOnly), taskTitle, formatTaskRunLogEntry(entry))
+		})
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		if attempt == maxAttempts {
+			a.printf("[%s] %s failed to tail logs: %v\n", time.Now().Format(time.TimeOnly), taskTitle, err)
+			return
+		}
+	}
+}
+
+// formatTaskRunLogEntry returns the printed log entry, e.g. "statement 3 done in 1.2s, 10 rows affected".
+func formatTaskRunLogEntry(entry *v1pb.TaskRunLogEntry) string {
+	statement := fmt.Sprintf("statement %d", entry.StatementIndex+1)
+	switch entry.Type {
+	case v1pb.TaskRunLogEntry_STATEMENT_START:
+		text := strings.Join(strings.Fields(entry.Statement), " ")
+		if len(text) > maxLogStatementLength {
+			text = text[:maxLogStatementLength] + "..."
+		}
+		return fmt.Sprintf("%s started: %s", statement, text)
+	case v1pb.TaskRunLogEntry_STATEMENT_END:
+		if entry.Error != "" {
+			return fmt.Sprintf("%s failed in %s: %s", statement, entry.Duration.AsDuration(), entry.Error)
+		}
+		return fmt.Sprintf("%s done in %s, %d rows affected", statement, entry.Duration.AsDuration(), entry.AffectedRows)
+	case v1pb.TaskRunLogEntry_DATABASE_NOTICE:
+		return fmt.Sprintf("%s notice: %s", statement, entry.Message)
+	default:
+		return entry.Message
+	}
+}
+
+// taskRunState returns the printed state of the task run, e.g. "RUNNING EXECUTING 3/10".
+func taskRunState(taskRun *v1pb.TaskRun) string {
+	state := taskRun.Status.String()
+	switch taskRun.Status {
+	case v1pb.TaskRun_RUNNING:
+		if taskRun.ExecutionStatus != v1pb.TaskRun_EXECUTION_STATUS_UNSPECIFIED {
+			state = fmt.Sprintf("%s %s", state, taskRun.ExecutionStatus.String())
+		}
+		if detail := taskRun.ExecutionDetail; detail != nil && detail.CommandsTotal > 0 {
+			state = fmt.Sprintf("%s %d/%d", state, detail.CommandsCompleted, detail.CommandsTotal)
+		}
+	case v1pb.TaskRun_DONE, v1pb.TaskRun_FAILED, v1pb.TaskRun_CANCELED:
+		if taskRun.Detail != "" {
+			state = fmt.Sprintf("%s: %s", state, taskRun.Detail)
+		}
+	}
+	return state
+}
+
+func (a *applier) printf(format string, args ...any) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, _ = fmt.Fprintf(a.progress, format, args...)
+}
+
+func findStage(rollout *v1pb.Rollout, stageName string) *v1pb.Stage {
+	for _, stage := range rollout.Stages {
+		if stage.Name == stageName {
+			return stage
+		}
+	}
+	return nil
+}
+
+// getProjectName returns the project name, e.g. projects/hr for projects/hr/plans/101.
+func getProjectName(name string) (string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) < 2 || parts[0] != "projects" {
+		return "", errors.Errorf("invalid resource name %q", name)
+	}
+	return strings.Join(parts[:2], "/"), nil
+}
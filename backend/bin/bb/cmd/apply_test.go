@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// newTestClient returns the client of a server responding the messages by the request paths, and the other requests with 404.
+func newTestClient(t *testing.T, responses map[string]proto.Message, logs map[string]string) *apiClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if log, ok := logs[r.URL.Path]; ok {
+			_, _ = w.Write([]byte(log))
+			return
+		}
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		content, err := protojson.Marshal(response)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return &apiClient{url: server.URL, client: server.Client()}
+}
+
+func TestWaitApproval(t *testing.T) {
+	const issueName = "projects/hr/issues/1"
+	oneStepTemplate := []*v1pb.ApprovalTemplate{{Flow: &v1pb.ApprovalFlow{Steps: []*v1pb.ApprovalStep{{}}}}}
+	tests := []struct {
+		description string
+		issue       *v1pb.Issue
+		timeout     time.Duration
+		wantErr     bool
+		exitCode    int
+	}{
+		{
+			description: "no approval template",
+			issue:       &v1pb.Issue{Name: issueName, ApprovalFindingDone: true},
+		},
+		{
+			description: "approved",
+			issue: &v1pb.Issue{
+				Name:                issueName,
+				ApprovalFindingDone: true,
+				ApprovalTemplates:   oneStepTemplate,
+				Approvers:           []*v1pb.Issue_Approver{{Status: v1pb.Issue_Approver_APPROVED, Principal: "users/dba@example.com"}},
+			},
+		},
+		{
+			description: "rejected",
+			issue: &v1pb.Issue{
+				Name:                issueName,
+				ApprovalFindingDone: true,
+				ApprovalTemplates:   oneStepTemplate,
+				Approvers:           []*v1pb.Issue_Approver{{Status: v1pb.Issue_Approver_REJECTED, Principal: "users/dba@example.com"}},
+			},
+			wantErr:  true,
+			exitCode: applyExitCodeRejected,
+		},
+		{
+			description: "approval finding error",
+			issue:       &v1pb.Issue{Name: issueName, ApprovalFindingError: "no matched template"},
+			wantErr:     true,
+			exitCode:    1,
+		},
+		{
+			description: "timeout waiting for the approval",
+			issue: &v1pb.Issue{
+				Name:                issueName,
+				ApprovalFindingDone: true,
+				ApprovalTemplates:   oneStepTemplate,
+			},
+			timeout:  100 * time.Millisecond,
+			wantErr:  true,
+			exitCode: 1,
+		},
+	}
+
+	for _, test := range tests {
+		a := require.New(t)
+		client := newTestClient(t, map[string]proto.Message{"/v1/" + issueName: test.issue}, nil)
+		timeout := test.timeout
+		if timeout == 0 {
+			timeout = time.Minute
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		applier := &applier{client: client, progress: &bytes.Buffer{}}
+		err := applier.waitApproval(ctx, issueName)
+		cancel()
+		if !test.wantErr {
+			a.NoError(err, test.description)
+			continue
+		}
+		a.Error(err, test.description)
+		a.Equal(test.exitCode, ExitCode(err), test.description)
+	}
+}
+
+func TestRolloutStageTaskFailed(t *testing.T) {
+	a := require.New(t)
+	const (
+		rolloutName = "projects/hr/rollouts/1"
+		stageName   = "projects/hr/rollouts/1/stages/1"
+		taskName    = "projects/hr/rollouts/1/stages/1/tasks/1"
+		taskRunName = "projects/hr/rollouts/1/stages/1/tasks/1/taskRuns/1"
+	)
+	client := newTestClient(t,
+		map[string]proto.Message{
+			"/v1/" + rolloutName: &v1pb.Rollout{
+				Name: rolloutName,
+				Stages: []*v1pb.Stage{{
+					Name:  stageName,
+					Title: "Prod",
+					Tasks: []*v1pb.Task{{Name: taskName, Title: "Migrate hr", Status: v1pb.Task_FAILED}},
+				}},
+			},
+			"/v1/" + taskName + "/taskRuns": &v1pb.ListTaskRunsResponse{
+				TaskRuns: []*v1pb.TaskRun{{Name: taskRunName, Status: v1pb.TaskRun_FAILED}},
+			},
+		},
+		map[string]string{
+			"/v1/" + taskRunName + "/logs:tail": `{"result": {"uid": "1", "type": "STATEMENT_END", "statementIndex": 0, "error": "duplicate column"}}` + "\n",
+		},
+	)
+
+	progress := &bytes.Buffer{}
+	applier := &applier{client: client, progress: progress, taskRunStates: make(map[string]string), tailedTaskRuns: make(map[string]bool)}
+	err := applier.rolloutStage(context.Background(), rolloutName, stageName)
+	a.Error(err)
+	var exitErr *ExitError
+	a.True(errors.As(err, &exitErr))
+	a.Equal(applyExitCodeTaskFailed, ExitCode(err))
+	// The logs of the failed task run are printed before returning.
+	a.Contains(progress.String(), "statement 1 failed")
+	a.Contains(progress.String(), "duplicate column")
+}
+
+func TestExitCode(t *testing.T) {
+	a := require.New(t)
+	a.Equal(1, ExitCode(errors.New("failed to get plan")))
+	a.Equal(applyExitCodeRejected, ExitCode(&ExitError{Code: applyExitCodeRejected, Err: errors.New("rejected")}))
+	a.Equal(applyExitCodeTaskFailed, ExitCode(errors.Wrap(&ExitError{Code: applyExitCodeTaskFailed, Err: errors.New("failed")}, "wrapped")))
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/sarif"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// pollInterval is the interval to poll the Bytebase server for the plan checks and the rollout.
+const pollInterval = 3 * time.Second
+
+const checkUsage = `Run the plan checks of a plan on the Bytebase server, wait for the results and print the advice.
+
+The advice of each sheet on each target is printed as the advice of a file named SHEET@TARGET.
+
+Exit codes:
+  0  no problem found, or the problems are below the --fail-on level
+  1  failed to run the plan checks
+  2  warnings found
+  3  errors found
+
+Examples:
+  bb check --url https://bytebase.example.com --plan projects/hr/plans/101
+  bb check --plan projects/hr/plans/101 --format sarif --output check.sarif
+`
+
+func newCheckCmd() *cobra.Command {
+	var (
+		server  serverFlags
+		plan    string
+		format  string
+		output  string
+		failOn  string
+		timeout time.Duration
+	)
+	checkCmd := &cobra.Command{
+		Use:   "check",
+		Short: "Runs the plan checks of a plan on a Bytebase server.",
+		Long:  checkUsage,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			switch format {
+			case reviewFormatText, reviewFormatJSON, reviewFormatJUnit, reviewFormatSARIF:
+			default:
+				return errors.Errorf("unsupported format %q, supported formats: text, json, junit, sarif", format)
+			}
+			failStatus, err := parseFailOn(failOn)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			client, err := newAPIClient(ctx, &server)
+			if err != nil {
+				return err
+			}
+			if err := client.runPlanChecks(ctx, plan); err != nil {
+				return errors.Wrapf(err, "failed to run plan checks for plan %s", plan)
+			}
+			runs, err := waitPlanChecks(ctx, client, plan)
+			if err != nil {
+				return err
+			}
+			results := convertPlanCheckRuns(runs)
+
+			if err := writeReviewOutput(cmd.OutOrStdout(), output, format, results); err != nil {
+				return err
+			}
+
+			if code := getReviewExitCode(results, failStatus); code != 0 {
+				// The results are the output, so the error and the usage are not printed.
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				return &ExitError{Code: code, Err: errors.Errorf("plan checks found problems")}
+			}
+			return nil
+		},
+	}
+
+	server.register(checkCmd)
+	checkCmd.Flags().StringVar(&plan, "plan", "", "Plan to check, e.g. projects/hr/plans/101.")
+	checkCmd.Flags().StringVar(&format, "format", reviewFormatText, "Output format, text, json, junit or sarif.")
+	checkCmd.Flags().StringVar(&output, "output", "", "File to store the results. Output to stdout if unspecified.")
+	checkCmd.Flags().StringVar(&failOn, "fail-on", "warning", "The minimum level exiting with non-zero code, warning, error or none.")
+	checkCmd.Flags().DurationVar(&timeout, "timeout", 0, "Timeout to wait for the plan checks, e.g. 10m. No timeout if unspecified.")
+	_ = checkCmd.MarkFlagRequired("plan")
+	return checkCmd
+}
+
+// waitPlanChecks waits for the latest plan check runs of each type, target and sheet to finish.
+func waitPlanChecks(ctx context.Context, client *apiClient, plan string) ([]*v1pb.PlanCheckRun, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		runs, err := client.listPlanCheckRuns(ctx, plan)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list plan check runs for plan %s", plan)
+		}
+		latest := latestPlanCheckRuns(runs)
+		running := false
+		for _, run := range latest {
+			if run.Status == v1pb.PlanCheckRun_RUNNING {
+				running = true
+				break
+			}
+		}
+		if !running {
+			return latest, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "failed to wait for the plan checks of plan %s", plan)
+		case <-ticker.C:
+		}
+	}
+}
+
+// latestPlanCheckRuns returns the latest run of each type, target and sheet, the runs of the previous checks are ignored.
+func latestPlanCheckRuns(runs []*v1pb.PlanCheckRun) []*v1pb.PlanCheckRun {
+	type runKey struct {
+		tp     v1pb.PlanCheckRun_Type
+		target string
+		sheet  string
+	}
+	latest := make(map[runKey]*v1pb.PlanCheckRun)
+	var keys []runKey
+	for _, run := range runs {
+		key := runKey{tp: run.Type, target: run.Target, sheet: run.Sheet}
+		previous, ok := latest[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || planCheckRunUID(run) > planCheckRunUID(previous) {
+			latest[key] = run
+		}
+	}
+	var result []*v1pb.PlanCheckRun
+	for _, key := range keys {
+		result = append(result, latest[key])
+	}
+	return result
+}
+
+func planCheckRunUID(run *v1pb.PlanCheckRun) int {
+	uid, err := strconv.Atoi(run.Uid)
+	if err != nil {
+		return 0
+	}
+	return uid
+}
+
+// convertPlanCheckRuns converts the plan check runs to the advice of each sheet on each target.
+func convertPlanCheckRuns(runs []*v1pb.PlanCheckRun) []*sarif.FileAdvice {
+	resultMap := make(map[string]*sarif.FileAdvice)
+	for _, run := range runs {
+		path := fmt.Sprintf("%s@%s", run.Sheet, run.Target)
+		result, ok := resultMap[path]
+		if !ok {
+			result = &sarif.FileAdvice{Path: path, Advices: []advisor.Advice{}}
+			resultMap[path] = result
+		}
+		if run.Status == v1pb.PlanCheckRun_FAILED {
+			result.Advices = append(result.Advices, advisor.Advice{
+				Status:  advisor.Error,
+				Code:    advisor.Internal,
+				Title:   run.Type.String(),
+				Content: run.Error,
+			})
+			continue
+		}
+		for _, r := range run.Results {
+			var status advisor.Status
+			switch r.Status {
+			case v1pb.PlanCheckRun_Result_ERROR:
+				status = advisor.Error
+			case v1pb.PlanCheckRun_Result_WARNING:
+				status = advisor.Warn
+			default:
+				continue
+			}
+			advice := advisor.Advice{
+				Status:  status,
+				Code:    advisor.Code(r.Code),
+				Title:   r.Title,
+				Content: r.Content,
+			}
+			if report := r.GetSqlReviewReport(); report != nil {
+				advice.Line = int(report.Line)
+				advice.Column = int(report.Column)
+			}
+			result.Advices = append(result.Advices, advice)
+		}
+	}
+
+	var results []*sarif.FileAdvice
+	for _, result := range resultMap {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Path < results[j].Path
+	})
+	return results
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestLatestPlanCheckRuns(t *testing.T) {
+	a := require.New(t)
+	runs := []*v1pb.PlanCheckRun{
+		{Uid: "9", Type: v1pb.PlanCheckRun_DATABASE_STATEMENT_ADVISE, Target: "instances/prod/databases/hr", Sheet: "projects/hr/sheets/1"},
+		{Uid: "10", Type: v1pb.PlanCheckRun_DATABASE_STATEMENT_ADVISE, Target: "instances/prod/databases/hr", Sheet: "projects/hr/sheets/2"},
+		{Uid: "12", Type: v1pb.PlanCheckRun_DATABASE_STATEMENT_ADVISE, Target: "instances/prod/databases/hr", Sheet: "projects/hr/sheets/1"},
+		{Uid: "11", Type: v1pb.PlanCheckRun_DATABASE_CONNECT, Target: "instances/prod/databases/hr", Sheet: "projects/hr/sheets/1"},
+		// The uid is compared as a number, so 100 is after 12.
+		{Uid: "100", Type: v1pb.PlanCheckRun_DATABASE_STATEMENT_ADVISE, Target: "instances/prod/databases/hr", Sheet: "projects/hr/sheets/1"},
+		{Uid: "8", Type: v1pb.PlanCheckRun_DATABASE_CONNECT, Target: "instances/prod/databases/hr", Sheet: "projects/hr/sheets/1"},
+	}
+
+	var uids []string
+	for _, run := range latestPlanCheckRuns(runs) {
+		uids = append(uids, run.Uid)
+	}
+	// The latest runs are returned in the order of the first run of each type, target and sheet.
+	a.Equal([]string{"100", "10", "11"}, uids)
+}
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// serverFlags are the flags to connect the Bytebase server, the environment variables are used if the flags are unspecified.
+type serverFlags struct {
+	url      string
+	token    string
+	email    string
+	password string
+}
+
+func (f *serverFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.url, "url", "", "External URL of the Bytebase server, e.g. https://bytebase.example.com. Defaults to $BB_URL.")
+	cmd.Flags().StringVar(&f.token, "token", "", "Access token of the Bytebase server. Defaults to $BB_TOKEN.")
+	cmd.Flags().StringVar(&f.email, "email", "", "Email of the user or the service account to login if the token is unspecified. Defaults to $BB_EMAIL.")
+	cmd.Flags().StringVar(&f.password, "password", "", "Password of the user or the key of the service account. Defaults to $BB_PASSWORD.")
+}
+
+// resolve fills the unspecified flags with the environment variables, so that the secrets are not shown in the usage.
+func (f *serverFlags) resolve() {
+	for _, v := range []struct {
+		value *string
+		env   string
+	}{
+		{&f.url, "BB_URL"},
+		{&f.token, "BB_TOKEN"},
+		{&f.email, "BB_EMAIL"},
+		{&f.password, "BB_PASSWORD"},
+	} {
+		if *v.value == "" {
+			*v.value = os.Getenv(v.env)
+		}
+	}
+}
+
+// apiClient is the client of the Bytebase v1 API through the HTTP gateway.
+type apiClient struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// newAPIClient creates the client, and logins with the email and the password if the token is unspecified.
+func newAPIClient(ctx context.Context, f *serverFlags) (*apiClient, error) {
+	f.resolve()
+	if f.url == "" {
+		return nil, errors.Errorf("the URL of the Bytebase server is required")
+	}
+	c := &apiClient{
+		url:    strings.TrimSuffix(f.url, "/"),
+		token:  f.token,
+		client: &http.Client{},
+	}
+	if c.token != "" {
+		return c, nil
+	}
+	if f.email == "" || f.password == "" {
+		return nil, errors.Errorf("either the token or the email and the password are required")
+	}
+	resp := &v1pb.LoginResponse{}
+	if err := c.call(ctx, http.MethodPost, "auth/login", &v1pb.LoginRequest{Email: f.email, Password: f.password}, resp); err != nil {
+		return nil, errors.Wrapf(err, "failed to login as %s", f.email)
+	}
+	if resp.Token == "" {
+		return nil, errors.Errorf("failed to login as %s, the multi-factor authentication is not supported", f.email)
+	}
+	c.token = resp.Token
+	return c, nil
+}
+
+// apiError is the error returned by the HTTP gateway.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// call calls the API with the path relative to /v1.
+// The request is the HTTP body, nil for no body, and the field other than message, e.g. the plan string of CreateRollout, is encoded as JSON.
+func (c *apiClient) call(ctx context.Context, method, path string, request any, response proto.Message) error {
+	resp, err := c.do(ctx, method, path, request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if response == nil {
+		return nil
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(content, response); err != nil {
+		return errors.Wrap(err, "failed to unmarshal response")
+	}
+	return nil
+}
+
+// do sends the request, and returns the response if the status is OK.
+// The caller must close the response body.
+func (c *apiClient) do(ctx context.Context, method, path string, request any) (*http.Response, error) {
+	var body io.Reader
+	if request != nil {
+		var content []byte
+		var err error
+		if m, ok := request.(proto.Message); ok {
+			content, err = protojson.Marshal(m)
+		} else {
+			content, err = json.Marshal(request)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal request")
+		}
+		body = bytes.NewReader(content)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/v1/%s", c.url, path), body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to request %s %s", method, path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var apiErr apiError
+		content, err := io.ReadAll(resp.Body)
+		if err != nil || json.Unmarshal(content, &apiErr) != nil || apiErr.Message == "" {
+			return nil, errors.Errorf("%s %s: %s", method, path, resp.Status)
+		}
+		return nil, errors.Errorf("%s %s: %s", method, path, apiErr.Message)
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createSheet(ctx context.Context, project string, sheet *v1pb.Sheet) (*v1pb.Sheet, error) {
+	resp := &v1pb.Sheet{}
+	if err := c.call(ctx, http.MethodPost, fmt.Sprintf("%s/sheets", project), sheet, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createPlan(ctx context.Context, project string, plan *v1pb.Plan) (*v1pb.Plan, error) {
+	resp := &v1pb.Plan{}
+	if err := c.call(ctx, http.MethodPost, fmt.Sprintf("%s/plans", project), plan, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) getPlan(ctx context.Context, name string) (*v1pb.Plan, error) {
+	resp := &v1pb.Plan{}
+	if err := c.call(ctx, http.MethodGet, name, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) runPlanChecks(ctx context.Context, plan string) error {
+	return c.call(ctx, http.MethodPost, fmt.Sprintf("%s:runPlanChecks", plan), &v1pb.RunPlanChecksRequest{Name: plan}, &v1pb.RunPlanChecksResponse{})
+}
+
+func (c *apiClient) listPlanCheckRuns(ctx context.Context, plan string) ([]*v1pb.PlanCheckRun, error) {
+	resp := &v1pb.ListPlanCheckRunsResponse{}
+	if err := c.call(ctx, http.MethodGet, fmt.Sprintf("%s/planCheckRuns", plan), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.PlanCheckRuns, nil
+}
+
+func (c *apiClient) createIssue(ctx context.Context, project string, issue *v1pb.Issue) (*v1pb.Issue, error) {
+	resp := &v1pb.Issue{}
+	if err := c.call(ctx, http.MethodPost, fmt.Sprintf("%s/issues", project), issue, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) getIssue(ctx context.Context, name string) (*v1pb.Issue, error) {
+	resp := &v1pb.Issue{}
+	if err := c.call(ctx, http.MethodGet, name, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createRollout(ctx context.Context, project, plan string) (*v1pb.Rollout, error) {
+	resp := &v1pb.Rollout{}
+	if err := c.call(ctx, http.MethodPost, fmt.Sprintf("%s/rollouts", project), plan, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) getRollout(ctx context.Context, name string) (*v1pb.Rollout, error) {
+	resp := &v1pb.Rollout{}
+	if err := c.call(ctx, http.MethodGet, name, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) batchRunTasks(ctx context.Context, stage string, tasks []string) error {
+	return c.call(ctx, http.MethodPost, fmt.Sprintf("%s/tasks:batchRun", stage), &v1pb.BatchRunTasksRequest{Parent: stage, Tasks: tasks}, &v1pb.BatchRunTasksResponse{})
+}
+
+func (c *apiClient) listTaskRuns(ctx context.Context, task string) ([]*v1pb.TaskRun, error) {
+	resp := &v1pb.ListTaskRunsResponse{}
+	if err := c.call(ctx, http.MethodGet, fmt.Sprintf("%s/taskRuns", task), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.TaskRuns, nil
+}
+
+// tailTaskRunLogs streams the log entries of the task run after the entry with the uid until the task run finishes.
+// The HTTP gateway sends the stream as the newline-delimited JSON objects of {"result": entry} or {"error": status}.
+func (c *apiClient) tailTaskRunLogs(ctx context.Context, taskRun string, afterUID string, onEntry func(*v1pb.TaskRunLogEntry)) error {
+	query := url.Values{}
+	query.Set("follow", "true")
+	if afterUID != "" {
+		query.Set("afterUid", afterUID)
+	}
+	resp, err := c.do(ctx, http.MethodGet, fmt.Sprintf("%s/logs:tail?%s", taskRun, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var chunk struct {
+			Result json.RawMessage `json:"result"`
+			Error  *apiError       `json:"error"`
+		}
+		if err := decoder.Decode(&chunk); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "failed to read the log stream")
+		}
+		if chunk.Error != nil {
+			return errors.Errorf("failed to tail logs: %s", chunk.Error.Message)
+		}
+		entry := &v1pb.TaskRunLogEntry{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(chunk.Result, entry); err != nil {
+			return errors.Wrap(err, "failed to unmarshal log entry")
+		}
+		onEntry(entry)
+	}
+}
+
+func (c *apiClient) exportDatabaseSchema(ctx context.Context, database string) ([]*v1pb.SchemaFile, error) {
+	resp := &v1pb.ExportDatabaseSchemaResponse{}
+	if err := c.call(ctx, http.MethodGet, fmt.Sprintf("%s/schema:export", database), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.Files, nil
+}
+
+func (c *apiClient) planWorkspaceConfig(ctx context.Context, config string) (*v1pb.PlanWorkspaceConfigResponse, error) {
+	resp := &v1pb.PlanWorkspaceConfigResponse{}
+	if err := c.call(ctx, http.MethodPost, "workspaceConfig:plan", &v1pb.PlanWorkspaceConfigRequest{Config: config}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) applyWorkspaceConfig(ctx context.Context, request *v1pb.ApplyWorkspaceConfigRequest) (*v1pb.ApplyWorkspaceConfigResponse, error) {
+	resp := &v1pb.ApplyWorkspaceConfigResponse{}
+	if err := c.call(ctx, http.MethodPost, "workspaceConfig:apply", request, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+const (
+	compressNone = "none"
+	compressGzip = "gzip"
+	compressZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// getCompression returns the compression of the dump file, the compression is inferred from the file extension if unspecified.
+func getCompression(compress, file string) (string, error) {
+	switch compress {
+	case compressNone, compressGzip, compressZstd:
+		return compress, nil
+	case "":
+		switch filepath.Ext(file) {
+		case ".gz":
+			return compressGzip, nil
+		case ".zst":
+			return compressZstd, nil
+		default:
+			return compressNone, nil
+		}
+	default:
+		return "", errors.Errorf("unsupported compression %q, supported compressions: none, gzip, zstd", compress)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// newCompressWriter returns the writer compressing to out, the writer must be closed to flush the compressed data.
+func newCompressWriter(out io.Writer, compression string) (io.WriteCloser, error) {
+	switch compression {
+	case compressGzip:
+		return gzip.NewWriter(out), nil
+	case compressZstd:
+		w, err := zstd.NewWriter(out)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create zstd writer")
+		}
+		return w, nil
+	default:
+		return nopWriteCloser{Writer: out}, nil
+	}
+}
+
+// newDecompressReader returns the reader decompressing the gzip or zstd data detected by the magic number, or the data as is.
+func newDecompressReader(in io.Reader) (io.ReadCloser, error) {
+	r := bufio.NewReader(in)
+	// Peek returns the available bytes with an error if the data is shorter than the magic number.
+	magic, _ := r.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create gzip reader")
+		}
+		return gr, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create zstd reader")
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return io.NopCloser(r), nil
+	}
+}
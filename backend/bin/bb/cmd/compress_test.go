@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCompression(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		compress string
+		file     string
+		want     string
+		wantErr  bool
+	}{
+		{compress: "", file: "dump.sql", want: compressNone},
+		{compress: "", file: "dump.sql.gz", want: compressGzip},
+		{compress: "", file: "dump.sql.zst", want: compressZstd},
+		{compress: "", file: "", want: compressNone},
+		// The specified compression wins over the file extension.
+		{compress: compressNone, file: "dump.sql.gz", want: compressNone},
+		{compress: compressZstd, file: "dump.sql", want: compressZstd},
+		{compress: "bzip2", file: "dump.sql", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := getCompression(test.compress, test.file)
+		if test.wantErr {
+			a.Error(err, test.compress)
+			continue
+		}
+		a.NoError(err)
+		a.Equal(test.want, got, "%s %s", test.compress, test.file)
+	}
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	a := require.New(t)
+	data := []byte("CREATE TABLE t (id INT);\nINSERT INTO t VALUES (1);\n")
+
+	for _, compression := range []string{compressNone, compressGzip, compressZstd} {
+		var buf bytes.Buffer
+		w, err := newCompressWriter(&buf, compression)
+		a.NoError(err)
+		_, err = w.Write(data)
+		a.NoError(err)
+		a.NoError(w.Close())
+		if compression == compressNone {
+			a.Equal(data, buf.Bytes())
+		} else {
+			a.NotEqual(data, buf.Bytes(), compression)
+		}
+
+		r, err := newDecompressReader(&buf)
+		a.NoError(err)
+		got, err := io.ReadAll(r)
+		a.NoError(err)
+		a.NoError(r.Close())
+		a.Equal(data, got, compression)
+	}
+}
+
+func TestDecompressReaderShortData(t *testing.T) {
+	a := require.New(t)
+	// The data shorter than the magic number is read as is.
+	for _, data := range []string{"", ";", "\x1f"} {
+		r, err := newDecompressReader(bytes.NewBufferString(data))
+		a.NoError(err)
+		got, err := io.ReadAll(r)
+		a.NoError(err)
+		a.Equal(data, string(got))
+	}
+}
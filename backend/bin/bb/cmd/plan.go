@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+const planUsage = `Create a plan on the Bytebase server from the versioned SQL files.
+
+Each file is uploaded as a sheet and becomes a change of the target in the plan.
+The directories are walked recursively for the *.sql files.
+The version, the type and the description are parsed from the file name by the file template,
+and the changes are ordered by the semantic version, e.g. 1.10 is after 1.9.
+
+Examples:
+  bb plan --url https://bytebase.example.com --project projects/hr --target instances/prod/databases/hr migrations/
+  bb plan --project projects/hr --target instances/prod/databases/hr --file-template "{{VERSION}}__{{DESCRIPTION}}.sql" --format json migrations/
+`
+
+func newPlanCmd() *cobra.Command {
+	var (
+		server       serverFlags
+		project      string
+		target       string
+		title        string
+		description  string
+		fileTemplate string
+		format       string
+	)
+	planCmd := &cobra.Command{
+		Use:   "plan [FILE|DIR]...",
+		Short: "Creates a plan from the versioned SQL files on a Bytebase server.",
+		Long:  planUsage,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputFormat(format); err != nil {
+				return err
+			}
+			files, err := collectSQLFiles(args)
+			if err != nil {
+				return err
+			}
+			changes, err := parseVersionedFiles(files, fileTemplate)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			client, err := newAPIClient(ctx, &server)
+			if err != nil {
+				return err
+			}
+			if title == "" {
+				title = fmt.Sprintf("Migrate %s", target)
+			}
+			plan, err := createPlan(ctx, client, project, target, title, description, changes)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if format == outputFormatJSON {
+				return writeMessage(out, plan)
+			}
+			if _, err := fmt.Fprintf(out, "Created plan %s\n", plan.Name); err != nil {
+				return err
+			}
+			for _, change := range changes {
+				if _, err := fmt.Fprintf(out, "  %s %s %s\n", change.version, change.changeType, change.path); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	server.register(planCmd)
+	planCmd.Flags().StringVar(&project, "project", "", "Project of the plan, e.g. projects/hr.")
+	planCmd.Flags().StringVar(&target, "target", "", "Target of the changes, e.g. instances/prod/databases/hr or projects/hr/databaseGroups/all.")
+	planCmd.Flags().StringVar(&title, "title", "", "Title of the plan. Defaults to \"Migrate <target>\".")
+	planCmd.Flags().StringVar(&description, "description", "", "Description of the plan.")
+	planCmd.Flags().StringVar(&fileTemplate, "file-template", "{{VERSION}}##{{TYPE}}##{{DESCRIPTION}}.sql", "Template of the file name, {{VERSION}} is required, {{TYPE}} is migrate (ddl) or data (dml), {{DESCRIPTION}} is optional.")
+	planCmd.Flags().StringVar(&format, "format", outputFormatText, "Output format, text or json.")
+	_ = planCmd.MarkFlagRequired("project")
+	_ = planCmd.MarkFlagRequired("target")
+	return planCmd
+}
+
+// versionedChange is a versioned SQL file.
+type versionedChange struct {
+	path    string
+	version string
+	// semanticVersion is the parsed version to order the changes.
+	semanticVersion semver.Version
+	changeType      v1pb.Plan_ChangeDatabaseConfig_Type
+	description     string
+	content         []byte
+}
+
+// parseVersionedFiles parses the version, the type and the description from the file names,
+// and returns the changes in the order of the semantic versions.
+func parseVersionedFiles(files []string, fileTemplate string) ([]*versionedChange, error) {
+	var changes []*versionedChange
+	versions := make(map[string]string)
+	for _, file := range files {
+		mi, err := db.ParseMigrationInfo(filepath.Base(file), fileTemplate, true /* allowOmitDatabaseName */)
+		if err != nil {
+			return nil, err
+		}
+		if mi == nil {
+			return nil, errors.Errorf("file %s does not match the file template %q", file, fileTemplate)
+		}
+		// The tolerant parsing accepts the versions like 0001, 1.2 and 20240101.
+		semanticVersion, err := semver.ParseTolerant(mi.Version.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "file %s has invalid semantic version %s", file, mi.Version.Version)
+		}
+		if previous, ok := versions[semanticVersion.String()]; ok {
+			return nil, errors.Errorf("files %s and %s have the same version %s", previous, file, mi.Version.Version)
+		}
+		versions[semanticVersion.String()] = file
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read file %s", file)
+		}
+		changeType := v1pb.Plan_ChangeDatabaseConfig_MIGRATE
+		if mi.Type == db.Data {
+			changeType = v1pb.Plan_ChangeDatabaseConfig_DATA
+		}
+		changes = append(changes, &versionedChange{
+			path:            filepath.ToSlash(file),
+			version:         mi.Version.Version,
+			semanticVersion: semanticVersion,
+			changeType:      changeType,
+			description:     mi.Description,
+			content:         content,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].semanticVersion.LT(changes[j].semanticVersion)
+	})
+	return changes, nil
+}
+
+// createPlan uploads the changes as sheets and creates the plan with a spec for each change in a single step.
+func createPlan(ctx context.Context, client *apiClient, project, target, title, description string, changes []*versionedChange) (*v1pb.Plan, error) {
+	step := &v1pb.Plan_Step{}
+	for _, change := range changes {
+		sheet, err := client.createSheet(ctx, project, &v1pb.Sheet{
+			Title:      change.description,
+			Content:    change.content,
+			Visibility: v1pb.Sheet_VISIBILITY_PROJECT,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create sheet for file %s", change.path)
+		}
+		step.Specs = append(step.Specs, &v1pb.Plan_Spec{
+			Config: &v1pb.Plan_Spec_ChangeDatabaseConfig{
+				ChangeDatabaseConfig: &v1pb.Plan_ChangeDatabaseConfig{
+					Target:        target,
+					Sheet:         sheet.Name,
+					Type:          change.changeType,
+					SchemaVersion: change.version,
+				},
+			},
+		})
+	}
+	plan, err := client.createPlan(ctx, project, &v1pb.Plan{
+		Title:       title,
+		Description: description,
+		Steps:       []*v1pb.Plan_Step{step},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create plan")
+	}
+	return plan, nil
+}
+
+func validateOutputFormat(format string) error {
+	switch format {
+	case outputFormatText, outputFormatJSON:
+		return nil
+	default:
+		return errors.Errorf("unsupported format %q, supported formats: text, json", format)
+	}
+}
+
+// writeMessage writes the message in the indented JSON.
+func writeMessage(out io.Writer, m proto.Message) error {
+	content, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal output")
+	}
+	if _, err := out.Write(content); err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, "\n")
+	return err
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestParseVersionedFiles(t *testing.T) {
+	const fileTemplate = "{{VERSION}}##{{TYPE}}##{{DESCRIPTION}}.sql"
+	tests := []struct {
+		description  string
+		files        []string
+		wantVersions []string
+		wantTypes    []v1pb.Plan_ChangeDatabaseConfig_Type
+		wantErr      bool
+	}{
+		{
+			description:  "ordered by the semantic version",
+			files:        []string{"1.10##migrate##add_index.sql", "1.2##migrate##init.sql", "1.9##data##seed.sql"},
+			wantVersions: []string{"1.2", "1.9", "1.10"},
+			wantTypes:    []v1pb.Plan_ChangeDatabaseConfig_Type{v1pb.Plan_ChangeDatabaseConfig_MIGRATE, v1pb.Plan_ChangeDatabaseConfig_DATA, v1pb.Plan_ChangeDatabaseConfig_MIGRATE},
+		},
+		{
+			description:  "leading zeros",
+			files:        []string{"0010##migrate##b.sql", "0002##migrate##a.sql"},
+			wantVersions: []string{"0002", "0010"},
+			wantTypes:    []v1pb.Plan_ChangeDatabaseConfig_Type{v1pb.Plan_ChangeDatabaseConfig_MIGRATE, v1pb.Plan_ChangeDatabaseConfig_MIGRATE},
+		},
+		{
+			description: "same version",
+			files:       []string{"1.0##migrate##a.sql", "1.0.0##migrate##b.sql"},
+			wantErr:     true,
+		},
+		{
+			description: "not a semantic version",
+			files:       []string{"abc##migrate##a.sql"},
+			wantErr:     true,
+		},
+		{
+			description: "not matching the file template",
+			files:       []string{"init.sql"},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		a := require.New(t)
+		dir := t.TempDir()
+		var files []string
+		for _, file := range test.files {
+			path := filepath.Join(dir, file)
+			a.NoError(os.WriteFile(path, []byte("SELECT 1;"), 0600))
+			files = append(files, path)
+		}
+		changes, err := parseVersionedFiles(files, fileTemplate)
+		if test.wantErr {
+			a.Error(err, test.description)
+			continue
+		}
+		a.NoError(err, test.description)
+		var versions []string
+		var types []v1pb.Plan_ChangeDatabaseConfig_Type
+		for _, change := range changes {
+			versions = append(versions, change.version)
+			types = append(types, change.changeType)
+		}
+		a.Equal(test.wantVersions, versions, test.description)
+		a.Equal(test.wantTypes, types, test.description)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/xo/dburl"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
 )
 
 func newRestoreCmd() *cobra.Command {
@@ -27,7 +29,7 @@ func newRestoreCmd() *cobra.Command {
 		},
 	}
 	restoreCmd.Flags().StringVar(&dsn, "dsn", "", dsnUsage)
-	restoreCmd.Flags().StringVar(&file, "file", "", "File to store the dump.")
+	restoreCmd.Flags().StringVar(&file, "file", "", "File to store the dump. The gzip and zstd compressed dump is decompressed automatically.")
 	if err := restoreCmd.MarkFlagRequired("file"); err != nil {
 		panic(err)
 	}
@@ -37,11 +39,24 @@ func newRestoreCmd() *cobra.Command {
 
 // restoreDatabase restores the schema of a database instance.
 func restoreDatabase(ctx context.Context, u *dburl.URL, file string) error {
+	e, err := getEngine(u)
+	if err != nil {
+		return err
+	}
+	if !db.GetCapability(e.dbType).Restore {
+		return errors.Errorf("restore is not supported for %s", e.title)
+	}
+
 	f, err := os.Open(file)
 	if err != nil {
 		return errors.Wrapf(err, "failed to open file %q", file)
 	}
 	defer f.Close()
+	r, err := newDecompressReader(f)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read file %q", file)
+	}
+	defer r.Close()
 
 	db, err := open(ctx, u)
 	if err != nil {
@@ -49,7 +64,7 @@ func restoreDatabase(ctx context.Context, u *dburl.URL, file string) error {
 	}
 	defer db.Close(ctx)
 
-	if err := db.Restore(ctx, f); err != nil {
+	if err := db.Restore(ctx, r); err != nil {
 		return errors.Wrapf(err, "failed to restore from backup file %q", file)
 	}
 	return nil
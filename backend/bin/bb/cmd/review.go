@@ -0,0 +1,410 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/sarif"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	reviewFormatText  = "text"
+	reviewFormatJSON  = "json"
+	reviewFormatJUnit = "junit"
+	reviewFormatSARIF = "sarif"
+
+	// The exit codes of bb review, the failure of running the review exits with 1.
+	reviewExitCodeWarning = 2
+	reviewExitCodeError   = 3
+)
+
+const reviewUsage = `Review the SQL files against the SQL review rules without a Bytebase server.
+
+The directories are walked recursively for the *.sql files.
+
+Exit codes:
+  0  no problem found, or the problems are below the --fail-on level
+  1  failed to run the review
+  2  warnings found
+  3  errors found
+
+Examples:
+  bb review --engine MYSQL migrations/
+  bb review --engine POSTGRES --config sql-review-override.yml --schema-file schema.sql --format sarif --output review.sarif migrations/
+  bb review --engine ORACLE --schema HR --schema-file hr.sql migrations/
+`
+
+func newReviewCmd() *cobra.Command {
+	var (
+		engine     string
+		config     string
+		template   string
+		schemaFile string
+		schema     string
+		database   string
+		format     string
+		output     string
+		failOn     string
+	)
+	reviewCmd := &cobra.Command{
+		Use:   "review [FILE|DIR]...",
+		Short: "Reviews SQL files against the SQL review rules.",
+		Long:  reviewUsage,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			engineValue, ok := storepb.Engine_value[strings.ToUpper(engine)]
+			if !ok || engineValue == int32(storepb.Engine_ENGINE_UNSPECIFIED) {
+				return errors.Errorf("unsupported engine %q", engine)
+			}
+			dbType := storepb.Engine(engineValue)
+			if !advisor.IsSupported(dbType) {
+				return errors.Errorf("engine %q has no SQL review rules", engine)
+			}
+			switch format {
+			case reviewFormatText, reviewFormatJSON, reviewFormatJUnit, reviewFormatSARIF:
+			default:
+				return errors.Errorf("unsupported format %q, supported formats: text, json, junit, sarif", format)
+			}
+			failStatus, err := parseFailOn(failOn)
+			if err != nil {
+				return err
+			}
+
+			ruleList, err := loadSQLReviewRules(config, template)
+			if err != nil {
+				return err
+			}
+			options := &reviewOptions{
+				dbType:          dbType,
+				ruleList:        ruleList,
+				currentSchema:   schema,
+				currentDatabase: database,
+			}
+			if schemaFile != "" {
+				content, err := os.ReadFile(schemaFile)
+				if err != nil {
+					return errors.Wrapf(err, "failed to read schema file %s", schemaFile)
+				}
+				options.schema = string(content)
+			}
+			files, err := collectSQLFiles(args)
+			if err != nil {
+				return err
+			}
+
+			results, err := reviewFiles(context.Background(), options, files)
+			if err != nil {
+				return err
+			}
+
+			if err := writeReviewOutput(cmd.OutOrStdout(), output, format, results); err != nil {
+				return err
+			}
+
+			if code := getReviewExitCode(results, failStatus); code != 0 {
+				// The results are the output, so the error and the usage are not printed.
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				return &ExitError{Code: code, Err: errors.Errorf("SQL review found problems")}
+			}
+			return nil
+		},
+	}
+
+	reviewCmd.Flags().StringVar(&engine, "engine", "", "Database engine, e.g. MYSQL, POSTGRES, TIDB, ORACLE, SNOWFLAKE, MSSQL.")
+	reviewCmd.Flags().StringVar(&config, "config", "", "SQL review policy in YAML, the same as sql-review.override.yaml. The rules of the template are used if unspecified.")
+	reviewCmd.Flags().StringVar(&template, "template", "bb.sql-review.prod", "SQL review template id, bb.sql-review.prod or bb.sql-review.dev. Ignored if the config is specified.")
+	reviewCmd.Flags().StringVar(&schemaFile, "schema-file", "", "Schema snapshot of the database for the catalog checks, e.g. the output of bb dump --schema-only.")
+	reviewCmd.Flags().StringVar(&schema, "schema", "", "Current schema for the unqualified names, e.g. HR for Oracle.")
+	reviewCmd.Flags().StringVar(&database, "database", "", "Current database for the unqualified names, e.g. for Snowflake and SQL Server.")
+	reviewCmd.Flags().StringVar(&format, "format", reviewFormatText, "Output format, text, json, junit or sarif.")
+	reviewCmd.Flags().StringVar(&output, "output", "", "File to store the results. Output to stdout if unspecified.")
+	reviewCmd.Flags().StringVar(&failOn, "fail-on", "warning", "The minimum level exiting with non-zero code, warning, error or none.")
+	_ = reviewCmd.MarkFlagRequired("engine")
+	return reviewCmd
+}
+
+// reviewCatalog is the catalog for the review of a file.
+type reviewCatalog struct {
+	finder *catalog.Finder
+}
+
+// GetFinder implements the catalog.Catalog interface.
+func (c *reviewCatalog) GetFinder() *catalog.Finder {
+	return c.finder
+}
+
+func parseFailOn(failOn string) (advisor.Status, error) {
+	switch failOn {
+	case "warning":
+		return advisor.Warn, nil
+	case "error":
+		return advisor.Error, nil
+	case "none":
+		return advisor.Success, nil
+	default:
+		return "", errors.Errorf("unsupported fail-on level %q, supported levels: warning, error, none", failOn)
+	}
+}
+
+func loadSQLReviewRules(config, template string) ([]*storepb.SQLReviewRule, error) {
+	override := &advisor.SQLReviewConfigOverride{Template: template}
+	if config != "" {
+		content, err := os.ReadFile(config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read config file %s", config)
+		}
+		override = &advisor.SQLReviewConfigOverride{}
+		if err := yaml.Unmarshal(content, override); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse config file %s", config)
+		}
+	}
+	ruleList, err := advisor.MergeSQLReviewRules(override)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to merge the config for template %s", override.Template)
+	}
+	return ruleList, nil
+}
+
+// collectSQLFiles collects the files, and the *.sql files in the directories recursively.
+func collectSQLFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		var dirFiles []string
+		if err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.EqualFold(filepath.Ext(p), ".sql") {
+				dirFiles = append(dirFiles, p)
+			}
+			return nil
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to walk directory %s", path)
+		}
+		sort.Strings(dirFiles)
+		files = append(files, dirFiles...)
+	}
+	return files, nil
+}
+
+// reviewOptions are the options to review the files.
+type reviewOptions struct {
+	dbType   storepb.Engine
+	ruleList []*storepb.SQLReviewRule
+	// schema is the schema snapshot of the database for the catalog checks.
+	schema string
+	// currentSchema and currentDatabase are used for the unqualified names.
+	currentSchema   string
+	currentDatabase string
+}
+
+// reviewFiles reviews the files independently, each against the schema if specified.
+func reviewFiles(ctx context.Context, options *reviewOptions, files []string) ([]*sarif.FileAdvice, error) {
+	var results []*sarif.FileAdvice
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read file %s", file)
+		}
+
+		finder := catalog.NewEmptyFinder(&catalog.FinderContext{CheckIntegrity: false, EngineType: options.dbType, IgnoreCaseSensitive: false, CurrentSchema: options.currentSchema})
+		if options.schema != "" {
+			finder, err = catalog.NewFinderWithSchema(options.dbType, options.currentSchema, options.schema)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to load schema")
+			}
+		}
+		adviceList, err := advisor.SQLReviewCheck(string(content), options.ruleList, advisor.SQLReviewCheckContext{
+			Charset:         "utf8mb4",
+			Collation:       "utf8mb4_general_ci",
+			DbType:          options.dbType,
+			Catalog:         &reviewCatalog{finder: finder},
+			Context:         ctx,
+			CurrentSchema:   options.currentSchema,
+			CurrentDatabase: options.currentDatabase,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to review file %s", file)
+		}
+
+		result := &sarif.FileAdvice{Path: filepath.ToSlash(file), Advices: []advisor.Advice{}}
+		for _, advice := range adviceList {
+			if advice.Status == advisor.Success {
+				continue
+			}
+			result.Advices = append(result.Advices, advice)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func getReviewExitCode(results []*sarif.FileAdvice, failStatus advisor.Status) int {
+	if failStatus == advisor.Success {
+		return 0
+	}
+	status := advisor.Success
+	for _, result := range results {
+		for _, advice := range result.Advices {
+			if advice.Status.GetPriority() > status.GetPriority() {
+				status = advice.Status
+			}
+		}
+	}
+	if status.GetPriority() < failStatus.GetPriority() {
+		return 0
+	}
+	switch status {
+	case advisor.Error:
+		return reviewExitCodeError
+	case advisor.Warn:
+		return reviewExitCodeWarning
+	default:
+		return 0
+	}
+}
+
+// writeReviewOutput writes the results to the output file, or to out if the output file is unspecified.
+func writeReviewOutput(out io.Writer, output, format string, results []*sarif.FileAdvice) error {
+	if output == "" {
+		return writeReviewResults(out, format, results)
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create output file %s", output)
+	}
+	if err := writeReviewResults(f, format, results); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close output file %s", output)
+	}
+	return nil
+}
+
+func writeReviewResults(out io.Writer, format string, results []*sarif.FileAdvice) error {
+	switch format {
+	case reviewFormatJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case reviewFormatSARIF:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(sarif.Convert(version, results))
+	case reviewFormatJUnit:
+		return writeJUnit(out, results)
+	default:
+		return writeText(out, results)
+	}
+}
+
+func writeText(out io.Writer, results []*sarif.FileAdvice) error {
+	var errorCount, warningCount int
+	for _, result := range results {
+		for _, advice := range result.Advices {
+			switch advice.Status {
+			case advisor.Error:
+				errorCount++
+			case advisor.Warn:
+				warningCount++
+			}
+			if _, err := fmt.Fprintf(out, "%s:%d:%d: %s [%s] %s\n", result.Path, advice.Line, advice.Column, advice.Status, advice.Title, advice.Content); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintf(out, "%d file(s) reviewed, %d error(s), %d warning(s)\n", len(results), errorCount, warningCount)
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// writeJUnit writes a test suite for each file, and a failed test case for each advice.
+// The file without advice has a passed test case.
+func writeJUnit(out io.Writer, results []*sarif.FileAdvice) error {
+	suites := &junitTestSuites{Name: "bb review"}
+	for _, result := range results {
+		suite := &junitTestSuite{Name: result.Path}
+		for _, advice := range result.Advices {
+			suite.TestCases = append(suite.TestCases, &junitTestCase{
+				Name:      fmt.Sprintf("%s:%d", advice.Title, advice.Line),
+				ClassName: result.Path,
+				Failure: &junitFailure{
+					Message: advice.Content,
+					Type:    string(advice.Status),
+					Content: fmt.Sprintf("%s:%d:%d: %s", result.Path, advice.Line, advice.Column, advice.Content),
+				},
+			})
+			suite.Failures++
+		}
+		if len(suite.TestCases) == 0 {
+			suite.TestCases = append(suite.TestCases, &junitTestCase{Name: "SQL review", ClassName: result.Path})
+		}
+		suite.Tests = len(suite.TestCases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
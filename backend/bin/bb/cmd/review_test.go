@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	// Register oracle advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/oracle"
+	// Register postgresql advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/pg"
+	// Register postgres parser driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
+)
+
+type reviewTest struct {
+	Description string
+	Statement   string
+	Format      string
+	FailOn      string
+	ExitCode    int
+	Output      string
+}
+
+func TestReview(t *testing.T) {
+	const (
+		record = false
+	)
+	var (
+		filepath = "testdata/review.yaml"
+	)
+
+	a := require.New(t)
+	yamlFile, err := os.Open(filepath)
+	a.NoError(err)
+
+	tests := []reviewTest{}
+	byteValue, err := io.ReadAll(yamlFile)
+	a.NoError(yamlFile.Close())
+	a.NoError(err)
+	a.NoError(yaml.Unmarshal(byteValue, &tests))
+
+	for i, test := range tests {
+		exitCode, output := runReview(t, test)
+		if record {
+			tests[i].ExitCode = exitCode
+			tests[i].Output = output
+		} else {
+			a.Equal(test.ExitCode, exitCode, test.Description)
+			a.Equal(test.Output, output, test.Description)
+		}
+	}
+
+	if record {
+		byteValue, err := yaml.Marshal(tests)
+		a.NoError(err)
+		err = os.WriteFile(filepath, byteValue, 0644)
+		a.NoError(err)
+	}
+}
+
+// runReview reviews the statement in migration.sql, and returns the exit code and the output.
+func runReview(t *testing.T, test reviewTest) (int, string) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "migration.sql")
+	require.NoError(t, os.WriteFile(file, []byte(test.Statement), 0644))
+
+	var out bytes.Buffer
+	cmd := NewRootCmd()
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"review", "--engine", "POSTGRES", "--format", test.Format, "--fail-on", test.FailOn, file})
+	exitCode := 0
+	if err := cmd.Execute(); err != nil {
+		exitCode = ExitCode(err)
+	}
+	// The temporary directory is trimmed for the golden output.
+	return exitCode, strings.ReplaceAll(out.String(), filepath.ToSlash(dir)+"/", "")
+}
+
+func TestReviewCurrentSchema(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	schemaFile := filepath.Join(dir, "schema.sql")
+	a.NoError(os.WriteFile(schemaFile, []byte("CREATE TABLE HR.EMPLOYEE(ID NUMBER, NAME VARCHAR2(20));\nCREATE TABLE SALES.ORDERS(ID NUMBER);\n"), 0644))
+	file := filepath.Join(dir, "migration.sql")
+	a.NoError(os.WriteFile(file, []byte("ALTER TABLE employee DROP COLUMN name;\n"), 0644))
+
+	review := func(args ...string) string {
+		var out bytes.Buffer
+		cmd := NewRootCmd()
+		cmd.SetOut(&out)
+		cmd.SetErr(io.Discard)
+		cmd.SetArgs(append([]string{"review", "--engine", "ORACLE", "--schema-file", schemaFile, "--fail-on", "none"}, append(args, file)...))
+		a.NoError(cmd.Execute())
+		return out.String()
+	}
+
+	// The unqualified table is in the current schema.
+	output := review("--schema", "HR")
+	a.Contains(output, "[schema.backward-compatibility]")
+	a.NotContains(output, "does not exist")
+
+	// The unqualified table is not found without the current schema.
+	output = review()
+	a.NotContains(output, "[schema.backward-compatibility]")
+}
+
+func TestReviewConfigCustomRule(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config.yaml")
+	a.NoError(os.WriteFile(config, []byte(`
+template: bb.sql-review.prod
+ruleList:
+  - type: custom.cel
+    level: ERROR
+    payload:
+      title: custom.no-delete
+      expression: statement.type == "DELETE"
+      message: DELETE is not allowed
+`), 0644))
+	file := filepath.Join(dir, "migration.sql")
+	a.NoError(os.WriteFile(file, []byte("DELETE FROM t WHERE id = 1;\n"), 0644))
+
+	var out bytes.Buffer
+	cmd := NewRootCmd()
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"review", "--engine", "POSTGRES", "--config", config, "--fail-on", "none", file})
+	a.NoError(cmd.Execute())
+	a.Contains(out.String(), "[custom.no-delete]")
+	a.Contains(out.String(), "DELETE is not allowed")
+}
+
+func TestReviewUnsupportedEngine(t *testing.T) {
+	a := require.New(t)
+	file := filepath.Join(t.TempDir(), "migration.sql")
+	a.NoError(os.WriteFile(file, []byte("DELETE FROM t;\n"), 0644))
+
+	cmd := NewRootCmd()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	// No advisor of MongoDB is registered.
+	cmd.SetArgs([]string{"review", "--engine", "MONGODB", file})
+	err := cmd.Execute()
+	a.Error(err)
+	a.Contains(err.Error(), "has no SQL review rules")
+}
@@ -2,6 +2,8 @@
 package cmd
 
 import (
+	"errors"
+
 	"github.com/spf13/cobra"
 )
 
@@ -15,7 +17,7 @@ func NewRootCmd() *cobra.Command {
 		},
 	}
 
-	rootCmd.AddCommand(newDumpCmd(), newRestoreCmd(), newVersionCmd(), newMigrateCmd())
+	rootCmd.AddCommand(newDumpCmd(), newRestoreCmd(), newVersionCmd(), newMigrateCmd(), newReviewCmd(), newPlanCmd(), newCheckCmd(), newApplyCmd(), newSchemaCmd(), newWorkspaceCmd())
 
 	return rootCmd
 }
@@ -24,3 +26,23 @@ func NewRootCmd() *cobra.Command {
 func Execute() (err error) {
 	return NewRootCmd().Execute()
 }
+
+// ExitError is the error exiting with the code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// ExitCode returns the exit code of the error returned by the command.
+func ExitCode(err error) int {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/schematree"
+	// Register the MySQL schema transformer for assembling the SDL schema.
+	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/transform/mysql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const schemaUsage = `Manage the schema as code.
+
+The schema tree has one file per schema object:
+  [<schema>/]schema.sql
+  [<schema>/]{tables,views,functions,procedures,sequences,triggers,events}/<name>.sql
+  misc.sql
+The schema directory is only used by PostgreSQL, the other statements are kept in misc.sql.
+The characters other than letters, digits and "_.$-" in the object names are percent-encoded in the file names.
+`
+
+const schemaExportUsage = `Export the synced schema of a database on the Bytebase server into the schema tree.
+
+MySQL, MariaDB, TiDB, OceanBase, PostgreSQL, RisingWave, Oracle, DM, OceanBase Oracle, Snowflake, ClickHouse,
+SQLite and Spanner are supported. The paths are in lower case, and the paths only differing in case are suffixed by
+"~" and the hash of the original path.
+
+Examples:
+  bb schema export --url https://bytebase.example.com --database instances/prod/databases/hr --dir schema/
+  bb schema export --database instances/prod/databases/hr --dir schema/ --prune
+`
+
+const schemaAssembleUsage = `Assemble the schema tree into the SDL schema for the schema migration.
+
+The objects are assembled in the order of schemas, misc.sql, sequences, tables, functions, procedures, views, triggers and events,
+and in the order of the paths for the objects of the same type.
+
+Examples:
+  bb schema assemble --engine MYSQL --dir schema/ --output schema.sql
+  bb schema assemble --engine POSTGRES --dir schema/
+`
+
+func newSchemaCmd() *cobra.Command {
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Manage the schema as code.",
+		Long:  schemaUsage,
+	}
+	schemaCmd.AddCommand(newSchemaExportCmd(), newSchemaAssembleCmd())
+	return schemaCmd
+}
+
+func newSchemaExportCmd() *cobra.Command {
+	var (
+		server   serverFlags
+		database string
+		dir      string
+		prune    bool
+	)
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports the schema of a database into the schema tree.",
+		Long:  schemaExportUsage,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			client, err := newAPIClient(ctx, &server)
+			if err != nil {
+				return err
+			}
+			files, err := client.exportDatabaseSchema(ctx, database)
+			if err != nil {
+				return err
+			}
+
+			exported := make(map[string]bool)
+			for _, file := range files {
+				path, err := getSchemaFilePath(dir, file.Path)
+				if err != nil {
+					return err
+				}
+				exported[path] = true
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					return errors.Wrapf(err, "failed to create directory for %s", path)
+				}
+				if err := os.WriteFile(path, []byte(file.Content), 0644); err != nil {
+					return errors.Wrapf(err, "failed to write file %s", path)
+				}
+			}
+			if prune {
+				if err := pruneSchemaFiles(dir, exported); err != nil {
+					return err
+				}
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Exported %d files to %s\n", len(files), dir)
+			return err
+		},
+	}
+
+	server.register(exportCmd)
+	exportCmd.Flags().StringVar(&database, "database", "", "Database to export, e.g. instances/prod/databases/hr.")
+	exportCmd.Flags().StringVar(&dir, "dir", "", "Directory of the schema tree.")
+	exportCmd.Flags().BoolVar(&prune, "prune", false, "Remove the *.sql files in the directory which are not exported, e.g. the files of the dropped objects.")
+	_ = exportCmd.MarkFlagRequired("database")
+	_ = exportCmd.MarkFlagRequired("dir")
+	return exportCmd
+}
+
+func newSchemaAssembleCmd() *cobra.Command {
+	var (
+		engine string
+		dir    string
+		output string
+	)
+	assembleCmd := &cobra.Command{
+		Use:   "assemble",
+		Short: "Assembles the schema tree into the SDL schema.",
+		Long:  schemaAssembleUsage,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			engineValue, ok := storepb.Engine_value[strings.ToUpper(engine)]
+			if !ok || engineValue == int32(storepb.Engine_ENGINE_UNSPECIFIED) {
+				return errors.Errorf("unsupported engine %q", engine)
+			}
+			files, err := readSchemaTree(dir)
+			if err != nil {
+				return err
+			}
+			sdl, err := schematree.Assemble(storepb.Engine(engineValue), files)
+			if err != nil {
+				return err
+			}
+
+			var out io.Writer = cmd.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return errors.Wrapf(err, "failed to create output file %s", output)
+				}
+				defer f.Close()
+				out = f
+			}
+			_, err = io.WriteString(out, sdl)
+			return err
+		},
+	}
+
+	assembleCmd.Flags().StringVar(&engine, "engine", "", "Database engine, MYSQL, MARIADB, TIDB, OCEANBASE, POSTGRES, ORACLE, DM, OCEANBASE_ORACLE, SNOWFLAKE, CLICKHOUSE, SQLITE or SPANNER.")
+	assembleCmd.Flags().StringVar(&dir, "dir", "", "Directory of the schema tree.")
+	assembleCmd.Flags().StringVar(&output, "output", "", "File to write the SDL schema. Output to stdout if unspecified.")
+	_ = assembleCmd.MarkFlagRequired("engine")
+	_ = assembleCmd.MarkFlagRequired("dir")
+	return assembleCmd
+}
+
+// getSchemaFilePath returns the local path of the file in the schema tree, the path must not escape the directory.
+func getSchemaFilePath(dir, path string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(path)) {
+		return "", errors.Errorf("invalid path %q in the schema tree", path)
+	}
+	return filepath.Join(dir, filepath.FromSlash(path)), nil
+}
+
+// readSchemaTree reads the *.sql files in the directory as the schema tree.
+func readSchemaTree(dir string) ([]*schematree.File, error) {
+	var files []*schematree.File
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".sql" {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read file %s", path)
+		}
+		files = append(files, &schematree.File{Path: filepath.ToSlash(rel), Content: string(content)})
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to walk directory %s", dir)
+	}
+	return files, nil
+}
+
+// pruneSchemaFiles removes the *.sql files in the directory which are not exported.
+func pruneSchemaFiles(dir string, exported map[string]bool) error {
+	var stale []string
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Ext(path) == ".sql" && !exported[path] {
+			stale = append(stale, path)
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "failed to walk directory %s", dir)
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return errors.Wrapf(err, "failed to remove file %s", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const workspaceUsage = `Sync the workspace configuration of a Bytebase server to the declarative YAML file.
+
+The top-level sections are environments, instances, projects, policies and settings.
+The entries are the resources in the JSON form of the v1 API identified by the name,
+and the projects accept the extra iamPolicy field for the members.
+Only the fields specified in an entry are managed. The environments, instances and projects
+which are not in a specified section are deleted, the policies and the settings are never deleted.
+Apply the fingerprint of the plan, and the deletes are only applied with --allow-deletes.
+The passwords and the SSL certificates of the data sources are only set when the data sources are created.
+
+Example:
+  environments:
+    - name: environments/prod
+      title: Prod
+      order: 1
+      tier: PROTECTED
+  instances:
+    - name: instances/mysql-prod
+      title: MySQL Prod
+      engine: MYSQL
+      environment: environments/prod
+      dataSources:
+        - id: admin
+          type: ADMIN
+          host: 10.0.0.1
+          port: "3306"
+          username: bytebase
+  projects:
+    - name: projects/hr
+      title: HR
+      key: HR
+      iamPolicy:
+        bindings:
+          - role: roles/OWNER
+            members: [user:alice@example.com]
+  policies:
+    - name: environments/prod/policies/sql_review
+      sqlReviewPolicy:
+        name: Prod
+        rules: []
+  settings:
+    - name: settings/bb.workspace.approval
+      value:
+        workspaceApprovalSettingValue:
+          rules: []
+`
+
+func newWorkspaceCmd() *cobra.Command {
+	workspaceCmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Syncs the workspace configuration to the declarative YAML file.",
+		Long:  workspaceUsage,
+	}
+	workspaceCmd.AddCommand(
+		newWorkspacePlanCmd(),
+		newWorkspaceApplyCmd(),
+	)
+	return workspaceCmd
+}
+
+func newWorkspacePlanCmd() *cobra.Command {
+	var (
+		server serverFlags
+		file   string
+		format string
+	)
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Shows the changes to sync the workspace to the configuration file.",
+		Long: `Shows the changes to sync the workspace to the configuration file and the fingerprint to apply them.
+
+Examples:
+  bb workspace plan --url https://bytebase.example.com --file workspace.yaml
+  bb workspace plan --file workspace.yaml --format json
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := validateOutputFormat(format); err != nil {
+				return err
+			}
+			config, err := readWorkspaceConfig(file)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			client, err := newAPIClient(ctx, &server)
+			if err != nil {
+				return err
+			}
+			resp, err := client.planWorkspaceConfig(ctx, config)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if format == outputFormatJSON {
+				return writeMessage(out, resp)
+			}
+			if err := writeWorkspaceConfigChanges(out, resp.Changes); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(out, "Fingerprint: %s\n", resp.Fingerprint)
+			return err
+		},
+	}
+
+	server.register(cmd)
+	cmd.Flags().StringVar(&file, "file", "", "The workspace configuration file in YAML.")
+	cmd.Flags().StringVar(&format, "format", outputFormatText, "Output format, text or json.")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func newWorkspaceApplyCmd() *cobra.Command {
+	var (
+		server       serverFlags
+		file         string
+		fingerprint  string
+		allowDeletes bool
+		format       string
+	)
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Applies the changes to sync the workspace to the configuration file.",
+		Long: `Applies the changes to sync the workspace to the configuration file.
+The configuration is rejected if its plan no longer matches the fingerprint of "bb workspace plan",
+or the plan deletes any resource without --allow-deletes.
+
+Examples:
+  bb workspace apply --url https://bytebase.example.com --file workspace.yaml --fingerprint 3f2a...
+  bb workspace apply --file workspace.yaml --fingerprint 3f2a... --allow-deletes --format json
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := validateOutputFormat(format); err != nil {
+				return err
+			}
+			config, err := readWorkspaceConfig(file)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			client, err := newAPIClient(ctx, &server)
+			if err != nil {
+				return err
+			}
+			resp, err := client.applyWorkspaceConfig(ctx, &v1pb.ApplyWorkspaceConfigRequest{
+				Config:       config,
+				Fingerprint:  fingerprint,
+				AllowDeletes: allowDeletes,
+			})
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if format == outputFormatJSON {
+				return writeMessage(out, resp)
+			}
+			return writeWorkspaceConfigChanges(out, resp.Changes)
+		},
+	}
+
+	server.register(cmd)
+	cmd.Flags().StringVar(&file, "file", "", "The workspace configuration file in YAML.")
+	cmd.Flags().StringVar(&fingerprint, "fingerprint", "", "The fingerprint of the plan from bb workspace plan.")
+	cmd.Flags().BoolVar(&allowDeletes, "allow-deletes", false, "Apply the planned deletes.")
+	cmd.Flags().StringVar(&format, "format", outputFormatText, "Output format, text or json.")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.MarkFlagRequired("fingerprint")
+	return cmd
+}
+
+func readWorkspaceConfig(file string) (string, error) {
+	config, err := os.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read file %s", file)
+	}
+	return string(config), nil
+}
+
+func writeWorkspaceConfigChanges(out io.Writer, changes []*v1pb.WorkspaceConfigChange) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(out, "No changes, the workspace is up to date.")
+		return err
+	}
+	for _, change := range changes {
+		line := fmt.Sprintf("%-8s %s", strings.ToLower(change.Action.String()), change.Resource)
+		if len(change.Fields) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(change.Fields, ", "))
+		}
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -10,10 +10,45 @@ import (
 	_ "github.com/bytebase/bytebase/backend/plugin/db/mysql"
 	// Register postgres driver.
 	_ "github.com/bytebase/bytebase/backend/plugin/db/pg"
+	// Register sqlite driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/db/sqlite"
+	// Register oracle driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/db/oracle"
+	// Register snowflake driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/db/snowflake"
+	// Register clickhouse driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/db/clickhouse"
+	// Register spanner driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/db/spanner"
+	// Register mssql driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/db/mssql"
+	// Register redshift driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/db/redshift"
+
+	// Register pingcap parser driver.
+	_ "github.com/pingcap/tidb/pkg/types/parser_driver"
+	// Register tidb advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/tidb"
+	// Register mysql advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/mysql"
+	// Register postgresql advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/pg"
+	// Register oracle advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/oracle"
+	// Register snowflake advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/snowflake"
+	// Register mssql advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/mssql"
+	// Register clickhouse advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/clickhouse"
+	// Register spanner advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/spanner"
+	// Register postgres parser driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -31,6 +31,10 @@ import (
 	_ "github.com/bytebase/bytebase/backend/plugin/advisor/snowflake"
 	// Register mssql advisor.
 	_ "github.com/bytebase/bytebase/backend/plugin/advisor/mssql"
+	// Register clickhouse advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/clickhouse"
+	// Register spanner advisor.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/spanner"
 
 	// Register postgres parser driver.
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
@@ -12,4 +12,7 @@ const (
 	LoopbackContextKey
 	// UserContextKey is the key name used to store user message in the context.
 	UserContextKey
+	// PersonalAccessTokenContextKey is the key name used to store the personal access token message in the context
+	// when the request is authenticated by a personal access token.
+	PersonalAccessTokenContextKey
 )
@@ -84,6 +84,18 @@ var ProjectMemberCELAttributes = []cel.EnvOption{
 	cel.ParserExpressionSizeLimit(celLimit),
 }
 
+// WorkloadIdentityCELAttributes are the variables when evaluating the claims of workload identity tokens.
+var WorkloadIdentityCELAttributes = []cel.EnvOption{
+	cel.Variable("claims", cel.MapType(cel.StringType, cel.DynType)),
+	cel.ParserExpressionSizeLimit(celLimit),
+}
+
+// SQLReviewCustomRuleCELAttributes are the variables when evaluating the custom SQL review rules.
+var SQLReviewCustomRuleCELAttributes = []cel.EnvOption{
+	cel.Variable("statement", cel.MapType(cel.StringType, cel.DynType)),
+	cel.ParserExpressionSizeLimit(celLimit),
+}
+
 // ConvertParsedRisk converts parsed risk to unparsed format.
 func ConvertParsedRisk(expression *exprproto.ParsedExpr) (*expr.Expr, error) {
 	if expression == nil || expression.Expr == nil {
@@ -49,6 +49,11 @@ const (
 	TaskTypeDropPrimaryKey Code = 408
 	TaskTypeDropForeignKey Code = 409
 	TaskTypeDropCheck      Code = 410
+
+	// 501 production safety error.
+	DbBlockingTransaction   Code = 501
+	DbReplicaLag            Code = 502
+	DbInsufficientDiskSpace Code = 503
 )
 
 // Int returns the int type of code.
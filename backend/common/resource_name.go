@@ -46,6 +46,7 @@ const (
 	BranchPrefix                 = "branches/"
 	DeploymentConfigPrefix       = "deploymentConfigs/"
 	ChangelistsPrefix            = "changelists/"
+	PersonalAccessTokenPrefix    = "personalAccessTokens/"
 
 	BackupSettingSuffix   = "/backupSetting"
 	SchemaSuffix          = "/schema"
@@ -222,6 +223,23 @@ func GetUserID(name string) (int, error) {
 	return GetUIDFromName(name, UserNamePrefix)
 }
 
+// GetUserIDPersonalAccessTokenID returns the user ID and personal access token ID from a resource name.
+func GetUserIDPersonalAccessTokenID(name string) (int, int, error) {
+	tokens, err := GetNameParentTokens(name, UserNamePrefix, PersonalAccessTokenPrefix)
+	if err != nil {
+		return 0, 0, err
+	}
+	userID, err := strconv.Atoi(tokens[0])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid user ID %q", tokens[0])
+	}
+	tokenID, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid personal access token ID %q", tokens[1])
+	}
+	return userID, tokenID, nil
+}
+
 // GetUserEmail returns the user email from a resource name.
 func GetUserEmail(name string) (string, error) {
 	tokens, err := GetNameParentTokens(name, UserNamePrefix)
@@ -27,6 +27,16 @@ func TestGetProjectAndBranchID(t *testing.T) {
 	require.Equal(t, "vv", branchID)
 }
 
+func TestGetUserIDPersonalAccessTokenID(t *testing.T) {
+	userID, tokenID, err := GetUserIDPersonalAccessTokenID("users/101/personalAccessTokens/102")
+	require.NoError(t, err)
+	require.Equal(t, 101, userID)
+	require.Equal(t, 102, tokenID)
+
+	_, _, err = GetUserIDPersonalAccessTokenID("users/101/personalAccessTokens/abc")
+	require.Error(t, err)
+}
+
 func TestGetSchemaTableName(t *testing.T) {
 	schema, table, err := GetSchemaTableName("schemas/a/tables/b")
 	require.NoError(t, err)
@@ -216,12 +216,19 @@ func (m *Manager) BatchCreateActivitiesForSkipTasks(ctx context.Context, tasks [
 // BatchCreateActivitiesForCancelTaskRuns creates activities for cancelling task runs.
 func (m *Manager) BatchCreateActivitiesForCancelTaskRuns(ctx context.Context, tasks []*store.TaskMessage, issue *store.IssueMessage, comment string, updaterUID int) error {
 	var creates []*store.ActivityMessage
+	now := time.Now().Unix()
 	for _, task := range tasks {
+		var earliestAllowedTs int64
+		// The canceled task run was scheduled to run at the earliest allowed time.
+		if task.EarliestAllowedTs > now {
+			earliestAllowedTs = task.EarliestAllowedTs
+		}
 		payload, err := json.Marshal(api.ActivityPipelineTaskRunStatusUpdatePayload{
-			TaskID:    task.ID,
-			NewStatus: api.TaskRunCanceled,
-			IssueName: issue.Title,
-			TaskName:  task.Name,
+			TaskID:            task.ID,
+			NewStatus:         api.TaskRunCanceled,
+			EarliestAllowedTs: earliestAllowedTs,
+			IssueName:         issue.Title,
+			TaskName:          task.Name,
 		})
 		if err != nil {
 			return errors.Wrapf(err, "failed to marshal activity after changing the task status: %v", task.Name)
@@ -0,0 +1,160 @@
+package deploymentwindow
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxSearchDays is the maximum number of days to search for the next or previous cron activation.
+// It covers the leap day schedules like "0 0 29 2 *".
+const maxSearchDays = 366 * 8
+
+// schedule is a parsed standard five-field cron expression: minute, hour, day of month, month and day of week.
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar record whether the day of month and day of week fields are "*".
+	// If neither is "*", a day matches when either field matches.
+	domStar, dowStar bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// parseCron parses the standard five-field cron expression.
+// Each field supports "*", values, ranges "a-b", steps "*/n" and "a-b/n", and lists separated by ",".
+func parseCron(expression string) (*schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, errors.Errorf("invalid cron expression %q, expecting 5 fields: minute hour day-of-month month day-of-week", expression)
+	}
+	var bits [5]uint64
+	for i, field := range fields {
+		v, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cron expression %q", expression)
+		}
+		bits[i] = v
+	}
+	// Both 0 and 7 are Sunday.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &schedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangeText, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			v, err := strconv.Atoi(stepText)
+			if err != nil || v <= 0 {
+				return 0, errors.Errorf("invalid step %q in %s field", stepText, f.name)
+			}
+			step = v
+		}
+		start, end := f.min, f.max
+		if rangeText != "*" {
+			startText, endText, isRange := strings.Cut(rangeText, "-")
+			v, err := parseCronValue(startText, f)
+			if err != nil {
+				return 0, err
+			}
+			start, end = v, v
+			if isRange {
+				if end, err = parseCronValue(endText, f); err != nil {
+					return 0, err
+				}
+				if end < start {
+					return 0, errors.Errorf("invalid range %q in %s field", rangeText, f.name)
+				}
+			} else if hasStep {
+				end = f.max
+			}
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(s string, f cronField) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errors.Errorf("invalid value %q in %s field, expecting %d-%d", s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+func (s *schedule) matchDay(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// next returns the earliest activation strictly after t, or the zero time if there is none.
+func (s *schedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(0, 0, maxSearchDays)
+	for t.Before(limit) {
+		switch {
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// prev returns the latest activation at or before t, or the zero time if there is none.
+func (s *schedule) prev(t time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	limit := t.AddDate(0, 0, -maxSearchDays)
+	for t.After(limit) {
+		switch {
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(-time.Minute)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(-time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
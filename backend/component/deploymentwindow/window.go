@@ -0,0 +1,167 @@
+// Package deploymentwindow evaluates the deployment window policy of environments,
+// which restricts task runs to recurring maintenance windows and blocks them in one-off freeze windows.
+package deploymentwindow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// maxBlockedIterations bounds the search for the next allowed time through the alternating freeze and maintenance windows.
+const maxBlockedIterations = 100
+
+// Block describes why the tasks are not allowed to run at the moment.
+type Block struct {
+	Reason string
+	// Until is the next time the tasks are allowed to run, or the zero time if there is no such time.
+	Until time.Time
+}
+
+func (b *Block) String() string {
+	if b.Until.IsZero() {
+		return b.Reason
+	}
+	return fmt.Sprintf("%s, the tasks will run at %s", b.Reason, b.Until.Format(time.RFC3339))
+}
+
+type maintenanceWindow struct {
+	title    string
+	schedule *schedule
+	duration time.Duration
+}
+
+type freezeWindow struct {
+	title      string
+	start, end time.Time
+}
+
+type window struct {
+	location    *time.Location
+	maintenance []maintenanceWindow
+	freezes     []freezeWindow
+}
+
+// Validate validates the deployment window policy.
+func Validate(policy *storepb.DeploymentWindowPolicy) error {
+	_, err := newWindow(policy)
+	return err
+}
+
+// Check returns the block if the tasks aren't allowed to run at now by the policy, or nil otherwise.
+// A nil policy allows the tasks to run at any time.
+func Check(policy *storepb.DeploymentWindowPolicy, now time.Time) (*Block, error) {
+	if policy == nil {
+		return nil, nil
+	}
+	w, err := newWindow(policy)
+	if err != nil {
+		return nil, err
+	}
+	reason := w.getBlockReason(now)
+	if reason == "" {
+		return nil, nil
+	}
+
+	until := now
+	for i := 0; i < maxBlockedIterations; i++ {
+		next := w.getNextCandidate(until)
+		if next.IsZero() {
+			return &Block{Reason: reason}, nil
+		}
+		until = next
+		if w.getBlockReason(until) == "" {
+			return &Block{Reason: reason, Until: until}, nil
+		}
+	}
+	return &Block{Reason: reason}, nil
+}
+
+func newWindow(policy *storepb.DeploymentWindowPolicy) (*window, error) {
+	location := time.UTC
+	if policy.TimeZone != "" {
+		l, err := time.LoadLocation(policy.TimeZone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid time zone %q", policy.TimeZone)
+		}
+		location = l
+	}
+	w := &window{location: location}
+	for _, m := range policy.MaintenanceWindows {
+		schedule, err := parseCron(m.Cron)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid maintenance window %q", m.Title)
+		}
+		duration := m.Duration.AsDuration()
+		if duration <= 0 {
+			return nil, errors.Errorf("invalid maintenance window %q, the duration must be positive", m.Title)
+		}
+		w.maintenance = append(w.maintenance, maintenanceWindow{title: m.Title, schedule: schedule, duration: duration})
+	}
+	for _, f := range policy.FreezeWindows {
+		start, end := f.StartTime.AsTime(), f.EndTime.AsTime()
+		if f.StartTime == nil || f.EndTime == nil || !start.Before(end) {
+			return nil, errors.Errorf("invalid freeze window %q, the start time must be before the end time", f.Title)
+		}
+		w.freezes = append(w.freezes, freezeWindow{title: f.Title, start: start, end: end})
+	}
+	return w, nil
+}
+
+// getBlockReason returns the reason if the tasks aren't allowed to run at t, or an empty string otherwise.
+func (w *window) getBlockReason(t time.Time) string {
+	if f := w.getFreeze(t); f != nil {
+		return fmt.Sprintf("the environment is in the freeze window %q until %s", f.title, f.end.Format(time.RFC3339))
+	}
+	if len(w.maintenance) > 0 && !w.inMaintenance(t) {
+		return "the environment is outside its maintenance windows"
+	}
+	return ""
+}
+
+func (w *window) getFreeze(t time.Time) *freezeWindow {
+	var freeze *freezeWindow
+	for i, f := range w.freezes {
+		if f.start.After(t) || !f.end.After(t) {
+			continue
+		}
+		// Use the freeze window ending the last for the overlapping ones.
+		if freeze == nil || f.end.After(freeze.end) {
+			freeze = &w.freezes[i]
+		}
+	}
+	return freeze
+}
+
+func (w *window) inMaintenance(t time.Time) bool {
+	t = t.In(w.location)
+	for _, m := range w.maintenance {
+		start := m.schedule.prev(t)
+		if !start.IsZero() && t.Before(start.Add(m.duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+// getNextCandidate returns the next time after t that may allow the tasks to run,
+// which is the end of the current freeze window or the start of the next maintenance window.
+func (w *window) getNextCandidate(t time.Time) time.Time {
+	if f := w.getFreeze(t); f != nil {
+		return f.end
+	}
+	var next time.Time
+	for _, m := range w.maintenance {
+		start := m.schedule.next(t.In(w.location))
+		if start.IsZero() {
+			continue
+		}
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return next
+}
@@ -0,0 +1,132 @@
+package deploymentwindow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestParseCron(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		expression string
+		now        time.Time
+		next       time.Time
+		prev       time.Time
+	}{
+		{
+			expression: "0 22 * * 1-5",
+			// Friday.
+			now:  time.Date(2023, 12, 22, 23, 0, 0, 0, time.UTC),
+			next: time.Date(2023, 12, 25, 22, 0, 0, 0, time.UTC),
+			prev: time.Date(2023, 12, 22, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			expression: "*/15 9-10 * * *",
+			now:        time.Date(2023, 12, 22, 10, 50, 0, 0, time.UTC),
+			next:       time.Date(2023, 12, 23, 9, 0, 0, 0, time.UTC),
+			prev:       time.Date(2023, 12, 22, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			// Sunday can be 0 or 7.
+			expression: "30 1 * * 7",
+			now:        time.Date(2023, 12, 22, 0, 0, 0, 0, time.UTC),
+			next:       time.Date(2023, 12, 24, 1, 30, 0, 0, time.UTC),
+			prev:       time.Date(2023, 12, 17, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			// The day of month or the day of week matches if both are restricted.
+			expression: "0 0 1 * 1",
+			now:        time.Date(2023, 12, 22, 0, 0, 0, 0, time.UTC),
+			next:       time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC),
+			prev:       time.Date(2023, 12, 18, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			expression: "0 0 29 2 *",
+			now:        time.Date(2023, 12, 22, 0, 0, 0, 0, time.UTC),
+			next:       time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			prev:       time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, test := range tests {
+		schedule, err := parseCron(test.expression)
+		a.NoError(err, test.expression)
+		a.Equal(test.next, schedule.next(test.now), test.expression)
+		a.Equal(test.prev, schedule.prev(test.now), test.expression)
+	}
+
+	for _, invalid := range []string{"* * * *", "60 * * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := parseCron(invalid)
+		a.Error(err, invalid)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	a := require.New(t)
+	policy := &storepb.DeploymentWindowPolicy{
+		TimeZone: "Asia/Shanghai",
+		MaintenanceWindows: []*storepb.DeploymentWindowPolicy_MaintenanceWindow{
+			{Title: "Nightly", Cron: "0 22 * * *", Duration: durationpb.New(4 * time.Hour)},
+		},
+		FreezeWindows: []*storepb.DeploymentWindowPolicy_FreezeWindow{
+			{
+				Title:     "New Year",
+				StartTime: timestamppb.New(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)),
+				EndTime:   timestamppb.New(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	location, err := time.LoadLocation("Asia/Shanghai")
+	a.NoError(err)
+
+	// In the maintenance window across midnight.
+	block, err := Check(policy, time.Date(2023, 12, 22, 1, 0, 0, 0, location))
+	a.NoError(err)
+	a.Nil(block)
+
+	// Outside the maintenance window.
+	block, err = Check(policy, time.Date(2023, 12, 22, 12, 0, 0, 0, location))
+	a.NoError(err)
+	a.NotNil(block)
+	a.Contains(block.Reason, "outside its maintenance windows")
+	a.True(time.Date(2023, 12, 22, 22, 0, 0, 0, location).Equal(block.Until))
+
+	// In the freeze window, the tasks run in the first maintenance window after the freeze.
+	block, err = Check(policy, time.Date(2023, 12, 31, 23, 0, 0, 0, location))
+	a.NoError(err)
+	a.NotNil(block)
+	a.Contains(block.Reason, `freeze window "New Year"`)
+	a.True(time.Date(2024, 1, 2, 22, 0, 0, 0, location).Equal(block.Until))
+
+	// Without maintenance windows, only the freeze windows block.
+	policy.MaintenanceWindows = nil
+	block, err = Check(policy, time.Date(2023, 12, 22, 12, 0, 0, 0, location))
+	a.NoError(err)
+	a.Nil(block)
+	block, err = Check(policy, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	a.NoError(err)
+	a.True(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Equal(block.Until))
+
+	block, err = Check(nil, time.Now())
+	a.NoError(err)
+	a.Nil(block)
+}
+
+func TestValidate(t *testing.T) {
+	a := require.New(t)
+	a.Error(Validate(&storepb.DeploymentWindowPolicy{TimeZone: "Mars/Olympus"}))
+	a.Error(Validate(&storepb.DeploymentWindowPolicy{
+		MaintenanceWindows: []*storepb.DeploymentWindowPolicy_MaintenanceWindow{{Cron: "0 22 * * *"}},
+	}))
+	a.Error(Validate(&storepb.DeploymentWindowPolicy{
+		FreezeWindows: []*storepb.DeploymentWindowPolicy_FreezeWindow{
+			{StartTime: timestamppb.New(time.Unix(2, 0)), EndTime: timestamppb.New(time.Unix(1, 0))},
+		},
+	}))
+	a.NoError(Validate(&storepb.DeploymentWindowPolicy{}))
+}
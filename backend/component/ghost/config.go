@@ -81,6 +81,9 @@ var knownKeys = map[string]bool{
 	"aliyun-rds":                       true,
 	"azure":                            true,
 	"gcp":                              true,
+	// cut-over-window and cut-over-window-timezone are handled by Bytebase rather than gh-ost.
+	"cut-over-window":          true,
+	"cut-over-window-timezone": true,
 }
 
 func GetUserFlags(flags map[string]string) (*UserFlags, error) {
@@ -199,10 +202,14 @@ func GetUserFlags(flags map[string]string) (*UserFlags, error) {
 		}
 		f.gcp = &gcp
 	}
+	if _, err := GetCutoverWindow(flags); err != nil {
+		return nil, err
+	}
 	return f, nil
 }
 
-func getSocketFilename(taskID int, databaseID int, databaseName string, tableName string) string {
+// GetSocketFilename gets the interactive socket filename for gh-ost.
+func GetSocketFilename(taskID int, databaseID int, databaseName string, tableName string) string {
 	return fmt.Sprintf("/tmp/gh-ost.%v.%v.%v.%v.sock", taskID, databaseID, databaseName, tableName)
 }
 
@@ -273,7 +280,7 @@ func NewMigrationContext(taskID int, database *store.DatabaseMessage, dataSource
 		}
 		migrationContext.OriginalTableName = parser.GetExplicitTable()
 	}
-	migrationContext.ServeSocketFile = getSocketFilename(taskID, database.UID, database.DatabaseName, tableName)
+	migrationContext.ServeSocketFile = GetSocketFilename(taskID, database.UID, database.DatabaseName, tableName)
 	migrationContext.PostponeCutOverFlagFile = GetPostponeFlagFilename(taskID, database.UID, database.DatabaseName, tableName)
 	migrationContext.TimestampAllTable = defaultConfig.timestampAllTable
 	migrationContext.SetHeartbeatIntervalMilliseconds(defaultConfig.heartbeatIntervalMilliseconds)
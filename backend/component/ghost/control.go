@@ -0,0 +1,75 @@
+package ghost
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/github/gh-ost/go/base"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const socketTimeout = 5 * time.Second
+
+// SendCommand sends the interactive command to the gh-ost socket and returns the response.
+// gh-ost serves one command per connection and closes the connection after the response.
+// See https://github.com/github/gh-ost/blob/master/doc/interactive-commands.md.
+func SendCommand(socketFile string, command string) (string, error) {
+	conn, err := net.DialTimeout("unix", socketFile, socketTimeout)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to connect to gh-ost socket %q, the migration may not be running", socketFile)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(socketTimeout)); err != nil {
+		return "", errors.Wrap(err, "failed to set gh-ost socket deadline")
+	}
+	if _, err := fmt.Fprintln(conn, command); err != nil {
+		return "", errors.Wrapf(err, "failed to send gh-ost command %q", command)
+	}
+	response, err := io.ReadAll(conn)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read gh-ost response for command %q", command)
+	}
+	return string(response), nil
+}
+
+// GetProgress gets the progress of the gh-ost migration.
+func GetProgress(migrationContext *base.MigrationContext) *v1pb.TaskRun_ExecutionDetail_GhostProgress {
+	throttled, reason, _ := migrationContext.IsThrottled()
+	return &v1pb.TaskRun_ExecutionDetail_GhostProgress{
+		RowsCopied:     migrationContext.GetTotalRowsCopied(),
+		RowsEstimate:   atomic.LoadInt64(&migrationContext.RowsEstimate) + atomic.LoadInt64(&migrationContext.RowsDeltaEstimate),
+		Eta:            durationpb.New(migrationContext.GetETADuration()),
+		Lag:            durationpb.New(migrationContext.GetCurrentLagDuration()),
+		Throttled:      throttled,
+		ThrottleReason: reason,
+		ChunkSize:      atomic.LoadInt64(&migrationContext.ChunkSize),
+		MaxLagMillis:   atomic.LoadInt64(&migrationContext.MaxLagMillisecondsThrottleThreshold),
+		NiceRatio:      migrationContext.GetNiceRatio(),
+	}
+}
+
+// GetStatusLine formats the progress of the gh-ost migration as a status line similar to the one printed by gh-ost.
+func GetStatusLine(progress *v1pb.TaskRun_ExecutionDetail_GhostProgress) string {
+	var percent float64
+	if progress.RowsEstimate > 0 {
+		percent = 100 * float64(progress.RowsCopied) / float64(progress.RowsEstimate)
+	}
+	state := "migrating"
+	if progress.Throttled {
+		state = fmt.Sprintf("throttled, %s", progress.ThrottleReason)
+	}
+	return fmt.Sprintf("Copy: %d/%d %.1f%%; Lag: %s; ETA: %s; State: %s",
+		progress.RowsCopied,
+		progress.RowsEstimate,
+		percent,
+		progress.Lag.AsDuration().Round(time.Millisecond),
+		progress.Eta.AsDuration().Round(time.Second),
+		state,
+	)
+}
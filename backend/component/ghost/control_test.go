@@ -0,0 +1,28 @@
+package ghost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestGetStatusLine(t *testing.T) {
+	a := require.New(t)
+	progress := &v1pb.TaskRun_ExecutionDetail_GhostProgress{
+		RowsCopied:   250,
+		RowsEstimate: 1000,
+		Eta:          durationpb.New(90 * time.Second),
+		Lag:          durationpb.New(1500 * time.Millisecond),
+	}
+	a.Equal("Copy: 250/1000 25.0%; Lag: 1.5s; ETA: 1m30s; State: migrating", GetStatusLine(progress))
+
+	progress.Throttled = true
+	progress.ThrottleReason = "lag=3s"
+	a.Equal("Copy: 250/1000 25.0%; Lag: 1.5s; ETA: 1m30s; State: throttled, lag=3s", GetStatusLine(progress))
+
+	a.Equal("Copy: 0/0 0.0%; Lag: 0s; ETA: 0s; State: migrating", GetStatusLine(&v1pb.TaskRun_ExecutionDetail_GhostProgress{}))
+}
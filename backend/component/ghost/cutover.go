@@ -0,0 +1,103 @@
+package ghost
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CutoverWindow is the daily time window in which gh-ost is allowed to cut over.
+// The window may wrap around midnight, e.g. 22:00-02:00.
+type CutoverWindow struct {
+	// start and end are the offsets from midnight.
+	start    time.Duration
+	end      time.Duration
+	location *time.Location
+}
+
+// ParseCutoverWindow parses the cutover window in "HH:MM-HH:MM" format in the timezone.
+// An empty timezone means UTC.
+func ParseCutoverWindow(window string, timezone string) (*CutoverWindow, error) {
+	startText, endText, ok := strings.Cut(window, "-")
+	if !ok {
+		return nil, errors.Errorf("invalid cut-over-window %q, expecting HH:MM-HH:MM", window)
+	}
+	start, err := parseTimeOfDay(startText)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cut-over-window %q", window)
+	}
+	end, err := parseTimeOfDay(endText)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cut-over-window %q", window)
+	}
+	if start == end {
+		return nil, errors.Errorf("invalid cut-over-window %q, the start and end must differ", window)
+	}
+	location := time.UTC
+	if timezone != "" {
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cut-over-window-timezone %q", timezone)
+		}
+	}
+	return &CutoverWindow{start: start, end: end, location: location}, nil
+}
+
+// GetCutoverWindow gets the cutover window from the gh-ost flags.
+// It returns nil if the cutover isn't restricted to a window.
+func GetCutoverWindow(flags map[string]string) (*CutoverWindow, error) {
+	window, ok := flags["cut-over-window"]
+	if !ok {
+		if _, ok := flags["cut-over-window-timezone"]; ok {
+			return nil, errors.Errorf("cut-over-window-timezone requires cut-over-window")
+		}
+		return nil, nil
+	}
+	return ParseCutoverWindow(window, flags["cut-over-window-timezone"])
+}
+
+// Contains returns whether t is in the window.
+func (w *CutoverWindow) Contains(t time.Time) bool {
+	offset := w.getOffset(t)
+	if w.start < w.end {
+		return w.start <= offset && offset < w.end
+	}
+	return offset >= w.start || offset < w.end
+}
+
+// NextStart returns t if t is in the window, or the next time the window opens otherwise.
+func (w *CutoverWindow) NextStart(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+	t = t.In(w.location)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, w.location)
+	next := midnight.Add(w.start)
+	if !next.After(t) {
+		next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, w.location).Add(w.start)
+	}
+	return next
+}
+
+func (w *CutoverWindow) String() string {
+	return fmt.Sprintf("%s-%s %s", formatTimeOfDay(w.start), formatTimeOfDay(w.end), w.location)
+}
+
+func (w *CutoverWindow) getOffset(t time.Time) time.Duration {
+	hour, minute, second := t.In(w.location).Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, errors.Errorf("invalid time %q, expecting HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
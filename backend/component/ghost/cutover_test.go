@@ -0,0 +1,70 @@
+package ghost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCutoverWindow(t *testing.T) {
+	a := require.New(t)
+	window, err := ParseCutoverWindow("22:00-02:30", "Asia/Shanghai")
+	a.NoError(err)
+	location, err := time.LoadLocation("Asia/Shanghai")
+	a.NoError(err)
+
+	tests := []struct {
+		now      time.Time
+		contains bool
+		next     time.Time
+	}{
+		{
+			now:      time.Date(2023, 10, 1, 23, 0, 0, 0, location),
+			contains: true,
+			next:     time.Date(2023, 10, 1, 23, 0, 0, 0, location),
+		},
+		{
+			now:      time.Date(2023, 10, 2, 1, 0, 0, 0, location),
+			contains: true,
+			next:     time.Date(2023, 10, 2, 1, 0, 0, 0, location),
+		},
+		{
+			now:  time.Date(2023, 10, 2, 2, 30, 0, 0, location),
+			next: time.Date(2023, 10, 2, 22, 0, 0, 0, location),
+		},
+		{
+			// 12:00 UTC is 20:00 in Shanghai.
+			now:  time.Date(2023, 10, 2, 12, 0, 0, 0, time.UTC),
+			next: time.Date(2023, 10, 2, 22, 0, 0, 0, location),
+		},
+	}
+	for _, test := range tests {
+		a.Equal(test.contains, window.Contains(test.now), test.now)
+		a.True(test.next.Equal(window.NextStart(test.now)), test.now)
+	}
+
+	window, err = ParseCutoverWindow("01:00-03:00", "")
+	a.NoError(err)
+	a.True(time.Date(2023, 10, 3, 1, 0, 0, 0, time.UTC).Equal(window.NextStart(time.Date(2023, 10, 2, 3, 0, 0, 0, time.UTC))))
+	a.Equal("01:00-03:00 UTC", window.String())
+
+	for _, invalid := range []string{"01:00", "25:00-03:00", "01:00-01:00"} {
+		_, err := ParseCutoverWindow(invalid, "")
+		a.Error(err, invalid)
+	}
+	_, err = ParseCutoverWindow("01:00-03:00", "Mars/Olympus")
+	a.Error(err)
+}
+
+func TestGetUserFlagsCutoverWindow(t *testing.T) {
+	a := require.New(t)
+	_, err := GetUserFlags(map[string]string{"cut-over-window": "01:00-03:00", "cut-over-window-timezone": "UTC"})
+	a.NoError(err)
+	_, err = GetUserFlags(map[string]string{"cut-over-window-timezone": "UTC"})
+	a.ErrorContains(err, "requires cut-over-window")
+
+	window, err := GetCutoverWindow(nil)
+	a.NoError(err)
+	a.Nil(window)
+}
@@ -3,10 +3,20 @@ package iam
 import (
 	"context"
 	_ "embed"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
 
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/interpreter"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
 )
@@ -14,6 +24,17 @@ import (
 //go:embed acl.yaml
 var aclYaml []byte
 
+// bindingConditionAttributes are the attributes of the IAM binding conditions, see common.ProjectMemberCELAttributes.
+var bindingConditionAttributes = []string{
+	"resource.environment_name",
+	"resource.database",
+	"resource.schema",
+	"resource.table",
+	"request.statement",
+	"request.row_limit",
+	"request.time",
+}
+
 type acl struct {
 	Roles []struct {
 		Name        string   `yaml:"name"`
@@ -22,8 +43,19 @@ type acl struct {
 }
 
 type Manager struct {
+	// predefinedRoles are the built-in roles defined in acl.yaml.
+	predefinedRoles map[string][]Permission
+
+	// rolesLock protects roles.
+	rolesLock sync.RWMutex
+	// roles are the predefined roles and the custom roles loaded from the store.
 	roles map[string][]Permission
 	store *store.Store
+
+	// bindingConditionEnv is the CEL environment of the IAM binding conditions.
+	bindingConditionEnv *cel.Env
+	// bindingConditions caches the programs of the IAM binding conditions by the expression.
+	bindingConditions *lru.Cache[string, cel.Program]
 }
 
 func NewManager(store *store.Store) (*Manager, error) {
@@ -39,12 +71,67 @@ func NewManager(store *store.Store) (*Manager, error) {
 		}
 	}
 
+	bindingConditionEnv, err := cel.NewEnv(common.ProjectMemberCELAttributes...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create binding condition env")
+	}
+	bindingConditions, err := lru.New[string, cel.Program](1024)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create binding condition cache")
+	}
+
 	return &Manager{
-		roles: roles,
-		store: store,
+		predefinedRoles:     roles,
+		roles:               roles,
+		store:               store,
+		bindingConditionEnv: bindingConditionEnv,
+		bindingConditions:   bindingConditions,
 	}, nil
 }
 
+// ReloadCache reloads the custom roles from the store.
+// It should be called on startup and whenever a custom role is created, updated or deleted.
+func (m *Manager) ReloadCache(ctx context.Context) error {
+	roleMessages, err := m.store.ListRoles(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list roles")
+	}
+
+	roles := make(map[string][]Permission)
+	for role, permissions := range m.predefinedRoles {
+		roles[role] = permissions
+	}
+	for _, roleMessage := range roleMessages {
+		role := convertProjectRole(roleMessage.ResourceID)
+		if _, ok := m.predefinedRoles[role]; ok {
+			continue
+		}
+		var permissions []Permission
+		for _, permission := range roleMessage.Permissions {
+			permissions = append(permissions, Permission(permission))
+		}
+		roles[role] = permissions
+	}
+
+	m.rolesLock.Lock()
+	defer m.rolesLock.Unlock()
+	m.roles = roles
+	return nil
+}
+
+// GetPermissions returns the permissions of the role, e.g. roles/OWNER.
+func (m *Manager) GetPermissions(role string) []Permission {
+	m.rolesLock.RLock()
+	defer m.rolesLock.RUnlock()
+	return m.roles[convertProjectRole(role)]
+}
+
+// IsPredefinedRole returns true if the role, e.g. roles/OWNER, is a built-in role.
+func (m *Manager) IsPredefinedRole(role string) bool {
+	_, ok := m.predefinedRoles[convertProjectRole(role)]
+	return ok
+}
+
 // Check if the user has the permission p
 // or has the permission p in every project.
 func (m *Manager) CheckPermission(ctx context.Context, p Permission, user *store.UserMessage, projectIDs ...string) (bool, error) {
@@ -60,12 +147,39 @@ func (m *Manager) CheckPermission(ctx context.Context, p Permission, user *store
 	return m.hasPermission(p, workspaceRoles, projectRoles), nil
 }
 
+// CheckDatabasePermission checks if the user has the permission p
+// or has the permission p on every database.
+// Unlike CheckPermission, IAM bindings whose conditions are scoped to databases are honored.
+func (m *Manager) CheckDatabasePermission(ctx context.Context, p Permission, user *store.UserMessage, databases ...*store.DatabaseMessage) (bool, error) {
+	workspaceRoles, err := m.getWorkspaceRoles(user)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get workspace roles")
+	}
+	var databaseRoles [][]string
+	for _, database := range databases {
+		iamPolicy, err := m.store.GetProjectPolicy(ctx, &store.GetProjectPolicyMessage{ProjectID: &database.ProjectID})
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get iam policy for project %q", database.ProjectID)
+		}
+		attributes := map[string]any{
+			"request.time":              time.Now(),
+			"resource.database":         common.FormatDatabase(database.InstanceID, database.DatabaseName),
+			"resource.environment_name": fmt.Sprintf("%s%s", common.EnvironmentNamePrefix, database.EffectiveEnvironmentID),
+		}
+		databaseRoles = append(databaseRoles, m.getRolesFromProjectPolicy(user, iamPolicy, attributes))
+	}
+
+	return m.hasPermission(p, workspaceRoles, databaseRoles), nil
+}
+
 func (m *Manager) hasPermission(p Permission, workspaceRoles []string, projectRoles [][]string) bool {
 	return m.hasPermissionOnWorkspace(p, workspaceRoles) ||
 		m.hasPermissionOnEveryProject(p, projectRoles)
 }
 
 func (m *Manager) hasPermissionOnWorkspace(p Permission, workspaceRoles []string) bool {
+	m.rolesLock.RLock()
+	defer m.rolesLock.RUnlock()
 	for _, role := range workspaceRoles {
 		permissions, ok := m.roles[role]
 		if !ok {
@@ -84,6 +198,8 @@ func (m *Manager) hasPermissionOnEveryProject(p Permission, projectRoles [][]str
 	if len(projectRoles) == 0 {
 		return false
 	}
+	m.rolesLock.RLock()
+	defer m.rolesLock.RUnlock()
 	for _, projectRole := range projectRoles {
 		has := false
 		for _, role := range projectRole {
@@ -119,18 +235,29 @@ func (m *Manager) getProjectRoles(ctx context.Context, user *store.UserMessage,
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get iam policy for project %q", projectID)
 		}
-		projectRoles := getRolesFromProjectPolicy(user, iamPolicy)
+		attributes := map[string]any{
+			"request.time": time.Now(),
+		}
+		projectRoles := m.getRolesFromProjectPolicy(user, iamPolicy, attributes)
 		roles = append(roles, projectRoles)
 	}
 	return roles, nil
 }
 
-func getRolesFromProjectPolicy(user *store.UserMessage, policy *store.IAMPolicyMessage) []string {
+func (m *Manager) getRolesFromProjectPolicy(user *store.UserMessage, policy *store.IAMPolicyMessage, attributes map[string]any) []string {
 	var roles []string
 	for _, binding := range policy.Bindings {
-		// TODO(p0ny): eval binding.Condition
 		for _, member := range binding.Members {
 			if member.ID == user.ID || member.Email == api.AllUsers {
+				if binding.Condition != nil {
+					ok, err := m.evalBindingCondition(binding.Condition.Expression, attributes)
+					if err != nil {
+						slog.Debug("failed to evaluate binding condition", log.BBError(err), slog.String("condition", binding.Condition.Expression))
+					}
+					if !ok {
+						break
+					}
+				}
 				roles = append(roles, convertProjectRole(binding.Role.String()))
 				break
 			}
@@ -139,6 +266,52 @@ func getRolesFromProjectPolicy(user *store.UserMessage, policy *store.IAMPolicyM
 	return roles
 }
 
+// evalBindingCondition evaluates the CEL condition of an IAM binding.
+// The attributes absent from the attributes are unknown, for example, resource.database when checking
+// project-level permissions. The condition is satisfied unless it's false regardless of the unknown attributes,
+// so the project-level checks only honor the expiry on request.time and leave the rest to the finer-grained checks.
+func (m *Manager) evalBindingCondition(expression string, attributes map[string]any) (bool, error) {
+	if expression == "" {
+		return true, nil
+	}
+	prg, ok := m.bindingConditions.Get(expression)
+	if !ok {
+		ast, issues := m.bindingConditionEnv.Compile(expression)
+		if issues != nil && issues.Err() != nil {
+			return false, issues.Err()
+		}
+		p, err := m.bindingConditionEnv.Program(ast, cel.EvalOptions(cel.OptPartialEval))
+		if err != nil {
+			return false, err
+		}
+		prg = p
+		m.bindingConditions.Add(expression, prg)
+	}
+
+	var unknowns []*interpreter.AttributePattern
+	for _, attribute := range bindingConditionAttributes {
+		if _, ok := attributes[attribute]; !ok {
+			unknowns = append(unknowns, cel.AttributePattern(attribute))
+		}
+	}
+	vars, err := cel.PartialVars(attributes, unknowns...)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	if types.IsUnknown(out) {
+		return true, nil
+	}
+	val, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.Errorf("expect bool result, got %v", out.Value())
+	}
+	return val, nil
+}
+
 func convertProjectRole(role string) string {
 	switch role {
 	case "OWNER":
@@ -0,0 +1,118 @@
+package iam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/type/expr"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func TestGetRolesFromProjectPolicy(t *testing.T) {
+	a := require.New(t)
+
+	user := &store.UserMessage{ID: 101, Email: "dev@example.com"}
+	policy := &store.IAMPolicyMessage{
+		Bindings: []*store.PolicyBinding{
+			{
+				Role:    api.Developer,
+				Members: []*store.UserMessage{user},
+			},
+			{
+				Role:      api.Role("expired"),
+				Members:   []*store.UserMessage{user},
+				Condition: &expr.Expr{Expression: `request.time < timestamp("2020-01-01T00:00:00Z")`},
+			},
+			{
+				Role:      api.Role("databaseScoped"),
+				Members:   []*store.UserMessage{user},
+				Condition: &expr.Expr{Expression: `resource.database == "instances/prod/databases/db1"`},
+			},
+			{
+				Role:      api.Role("expiredDatabaseScoped"),
+				Members:   []*store.UserMessage{user},
+				Condition: &expr.Expr{Expression: `resource.database == "instances/prod/databases/db1" && request.time < timestamp("2020-01-01T00:00:00Z")`},
+			},
+			{
+				Role:      api.Role("querier"),
+				Members:   []*store.UserMessage{user},
+				Condition: &expr.Expr{Expression: `request.row_limit <= 1000 && request.time < timestamp("2999-01-01T00:00:00Z")`},
+			},
+			{
+				Role:      api.Role("exporter"),
+				Members:   []*store.UserMessage{user},
+				Condition: &expr.Expr{Expression: `request.statement != "" || request.time < timestamp("2020-01-01T00:00:00Z")`},
+			},
+			{
+				Role:    api.Role("others"),
+				Members: []*store.UserMessage{{ID: 102}},
+			},
+		},
+	}
+
+	tests := []struct {
+		attributes map[string]any
+		want       []string
+	}{
+		{
+			// The project-level checks only honor the expiry, and the conditions on the other attributes are left to the finer-grained checks.
+			attributes: map[string]any{
+				"request.time": time.Now(),
+			},
+			want: []string{"roles/projectDeveloper", "roles/databaseScoped", "roles/querier", "roles/exporter"},
+		},
+		{
+			attributes: map[string]any{
+				"request.time":      time.Now(),
+				"resource.database": "instances/prod/databases/db1",
+			},
+			want: []string{"roles/projectDeveloper", "roles/databaseScoped", "roles/querier", "roles/exporter"},
+		},
+		{
+			attributes: map[string]any{
+				"request.time":      time.Now(),
+				"resource.database": "instances/prod/databases/db2",
+			},
+			want: []string{"roles/projectDeveloper", "roles/querier", "roles/exporter"},
+		},
+		{
+			attributes: map[string]any{
+				"request.time":      time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+				"resource.database": "instances/prod/databases/db1",
+			},
+			want: []string{"roles/projectDeveloper", "roles/expired", "roles/databaseScoped", "roles/expiredDatabaseScoped", "roles/querier", "roles/exporter"},
+		},
+	}
+
+	m, err := NewManager(nil)
+	a.NoError(err)
+	for _, test := range tests {
+		// Run twice to use the cached programs.
+		for i := 0; i < 2; i++ {
+			got := m.getRolesFromProjectPolicy(user, policy, test.attributes)
+			a.Equal(test.want, got)
+		}
+	}
+}
+
+func TestCustomRolePermission(t *testing.T) {
+	a := require.New(t)
+
+	m, err := NewManager(nil)
+	a.NoError(err)
+	m.roles = map[string][]Permission{
+		"roles/workspaceMember": m.predefinedRoles["roles/workspaceMember"],
+		"roles/projectQuerier":  m.predefinedRoles["roles/projectQuerier"],
+		"roles/dbViewer":        {PermissionDatabasesGet},
+	}
+
+	a.True(m.hasPermission(PermissionDatabasesGet, []string{"roles/workspaceMember"}, [][]string{{"roles/dbViewer"}}))
+	a.False(m.hasPermission(PermissionDatabasesUpdate, []string{"roles/workspaceMember"}, [][]string{{"roles/dbViewer"}}))
+	a.False(m.hasPermission(PermissionDatabasesGet, []string{"roles/workspaceMember"}, [][]string{{"roles/dbViewer"}, {"roles/projectQuerier"}}))
+	a.Equal([]Permission{PermissionDatabasesGet}, m.GetPermissions("dbViewer"))
+	a.True(m.IsPredefinedRole("OWNER"))
+	a.False(m.IsPredefinedRole("dbViewer"))
+}
@@ -90,3 +90,90 @@ const (
 	PermissionTasksSkip         Permission = "bb.tasks.skip"
 	PermissionTaskRunsCancel    Permission = "bb.taskRuns.cancel"
 )
+
+// PermissionExist returns true if the permission p is defined.
+func PermissionExist(p Permission) bool {
+	//exhaustive:enforce
+	switch p {
+	case
+		PermissionInstancesList,
+		PermissionInstancesGet,
+		PermissionInstancesCreate,
+		PermissionInstancesUpdate,
+		PermissionInstancesDelete,
+		PermissionInstancesUndelete,
+		PermissionInstancesSync,
+		PermissionDatabasesList,
+		PermissionDatabasesGet,
+		PermissionDatabasesUpdate,
+		PermissionDatabasesSync,
+		PermissionDatabasesGetMetadata,
+		PermissionDatabasesUpdateMetadata,
+		PermissionDatabasesGetSchema,
+		PermissionDatabasesGetBackupSetting,
+		PermissionDatabasesUpdateBackupSetting,
+		PermissionBackupsList,
+		PermissionBackupsCreate,
+		PermissionChangeHistoriesList,
+		PermissionChangeHistoriesGet,
+		PermissionDatabaseSecretsList,
+		PermissionDatabaseSecretsUpdate,
+		PermissionDatabaseSecretsDelete,
+		PermissionSlowQueriesList,
+		PermissionEnvironmentsList,
+		PermissionEnvironmentsGet,
+		PermissionEnvironmentsCreate,
+		PermissionEnvironmentsUpdate,
+		PermissionEnvironmentsDelete,
+		PermissionEnvironmentsUndelete,
+		PermissionIssuesList,
+		PermissionIssuesGet,
+		PermissionIssuesCreate,
+		PermissionIssuesUpdate,
+		PermissionIssueCommentsCreate,
+		PermissionIssueCommentsUpdate,
+		PermissionProjectsList,
+		PermissionProjectsGet,
+		PermissionProjectsCreate,
+		PermissionProjectsUpdate,
+		PermissionProjectsDelete,
+		PermissionProjectsUndelete,
+		PermissionProjectsGetIAMPolicy,
+		PermissionProjectsSetIAMPolicy,
+		PermissionRisksList,
+		PermissionRisksCreate,
+		PermissionRisksUpdate,
+		PermissionRisksDelete,
+		PermissionRolesList,
+		PermissionRolesCreate,
+		PermissionRolesUpdate,
+		PermissionRolesDelete,
+		PermissionChangelistsList,
+		PermissionChangelistsGet,
+		PermissionChangelistsUpdate,
+		PermissionChangelistsCreate,
+		PermissionChangelistsDelete,
+		PermissionInstanceRolesList,
+		PermissionInstanceRolesGet,
+		PermissionInstanceRolesCreate,
+		PermissionInstanceRolesUpdate,
+		PermissionInstanceRolesDelete,
+		PermissionInstanceRolesUndelete,
+		PermissionPlansList,
+		PermissionPlansGet,
+		PermissionPlansCreate,
+		PermissionPlansUpdate,
+		PermissionRolloutsGet,
+		PermissionRolloutsCreate,
+		PermissionRolloutsPreview,
+		PermissionTaskRunsList,
+		PermissionPlanCheckRunsList,
+		PermissionPlanCheckRunsRun,
+		PermissionTasksRun,
+		PermissionTasksSkip,
+		PermissionTaskRunsCancel:
+		return true
+	default:
+		return false
+	}
+}
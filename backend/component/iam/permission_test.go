@@ -15,94 +15,8 @@ func TestPermissionExists(t *testing.T) {
 
 	for _, permissions := range m.roles {
 		for _, p := range permissions {
-			exist := permissionExist(p)
+			exist := PermissionExist(p)
 			a.True(exist, "permission %s is not defined as a constant", p)
 		}
 	}
 }
-
-func permissionExist(p Permission) bool {
-	//exhaustive:enforce
-	switch p {
-	case
-		PermissionInstancesList,
-		PermissionInstancesGet,
-		PermissionInstancesCreate,
-		PermissionInstancesUpdate,
-		PermissionInstancesDelete,
-		PermissionInstancesUndelete,
-		PermissionInstancesSync,
-		PermissionDatabasesList,
-		PermissionDatabasesGet,
-		PermissionDatabasesUpdate,
-		PermissionDatabasesSync,
-		PermissionDatabasesGetMetadata,
-		PermissionDatabasesUpdateMetadata,
-		PermissionDatabasesGetSchema,
-		PermissionDatabasesGetBackupSetting,
-		PermissionDatabasesUpdateBackupSetting,
-		PermissionBackupsList,
-		PermissionBackupsCreate,
-		PermissionChangeHistoriesList,
-		PermissionChangeHistoriesGet,
-		PermissionDatabaseSecretsList,
-		PermissionDatabaseSecretsUpdate,
-		PermissionDatabaseSecretsDelete,
-		PermissionSlowQueriesList,
-		PermissionEnvironmentsList,
-		PermissionEnvironmentsGet,
-		PermissionEnvironmentsCreate,
-		PermissionEnvironmentsUpdate,
-		PermissionEnvironmentsDelete,
-		PermissionEnvironmentsUndelete,
-		PermissionIssuesList,
-		PermissionIssuesGet,
-		PermissionIssuesCreate,
-		PermissionIssuesUpdate,
-		PermissionIssueCommentsCreate,
-		PermissionIssueCommentsUpdate,
-		PermissionProjectsList,
-		PermissionProjectsGet,
-		PermissionProjectsCreate,
-		PermissionProjectsUpdate,
-		PermissionProjectsDelete,
-		PermissionProjectsUndelete,
-		PermissionProjectsGetIAMPolicy,
-		PermissionProjectsSetIAMPolicy,
-		PermissionRisksList,
-		PermissionRisksCreate,
-		PermissionRisksUpdate,
-		PermissionRisksDelete,
-		PermissionRolesList,
-		PermissionRolesCreate,
-		PermissionRolesUpdate,
-		PermissionRolesDelete,
-		PermissionChangelistsList,
-		PermissionChangelistsGet,
-		PermissionChangelistsUpdate,
-		PermissionChangelistsCreate,
-		PermissionChangelistsDelete,
-		PermissionInstanceRolesList,
-		PermissionInstanceRolesGet,
-		PermissionInstanceRolesCreate,
-		PermissionInstanceRolesUpdate,
-		PermissionInstanceRolesDelete,
-		PermissionInstanceRolesUndelete,
-		PermissionPlansList,
-		PermissionPlansGet,
-		PermissionPlansCreate,
-		PermissionPlansUpdate,
-		PermissionRolloutsGet,
-		PermissionRolloutsCreate,
-		PermissionRolloutsPreview,
-		PermissionTaskRunsList,
-		PermissionPlanCheckRunsList,
-		PermissionPlanCheckRunsRun,
-		PermissionTasksRun,
-		PermissionTasksSkip,
-		PermissionTaskRunsCancel:
-		return true
-	default:
-		return false
-	}
-}
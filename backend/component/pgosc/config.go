@@ -0,0 +1,117 @@
+// Package pgosc implements the online schema change for PostgreSQL.
+//
+// The altered table is built as a shadow table, the rows are copied in chunks
+// and the changes made meanwhile are applied to the shadow table by the triggers.
+// Finally the shadow table replaces the original table in one transaction.
+package pgosc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+var defaultConfig = struct {
+	chunkSize           int64
+	chunkIntervalMillis int64
+	lockTimeoutMillis   int64
+}{
+	chunkSize:           1000, // chunk-size
+	chunkIntervalMillis: 0,    // chunk-interval-millis
+	lockTimeoutMillis:   3000, // lock-timeout-millis
+}
+
+// UserFlags is the config of the online schema change specified by the user.
+type UserFlags struct {
+	// ChunkSize is the number of rows copied to the shadow table in one statement.
+	ChunkSize int64
+	// ChunkIntervalMillis is the sleep time between the chunks for throttling.
+	ChunkIntervalMillis int64
+	// LockTimeoutMillis is the lock timeout of the statements locking the original table.
+	LockTimeoutMillis int64
+}
+
+var knownKeys = map[string]bool{
+	"chunk-size":            true,
+	"chunk-interval-millis": true,
+	"lock-timeout-millis":   true,
+}
+
+// GetUserFlags gets the user flags with the default values for the unspecified flags.
+func GetUserFlags(flags map[string]string) (*UserFlags, error) {
+	f := &UserFlags{
+		ChunkSize:           defaultConfig.chunkSize,
+		ChunkIntervalMillis: defaultConfig.chunkIntervalMillis,
+		LockTimeoutMillis:   defaultConfig.lockTimeoutMillis,
+	}
+	for k := range flags {
+		if !knownKeys[k] {
+			return nil, errors.Errorf("unsupported flag: %s", k)
+		}
+	}
+
+	if v, ok := flags["chunk-size"]; ok {
+		chunkSize, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || chunkSize <= 0 {
+			return nil, errors.Errorf("chunk-size must be a positive integer, got %q", v)
+		}
+		f.ChunkSize = chunkSize
+	}
+	if v, ok := flags["chunk-interval-millis"]; ok {
+		chunkIntervalMillis, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || chunkIntervalMillis < 0 {
+			return nil, errors.Errorf("chunk-interval-millis must be a non-negative integer, got %q", v)
+		}
+		f.ChunkIntervalMillis = chunkIntervalMillis
+	}
+	if v, ok := flags["lock-timeout-millis"]; ok {
+		lockTimeoutMillis, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || lockTimeoutMillis <= 0 {
+			return nil, errors.Errorf("lock-timeout-millis must be a positive integer, got %q", v)
+		}
+		f.LockTimeoutMillis = lockTimeoutMillis
+	}
+	return f, nil
+}
+
+const (
+	// maxTableNameLength keeps the names derived from the table name within the 63 bytes identifier limit.
+	maxTableNameLength = 48
+	// truncatedTableNameLength leaves room for the hash of the full name in the longer names,
+	// so that the tables sharing the truncated prefix don't share the derived names.
+	truncatedTableNameLength = maxTableNameLength - 9
+)
+
+func getNamePrefix(table string) string {
+	if len(table) <= maxTableNameLength {
+		return fmt.Sprintf("_%s_osc", table)
+	}
+	h := sha256.Sum256([]byte(table))
+	truncated := table
+	for len(truncated) > truncatedTableNameLength {
+		_, size := utf8.DecodeLastRuneInString(truncated)
+		truncated = truncated[:len(truncated)-size]
+	}
+	return fmt.Sprintf("_%s_%s_osc", truncated, hex.EncodeToString(h[:4]))
+}
+
+// GetShadowTableName gets the name of the shadow table.
+func GetShadowTableName(table string) string {
+	return getNamePrefix(table)
+}
+
+func getSyncFunctionName(table string) string {
+	return getNamePrefix(table) + "_sync"
+}
+
+func getSyncTriggerName(table string) string {
+	return getNamePrefix(table) + "_sync"
+}
+
+func getTruncateTriggerName(table string) string {
+	return getNamePrefix(table) + "_truncate"
+}
@@ -0,0 +1,664 @@
+package pgosc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// queryer is the common interface of *sql.DB and *sql.Tx.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Migrator runs the online schema change of a table.
+//
+// Prepare creates the shadow table with the altered schema and the triggers applying the changes of the original table to it,
+// Backfill copies the rows of the original table to the shadow table in chunks,
+// and Cutover replaces the original table with the shadow table.
+// The state is kept in the database, so Cutover can be called by another Migrator after Backfill is done.
+// The triggers apply the changes in the transactions writing the original table,
+// so a change violating the altered schema of the shadow table fails the write of the original table.
+type Migrator struct {
+	db        *sql.DB
+	statement *Statement
+	flags     *UserFlags
+
+	schema string
+	table  string
+	shadow string
+}
+
+// NewMigrator creates a migrator for the statement on the database.
+func NewMigrator(ctx context.Context, db *sql.DB, statement *Statement, flags map[string]string) (*Migrator, error) {
+	userFlags, err := GetUserFlags(flags)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get user flags")
+	}
+	schema := statement.Schema
+	if schema == "" {
+		if err := db.QueryRowContext(ctx, "SELECT current_schema()").Scan(&schema); err != nil {
+			return nil, errors.Wrapf(err, "failed to get current schema")
+		}
+	}
+	return &Migrator{
+		db:        db,
+		statement: statement,
+		flags:     userFlags,
+		schema:    schema,
+		table:     statement.Table,
+		shadow:    GetShadowTableName(statement.Table),
+	}, nil
+}
+
+// Check validates the table and creates the shadow table in a transaction which is rolled back.
+// The foreign keys aren't added to the shadow table by the check, which would block the writes of the referenced tables.
+func (m *Migrator) Check(ctx context.Context) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := m.setLockTimeout(ctx, tx); err != nil {
+		return err
+	}
+	var syncing bool
+	if err := tx.QueryRowContext(ctx, "SELECT to_regclass($1::TEXT) IS NOT NULL", m.getShadowTable()).Scan(&syncing); err != nil {
+		return err
+	}
+	// Don't touch the shadow table which is being synced.
+	if syncing {
+		return m.checkTable(ctx, tx)
+	}
+	_, err = m.createShadowTable(ctx, tx, false /* foreignKeys */)
+	return err
+}
+
+// Prepare creates the shadow table and the triggers syncing it. The leftovers of the previous run are cleaned up first.
+func (m *Migrator) Prepare(ctx context.Context) error {
+	if err := m.Cleanup(ctx); err != nil {
+		return err
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := m.setLockTimeout(ctx, tx); err != nil {
+		return err
+	}
+	columns, err := m.createShadowTable(ctx, tx, true /* foreignKeys */)
+	if err != nil {
+		return err
+	}
+	primaryKey, err := m.getPrimaryKey(ctx, tx, m.getTable())
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, m.getSyncFunctionStatement(columns, primaryKey)); err != nil {
+		return errors.Wrapf(err, "failed to create the sync function")
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TRIGGER %s AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION %s()", quoteIdentifier(getSyncTriggerName(m.table)), m.getTable(), m.getSyncFunction())); err != nil {
+		return errors.Wrapf(err, "failed to create the sync trigger")
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TRIGGER %s AFTER TRUNCATE ON %s FOR EACH STATEMENT EXECUTE FUNCTION %s()", quoteIdentifier(getTruncateTriggerName(m.table)), m.getTable(), m.getSyncFunction())); err != nil {
+		return errors.Wrapf(err, "failed to create the truncate trigger")
+	}
+	return tx.Commit()
+}
+
+// Backfill copies the rows of the original table to the shadow table in chunks ordered by the primary key.
+// The progress is reported with the copied rows and the estimated total rows after each chunk.
+func (m *Migrator) Backfill(ctx context.Context, progress func(copied, total int64)) error {
+	var total int64
+	if err := m.db.QueryRowContext(ctx, "SELECT GREATEST(reltuples::BIGINT, 0) FROM pg_class WHERE oid = $1::TEXT::REGCLASS", m.getTable()).Scan(&total); err != nil {
+		return errors.Wrapf(err, "failed to estimate the rows of table %q", m.table)
+	}
+	columns, err := m.getCopiedColumns(ctx, m.db)
+	if err != nil {
+		return err
+	}
+	primaryKey, err := m.getPrimaryKey(ctx, m.db, m.getTable())
+	if err != nil {
+		return err
+	}
+	types, err := m.getColumnTypes(ctx, primaryKey)
+	if err != nil {
+		return err
+	}
+
+	var copied int64
+	var last []any
+	for {
+		query := m.getBackfillStatement(columns, primaryKey, types, last != nil)
+		var count int64
+		values := make([]string, len(primaryKey))
+		dest := []any{&count}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := m.db.QueryRowContext(ctx, query, last...).Scan(dest...); err != nil {
+			if err == sql.ErrNoRows {
+				break
+			}
+			return errors.Wrapf(err, "failed to copy rows to the shadow table")
+		}
+		copied += count
+		if copied > total {
+			total = copied
+		}
+		progress(copied, total)
+
+		last = nil
+		for _, value := range values {
+			last = append(last, value)
+		}
+		if m.flags.ChunkIntervalMillis > 0 {
+			select {
+			case <-time.After(time.Duration(m.flags.ChunkIntervalMillis) * time.Millisecond):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	if _, err := m.db.ExecContext(ctx, fmt.Sprintf("ANALYZE %s", m.getShadowTable())); err != nil {
+		return errors.Wrapf(err, "failed to analyze the shadow table")
+	}
+	return nil
+}
+
+// Cutover replaces the original table with the shadow table in one transaction.
+// The sequences, the owner, the privileges, the comment and the index names of the original table are moved to the shadow table.
+func (m *Migrator) Cutover(ctx context.Context) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := m.setLockTimeout(ctx, tx); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("LOCK TABLE %s IN ACCESS EXCLUSIVE MODE", m.getTable())); err != nil {
+		return errors.Wrapf(err, "failed to lock table %q", m.table)
+	}
+	var triggers int
+	if err := tx.QueryRowContext(ctx, "SELECT count(*) FROM pg_trigger WHERE tgrelid = $1::TEXT::REGCLASS AND tgname = $2", m.getTable(), getSyncTriggerName(m.table)).Scan(&triggers); err != nil {
+		return err
+	}
+	if triggers == 0 {
+		return errors.Errorf("the shadow table of %q is not synced, the sync task must be run first", m.table)
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP FUNCTION %s() CASCADE", m.getSyncFunction())); err != nil {
+		return errors.Wrapf(err, "failed to drop the sync function")
+	}
+	if err := m.moveSequences(ctx, tx); err != nil {
+		return err
+	}
+	if err := m.copyTableProperties(ctx, tx); err != nil {
+		return err
+	}
+	indexNames, err := m.matchIndexes(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TABLE %s", m.getTable())); err != nil {
+		return errors.Wrapf(err, "failed to drop the original table %q", m.table)
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", m.getShadowTable(), quoteIdentifier(m.table))); err != nil {
+		return errors.Wrapf(err, "failed to rename the shadow table")
+	}
+	for shadowIndex, index := range indexNames {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER INDEX %s.%s RENAME TO %s", quoteIdentifier(m.schema), quoteIdentifier(shadowIndex), quoteIdentifier(index))); err != nil {
+			return errors.Wrapf(err, "failed to rename index %q", shadowIndex)
+		}
+	}
+	return tx.Commit()
+}
+
+// Cleanup drops the shadow table and the triggers.
+func (m *Migrator) Cleanup(ctx context.Context) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := m.setLockTimeout(ctx, tx); err != nil {
+		return err
+	}
+	// Dropping the function drops the triggers depending on it.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP FUNCTION IF EXISTS %s() CASCADE", m.getSyncFunction())); err != nil {
+		return errors.Wrapf(err, "failed to drop the sync function")
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", m.getShadowTable())); err != nil {
+		return errors.Wrapf(err, "failed to drop the shadow table")
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) setLockTimeout(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL lock_timeout = %d", m.flags.LockTimeoutMillis)); err != nil {
+		return errors.Wrapf(err, "failed to set lock timeout")
+	}
+	return nil
+}
+
+// checkTable checks that the table can be changed online.
+func (m *Migrator) checkTable(ctx context.Context, q queryer) error {
+	var relkind string
+	var rowSecurity bool
+	if err := q.QueryRowContext(ctx, `
+		SELECT c.relkind, c.relrowsecurity
+		FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2`, m.schema, m.table).Scan(&relkind, &rowSecurity); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Errorf("table %q.%q not found", m.schema, m.table)
+		}
+		return err
+	}
+	if relkind != "r" {
+		return errors.Errorf("%q is not a regular table, partitioned tables are not supported", m.table)
+	}
+	if rowSecurity {
+		return errors.Errorf("table %q has row level security enabled, which is not supported", m.table)
+	}
+
+	checks := []struct {
+		query  string
+		format string
+	}{
+		{
+			query:  "SELECT inhrelid::REGCLASS::TEXT FROM pg_inherits WHERE inhparent = $1::TEXT::REGCLASS UNION ALL SELECT inhparent::REGCLASS::TEXT FROM pg_inherits WHERE inhrelid = $1::TEXT::REGCLASS",
+			format: "table %q has inheritance with %s",
+		},
+		{
+			query:  "SELECT conname || ' on ' || conrelid::REGCLASS::TEXT FROM pg_constraint WHERE contype = 'f' AND confrelid = $1::TEXT::REGCLASS",
+			format: "table %q is referenced by foreign keys %s",
+		},
+		{
+			query: `
+				SELECT DISTINCT r.ev_class::REGCLASS::TEXT
+				FROM pg_depend d JOIN pg_rewrite r ON r.oid = d.objid
+				WHERE d.classid = 'pg_rewrite'::REGCLASS AND d.refobjid = $1::TEXT::REGCLASS AND r.ev_class <> $1::TEXT::REGCLASS`,
+			format: "table %q is used by views %s",
+		},
+		{
+			query:  "SELECT tgname FROM pg_trigger WHERE tgrelid = $1::TEXT::REGCLASS AND NOT tgisinternal AND tgname NOT IN ($2, $3)",
+			format: "table %q has triggers %s",
+		},
+	}
+	for _, check := range checks {
+		args := []any{m.getTable()}
+		if strings.Contains(check.query, "$2") {
+			args = append(args, getSyncTriggerName(m.table), getTruncateTriggerName(m.table))
+		}
+		names, err := queryStrings(ctx, q, check.query, args...)
+		if err != nil {
+			return err
+		}
+		if len(names) > 0 {
+			return errors.Errorf(check.format+", which is not supported", m.table, strings.Join(names, ", "))
+		}
+	}
+
+	primaryKey, err := m.getPrimaryKey(ctx, q, m.getTable())
+	if err != nil {
+		return err
+	}
+	if len(primaryKey) == 0 {
+		return errors.Errorf("table %q has no primary key, which is required by the online schema change", m.table)
+	}
+	return nil
+}
+
+// createShadowTable creates the shadow table with the altered schema and returns the columns copied from the original table.
+// The foreign keys of the original table and the statement are added if foreignKeys is true.
+func (m *Migrator) createShadowTable(ctx context.Context, tx *sql.Tx, foreignKeys bool) ([]string, error) {
+	if err := m.checkTable(ctx, tx); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL)", m.getShadowTable(), m.getTable())); err != nil {
+		return nil, errors.Wrapf(err, "failed to create the shadow table")
+	}
+	// The foreign keys aren't copied by LIKE.
+	if foreignKeys {
+		constraints, err := queryStrings(ctx, tx, "SELECT 'ADD CONSTRAINT ' || quote_ident(conname) || ' ' || pg_get_constraintdef(oid) FROM pg_constraint WHERE contype = 'f' AND conrelid = $1::TEXT::REGCLASS ORDER BY conname", m.getTable())
+		if err != nil {
+			return nil, err
+		}
+		for _, constraint := range constraints {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s %s", m.getShadowTable(), constraint)); err != nil {
+				return nil, errors.Wrapf(err, "failed to copy the foreign key to the shadow table")
+			}
+		}
+	}
+
+	indexNames, err := m.matchIndexes(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	constraintNames := make(map[string]string)
+	for shadowIndex, index := range indexNames {
+		constraintNames[index] = shadowIndex
+	}
+	statement, err := m.statement.getShadowStatement(m.schema, m.shadow, constraintNames, foreignKeys)
+	if err != nil {
+		return nil, err
+	}
+	if statement != "" {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return nil, errors.Wrapf(err, "failed to alter the shadow table")
+		}
+	}
+
+	primaryKey, err := m.getPrimaryKey(ctx, tx, m.getTable())
+	if err != nil {
+		return nil, err
+	}
+	shadowPrimaryKey, err := m.getPrimaryKey(ctx, tx, m.getShadowTable())
+	if err != nil {
+		return nil, err
+	}
+	if strings.Join(primaryKey, ",") != strings.Join(shadowPrimaryKey, ",") {
+		return nil, errors.New("the primary key can't be changed by the online schema change")
+	}
+	return m.getCopiedColumns(ctx, tx)
+}
+
+// getCopiedColumns gets the columns of the shadow table copied from the original table.
+// The generated columns are computed by the shadow table.
+func (m *Migrator) getCopiedColumns(ctx context.Context, q queryer) ([]string, error) {
+	return queryStrings(ctx, q, `
+		SELECT a.attname FROM pg_attribute a
+		WHERE a.attrelid = $2::TEXT::REGCLASS AND a.attnum > 0 AND NOT a.attisdropped AND a.attgenerated = ''
+		AND EXISTS (
+			SELECT 1 FROM pg_attribute o
+			WHERE o.attrelid = $1::TEXT::REGCLASS AND o.attname = a.attname AND o.attnum > 0 AND NOT o.attisdropped AND o.attgenerated = ''
+		)
+		ORDER BY a.attnum`, m.getTable(), m.getShadowTable())
+}
+
+func (*Migrator) getPrimaryKey(ctx context.Context, q queryer, table string) ([]string, error) {
+	return queryStrings(ctx, q, `
+		SELECT a.attname
+		FROM pg_index i
+		CROSS JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, ord)
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+		WHERE i.indrelid = $1::TEXT::REGCLASS AND i.indisprimary
+		ORDER BY k.ord`, table)
+}
+
+func (m *Migrator) getColumnTypes(ctx context.Context, columns []string) ([]string, error) {
+	var types []string
+	for _, column := range columns {
+		var columnType string
+		if err := m.db.QueryRowContext(ctx, "SELECT format_type(atttypid, atttypmod) FROM pg_attribute WHERE attrelid = $1::TEXT::REGCLASS AND attname = $2", m.getTable(), column).Scan(&columnType); err != nil {
+			return nil, errors.Wrapf(err, "failed to get the type of column %q", column)
+		}
+		types = append(types, columnType)
+	}
+	return types, nil
+}
+
+// matchIndexes matches the indexes of the shadow table to the indexes of the original table by the definition.
+// It returns the map from the shadow index name to the original index name.
+func (m *Migrator) matchIndexes(ctx context.Context, q queryer) (map[string]string, error) {
+	indexes, err := m.listIndexes(ctx, q, m.getTable())
+	if err != nil {
+		return nil, err
+	}
+	shadowIndexes, err := m.listIndexes(ctx, q, m.getShadowTable())
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string)
+	for _, shadowIndex := range shadowIndexes {
+		for i, index := range indexes {
+			if index.definition == shadowIndex.definition {
+				result[shadowIndex.name] = index.name
+				indexes = append(indexes[:i], indexes[i+1:]...)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+type indexDefinition struct {
+	name string
+	// definition is the index definition without the index and the table names.
+	definition string
+}
+
+func (*Migrator) listIndexes(ctx context.Context, q queryer, table string) ([]*indexDefinition, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT c.relname, i.indisprimary, i.indisunique, pg_get_indexdef(i.indexrelid)
+		FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid
+		WHERE i.indrelid = $1::TEXT::REGCLASS
+		ORDER BY c.relname`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var indexes []*indexDefinition
+	for rows.Next() {
+		var name, definition string
+		var primary, unique bool
+		if err := rows.Scan(&name, &primary, &unique, &definition); err != nil {
+			return nil, err
+		}
+		if i := strings.Index(definition, " USING "); i >= 0 {
+			definition = definition[i:]
+		}
+		indexes = append(indexes, &indexDefinition{
+			name:       name,
+			definition: fmt.Sprintf("%t %t%s", primary, unique, definition),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return indexes, nil
+}
+
+// moveSequences moves the sequences owned by the original table to the shadow table,
+// and sets the identity sequences of the shadow table to the values of the original table.
+func (m *Migrator) moveSequences(ctx context.Context, tx *sql.Tx) error {
+	ownedSequences, err := queryStrings(ctx, tx, `
+		SELECT 'ALTER SEQUENCE ' || d.objid::REGCLASS::TEXT || ' OWNED BY ' || $2 || '.' || quote_ident(a.attname)
+		FROM pg_depend d
+		JOIN pg_class s ON s.oid = d.objid AND s.relkind = 'S'
+		JOIN pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+		WHERE d.classid = 'pg_class'::REGCLASS AND d.refobjid = $1::TEXT::REGCLASS AND d.deptype = 'a'
+		AND EXISTS (SELECT 1 FROM pg_attribute sa WHERE sa.attrelid = $2::TEXT::REGCLASS AND sa.attname = a.attname AND NOT sa.attisdropped)`, m.getTable(), m.getShadowTable())
+	if err != nil {
+		return err
+	}
+	for _, statement := range ownedSequences {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return errors.Wrapf(err, "failed to move the sequence to the shadow table")
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT pg_get_serial_sequence($1, a.attname), pg_get_serial_sequence($2, a.attname)
+		FROM pg_attribute a
+		WHERE a.attrelid = $2::TEXT::REGCLASS AND a.attidentity <> '' AND NOT a.attisdropped
+		AND EXISTS (SELECT 1 FROM pg_attribute o WHERE o.attrelid = $1::TEXT::REGCLASS AND o.attname = a.attname AND NOT o.attisdropped)`, m.getTable(), m.getShadowTable())
+	if err != nil {
+		return err
+	}
+	type sequencePair struct {
+		sequence       sql.NullString
+		shadowSequence sql.NullString
+	}
+	var pairs []sequencePair
+	for rows.Next() {
+		var pair sequencePair
+		if err := rows.Scan(&pair.sequence, &pair.shadowSequence); err != nil {
+			rows.Close()
+			return err
+		}
+		pairs = append(pairs, pair)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		if !pair.sequence.Valid || !pair.shadowSequence.Valid || pair.sequence.String == pair.shadowSequence.String {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SELECT setval($1::TEXT::REGCLASS, last_value, is_called) FROM %s", pair.sequence.String), pair.shadowSequence.String); err != nil {
+			return errors.Wrapf(err, "failed to set the identity sequence of the shadow table")
+		}
+	}
+	return nil
+}
+
+// copyTableProperties copies the owner, the privileges and the comment of the original table to the shadow table.
+func (m *Migrator) copyTableProperties(ctx context.Context, tx *sql.Tx) error {
+	statements, err := queryStrings(ctx, tx, `
+		SELECT 'ALTER TABLE ' || $2 || ' OWNER TO ' || quote_ident(pg_get_userbyid(c.relowner))
+		FROM pg_class c, pg_class s
+		WHERE c.oid = $1::TEXT::REGCLASS AND s.oid = $2::TEXT::REGCLASS AND c.relowner <> s.relowner
+		UNION ALL
+		SELECT 'GRANT ' || a.privilege_type || ' ON ' || $2 || ' TO '
+			|| CASE WHEN a.grantee = 0 THEN 'PUBLIC' ELSE quote_ident(pg_get_userbyid(a.grantee)) END
+			|| CASE WHEN a.is_grantable THEN ' WITH GRANT OPTION' ELSE '' END
+		FROM pg_class c, aclexplode(c.relacl) a
+		WHERE c.oid = $1::TEXT::REGCLASS AND a.grantee <> c.relowner
+		UNION ALL
+		SELECT 'COMMENT ON TABLE ' || $2 || ' IS ' || quote_literal(d.description)
+		FROM pg_description d
+		WHERE d.classoid = 'pg_class'::REGCLASS AND d.objoid = $1::TEXT::REGCLASS AND d.objsubid = 0`, m.getTable(), m.getShadowTable())
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return errors.Wrapf(err, "failed to copy the table properties to the shadow table")
+		}
+	}
+	return nil
+}
+
+// getSyncFunctionStatement gets the trigger function applying the changes of the original table to the shadow table.
+// It runs as the creator so that the users writing the original table don't need the privileges on the shadow table.
+// The deleted and the old rows are removed from the shadow table, and the new rows are upserted,
+// so the changes apply whether or not the rows have been copied by the backfill.
+func (m *Migrator) getSyncFunctionStatement(columns, primaryKey []string) string {
+	var newValues, updates, keyMatches []string
+	for _, column := range columns {
+		newValues = append(newValues, "NEW."+quoteIdentifier(column))
+		updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", quoteIdentifier(column), quoteIdentifier(column)))
+	}
+	for _, column := range primaryKey {
+		keyMatches = append(keyMatches, fmt.Sprintf("%s = OLD.%s", quoteIdentifier(column), quoteIdentifier(column)))
+	}
+	return fmt.Sprintf(`CREATE FUNCTION %s() RETURNS TRIGGER LANGUAGE plpgsql SECURITY DEFINER SET search_path = pg_catalog, pg_temp AS $osc$
+BEGIN
+  IF TG_OP = 'TRUNCATE' THEN
+    TRUNCATE %s;
+  END IF;
+  IF TG_OP = 'UPDATE' OR TG_OP = 'DELETE' THEN
+    DELETE FROM %s WHERE %s;
+  END IF;
+  IF TG_OP = 'INSERT' OR TG_OP = 'UPDATE' THEN
+    INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s;
+  END IF;
+  RETURN NULL;
+END
+$osc$`,
+		m.getSyncFunction(),
+		m.getShadowTable(),
+		m.getShadowTable(), strings.Join(keyMatches, " AND "),
+		m.getShadowTable(), joinIdentifiers(columns), strings.Join(newValues, ", "), joinIdentifiers(primaryKey), strings.Join(updates, ", "),
+	)
+}
+
+// getBackfillStatement gets the statement copying the next chunk of rows after the last primary key.
+// The rows are locked so that the rows changed or deleted concurrently are not copied with the stale values,
+// and the rows already copied by the trigger are skipped.
+// It returns the number of the copied rows and the last primary key as text, or no row if there are no more rows.
+func (m *Migrator) getBackfillStatement(columns, primaryKey, types []string, hasLast bool) string {
+	var where string
+	if hasLast {
+		var values []string
+		for i, columnType := range types {
+			values = append(values, fmt.Sprintf("CAST($%d::TEXT AS %s)", i+1, columnType))
+		}
+		where = fmt.Sprintf("WHERE (%s) > (%s)", joinIdentifiers(primaryKey), strings.Join(values, ", "))
+	}
+	var keys, descKeys []string
+	for _, column := range primaryKey {
+		keys = append(keys, quoteIdentifier(column)+"::TEXT")
+		descKeys = append(descKeys, quoteIdentifier(column)+" DESC")
+	}
+	return fmt.Sprintf(`WITH chunk AS (
+  SELECT %s FROM %s %s ORDER BY %s LIMIT %d FOR SHARE
+), copied AS (
+  INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM chunk ON CONFLICT DO NOTHING
+)
+SELECT count(*) OVER (), %s FROM chunk ORDER BY %s LIMIT 1`,
+		joinIdentifiers(columns), m.getTable(), where, joinIdentifiers(primaryKey), m.flags.ChunkSize,
+		m.getShadowTable(), joinIdentifiers(columns), joinIdentifiers(columns),
+		strings.Join(keys, ", "), strings.Join(descKeys, ", "),
+	)
+}
+
+func (m *Migrator) getTable() string {
+	return quoteIdentifier(m.schema) + "." + quoteIdentifier(m.table)
+}
+
+func (m *Migrator) getShadowTable() string {
+	return quoteIdentifier(m.schema) + "." + quoteIdentifier(m.shadow)
+}
+
+func (m *Migrator) getSyncFunction() string {
+	return quoteIdentifier(m.schema) + "." + quoteIdentifier(getSyncFunctionName(m.table))
+}
+
+func queryStrings(ctx context.Context, q queryer, query string, args ...any) ([]string, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func quoteIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
+
+func joinIdentifiers(identifiers []string) string {
+	var quoted []string
+	for _, identifier := range identifiers {
+		quoted = append(quoted, quoteIdentifier(identifier))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,120 @@
+package pgosc
+
+import (
+	"strings"
+
+	pgquery "github.com/pganalyze/pg_query_go/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// supportedAlterTableTypes are the ALTER TABLE subcommands which can be applied to the shadow table.
+// The subcommands changing the table identity or the properties not copied by the shadow table are not supported.
+var supportedAlterTableTypes = map[pgquery.AlterTableType]bool{
+	pgquery.AlterTableType_AT_AddColumn:       true,
+	pgquery.AlterTableType_AT_ColumnDefault:   true,
+	pgquery.AlterTableType_AT_DropNotNull:     true,
+	pgquery.AlterTableType_AT_SetNotNull:      true,
+	pgquery.AlterTableType_AT_DropExpression:  true,
+	pgquery.AlterTableType_AT_SetStatistics:   true,
+	pgquery.AlterTableType_AT_SetStorage:      true,
+	pgquery.AlterTableType_AT_SetCompression:  true,
+	pgquery.AlterTableType_AT_DropColumn:      true,
+	pgquery.AlterTableType_AT_AddConstraint:   true,
+	pgquery.AlterTableType_AT_DropConstraint:  true,
+	pgquery.AlterTableType_AT_AlterColumnType: true,
+	pgquery.AlterTableType_AT_AddIdentity:     true,
+	pgquery.AlterTableType_AT_SetIdentity:     true,
+	pgquery.AlterTableType_AT_DropIdentity:    true,
+}
+
+// Statement is the ALTER TABLE statement of the online schema change.
+type Statement struct {
+	// Schema is the schema of the table, it's empty if the table name is unqualified.
+	Schema string
+	// Table is the name of the altered table.
+	Table string
+
+	tree *pgquery.ParseResult
+}
+
+// ParseStatement parses the statement of the online schema change, which must be exactly one ALTER TABLE statement.
+func ParseStatement(statement string) (*Statement, error) {
+	tree, err := pgquery.Parse(statement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse statement")
+	}
+	if len(tree.Stmts) != 1 {
+		return nil, errors.Errorf("the online schema change requires exactly one ALTER TABLE statement, got %d statements", len(tree.Stmts))
+	}
+	alter := tree.Stmts[0].Stmt.GetAlterTableStmt()
+	if alter == nil || alter.Objtype != pgquery.ObjectType_OBJECT_TABLE {
+		return nil, errors.New("the online schema change only supports the ALTER TABLE statement")
+	}
+	if !alter.Relation.Inh {
+		return nil, errors.New("ALTER TABLE ONLY is not supported by the online schema change")
+	}
+	for _, node := range alter.Cmds {
+		cmd := node.GetAlterTableCmd()
+		if cmd == nil || !supportedAlterTableTypes[cmd.Subtype] {
+			return nil, errors.Errorf("ALTER TABLE subcommand %s is not supported by the online schema change", strings.TrimPrefix(cmd.GetSubtype().String(), "AT_"))
+		}
+		// The rows are copied to the shadow table by the assignment cast, so the USING expression can't be applied.
+		if cmd.Subtype == pgquery.AlterTableType_AT_AlterColumnType && cmd.GetDef().GetColumnDef().GetRawDefault() != nil {
+			return nil, errors.Errorf("ALTER COLUMN %s TYPE with USING is not supported by the online schema change", cmd.Name)
+		}
+	}
+	return &Statement{
+		Schema: alter.Relation.Schemaname,
+		Table:  alter.Relation.Relname,
+		tree:   tree,
+	}, nil
+}
+
+// getShadowStatement gets the statement altering the shadow table.
+// The dropped constraints are renamed by constraintNames because the index-backed constraints
+// of the shadow table are named after the shadow table.
+// The added foreign keys are removed if foreignKeys is false, and it returns empty if no subcommand is left.
+func (s *Statement) getShadowStatement(schema, shadow string, constraintNames map[string]string, foreignKeys bool) (string, error) {
+	tree, ok := proto.Clone(s.tree).(*pgquery.ParseResult)
+	if !ok {
+		return "", errors.New("failed to clone the parse result")
+	}
+	alter := tree.Stmts[0].Stmt.GetAlterTableStmt()
+	alter.Relation.Schemaname = schema
+	alter.Relation.Relname = shadow
+	var cmds []*pgquery.Node
+	for _, node := range alter.Cmds {
+		cmd := node.GetAlterTableCmd()
+		switch cmd.Subtype {
+		case pgquery.AlterTableType_AT_DropConstraint:
+			if name, ok := constraintNames[cmd.Name]; ok {
+				cmd.Name = name
+			}
+		case pgquery.AlterTableType_AT_AddConstraint:
+			if !foreignKeys && cmd.GetDef().GetConstraint().GetContype() == pgquery.ConstrType_CONSTR_FOREIGN {
+				continue
+			}
+		case pgquery.AlterTableType_AT_AddColumn:
+			if columnDef := cmd.GetDef().GetColumnDef(); !foreignKeys && columnDef != nil {
+				var constraints []*pgquery.Node
+				for _, constraint := range columnDef.Constraints {
+					if constraint.GetConstraint().GetContype() != pgquery.ConstrType_CONSTR_FOREIGN {
+						constraints = append(constraints, constraint)
+					}
+				}
+				columnDef.Constraints = constraints
+			}
+		}
+		cmds = append(cmds, node)
+	}
+	if len(cmds) == 0 {
+		return "", nil
+	}
+	alter.Cmds = cmds
+	statement, err := pgquery.Deparse(tree)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to deparse the statement for the shadow table")
+	}
+	return statement, nil
+}
@@ -0,0 +1,153 @@
+package pgosc
+
+import (
+	"strings"
+	"testing"
+
+	pgquery "github.com/pganalyze/pg_query_go/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		schema    string
+		table     string
+		err       string
+	}{
+		{
+			statement: "ALTER TABLE public.users ADD COLUMN age INT NOT NULL DEFAULT 0, ALTER COLUMN name TYPE TEXT;",
+			schema:    "public",
+			table:     "users",
+		},
+		{
+			statement: `ALTER TABLE "Users" DROP CONSTRAINT users_email_key`,
+			table:     "Users",
+		},
+		{
+			statement: "ALTER TABLE users ADD COLUMN age INT; ALTER TABLE users ADD COLUMN city TEXT;",
+			err:       "exactly one ALTER TABLE statement",
+		},
+		{
+			statement: "CREATE INDEX idx_users_name ON users (name)",
+			err:       "only supports the ALTER TABLE statement",
+		},
+		{
+			statement: "ALTER TABLE ONLY users ADD COLUMN age INT",
+			err:       "ALTER TABLE ONLY is not supported",
+		},
+		{
+			statement: "ALTER TABLE users SET TABLESPACE fast",
+			err:       "subcommand SetTableSpace is not supported",
+		},
+		{
+			statement: "ALTER TABLE users ALTER COLUMN age TYPE INT USING age::INT",
+			err:       "USING is not supported",
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		statement, err := ParseStatement(test.statement)
+		if test.err != "" {
+			a.ErrorContains(err, test.err, test.statement)
+			continue
+		}
+		a.NoError(err, test.statement)
+		a.Equal(test.schema, statement.Schema)
+		a.Equal(test.table, statement.Table)
+	}
+}
+
+func TestGetShadowStatement(t *testing.T) {
+	a := require.New(t)
+	statement, err := ParseStatement("ALTER TABLE users ADD COLUMN age INT, DROP CONSTRAINT users_email_key, DROP CONSTRAINT users_age_check")
+	a.NoError(err)
+	shadowStatement, err := statement.getShadowStatement("public", GetShadowTableName("users"), map[string]string{"users_email_key": "_users_osc_email_key"}, true /* foreignKeys */)
+	a.NoError(err)
+	a.Equal("ALTER TABLE public._users_osc ADD COLUMN age int, DROP CONSTRAINT _users_osc_email_key, DROP CONSTRAINT users_age_check", shadowStatement)
+	// The statement isn't changed.
+	a.Equal("users", statement.tree.Stmts[0].Stmt.GetAlterTableStmt().Relation.Relname)
+
+	// The foreign keys are removed for the check.
+	statement, err = ParseStatement("ALTER TABLE users ADD COLUMN team_id INT NOT NULL REFERENCES teams (id), ADD CONSTRAINT users_org_fk FOREIGN KEY (org_id) REFERENCES orgs (id)")
+	a.NoError(err)
+	shadowStatement, err = statement.getShadowStatement("public", GetShadowTableName("users"), nil, true /* foreignKeys */)
+	a.NoError(err)
+	a.Equal("ALTER TABLE public._users_osc ADD COLUMN team_id int NOT NULL REFERENCES teams (id), ADD CONSTRAINT users_org_fk FOREIGN KEY (org_id) REFERENCES orgs (id)", shadowStatement)
+	shadowStatement, err = statement.getShadowStatement("public", GetShadowTableName("users"), nil, false /* foreignKeys */)
+	a.NoError(err)
+	a.Equal("ALTER TABLE public._users_osc ADD COLUMN team_id int NOT NULL", shadowStatement)
+
+	statement, err = ParseStatement("ALTER TABLE users ADD CONSTRAINT users_org_fk FOREIGN KEY (org_id) REFERENCES orgs (id)")
+	a.NoError(err)
+	shadowStatement, err = statement.getShadowStatement("public", GetShadowTableName("users"), nil, false /* foreignKeys */)
+	a.NoError(err)
+	a.Empty(shadowStatement)
+}
+
+func TestGetUserFlags(t *testing.T) {
+	a := require.New(t)
+	flags, err := GetUserFlags(nil)
+	a.NoError(err)
+	a.Equal(&UserFlags{ChunkSize: 1000, ChunkIntervalMillis: 0, LockTimeoutMillis: 3000}, flags)
+
+	flags, err = GetUserFlags(map[string]string{"chunk-size": "500", "chunk-interval-millis": "100"})
+	a.NoError(err)
+	a.Equal(&UserFlags{ChunkSize: 500, ChunkIntervalMillis: 100, LockTimeoutMillis: 3000}, flags)
+
+	_, err = GetUserFlags(map[string]string{"chunk-size": "0"})
+	a.Error(err)
+	_, err = GetUserFlags(map[string]string{"max-load": "Threads_running=25"})
+	a.ErrorContains(err, "unsupported flag")
+}
+
+func TestGetNames(t *testing.T) {
+	a := require.New(t)
+	a.Equal("_users_osc", GetShadowTableName("users"))
+	a.Equal("_users_osc_sync", getSyncFunctionName("users"))
+	a.Equal("_users_osc_truncate", getTruncateTriggerName("users"))
+	// The names of 48 bytes are kept.
+	a.Equal("_"+strings.Repeat("a", 48)+"_osc", GetShadowTableName(strings.Repeat("a", 48)))
+	// The longer names are truncated with the hash of the full name.
+	long := strings.Repeat("表", 30)
+	a.LessOrEqual(len(getTruncateTriggerName(long)), 63)
+	a.Regexp("^_"+strings.Repeat("表", 13)+"_[0-9a-f]{8}_osc_truncate$", getTruncateTriggerName(long))
+	// The names sharing the truncated prefix don't collide.
+	a.NotEqual(GetShadowTableName(strings.Repeat("a", 50)+"_1"), GetShadowTableName(strings.Repeat("a", 50)+"_2"))
+	a.LessOrEqual(len(getTruncateTriggerName(strings.Repeat("a", 63))), 63)
+}
+
+func TestGeneratedStatements(t *testing.T) {
+	a := require.New(t)
+	statement, err := ParseStatement("ALTER TABLE users ADD COLUMN age INT")
+	a.NoError(err)
+	m := &Migrator{
+		statement: statement,
+		flags:     &UserFlags{ChunkSize: 100},
+		schema:    "public",
+		table:     "users",
+		shadow:    GetShadowTableName("users"),
+	}
+	columns := []string{"tenant", "id", "name"}
+	primaryKey := []string{"tenant", "id"}
+
+	syncFunction := m.getSyncFunctionStatement(columns, primaryKey)
+	_, err = pgquery.Parse(syncFunction)
+	a.NoError(err)
+	a.Contains(syncFunction, `DELETE FROM "public"."_users_osc" WHERE "tenant" = OLD."tenant" AND "id" = OLD."id";`)
+	a.Contains(syncFunction, `INSERT INTO "public"."_users_osc" ("tenant", "id", "name") OVERRIDING SYSTEM VALUE VALUES (NEW."tenant", NEW."id", NEW."name") ON CONFLICT ("tenant", "id") DO UPDATE SET`)
+	// The changes are not applied in a subtransaction per row.
+	a.NotContains(syncFunction, "EXCEPTION")
+
+	backfill := m.getBackfillStatement(columns, primaryKey, []string{"text", "bigint"}, false)
+	_, err = pgquery.Parse(backfill)
+	a.NoError(err)
+	a.NotContains(backfill, "WHERE")
+
+	backfill = m.getBackfillStatement(columns, primaryKey, []string{"text", "bigint"}, true)
+	_, err = pgquery.Parse(backfill)
+	a.NoError(err)
+	a.Contains(backfill, `WHERE ("tenant", "id") > (CAST($1::TEXT AS text), CAST($2::TEXT AS bigint))`)
+	a.Contains(backfill, `ORDER BY "tenant", "id" LIMIT 100 FOR SHARE`)
+}
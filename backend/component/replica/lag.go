@@ -0,0 +1,128 @@
+// Package replica provides the replication status of the database instances.
+package replica
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// Lag is the replication lag of a replica.
+type Lag struct {
+	// Replica describes the replica, e.g. the read-only data source connecting to it.
+	Replica string
+	// Stopped is true if the replication is not running.
+	Stopped bool
+	Lag     time.Duration
+}
+
+func (l *Lag) String() string {
+	if l.Stopped {
+		return fmt.Sprintf("Replica %s stopped replicating", l.Replica)
+	}
+	return fmt.Sprintf("Replica %s lags behind by %s", l.Replica, l.Lag)
+}
+
+// GetLags gets the replication lags of the replicas of the instance.
+// For MySQL, the lags are read from the read-only data sources connecting to the replicas.
+// For PostgreSQL, the lags are read from the primary through the admin connection sqlDB.
+// The other engines have no replica reported.
+func GetLags(ctx context.Context, dbFactory *dbfactory.DBFactory, instance *store.InstanceMessage, database *store.DatabaseMessage, sqlDB *sql.DB) ([]*Lag, error) {
+	var lags []*Lag
+	switch instance.Engine {
+	case storepb.Engine_MYSQL, storepb.Engine_MARIADB:
+		for _, dataSource := range instance.DataSources {
+			if dataSource.Type != api.RO {
+				continue
+			}
+			lag, err := getMySQLLag(ctx, dbFactory, instance, database, dataSource.ID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get the replica lag of data source %q", dataSource.ID)
+			}
+			if lag == nil {
+				continue
+			}
+			lag.Replica = fmt.Sprintf("of data source %q", dataSource.ID)
+			lags = append(lags, lag)
+		}
+	case storepb.Engine_POSTGRES:
+		rows, err := sqlDB.QueryContext(ctx, `
+			SELECT application_name, COALESCE(client_addr::TEXT, ''), COALESCE(EXTRACT(EPOCH FROM replay_lag), 0)
+			FROM pg_stat_replication`)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list replicas")
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var name, address string
+			var seconds float64
+			if err := rows.Scan(&name, &address, &seconds); err != nil {
+				return nil, err
+			}
+			lags = append(lags, &Lag{
+				Replica: fmt.Sprintf("%q (%s)", name, address),
+				Lag:     time.Duration(seconds * float64(time.Second)).Round(time.Millisecond),
+			})
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return lags, nil
+}
+
+// getMySQLLag gets the replica lag of the data source, or nil if the data source doesn't connect to a replica.
+func getMySQLLag(ctx context.Context, dbFactory *dbfactory.DBFactory, instance *store.InstanceMessage, database *store.DatabaseMessage, dataSourceID string) (*Lag, error) {
+	driver, err := dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, database, dataSourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+
+	// SHOW REPLICA STATUS is introduced in MySQL 8.0.22.
+	rows, err := driver.GetDB().QueryContext(ctx, "SHOW REPLICA STATUS")
+	if err != nil {
+		rows, err = driver.GetDB().QueryContext(ctx, "SHOW SLAVE STATUS")
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	for i, column := range columns {
+		if column != "Seconds_Behind_Source" && column != "Seconds_Behind_Master" {
+			continue
+		}
+		if !values[i].Valid {
+			return &Lag{Stopped: true}, nil
+		}
+		var seconds int64
+		if _, err := fmt.Sscan(values[i].String, &seconds); err != nil {
+			return nil, errors.Wrapf(err, "invalid replica lag %q", values[i].String)
+		}
+		return &Lag{Lag: time.Duration(seconds) * time.Second}, nil
+	}
+	return nil, nil
+}
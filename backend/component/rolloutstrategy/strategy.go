@@ -0,0 +1,150 @@
+// Package rolloutstrategy evaluates the rollout strategy of stages,
+// which rolls out the tasks of a stage in canary and percentage-based waves with a concurrency limit,
+// and pauses the rollout when too many tasks fail.
+package rolloutstrategy
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// TaskStatus is the rollout status of a task in the stage.
+type TaskStatus int
+
+const (
+	// TaskPending means the task hasn't started or its latest task run is pending or canceled.
+	TaskPending TaskStatus = iota
+	// TaskRunning means the latest task run of the task is running.
+	TaskRunning
+	// TaskDone means the latest task run of the task is done or the task is skipped.
+	TaskDone
+	// TaskFailed means the latest task run of the task failed.
+	TaskFailed
+)
+
+// Validate validates the rollout strategy.
+func Validate(strategy *storepb.RolloutStrategy) error {
+	if strategy == nil {
+		return nil
+	}
+	if strategy.CanaryCount < 0 {
+		return errors.Errorf("canary count must not be negative")
+	}
+	if strategy.CanaryPercent < 0 || strategy.CanaryPercent > 100 {
+		return errors.Errorf("canary percent must be between 0 and 100")
+	}
+	if strategy.CanaryCount > 0 && strategy.CanaryPercent > 0 {
+		return errors.Errorf("only one of canary count and canary percent can be set")
+	}
+	prev := int32(0)
+	for _, p := range strategy.WavePercents {
+		if p <= prev || p > 100 {
+			return errors.Errorf("wave percents must be increasing and between 1 and 100, got %v", strategy.WavePercents)
+		}
+		prev = p
+	}
+	if strategy.MaxConcurrency < 0 {
+		return errors.Errorf("max concurrency must not be negative")
+	}
+	if strategy.MaxFailurePercent < 0 || strategy.MaxFailurePercent > 100 {
+		return errors.Errorf("max failure percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// GetWaveEnds returns the exclusive end index of each wave for the total number of databases in the stage.
+// The databases are ordered by their first tasks, and the last wave always ends at the total.
+func GetWaveEnds(strategy *storepb.RolloutStrategy, total int) []int {
+	var ends []int
+	appendEnd := func(end int) {
+		end = min(end, total)
+		if end > 0 && (len(ends) == 0 || end > ends[len(ends)-1]) {
+			ends = append(ends, end)
+		}
+	}
+	switch {
+	case strategy.CanaryCount > 0:
+		appendEnd(int(strategy.CanaryCount))
+	case strategy.CanaryPercent > 0:
+		appendEnd(ceilPercent(total, int(strategy.CanaryPercent)))
+	}
+	for _, p := range strategy.WavePercents {
+		appendEnd(ceilPercent(total, int(p)))
+	}
+	appendEnd(total)
+	return ends
+}
+
+// Check returns the reason if the task of the database at the index of the stage isn't allowed to start, or an empty string otherwise.
+// The statuses are the statuses of all databases in the stage ordered by their first tasks, see MergeStatuses.
+func Check(strategy *storepb.RolloutStrategy, statuses []TaskStatus, index int) string {
+	if strategy == nil {
+		return ""
+	}
+	ends := GetWaveEnds(strategy, len(statuses))
+	wave, waveStart := 0, 0
+	for wave < len(ends) && index >= ends[wave] {
+		waveStart = ends[wave]
+		wave++
+	}
+	for i := 0; i < waveStart; i++ {
+		if statuses[i] == TaskPending || statuses[i] == TaskRunning {
+			return fmt.Sprintf("waiting for the databases of wave %d to finish before starting wave %d", wave, wave+1)
+		}
+	}
+
+	running, finished, failed := 0, 0, 0
+	for _, status := range statuses {
+		switch status {
+		case TaskRunning:
+			running++
+		case TaskDone:
+			finished++
+		case TaskFailed:
+			finished++
+			failed++
+		}
+	}
+	if failed > 0 && failed*100 > int(strategy.MaxFailurePercent)*finished {
+		return fmt.Sprintf("the rollout is paused because %d of %d finished databases failed, exceeding the max failure percent %d%%", failed, finished, strategy.MaxFailurePercent)
+	}
+	if strategy.MaxConcurrency > 0 && running >= int(strategy.MaxConcurrency) {
+		return fmt.Sprintf("%d databases are rolling out, reaching the max concurrency", running)
+	}
+	return ""
+}
+
+// MergeStatuses returns the rollout status of a database from the statuses of its tasks in the stage,
+// e.g. the sync and the cutover tasks of the online schema change.
+// The database is running if some of its tasks are done and the others are pending.
+func MergeStatuses(statuses []TaskStatus) TaskStatus {
+	pending, running, done := 0, 0, 0
+	for _, status := range statuses {
+		switch status {
+		case TaskFailed:
+			return TaskFailed
+		case TaskRunning:
+			running++
+		case TaskDone:
+			done++
+		case TaskPending:
+			pending++
+		}
+	}
+	switch {
+	case running > 0, done > 0 && pending > 0:
+		return TaskRunning
+	case pending > 0:
+		return TaskPending
+	default:
+		return TaskDone
+	}
+}
+
+// ceilPercent rounds up so that a non-zero percent always covers at least one task.
+func ceilPercent(total, percent int) int {
+	return (total*percent + 99) / 100
+}
@@ -0,0 +1,113 @@
+package rolloutstrategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestGetWaveEnds(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		strategy *storepb.RolloutStrategy
+		total    int
+		want     []int
+	}{
+		{
+			strategy: &storepb.RolloutStrategy{},
+			total:    10,
+			want:     []int{10},
+		},
+		{
+			strategy: &storepb.RolloutStrategy{CanaryCount: 2, WavePercents: []int32{10, 50}},
+			total:    2000,
+			want:     []int{2, 200, 1000, 2000},
+		},
+		{
+			// The canary percent rounds up to one task.
+			strategy: &storepb.RolloutStrategy{CanaryPercent: 1, WavePercents: []int32{50, 100}},
+			total:    10,
+			want:     []int{1, 5, 10},
+		},
+		{
+			// The waves covered by the canary are merged.
+			strategy: &storepb.RolloutStrategy{CanaryCount: 5, WavePercents: []int32{10, 60}},
+			total:    10,
+			want:     []int{5, 6, 10},
+		},
+		{
+			strategy: &storepb.RolloutStrategy{CanaryCount: 5},
+			total:    3,
+			want:     []int{3},
+		},
+		{
+			strategy: &storepb.RolloutStrategy{CanaryCount: 1},
+			total:    0,
+			want:     nil,
+		},
+	}
+	for _, test := range tests {
+		a.Equal(test.want, GetWaveEnds(test.strategy, test.total), test.strategy.String())
+	}
+}
+
+func TestCheck(t *testing.T) {
+	a := require.New(t)
+	strategy := &storepb.RolloutStrategy{CanaryCount: 1, WavePercents: []int32{50}, MaxConcurrency: 2, MaxFailurePercent: 20}
+
+	// The canary runs first.
+	statuses := []TaskStatus{TaskPending, TaskPending, TaskPending, TaskPending, TaskPending, TaskPending}
+	a.Empty(Check(strategy, statuses, 0))
+	a.Contains(Check(strategy, statuses, 1), "wave 1")
+
+	// The second wave starts after the canary is done.
+	statuses[0] = TaskDone
+	a.Empty(Check(strategy, statuses, 1))
+	a.Contains(Check(strategy, statuses, 3), "wave 2")
+
+	// The concurrency limit.
+	statuses[1], statuses[2] = TaskRunning, TaskRunning
+	a.Contains(Check(strategy, statuses, 2), "max concurrency")
+
+	// One of three finished tasks failed, exceeding 20%.
+	statuses[1], statuses[2] = TaskDone, TaskFailed
+	a.Contains(Check(strategy, statuses, 3), "paused")
+
+	// Failures under the threshold don't pause the rollout.
+	strategy.MaxFailurePercent = 50
+	a.Empty(Check(strategy, statuses, 3))
+
+	a.Empty(Check(nil, statuses, 3))
+}
+
+func TestValidate(t *testing.T) {
+	a := require.New(t)
+	a.NoError(Validate(nil))
+	a.NoError(Validate(&storepb.RolloutStrategy{CanaryPercent: 5, WavePercents: []int32{20, 100}, MaxConcurrency: 10, MaxFailurePercent: 5}))
+	a.Error(Validate(&storepb.RolloutStrategy{CanaryCount: 1, CanaryPercent: 5}))
+	a.Error(Validate(&storepb.RolloutStrategy{WavePercents: []int32{50, 20}}))
+	a.Error(Validate(&storepb.RolloutStrategy{WavePercents: []int32{120}}))
+	a.Error(Validate(&storepb.RolloutStrategy{MaxFailurePercent: 101}))
+	a.Error(Validate(&storepb.RolloutStrategy{MaxConcurrency: -1}))
+}
+
+func TestMergeStatuses(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		statuses []TaskStatus
+		want     TaskStatus
+	}{
+		{[]TaskStatus{TaskPending}, TaskPending},
+		{[]TaskStatus{TaskDone, TaskDone}, TaskDone},
+		// The sync task of the online schema change is done, and the cutover task is pending.
+		{[]TaskStatus{TaskDone, TaskPending}, TaskRunning},
+		{[]TaskStatus{TaskRunning, TaskPending}, TaskRunning},
+		{[]TaskStatus{TaskDone, TaskFailed}, TaskFailed},
+		{nil, TaskDone},
+	}
+	for _, test := range tests {
+		a.Equal(test.want, MergeStatuses(test.statuses), test.statuses)
+	}
+}
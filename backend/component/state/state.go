@@ -6,6 +6,7 @@ import (
 	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 
@@ -37,6 +38,8 @@ type State struct {
 
 	// TaskRunExecutionStatuses is the map from task run ID to task run execution status.
 	TaskRunExecutionStatuses sync.Map // map[taskRunID]TaskRunExecutionStatus
+	// BatchDataUpdateControls is the map from task ID to the control of the running batched data update.
+	BatchDataUpdateControls sync.Map // map[taskID]*BatchDataUpdateControl
 
 	// RunningTaskRuns is the set of running taskruns.
 	RunningTaskRuns sync.Map // map[taskRunID]bool
@@ -61,6 +64,8 @@ type State struct {
 
 	// TaskSkippedOrDoneChan is the channel for notifying the task is skipped or done.
 	TaskSkippedOrDoneChan chan int
+	// PGOSCCleanupChan is the channel for cleaning up the abandoned PostgreSQL online schema changes of pipeline pipelineUID.
+	PGOSCCleanupChan chan int
 
 	// InstanceSyncTickleChan is the tickler for syncing instances.
 	InstanceSyncTickleChan chan int
@@ -84,6 +89,7 @@ func New() (*State, error) {
 		InstanceOutstandingConnections:       make(map[int]int),
 		IssueExternalApprovalRelayCancelChan: make(chan int, 1),
 		TaskSkippedOrDoneChan:                make(chan int, 1000),
+		PGOSCCleanupChan:                     make(chan int, 1000),
 		InstanceSyncTickleChan:               make(chan int, 1000),
 		PlanCheckTickleChan:                  make(chan int, 1000),
 		TaskRunTickleChan:                    make(chan int, 1000),
@@ -106,3 +112,72 @@ type TaskRunExecutionStatus struct {
 	ExecutionDetail *v1pb.TaskRun_ExecutionDetail
 	UpdateTime      time.Time
 }
+
+// BatchDataUpdateControl controls a running batched data update, which can be paused, resumed and adjusted by the users.
+type BatchDataUpdateControl struct {
+	mu            sync.Mutex
+	paused        bool
+	batchSize     int64
+	sleepInterval time.Duration
+	progress      *v1pb.TaskRun_ExecutionDetail_BatchProgress
+}
+
+// NewBatchDataUpdateControl creates a batched data update control.
+func NewBatchDataUpdateControl(batchSize int64, sleepInterval time.Duration) *BatchDataUpdateControl {
+	return &BatchDataUpdateControl{
+		batchSize:     batchSize,
+		sleepInterval: sleepInterval,
+		progress:      &v1pb.TaskRun_ExecutionDetail_BatchProgress{},
+	}
+}
+
+// Get gets the settings of the batched data update.
+func (c *BatchDataUpdateControl) Get() (paused bool, batchSize int64, sleepInterval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused, c.batchSize, c.sleepInterval
+}
+
+// SetPaused pauses or resumes the batched data update.
+func (c *BatchDataUpdateControl) SetPaused(paused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = paused
+}
+
+// SetBatchSize sets the number of rows in the following batches.
+func (c *BatchDataUpdateControl) SetBatchSize(batchSize int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.batchSize = batchSize
+}
+
+// SetSleepInterval sets the time to sleep after the following batches.
+func (c *BatchDataUpdateControl) SetSleepInterval(sleepInterval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleepInterval = sleepInterval
+}
+
+// SetProgress sets the progress reported by the batched data update.
+func (c *BatchDataUpdateControl) SetProgress(progress *v1pb.TaskRun_ExecutionDetail_BatchProgress) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.progress = progress
+}
+
+// GetProgress gets the progress with the current settings.
+func (c *BatchDataUpdateControl) GetProgress() *v1pb.TaskRun_ExecutionDetail_BatchProgress {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &v1pb.TaskRun_ExecutionDetail_BatchProgress{
+		StatementIndex:   c.progress.StatementIndex,
+		BatchesCompleted: c.progress.BatchesCompleted,
+		AffectedRows:     c.progress.AffectedRows,
+		Paused:           c.paused,
+		Throttled:        c.progress.Throttled,
+		ThrottleReason:   c.progress.ThrottleReason,
+		BatchSize:        c.batchSize,
+		SleepInterval:    durationpb.New(c.sleepInterval),
+	}
+}
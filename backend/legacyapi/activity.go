@@ -176,6 +176,8 @@ type ActivityPipelineTaskStatusUpdatePayload struct {
 type ActivityPipelineTaskRunStatusUpdatePayload struct {
 	TaskID    int           `json:"taskId"`
 	NewStatus TaskRunStatus `json:"newStatus,omitempty"`
+	// EarliestAllowedTs is the earliest allowed time of the canceled task run that was waiting for it.
+	EarliestAllowedTs int64 `json:"earliestAllowedTs,omitempty"`
 	// Used by inbox to display info without paying the join cost
 	IssueName string `json:"issueName"`
 	TaskName  string `json:"taskName"`
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/bytebase/bytebase/backend/common"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
 // DeploymentConfig is the API message for deployment configurations.
@@ -30,6 +31,8 @@ type Deployment struct {
 // DeploymentSpec is the API message for deployment specification.
 type DeploymentSpec struct {
 	Selector *LabelSelector `json:"selector"`
+	// Rollout is the rollout strategy of the stage, or nil to roll out all databases in the stage at once.
+	Rollout *storepb.RolloutStrategy `json:"rollout,omitempty"`
 }
 
 // LabelSelector is the API message for label selector.
@@ -53,6 +53,10 @@ const (
 	PolicyTypeMaskingRule PolicyType = "bb.policy.masking-rule"
 	// PolicyTypeRestrictIssueCreationForSQLReview is the policy type for restricting issue creation for SQL review.
 	PolicyTypeRestrictIssueCreationForSQLReview PolicyType = "bb.policy.restrict-issue-creation-for-sql-review"
+	// PolicyTypeDeploymentWindow is the policy type for the maintenance and freeze windows of task runs.
+	PolicyTypeDeploymentWindow PolicyType = "bb.policy.deployment-window"
+	// PolicyTypeProductionSafety is the policy type for the plan checks on the live state of the databases.
+	PolicyTypeProductionSafety PolicyType = "bb.policy.production-safety"
 
 	// PipelineApprovalValueManualNever means the pipeline will automatically be approved without user intervention.
 	PipelineApprovalValueManualNever PipelineApprovalValue = "MANUAL_APPROVAL_NEVER"
@@ -105,6 +109,8 @@ var (
 		PolicyTypeMaskingRule:                       {PolicyResourceTypeWorkspace},
 		PolicyTypeMaskingException:                  {PolicyResourceTypeProject},
 		PolicyTypeRestrictIssueCreationForSQLReview: {PolicyResourceTypeWorkspace},
+		PolicyTypeDeploymentWindow:                  {PolicyResourceTypeEnvironment},
+		PolicyTypeProductionSafety:                  {PolicyResourceTypeEnvironment},
 	}
 )
 
@@ -44,6 +44,8 @@ const (
 	SettingSemanticTypes SettingName = "bb.workspace.semantic-types"
 	// SettingMaskingAlgorithms is the setting name for masking algorithms.
 	SettingMaskingAlgorithm SettingName = "bb.workspace.masking-algorithm"
+	// SettingWorkloadIdentity is the setting name for workload identity providers.
+	SettingWorkloadIdentity SettingName = "bb.workspace.workload-identity"
 )
 
 // IMType is the type of IM.
@@ -42,6 +42,10 @@ const (
 	TaskDatabaseSchemaUpdateGhostSync TaskType = "bb.task.database.schema.update.ghost.sync"
 	// TaskDatabaseSchemaUpdateGhostCutover is the task type for gh-ost switching the original table and the ghost table.
 	TaskDatabaseSchemaUpdateGhostCutover TaskType = "bb.task.database.schema.update.ghost.cutover"
+	// TaskDatabaseSchemaUpdatePGOSCSync is the task type for the PostgreSQL online schema change syncing the shadow table.
+	TaskDatabaseSchemaUpdatePGOSCSync TaskType = "bb.task.database.schema.update.pgosc.sync"
+	// TaskDatabaseSchemaUpdatePGOSCCutover is the task type for the PostgreSQL online schema change replacing the original table with the shadow table.
+	TaskDatabaseSchemaUpdatePGOSCCutover TaskType = "bb.task.database.schema.update.pgosc.cutover"
 	// TaskDatabaseDataUpdate is the task type for updating database data.
 	TaskDatabaseDataUpdate TaskType = "bb.task.database.data.update"
 	// TaskDatabaseBackup is the task type for creating database backups.
@@ -82,6 +86,9 @@ type TaskDatabasePITRRestorePayload struct {
 	// After the PITR operations, the database will be recovered to the state at this time.
 	// Represented in UNIX timestamp in seconds.
 	PointInTimeTs *int64 `json:"pointInTimeTs,omitempty"`
+
+	// RollbackFromTaskID is the task ID whose change is rolled back by restoring the backup.
+	RollbackFromTaskID int `json:"rollbackFromTaskId,omitempty"`
 }
 
 // TaskDatabasePITRCutoverPayload is the task payload for PITR cutover.
@@ -91,6 +98,9 @@ type TaskDatabasePITRCutoverPayload struct {
 	Skipped       bool   `json:"skipped,omitempty"`
 	SkippedReason string `json:"skippedReason,omitempty"`
 	SpecID        string `json:"specId,omitempty"`
+
+	// RollbackFromTaskID is the task ID whose change is rolled back by restoring the backup.
+	RollbackFromTaskID int `json:"rollbackFromTaskId,omitempty"`
 }
 
 // TaskDatabaseCreatePayload is the task payload for creating databases.
@@ -169,6 +179,27 @@ type TaskDatabaseSchemaUpdateGhostCutoverPayload struct {
 	SpecID        string `json:"specId,omitempty"`
 }
 
+// TaskDatabaseSchemaUpdatePGOSCSyncPayload is the task payload for the PostgreSQL online schema change syncing the shadow table.
+type TaskDatabaseSchemaUpdatePGOSCSyncPayload struct {
+	// Common fields
+	Skipped       bool   `json:"skipped,omitempty"`
+	SkippedReason string `json:"skippedReason,omitempty"`
+	SpecID        string `json:"specId,omitempty"`
+
+	SheetID       int    `json:"sheetId,omitempty"`
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+
+	Flags map[string]string `json:"flags,omitempty"`
+}
+
+// TaskDatabaseSchemaUpdatePGOSCCutoverPayload is the task payload for the PostgreSQL online schema change replacing the original table with the shadow table.
+type TaskDatabaseSchemaUpdatePGOSCCutoverPayload struct {
+	// Common fields
+	Skipped       bool   `json:"skipped,omitempty"`
+	SkippedReason string `json:"skippedReason,omitempty"`
+	SpecID        string `json:"specId,omitempty"`
+}
+
 // RollbackSQLStatus is the status of a rollback SQL generation task.
 type RollbackSQLStatus string
 
@@ -221,6 +252,19 @@ type TaskDatabaseDataUpdatePayload struct {
 	RollbackFromTaskID int `json:"rollbackFromTaskId,omitempty"`
 
 	SchemaGroupName string `json:"schemaGroupName,omitempty"`
+
+	// BatchConfig runs the single-table UPDATE and DELETE statements in batches of the primary key ranges if set.
+	BatchConfig *DataUpdateBatchConfig `json:"batchConfig,omitempty"`
+}
+
+// DataUpdateBatchConfig is the config of running the data update in batches.
+type DataUpdateBatchConfig struct {
+	// BatchSize is the number of rows in each batch.
+	BatchSize int64 `json:"batchSize,omitempty"`
+	// SleepIntervalMs is the time to sleep after each batch in milliseconds.
+	SleepIntervalMs int64 `json:"sleepIntervalMs,omitempty"`
+	// MaxReplicaLagMs pauses the batches while the replica lag exceeds it. Zero disables the throttling.
+	MaxReplicaLagMs int64 `json:"maxReplicaLagMs,omitempty"`
 }
 
 // TaskDatabaseBackupPayload is the task payload for database backup.
@@ -304,4 +348,6 @@ type TaskPatch struct {
 
 	// Flags for gh-ost.
 	Flags *map[string]string
+	// BatchConfig for the batched data update. A pointer to nil config runs the data update as is.
+	BatchConfig **DataUpdateBatchConfig
 }
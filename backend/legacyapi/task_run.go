@@ -1,5 +1,9 @@
 package api
 
+import (
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
 // TaskRunStatus is the status of a task run.
 type TaskRunStatus string
 
@@ -28,4 +32,6 @@ type TaskRunResultPayload struct {
 	MigrationID   string `json:"migrationId,omitempty"`
 	ChangeHistory string `json:"changeHistory,omitempty"`
 	Version       string `json:"version,omitempty"`
+	// StatementResults are the results of the statements if the task executes the statements one by one.
+	StatementResults []*storepb.TaskRunResult_StatementResult `json:"statementResults,omitempty"`
 }
@@ -0,0 +1,19 @@
+CREATE TABLE personal_access_token (
+    id SERIAL PRIMARY KEY,
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    name TEXT NOT NULL,
+    -- The SHA-256 hash of the token, the token itself is never stored.
+    token_hash TEXT NOT NULL,
+    -- 0 means the token never expires.
+    expire_ts BIGINT NOT NULL DEFAULT 0,
+    last_used_ts BIGINT NOT NULL DEFAULT 0,
+    -- Stored as PersonalAccessTokenPayload.
+    payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE UNIQUE INDEX idx_personal_access_token_unique_token_hash ON personal_access_token(token_hash);
+
+CREATE UNIQUE INDEX idx_personal_access_token_unique_principal_id_name ON personal_access_token(principal_id, name);
+
+ALTER SEQUENCE personal_access_token_id_seq RESTART WITH 101;
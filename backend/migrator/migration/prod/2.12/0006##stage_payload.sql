@@ -0,0 +1 @@
+ALTER TABLE stage ADD COLUMN payload JSONB NOT NULL DEFAULT '{}';
@@ -577,7 +577,8 @@ CREATE TABLE stage (
     updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
     pipeline_id INTEGER NOT NULL REFERENCES pipeline (id),
     environment_id INTEGER NOT NULL REFERENCES environment (id),
-    name TEXT NOT NULL
+    name TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'
 );
 
 CREATE INDEX idx_stage_pipeline_id ON stage(pipeline_id);
@@ -1279,4 +1280,38 @@ CREATE TRIGGER update_branch_updated_ts
 BEFORE
 UPDATE
     ON branch FOR EACH ROW
-EXECUTE FUNCTION trigger_update_updated_ts();
\ No newline at end of file
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+CREATE TABLE personal_access_token (
+  id SERIAL PRIMARY KEY,
+  principal_id INTEGER NOT NULL REFERENCES principal (id),
+  created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+  name TEXT NOT NULL,
+  -- The SHA-256 hash of the token, the token itself is never stored.
+  token_hash TEXT NOT NULL,
+  -- 0 means the token never expires.
+  expire_ts BIGINT NOT NULL DEFAULT 0,
+  last_used_ts BIGINT NOT NULL DEFAULT 0,
+  -- Stored as PersonalAccessTokenPayload.
+  payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE UNIQUE INDEX idx_personal_access_token_unique_token_hash ON personal_access_token(token_hash);
+
+CREATE UNIQUE INDEX idx_personal_access_token_unique_principal_id_name ON personal_access_token(principal_id, name);
+
+ALTER SEQUENCE personal_access_token_id_seq RESTART WITH 101;
+
+CREATE TABLE task_run_log (
+  id BIGSERIAL PRIMARY KEY,
+  task_run_id INTEGER NOT NULL REFERENCES task_run (id) ON DELETE CASCADE,
+  created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+  -- Stored as TaskRunLog.
+  payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_task_run_log_task_run_id ON task_run_log(task_run_id);
+
+CREATE INDEX idx_task_run_log_created_ts ON task_run_log(created_ts);
+
+ALTER SEQUENCE task_run_log_id_seq RESTART WITH 101;
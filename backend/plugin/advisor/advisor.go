@@ -216,6 +216,9 @@ const (
 	// MySQLStatementDMLDryRun is an advisor type for MySQL DML dry run.
 	MySQLStatementDMLDryRun Type = "bb.plugin.advisor.mysql.statement.dml-dry-run"
 
+	// MySQLCustomRule is an advisor type for MySQL custom rules in CEL.
+	MySQLCustomRule Type = "bb.plugin.advisor.mysql.custom-rule"
+
 	// PostgreSQL Advisor.
 
 	// PostgreSQLSyntax is an advisor type for PostgreSQL syntax.
@@ -329,12 +332,33 @@ const (
 	// PostgreSQLDisallowAddNotNull is an advisor type for PostgreSQl to disallow add not null.
 	PostgreSQLDisallowAddNotNull Type = "bb.plugin.advisor.postgresql.statement.disallow-add-not-null"
 
+	// PostgreSQLDisallowTableRewrite is an advisor type for PostgreSQL to disallow ALTER COLUMN TYPE rewriting the table.
+	PostgreSQLDisallowTableRewrite Type = "bb.plugin.advisor.postgresql.statement.disallow-table-rewrite"
+
+	// PostgreSQLSetNotNullRequireCheck is an advisor type for PostgreSQL to require a validated CHECK constraint before SET NOT NULL.
+	PostgreSQLSetNotNullRequireCheck Type = "bb.plugin.advisor.postgresql.statement.set-not-null-require-check"
+
+	// PostgreSQLAddFKNotValid is an advisor type for PostgreSQL to add foreign key not valid.
+	PostgreSQLAddFKNotValid Type = "bb.plugin.advisor.postgresql.statement.add-fk-not-valid"
+
+	// PostgreSQLDisallowBlockingMaintenance is an advisor type for PostgreSQL to disallow VACUUM FULL, CLUSTER and REINDEX without CONCURRENTLY.
+	PostgreSQLDisallowBlockingMaintenance Type = "bb.plugin.advisor.postgresql.statement.disallow-blocking-maintenance"
+
+	// PostgreSQLRequireLockTimeout is an advisor type for PostgreSQL to require lock_timeout or statement_timeout before DDL.
+	PostgreSQLRequireLockTimeout Type = "bb.plugin.advisor.postgresql.statement.require-lock-timeout"
+
+	// PostgreSQLColumnDisallowRenameReferencedByView is an advisor type for PostgreSQL to disallow renaming the column referenced by views.
+	PostgreSQLColumnDisallowRenameReferencedByView Type = "bb.plugin.advisor.postgresql.column.disallow-rename-referenced-by-view"
+
 	// PostgreSQLTableDropNamingConvention is an advisor type for PostgreSQL table drop with naming convention.
 	PostgreSQLTableDropNamingConvention Type = "bb.plugin.advisor.postgresql.table.drop-naming-convention"
 
 	// PostgreSQLCollationAllowlist is an advisor type for PostgreSQL collation allowlist.
 	PostgreSQLCollationAllowlist Type = "bb.plugin.advisor.postgresql.collation.allowlist"
 
+	// PostgreSQLCustomRule is an advisor type for PostgreSQL custom rules in CEL.
+	PostgreSQLCustomRule Type = "bb.plugin.advisor.postgresql.custom-rule"
+
 	// Oracle Advisor.
 
 	// OracleSyntax is an advisor type for Oracle syntax.
@@ -394,6 +418,15 @@ const (
 	// OracleIdentifierCase is an advisor type for Oracle identifier case.
 	OracleIdentifierCase Type = "bb.plugin.advisor.oracle.naming.identifier-case"
 
+	// OracleMigrationCompatibility is an advisor type for Oracle migration compatibility.
+	OracleMigrationCompatibility Type = "bb.plugin.advisor.oracle.migration-compatibility"
+
+	// OracleColumnDisallowDropInIndex is an advisor type for Oracle disallow DROP COLUMN in index.
+	OracleColumnDisallowDropInIndex Type = "bb.plugin.advisor.oracle.column.disallow-drop-in-index"
+
+	// OracleIndexNoDuplicateIndex is an advisor type for Oracle no duplicate index.
+	OracleIndexNoDuplicateIndex Type = "bb.plugin.advisor.oracle.index.no-duplicate-index"
+
 	// Snowflake Advisor.
 
 	// SnowflakeSyntax is an advisor type for Snowflake syntax.
@@ -438,6 +471,42 @@ const (
 	// SnowflakeMigrationCompatibility is an advisor type for Snowflake migration compatibility.
 	SnowflakeMigrationCompatibility Type = "bb.plugin.advisor.snowflake.migration-compatibility"
 
+	// SnowflakeIndexKeyNumberLimit is an advisor type for Snowflake index key number limit.
+	SnowflakeIndexKeyNumberLimit Type = "bb.plugin.advisor.snowflake.index.key-number-limit"
+
+	// SnowflakeIndexTotalNumberLimit is an advisor type for Snowflake index total number limit.
+	SnowflakeIndexTotalNumberLimit Type = "bb.plugin.advisor.snowflake.index.total-number-limit"
+
+	// SnowflakeIndexNoDuplicateColumn is an advisor type for Snowflake no duplicate columns in index.
+	SnowflakeIndexNoDuplicateColumn Type = "bb.plugin.advisor.snowflake.index.no-duplicate-column"
+
+	// SnowflakePrimaryKeyTypeAllowlist is an advisor type for Snowflake primary key type allowlist.
+	SnowflakePrimaryKeyTypeAllowlist Type = "bb.plugin.advisor.snowflake.index.primary-key-type-allowlist"
+
+	// SnowflakeRequireColumnDefault is an advisor type for Snowflake column requires default value.
+	SnowflakeRequireColumnDefault Type = "bb.plugin.advisor.snowflake.column.require-default"
+
+	// SnowflakeColumnTypeDisallowList is an advisor type for Snowflake column type disallow list.
+	SnowflakeColumnTypeDisallowList Type = "bb.plugin.advisor.snowflake.column.type-disallow-list"
+
+	// SnowflakeColumnCommentConvention is an advisor type for Snowflake column comment convention.
+	SnowflakeColumnCommentConvention Type = "bb.plugin.advisor.snowflake.column.comment"
+
+	// SnowflakeColumnDisallowChangingType is an advisor type for Snowflake disallow changing column type.
+	SnowflakeColumnDisallowChangingType Type = "bb.plugin.advisor.snowflake.column.disallow-changing-type"
+
+	// SnowflakeStatementAffectedRowLimit is an advisor type for Snowflake UPDATE/DELETE affected row limit.
+	SnowflakeStatementAffectedRowLimit Type = "bb.plugin.advisor.snowflake.statement.affected-row-limit"
+
+	// SnowflakeStatementDMLDryRun is an advisor type for Snowflake DML dry run.
+	SnowflakeStatementDMLDryRun Type = "bb.plugin.advisor.snowflake.statement.dml-dry-run"
+
+	// SnowflakeInsertMustSpecifyColumn is an advisor type for Snowflake INSERT must specify column.
+	SnowflakeInsertMustSpecifyColumn Type = "bb.plugin.advisor.snowflake.insert.must-specify-column"
+
+	// SnowflakeTableCommentConvention is an advisor type for Snowflake table comment convention.
+	SnowflakeTableCommentConvention Type = "bb.plugin.advisor.snowflake.table.comment"
+
 	// MSSQL Advisor.
 
 	// MSSQLSyntax is an advisor type for MSSQL syntax.
@@ -478,6 +547,112 @@ const (
 
 	// MSSQLColumnRequirement is an advisor type for MSSQL column requirement.
 	MSSQLColumnRequirement Type = "bb.plugin.advisor.mssql.column.require"
+
+	// MSSQLColumnDisallowDropInIndex is an advisor type for MSSQL disallow DROP COLUMN in index.
+	MSSQLColumnDisallowDropInIndex Type = "bb.plugin.advisor.mssql.column.disallow-drop-in-index"
+
+	// MSSQLIndexNoDuplicateIndex is an advisor type for MSSQL no duplicate index.
+	MSSQLIndexNoDuplicateIndex Type = "bb.plugin.advisor.mssql.index.no-duplicate-index"
+
+	// MSSQLIndexKeyNumberLimit is an advisor type for MSSQL index key number limit.
+	MSSQLIndexKeyNumberLimit Type = "bb.plugin.advisor.mssql.index.key-number-limit"
+
+	// MSSQLIndexTotalNumberLimit is an advisor type for MSSQL index total number limit.
+	MSSQLIndexTotalNumberLimit Type = "bb.plugin.advisor.mssql.index.total-number-limit"
+
+	// MSSQLIndexNoDuplicateColumn is an advisor type for MSSQL no duplicate columns in index.
+	MSSQLIndexNoDuplicateColumn Type = "bb.plugin.advisor.mssql.index.no-duplicate-column"
+
+	// MSSQLPrimaryKeyTypeAllowlist is an advisor type for MSSQL primary key type allowlist.
+	MSSQLPrimaryKeyTypeAllowlist Type = "bb.plugin.advisor.mssql.index.primary-key-type-allowlist"
+
+	// MSSQLRequireColumnDefault is an advisor type for MSSQL column requires default value.
+	MSSQLRequireColumnDefault Type = "bb.plugin.advisor.mssql.column.require-default"
+
+	// MSSQLColumnTypeDisallowList is an advisor type for MSSQL column type disallow list.
+	MSSQLColumnTypeDisallowList Type = "bb.plugin.advisor.mssql.column.type-disallow-list"
+
+	// MSSQLColumnCommentConvention is an advisor type for MSSQL column comment convention.
+	MSSQLColumnCommentConvention Type = "bb.plugin.advisor.mssql.column.comment"
+
+	// MSSQLColumnDisallowChangingType is an advisor type for MSSQL disallow changing column type.
+	MSSQLColumnDisallowChangingType Type = "bb.plugin.advisor.mssql.column.disallow-changing-type"
+
+	// MSSQLStatementAffectedRowLimit is an advisor type for MSSQL UPDATE/DELETE affected row limit.
+	MSSQLStatementAffectedRowLimit Type = "bb.plugin.advisor.mssql.statement.affected-row-limit"
+
+	// MSSQLStatementDMLDryRun is an advisor type for MSSQL DML dry run.
+	MSSQLStatementDMLDryRun Type = "bb.plugin.advisor.mssql.statement.dml-dry-run"
+
+	// MSSQLInsertMustSpecifyColumn is an advisor type for MSSQL INSERT must specify column.
+	MSSQLInsertMustSpecifyColumn Type = "bb.plugin.advisor.mssql.insert.must-specify-column"
+
+	// MSSQLTableCommentConvention is an advisor type for MSSQL table comment convention.
+	MSSQLTableCommentConvention Type = "bb.plugin.advisor.mssql.table.comment"
+
+	// ClickHouse Advisor.
+
+	// ClickHouseSyntax is an advisor type for ClickHouse syntax.
+	ClickHouseSyntax Type = "bb.plugin.advisor.clickhouse.syntax"
+
+	// ClickHouseNamingTableConvention is an advisor type for ClickHouse table naming convention.
+	ClickHouseNamingTableConvention Type = "bb.plugin.advisor.clickhouse.naming.table"
+
+	// ClickHouseNamingColumnConvention is an advisor type for ClickHouse column naming convention.
+	ClickHouseNamingColumnConvention Type = "bb.plugin.advisor.clickhouse.naming.column"
+
+	// ClickHouseTableRequirePK is an advisor type for ClickHouse table require primary key or ORDER BY.
+	ClickHouseTableRequirePK Type = "bb.plugin.advisor.clickhouse.table.require-pk"
+
+	// ClickHouseNoSelectAll is an advisor type for ClickHouse no select all.
+	ClickHouseNoSelectAll Type = "bb.plugin.advisor.clickhouse.select.no-select-all"
+
+	// ClickHouseWhereRequirement is an advisor type for ClickHouse WHERE clause requirement.
+	ClickHouseWhereRequirement Type = "bb.plugin.advisor.clickhouse.where.require"
+
+	// ClickHouseTableDropNamingConvention is an advisor type for ClickHouse table drop with naming convention.
+	ClickHouseTableDropNamingConvention Type = "bb.plugin.advisor.clickhouse.table.drop-naming-convention"
+
+	// ClickHouseDisallowMutation is an advisor type for ClickHouse disallow ALTER TABLE ... UPDATE/DELETE mutations.
+	ClickHouseDisallowMutation Type = "bb.plugin.advisor.clickhouse.statement.disallow-mutation"
+
+	// ClickHouseTableRequireTTL is an advisor type for ClickHouse table require TTL.
+	ClickHouseTableRequireTTL Type = "bb.plugin.advisor.clickhouse.table.require-ttl"
+
+	// ClickHouseColumnDisallowNullableInSortingKey is an advisor type for ClickHouse disallow nullable column in sorting key.
+	ClickHouseColumnDisallowNullableInSortingKey Type = "bb.plugin.advisor.clickhouse.column.disallow-nullable-in-sorting-key"
+
+	// Spanner Advisor.
+
+	// SpannerSyntax is an advisor type for Spanner syntax.
+	SpannerSyntax Type = "bb.plugin.advisor.spanner.syntax"
+
+	// SpannerNamingTableConvention is an advisor type for Spanner table naming convention.
+	SpannerNamingTableConvention Type = "bb.plugin.advisor.spanner.naming.table"
+
+	// SpannerNamingColumnConvention is an advisor type for Spanner column naming convention.
+	SpannerNamingColumnConvention Type = "bb.plugin.advisor.spanner.naming.column"
+
+	// SpannerTableRequirePK is an advisor type for Spanner table require primary key.
+	SpannerTableRequirePK Type = "bb.plugin.advisor.spanner.table.require-pk"
+
+	// SpannerNoSelectAll is an advisor type for Spanner no select all.
+	SpannerNoSelectAll Type = "bb.plugin.advisor.spanner.select.no-select-all"
+
+	// SpannerWhereRequirement is an advisor type for Spanner WHERE clause requirement.
+	SpannerWhereRequirement Type = "bb.plugin.advisor.spanner.where.require"
+
+	// SpannerTableDropNamingConvention is an advisor type for Spanner table drop with naming convention.
+	SpannerTableDropNamingConvention Type = "bb.plugin.advisor.spanner.table.drop-naming-convention"
+
+	// SpannerTableRequireInterleave is an advisor type for Spanner require interleaving the child table.
+	SpannerTableRequireInterleave Type = "bb.plugin.advisor.spanner.table.require-interleave"
+
+	// SpannerTableRequireInterleaveOnDelete is an advisor type for Spanner require ON DELETE for the interleaved table.
+	SpannerTableRequireInterleaveOnDelete Type = "bb.plugin.advisor.spanner.table.require-interleave-on-delete"
+
+	// SpannerPrimaryKeyDisallowMonotonic is an advisor type for Spanner disallow monotonically increasing primary key.
+	SpannerPrimaryKeyDisallowMonotonic Type = "bb.plugin.advisor.spanner.index.primary-key-disallow-monotonic"
 )
 
 // Advice is the result of an advisor.
@@ -489,8 +664,14 @@ type Advice struct {
 	Title   string `json:"title"`
 	Content string `json:"content"`
 	Line    int    `json:"line"`
+	// Column is the zero-based character offset in the line, the same as the columns of Fix.
 	Column  int    `json:"column"`
 	Details string `json:"details,omitempty"`
+	// Fix is the optional suggested change to resolve the advice.
+	Fix *Fix `json:"fix,omitempty"`
+	// RuleType is the type of the SQL review rule reporting the advice.
+	// It's empty for the advice not reported by a rule, e.g. the syntax error.
+	RuleType string `json:"ruleType,omitempty"`
 }
 
 // SyntaxMode is the type of syntax mode.
@@ -554,6 +735,13 @@ func Register(dbType storepb.Engine, advType Type, f Advisor) {
 	}
 }
 
+// IsSupported returns true if any advisor is registered for the database type.
+func IsSupported(dbType storepb.Engine) bool {
+	advisorMu.RLock()
+	defer advisorMu.RUnlock()
+	return len(advisors[dbType]) > 0
+}
+
 // Check runs the advisor and returns the advices.
 func Check(dbType storepb.Engine, advType Type, ctx Context, statement string) (adviceList []Advice, err error) {
 	defer func() {
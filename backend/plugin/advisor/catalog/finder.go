@@ -1,6 +1,8 @@
 package catalog
 
 import (
+	"github.com/pkg/errors"
+
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
@@ -30,6 +32,10 @@ type FinderContext struct {
 	// This controls the following identifier comparisons:
 	// Database, Table
 	IgnoreCaseSensitive bool
+
+	// CurrentSchema is the schema for the unqualified object names, it's only used for Oracle now.
+	// The schema with the same name as the database is used if it's empty.
+	CurrentSchema string
 }
 
 // Copy returns the deep copy.
@@ -38,6 +44,7 @@ func (ctx *FinderContext) Copy() *FinderContext {
 		CheckIntegrity:      ctx.CheckIntegrity,
 		EngineType:          ctx.EngineType,
 		IgnoreCaseSensitive: ctx.IgnoreCaseSensitive,
+		CurrentSchema:       ctx.CurrentSchema,
 	}
 }
 
@@ -57,6 +64,36 @@ func NewEmptyFinder(ctx *FinderContext) *Finder {
 	return &Finder{Origin: newDatabaseState(&storepb.DatabaseSchemaMetadata{}, ctx), Final: newDatabaseState(&storepb.DatabaseSchemaMetadata{}, ctx)}
 }
 
+// NewFinderWithSchema creates a finder with the database built by walking through the schema dump.
+// The finder checks integrity, e.g. altering a non-existent table is reported.
+// The currentSchema is the schema for the unqualified object names, see FinderContext.CurrentSchema.
+func NewFinderWithSchema(dbType storepb.Engine, currentSchema string, schema string) (*Finder, error) {
+	if !IsWalkThroughSupported(dbType) {
+		return nil, errors.Errorf("walk-through doesn't support engine type: %s", dbType)
+	}
+	database := &storepb.DatabaseSchemaMetadata{}
+	switch dbType {
+	case storepb.Engine_POSTGRES:
+		database.Schemas = []*storepb.SchemaMetadata{{Name: publicSchemaName}}
+	case storepb.Engine_MSSQL:
+		database.Schemas = []*storepb.SchemaMetadata{{Name: mssqlDefaultSchemaName}}
+	}
+	finder := NewFinder(database, &FinderContext{CheckIntegrity: true, EngineType: dbType, IgnoreCaseSensitive: false, CurrentSchema: currentSchema})
+	if err := finder.Origin.WalkThrough(schema); err != nil {
+		return nil, errors.Wrapf(err, "failed to walk through the schema")
+	}
+	if err := finder.Final.WalkThrough(schema); err != nil {
+		return nil, errors.Wrapf(err, "failed to walk through the schema")
+	}
+	return finder, nil
+}
+
+// SetCurrentSchema sets the schema for the unqualified object names.
+func (f *Finder) SetCurrentSchema(schema string) {
+	f.Origin.ctx.CurrentSchema = schema
+	f.Final.ctx.CurrentSchema = schema
+}
+
 // WalkThrough does the walk through.
 func (f *Finder) WalkThrough(statements string) error {
 	return f.Final.WalkThrough(statements)
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"strings"
 
+	"golang.org/x/exp/slices"
+
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
@@ -23,7 +25,11 @@ func newDatabaseState(d *storepb.DatabaseSchemaMetadata, context *FinderContext)
 	}
 
 	for _, schema := range d.Schemas {
-		database.schemaSet[schema.Name] = newSchemaState(schema, database.ctx)
+		schemaName := schema.Name
+		if context.EngineType == storepb.Engine_MSSQL {
+			schemaName = strings.ToLower(schemaName)
+		}
+		database.schemaSet[schemaName] = newSchemaState(schema, database.ctx)
 	}
 
 	for _, schema := range d.Schemas {
@@ -55,7 +61,11 @@ func newSchemaState(s *storepb.SchemaMetadata, context *FinderContext) *SchemaSt
 
 	for _, table := range s.Tables {
 		tableState := newTableState(table, context)
-		schema.tableSet[table.Name] = tableState
+		tableName := table.Name
+		if context.EngineType == storepb.Engine_MSSQL {
+			tableName = strings.ToLower(tableName)
+		}
+		schema.tableSet[tableName] = tableState
 
 		schema.identifierMap[table.Name] = true
 		for indexName := range tableState.indexSet {
@@ -89,12 +99,13 @@ func newTableState(t *storepb.TableMetadata, context *FinderContext) *TableState
 		columnSet:     make(columnStateMap),
 		indexSet:      make(IndexStateMap),
 		dependentView: make(map[string]bool),
+		rowCount:      t.RowCount,
 	}
 
 	for i, column := range t.Columns {
 		columnName := column.Name
 		switch context.EngineType {
-		case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB:
+		case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_MSSQL:
 			columnName = strings.ToLower(columnName)
 		}
 		table.columnSet[columnName] = newColumnState(column, i+1)
@@ -103,7 +114,7 @@ func newTableState(t *storepb.TableMetadata, context *FinderContext) *TableState
 	for _, index := range t.Indexes {
 		indexName := index.Name
 		switch context.EngineType {
-		case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB:
+		case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_MSSQL:
 			indexName = strings.ToLower(indexName)
 		}
 		table.indexSet[indexName] = newIndexState(index)
@@ -213,7 +224,7 @@ type IndexFind struct {
 // FindIndex finds the index.
 func (d *DatabaseState) FindIndex(find *IndexFind) (string, *IndexState) {
 	switch d.dbType {
-	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB:
+	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_MSSQL:
 		find.IndexName = strings.ToLower(find.IndexName)
 	}
 	// There are two cases to find a index:
@@ -246,7 +257,7 @@ func (d *DatabaseState) FindIndex(find *IndexFind) (string, *IndexState) {
 			// no need to further match table name because index is already unique in the schema
 			index, exists := table.indexSet[find.IndexName]
 			if !exists {
-				return "", nil
+				continue
 			}
 			return table.name, index
 		}
@@ -316,7 +327,7 @@ type ColumnFind struct {
 // FindColumn finds the column.
 func (d *DatabaseState) FindColumn(find *ColumnFind) *ColumnState {
 	switch d.dbType {
-	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB:
+	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_MSSQL:
 		find.ColumnName = strings.ToLower(find.ColumnName)
 	}
 	schema, exists := d.schemaSet[find.SchemaName]
@@ -334,6 +345,20 @@ func (d *DatabaseState) FindColumn(find *ColumnFind) *ColumnState {
 	return column
 }
 
+// FindColumnDependentView finds the existing views referencing the column, in "schema"."view" format.
+func (d *DatabaseState) FindColumnDependentView(find *ColumnFind) ([]string, error) {
+	column := d.FindColumn(find)
+	if column == nil {
+		return nil, nil
+	}
+	viewList, err := d.existedViewList(column.dependentView)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(viewList)
+	return viewList, nil
+}
+
 // TableFind is for find table.
 type TableFind struct {
 	SchemaName string
@@ -389,6 +414,8 @@ type TableState struct {
 	columnSet columnStateMap
 	// indexSet isn't supported for ClickHouse, Snowflake.
 	indexSet IndexStateMap
+	// rowCount is the row count in the database metadata, it's zero for the tables created by walk-through.
+	rowCount int64
 
 	// dependentView is used to record the dependent view for the table.
 	// Used to check if the table is used by any view.
@@ -400,6 +427,11 @@ func (table *TableState) CountIndex() int {
 	return len(table.indexSet)
 }
 
+// RowCount returns the row count in the database metadata, it's zero for the tables created by walk-through.
+func (table *TableState) RowCount() int64 {
+	return table.rowCount
+}
+
 // Index return the index map of table.
 func (table *TableState) Index(_ *TableIndexFind) *IndexStateMap {
 	return &table.indexSet
@@ -413,6 +445,7 @@ func (table *TableState) copy() *TableState {
 		comment:   copyStringPointer(table.comment),
 		columnSet: table.columnSet.copy(),
 		indexSet:  table.indexSet.copy(),
+		rowCount:  table.rowCount,
 	}
 }
 
@@ -0,0 +1,657 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+
+	tidbparser "github.com/pingcap/tidb/pkg/parser"
+	tidbast "github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	pgrawparser "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// StatementTypeCreateTable is the statement type for CREATE TABLE.
+	StatementTypeCreateTable = "CREATE_TABLE"
+	// StatementTypeAlterTable is the statement type for ALTER TABLE.
+	StatementTypeAlterTable = "ALTER_TABLE"
+	// StatementTypeDropTable is the statement type for DROP TABLE.
+	StatementTypeDropTable = "DROP_TABLE"
+	// StatementTypeRenameTable is the statement type for RENAME TABLE.
+	StatementTypeRenameTable = "RENAME_TABLE"
+	// StatementTypeCreateIndex is the statement type for CREATE INDEX.
+	StatementTypeCreateIndex = "CREATE_INDEX"
+	// StatementTypeDropIndex is the statement type for DROP INDEX.
+	StatementTypeDropIndex = "DROP_INDEX"
+	// StatementTypeInsert is the statement type for INSERT.
+	StatementTypeInsert = "INSERT"
+	// StatementTypeUpdate is the statement type for UPDATE.
+	StatementTypeUpdate = "UPDATE"
+	// StatementTypeDelete is the statement type for DELETE.
+	StatementTypeDelete = "DELETE"
+	// StatementTypeSelect is the statement type for SELECT.
+	StatementTypeSelect = "SELECT"
+	// StatementTypeOther is the statement type for the other statements.
+	StatementTypeOther = "OTHER"
+)
+
+// StatementModel is the engine-agnostic model of a statement, used by the custom SQL review rules.
+type StatementModel struct {
+	Type string
+	Text string
+	Line int
+	// Tables are the tables the statement targets.
+	Tables       []string
+	AddedColumns []*ColumnModel
+	Indexes      []*IndexModel
+	HasWhere     bool
+	// AffectedRows is the number of the affected rows, or -1 if it cannot be known without running the statement.
+	AffectedRows int64
+	// TargetTables are the tables the statement targets in the walk-through catalog, in the same order as Tables.
+	// The tables not found in the catalog are omitted.
+	TargetTables []*TableModel
+
+	// tableFinds are the finds of the tables in the catalog, in the same order as Tables.
+	tableFinds []*TableFind
+}
+
+// TableModel is the model of a table in the walk-through catalog.
+type TableModel struct {
+	Name string
+	// Exists is true if the table exists before the statements.
+	Exists bool
+	// RowCount is the row count in the database metadata, it's zero for the new tables.
+	RowCount int64
+	// Columns and Indexes are the ones after the statements, or before the statements if the statements drop the table.
+	Columns []*ColumnModel
+	Indexes []*IndexModel
+}
+
+// ColumnModel is the model of a column added by a statement.
+type ColumnModel struct {
+	Table      string
+	Name       string
+	Type       string
+	Nullable   bool
+	HasDefault bool
+	Default    string
+}
+
+// IndexModel is the model of an index created by a statement.
+type IndexModel struct {
+	Table   string
+	Name    string
+	Columns []string
+	Unique  bool
+	Primary bool
+}
+
+// CELValue returns the statement model as the value of the `statement` variable in CEL.
+func (m *StatementModel) CELValue() map[string]any {
+	tables := []any{}
+	for _, table := range m.Tables {
+		tables = append(tables, table)
+	}
+	targetTables := []any{}
+	for _, table := range m.TargetTables {
+		targetTables = append(targetTables, map[string]any{
+			"name":      table.Name,
+			"exists":    table.Exists,
+			"row_count": table.RowCount,
+			"columns":   columnsCELValue(table.Columns),
+			"indexes":   indexesCELValue(table.Indexes),
+		})
+	}
+	return map[string]any{
+		"type":          m.Type,
+		"text":          m.Text,
+		"line":          int64(m.Line),
+		"tables":        tables,
+		"target_tables": targetTables,
+		"added_columns": columnsCELValue(m.AddedColumns),
+		"indexes":       indexesCELValue(m.Indexes),
+		"has_where":     m.HasWhere,
+		"affected_rows": m.AffectedRows,
+	}
+}
+
+func columnsCELValue(columnList []*ColumnModel) []any {
+	columns := []any{}
+	for _, column := range columnList {
+		columns = append(columns, map[string]any{
+			"table":       column.Table,
+			"name":        column.Name,
+			"type":        column.Type,
+			"nullable":    column.Nullable,
+			"has_default": column.HasDefault,
+			"default":     column.Default,
+		})
+	}
+	return columns
+}
+
+func indexesCELValue(indexList []*IndexModel) []any {
+	indexes := []any{}
+	for _, index := range indexList {
+		keys := []any{}
+		for _, key := range index.Columns {
+			keys = append(keys, key)
+		}
+		indexes = append(indexes, map[string]any{
+			"table":   index.Table,
+			"name":    index.Name,
+			"columns": keys,
+			"unique":  index.Unique,
+			"primary": index.Primary,
+		})
+	}
+	return indexes
+}
+
+// BuildStatementModels builds the statement models, and finds their target tables in the finder which has walked through the statements.
+// Statements that cannot be parsed into the model are kept with the OTHER type.
+func BuildStatementModels(dbType storepb.Engine, statement string, finder *Finder) ([]*StatementModel, error) {
+	var models []*StatementModel
+	switch dbType {
+	case storepb.Engine_TIDB, storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
+		list, err := buildTiDBStatementModels(statement)
+		if err != nil {
+			return nil, err
+		}
+		models = list
+	case storepb.Engine_POSTGRES:
+		nodeList, err := pgParse(statement)
+		if err != nil {
+			return nil, NewParseError(err.Error())
+		}
+		for _, node := range nodeList {
+			models = append(models, newPGStatementModel(node))
+		}
+	default:
+		return nil, &WalkThroughError{
+			Type:    ErrorTypeUnsupported,
+			Content: fmt.Sprintf("Statement model doesn't support engine type: %s", dbType),
+		}
+	}
+
+	if finder != nil && finder.Final.Usable() {
+		for _, model := range models {
+			model.findTargetTables(finder)
+		}
+	}
+	return models, nil
+}
+
+func (m *StatementModel) findTargetTables(finder *Finder) {
+	for i, find := range m.tableFinds {
+		origin := finder.Origin.FindTable(find)
+		table := finder.Final.FindTable(find)
+		if table == nil {
+			// The table is dropped by the statements.
+			table = origin
+		}
+		if table == nil {
+			continue
+		}
+		model := &TableModel{
+			Name:   m.Tables[i],
+			Exists: origin != nil,
+		}
+		if origin != nil {
+			model.RowCount = origin.rowCount
+		}
+		var columns []*ColumnState
+		for _, column := range table.columnSet {
+			columns = append(columns, column)
+		}
+		// The columns without the position, e.g. in the incomplete table, are at the end in the name order.
+		sort.Slice(columns, func(i, j int) bool {
+			if pi, pj := columns[i].position, columns[j].position; pi != nil || pj != nil {
+				if pi == nil || pj == nil {
+					return pi != nil
+				}
+				if *pi != *pj {
+					return *pi < *pj
+				}
+			}
+			return columns[i].name < columns[j].name
+		})
+		for _, column := range columns {
+			columnModel := &ColumnModel{
+				Table:      model.Name,
+				Name:       column.name,
+				Type:       column.Type(),
+				Nullable:   column.Nullable(),
+				HasDefault: column.HasDefault(),
+			}
+			if column.defaultValue != nil {
+				columnModel.Default = *column.defaultValue
+			}
+			model.Columns = append(model.Columns, columnModel)
+		}
+		var indexNames []string
+		for name := range table.indexSet {
+			indexNames = append(indexNames, name)
+		}
+		sort.Strings(indexNames)
+		for _, name := range indexNames {
+			index := table.indexSet[name]
+			model.Indexes = append(model.Indexes, &IndexModel{
+				Table:   model.Name,
+				Name:    index.name,
+				Columns: index.ExpressionList(),
+				Unique:  index.Unique(),
+				Primary: index.Primary(),
+			})
+		}
+		m.TargetTables = append(m.TargetTables, model)
+	}
+}
+
+func buildTiDBStatementModels(statement string) ([]*StatementModel, error) {
+	list, err := base.SplitMultiSQL(storepb.Engine_TIDB, statement)
+	if err != nil {
+		return nil, NewSetLineError(err.Error())
+	}
+
+	p := tidbparser.New()
+	p.EnableWindowFunc(true)
+	var models []*StatementModel
+	for _, item := range list {
+		if item.Empty {
+			continue
+		}
+		nodeList, _, err := p.Parse(item.Text, "", "")
+		if err != nil || len(nodeList) != 1 {
+			// The TiDB parser doesn't support all MySQL dialects, keep the statement with unknown details.
+			models = append(models, &StatementModel{
+				Type:         StatementTypeOther,
+				Text:         item.Text,
+				Line:         item.LastLine,
+				AffectedRows: -1,
+			})
+			continue
+		}
+		node := nodeList[0]
+		node.SetText(nil, item.Text)
+		node.SetOriginTextPosition(item.LastLine)
+		models = append(models, newTiDBStatementModel(node))
+	}
+	return models, nil
+}
+
+func newTiDBStatementModel(in tidbast.StmtNode) *StatementModel {
+	model := &StatementModel{
+		Type:         StatementTypeOther,
+		Text:         in.Text(),
+		Line:         in.OriginTextPosition(),
+		AffectedRows: -1,
+	}
+	switch node := in.(type) {
+	case *tidbast.CreateTableStmt:
+		model.Type = StatementTypeCreateTable
+		table := model.addTiDBTable(node.Table)
+		for _, column := range node.Cols {
+			model.addTiDBColumn(table, column)
+		}
+		for _, constraint := range node.Constraints {
+			model.addTiDBConstraint(table, constraint)
+		}
+	case *tidbast.AlterTableStmt:
+		model.Type = StatementTypeAlterTable
+		table := model.addTiDBTable(node.Table)
+		for _, spec := range node.Specs {
+			switch spec.Tp {
+			case tidbast.AlterTableAddColumns:
+				for _, column := range spec.NewColumns {
+					model.addTiDBColumn(table, column)
+				}
+			case tidbast.AlterTableAddConstraint:
+				model.addTiDBConstraint(table, spec.Constraint)
+			}
+		}
+	case *tidbast.DropTableStmt:
+		model.Type = StatementTypeDropTable
+		for _, table := range node.Tables {
+			model.addTiDBTable(table)
+		}
+	case *tidbast.RenameTableStmt:
+		model.Type = StatementTypeRenameTable
+		for _, tableToTable := range node.TableToTables {
+			model.addTiDBTable(tableToTable.OldTable)
+		}
+	case *tidbast.CreateIndexStmt:
+		model.Type = StatementTypeCreateIndex
+		table := model.addTiDBTable(node.Table)
+		model.Indexes = append(model.Indexes, &IndexModel{
+			Table:   table,
+			Name:    node.IndexName,
+			Columns: tidbIndexColumns(node.IndexPartSpecifications),
+			Unique:  node.KeyType == tidbast.IndexKeyTypeUnique,
+		})
+	case *tidbast.DropIndexStmt:
+		model.Type = StatementTypeDropIndex
+		model.addTiDBTable(node.Table)
+	case *tidbast.InsertStmt:
+		model.Type = StatementTypeInsert
+		model.addTiDBTableRefs(node.Table)
+		if node.Select == nil {
+			model.AffectedRows = int64(len(node.Lists))
+		}
+	case *tidbast.UpdateStmt:
+		model.Type = StatementTypeUpdate
+		model.addTiDBTableRefs(node.TableRefs)
+		model.HasWhere = node.Where != nil
+	case *tidbast.DeleteStmt:
+		model.Type = StatementTypeDelete
+		if node.IsMultiTable && node.Tables != nil {
+			for _, table := range node.Tables.Tables {
+				model.addTiDBTable(table)
+			}
+		} else {
+			model.addTiDBTableRefs(node.TableRefs)
+		}
+		model.HasWhere = node.Where != nil
+	case *tidbast.SelectStmt:
+		model.Type = StatementTypeSelect
+		model.addTiDBTableRefs(node.From)
+		model.HasWhere = node.Where != nil
+	}
+	return model
+}
+
+func (m *StatementModel) addTiDBColumn(table string, column *tidbast.ColumnDef) {
+	columnModel := &ColumnModel{
+		Table:    table,
+		Name:     column.Name.Name.O,
+		Nullable: true,
+	}
+	if column.Tp != nil {
+		columnModel.Type = column.Tp.CompactStr()
+	}
+	for _, option := range column.Options {
+		switch option.Tp {
+		case tidbast.ColumnOptionPrimaryKey:
+			columnModel.Nullable = false
+			m.Indexes = append(m.Indexes, &IndexModel{
+				Table:   table,
+				Name:    PrimaryKeyName,
+				Columns: []string{columnModel.Name},
+				Unique:  true,
+				Primary: true,
+			})
+		case tidbast.ColumnOptionUniqKey:
+			m.Indexes = append(m.Indexes, &IndexModel{
+				Table:   table,
+				Columns: []string{columnModel.Name},
+				Unique:  true,
+			})
+		case tidbast.ColumnOptionNotNull:
+			columnModel.Nullable = false
+		case tidbast.ColumnOptionNull:
+			columnModel.Nullable = true
+		case tidbast.ColumnOptionDefaultValue:
+			if option.Expr.GetType().GetType() == mysql.TypeNull {
+				continue
+			}
+			defaultValue, err := restoreNode(option.Expr, format.RestoreStringWithoutCharset)
+			if err != nil {
+				continue
+			}
+			columnModel.HasDefault = true
+			columnModel.Default = defaultValue
+		}
+	}
+	m.AddedColumns = append(m.AddedColumns, columnModel)
+}
+
+func (m *StatementModel) addTiDBConstraint(table string, constraint *tidbast.Constraint) {
+	if constraint == nil {
+		return
+	}
+	index := &IndexModel{
+		Table:   table,
+		Name:    constraint.Name,
+		Columns: tidbIndexColumns(constraint.Keys),
+	}
+	switch constraint.Tp {
+	case tidbast.ConstraintPrimaryKey:
+		index.Name = PrimaryKeyName
+		index.Unique = true
+		index.Primary = true
+	case tidbast.ConstraintUniq, tidbast.ConstraintUniqKey, tidbast.ConstraintUniqIndex:
+		index.Unique = true
+	case tidbast.ConstraintKey, tidbast.ConstraintIndex, tidbast.ConstraintFulltext:
+	default:
+		return
+	}
+	m.Indexes = append(m.Indexes, index)
+}
+
+func tidbIndexColumns(keyList []*tidbast.IndexPartSpecification) []string {
+	var columns []string
+	for _, key := range keyList {
+		if key.Column == nil {
+			// Expression index.
+			continue
+		}
+		columns = append(columns, key.Column.Name.O)
+	}
+	return columns
+}
+
+func tidbTableName(table *tidbast.TableName) string {
+	if table == nil {
+		return ""
+	}
+	if table.Schema.O != "" {
+		return fmt.Sprintf("%s.%s", table.Schema.O, table.Name.O)
+	}
+	return table.Name.O
+}
+
+// addTiDBTable adds the table to the targets of the statement, and returns the table name.
+func (m *StatementModel) addTiDBTable(table *tidbast.TableName) string {
+	name := tidbTableName(table)
+	if table == nil {
+		return name
+	}
+	m.Tables = append(m.Tables, name)
+	// The walk-through puts the MySQL tables in the schema with the empty name.
+	m.tableFinds = append(m.tableFinds, &TableFind{TableName: table.Name.O})
+	return name
+}
+
+func (m *StatementModel) addTiDBTableRefs(refs *tidbast.TableRefsClause) {
+	if refs == nil || refs.TableRefs == nil {
+		return
+	}
+	collector := &tidbTableNameCollector{model: m}
+	refs.TableRefs.Accept(collector)
+}
+
+type tidbTableNameCollector struct {
+	model *StatementModel
+}
+
+// Enter implements the ast.Visitor interface.
+func (c *tidbTableNameCollector) Enter(in tidbast.Node) (tidbast.Node, bool) {
+	if table, ok := in.(*tidbast.TableName); ok {
+		c.model.addTiDBTable(table)
+	}
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface.
+func (*tidbTableNameCollector) Leave(in tidbast.Node) (tidbast.Node, bool) {
+	return in, true
+}
+
+func newPGStatementModel(in ast.Node) *StatementModel {
+	model := &StatementModel{
+		Type:         StatementTypeOther,
+		Text:         in.Text(),
+		Line:         in.LastLine(),
+		AffectedRows: -1,
+	}
+	switch node := in.(type) {
+	case *ast.CreateTableStmt:
+		model.Type = StatementTypeCreateTable
+		table := model.addPGTable(node.Name)
+		for _, column := range node.ColumnList {
+			model.addPGColumn(table, column)
+		}
+		for _, constraint := range node.ConstraintList {
+			model.addPGConstraint(table, constraint)
+		}
+	case *ast.AlterTableStmt:
+		model.Type = StatementTypeAlterTable
+		table := model.addPGTable(node.Table)
+		for _, item := range node.AlterItemList {
+			switch itemNode := item.(type) {
+			case *ast.AddColumnListStmt:
+				for _, column := range itemNode.ColumnList {
+					model.addPGColumn(table, column)
+				}
+			case *ast.AddConstraintStmt:
+				model.addPGConstraint(table, itemNode.Constraint)
+			}
+		}
+	case *ast.DropTableStmt:
+		model.Type = StatementTypeDropTable
+		for _, table := range node.TableList {
+			model.addPGTable(table)
+		}
+	case *ast.RenameTableStmt:
+		model.Type = StatementTypeRenameTable
+		model.addPGTable(node.Table)
+	case *ast.CreateIndexStmt:
+		model.Type = StatementTypeCreateIndex
+		table := model.addPGTable(node.Index.Table)
+		model.Indexes = append(model.Indexes, &IndexModel{
+			Table:   table,
+			Name:    node.Index.Name,
+			Columns: node.Index.GetKeyNameList(),
+			Unique:  node.Index.Unique,
+		})
+	case *ast.DropIndexStmt:
+		model.Type = StatementTypeDropIndex
+		for _, index := range node.IndexList {
+			if index.Table != nil {
+				model.addPGTable(index.Table)
+			}
+		}
+	case *ast.InsertStmt:
+		model.Type = StatementTypeInsert
+		model.addPGTable(node.Table)
+		if node.Select == nil {
+			model.AffectedRows = int64(len(node.ValueList))
+		}
+	case *ast.UpdateStmt:
+		model.Type = StatementTypeUpdate
+		model.addPGTable(node.Table)
+		model.HasWhere = node.WhereClause != nil
+	case *ast.DeleteStmt:
+		model.Type = StatementTypeDelete
+		model.addPGTable(node.Table)
+		model.HasWhere = node.WhereClause != nil
+	case *ast.SelectStmt:
+		model.Type = StatementTypeSelect
+		model.HasWhere = node.WhereClause != nil
+	}
+	return model
+}
+
+func (m *StatementModel) addPGColumn(table string, column *ast.ColumnDef) {
+	columnModel := &ColumnModel{
+		Table:    table,
+		Name:     column.ColumnName,
+		Nullable: true,
+	}
+	if typeString, err := pgrawparser.Deparse(pgrawparser.DeparseContext{}, column.Type); err == nil {
+		columnModel.Type = typeString
+	}
+	for _, constraint := range column.ConstraintList {
+		switch constraint.Type {
+		case ast.ConstraintTypeNotNull:
+			columnModel.Nullable = false
+		case ast.ConstraintTypeNull:
+			columnModel.Nullable = true
+		case ast.ConstraintTypeDefault:
+			columnModel.HasDefault = true
+			if constraint.Expression != nil {
+				columnModel.Default = constraint.Expression.Text()
+			}
+		case ast.ConstraintTypePrimary:
+			columnModel.Nullable = false
+			m.Indexes = append(m.Indexes, &IndexModel{
+				Table:   table,
+				Name:    constraint.Name,
+				Columns: []string{column.ColumnName},
+				Unique:  true,
+				Primary: true,
+			})
+		case ast.ConstraintTypeUnique:
+			m.Indexes = append(m.Indexes, &IndexModel{
+				Table:   table,
+				Name:    constraint.Name,
+				Columns: []string{column.ColumnName},
+				Unique:  true,
+			})
+		}
+	}
+	m.AddedColumns = append(m.AddedColumns, columnModel)
+}
+
+func (m *StatementModel) addPGConstraint(table string, constraint *ast.ConstraintDef) {
+	if constraint == nil {
+		return
+	}
+	switch constraint.Type {
+	case ast.ConstraintTypePrimary, ast.ConstraintTypePrimaryUsingIndex:
+		m.Indexes = append(m.Indexes, &IndexModel{
+			Table:   table,
+			Name:    constraint.Name,
+			Columns: constraint.KeyList,
+			Unique:  true,
+			Primary: true,
+		})
+	case ast.ConstraintTypeUnique, ast.ConstraintTypeUniqueUsingIndex:
+		m.Indexes = append(m.Indexes, &IndexModel{
+			Table:   table,
+			Name:    constraint.Name,
+			Columns: constraint.KeyList,
+			Unique:  true,
+		})
+	}
+}
+
+// addPGTable adds the table to the targets of the statement, and returns the table name.
+func (m *StatementModel) addPGTable(table *ast.TableDef) string {
+	name := pgTableName(table)
+	if table == nil {
+		return name
+	}
+	m.Tables = append(m.Tables, name)
+	schema := table.Schema
+	if schema == "" {
+		schema = publicSchemaName
+	}
+	m.tableFinds = append(m.tableFinds, &TableFind{SchemaName: schema, TableName: table.Name})
+	return name
+}
+
+func pgTableName(table *ast.TableDef) string {
+	if table == nil {
+		return ""
+	}
+	if table.Schema != "" {
+		return fmt.Sprintf("%s.%s", table.Schema, table.Name)
+	}
+	return table.Name
+}
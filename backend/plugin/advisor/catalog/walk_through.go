@@ -212,6 +212,17 @@ func (e *WalkThroughError) Error() string {
 	return e.Content
 }
 
+// IsWalkThroughSupported returns true if the engine supports walk-through.
+func IsWalkThroughSupported(dbType storepb.Engine) bool {
+	switch dbType {
+	case storepb.Engine_TIDB, storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE, storepb.Engine_POSTGRES,
+		storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_MSSQL:
+		return true
+	default:
+		return false
+	}
+}
+
 // WalkThrough will collect the catalog schema in the databaseState as it walks through the stmt.
 func (d *DatabaseState) WalkThrough(stmt string) error {
 	switch d.dbType {
@@ -232,6 +243,10 @@ func (d *DatabaseState) WalkThrough(stmt string) error {
 			d.usable = false
 		}
 		return nil
+	case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		return d.oracleWalkThrough(stmt)
+	case storepb.Engine_MSSQL:
+		return d.mssqlWalkThrough(stmt)
 	default:
 		return &WalkThroughError{
 			Type:    ErrorTypeUnsupported,
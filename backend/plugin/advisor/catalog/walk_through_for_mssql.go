@@ -0,0 +1,431 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	tsql "github.com/bytebase/tsql-parser"
+
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+)
+
+const (
+	mssqlDefaultSchemaName = "dbo"
+)
+
+// SQL Server identifiers are case-insensitive under the default collation, and the T-SQL parser
+// normalizes them to lower case. So we use the lower-case names as the keys of schema, table,
+// column and index sets.
+
+func (d *DatabaseState) mssqlWalkThrough(stmt string) error {
+	result, err := tsqlparser.ParseTSQL(stmt)
+	if err != nil {
+		return NewParseError(err.Error())
+	}
+
+	listener := &mssqlListener{
+		databaseState: d,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, result.Tree)
+	if listener.err != nil {
+		if listener.err.Line == 0 {
+			listener.err.Line = listener.lineNumber
+		}
+		return listener.err
+	}
+	return nil
+}
+
+type mssqlListener struct {
+	*tsql.BaseTSqlParserListener
+
+	lineNumber    int
+	databaseState *DatabaseState
+	err           *WalkThroughError
+}
+
+// EnterSql_clauses is called when production sql_clauses is entered.
+func (l *mssqlListener) EnterSql_clauses(ctx *tsql.Sql_clausesContext) {
+	l.lineNumber = ctx.GetStart().GetLine()
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *mssqlListener) EnterCreate_table(ctx *tsql.Create_tableContext) {
+	if l.err != nil {
+		return
+	}
+
+	schema, tableName := l.databaseState.mssqlTableName(ctx.Table_name())
+	if schema == nil {
+		return
+	}
+	if _, exists := schema.tableSet[tableName]; exists {
+		l.err = NewTableExistsError(fmt.Sprintf("%s.%s", schema.name, tableName))
+		return
+	}
+
+	table := &TableState{
+		name:          tableName,
+		engine:        newEmptyStringPointer(),
+		collation:     newEmptyStringPointer(),
+		comment:       newEmptyStringPointer(),
+		columnSet:     make(columnStateMap),
+		indexSet:      make(IndexStateMap),
+		dependentView: make(map[string]bool),
+	}
+	schema.tableSet[table.name] = table
+
+	if err := table.mssqlCreateColumnsAndConstraints(ctx.Column_def_table_constraints()); err != nil {
+		l.err = err
+		return
+	}
+	for _, index := range ctx.AllTable_indices() {
+		if index.Column_name_list_with_order() == nil {
+			// Columnstore indexes are not tracked.
+			continue
+		}
+		indexName := tsqlparser.NormalizeTSQLIdentifier(index.Id_(0))
+		if err := table.mssqlCreateIndex(indexName, mssqlKeyList(index.Column_name_list_with_order()), index.UNIQUE() != nil /* unique */, false /* primary */, false /* isConstraint */); err != nil {
+			err.Line = index.GetStart().GetLine()
+			l.err = err
+			return
+		}
+	}
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *mssqlListener) EnterDrop_table(ctx *tsql.Drop_tableContext) {
+	if l.err != nil {
+		return
+	}
+
+	for _, tableNameCtx := range ctx.AllTable_name() {
+		schema, tableName := l.databaseState.mssqlTableName(tableNameCtx)
+		if schema == nil {
+			continue
+		}
+		if _, exists := schema.tableSet[tableName]; !exists {
+			if ctx.EXISTS() == nil && l.databaseState.ctx.CheckIntegrity {
+				l.err = NewTableNotExistsError(fmt.Sprintf("%s.%s", schema.name, tableName))
+				return
+			}
+			continue
+		}
+		delete(schema.tableSet, tableName)
+	}
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *mssqlListener) EnterAlter_table(ctx *tsql.Alter_tableContext) {
+	if l.err != nil {
+		return
+	}
+
+	schema, tableName := l.databaseState.mssqlTableName(ctx.Table_name(0))
+	if schema == nil {
+		return
+	}
+	table, err := l.databaseState.mssqlFindTableState(schema, tableName)
+	if err != nil {
+		l.err = err
+		return
+	}
+
+	switch {
+	case ctx.ADD() != nil && ctx.Column_def_table_constraints() != nil:
+		l.err = table.mssqlCreateColumnsAndConstraints(ctx.Column_def_table_constraints())
+	case ctx.DROP() != nil && ctx.COLUMN() != nil:
+		for _, column := range ctx.AllId_() {
+			if l.err = table.dropColumn(l.databaseState.ctx, tsqlparser.NormalizeTSQLIdentifier(column)); l.err != nil {
+				return
+			}
+		}
+	case ctx.DROP() != nil && ctx.CONSTRAINT() != nil && ctx.GetConstraint() != nil:
+		// The constraint may be a foreign key, check or default constraint, which is not tracked.
+		delete(table.indexSet, tsqlparser.NormalizeTSQLIdentifier(ctx.GetConstraint()))
+	case len(ctx.AllALTER()) == 2 && ctx.COLUMN() != nil && ctx.Column_definition() != nil:
+		definition := ctx.Column_definition()
+		columnName := tsqlparser.NormalizeTSQLIdentifier(definition.Id_())
+		column, exists := table.columnSet[columnName]
+		if !exists {
+			if l.databaseState.ctx.CheckIntegrity {
+				l.err = NewColumnNotExistsError(table.name, columnName)
+				return
+			}
+			column = table.createIncompleteColumn(columnName)
+		}
+		if definition.Data_type() != nil {
+			column.columnType = newStringPointer(definition.GetParser().GetTokenStream().GetTextFromRuleContext(definition.Data_type()))
+		}
+		// ALTER COLUMN resets the nullability, the column is nullable if NOT NULL is not specified.
+		column.nullable = newTruePointer()
+		for _, element := range definition.AllColumn_definition_element() {
+			if constraint := element.Column_constraint(); constraint != nil && constraint.Null_notnull() != nil {
+				column.nullable = newBoolPointer(constraint.Null_notnull().NOT() == nil)
+			}
+		}
+	}
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *mssqlListener) EnterCreate_index(ctx *tsql.Create_indexContext) {
+	if l.err != nil {
+		return
+	}
+
+	schema, tableName := l.databaseState.mssqlTableName(ctx.Table_name())
+	if schema == nil {
+		return
+	}
+	table, err := l.databaseState.mssqlFindTableState(schema, tableName)
+	if err != nil {
+		l.err = err
+		return
+	}
+
+	keyList := mssqlKeyList(ctx.Column_name_list_with_order())
+	if l.databaseState.ctx.CheckIntegrity {
+		for _, key := range keyList {
+			if _, exists := table.columnSet[key]; !exists {
+				l.err = NewColumnNotExistsError(table.name, key)
+				return
+			}
+		}
+	}
+	indexName := tsqlparser.NormalizeTSQLIdentifier(ctx.Id_(0))
+	l.err = table.mssqlCreateIndex(indexName, keyList, ctx.UNIQUE() != nil /* unique */, false /* primary */, false /* isConstraint */)
+}
+
+// EnterDrop_index is called when production drop_index is entered.
+func (l *mssqlListener) EnterDrop_index(ctx *tsql.Drop_indexContext) {
+	if l.err != nil {
+		return
+	}
+
+	type indexReference struct {
+		schemaName string
+		tableName  string
+		indexName  string
+	}
+	var references []indexReference
+	for _, index := range ctx.AllDrop_relational_or_xml_or_spatial_index() {
+		fullTableName := index.Full_table_name()
+		reference := indexReference{
+			schemaName: tsqlparser.NormalizeTSQLIdentifier(fullTableName.GetSchema()),
+			tableName:  tsqlparser.NormalizeTSQLIdentifier(fullTableName.GetTable()),
+			indexName:  tsqlparser.NormalizeTSQLIdentifier(index.GetIndex_name()),
+		}
+		references = append(references, reference)
+	}
+	for _, index := range ctx.AllDrop_backward_compatible_index() {
+		reference := indexReference{
+			schemaName: tsqlparser.NormalizeTSQLIdentifier(index.GetOwner_name()),
+			tableName:  tsqlparser.NormalizeTSQLIdentifier(index.GetTable_or_view_name()),
+			indexName:  tsqlparser.NormalizeTSQLIdentifier(index.GetIndex_name()),
+		}
+		references = append(references, reference)
+	}
+
+	for _, reference := range references {
+		if reference.schemaName == "" {
+			reference.schemaName = mssqlDefaultSchemaName
+		}
+		schema := l.databaseState.mssqlSchema(reference.schemaName)
+		table, err := l.databaseState.mssqlFindTableState(schema, reference.tableName)
+		if err != nil {
+			l.err = err
+			return
+		}
+		if _, exists := table.indexSet[reference.indexName]; !exists {
+			if ctx.EXISTS() == nil && l.databaseState.ctx.CheckIntegrity {
+				l.err = NewIndexNotExistsError(table.name, reference.indexName)
+				return
+			}
+			continue
+		}
+		delete(table.indexSet, reference.indexName)
+	}
+}
+
+func (d *DatabaseState) mssqlSchema(name string) *SchemaState {
+	name = strings.ToLower(name)
+	schema, exists := d.schemaSet[name]
+	if !exists {
+		schema = d.createSchema(name)
+	}
+	return schema
+}
+
+// mssqlTableName returns the schema state and the normalized table name.
+// It returns nil schema if the table belongs to other databases, which is allowed in SQL Server but not tracked.
+func (d *DatabaseState) mssqlTableName(ctx tsql.ITable_nameContext) (*SchemaState, string) {
+	if database := tsqlparser.NormalizeTSQLIdentifier(ctx.GetDatabase()); database != "" && d.name != "" && !strings.EqualFold(database, d.name) {
+		return nil, ""
+	}
+	schemaName := tsqlparser.NormalizeTSQLIdentifier(ctx.GetSchema())
+	if schemaName == "" {
+		schemaName = mssqlDefaultSchemaName
+	}
+	return d.mssqlSchema(schemaName), tsqlparser.NormalizeTSQLIdentifier(ctx.GetTable())
+}
+
+func (d *DatabaseState) mssqlFindTableState(schema *SchemaState, tableName string) (*TableState, *WalkThroughError) {
+	table, exists := schema.tableSet[tableName]
+	if !exists {
+		if d.ctx.CheckIntegrity {
+			return nil, NewTableNotExistsError(fmt.Sprintf("%s.%s", schema.name, tableName))
+		}
+		table = schema.createIncompleteTable(tableName)
+	}
+	return table, nil
+}
+
+func mssqlKeyList(ctx tsql.IColumn_name_list_with_orderContext) []string {
+	if ctx == nil {
+		return nil
+	}
+	var keyList []string
+	for _, column := range ctx.AllId_() {
+		keyList = append(keyList, tsqlparser.NormalizeTSQLIdentifier(column))
+	}
+	return keyList
+}
+
+func (t *TableState) mssqlCreateColumnsAndConstraints(ctx tsql.IColumn_def_table_constraintsContext) *WalkThroughError {
+	if ctx == nil {
+		return nil
+	}
+	for _, item := range ctx.AllColumn_def_table_constraint() {
+		var err *WalkThroughError
+		switch {
+		case item.Column_definition() != nil:
+			err = t.mssqlCreateColumn(item.Column_definition())
+		case item.Table_constraint() != nil:
+			constraint := item.Table_constraint()
+			if constraint.PRIMARY() == nil && constraint.UNIQUE() == nil {
+				continue
+			}
+			keyList := mssqlKeyList(constraint.Column_name_list_with_order())
+			if constraint.PRIMARY() != nil {
+				for _, key := range keyList {
+					if column, exists := t.columnSet[key]; exists {
+						column.nullable = newFalsePointer()
+					}
+				}
+			}
+			constraintName := tsqlparser.NormalizeTSQLIdentifier(constraint.GetConstraint())
+			err = t.mssqlCreateIndex(constraintName, keyList, true /* unique */, constraint.PRIMARY() != nil, true /* isConstraint */)
+		}
+		if err != nil {
+			err.Line = item.GetStart().GetLine()
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TableState) mssqlCreateColumn(ctx tsql.IColumn_definitionContext) *WalkThroughError {
+	columnName := tsqlparser.NormalizeTSQLIdentifier(ctx.Id_())
+	if _, exists := t.columnSet[columnName]; exists {
+		return &WalkThroughError{
+			Type:    ErrorTypeColumnExists,
+			Content: fmt.Sprintf("Column `%s` already exists in table `%s`", columnName, t.name),
+		}
+	}
+
+	columnType := ""
+	if ctx.Data_type() != nil {
+		columnType = ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx.Data_type())
+	}
+	column := &ColumnState{
+		name:          columnName,
+		position:      newIntPointer(len(t.columnSet) + 1),
+		nullable:      newTruePointer(),
+		columnType:    newStringPointer(columnType),
+		characterSet:  newEmptyStringPointer(),
+		collation:     newEmptyStringPointer(),
+		comment:       newEmptyStringPointer(),
+		dependentView: make(map[string]bool),
+	}
+	t.columnSet[columnName] = column
+
+	for _, element := range ctx.AllColumn_definition_element() {
+		if element.DEFAULT() != nil && element.GetConstant_expr() != nil {
+			column.defaultValue = newStringPointer(element.GetParser().GetTokenStream().GetTextFromRuleContext(element.GetConstant_expr()))
+			continue
+		}
+		constraint := element.Column_constraint()
+		if constraint == nil {
+			continue
+		}
+		constraintName := tsqlparser.NormalizeTSQLIdentifier(constraint.GetConstraint())
+		switch {
+		case constraint.Null_notnull() != nil:
+			column.nullable = newBoolPointer(constraint.Null_notnull().NOT() == nil)
+		case constraint.PRIMARY() != nil:
+			column.nullable = newFalsePointer()
+			if err := t.mssqlCreateIndex(constraintName, []string{columnName}, true /* unique */, true /* primary */, true /* isConstraint */); err != nil {
+				return err
+			}
+		case constraint.UNIQUE() != nil:
+			if err := t.mssqlCreateIndex(constraintName, []string{columnName}, true /* unique */, false /* primary */, true /* isConstraint */); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *TableState) mssqlCreateIndex(name string, keyList []string, unique bool, primary bool, isConstraint bool) *WalkThroughError {
+	if len(keyList) == 0 {
+		return &WalkThroughError{
+			Type:    ErrorTypeIndexEmptyKeys,
+			Content: fmt.Sprintf("Index `%s` in table `%s` has empty key", name, t.name),
+		}
+	}
+	if primary {
+		for _, index := range t.indexSet {
+			if index.Primary() {
+				return &WalkThroughError{
+					Type:    ErrorTypePrimaryKeyExists,
+					Content: fmt.Sprintf("Primary key exists in table `%s`", t.name),
+				}
+			}
+		}
+	}
+
+	if name != "" {
+		if _, exists := t.indexSet[name]; exists {
+			return NewIndexExistsError(t.name, name)
+		}
+	} else {
+		// SQL Server generates the name such as PK__table__hash for unnamed constraints.
+		prefix := "uq"
+		if primary {
+			prefix = "pk"
+		}
+		for suffix := 1; ; suffix++ {
+			name = fmt.Sprintf("%s__%s__%d", prefix, t.name, suffix)
+			if _, exists := t.indexSet[name]; !exists {
+				break
+			}
+		}
+	}
+
+	indexType := "NONCLUSTERED"
+	if primary {
+		indexType = "CLUSTERED"
+	}
+	t.indexSet[name] = &IndexState{
+		name:           name,
+		expressionList: keyList,
+		indexType:      newStringPointer(indexType),
+		unique:         newBoolPointer(unique),
+		primary:        newBoolPointer(primary),
+		visible:        newTruePointer(),
+		comment:        newEmptyStringPointer(),
+		isConstraint:   isConstraint,
+	}
+	return nil
+}
@@ -0,0 +1,597 @@
+package catalog
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	plsql "github.com/bytebase/plsql-parser"
+
+	plsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/plsql"
+)
+
+// Oracle identifiers are normalized by the PL/SQL parser, regular identifiers are upper-cased
+// and delimited identifiers keep their original case. So we use the normalized names as the keys
+// of table, column and index sets directly.
+
+func (d *DatabaseState) oracleWalkThrough(stmt string) error {
+	tree, _, err := plsqlparser.ParsePLSQL(stmt)
+	if err != nil {
+		return NewParseError(err.Error())
+	}
+
+	listener := &oracleListener{
+		databaseState: d,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	if listener.err != nil {
+		if listener.err.Line == 0 {
+			listener.err.Line = listener.lineNumber
+		}
+		return listener.err
+	}
+	return nil
+}
+
+type oracleListener struct {
+	*plsql.BasePlSqlParserListener
+
+	lineNumber    int
+	databaseState *DatabaseState
+	err           *WalkThroughError
+}
+
+// EnterUnit_statement is called when production unit_statement is entered.
+func (l *oracleListener) EnterUnit_statement(ctx *plsql.Unit_statementContext) {
+	l.lineNumber = ctx.GetStart().GetLine()
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *oracleListener) EnterCreate_table(ctx *plsql.Create_tableContext) {
+	if l.err != nil {
+		return
+	}
+
+	schemaName := l.databaseState.oracleDefaultSchemaName()
+	if ctx.Schema_name() != nil {
+		schemaName = plsqlparser.NormalizeIdentifierContext(ctx.Schema_name().Identifier())
+	}
+	tableName := plsqlparser.NormalizeIdentifierContext(ctx.Table_name().Identifier())
+	schema := l.databaseState.oracleSchema(schemaName)
+	if _, exists := schema.getTable(tableName); exists {
+		l.err = NewTableExistsError(fmt.Sprintf("%s.%s", schemaName, tableName))
+		return
+	}
+
+	table := &TableState{
+		name:          tableName,
+		engine:        newEmptyStringPointer(),
+		collation:     newEmptyStringPointer(),
+		comment:       newEmptyStringPointer(),
+		columnSet:     make(columnStateMap),
+		indexSet:      make(IndexStateMap),
+		dependentView: make(map[string]bool),
+	}
+	schema.tableSet[table.name] = table
+
+	// Object tables and XMLType tables have no relational properties.
+	if ctx.Relational_table() == nil {
+		return
+	}
+	for _, property := range ctx.Relational_table().AllRelational_property() {
+		switch {
+		case property.Column_definition() != nil:
+			if err := table.oracleCreateColumn(schema, property.Column_definition()); err != nil {
+				err.Line = property.GetStart().GetLine()
+				l.err = err
+				return
+			}
+		case property.Out_of_line_constraint() != nil:
+			if err := table.oracleCreateConstraint(schema, property.Out_of_line_constraint()); err != nil {
+				err.Line = property.GetStart().GetLine()
+				l.err = err
+				return
+			}
+		}
+	}
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *oracleListener) EnterDrop_table(ctx *plsql.Drop_tableContext) {
+	if l.err != nil {
+		return
+	}
+
+	schemaName, tableName := l.databaseState.oracleTableviewName(ctx.Tableview_name())
+	schema := l.databaseState.oracleSchema(schemaName)
+	table, exists := schema.getTable(tableName)
+	if !exists {
+		if l.databaseState.ctx.CheckIntegrity {
+			l.err = NewTableNotExistsError(fmt.Sprintf("%s.%s", schemaName, tableName))
+		}
+		return
+	}
+	delete(schema.tableSet, table.name)
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *oracleListener) EnterAlter_table(ctx *plsql.Alter_tableContext) {
+	if l.err != nil {
+		return
+	}
+
+	schemaName, tableName := l.databaseState.oracleTableviewName(ctx.Tableview_name())
+	schema := l.databaseState.oracleSchema(schemaName)
+	table, err := l.databaseState.oracleFindTableState(schema, tableName)
+	if err != nil {
+		l.err = err
+		return
+	}
+
+	if properties := ctx.Alter_table_properties(); properties != nil && properties.RENAME() != nil {
+		_, newTableName := l.databaseState.oracleTableviewName(properties.Tableview_name())
+		if _, exists := schema.getTable(newTableName); exists {
+			l.err = NewTableExistsError(fmt.Sprintf("%s.%s", schemaName, newTableName))
+			return
+		}
+		delete(schema.tableSet, table.name)
+		table.name = newTableName
+		schema.tableSet[table.name] = table
+		return
+	}
+
+	if clauses := ctx.Constraint_clauses(); clauses != nil {
+		l.err = table.oracleAlterConstraint(l.databaseState.ctx, schema, clauses)
+		return
+	}
+
+	columnClauses := ctx.Column_clauses()
+	if columnClauses == nil {
+		return
+	}
+	if rename := columnClauses.Rename_column_clause(); rename != nil {
+		oldName := oracleColumnName(rename.Old_column_name().Column_name())
+		newName := oracleColumnName(rename.New_column_name().Column_name())
+		l.err = table.oracleRenameColumn(l.databaseState.ctx, oldName, newName)
+		return
+	}
+	clauses := columnClauses.Add_modify_drop_column_clauses()
+	if clauses == nil {
+		return
+	}
+	// Keep the order of the clauses, e.g. ADD (a ...) DROP COLUMN a.
+	for _, child := range clauses.GetChildren() {
+		switch clause := child.(type) {
+		case *plsql.Constraint_clausesContext:
+			l.err = table.oracleAlterConstraint(l.databaseState.ctx, schema, clause)
+		case *plsql.Add_column_clauseContext:
+			for _, column := range clause.AllColumn_definition() {
+				if l.err = table.oracleCreateColumn(schema, column); l.err != nil {
+					break
+				}
+			}
+		case *plsql.Modify_column_clausesContext:
+			for _, column := range clause.AllModify_col_properties() {
+				if l.err = table.oracleModifyColumn(l.databaseState.ctx, schema, column); l.err != nil {
+					break
+				}
+			}
+		case *plsql.Drop_column_clauseContext:
+			for _, column := range clause.AllColumn_name() {
+				if l.err = table.oracleDropColumn(l.databaseState.ctx, oracleColumnName(column)); l.err != nil {
+					break
+				}
+			}
+		}
+		if l.err != nil {
+			if clause, ok := child.(antlr.ParserRuleContext); ok {
+				l.err.Line = clause.GetStart().GetLine()
+			}
+			return
+		}
+	}
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *oracleListener) EnterCreate_index(ctx *plsql.Create_indexContext) {
+	if l.err != nil {
+		return
+	}
+	// We only support the index on the table columns.
+	clause := ctx.Table_index_clause()
+	if clause == nil {
+		return
+	}
+
+	schemaName, tableName := l.databaseState.oracleTableviewName(clause.Tableview_name())
+	schema := l.databaseState.oracleSchema(schemaName)
+	table, err := l.databaseState.oracleFindTableState(schema, tableName)
+	if err != nil {
+		l.err = err
+		return
+	}
+
+	var keyList []string
+	for _, expression := range clause.AllIndex_expr() {
+		if expression.Column_name() == nil {
+			keyList = append(keyList, expression.GetParser().GetTokenStream().GetTextFromRuleContext(expression))
+			continue
+		}
+		columnName := oracleColumnName(expression.Column_name())
+		if _, exists := table.columnSet[columnName]; !exists && l.databaseState.ctx.CheckIntegrity {
+			l.err = NewColumnNotExistsError(table.name, columnName)
+			return
+		}
+		keyList = append(keyList, columnName)
+	}
+
+	_, indexName := plsqlparser.NormalizeIndexName(ctx.Index_name())
+	l.err = table.oracleCreateIndex(schema, indexName, keyList, ctx.UNIQUE() != nil /* unique */, false /* primary */, false /* isConstraint */)
+}
+
+// EnterDrop_index is called when production drop_index is entered.
+func (l *oracleListener) EnterDrop_index(ctx *plsql.Drop_indexContext) {
+	if l.err != nil {
+		return
+	}
+
+	schemaName, indexName := plsqlparser.NormalizeIndexName(ctx.Index_name())
+	if schemaName == "" {
+		schemaName = l.databaseState.oracleDefaultSchemaName()
+	}
+	schema := l.databaseState.oracleSchema(schemaName)
+	table, index := schema.oracleFindIndex(indexName)
+	if index == nil {
+		if l.databaseState.ctx.CheckIntegrity {
+			l.err = &WalkThroughError{
+				Type:    ErrorTypeIndexNotExists,
+				Content: fmt.Sprintf("Index `%s` does not exist in schema `%s`", indexName, schemaName),
+			}
+		}
+		return
+	}
+	delete(table.indexSet, index.name)
+}
+
+// oracleDefaultSchemaName returns the schema used for the unqualified object names.
+// In Oracle, the schema is the user who owns the objects. We use the current schema if it's set,
+// otherwise the schema with the same name as the database, or the only schema for the database.
+func (d *DatabaseState) oracleDefaultSchemaName() string {
+	if d.ctx.CurrentSchema != "" {
+		return d.ctx.CurrentSchema
+	}
+	if _, exists := d.schemaSet[d.name]; exists {
+		return d.name
+	}
+	if len(d.schemaSet) == 1 {
+		for name := range d.schemaSet {
+			return name
+		}
+	}
+	return d.name
+}
+
+func (d *DatabaseState) oracleSchema(name string) *SchemaState {
+	schema, exists := d.schemaSet[name]
+	if !exists {
+		schema = d.createSchema(name)
+	}
+	return schema
+}
+
+func (d *DatabaseState) oracleTableviewName(ctx plsql.ITableview_nameContext) (string, string) {
+	if ctx == nil || ctx.Identifier() == nil {
+		return d.oracleDefaultSchemaName(), ""
+	}
+	if ctx.Id_expression() == nil {
+		return d.oracleDefaultSchemaName(), plsqlparser.NormalizeIdentifierContext(ctx.Identifier())
+	}
+	return plsqlparser.NormalizeIdentifierContext(ctx.Identifier()), plsqlparser.NormalizeIDExpression(ctx.Id_expression())
+}
+
+func (d *DatabaseState) oracleFindTableState(schema *SchemaState, tableName string) (*TableState, *WalkThroughError) {
+	table, exists := schema.getTable(tableName)
+	if !exists {
+		if d.ctx.CheckIntegrity {
+			return nil, NewTableNotExistsError(fmt.Sprintf("%s.%s", schema.name, tableName))
+		}
+		table = schema.createIncompleteTable(tableName)
+	}
+	return table, nil
+}
+
+// oracleColumnName returns the last part of the column name, e.g. "T"."A" returns A.
+func oracleColumnName(ctx plsql.IColumn_nameContext) string {
+	if ctx == nil {
+		return ""
+	}
+	if list := ctx.AllId_expression(); len(list) > 0 {
+		return plsqlparser.NormalizeIDExpression(list[len(list)-1])
+	}
+	return plsqlparser.NormalizeIdentifierContext(ctx.Identifier())
+}
+
+func (s *SchemaState) oracleFindIndex(indexName string) (*TableState, *IndexState) {
+	for _, table := range s.tableSet {
+		if index, exists := table.indexSet[indexName]; exists {
+			return table, index
+		}
+	}
+	return nil, nil
+}
+
+func (t *TableState) oracleCreateColumn(schema *SchemaState, ctx plsql.IColumn_definitionContext) *WalkThroughError {
+	columnName := oracleColumnName(ctx.Column_name())
+	if _, exists := t.columnSet[columnName]; exists {
+		return &WalkThroughError{
+			Type:    ErrorTypeColumnExists,
+			Content: fmt.Sprintf("Column `%s` already exists in table `%s`", columnName, t.name),
+		}
+	}
+
+	columnType := ""
+	if ctx.Datatype() != nil {
+		columnType = ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx.Datatype())
+	}
+	column := &ColumnState{
+		name:          columnName,
+		position:      newIntPointer(len(t.columnSet) + 1),
+		nullable:      newTruePointer(),
+		columnType:    newStringPointer(columnType),
+		characterSet:  newEmptyStringPointer(),
+		collation:     newEmptyStringPointer(),
+		comment:       newEmptyStringPointer(),
+		dependentView: make(map[string]bool),
+	}
+	if ctx.DEFAULT() != nil && ctx.Expression() != nil {
+		column.defaultValue = newStringPointer(ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx.Expression()))
+	}
+	t.columnSet[columnName] = column
+
+	return t.oracleApplyInlineConstraints(schema, column, ctx.AllInline_constraint())
+}
+
+func (t *TableState) oracleApplyInlineConstraints(schema *SchemaState, column *ColumnState, constraints []plsql.IInline_constraintContext) *WalkThroughError {
+	for _, constraint := range constraints {
+		_, constraintName := plsqlparser.NormalizeConstraintName(constraint.Constraint_name())
+		switch {
+		case constraint.NULL_() != nil:
+			column.nullable = newBoolPointer(constraint.NOT() == nil)
+		case constraint.PRIMARY() != nil:
+			column.nullable = newFalsePointer()
+			if err := t.oracleCreateIndex(schema, constraintName, []string{column.name}, true /* unique */, true /* primary */, true /* isConstraint */); err != nil {
+				return err
+			}
+		case constraint.UNIQUE() != nil:
+			if err := t.oracleCreateIndex(schema, constraintName, []string{column.name}, true /* unique */, false /* primary */, true /* isConstraint */); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *TableState) oracleModifyColumn(ctx *FinderContext, schema *SchemaState, modify plsql.IModify_col_propertiesContext) *WalkThroughError {
+	columnName := oracleColumnName(modify.Column_name())
+	column, exists := t.columnSet[columnName]
+	if !exists {
+		if ctx.CheckIntegrity {
+			return NewColumnNotExistsError(t.name, columnName)
+		}
+		column = &ColumnState{name: columnName}
+		t.columnSet[columnName] = column
+	}
+
+	if modify.Datatype() != nil {
+		column.columnType = newStringPointer(modify.GetParser().GetTokenStream().GetTextFromRuleContext(modify.Datatype()))
+	}
+	if modify.DEFAULT() != nil && modify.Expression() != nil {
+		column.defaultValue = newStringPointer(modify.GetParser().GetTokenStream().GetTextFromRuleContext(modify.Expression()))
+	}
+	return t.oracleApplyInlineConstraints(schema, column, modify.AllInline_constraint())
+}
+
+func (t *TableState) oracleRenameColumn(ctx *FinderContext, oldName string, newName string) *WalkThroughError {
+	if oldName == newName {
+		return nil
+	}
+	column, exists := t.columnSet[oldName]
+	if !exists {
+		if ctx.CheckIntegrity {
+			return NewColumnNotExistsError(t.name, oldName)
+		}
+		column = &ColumnState{name: oldName}
+	}
+	if _, exists := t.columnSet[newName]; exists {
+		return &WalkThroughError{
+			Type:    ErrorTypeColumnExists,
+			Content: fmt.Sprintf("Column `%s` already exists in table `%s`", newName, t.name),
+		}
+	}
+
+	delete(t.columnSet, oldName)
+	column.name = newName
+	t.columnSet[newName] = column
+	for _, index := range t.indexSet {
+		for i, key := range index.expressionList {
+			if key == oldName {
+				index.expressionList[i] = newName
+			}
+		}
+	}
+	return nil
+}
+
+func (t *TableState) oracleDropColumn(ctx *FinderContext, columnName string) *WalkThroughError {
+	column, exists := t.columnSet[columnName]
+	if !exists {
+		if ctx.CheckIntegrity {
+			return NewColumnNotExistsError(t.name, columnName)
+		}
+	} else if ctx.CheckIntegrity && len(t.columnSet) == 1 {
+		// We only know all the columns of the table with the integrity check.
+		return &WalkThroughError{
+			Type: ErrorTypeDropAllColumns,
+			// Error content comes from Oracle error content.
+			Content: fmt.Sprintf("Cannot drop all columns in table `%s`", t.name),
+		}
+	}
+
+	// Dropping a column drops the indexes and constraints that contain the column.
+	for name, index := range t.indexSet {
+		for _, key := range index.expressionList {
+			if key == columnName {
+				delete(t.indexSet, name)
+				break
+			}
+		}
+	}
+
+	if column != nil && column.position != nil {
+		for _, col := range t.columnSet {
+			if col.position != nil && *col.position > *column.position {
+				*col.position--
+			}
+		}
+	}
+	delete(t.columnSet, columnName)
+	return nil
+}
+
+func (t *TableState) oracleCreateConstraint(schema *SchemaState, constraint plsql.IOut_of_line_constraintContext) *WalkThroughError {
+	if constraint.PRIMARY() == nil && constraint.UNIQUE() == nil {
+		return nil
+	}
+	_, constraintName := plsqlparser.NormalizeConstraintName(constraint.Constraint_name())
+	var keyList []string
+	for _, column := range constraint.AllColumn_name() {
+		columnName := oracleColumnName(column)
+		if constraint.PRIMARY() != nil {
+			if column, exists := t.columnSet[columnName]; exists {
+				column.nullable = newFalsePointer()
+			}
+		}
+		keyList = append(keyList, columnName)
+	}
+	return t.oracleCreateIndex(schema, constraintName, keyList, true /* unique */, constraint.PRIMARY() != nil, true /* isConstraint */)
+}
+
+func (t *TableState) oracleAlterConstraint(ctx *FinderContext, schema *SchemaState, clauses plsql.IConstraint_clausesContext) *WalkThroughError {
+	if clauses.ADD() != nil {
+		for _, constraint := range clauses.AllOut_of_line_constraint() {
+			if err := t.oracleCreateConstraint(schema, constraint); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if clauses.RENAME() != nil {
+		_, oldName := plsqlparser.NormalizeConstraintName(clauses.Old_constraint_name().Constraint_name())
+		_, newName := plsqlparser.NormalizeConstraintName(clauses.New_constraint_name().Constraint_name())
+		if index, exists := t.indexSet[oldName]; exists {
+			delete(t.indexSet, oldName)
+			index.name = newName
+			t.indexSet[newName] = index
+		}
+		return nil
+	}
+
+	for _, drop := range clauses.AllDrop_constraint_clause() {
+		clause := drop.Drop_primary_key_or_unique_or_generic_clause()
+		if clause == nil {
+			continue
+		}
+		switch {
+		case clause.PRIMARY() != nil:
+			name := ""
+			for indexName, index := range t.indexSet {
+				if index.Primary() {
+					name = indexName
+				}
+			}
+			if name == "" {
+				if ctx.CheckIntegrity {
+					return &WalkThroughError{
+						Type:    ErrorTypePrimaryKeyNotExists,
+						Content: fmt.Sprintf("Primary key does not exist in table `%s`", t.name),
+					}
+				}
+				continue
+			}
+			delete(t.indexSet, name)
+		case clause.UNIQUE() != nil:
+			var keyList []string
+			for _, column := range clause.AllColumn_name() {
+				keyList = append(keyList, oracleColumnName(column))
+			}
+			for indexName, index := range t.indexSet {
+				if index.isConstraint && index.Unique() && !index.Primary() && equalKeyList(index.expressionList, keyList) {
+					delete(t.indexSet, indexName)
+				}
+			}
+		default:
+			// The constraint may be a foreign key or check constraint, which is not tracked.
+			_, constraintName := plsqlparser.NormalizeConstraintName(clause.Constraint_name())
+			delete(t.indexSet, constraintName)
+		}
+	}
+	return nil
+}
+
+func (t *TableState) oracleCreateIndex(schema *SchemaState, name string, keyList []string, unique bool, primary bool, isConstraint bool) *WalkThroughError {
+	if len(keyList) == 0 {
+		return &WalkThroughError{
+			Type:    ErrorTypeIndexEmptyKeys,
+			Content: fmt.Sprintf("Index `%s` in table `%s` has empty key", name, t.name),
+		}
+	}
+	if primary {
+		for _, index := range t.indexSet {
+			if index.Primary() {
+				return &WalkThroughError{
+					Type:    ErrorTypePrimaryKeyExists,
+					Content: fmt.Sprintf("Primary key exists in table `%s`", t.name),
+				}
+			}
+		}
+	}
+
+	// In Oracle, the index name is unique in the schema.
+	if name != "" {
+		if _, index := schema.oracleFindIndex(name); index != nil {
+			return NewIndexExistsError(t.name, name)
+		}
+	} else {
+		// Oracle generates the name in the SYS_Cn format for unnamed constraints.
+		for suffix := 1; ; suffix++ {
+			name = fmt.Sprintf("SYS_C%06d", suffix)
+			if _, index := schema.oracleFindIndex(name); index == nil {
+				break
+			}
+		}
+	}
+
+	t.indexSet[name] = &IndexState{
+		name:           name,
+		expressionList: keyList,
+		indexType:      newStringPointer("NORMAL"),
+		unique:         newBoolPointer(unique),
+		primary:        newBoolPointer(primary),
+		visible:        newTruePointer(),
+		comment:        newEmptyStringPointer(),
+		isConstraint:   isConstraint,
+	}
+	return nil
+}
+
+func equalKeyList(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -120,6 +120,96 @@ func TestPostgreSQLWalkThrough(t *testing.T) {
 	}
 }
 
+func TestOracleWalkThrough(t *testing.T) {
+	originDatabase := &storepb.DatabaseSchemaMetadata{
+		Name: "TEST_DB",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "TEST_DB",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "EMPLOYEE",
+						Columns: []*storepb.ColumnMetadata{
+							{
+								Name:     "ID",
+								Type:     "NUMBER",
+								Nullable: false,
+							},
+							{
+								Name:     "NAME",
+								Type:     "VARCHAR2(20)",
+								Nullable: true,
+							},
+						},
+						Indexes: []*storepb.IndexMetadata{
+							{
+								Name:        "PK_EMPLOYEE",
+								Expressions: []string{"ID"},
+								Type:        "NORMAL",
+								Unique:      true,
+								Primary:     true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []string{
+		"oracle_walk_through",
+	}
+
+	for _, test := range tests {
+		runWalkThroughTest(t, test, storepb.Engine_ORACLE, originDatabase, false /* record */)
+	}
+}
+
+func TestMSSQLWalkThrough(t *testing.T) {
+	originDatabase := &storepb.DatabaseSchemaMetadata{
+		Name: "test_db",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "dbo",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "employee",
+						Columns: []*storepb.ColumnMetadata{
+							{
+								Name:     "id",
+								Type:     "int",
+								Nullable: false,
+							},
+							{
+								Name:     "name",
+								Type:     "varchar(20)",
+								Nullable: true,
+							},
+						},
+						Indexes: []*storepb.IndexMetadata{
+							{
+								Name:        "pk_employee",
+								Expressions: []string{"id"},
+								Type:        "CLUSTERED",
+								Unique:      true,
+								Primary:     true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []string{
+		"mssql_walk_through",
+	}
+
+	for _, test := range tests {
+		runWalkThroughTest(t, test, storepb.Engine_MSSQL, originDatabase, false /* record */)
+	}
+}
+
 func convertInterfaceSliceToStringSlice(slice []any) []string {
 	var res []string
 	for _, item := range slice {
@@ -346,3 +436,30 @@ func (t *TableState) convertToColumnMetadataList() []*storepb.ColumnMetadata {
 
 	return result
 }
+
+func TestPostgreSQLWalkThroughWithoutSchema(t *testing.T) {
+	a := require.New(t)
+	// The public schema and the schema created by the statements are not in the empty database.
+	finder := NewEmptyFinder(&FinderContext{CheckIntegrity: false, EngineType: storepb.Engine_POSTGRES})
+	a.NoError(finder.WalkThrough("CREATE TABLE t(a int); CREATE SCHEMA s; CREATE TABLE s.t(b int);"))
+	a.NotNil(finder.Final.FindColumn(&ColumnFind{SchemaName: "public", TableName: "t", ColumnName: "a"}))
+	a.NotNil(finder.Final.FindColumn(&ColumnFind{SchemaName: "s", TableName: "t", ColumnName: "b"}))
+}
+
+func TestOracleWalkThroughWithCurrentSchema(t *testing.T) {
+	a := require.New(t)
+	database := &storepb.DatabaseSchemaMetadata{
+		Name: "TEST_DB",
+		Schemas: []*storepb.SchemaMetadata{
+			{Name: "TEST_DB"},
+			{Name: "HR", Tables: []*storepb.TableMetadata{{Name: "EMPLOYEE", Columns: []*storepb.ColumnMetadata{{Name: "ID", Type: "NUMBER"}}}}},
+		},
+	}
+	finder := NewFinder(database, &FinderContext{CheckIntegrity: true, EngineType: storepb.Engine_ORACLE})
+	finder.SetCurrentSchema("HR")
+	// The unqualified names are resolved in the current schema instead of the schema named after the database.
+	a.NoError(finder.WalkThrough("ALTER TABLE employee ADD (name VARCHAR2(20)); CREATE TABLE t(a INT);"))
+	a.NotNil(finder.Final.FindColumn(&ColumnFind{SchemaName: "HR", TableName: "EMPLOYEE", ColumnName: "NAME"}))
+	a.NotNil(finder.Final.FindTable(&TableFind{SchemaName: "HR", TableName: "T"}))
+	a.Nil(finder.Final.FindTable(&TableFind{SchemaName: "TEST_DB", TableName: "T"}))
+}
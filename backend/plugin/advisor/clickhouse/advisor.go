@@ -0,0 +1,51 @@
+// Package clickhouse is the advisor for ClickHouse database.
+package clickhouse
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
+)
+
+// getNodeList returns the statements parsed by the syntax check.
+func getNodeList(ast any) ([]clickhouseparser.Node, error) {
+	nodeList, ok := ast.([]clickhouseparser.Node)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to ClickHouse node list")
+	}
+	return nodeList, nil
+}
+
+// generateAdvice returns the advice list, the advice list must not be empty.
+func generateAdvice(adviceList []advisor.Advice) []advisor.Advice {
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList
+}
+
+// isAlwaysTrue returns whether the WHERE expression is empty or a constant true, such as "1" or "1 = 1".
+func isAlwaysTrue(where string) bool {
+	switch strings.ToLower(strings.Join(strings.Fields(where), "")) {
+	case "", "1", "true", "1=1":
+		return true
+	default:
+		return false
+	}
+}
+
+// normalizeIdentifier removes the backquotes and double quotes around the identifier.
+func normalizeIdentifier(identifier string) string {
+	if len(identifier) >= 2 && (identifier[0] == '`' || identifier[0] == '"') && identifier[len(identifier)-1] == identifier[0] {
+		return identifier[1 : len(identifier)-1]
+	}
+	return identifier
+}
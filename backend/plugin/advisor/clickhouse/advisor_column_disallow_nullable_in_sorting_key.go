@@ -0,0 +1,58 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnDisallowNullableInSortingKeyAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_CLICKHOUSE, advisor.ClickHouseColumnDisallowNullableInSortingKey, &ColumnDisallowNullableInSortingKeyAdvisor{})
+}
+
+// ColumnDisallowNullableInSortingKeyAdvisor is the advisor checking for the nullable column in the sorting key.
+// ClickHouse rejects the nullable sorting key unless allow_nullable_key is enabled, which also slows down the queries.
+type ColumnDisallowNullableInSortingKeyAdvisor struct {
+}
+
+// Check checks for the nullable column in the sorting key.
+func (*ColumnDisallowNullableInSortingKeyAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	nodeList, err := getNodeList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, node := range nodeList {
+		create, ok := node.(*clickhouseparser.CreateTableStmt)
+		if !ok {
+			continue
+		}
+		for _, key := range create.SortingKey() {
+			// Only the bare columns are checked, the expressions such as "ifNull(a, 0)" are allowed.
+			column := create.FindColumn(normalizeIdentifier(key))
+			if column == nil || !column.IsNullable() {
+				continue
+			}
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.ColumnNullableInSortingKey,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("Nullable column `%s`.`%s` cannot be in the sorting key.", create.Table.Name, column.Name),
+				Line:    column.Line,
+			})
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
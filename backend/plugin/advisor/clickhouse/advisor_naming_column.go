@@ -0,0 +1,79 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*NamingColumnAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_CLICKHOUSE, advisor.ClickHouseNamingColumnConvention, &NamingColumnAdvisor{})
+}
+
+// NamingColumnAdvisor is the advisor checking for column naming convention.
+type NamingColumnAdvisor struct {
+}
+
+// Check checks for column naming convention.
+func (*NamingColumnAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	nodeList, err := getNodeList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, maxLength, err := advisor.UnmarshalNamingRulePayloadAsRegexp(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	check := func(tableName, columnName string, line int) {
+		if !format.MatchString(columnName) {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingColumnConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("`%s`.`%s` mismatches column naming convention, naming format should be %q", tableName, columnName, format),
+				Line:    line,
+			})
+		}
+		if maxLength > 0 && len(columnName) > maxLength {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingColumnConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("`%s`.`%s` mismatches column naming convention, its length should be within %d characters", tableName, columnName, maxLength),
+				Line:    line,
+			})
+		}
+	}
+
+	for _, node := range nodeList {
+		switch node := node.(type) {
+		case *clickhouseparser.CreateTableStmt:
+			for _, column := range node.ColumnList {
+				check(node.Table.Name, column.Name, column.Line)
+			}
+		case *clickhouseparser.AlterTableStmt:
+			for _, command := range node.CommandList {
+				switch command.Type {
+				case clickhouseparser.AlterCommandAddColumn:
+					check(node.Table.Name, command.Column.Name, command.Column.Line)
+				case clickhouseparser.AlterCommandRenameColumn:
+					check(node.Table.Name, command.NewColumnName, node.LastLine())
+				}
+			}
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
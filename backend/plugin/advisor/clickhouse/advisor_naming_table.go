@@ -0,0 +1,72 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*NamingTableAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_CLICKHOUSE, advisor.ClickHouseNamingTableConvention, &NamingTableAdvisor{})
+}
+
+// NamingTableAdvisor is the advisor checking for table naming convention.
+type NamingTableAdvisor struct {
+}
+
+// Check checks for table naming convention.
+func (*NamingTableAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	nodeList, err := getNodeList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, maxLength, err := advisor.UnmarshalNamingRulePayloadAsRegexp(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	check := func(tableName string, line int) {
+		if !format.MatchString(tableName) {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingTableConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf(`"%s" mismatches table naming convention, naming format should be %q`, tableName, format),
+				Line:    line,
+			})
+		}
+		if maxLength > 0 && len(tableName) > maxLength {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingTableConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("\"%s\" mismatches table naming convention, its length should be within %d characters", tableName, maxLength),
+				Line:    line,
+			})
+		}
+	}
+
+	for _, node := range nodeList {
+		switch node := node.(type) {
+		case *clickhouseparser.CreateTableStmt:
+			check(node.Table.Name, node.LastLine())
+		case *clickhouseparser.RenameTableStmt:
+			for _, pair := range node.Pairs {
+				check(pair[1].Name, node.LastLine())
+			}
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
@@ -0,0 +1,46 @@
+package clickhouse
+
+import (
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*SelectNoSelectAllAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_CLICKHOUSE, advisor.ClickHouseNoSelectAll, &SelectNoSelectAllAdvisor{})
+}
+
+// SelectNoSelectAllAdvisor is the advisor checking for no "select *".
+type SelectNoSelectAllAdvisor struct {
+}
+
+// Check checks for no "select *".
+func (*SelectNoSelectAllAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	nodeList, err := getNodeList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, node := range nodeList {
+		if selectStmt, ok := node.(*clickhouseparser.SelectStmt); ok && selectStmt.SelectAll {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.StatementSelectAll,
+				Title:   string(ctx.Rule.Type),
+				Content: "Avoid using SELECT *.",
+				Line:    selectStmt.LastLine(),
+			})
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
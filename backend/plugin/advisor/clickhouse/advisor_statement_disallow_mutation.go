@@ -0,0 +1,62 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDisallowMutationAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_CLICKHOUSE, advisor.ClickHouseDisallowMutation, &StatementDisallowMutationAdvisor{})
+}
+
+// StatementDisallowMutationAdvisor is the advisor checking for the ALTER TABLE ... UPDATE/DELETE mutations.
+// The mutations rewrite all the data parts containing the affected rows asynchronously, which is expensive and cannot be rolled back.
+type StatementDisallowMutationAdvisor struct {
+}
+
+// Check checks for the ALTER TABLE ... UPDATE/DELETE mutations.
+func (*StatementDisallowMutationAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	nodeList, err := getNodeList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, node := range nodeList {
+		alter, ok := node.(*clickhouseparser.AlterTableStmt)
+		if !ok {
+			continue
+		}
+		for _, command := range alter.CommandList {
+			var mutation string
+			switch command.Type {
+			case clickhouseparser.AlterCommandUpdate:
+				mutation = "UPDATE"
+			case clickhouseparser.AlterCommandDelete:
+				mutation = "DELETE"
+			default:
+				continue
+			}
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.StatementMutation,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("ALTER TABLE %s %s is a mutation rewriting the whole data parts, consider the lightweight DELETE or a ReplacingMergeTree table instead.", alter.Table.String(), mutation),
+				Line:    alter.LastLine(),
+			})
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
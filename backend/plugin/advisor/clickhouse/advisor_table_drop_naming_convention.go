@@ -0,0 +1,58 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*TableDropNamingConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_CLICKHOUSE, advisor.ClickHouseTableDropNamingConvention, &TableDropNamingConventionAdvisor{})
+}
+
+// TableDropNamingConventionAdvisor is the advisor checking for table drop with naming convention.
+type TableDropNamingConventionAdvisor struct {
+}
+
+// Check checks for table drop with naming convention.
+func (*TableDropNamingConventionAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	nodeList, err := getNodeList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, _, err := advisor.UnmarshalNamingRulePayloadAsRegexp(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, node := range nodeList {
+		drop, ok := node.(*clickhouseparser.DropTableStmt)
+		if !ok {
+			continue
+		}
+		for _, table := range drop.TableList {
+			if !format.MatchString(table.Name) {
+				adviceList = append(adviceList, advisor.Advice{
+					Status:  level,
+					Code:    advisor.TableDropNamingConventionMismatch,
+					Title:   string(ctx.Rule.Type),
+					Content: fmt.Sprintf("%q mismatches drop table naming convention, naming format should be %q", table.Name, format),
+					Line:    drop.LastLine(),
+				})
+			}
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
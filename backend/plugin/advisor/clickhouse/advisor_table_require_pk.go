@@ -0,0 +1,53 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*TableRequirePKAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_CLICKHOUSE, advisor.ClickHouseTableRequirePK, &TableRequirePKAdvisor{})
+}
+
+// TableRequirePKAdvisor is the advisor checking for the MergeTree table sorting key.
+// The MergeTree table without ORDER BY and PRIMARY KEY, or with ORDER BY tuple(), stores the rows unsorted and cannot skip any data in the queries.
+type TableRequirePKAdvisor struct {
+}
+
+// Check checks for the MergeTree table sorting key.
+func (*TableRequirePKAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	nodeList, err := getNodeList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, node := range nodeList {
+		create, ok := node.(*clickhouseparser.CreateTableStmt)
+		if !ok || !create.IsMergeTree() {
+			continue
+		}
+		if len(create.SortingKey()) == 0 {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.TableNoPK,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("Table %s requires ORDER BY or PRIMARY KEY.", create.Table.String()),
+				Line:    create.LastLine(),
+			})
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
@@ -0,0 +1,52 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*TableRequireTTLAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_CLICKHOUSE, advisor.ClickHouseTableRequireTTL, &TableRequireTTLAdvisor{})
+}
+
+// TableRequireTTLAdvisor is the advisor checking for the MergeTree table TTL.
+type TableRequireTTLAdvisor struct {
+}
+
+// Check checks for the MergeTree table TTL.
+func (*TableRequireTTLAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	nodeList, err := getNodeList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, node := range nodeList {
+		create, ok := node.(*clickhouseparser.CreateTableStmt)
+		if !ok || !create.IsMergeTree() {
+			continue
+		}
+		if create.TTL == "" {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.TableNoTTL,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("Table %s requires TTL.", create.Table.String()),
+				Line:    create.LastLine(),
+			})
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
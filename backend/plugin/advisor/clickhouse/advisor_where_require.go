@@ -0,0 +1,77 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*WhereRequireAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_CLICKHOUSE, advisor.ClickHouseWhereRequirement, &WhereRequireAdvisor{})
+}
+
+// WhereRequireAdvisor is the advisor checking for WHERE clause requirement.
+// The UPDATE and DELETE mutations always have the WHERE clause, so the constant true condition such as "WHERE 1" is reported as well.
+type WhereRequireAdvisor struct {
+}
+
+// Check checks for WHERE clause requirement.
+func (*WhereRequireAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	nodeList, err := getNodeList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	add := func(statementType string, line int) {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  level,
+			Code:    advisor.StatementNoWhere,
+			Title:   string(ctx.Rule.Type),
+			Content: fmt.Sprintf("WHERE clause is required for %s statement.", statementType),
+			Line:    line,
+		})
+	}
+
+	for _, node := range nodeList {
+		switch node := node.(type) {
+		case *clickhouseparser.SelectStmt:
+			if node.HasFrom && node.Where == "" {
+				add("SELECT", node.LastLine())
+			}
+		case *clickhouseparser.UpdateStmt:
+			if isAlwaysTrue(node.Where) {
+				add("UPDATE", node.LastLine())
+			}
+		case *clickhouseparser.DeleteStmt:
+			if isAlwaysTrue(node.Where) {
+				add("DELETE", node.LastLine())
+			}
+		case *clickhouseparser.AlterTableStmt:
+			for _, command := range node.CommandList {
+				switch command.Type {
+				case clickhouseparser.AlterCommandUpdate:
+					if isAlwaysTrue(command.Where) {
+						add("ALTER TABLE ... UPDATE", node.LastLine())
+					}
+				case clickhouseparser.AlterCommandDelete:
+					if isAlwaysTrue(command.Where) {
+						add("ALTER TABLE ... DELETE", node.LastLine())
+					}
+				}
+			}
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
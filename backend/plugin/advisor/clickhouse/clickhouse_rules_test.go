@@ -0,0 +1,26 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestClickHouseRules(t *testing.T) {
+	clickhouseRules := []advisor.SQLReviewRuleType{
+		advisor.SchemaRuleTableNaming,
+		advisor.SchemaRuleColumnNaming,
+		advisor.SchemaRuleTableRequirePK,
+		advisor.SchemaRuleStatementNoSelectAll,
+		advisor.SchemaRuleStatementRequireWhere,
+		advisor.SchemaRuleTableDropNamingConvention,
+		advisor.SchemaRuleStatementDisallowMutation,
+		advisor.SchemaRuleTableRequireTTL,
+		advisor.SchemaRuleColumnDisallowNullableInSortingKey,
+	}
+
+	for _, rule := range clickhouseRules {
+		advisor.RunSQLReviewRuleTest(t, rule, storepb.Engine_CLICKHOUSE, false /* record */)
+	}
+}
@@ -11,18 +11,19 @@ const (
 	Unsupported Code = 3
 
 	// 101 ~ 199 compatibility error code.
-	CompatibilityDropDatabase  Code = 101
-	CompatibilityRenameTable   Code = 102
-	CompatibilityDropTable     Code = 103
-	CompatibilityRenameColumn  Code = 104
-	CompatibilityDropColumn    Code = 105
-	CompatibilityAddPrimaryKey Code = 106
-	CompatibilityAddUniqueKey  Code = 107
-	CompatibilityAddForeignKey Code = 108
-	CompatibilityAddCheck      Code = 109
-	CompatibilityAlterCheck    Code = 110
-	CompatibilityAlterColumn   Code = 111
-	CompatibilityDropSchema    Code = 112
+	CompatibilityDropDatabase     Code = 101
+	CompatibilityRenameTable      Code = 102
+	CompatibilityDropTable        Code = 103
+	CompatibilityRenameColumn     Code = 104
+	CompatibilityDropColumn       Code = 105
+	CompatibilityAddPrimaryKey    Code = 106
+	CompatibilityAddUniqueKey     Code = 107
+	CompatibilityAddForeignKey    Code = 108
+	CompatibilityAddCheck         Code = 109
+	CompatibilityAlterCheck       Code = 110
+	CompatibilityAlterColumn      Code = 111
+	CompatibilityDropSchema       Code = 112
+	CompatibilityAddNotNullColumn Code = 113
 
 	// 201 ~ 299 statement error code.
 	StatementSyntaxError             Code = 201
@@ -37,6 +38,12 @@ const (
 	StatementAddColumnWithDefault    Code = 210
 	StatementAddCheckWithValidation  Code = 211
 	StatementAddNotNull              Code = 212
+	StatementTableRewrite            Code = 213
+	StatementSetNotNullWithoutCheck  Code = 214
+	StatementAddFKWithValidation     Code = 215
+	StatementBlockingMaintenance     Code = 216
+	StatementNoLockTimeout           Code = 217
+	StatementMutation                Code = 218
 
 	// 301 ～ 399 naming error code
 	// 301 table naming advisor error code.
@@ -83,6 +90,7 @@ const (
 	VarcharLengthExceedsLimit                  Code = 422
 	InvalidColumnDefault                       Code = 423
 	DropIndexColumn                            Code = 424
+	ColumnNullableInSortingKey                 Code = 425
 
 	// 501 engine error code.
 	NotInnoDBEngine Code = 501
@@ -97,6 +105,9 @@ const (
 	TableExists                       Code = 607
 	CreateTablePartition              Code = 608
 	TableIsReferencedByView           Code = 609
+	TableNoTTL                        Code = 610
+	TableNotInterleaved               Code = 611
+	InterleaveWithoutOnDelete         Code = 612
 
 	// 701 ~ 799 database advisor error code.
 	DatabaseNotEmpty   Code = 701
@@ -118,6 +129,8 @@ const (
 	DuplicateColumnInIndex     Code = 812
 	IndexCountExceedsLimit     Code = 813
 	CreateIndexUnconcurrently  Code = 814
+	DuplicateIndex             Code = 815
+	PrimaryKeyMonotonic        Code = 816
 
 	// 1001 ~ 1099 charset error code.
 	DisabledCharset Code = 1001
@@ -137,6 +150,10 @@ const (
 
 	// 1301 ~ 1399 comment error code.
 	CommentTooLong Code = 1301
+
+	// 1401 ~ 1499 custom rule error code.
+	CustomRuleViolation  Code = 1401
+	CustomRuleEvalFailed Code = 1402
 )
 
 // Int returns the int type of code.
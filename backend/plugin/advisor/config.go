@@ -27,10 +27,12 @@ type SQLReviewTemplateData struct {
 
 // SQLReviewRuleData is the API message for SQL review rule update.
 type SQLReviewRuleData struct {
-	Type    SQLReviewRuleType `yaml:"type"`
-	Level   string            `yaml:"level,omitempty"`
-	Comment string            `yaml:"comment"`
-	Payload map[string]any    `yaml:"payload"`
+	Type  SQLReviewRuleType `yaml:"type"`
+	Level string            `yaml:"level,omitempty"`
+	// Engine is the engine that the rule applies to, the rule applies to all engines if it's empty.
+	Engine  string         `yaml:"engine,omitempty"`
+	Comment string         `yaml:"comment"`
+	Payload map[string]any `yaml:"payload"`
 }
 
 // SQLReviewConfigOverride is the API message for SQL review configuration override.
@@ -40,6 +42,7 @@ type SQLReviewConfigOverride struct {
 }
 
 // MergeSQLReviewRules will merge the input YML config into default template.
+// The override rules that the template lacks, such as the custom rules, are appended after the template rules.
 func MergeSQLReviewRules(override *SQLReviewConfigOverride) ([]*storepb.SQLReviewRule, error) {
 	templateList, err := parseSQLReviewTemplateList()
 	if err != nil {
@@ -58,7 +61,9 @@ func MergeSQLReviewRules(override *SQLReviewConfigOverride) ([]*storepb.SQLRevie
 
 	var res []*storepb.SQLReviewRule
 
+	templateRuleTypes := make(map[SQLReviewRuleType]bool)
 	for _, ruleTemplate := range template.RuleList {
+		templateRuleTypes[ruleTemplate.Type] = true
 		ruleUpdate := ruleUpdateMap[ruleTemplate.Type]
 		rule, err := mergeRule(ruleTemplate, ruleUpdate)
 		if err != nil {
@@ -67,6 +72,17 @@ func MergeSQLReviewRules(override *SQLReviewConfigOverride) ([]*storepb.SQLRevie
 		res = append(res, rule)
 	}
 
+	for _, ruleOverride := range override.RuleList {
+		if templateRuleTypes[ruleOverride.Type] {
+			continue
+		}
+		rule, err := mergeRule(ruleOverride, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rule %q", ruleOverride.Type)
+		}
+		res = append(res, rule)
+	}
+
 	return res, nil
 }
 
@@ -125,10 +141,19 @@ func mergeRule(source *SQLReviewRuleData, override *SQLReviewRuleData) (*storepb
 	if !ok {
 		return nil, errors.Errorf("invalid rule level %q", level)
 	}
+	engine := storepb.Engine_ENGINE_UNSPECIFIED
+	if source.Engine != "" {
+		engineValue, ok := storepb.Engine_value[source.Engine]
+		if !ok {
+			return nil, errors.Errorf("invalid rule engine %q", source.Engine)
+		}
+		engine = storepb.Engine(engineValue)
+	}
 
 	return &storepb.SQLReviewRule{
 		Type:    string(source.Type),
 		Level:   storepb.SQLReviewRuleLevel(ruleLevelValue),
+		Engine:  engine,
 		Comment: comment,
 		Payload: string(str),
 	}, nil
@@ -78,4 +78,54 @@ func TestConfigOverride(t *testing.T) {
 			assert.Equal(t, "name", payload.List[0])
 		}
 	}
+
+	// The engine-specific rules of the template keep their engines.
+	var engineList []storepb.Engine
+	for _, rule := range ruleList {
+		if rule.Type == "column.required" {
+			engineList = append(engineList, rule.Engine)
+		}
+	}
+	assert.Equal(t, []storepb.Engine{storepb.Engine_ENGINE_UNSPECIFIED, storepb.Engine_SNOWFLAKE, storepb.Engine_ORACLE}, engineList)
+}
+
+func TestConfigOverrideAppendRules(t *testing.T) {
+	override := &SQLReviewConfigOverride{}
+	err := yaml.Unmarshal([]byte(`
+template: bb.sql-review.prod
+ruleList:
+  - type: custom.cel
+    level: ERROR
+    payload:
+      title: custom.no-delete
+      expression: statement.type == "DELETE"
+  - type: custom.cel
+    level: WARNING
+    payload:
+      title: custom.no-update
+      expression: statement.type == "UPDATE"
+`), override)
+	require.NoError(t, err)
+
+	ruleList, err := MergeSQLReviewRules(override)
+	require.NoError(t, err)
+
+	// The custom rules are not in the template, and they are appended in order.
+	var customRuleList []*storepb.SQLReviewRule
+	for _, rule := range ruleList {
+		if rule.Type == string(SchemaRuleCustom) {
+			customRuleList = append(customRuleList, rule)
+		}
+	}
+	require.Len(t, customRuleList, 2)
+	assert.Equal(t, storepb.SQLReviewRuleLevel_ERROR, customRuleList[0].Level)
+	assert.Equal(t, storepb.SQLReviewRuleLevel_WARNING, customRuleList[1].Level)
+	payload, err := UnmarshalCustomRulePayload(customRuleList[1].Payload)
+	require.NoError(t, err)
+	assert.Equal(t, "custom.no-update", payload.Title)
+
+	// The appended rules need a valid level.
+	override.RuleList[0].Level = ""
+	_, err = MergeSQLReviewRules(override)
+	require.Error(t, err)
 }
@@ -0,0 +1,118 @@
+package advisor
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/google/cel-go/cel"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+)
+
+// CompileCustomRuleExpression compiles the CEL expression of the custom rule.
+// The expression must evaluate to a bool over the `statement` variable.
+// The attributes of the statement are dynamically typed, so they must be compared to return bool, e.g. `statement.has_where == false`.
+func CompileCustomRuleExpression(expression string) (cel.Program, error) {
+	if expression == "" {
+		return nil, errors.Errorf("custom rule expression cannot be empty")
+	}
+	e, err := cel.NewEnv(common.SQLReviewCustomRuleCELAttributes...)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := e.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, errors.Wrapf(issues.Err(), "failed to compile custom rule expression %q", expression)
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, errors.Errorf("custom rule expression %q must return bool, but got %s", expression, ast.OutputType())
+	}
+	return e.Program(ast)
+}
+
+// CheckCustomRule checks the statement models with the custom rule in the context.
+func CheckCustomRule(ctx Context, modelList []*catalog.StatementModel) ([]Advice, error) {
+	level, err := NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := UnmarshalCustomRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	prg, err := CompileCustomRuleExpression(payload.Expression)
+	if err != nil {
+		return nil, err
+	}
+	title := payload.Title
+	if title == "" {
+		title = string(ctx.Rule.Type)
+	}
+
+	var adviceList []Advice
+	for _, model := range modelList {
+		out, _, err := prg.Eval(map[string]any{"statement": model.CELValue()})
+		if err != nil {
+			adviceList = append(adviceList, Advice{
+				Status:  level,
+				Code:    CustomRuleEvalFailed,
+				Title:   title,
+				Content: fmt.Sprintf("Failed to evaluate the custom rule on \"%s\": %v", NormalizeStatement(model.Text), err),
+				Line:    model.Line,
+			})
+			continue
+		}
+		violated, ok := out.Value().(bool)
+		if !ok {
+			return nil, errors.Errorf("custom rule expression %q must return bool", payload.Expression)
+		}
+		if !violated {
+			continue
+		}
+		content := payload.Message
+		if content == "" {
+			content = fmt.Sprintf("\"%s\" violates the custom rule", NormalizeStatement(model.Text))
+		}
+		adviceList = append(adviceList, Advice{
+			Status:  level,
+			Code:    CustomRuleViolation,
+			Title:   title,
+			Content: content,
+			Line:    model.Line,
+		})
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, Advice{
+			Status:  Success,
+			Code:    Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
+
+// FillAffectedRows fills the unknown affected rows of the DML statement models with the estimate, e.g. by EXPLAIN in the database.
+// The affected rows stay unknown if the estimate fails.
+func FillAffectedRows(modelList []*catalog.StatementModel, estimate func(statement string) (int64, error)) {
+	for _, model := range modelList {
+		if model.AffectedRows >= 0 {
+			continue
+		}
+		switch model.Type {
+		case catalog.StatementTypeInsert, catalog.StatementTypeUpdate, catalog.StatementTypeDelete:
+		default:
+			continue
+		}
+		rows, err := estimate(model.Text)
+		if err != nil {
+			slog.Debug("failed to estimate the affected rows", slog.String("statement", model.Text), log.BBError(err))
+			continue
+		}
+		model.AffectedRows = rows
+	}
+}
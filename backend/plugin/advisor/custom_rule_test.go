@@ -0,0 +1,65 @@
+package advisor
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestFillAffectedRows(t *testing.T) {
+	a := require.New(t)
+	modelList, err := catalog.BuildStatementModels(storepb.Engine_MYSQL, `
+		INSERT INTO t VALUES (1), (2);
+		INSERT INTO t SELECT * FROM s;
+		UPDATE t SET a = 1;
+		DELETE FROM t WHERE a = 1;
+		ALTER TABLE t ADD COLUMN b INT;`, nil)
+	a.NoError(err)
+
+	var estimated []string
+	FillAffectedRows(modelList, func(statement string) (int64, error) {
+		estimated = append(estimated, statement)
+		if len(estimated) == 3 {
+			return 0, errors.New("explain failed")
+		}
+		return 10, nil
+	})
+	// The INSERT ... VALUES statement has the exact count, and the ALTER TABLE statement has no estimate.
+	a.Len(estimated, 3)
+	var affectedRows []int64
+	for _, model := range modelList {
+		affectedRows = append(affectedRows, model.AffectedRows)
+	}
+	a.Equal([]int64{2, 10, 10, -1, -1}, affectedRows)
+}
+
+func TestCompileCustomRuleExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    bool
+	}{
+		{expression: `statement.type == "DELETE" && statement.has_where == false`},
+		{expression: `!statement.has_where`},
+		{expression: `statement.affected_rows > 1000`},
+		{expression: `statement.added_columns.exists(c, c.nullable == false)`},
+		{expression: "", wantErr: true},
+		{expression: "statement.type ==", wantErr: true},
+		// The expression must return bool at compile time.
+		{expression: "statement.has_where", wantErr: true},
+		{expression: "statement.affected_rows + 1", wantErr: true},
+		{expression: `"DELETE"`, wantErr: true},
+	}
+	a := require.New(t)
+	for _, test := range tests {
+		_, err := CompileCustomRuleExpression(test.expression)
+		if test.wantErr {
+			a.Error(err, test.expression)
+		} else {
+			a.NoError(err, test.expression)
+		}
+	}
+}
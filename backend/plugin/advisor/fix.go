@@ -0,0 +1,185 @@
+package advisor
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Fix is the suggested change to resolve the advice.
+// The range covers [StartLine:StartColumn, EndLine:EndColumn) of the checked statements.
+type Fix struct {
+	// Description is the short description of the change, e.g. "Add CONCURRENTLY".
+	Description string `json:"description"`
+	// StartLine and EndLine are one-based line numbers.
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+	// StartColumn and EndColumn are zero-based character offsets in the line, EndColumn is exclusive.
+	StartColumn int `json:"startColumn"`
+	EndColumn   int `json:"endColumn"`
+	// NewText is the text to replace the range with.
+	NewText string `json:"newText"`
+
+	// origin is the original text replaced by the advisor, it is resolved to the range by SQLReviewCheck.
+	origin string
+}
+
+// NewFix returns the fix replacing the origin text in the statements with the new text.
+// Advisors usually pass the statement text as the origin, and the range is narrowed down to the changed part.
+func NewFix(description, origin, newText string) *Fix {
+	return &Fix{
+		Description: description,
+		NewText:     newText,
+		origin:      origin,
+	}
+}
+
+// NewMergeFix returns the fix replacing the statements with the merged one.
+// The statements must be adjacent in the checked statements, i.e. separated by the whitespaces and semicolons only,
+// and the first one is around the line. It returns nil otherwise.
+func NewMergeFix(description, statements string, textList []string, line int, newText string) *Fix {
+	if len(textList) < 2 {
+		return nil
+	}
+	start := findOrigin(statements, textList[0], line)
+	if start < 0 {
+		return nil
+	}
+	end := start + len(textList[0])
+	for _, text := range textList[1:] {
+		next := end + len(statements[end:]) - len(strings.TrimLeft(statements[end:], " \t\r\n;"))
+		if !strings.HasPrefix(statements[next:], text) {
+			return nil
+		}
+		end = next + len(text)
+	}
+	return NewFix(description, statements[start:end], newText)
+}
+
+// resolveFixRange resolves the fix range of the advice list in the statements.
+// The fix will be dropped if the origin text cannot be found.
+func resolveFixRange(statements string, adviceList []Advice) {
+	for i := range adviceList {
+		fix := adviceList[i].Fix
+		if fix == nil {
+			continue
+		}
+		if fix.origin == "" {
+			// The advisor may set the range directly.
+			if fix.StartLine == 0 {
+				adviceList[i].Fix = nil
+			}
+			continue
+		}
+		offset := findOrigin(statements, fix.origin, adviceList[i].Line)
+		if offset < 0 {
+			adviceList[i].Fix = nil
+			continue
+		}
+
+		origin, newText := fix.origin, fix.NewText
+		prefix := commonPrefixLength(origin, newText)
+		suffix := commonSuffixLength(origin[prefix:], newText[prefix:])
+		start, end := offset+prefix, offset+len(origin)-suffix
+
+		fix.StartLine, fix.StartColumn = positionOfOffset(statements, start)
+		fix.EndLine, fix.EndColumn = positionOfOffset(statements, end)
+		fix.NewText = newText[prefix : len(newText)-suffix]
+		fix.origin = ""
+	}
+}
+
+// findOrigin returns the byte offset of the origin in the statements.
+// It prefers the occurrence covering the line, and falls back to the first one.
+func findOrigin(statements, origin string, line int) int {
+	first := -1
+	for offset := 0; offset < len(statements); {
+		index := strings.Index(statements[offset:], origin)
+		if index < 0 {
+			break
+		}
+		start := offset + index
+		if first < 0 {
+			first = start
+		}
+		startLine := strings.Count(statements[:start], "\n") + 1
+		endLine := startLine + strings.Count(origin, "\n")
+		if startLine <= line && line <= endLine {
+			return start
+		}
+		offset = start + len(origin)
+	}
+	return first
+}
+
+func commonPrefixLength(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	// Do not split the multi-byte character.
+	for n > 0 && n < len(a) && !utf8.RuneStart(a[n]) {
+		n--
+	}
+	return n
+}
+
+func commonSuffixLength(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[len(a)-1-n] == b[len(b)-1-n] {
+		n++
+	}
+	// Do not split the multi-byte character.
+	for n > 0 && n < len(a) && !utf8.RuneStart(a[len(a)-n]) {
+		n--
+	}
+	return n
+}
+
+// positionOfOffset returns the one-based line and zero-based character column of the byte offset.
+func positionOfOffset(text string, offset int) (int, int) {
+	before := text[:offset]
+	line := strings.Count(before, "\n") + 1
+	lineStart := strings.LastIndex(before, "\n") + 1
+	return line, utf8.RuneCountInString(before[lineStart:])
+}
+
+// ExpandToLines returns the equivalent fix replacing the whole lines [StartLine, EndLine] of the text.
+// It is used by the line based suggestions, such as the suggested changes in the pull request comments.
+func (f *Fix) ExpandToLines(text string) (*Fix, bool) {
+	lines := strings.Split(text, "\n")
+	if f.StartLine < 1 || f.EndLine > len(lines) || f.StartLine > f.EndLine {
+		return nil, false
+	}
+	startLine, endLine := lines[f.StartLine-1], lines[f.EndLine-1]
+	origin := strings.Join(lines[f.StartLine-1:f.EndLine], "\n")
+	startColumn := byteOffsetOfColumn(startLine, f.StartColumn)
+	endColumn := byteOffsetOfColumn(endLine, f.EndColumn)
+	if startColumn < 0 || endColumn < 0 {
+		return nil, false
+	}
+	start, end := startColumn, len(origin)-len(endLine)+endColumn
+	if end < start {
+		return nil, false
+	}
+	return &Fix{
+		Description: f.Description,
+		StartLine:   f.StartLine,
+		StartColumn: 0,
+		EndLine:     f.EndLine,
+		EndColumn:   utf8.RuneCountInString(endLine),
+		NewText:     origin[:start] + f.NewText + origin[end:],
+	}, true
+}
+
+func byteOffsetOfColumn(line string, column int) int {
+	for i := range line {
+		if column == 0 {
+			return i
+		}
+		column--
+	}
+	if column == 0 {
+		return len(line)
+	}
+	return -1
+}
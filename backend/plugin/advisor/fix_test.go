@@ -0,0 +1,29 @@
+package advisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveFixRange(t *testing.T) {
+	a := require.New(t)
+
+	statements := "CREATE INDEX idx_a ON t(a);\nCREATE INDEX idx_a ON t(a);\nALTER TABLE t\n  ADD CONSTRAINT c CHECK (a > 0);"
+	adviceList := []Advice{
+		{Line: 2, Fix: NewFix("Add CONCURRENTLY", "CREATE INDEX idx_a ON t(a);", "CREATE INDEX CONCURRENTLY idx_a ON t(a);")},
+		{Line: 4, Fix: NewFix("Add NOT VALID", "ALTER TABLE t\n  ADD CONSTRAINT c CHECK (a > 0);", "ALTER TABLE t\n  ADD CONSTRAINT c CHECK (a > 0) NOT VALID;")},
+		{Line: 1, Fix: NewFix("Not found", "DROP TABLE t;", "")},
+		{Line: 1, Fix: &Fix{Description: "Unresolved"}},
+	}
+	resolveFixRange(statements, adviceList)
+
+	a.Equal(&Fix{Description: "Add CONCURRENTLY", StartLine: 2, StartColumn: 13, EndLine: 2, EndColumn: 13, NewText: "CONCURRENTLY "}, adviceList[0].Fix)
+	a.Equal(&Fix{Description: "Add NOT VALID", StartLine: 4, StartColumn: 32, EndLine: 4, EndColumn: 32, NewText: " NOT VALID"}, adviceList[1].Fix)
+	a.Nil(adviceList[2].Fix)
+	a.Nil(adviceList[3].Fix)
+
+	fix, ok := adviceList[1].Fix.ExpandToLines(statements)
+	a.True(ok)
+	a.Equal(&Fix{Description: "Add NOT VALID", StartLine: 4, StartColumn: 0, EndLine: 4, EndColumn: 33, NewText: "  ADD CONSTRAINT c CHECK (a > 0) NOT VALID;"}, fix)
+}
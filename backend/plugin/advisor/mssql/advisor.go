@@ -1,6 +1,10 @@
 // Package mssql is the advisor for MSSQL database.
 package mssql
 
+import (
+	"strings"
+)
+
 // currentConstraintAction is the action of current constraint.
 type currentConstraintAction int
 
@@ -9,3 +13,15 @@ const (
 	currentConstraintActionAdd
 	currentConstraintActionDrop
 )
+
+// normalizeColumnType returns the lower case column type without the IDENTITY property and the optional spaces,
+// e.g. "decimal(10,2)" for "DECIMAL(10, 2)". The tsql parser treats "INT IDENTITY(1, 1)" as a data type.
+func normalizeColumnType(tp string) string {
+	tp = strings.ToLower(tp)
+	if i := strings.Index(tp, "identity"); i > 0 {
+		tp = tp[:i]
+	}
+	return columnTypeSpaceReplacer.Replace(strings.Join(strings.Fields(tp), " "))
+}
+
+var columnTypeSpaceReplacer = strings.NewReplacer(" (", "(", "( ", "(", " )", ")", " ,", ",", ", ", ",")
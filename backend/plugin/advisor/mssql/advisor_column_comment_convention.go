@@ -0,0 +1,170 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnCommentConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLColumnCommentConvention, &ColumnCommentConventionAdvisor{})
+}
+
+// ColumnCommentConventionAdvisor is the advisor checking for column comment convention.
+type ColumnCommentConventionAdvisor struct {
+}
+
+// Check checks for column comment convention.
+func (*ColumnCommentConventionAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalCommentConventionRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &columnCommentConventionChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		payload:   payload,
+		commented: make(map[columnReference]bool),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// columnReference is the normalized column name with its table.
+type columnReference struct {
+	table      tableReference
+	columnName string
+}
+
+// createdColumn is the column created by CREATE TABLE or ALTER TABLE ADD.
+type createdColumn struct {
+	column columnReference
+	line   int
+}
+
+// columnCommentConventionChecker is the listener for column comment convention.
+// The column comment is the MS_Description extended property added by sp_addextendedproperty after the column is created.
+type columnCommentConventionChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level   advisor.Status
+	title   string
+	payload *advisor.CommentConventionRulePayload
+	// currentTable is set when entering create_table and alter_table.
+	currentTable *tableReference
+	// createdColumnList is the columns created by the statements in order.
+	createdColumnList []createdColumn
+	// commented is the set of the columns with the description.
+	commented map[columnReference]bool
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *columnCommentConventionChecker) generateAdvice() ([]advisor.Advice, error) {
+	if l.payload.Required {
+		for _, created := range l.createdColumnList {
+			if l.commented[created.column] {
+				continue
+			}
+			l.adviceList = append(l.adviceList, advisor.Advice{
+				Status:  l.level,
+				Code:    advisor.NoColumnComment,
+				Title:   l.title,
+				Content: fmt.Sprintf("Column %s in table %s.%s requires comments", created.column.columnName, created.column.table.schemaName, created.column.table.tableName),
+				Line:    created.line,
+			})
+		}
+	}
+
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *columnCommentConventionChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	l.currentTable = &tableReference{schemaName: schemaName, tableName: tableName}
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *columnCommentConventionChecker) ExitCreate_table(_ *parser.Create_tableContext) {
+	l.currentTable = nil
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *columnCommentConventionChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if ctx.ADD() == nil {
+		return
+	}
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name(0))
+	l.currentTable = &tableReference{schemaName: schemaName, tableName: tableName}
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *columnCommentConventionChecker) ExitAlter_table(_ *parser.Alter_tableContext) {
+	l.currentTable = nil
+}
+
+// EnterColumn_definition is called when production column_definition is entered.
+func (l *columnCommentConventionChecker) EnterColumn_definition(ctx *parser.Column_definitionContext) {
+	if l.currentTable == nil {
+		return
+	}
+	l.createdColumnList = append(l.createdColumnList, createdColumn{
+		column: columnReference{
+			table:      *l.currentTable,
+			columnName: tsqlparser.NormalizeTSQLIdentifier(ctx.Id_()),
+		},
+		line: ctx.GetStart().GetLine(),
+	})
+}
+
+// EnterExecute_body is called when production execute_body is entered.
+func (l *columnCommentConventionChecker) EnterExecute_body(ctx *parser.Execute_bodyContext) {
+	property := extractDescriptionProperty(ctx)
+	if property == nil || property.columnName == "" {
+		return
+	}
+	l.commented[columnReference{table: property.table, columnName: property.columnName}] = true
+	if l.payload.MaxLength >= 0 && utf8.RuneCountInString(property.value) > l.payload.MaxLength {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.ColumnCommentTooLong,
+			Title:   l.title,
+			Content: fmt.Sprintf("The length of column %s in table %s.%s comment should be within %d characters", property.columnName, property.table.schemaName, property.table.tableName, l.payload.MaxLength),
+			Line:    ctx.GetStart().GetLine(),
+		})
+	}
+}
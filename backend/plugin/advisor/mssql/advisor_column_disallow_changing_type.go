@@ -0,0 +1,105 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnDisallowChangingTypeAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLColumnDisallowChangingType, &ColumnDisallowChangingTypeAdvisor{})
+}
+
+// ColumnDisallowChangingTypeAdvisor is the advisor checking for disallow changing column type.
+type ColumnDisallowChangingTypeAdvisor struct {
+}
+
+// Check checks for disallow changing column type.
+func (*ColumnDisallowChangingTypeAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &columnDisallowChangingTypeChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		catalog: ctx.Catalog,
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// columnDisallowChangingTypeChecker is the listener for disallow changing column type.
+// ALTER COLUMN always restates the data type in SQL Server, so it compares the type with the original schema.
+type columnDisallowChangingTypeChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level   advisor.Status
+	title   string
+	catalog *catalog.Finder
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *columnDisallowChangingTypeChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *columnDisallowChangingTypeChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if len(ctx.AllALTER()) != 2 || ctx.COLUMN() == nil || ctx.Column_definition() == nil || ctx.Column_definition().Data_type() == nil {
+		return
+	}
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name(0))
+	definition := ctx.Column_definition()
+	columnName := tsqlparser.NormalizeTSQLIdentifier(definition.Id_())
+	column := l.catalog.Origin.FindColumn(&catalog.ColumnFind{
+		SchemaName: schemaName,
+		TableName:  tableName,
+		ColumnName: columnName,
+	})
+	if column == nil {
+		return
+	}
+	dataType := definition.GetParser().GetTokenStream().GetTextFromRuleContext(definition.Data_type())
+	if normalizeColumnType(column.Type()) == normalizeColumnType(dataType) {
+		return
+	}
+	text := statementText(ctx.GetParser(), ctx)
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.ChangeColumnType,
+		Title:   l.title,
+		Content: fmt.Sprintf("\"%s\" changes the type of column %s in table %s.%s from %s to %s", text, columnName, schemaName, tableName, column.Type(), dataType),
+		Line:    definition.GetStart().GetLine(),
+	})
+}
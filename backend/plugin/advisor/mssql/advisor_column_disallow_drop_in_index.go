@@ -0,0 +1,194 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnDisallowDropInIndexAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLColumnDisallowDropInIndex, &ColumnDisallowDropInIndexAdvisor{})
+}
+
+// ColumnDisallowDropInIndexAdvisor is the advisor checking for disallow DROP COLUMN in index.
+type ColumnDisallowDropInIndexAdvisor struct {
+}
+
+// Check checks for disallow DROP COLUMN in index.
+func (*ColumnDisallowDropInIndexAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &columnDisallowDropInIndexChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		catalog: ctx.Catalog,
+		tables:  make(map[string]map[string]bool),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// columnDisallowDropInIndexChecker is the listener for disallow DROP COLUMN in index.
+type columnDisallowDropInIndexChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level   advisor.Status
+	title   string
+	catalog *catalog.Finder
+	// tables is the map from schema.table to the set of the index columns.
+	tables map[string]map[string]bool
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *columnDisallowDropInIndexChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *columnDisallowDropInIndexChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	key := fmt.Sprintf("%s.%s", schemaName, tableName)
+	// The re-created table does not have the indexes in the original schema.
+	l.tables[key] = make(map[string]bool)
+	l.addColumnsAndConstraints(key, ctx.Column_def_table_constraints())
+	for _, index := range ctx.AllTable_indices() {
+		for _, column := range indexKeyList(index.Column_name_list_with_order()) {
+			l.tables[key][column] = true
+		}
+	}
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *columnDisallowDropInIndexChecker) EnterCreate_index(ctx *parser.Create_indexContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	columns := l.indexColumns(schemaName, tableName)
+	for _, column := range indexKeyList(ctx.Column_name_list_with_order()) {
+		columns[column] = true
+	}
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *columnDisallowDropInIndexChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name(0))
+	if ctx.ADD() != nil && ctx.Column_def_table_constraints() != nil {
+		l.indexColumns(schemaName, tableName)
+		l.addColumnsAndConstraints(fmt.Sprintf("%s.%s", schemaName, tableName), ctx.Column_def_table_constraints())
+		return
+	}
+	if ctx.DROP() == nil || ctx.COLUMN() == nil {
+		return
+	}
+	columns := l.indexColumns(schemaName, tableName)
+	for _, column := range ctx.AllId_() {
+		columnName := tsqlparser.NormalizeTSQLIdentifier(column)
+		if columns[columnName] {
+			l.adviceList = append(l.adviceList, advisor.Advice{
+				Status:  l.level,
+				Code:    advisor.DropIndexColumn,
+				Title:   l.title,
+				Content: fmt.Sprintf("%s.%s.%s cannot drop index column", schemaName, tableName, columnName),
+				Line:    column.GetStart().GetLine(),
+			})
+		}
+	}
+}
+
+func (l *columnDisallowDropInIndexChecker) addColumnsAndConstraints(key string, ctx parser.IColumn_def_table_constraintsContext) {
+	if ctx == nil {
+		return
+	}
+	for _, item := range ctx.AllColumn_def_table_constraint() {
+		switch {
+		case item.Column_definition() != nil:
+			definition := item.Column_definition()
+			for _, element := range definition.AllColumn_definition_element() {
+				constraint := element.Column_constraint()
+				if constraint != nil && (constraint.PRIMARY() != nil || constraint.UNIQUE() != nil) {
+					l.tables[key][tsqlparser.NormalizeTSQLIdentifier(definition.Id_())] = true
+				}
+			}
+		case item.Table_constraint() != nil:
+			constraint := item.Table_constraint()
+			if constraint.PRIMARY() == nil && constraint.UNIQUE() == nil {
+				continue
+			}
+			for _, column := range indexKeyList(constraint.Column_name_list_with_order()) {
+				l.tables[key][column] = true
+			}
+		}
+	}
+}
+
+// indexColumns returns the index columns of the table, loading the indexes in the original schema on first access.
+func (l *columnDisallowDropInIndexChecker) indexColumns(schemaName string, tableName string) map[string]bool {
+	key := fmt.Sprintf("%s.%s", schemaName, tableName)
+	if columns, exists := l.tables[key]; exists {
+		return columns
+	}
+	columns := make(map[string]bool)
+	indexes := l.catalog.Origin.Index(&catalog.TableIndexFind{
+		SchemaName: schemaName,
+		TableName:  tableName,
+	})
+	if indexes != nil {
+		for _, index := range *indexes {
+			for _, column := range index.ExpressionList() {
+				columns[column] = true
+			}
+		}
+	}
+	l.tables[key] = columns
+	return columns
+}
+
+// normalizeSchemaAndTableName returns the normalized schema and table name, the schema falls back to dbo.
+func normalizeSchemaAndTableName(ctx parser.ITable_nameContext) (string, string) {
+	schemaName := tsqlparser.NormalizeTSQLIdentifier(ctx.GetSchema())
+	if schemaName == "" {
+		schemaName = "dbo"
+	}
+	return schemaName, tsqlparser.NormalizeTSQLIdentifier(ctx.GetTable())
+}
+
+func indexKeyList(ctx parser.IColumn_name_list_with_orderContext) []string {
+	if ctx == nil {
+		return nil
+	}
+	var keyList []string
+	for _, column := range ctx.AllId_() {
+		keyList = append(keyList, tsqlparser.NormalizeTSQLIdentifier(column))
+	}
+	return keyList
+}
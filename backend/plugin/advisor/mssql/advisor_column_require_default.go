@@ -0,0 +1,147 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnRequireDefaultAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLRequireColumnDefault, &ColumnRequireDefaultAdvisor{})
+}
+
+// ColumnRequireDefaultAdvisor is the advisor checking for column default requirement.
+type ColumnRequireDefaultAdvisor struct {
+}
+
+// Check checks for column default requirement.
+func (*ColumnRequireDefaultAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &columnRequireDefaultChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// columnRequireDefaultChecker is the listener for column default requirement.
+type columnRequireDefaultChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level advisor.Status
+	title string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *columnRequireDefaultChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *columnRequireDefaultChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	l.checkColumns(schemaName, tableName, ctx.Column_def_table_constraints())
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *columnRequireDefaultChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if ctx.ADD() == nil {
+		return
+	}
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name(0))
+	l.checkColumns(schemaName, tableName, ctx.Column_def_table_constraints())
+}
+
+func (l *columnRequireDefaultChecker) checkColumns(schemaName string, tableName string, ctx parser.IColumn_def_table_constraintsContext) {
+	if ctx == nil {
+		return
+	}
+	// The primary key columns and the columns with a DEFAULT ... FOR table constraint are exempted.
+	exempted := make(map[string]bool)
+	for _, item := range ctx.AllColumn_def_table_constraint() {
+		constraint := item.Table_constraint()
+		if constraint == nil {
+			continue
+		}
+		switch {
+		case constraint.PRIMARY() != nil:
+			for _, column := range indexKeyList(constraint.Column_name_list_with_order()) {
+				exempted[column] = true
+			}
+		case constraint.DEFAULT() != nil && constraint.GetColumn() != nil:
+			exempted[tsqlparser.NormalizeTSQLIdentifier(constraint.GetColumn())] = true
+		}
+	}
+
+	for _, item := range ctx.AllColumn_def_table_constraint() {
+		definition := item.Column_definition()
+		if definition == nil {
+			continue
+		}
+		columnName := tsqlparser.NormalizeTSQLIdentifier(definition.Id_())
+		if exempted[columnName] || !requireDefault(definition) {
+			continue
+		}
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.NoDefault,
+			Title:   l.title,
+			Content: fmt.Sprintf("Column %s in table %s.%s doesn't have DEFAULT.", columnName, schemaName, tableName),
+			Line:    definition.GetStart().GetLine(),
+		})
+	}
+}
+
+// requireDefault returns true if the column needs a DEFAULT, the computed, identity, rowversion and primary key columns do not.
+func requireDefault(ctx parser.IColumn_definitionContext) bool {
+	dataType := ctx.Data_type()
+	if dataType == nil || dataType.IDENTITY() != nil {
+		return false
+	}
+	switch normalizeColumnType(dataType.GetText()) {
+	case "rowversion", "timestamp":
+		return false
+	}
+	for _, element := range ctx.AllColumn_definition_element() {
+		if element.DEFAULT() != nil || element.IDENTITY() != nil {
+			return false
+		}
+		if constraint := element.Column_constraint(); constraint != nil && constraint.PRIMARY() != nil {
+			return false
+		}
+	}
+	return true
+}
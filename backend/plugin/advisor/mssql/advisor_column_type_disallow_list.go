@@ -0,0 +1,130 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnTypeDisallowListAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLColumnTypeDisallowList, &ColumnTypeDisallowListAdvisor{})
+}
+
+// ColumnTypeDisallowListAdvisor is the advisor checking for column type disallow list.
+type ColumnTypeDisallowListAdvisor struct {
+}
+
+// Check checks for column type disallow list.
+func (*ColumnTypeDisallowListAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalStringArrayTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	disallowList := make(map[string]bool)
+	for _, tp := range payload.List {
+		disallowList[normalizeColumnType(tp)] = true
+	}
+
+	listener := &columnTypeDisallowListChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		disallowList: disallowList,
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// columnTypeDisallowListChecker is the listener for column type disallow list.
+type columnTypeDisallowListChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level advisor.Status
+	title string
+	// disallowList is the set of the normalized disallowed types.
+	disallowList map[string]bool
+	// currentSchemaName and currentTableName are set when entering create_table and alter_table.
+	currentSchemaName string
+	currentTableName  string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *columnTypeDisallowListChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *columnTypeDisallowListChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.currentSchemaName, l.currentTableName = normalizeSchemaAndTableName(ctx.Table_name())
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *columnTypeDisallowListChecker) ExitCreate_table(_ *parser.Create_tableContext) {
+	l.currentSchemaName, l.currentTableName = "", ""
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *columnTypeDisallowListChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	l.currentSchemaName, l.currentTableName = normalizeSchemaAndTableName(ctx.Table_name(0))
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *columnTypeDisallowListChecker) ExitAlter_table(_ *parser.Alter_tableContext) {
+	l.currentSchemaName, l.currentTableName = "", ""
+}
+
+// EnterColumn_definition is called when production column_definition is entered.
+func (l *columnTypeDisallowListChecker) EnterColumn_definition(ctx *parser.Column_definitionContext) {
+	if l.currentTableName == "" || ctx.Data_type() == nil {
+		return
+	}
+	dataType := ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx.Data_type())
+	normalizedType := normalizeColumnType(dataType)
+	// The type without the length, precision and scale is also checked, e.g. VARCHAR for VARCHAR(MAX).
+	baseType := normalizedType
+	if i := strings.Index(baseType, "("); i > 0 {
+		baseType = strings.TrimSpace(baseType[:i])
+	}
+	if !l.disallowList[normalizedType] && !l.disallowList[baseType] {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.DisabledColumnType,
+		Title:   l.title,
+		Content: fmt.Sprintf("Disallow column type %s but column %s in table %s.%s is", strings.ToUpper(normalizedType), tsqlparser.NormalizeTSQLIdentifier(ctx.Id_()), l.currentSchemaName, l.currentTableName),
+		Line:    ctx.GetStart().GetLine(),
+	})
+}
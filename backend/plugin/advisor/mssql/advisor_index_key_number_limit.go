@@ -0,0 +1,146 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*IndexKeyNumberLimitAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLIndexKeyNumberLimit, &IndexKeyNumberLimitAdvisor{})
+}
+
+// IndexKeyNumberLimitAdvisor is the advisor checking for index key number limit.
+type IndexKeyNumberLimitAdvisor struct {
+}
+
+// Check checks for index key number limit.
+func (*IndexKeyNumberLimitAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &indexKeyNumberLimitChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+		max:   payload.Number,
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// indexKeyNumberLimitChecker is the listener for index key number limit.
+type indexKeyNumberLimitChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level advisor.Status
+	title string
+	max   int
+	// currentSchemaName and currentTableName are set when entering create_table and alter_table,
+	// the table constraints and indices belong to them.
+	currentSchemaName string
+	currentTableName  string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *indexKeyNumberLimitChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *indexKeyNumberLimitChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.currentSchemaName, l.currentTableName = normalizeSchemaAndTableName(ctx.Table_name())
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *indexKeyNumberLimitChecker) ExitCreate_table(_ *parser.Create_tableContext) {
+	l.currentSchemaName, l.currentTableName = "", ""
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *indexKeyNumberLimitChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	l.currentSchemaName, l.currentTableName = normalizeSchemaAndTableName(ctx.Table_name(0))
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *indexKeyNumberLimitChecker) ExitAlter_table(_ *parser.Alter_tableContext) {
+	l.currentSchemaName, l.currentTableName = "", ""
+}
+
+// EnterTable_constraint is called when production table_constraint is entered.
+func (l *indexKeyNumberLimitChecker) EnterTable_constraint(ctx *parser.Table_constraintContext) {
+	if l.currentTableName == "" || (ctx.PRIMARY() == nil && ctx.UNIQUE() == nil) {
+		return
+	}
+	l.check(l.currentSchemaName, l.currentTableName, &indexDefinition{
+		name:    tsqlparser.NormalizeTSQLIdentifier(ctx.GetConstraint()),
+		columns: indexKeyList(ctx.Column_name_list_with_order()),
+		primary: ctx.PRIMARY() != nil,
+	}, ctx.GetStart().GetLine())
+}
+
+// EnterTable_indices is called when production table_indices is entered.
+func (l *indexKeyNumberLimitChecker) EnterTable_indices(ctx *parser.Table_indicesContext) {
+	if l.currentTableName == "" || ctx.Column_name_list_with_order() == nil {
+		return
+	}
+	l.check(l.currentSchemaName, l.currentTableName, &indexDefinition{
+		name:    tsqlparser.NormalizeTSQLIdentifier(ctx.Id_(0)),
+		columns: indexKeyList(ctx.Column_name_list_with_order()),
+	}, ctx.GetStart().GetLine())
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *indexKeyNumberLimitChecker) EnterCreate_index(ctx *parser.Create_indexContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	l.check(schemaName, tableName, &indexDefinition{
+		name:    tsqlparser.NormalizeTSQLIdentifier(ctx.Id_(0)),
+		columns: indexKeyList(ctx.Column_name_list_with_order()),
+	}, ctx.GetStart().GetLine())
+}
+
+func (l *indexKeyNumberLimitChecker) check(schemaName string, tableName string, index *indexDefinition, line int) {
+	if l.max <= 0 || len(index.columns) <= l.max {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.IndexKeyNumberExceedsLimit,
+		Title:   l.title,
+		Content: fmt.Sprintf("The number of keys of %s in table %s.%s should be not greater than %d, but found %d", index.description(), schemaName, tableName, l.max, len(index.columns)),
+		Line:    line,
+	})
+}
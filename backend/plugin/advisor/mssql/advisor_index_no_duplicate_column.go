@@ -0,0 +1,170 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*IndexNoDuplicateColumnAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLIndexNoDuplicateColumn, &IndexNoDuplicateColumnAdvisor{})
+}
+
+// IndexNoDuplicateColumnAdvisor is the advisor checking for no duplicate columns in index.
+type IndexNoDuplicateColumnAdvisor struct {
+}
+
+// Check checks for no duplicate columns in index.
+func (*IndexNoDuplicateColumnAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &indexNoDuplicateColumnChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// indexNoDuplicateColumnChecker is the listener for no duplicate columns in index.
+type indexNoDuplicateColumnChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level advisor.Status
+	title string
+	// currentSchemaName and currentTableName are set when entering create_table and alter_table,
+	// the table constraints and indices belong to them.
+	currentSchemaName string
+	currentTableName  string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *indexNoDuplicateColumnChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *indexNoDuplicateColumnChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.currentSchemaName, l.currentTableName = normalizeSchemaAndTableName(ctx.Table_name())
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *indexNoDuplicateColumnChecker) ExitCreate_table(_ *parser.Create_tableContext) {
+	l.currentSchemaName, l.currentTableName = "", ""
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *indexNoDuplicateColumnChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	l.currentSchemaName, l.currentTableName = normalizeSchemaAndTableName(ctx.Table_name(0))
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *indexNoDuplicateColumnChecker) ExitAlter_table(_ *parser.Alter_tableContext) {
+	l.currentSchemaName, l.currentTableName = "", ""
+}
+
+// EnterTable_constraint is called when production table_constraint is entered.
+func (l *indexNoDuplicateColumnChecker) EnterTable_constraint(ctx *parser.Table_constraintContext) {
+	if l.currentTableName == "" {
+		return
+	}
+	constraintName := tsqlparser.NormalizeTSQLIdentifier(ctx.GetConstraint())
+	switch {
+	case ctx.PRIMARY() != nil, ctx.UNIQUE() != nil:
+		index := &indexDefinition{
+			name:    constraintName,
+			columns: indexKeyList(ctx.Column_name_list_with_order()),
+			primary: ctx.PRIMARY() != nil,
+		}
+		l.check(l.currentSchemaName, l.currentTableName, index.description(), index.columns, ctx.GetStart().GetLine())
+	case ctx.FOREIGN() != nil && ctx.GetFk() != nil:
+		var columns []string
+		for _, column := range ctx.GetFk().AllId_() {
+			columns = append(columns, tsqlparser.NormalizeTSQLIdentifier(column))
+		}
+		description := "foreign key"
+		if constraintName != "" {
+			description = fmt.Sprintf("foreign key %s", constraintName)
+		}
+		l.check(l.currentSchemaName, l.currentTableName, description, columns, ctx.GetStart().GetLine())
+	}
+}
+
+// EnterTable_indices is called when production table_indices is entered.
+func (l *indexNoDuplicateColumnChecker) EnterTable_indices(ctx *parser.Table_indicesContext) {
+	if l.currentTableName == "" {
+		return
+	}
+	index := &indexDefinition{
+		name: tsqlparser.NormalizeTSQLIdentifier(ctx.Id_(0)),
+	}
+	switch {
+	case ctx.Column_name_list_with_order() != nil:
+		index.columns = indexKeyList(ctx.Column_name_list_with_order())
+	case ctx.Column_name_list() != nil:
+		for _, column := range ctx.Column_name_list().AllId_() {
+			index.columns = append(index.columns, tsqlparser.NormalizeTSQLIdentifier(column))
+		}
+	}
+	l.check(l.currentSchemaName, l.currentTableName, index.description(), index.columns, ctx.GetStart().GetLine())
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *indexNoDuplicateColumnChecker) EnterCreate_index(ctx *parser.Create_indexContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	index := &indexDefinition{
+		name:    tsqlparser.NormalizeTSQLIdentifier(ctx.Id_(0)),
+		columns: indexKeyList(ctx.Column_name_list_with_order()),
+	}
+	l.check(schemaName, tableName, index.description(), index.columns, ctx.GetStart().GetLine())
+}
+
+// check reports the first column which appears more than once in the column list.
+func (l *indexNoDuplicateColumnChecker) check(schemaName string, tableName string, description string, columns []string, line int) {
+	seen := make(map[string]bool)
+	for _, column := range columns {
+		if !seen[column] {
+			seen[column] = true
+			continue
+		}
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.DuplicateColumnInIndex,
+			Title:   l.title,
+			Content: fmt.Sprintf("The %s in table %s.%s has duplicate column %s", description, schemaName, tableName, column),
+			Line:    line,
+		})
+		return
+	}
+}
@@ -0,0 +1,253 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*IndexNoDuplicateIndexAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLIndexNoDuplicateIndex, &IndexNoDuplicateIndexAdvisor{})
+}
+
+// IndexNoDuplicateIndexAdvisor is the advisor checking for no duplicate index.
+type IndexNoDuplicateIndexAdvisor struct {
+}
+
+// Check checks for no duplicate index.
+func (*IndexNoDuplicateIndexAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &indexNoDuplicateIndexChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		catalog: ctx.Catalog,
+		tables:  make(map[string][]*indexDefinition),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// indexDefinition is the index in the original schema or created by the statements.
+type indexDefinition struct {
+	// name is empty for the unnamed constraint.
+	name    string
+	columns []string
+	primary bool
+}
+
+// indexNoDuplicateIndexChecker is the listener for no duplicate index.
+// SQL Server accepts the indexes with the same key columns, which only slow down the writes.
+type indexNoDuplicateIndexChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level   advisor.Status
+	title   string
+	catalog *catalog.Finder
+	// tables is the map from schema.table to the indexes of the table.
+	tables map[string][]*indexDefinition
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *indexNoDuplicateIndexChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *indexNoDuplicateIndexChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	l.tables[fmt.Sprintf("%s.%s", schemaName, tableName)] = nil
+	l.addConstraints(schemaName, tableName, ctx.Column_def_table_constraints())
+	for _, index := range ctx.AllTable_indices() {
+		if index.Column_name_list_with_order() == nil {
+			continue
+		}
+		l.addIndex(schemaName, tableName, &indexDefinition{
+			name:    tsqlparser.NormalizeTSQLIdentifier(index.Id_(0)),
+			columns: indexKeyList(index.Column_name_list_with_order()),
+		}, index.GetStart().GetLine())
+	}
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *indexNoDuplicateIndexChecker) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	for _, table := range ctx.AllTable_name() {
+		schemaName, tableName := normalizeSchemaAndTableName(table)
+		l.tables[fmt.Sprintf("%s.%s", schemaName, tableName)] = nil
+	}
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *indexNoDuplicateIndexChecker) EnterCreate_index(ctx *parser.Create_indexContext) {
+	if ctx.WHERE() != nil {
+		// The filtered index covers a subset of rows, so it is not a duplicate.
+		return
+	}
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	l.addIndex(schemaName, tableName, &indexDefinition{
+		name:    tsqlparser.NormalizeTSQLIdentifier(ctx.Id_(0)),
+		columns: indexKeyList(ctx.Column_name_list_with_order()),
+	}, ctx.GetStart().GetLine())
+}
+
+// EnterDrop_index is called when production drop_index is entered.
+func (l *indexNoDuplicateIndexChecker) EnterDrop_index(ctx *parser.Drop_indexContext) {
+	for _, index := range ctx.AllDrop_relational_or_xml_or_spatial_index() {
+		fullTableName := index.Full_table_name()
+		schemaName := tsqlparser.NormalizeTSQLIdentifier(fullTableName.GetSchema())
+		if schemaName == "" {
+			schemaName = "dbo"
+		}
+		l.dropIndex(schemaName, tsqlparser.NormalizeTSQLIdentifier(fullTableName.GetTable()), tsqlparser.NormalizeTSQLIdentifier(index.GetIndex_name()))
+	}
+	for _, index := range ctx.AllDrop_backward_compatible_index() {
+		schemaName := tsqlparser.NormalizeTSQLIdentifier(index.GetOwner_name())
+		if schemaName == "" {
+			schemaName = "dbo"
+		}
+		l.dropIndex(schemaName, tsqlparser.NormalizeTSQLIdentifier(index.GetTable_or_view_name()), tsqlparser.NormalizeTSQLIdentifier(index.GetIndex_name()))
+	}
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *indexNoDuplicateIndexChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name(0))
+	switch {
+	case ctx.ADD() != nil && ctx.Column_def_table_constraints() != nil:
+		l.addConstraints(schemaName, tableName, ctx.Column_def_table_constraints())
+	case ctx.DROP() != nil && ctx.CONSTRAINT() != nil && ctx.GetConstraint() != nil:
+		l.dropIndex(schemaName, tableName, tsqlparser.NormalizeTSQLIdentifier(ctx.GetConstraint()))
+	}
+}
+
+func (l *indexNoDuplicateIndexChecker) addConstraints(schemaName string, tableName string, ctx parser.IColumn_def_table_constraintsContext) {
+	if ctx == nil {
+		return
+	}
+	for _, item := range ctx.AllColumn_def_table_constraint() {
+		switch {
+		case item.Column_definition() != nil:
+			definition := item.Column_definition()
+			for _, element := range definition.AllColumn_definition_element() {
+				constraint := element.Column_constraint()
+				if constraint == nil || (constraint.PRIMARY() == nil && constraint.UNIQUE() == nil) {
+					continue
+				}
+				l.addIndex(schemaName, tableName, &indexDefinition{
+					name:    tsqlparser.NormalizeTSQLIdentifier(constraint.GetConstraint()),
+					columns: []string{tsqlparser.NormalizeTSQLIdentifier(definition.Id_())},
+					primary: constraint.PRIMARY() != nil,
+				}, constraint.GetStart().GetLine())
+			}
+		case item.Table_constraint() != nil:
+			constraint := item.Table_constraint()
+			if constraint.PRIMARY() == nil && constraint.UNIQUE() == nil {
+				continue
+			}
+			l.addIndex(schemaName, tableName, &indexDefinition{
+				name:    tsqlparser.NormalizeTSQLIdentifier(constraint.GetConstraint()),
+				columns: indexKeyList(constraint.Column_name_list_with_order()),
+				primary: constraint.PRIMARY() != nil,
+			}, constraint.GetStart().GetLine())
+		}
+	}
+}
+
+// addIndex adds the index to the table and reports it if it has the same key columns as an existing index.
+func (l *indexNoDuplicateIndexChecker) addIndex(schemaName string, tableName string, index *indexDefinition, line int) {
+	indexes := l.indexes(schemaName, tableName)
+	for _, existing := range indexes {
+		if !slices.Equal(existing.columns, index.columns) {
+			continue
+		}
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status: l.level,
+			Code:   advisor.DuplicateIndex,
+			Title:  l.title,
+			Content: fmt.Sprintf("%s on table %s.%s duplicates %s with the same columns (%s)",
+				index.description(), schemaName, tableName, existing.description(), strings.Join(index.columns, ", ")),
+			Line: line,
+		})
+		break
+	}
+	l.tables[fmt.Sprintf("%s.%s", schemaName, tableName)] = append(indexes, index)
+}
+
+func (l *indexNoDuplicateIndexChecker) dropIndex(schemaName string, tableName string, indexName string) {
+	l.tables[fmt.Sprintf("%s.%s", schemaName, tableName)] = slices.DeleteFunc(l.indexes(schemaName, tableName), func(index *indexDefinition) bool {
+		return index.name == indexName
+	})
+}
+
+// indexes returns the indexes of the table, loading the indexes in the original schema on first access.
+func (l *indexNoDuplicateIndexChecker) indexes(schemaName string, tableName string) []*indexDefinition {
+	key := fmt.Sprintf("%s.%s", schemaName, tableName)
+	if indexes, exists := l.tables[key]; exists {
+		return indexes
+	}
+	var indexes []*indexDefinition
+	indexMap := l.catalog.Origin.Index(&catalog.TableIndexFind{
+		SchemaName: schemaName,
+		TableName:  tableName,
+	})
+	if indexMap != nil {
+		for name, index := range *indexMap {
+			indexes = append(indexes, &indexDefinition{
+				name:    name,
+				columns: index.ExpressionList(),
+				primary: index.Primary(),
+			})
+		}
+		// Keep the advice stable.
+		slices.SortFunc(indexes, func(a, b *indexDefinition) int {
+			return strings.Compare(a.name, b.name)
+		})
+	}
+	l.tables[key] = indexes
+	return indexes
+}
+
+func (index *indexDefinition) description() string {
+	kind := "index"
+	if index.primary {
+		kind = "primary key"
+	}
+	if index.name == "" {
+		return kind
+	}
+	return fmt.Sprintf("%s %s", kind, index.name)
+}
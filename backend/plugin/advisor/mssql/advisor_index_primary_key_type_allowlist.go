@@ -0,0 +1,164 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*IndexPrimaryKeyTypeAllowlistAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLPrimaryKeyTypeAllowlist, &IndexPrimaryKeyTypeAllowlistAdvisor{})
+}
+
+// IndexPrimaryKeyTypeAllowlistAdvisor is the advisor checking for primary key type allowlist.
+type IndexPrimaryKeyTypeAllowlistAdvisor struct {
+}
+
+// Check checks for primary key type allowlist.
+func (*IndexPrimaryKeyTypeAllowlistAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalStringArrayTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	allowlist := make(map[string]bool)
+	for _, tp := range payload.List {
+		allowlist[strings.ToLower(tp)] = true
+	}
+
+	listener := &indexPrimaryKeyTypeAllowlistChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		allowlist:    allowlist,
+		catalog:      ctx.Catalog,
+		lineForTable: make(map[tableReference]int),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// indexPrimaryKeyTypeAllowlistChecker is the listener for primary key type allowlist.
+// The primary key may be added after the columns are created, so it checks the column types in the final state of the catalog.
+type indexPrimaryKeyTypeAllowlistChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level     advisor.Status
+	title     string
+	allowlist map[string]bool
+	catalog   *catalog.Finder
+	// currentTable is set when entering create_table and alter_table.
+	currentTable *tableReference
+	// lineForTable is the map from the table to the line of the primary key definition.
+	lineForTable map[tableReference]int
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *indexPrimaryKeyTypeAllowlistChecker) generateAdvice() ([]advisor.Advice, error) {
+	var tableList []tableReference
+	for table := range l.lineForTable {
+		tableList = append(tableList, table)
+	}
+	slices.SortFunc(tableList, func(a, b tableReference) int {
+		return l.lineForTable[a] - l.lineForTable[b]
+	})
+
+	for _, table := range tableList {
+		primaryKey := l.catalog.Final.FindPrimaryKey(&catalog.PrimaryKeyFind{
+			SchemaName: table.schemaName,
+			TableName:  table.tableName,
+		})
+		if primaryKey == nil {
+			continue
+		}
+		for _, columnName := range primaryKey.ExpressionList() {
+			column := l.catalog.Final.FindColumn(&catalog.ColumnFind{
+				SchemaName: table.schemaName,
+				TableName:  table.tableName,
+				ColumnName: columnName,
+			})
+			if column == nil || l.allowlist[normalizeColumnType(column.Type())] {
+				continue
+			}
+			l.adviceList = append(l.adviceList, advisor.Advice{
+				Status:  l.level,
+				Code:    advisor.IndexPKType,
+				Title:   l.title,
+				Content: fmt.Sprintf("The column %s in table %s.%s is one of the primary key, but its type \"%s\" is not in allowlist", columnName, table.schemaName, table.tableName, column.Type()),
+				Line:    l.lineForTable[table],
+			})
+		}
+	}
+
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *indexPrimaryKeyTypeAllowlistChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	l.currentTable = &tableReference{schemaName: schemaName, tableName: tableName}
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *indexPrimaryKeyTypeAllowlistChecker) ExitCreate_table(_ *parser.Create_tableContext) {
+	l.currentTable = nil
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *indexPrimaryKeyTypeAllowlistChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name(0))
+	l.currentTable = &tableReference{schemaName: schemaName, tableName: tableName}
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *indexPrimaryKeyTypeAllowlistChecker) ExitAlter_table(_ *parser.Alter_tableContext) {
+	l.currentTable = nil
+}
+
+// EnterColumn_constraint is called when production column_constraint is entered.
+func (l *indexPrimaryKeyTypeAllowlistChecker) EnterColumn_constraint(ctx *parser.Column_constraintContext) {
+	if l.currentTable == nil || ctx.PRIMARY() == nil {
+		return
+	}
+	l.lineForTable[*l.currentTable] = ctx.GetStart().GetLine()
+}
+
+// EnterTable_constraint is called when production table_constraint is entered.
+func (l *indexPrimaryKeyTypeAllowlistChecker) EnterTable_constraint(ctx *parser.Table_constraintContext) {
+	if l.currentTable == nil || ctx.PRIMARY() == nil {
+		return
+	}
+	l.lineForTable[*l.currentTable] = ctx.GetStart().GetLine()
+}
@@ -0,0 +1,136 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*IndexTotalNumberLimitAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLIndexTotalNumberLimit, &IndexTotalNumberLimitAdvisor{})
+}
+
+// IndexTotalNumberLimitAdvisor is the advisor checking for index total number limit.
+type IndexTotalNumberLimitAdvisor struct {
+}
+
+// Check checks for index total number limit.
+func (*IndexTotalNumberLimitAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &indexTotalNumberLimitChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		max:          payload.Number,
+		catalog:      ctx.Catalog,
+		lineForTable: make(map[tableReference]int),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// tableReference is the normalized schema and table name.
+type tableReference struct {
+	schemaName string
+	tableName  string
+}
+
+// indexTotalNumberLimitChecker is the listener for index total number limit.
+// It records the tables which may get new indexes, and counts their indexes in the final state of the catalog.
+type indexTotalNumberLimitChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level   advisor.Status
+	title   string
+	max     int
+	catalog *catalog.Finder
+	// lineForTable is the map from the table to the line of the last statement adding indexes to it.
+	lineForTable map[tableReference]int
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *indexTotalNumberLimitChecker) generateAdvice() ([]advisor.Advice, error) {
+	var tableList []tableReference
+	for table := range l.lineForTable {
+		tableList = append(tableList, table)
+	}
+	slices.SortFunc(tableList, func(a, b tableReference) int {
+		return l.lineForTable[a] - l.lineForTable[b]
+	})
+
+	for _, table := range tableList {
+		tableState := l.catalog.Final.FindTable(&catalog.TableFind{
+			SchemaName: table.schemaName,
+			TableName:  table.tableName,
+		})
+		if tableState == nil || l.max <= 0 || tableState.CountIndex() <= l.max {
+			continue
+		}
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.IndexCountExceedsLimit,
+			Title:   l.title,
+			Content: fmt.Sprintf("The count of index in table %s.%s should be no more than %d, but found %d", table.schemaName, table.tableName, l.max, tableState.CountIndex()),
+			Line:    l.lineForTable[table],
+		})
+	}
+
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *indexTotalNumberLimitChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	l.lineForTable[tableReference{schemaName: schemaName, tableName: tableName}] = ctx.GetStart().GetLine()
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *indexTotalNumberLimitChecker) EnterCreate_index(ctx *parser.Create_indexContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	l.lineForTable[tableReference{schemaName: schemaName, tableName: tableName}] = ctx.GetStart().GetLine()
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *indexTotalNumberLimitChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if ctx.ADD() == nil || ctx.Column_def_table_constraints() == nil {
+		return
+	}
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name(0))
+	l.lineForTable[tableReference{schemaName: schemaName, tableName: tableName}] = ctx.GetStart().GetLine()
+}
@@ -0,0 +1,89 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*InsertMustSpecifyColumnAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLInsertMustSpecifyColumn, &InsertMustSpecifyColumnAdvisor{})
+}
+
+// InsertMustSpecifyColumnAdvisor is the advisor checking for INSERT must specify column.
+type InsertMustSpecifyColumnAdvisor struct {
+}
+
+// Check checks for INSERT must specify column.
+func (*InsertMustSpecifyColumnAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &insertMustSpecifyColumnChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// insertMustSpecifyColumnChecker is the listener for INSERT must specify column.
+type insertMustSpecifyColumnChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level advisor.Status
+	title string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *insertMustSpecifyColumnChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterInsert_statement is called when production insert_statement is entered.
+func (l *insertMustSpecifyColumnChecker) EnterInsert_statement(ctx *parser.Insert_statementContext) {
+	if ctx.Insert_column_name_list() != nil {
+		return
+	}
+	// INSERT ... DEFAULT VALUES has no value to match the columns.
+	if ctx.Insert_statement_value() != nil && ctx.Insert_statement_value().DEFAULT() != nil {
+		return
+	}
+	text := statementText(ctx.GetParser(), ctx)
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.InsertNotSpecifyColumn,
+		Title:   l.title,
+		Content: fmt.Sprintf("The INSERT statement must specify columns but \"%s\" does not", text),
+		Line:    ctx.GetStart().GetLine(),
+	})
+}
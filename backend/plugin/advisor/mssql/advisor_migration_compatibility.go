@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
 	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -42,6 +43,7 @@ func (*MigrationCompatibilityAdvisor) Check(ctx advisor.Context, _ string) ([]ad
 		level:                              level,
 		title:                              string(ctx.Rule.Type),
 		currentDatabase:                    ctx.CurrentDatabase,
+		catalog:                            ctx.Catalog,
 		normalizedNewCreateTableNameMap:    make(map[string]any),
 		normalizedNewCreateSchemaNameMap:   make(map[string]any),
 		normalizedNewCreateDatabaseNameMap: make(map[string]any),
@@ -68,6 +70,8 @@ type migrationCompatibilityChecker struct {
 
 	// currentDatabase is the current database name.
 	currentDatabase string
+	// catalog is used to check whether the objects exist in the original schema.
+	catalog *catalog.Finder
 
 	adviceList []advisor.Advice
 }
@@ -118,7 +122,7 @@ func (l *migrationCompatibilityChecker) EnterDrop_table(ctx *parser.Drop_tableCo
 			continue
 		}
 		normalizedTableName := tsqlparser.NormalizeTSQLTableName(tableName, l.currentDatabase, "dbo", false)
-		if _, ok := l.normalizedNewCreateTableNameMap[normalizedTableName]; !ok {
+		if l.originTable(tableName) != nil {
 			l.adviceList = append(l.adviceList, advisor.Advice{
 				Status:  l.level,
 				Code:    advisor.CompatibilityDropSchema,
@@ -162,9 +166,8 @@ func (l *migrationCompatibilityChecker) EnterDrop_database(ctx *parser.Drop_data
 }
 
 func (l *migrationCompatibilityChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
-	handleTableName := ctx.Table_name(0)
-	normalizedHandleTableName := tsqlparser.NormalizeTSQLTableName(handleTableName, l.currentDatabase, "dbo", false)
-	if _, ok := l.normalizedNewCreateTableNameMap[normalizedHandleTableName]; ok {
+	table := l.originTable(ctx.Table_name(0))
+	if table == nil {
 		return
 	}
 
@@ -192,6 +195,18 @@ func (l *migrationCompatibilityChecker) EnterAlter_table(ctx *parser.Alter_table
 		} else if ctx.Column_modifier() != nil {
 			normalizedColumnName = tsqlparser.NormalizeTSQLIdentifier(ctx.Column_modifier().Id_())
 		}
+		schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name(0))
+		column := l.catalog.Origin.FindColumn(&catalog.ColumnFind{
+			SchemaName: schemaName,
+			TableName:  tableName,
+			ColumnName: normalizedColumnName,
+		})
+		if column == nil {
+			return
+		}
+		if ctx.Column_definition() != nil && !columnChanged(column, ctx.Column_definition()) {
+			return
+		}
 
 		l.adviceList = append(l.adviceList, advisor.Advice{
 			Status:  l.level,
@@ -205,6 +220,16 @@ func (l *migrationCompatibilityChecker) EnterAlter_table(ctx *parser.Alter_table
 	if v := ctx.Column_def_table_constraints(); v != nil {
 		allColumnDefTableConstraints := v.AllColumn_def_table_constraint()
 		for _, columnDefTableConstraint := range allColumnDefTableConstraints {
+			if definition := columnDefTableConstraint.Column_definition(); definition != nil && table.RowCount() > 0 && isNotNullWithoutDefault(definition) {
+				l.adviceList = append(l.adviceList, advisor.Advice{
+					Status:  l.level,
+					Code:    advisor.CompatibilityAddNotNullColumn,
+					Title:   l.title,
+					Content: fmt.Sprintf("Add NOT NULL column %s without default to the table with data may cause incompatibility with the existing data and code", tsqlparser.NormalizeTSQLIdentifier(definition.Id_())),
+					Line:    definition.GetStart().GetLine(),
+				})
+				continue
+			}
 			code := advisor.Ok
 			operation := ""
 			tableConstraint := columnDefTableConstraint.Table_constraint()
@@ -257,6 +282,63 @@ func (l *migrationCompatibilityChecker) EnterAlter_table(ctx *parser.Alter_table
 	}
 }
 
+// originTable returns the table in the original schema, it returns nil if the table is created in the statements.
+func (l *migrationCompatibilityChecker) originTable(ctx parser.ITable_nameContext) *catalog.TableState {
+	if ctx == nil || ctx.GetTable() == nil {
+		return nil
+	}
+	if _, ok := l.normalizedNewCreateTableNameMap[tsqlparser.NormalizeTSQLTableName(ctx, l.currentDatabase, "dbo", false)]; ok {
+		return nil
+	}
+	// The tables in other databases are not in the catalog.
+	if database := tsqlparser.NormalizeTSQLIdentifier(ctx.GetDatabase()); database != "" && !strings.EqualFold(database, l.currentDatabase) {
+		return nil
+	}
+	schemaName, tableName := normalizeSchemaAndTableName(ctx)
+	return l.catalog.Origin.FindTable(&catalog.TableFind{
+		SchemaName: schemaName,
+		TableName:  tableName,
+	})
+}
+
+// columnChanged returns true if ALTER COLUMN changes the type, or disallows NULL for the nullable column.
+func columnChanged(column *catalog.ColumnState, ctx parser.IColumn_definitionContext) bool {
+	if ctx.Data_type() != nil {
+		newType := ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx.Data_type())
+		if normalizeColumnType(newType) != normalizeColumnType(column.Type()) {
+			return true
+		}
+	}
+	if !column.Nullable() {
+		return false
+	}
+	for _, element := range ctx.AllColumn_definition_element() {
+		if constraint := element.Column_constraint(); constraint != nil && constraint.Null_notnull() != nil && constraint.Null_notnull().NOT() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isNotNullWithoutDefault returns true if the column is NOT NULL without the default value,
+// which fails for the tables with rows.
+func isNotNullWithoutDefault(ctx parser.IColumn_definitionContext) bool {
+	notNull := false
+	for _, element := range ctx.AllColumn_definition_element() {
+		if element.DEFAULT() != nil {
+			return false
+		}
+		constraint := element.Column_constraint()
+		if constraint == nil {
+			continue
+		}
+		if (constraint.Null_notnull() != nil && constraint.Null_notnull().NOT() != nil) || constraint.PRIMARY() != nil {
+			notNull = true
+		}
+	}
+	return notNull
+}
+
 // EnterExecute_body is called when production execute_body is entered.
 func (l *migrationCompatibilityChecker) EnterExecute_body(ctx *parser.Execute_bodyContext) {
 	if ctx.Func_proc_name_server_database_schema() == nil {
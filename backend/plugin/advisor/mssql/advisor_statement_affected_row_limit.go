@@ -0,0 +1,117 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementAffectedRowLimitAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLStatementAffectedRowLimit, &StatementAffectedRowLimitAdvisor{})
+}
+
+// StatementAffectedRowLimitAdvisor is the advisor checking for UPDATE/DELETE affected row limit.
+type StatementAffectedRowLimitAdvisor struct {
+}
+
+// Check checks for UPDATE/DELETE affected row limit.
+func (*StatementAffectedRowLimitAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &statementAffectedRowLimitChecker{
+		level:  level,
+		title:  string(ctx.Rule.Type),
+		maxRow: payload.Number,
+		driver: ctx.Driver,
+		ctx:    ctx.Context,
+	}
+
+	if listener.driver != nil {
+		antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	}
+
+	return listener.generateAdvice()
+}
+
+// statementAffectedRowLimitChecker is the listener for UPDATE/DELETE affected row limit.
+type statementAffectedRowLimitChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level  advisor.Status
+	title  string
+	maxRow int
+	driver *sql.DB
+	ctx    context.Context
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *statementAffectedRowLimitChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterUpdate_statement is called when production update_statement is entered.
+func (l *statementAffectedRowLimitChecker) EnterUpdate_statement(ctx *parser.Update_statementContext) {
+	l.check(statementText(ctx.GetParser(), ctx), ctx.GetStart().GetLine())
+}
+
+// EnterDelete_statement is called when production delete_statement is entered.
+func (l *statementAffectedRowLimitChecker) EnterDelete_statement(ctx *parser.Delete_statementContext) {
+	l.check(statementText(ctx.GetParser(), ctx), ctx.GetStart().GetLine())
+}
+
+func (l *statementAffectedRowLimitChecker) check(text string, line int) {
+	rowCount, err := estimateRows(l.ctx, l.driver, text)
+	if err != nil {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.StatementAffectedRowExceedsLimit,
+			Title:   l.title,
+			Content: fmt.Sprintf("\"%s\" dry runs failed: %s", text, err.Error()),
+			Line:    line,
+		})
+		return
+	}
+	if l.maxRow > 0 && rowCount > int64(l.maxRow) {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.StatementAffectedRowExceedsLimit,
+			Title:   l.title,
+			Content: fmt.Sprintf("\"%s\" affected %d rows. The count exceeds %d.", text, rowCount, l.maxRow),
+			Line:    line,
+		})
+	}
+}
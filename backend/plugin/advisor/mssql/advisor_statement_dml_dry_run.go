@@ -0,0 +1,185 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDMLDryRunAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLStatementDMLDryRun, &StatementDMLDryRunAdvisor{})
+}
+
+// StatementDMLDryRunAdvisor is the advisor checking for DML dry run.
+type StatementDMLDryRunAdvisor struct {
+}
+
+// Check checks for DML dry run.
+func (*StatementDMLDryRunAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &statementDMLDryRunChecker{
+		level:  level,
+		title:  string(ctx.Rule.Type),
+		driver: ctx.Driver,
+		ctx:    ctx.Context,
+	}
+
+	if listener.driver != nil {
+		antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	}
+
+	return listener.generateAdvice()
+}
+
+// statementDMLDryRunChecker is the listener for DML dry run.
+type statementDMLDryRunChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level  advisor.Status
+	title  string
+	driver *sql.DB
+	ctx    context.Context
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *statementDMLDryRunChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterInsert_statement is called when production insert_statement is entered.
+func (l *statementDMLDryRunChecker) EnterInsert_statement(ctx *parser.Insert_statementContext) {
+	l.dryRun(statementText(ctx.GetParser(), ctx), ctx.GetStart().GetLine())
+}
+
+// EnterUpdate_statement is called when production update_statement is entered.
+func (l *statementDMLDryRunChecker) EnterUpdate_statement(ctx *parser.Update_statementContext) {
+	l.dryRun(statementText(ctx.GetParser(), ctx), ctx.GetStart().GetLine())
+}
+
+// EnterDelete_statement is called when production delete_statement is entered.
+func (l *statementDMLDryRunChecker) EnterDelete_statement(ctx *parser.Delete_statementContext) {
+	l.dryRun(statementText(ctx.GetParser(), ctx), ctx.GetStart().GetLine())
+}
+
+func (l *statementDMLDryRunChecker) dryRun(text string, line int) {
+	if _, err := estimateRows(l.ctx, l.driver, text); err != nil {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.StatementDMLDryRunFailed,
+			Title:   l.title,
+			Content: fmt.Sprintf("\"%s\" dry runs failed: %s", text, err.Error()),
+			Line:    line,
+		})
+	}
+}
+
+// statementText returns the text of the statement without the trailing semicolon.
+func statementText(p antlr.Parser, ctx antlr.RuleContext) string {
+	return strings.TrimRight(p.GetTokenStream().GetTextFromRuleContext(ctx), " \t\r\n;")
+}
+
+// estimateRows compiles the statement with SHOWPLAN_ALL, which returns the estimated plan instead of executing the statement.
+// It returns the EstimateRows of the first row, which is the root operator of the statement.
+//
+// SHOWPLAN_ALL is a session option, so it must be turned on and off on the same connection.
+func estimateRows(ctx context.Context, db *sql.DB, statement string) (int64, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SET SHOWPLAN_ALL ON"); err != nil {
+		return 0, err
+	}
+	defer func() {
+		// Turn the option off even if the context is canceled, the connection goes back to the pool.
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), "SET SHOWPLAN_ALL OFF"); err != nil {
+			// Discard the connection, otherwise the next statement on it would be compiled instead of executed.
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+
+	rows, err := conn.QueryContext(ctx, statement)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	index := slices.Index(columns, "EstimateRows")
+	if index < 0 {
+		return 0, errors.Errorf("column EstimateRows not found in the plan")
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, errors.Errorf("not found any plan")
+	}
+	values := make([]any, len(columns))
+	scanArgs := make([]any, len(columns))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	if err := rows.Scan(scanArgs...); err != nil {
+		return 0, err
+	}
+
+	switch v := values[index].(type) {
+	case float64:
+		return int64(v), nil
+	case float32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case []byte:
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse EstimateRows %q", string(v))
+		}
+		return int64(f), nil
+	case nil:
+		return 0, nil
+	default:
+		return 0, errors.Errorf("unexpected EstimateRows type %T", v)
+	}
+}
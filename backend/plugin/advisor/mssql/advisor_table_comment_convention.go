@@ -0,0 +1,224 @@
+// Package mssql is the advisor for MSSQL database.
+package mssql
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*TableCommentConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_MSSQL, advisor.MSSQLTableCommentConvention, &TableCommentConventionAdvisor{})
+}
+
+// TableCommentConventionAdvisor is the advisor checking for table comment convention.
+type TableCommentConventionAdvisor struct {
+}
+
+// Check checks for table comment convention.
+func (*TableCommentConventionAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalCommentConventionRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &tableCommentConventionChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		payload:      payload,
+		lineForTable: make(map[tableReference]int),
+		commented:    make(map[tableReference]bool),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// tableCommentConventionChecker is the listener for table comment convention.
+// SQL Server has no COMMENT clause, the table comment is the MS_Description extended property
+// added by sp_addextendedproperty after the table is created.
+type tableCommentConventionChecker struct {
+	*parser.BaseTSqlParserListener
+
+	level   advisor.Status
+	title   string
+	payload *advisor.CommentConventionRulePayload
+	// lineForTable is the map from the created table to the line of the CREATE TABLE statement.
+	lineForTable map[tableReference]int
+	// commented is the set of the tables with the description.
+	commented map[tableReference]bool
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *tableCommentConventionChecker) generateAdvice() ([]advisor.Advice, error) {
+	if l.payload.Required {
+		var tableList []tableReference
+		for table := range l.lineForTable {
+			tableList = append(tableList, table)
+		}
+		slices.SortFunc(tableList, func(a, b tableReference) int {
+			return l.lineForTable[a] - l.lineForTable[b]
+		})
+		for _, table := range tableList {
+			if l.commented[table] {
+				continue
+			}
+			l.adviceList = append(l.adviceList, advisor.Advice{
+				Status:  l.level,
+				Code:    advisor.NoTableComment,
+				Title:   l.title,
+				Content: fmt.Sprintf("Table %s.%s requires comments", table.schemaName, table.tableName),
+				Line:    l.lineForTable[table],
+			})
+		}
+	}
+
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *tableCommentConventionChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	schemaName, tableName := normalizeSchemaAndTableName(ctx.Table_name())
+	l.lineForTable[tableReference{schemaName: schemaName, tableName: tableName}] = ctx.GetStart().GetLine()
+}
+
+// EnterExecute_body is called when production execute_body is entered.
+func (l *tableCommentConventionChecker) EnterExecute_body(ctx *parser.Execute_bodyContext) {
+	property := extractDescriptionProperty(ctx)
+	if property == nil || property.columnName != "" {
+		return
+	}
+	l.commented[property.table] = true
+	if l.payload.MaxLength >= 0 && utf8.RuneCountInString(property.value) > l.payload.MaxLength {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.TableCommentTooLong,
+			Title:   l.title,
+			Content: fmt.Sprintf("The length of table %s.%s comment should be within %d characters", property.table.schemaName, property.table.tableName, l.payload.MaxLength),
+			Line:    ctx.GetStart().GetLine(),
+		})
+	}
+}
+
+// descriptionProperty is the MS_Description extended property, which is the comment of the table or column in SQL Server.
+type descriptionProperty struct {
+	table tableReference
+	// columnName is empty for the table description.
+	columnName string
+	value      string
+}
+
+// extendedPropertyParameters is the parameter list of sp_addextendedproperty and sp_updateextendedproperty.
+var extendedPropertyParameters = []string{"name", "value", "level0type", "level0name", "level1type", "level1name", "level2type", "level2name"}
+
+// extractDescriptionProperty returns the table or column description set by the EXECUTE statement, it returns nil for the other statements.
+func extractDescriptionProperty(ctx parser.IExecute_bodyContext) *descriptionProperty {
+	procedureName := ctx.Func_proc_name_server_database_schema()
+	if procedureName == nil || procedureName.Func_proc_name_database_schema() == nil || procedureName.Func_proc_name_database_schema().Func_proc_name_schema() == nil {
+		return nil
+	}
+	switch tsqlparser.NormalizeTSQLIdentifier(procedureName.Func_proc_name_database_schema().Func_proc_name_schema().GetProcedure()) {
+	case "sp_addextendedproperty", "sp_updateextendedproperty":
+	default:
+		return nil
+	}
+
+	arguments := make(map[string]string)
+	collectExtendedPropertyArguments(ctx.Execute_statement_arg(), 0, arguments)
+	if !strings.EqualFold(arguments["name"], "MS_Description") ||
+		!strings.EqualFold(arguments["level0type"], "SCHEMA") ||
+		!strings.EqualFold(arguments["level1type"], "TABLE") {
+		return nil
+	}
+	property := &descriptionProperty{
+		table: tableReference{
+			schemaName: strings.ToLower(arguments["level0name"]),
+			tableName:  strings.ToLower(arguments["level1name"]),
+		},
+		value: arguments["value"],
+	}
+	switch {
+	case arguments["level2type"] == "":
+	case strings.EqualFold(arguments["level2type"], "COLUMN"):
+		property.columnName = strings.ToLower(arguments["level2name"])
+	default:
+		// The description of the constraint, index or trigger.
+		return nil
+	}
+	return property
+}
+
+// collectExtendedPropertyArguments collects the unnamed arguments by position and the named arguments by name.
+func collectExtendedPropertyArguments(ctx parser.IExecute_statement_argContext, position int, arguments map[string]string) {
+	if ctx == nil {
+		return
+	}
+	if unnamed := ctx.Execute_statement_arg_unnamed(); unnamed != nil {
+		if position < len(extendedPropertyParameters) {
+			arguments[extendedPropertyParameters[position]] = executeParameterValue(unnamed.GetValue())
+		}
+		for _, arg := range ctx.AllExecute_statement_arg() {
+			position++
+			collectExtendedPropertyArguments(arg, position, arguments)
+		}
+		return
+	}
+	for _, named := range ctx.AllExecute_statement_arg_named() {
+		name := strings.ToLower(strings.TrimPrefix(named.GetName().GetText(), "@"))
+		arguments[name] = executeParameterValue(named.GetValue())
+	}
+}
+
+// executeParameterValue returns the value of the string constant or identifier, e.g. abc for N'abc'.
+func executeParameterValue(ctx parser.IExecute_parameterContext) string {
+	if ctx == nil {
+		return ""
+	}
+	if ctx.Constant() != nil && ctx.Constant().STRING() != nil {
+		text := ctx.Constant().STRING().GetText()
+		if len(text) > 0 && (text[0] == 'N' || text[0] == 'n') {
+			text = text[1:]
+		}
+		if len(text) >= 2 && text[0] == '\'' && text[len(text)-1] == '\'' {
+			text = strings.ReplaceAll(text[1:len(text)-1], "''", "'")
+		}
+		return text
+	}
+	if ctx.Id_() != nil {
+		return tsqlparser.NormalizeTSQLIdentifier(ctx.Id_())
+	}
+	return ""
+}
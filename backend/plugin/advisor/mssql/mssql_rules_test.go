@@ -22,9 +22,30 @@ func TestMSSQLRules(t *testing.T) {
 		advisor.SchemaRuleTableNoFK,
 		advisor.SchemaRuleSchemaBackwardCompatibility,
 		advisor.SchemaRuleRequiredColumn,
+		advisor.SchemaRuleColumnDisallowDropInIndex,
+		advisor.SchemaRuleIndexNoDuplicateIndex,
+		advisor.SchemaRuleIndexKeyNumberLimit,
+		advisor.SchemaRuleIndexTotalNumberLimit,
+		advisor.SchemaRuleIndexNoDuplicateColumn,
+		advisor.SchemaRuleIndexPrimaryKeyTypeAllowlist,
+		advisor.SchemaRuleColumnRequireDefault,
+		advisor.SchemaRuleColumnTypeDisallowList,
+		advisor.SchemaRuleColumnCommentConvention,
+		advisor.SchemaRuleColumnDisallowChangeType,
+		advisor.SchemaRuleStatementInsertMustSpecifyColumn,
+		advisor.SchemaRuleTableCommentConvention,
 	}
 
 	for _, rule := range snowflakeRules {
 		advisor.RunSQLReviewRuleTest(t, rule, storepb.Engine_MSSQL, false /* record */)
 	}
 }
+
+func TestMSSQLDriverRules(t *testing.T) {
+	// The affected row limit and DML dry run rules query the database, they share the test cases on the mock database.
+	driverRules := []advisor.SQLReviewRuleType{
+		advisor.SchemaRuleStatementAffectedRowLimit,
+		advisor.SchemaRuleStatementDMLDryRun,
+	}
+	advisor.RunSQLReviewRuleDriverTest(t, "statement_dml_driver", driverRules, storepb.Engine_MSSQL, false /* record */)
+}
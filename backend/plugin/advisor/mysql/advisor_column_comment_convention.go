@@ -99,7 +99,7 @@ func (checker *columnCommentConventionChecker) EnterCreateTable(ctx *mysql.Creat
 		if tableElement.ColumnDefinition().FieldDefinition() == nil {
 			continue
 		}
-		checker.checkFieldDefinition(tableName, columnName, tableElement.ColumnDefinition().FieldDefinition())
+		checker.checkFieldDefinition(ctx, tableName, columnName, tableElement.ColumnDefinition().FieldDefinition())
 	}
 }
 
@@ -128,29 +128,30 @@ func (checker *columnCommentConventionChecker) EnterAlterTable(ctx *mysql.AlterT
 			switch {
 			case item.Identifier() != nil && item.FieldDefinition() != nil:
 				columnName := mysqlparser.NormalizeMySQLIdentifier(item.Identifier())
-				checker.checkFieldDefinition(tableName, columnName, item.FieldDefinition())
+				checker.checkFieldDefinition(ctx, tableName, columnName, item.FieldDefinition())
 			case item.OPEN_PAR_SYMBOL() != nil && item.TableElementList() != nil:
 				for _, tableElement := range item.TableElementList().AllTableElement() {
 					if tableElement.ColumnDefinition() == nil || tableElement.ColumnDefinition().ColumnName() == nil || tableElement.ColumnDefinition().FieldDefinition() == nil {
 						continue
 					}
 					_, _, columnName := mysqlparser.NormalizeMySQLColumnName(tableElement.ColumnDefinition().ColumnName())
-					checker.checkFieldDefinition(tableName, columnName, tableElement.ColumnDefinition().FieldDefinition())
+					checker.checkFieldDefinition(ctx, tableName, columnName, tableElement.ColumnDefinition().FieldDefinition())
 				}
 			}
 		// modify column
 		case item.MODIFY_SYMBOL() != nil && item.ColumnInternalRef() != nil && item.FieldDefinition() != nil:
 			columnName = mysqlparser.NormalizeMySQLColumnInternalRef(item.ColumnInternalRef())
-			checker.checkFieldDefinition(tableName, columnName, item.FieldDefinition())
+			checker.checkFieldDefinition(ctx, tableName, columnName, item.FieldDefinition())
 		// change column
 		case item.CHANGE_SYMBOL() != nil && item.ColumnInternalRef() != nil && item.Identifier() != nil && item.FieldDefinition() != nil:
 			columnName = mysqlparser.NormalizeMySQLIdentifier(item.Identifier())
-			checker.checkFieldDefinition(tableName, columnName, item.FieldDefinition())
+			checker.checkFieldDefinition(ctx, tableName, columnName, item.FieldDefinition())
 		}
 	}
 }
 
-func (checker *columnCommentConventionChecker) checkFieldDefinition(tableName, columnName string, ctx mysql.IFieldDefinitionContext) {
+// checkFieldDefinition checks the comment of the column definition in the statement.
+func (checker *columnCommentConventionChecker) checkFieldDefinition(stmt antlr.ParserRuleContext, tableName, columnName string, ctx mysql.IFieldDefinitionContext) {
 	comment := ""
 	for _, attribute := range ctx.AllColumnAttribute() {
 		if attribute == nil || attribute.GetValue() == nil {
@@ -181,6 +182,7 @@ func (checker *columnCommentConventionChecker) checkFieldDefinition(tableName, c
 				Title:   checker.title,
 				Content: fmt.Sprintf("Column `%s`.`%s` requires comments", tableName, columnName),
 				Line:    checker.baseLine + ctx.GetStart().GetLine(),
+				Fix:     newCommentFix(ctx.GetParser().GetTokenStream(), stmt, ctx.GetStop(), columnName, checker.maxLength),
 			})
 		}
 	}
@@ -55,6 +55,7 @@ func (*ColumnRequirementAdvisor) Check(ctx advisor.Context, _ string) ([]advisor
 		requiredColumns: requiredColumns,
 		tables:          make(tableState),
 		line:            make(map[string]int),
+		createTables:    make(map[string]*mysql.CreateTableContext),
 	}
 
 	for _, stmt := range list {
@@ -75,6 +76,8 @@ type columnRequirementChecker struct {
 	requiredColumns columnSet
 	tables          tableState
 	line            map[string]int
+	// createTables are the CREATE TABLE statements which the advice is reported on and the missing columns can be added to.
+	createTables map[string]*mysql.CreateTableContext
 }
 
 // EnterCreateDatabase is called when production createDatabase is entered.
@@ -91,6 +94,7 @@ func (checker *columnRequirementChecker) EnterDropTable(ctx *mysql.DropTableCont
 	for _, tableRef := range ctx.TableRefList().AllTableRef() {
 		_, tableName := mysqlparser.NormalizeMySQLTableRef(tableRef)
 		delete(checker.tables, tableName)
+		delete(checker.createTables, tableName)
 	}
 }
 
@@ -135,6 +139,7 @@ func (checker *columnRequirementChecker) EnterAlterTable(ctx *mysql.AlterTableCo
 			columnName := mysqlparser.NormalizeMySQLColumnInternalRef(item.ColumnInternalRef())
 			if checker.dropColumn(tableName, columnName) {
 				checker.line[tableName] = lineNumber
+				delete(checker.createTables, tableName)
 			}
 		// rename column
 		case item.RENAME_SYMBOL() != nil && item.COLUMN_SYMBOL() != nil:
@@ -142,12 +147,14 @@ func (checker *columnRequirementChecker) EnterAlterTable(ctx *mysql.AlterTableCo
 			newColumnName := mysqlparser.NormalizeMySQLIdentifier(item.Identifier())
 			checker.renameColumn(tableName, oldColumnName, newColumnName)
 			checker.line[tableName] = lineNumber
+			delete(checker.createTables, tableName)
 		// change column
 		case item.CHANGE_SYMBOL() != nil && item.ColumnInternalRef() != nil && item.Identifier() != nil:
 			oldColumnName := mysqlparser.NormalizeMySQLColumnInternalRef(item.ColumnInternalRef())
 			newColumnName := mysqlparser.NormalizeMySQLIdentifier(item.Identifier())
 			if checker.renameColumn(tableName, oldColumnName, newColumnName) {
 				checker.line[tableName] = lineNumber
+				delete(checker.createTables, tableName)
 			}
 		}
 	}
@@ -174,6 +181,7 @@ func (checker *columnRequirementChecker) generateAdviceList() []advisor.Advice {
 				Title:   checker.title,
 				Content: fmt.Sprintf("Table `%s` requires columns: %s", tableName, strings.Join(missingColumns, ", ")),
 				Line:    checker.line[tableName],
+				Fix:     checker.newRequiredColumnsFix(tableName, missingColumns),
 			})
 		}
 	}
@@ -193,10 +201,12 @@ func (checker *columnRequirementChecker) createTable(ctx *mysql.CreateTableConte
 	_, tableName := mysqlparser.NormalizeMySQLTableName(ctx.TableName())
 	checker.line[tableName] = checker.baseLine + ctx.GetStart().GetLine()
 	checker.initEmptyTable(tableName)
+	delete(checker.createTables, tableName)
 
 	if ctx.TableElementList() == nil {
 		return
 	}
+	checker.createTables[tableName] = ctx
 
 	for _, tableElement := range ctx.TableElementList().AllTableElement() {
 		if tableElement.ColumnDefinition() == nil {
@@ -207,6 +217,19 @@ func (checker *columnRequirementChecker) createTable(ctx *mysql.CreateTableConte
 	}
 }
 
+// newRequiredColumnsFix returns the fix adding the missing columns to the CREATE TABLE statement.
+func (checker *columnRequirementChecker) newRequiredColumnsFix(tableName string, missingColumns []string) *advisor.Fix {
+	ctx, ok := checker.createTables[tableName]
+	if !ok {
+		return nil
+	}
+	var buf strings.Builder
+	for _, column := range missingColumns {
+		_, _ = fmt.Fprintf(&buf, ", `%s` %s", column, getRequiredColumnType(column))
+	}
+	return newInsertFix("Add required columns", ctx.GetParser().GetTokenStream(), ctx, ctx.TableElementList().GetStop(), buf.String())
+}
+
 func (checker *columnRequirementChecker) initEmptyTable(tableName string) columnSet {
 	checker.tables[tableName] = make(columnSet)
 	return checker.tables[tableName]
@@ -5,6 +5,7 @@ package mysql
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/antlr4-go/antlr/v4"
 	"github.com/pkg/errors"
@@ -31,7 +32,7 @@ type StatementMergeAlterTableAdvisor struct {
 }
 
 // Check checks for merging ALTER TABLE statements.
-func (*StatementMergeAlterTableAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+func (*StatementMergeAlterTableAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
 	stmtList, ok := ctx.AST.([]*mysqlparser.ParseResult)
 	if !ok {
 		return nil, errors.Errorf("failed to convert to mysql parse result")
@@ -42,13 +43,15 @@ func (*StatementMergeAlterTableAdvisor) Check(ctx advisor.Context, _ string) ([]
 		return nil, err
 	}
 	checker := &statementMergeAlterTableChecker{
-		level:    level,
-		title:    string(ctx.Rule.Type),
-		tableMap: make(map[string]tableStatement),
+		level:      level,
+		title:      string(ctx.Rule.Type),
+		statements: statement,
+		tableMap:   make(map[string]tableStatement),
 	}
 
-	for _, stmt := range stmtList {
+	for i, stmt := range stmtList {
 		checker.baseLine = stmt.BaseLine
+		checker.index = i
 		antlr.ParseTreeWalkerDefault.Walk(checker, stmt.Tree)
 	}
 
@@ -59,10 +62,12 @@ type statementMergeAlterTableChecker struct {
 	*mysql.BaseMySQLParserListener
 
 	baseLine   int
+	index      int
 	text       string
 	adviceList []advisor.Advice
 	level      advisor.Status
 	title      string
+	statements string
 	tableMap   map[string]tableStatement
 }
 
@@ -70,6 +75,14 @@ type tableStatement struct {
 	name     string
 	count    int
 	lastLine int
+	// alterList are the last adjacent ALTER TABLE statements, which can be merged by the fix.
+	alterList []alterTableStatement
+}
+
+type alterTableStatement struct {
+	ctx   *mysql.AlterTableContext
+	index int
+	line  int
 }
 
 func (checker *statementMergeAlterTableChecker) EnterQuery(ctx *mysql.QueryContext) {
@@ -105,6 +118,10 @@ func (checker *statementMergeAlterTableChecker) EnterAlterTable(ctx *mysql.Alter
 	}
 	table.count++
 	table.lastLine = checker.baseLine + ctx.GetStart().GetLine()
+	if n := len(table.alterList); n == 0 || table.alterList[n-1].index != checker.index-1 {
+		table.alterList = nil
+	}
+	table.alterList = append(table.alterList, alterTableStatement{ctx: ctx, index: checker.index, line: table.lastLine})
 	checker.tableMap[tableName] = table
 }
 
@@ -125,6 +142,7 @@ func (checker *statementMergeAlterTableChecker) generateAdvice() []advisor.Advic
 				Title:   checker.title,
 				Content: fmt.Sprintf("There are %d statements to modify table `%s`", table.count, table.name),
 				Line:    table.lastLine,
+				Fix:     checker.newMergeFix(table.alterList),
 			})
 		}
 	}
@@ -139,3 +157,31 @@ func (checker *statementMergeAlterTableChecker) generateAdvice() []advisor.Advic
 	}
 	return checker.adviceList
 }
+
+// newMergeFix returns the fix merging the adjacent ALTER TABLE statements into one.
+// The statements with the modifiers, such as ALGORITHM, or the partition options are not merged.
+func (checker *statementMergeAlterTableChecker) newMergeFix(alterList []alterTableStatement) *advisor.Fix {
+	if len(alterList) < 2 {
+		return nil
+	}
+	var prefix string
+	var textList, itemList []string
+	for i, alter := range alterList {
+		actions := alter.ctx.AlterTableActions()
+		if actions == nil || actions.AlterCommandList() == nil || actions.AlterCommandList().AlterList() == nil ||
+			actions.AlterCommandList().AlterCommandsModifierList() != nil || actions.PartitionClause() != nil || actions.RemovePartitioning() != nil {
+			return nil
+		}
+		stmt, ok := alter.ctx.GetParent().(*mysql.AlterStatementContext)
+		if !ok {
+			return nil
+		}
+		tokens := alter.ctx.GetParser().GetTokenStream()
+		if i == 0 {
+			prefix = tokens.GetTextFromTokens(stmt.GetStart(), alter.ctx.TableRef().GetStop())
+		}
+		textList = append(textList, tokens.GetTextFromRuleContext(stmt))
+		itemList = append(itemList, tokens.GetTextFromRuleContext(actions.AlterCommandList().AlterList()))
+	}
+	return advisor.NewMergeFix("Merge ALTER TABLE statements", checker.statements, textList, alterList[0].line, fmt.Sprintf("%s %s", prefix, strings.Join(itemList, ", ")))
+}
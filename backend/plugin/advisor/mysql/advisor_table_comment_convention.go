@@ -93,6 +93,7 @@ func (checker *tableCommentConventionChecker) EnterCreateTable(ctx *mysql.Create
 			Title:   checker.title,
 			Content: fmt.Sprintf("Table `%s` requires comments", tableName),
 			Line:    checker.baseLine + ctx.GetStart().GetLine(),
+			Fix:     checker.newCommentFix(ctx, tableName),
 		})
 	}
 	if checker.maxLength >= 0 && len(comment) > checker.maxLength {
@@ -120,3 +121,17 @@ func (*tableCommentConventionChecker) handleCreateTableOptions(ctx mysql.ICreate
 	}
 	return "", false
 }
+
+// newCommentFix returns the fix adding the comment after the table options, or after the table elements if there is no option.
+func (checker *tableCommentConventionChecker) newCommentFix(ctx *mysql.CreateTableContext, tableName string) *advisor.Fix {
+	var after antlr.Token
+	switch {
+	case ctx.CreateTableOptions() != nil:
+		after = ctx.CreateTableOptions().GetStop()
+	case ctx.CLOSE_PAR_SYMBOL() != nil && ctx.TableElementList() != nil:
+		after = ctx.CLOSE_PAR_SYMBOL().GetSymbol()
+	default:
+		return nil
+	}
+	return newCommentFix(ctx.GetParser().GetTokenStream(), ctx, after, tableName, checker.maxLength)
+}
@@ -117,6 +117,9 @@ func TestMySQLRules(t *testing.T) {
 
 		// advisor.SchemaRuleCollationAllowlist enforce the collation allowlist.
 		advisor.SchemaRuleCollationAllowlist,
+
+		// advisor.SchemaRuleCustom is the user-defined rule in CEL.
+		advisor.SchemaRuleCustom,
 	}
 
 	for _, rule := range mysqlRules {
@@ -2,9 +2,14 @@
 package mysql
 
 import (
+	"fmt"
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
 )
 
 type columnSet map[string]bool
@@ -83,3 +88,39 @@ type tableData struct {
 	onUpdateCurrentTimeCount int
 	line                     int
 }
+
+// newInsertFix returns the fix inserting the text after the token in the statement.
+func newInsertFix(description string, tokens antlr.TokenStream, stmt antlr.ParserRuleContext, after antlr.Token, text string) *advisor.Fix {
+	origin := tokens.GetTextFromRuleContext(stmt)
+	prefix := tokens.GetTextFromTokens(stmt.GetStart(), after)
+	if !strings.HasPrefix(origin, prefix) {
+		return nil
+	}
+	return advisor.NewFix(description, origin, prefix+text+origin[len(prefix):])
+}
+
+// newCommentFix returns the fix adding the comment after the token in the statement.
+// The comment is the name truncated to the max length, which is a placeholder for the user to edit.
+func newCommentFix(tokens antlr.TokenStream, stmt antlr.ParserRuleContext, after antlr.Token, name string, maxLength int) *advisor.Fix {
+	comment := name
+	if maxLength >= 0 && len(comment) > maxLength {
+		comment = comment[:maxLength]
+	}
+	if comment == "" {
+		return nil
+	}
+	return newInsertFix("Add comment", tokens, stmt, after, fmt.Sprintf(" COMMENT '%s'", strings.ReplaceAll(comment, "'", "''")))
+}
+
+// getRequiredColumnType returns the column type for adding the required column, which is guessed from the column name.
+func getRequiredColumnType(column string) string {
+	column = strings.ToLower(column)
+	switch {
+	case column == "id" || strings.HasSuffix(column, "_id"):
+		return "BIGINT NOT NULL"
+	case strings.HasSuffix(column, "_ts") || strings.HasSuffix(column, "_at") || strings.HasSuffix(column, "_time"):
+		return "TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP"
+	default:
+		return "VARCHAR(255)"
+	}
+}
@@ -0,0 +1,178 @@
+// Package oracle is the advisor for oracle database.
+package oracle
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/plsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnDisallowDropInIndexAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_ORACLE, advisor.OracleColumnDisallowDropInIndex, &ColumnDisallowDropInIndexAdvisor{})
+	advisor.Register(storepb.Engine_OCEANBASE_ORACLE, advisor.OracleColumnDisallowDropInIndex, &ColumnDisallowDropInIndexAdvisor{})
+}
+
+// ColumnDisallowDropInIndexAdvisor is the advisor checking for disallow DROP COLUMN in index.
+type ColumnDisallowDropInIndexAdvisor struct {
+}
+
+// Check checks for disallow DROP COLUMN in index.
+func (*ColumnDisallowDropInIndexAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &columnDisallowDropInIndexListener{
+		level:         level,
+		title:         string(ctx.Rule.Type),
+		currentSchema: ctx.CurrentSchema,
+		catalog:       ctx.Catalog,
+		tables:        make(map[string]map[string]bool),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// columnDisallowDropInIndexListener is the listener for disallow DROP COLUMN in index.
+type columnDisallowDropInIndexListener struct {
+	*parser.BasePlSqlParserListener
+
+	level         advisor.Status
+	title         string
+	currentSchema string
+	catalog       *catalog.Finder
+	// tables is the map from SCHEMA.TABLE to the set of the index columns.
+	tables     map[string]map[string]bool
+	adviceList []advisor.Advice
+}
+
+func (l *columnDisallowDropInIndexListener) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *columnDisallowDropInIndexListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	schemaName := l.currentSchema
+	if ctx.Schema_name() != nil {
+		schemaName = normalizeIdentifier(ctx.Schema_name(), l.currentSchema)
+	}
+	tableName := fmt.Sprintf("%s.%s", schemaName, normalizeIdentifier(ctx.Table_name(), schemaName))
+	// The re-created table does not have the indexes in the original schema.
+	l.tables[tableName] = make(map[string]bool)
+	if ctx.Relational_table() == nil {
+		return
+	}
+	for _, property := range ctx.Relational_table().AllRelational_property() {
+		switch {
+		case property.Column_definition() != nil:
+			column := property.Column_definition()
+			for _, constraint := range column.AllInline_constraint() {
+				if constraint.PRIMARY() != nil || constraint.UNIQUE() != nil {
+					l.tables[tableName][normalizeIdentifier(column.Column_name(), l.currentSchema)] = true
+				}
+			}
+		case property.Out_of_line_constraint() != nil:
+			l.addConstraint(tableName, property.Out_of_line_constraint())
+		}
+	}
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *columnDisallowDropInIndexListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	clause := ctx.Table_index_clause()
+	if clause == nil {
+		return
+	}
+	tableName := normalizeIdentifier(clause.Tableview_name(), l.currentSchema)
+	columns := l.indexColumns(tableName)
+	for _, expression := range clause.AllIndex_expr() {
+		if expression.Column_name() != nil {
+			columns[normalizeIdentifier(expression.Column_name(), l.currentSchema)] = true
+		}
+	}
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *columnDisallowDropInIndexListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	tableName := normalizeIdentifier(ctx.Tableview_name(), l.currentSchema)
+	if clauses := ctx.Constraint_clauses(); clauses != nil && clauses.ADD() != nil {
+		for _, constraint := range clauses.AllOut_of_line_constraint() {
+			l.addConstraint(tableName, constraint)
+		}
+		return
+	}
+	if ctx.Column_clauses() == nil || ctx.Column_clauses().Add_modify_drop_column_clauses() == nil {
+		return
+	}
+	for _, clause := range ctx.Column_clauses().Add_modify_drop_column_clauses().AllDrop_column_clause() {
+		for _, column := range clause.AllColumn_name() {
+			columnName := normalizeIdentifier(column, l.currentSchema)
+			if l.indexColumns(tableName)[columnName] {
+				l.adviceList = append(l.adviceList, advisor.Advice{
+					Status:  l.level,
+					Code:    advisor.DropIndexColumn,
+					Title:   l.title,
+					Content: fmt.Sprintf("%s.%s cannot drop index column", normalizeTableName(tableName), normalizeTableName(columnName)),
+					Line:    clause.GetStart().GetLine(),
+				})
+			}
+		}
+	}
+}
+
+func (l *columnDisallowDropInIndexListener) addConstraint(tableName string, constraint parser.IOut_of_line_constraintContext) {
+	if constraint.PRIMARY() == nil && constraint.UNIQUE() == nil {
+		return
+	}
+	columns := l.indexColumns(tableName)
+	for _, column := range constraint.AllColumn_name() {
+		columns[normalizeIdentifier(column, l.currentSchema)] = true
+	}
+}
+
+// indexColumns returns the index columns of the table, loading the indexes in the original schema on first access.
+func (l *columnDisallowDropInIndexListener) indexColumns(tableName string) map[string]bool {
+	if columns, exists := l.tables[tableName]; exists {
+		return columns
+	}
+	columns := make(map[string]bool)
+	indexes := l.catalog.Origin.Index(&catalog.TableIndexFind{
+		SchemaName: firstIdentifier(tableName),
+		TableName:  lastIdentifier(tableName),
+	})
+	if indexes != nil {
+		for _, index := range *indexes {
+			for _, column := range index.ExpressionList() {
+				columns[column] = true
+			}
+		}
+	}
+	l.tables[tableName] = columns
+	return columns
+}
@@ -0,0 +1,312 @@
+// Package oracle is the advisor for oracle database.
+package oracle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/plsql-parser"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	plsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/plsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*IndexNoDuplicateIndexAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_ORACLE, advisor.OracleIndexNoDuplicateIndex, &IndexNoDuplicateIndexAdvisor{})
+	advisor.Register(storepb.Engine_OCEANBASE_ORACLE, advisor.OracleIndexNoDuplicateIndex, &IndexNoDuplicateIndexAdvisor{})
+}
+
+// IndexNoDuplicateIndexAdvisor is the advisor checking for no duplicate index.
+type IndexNoDuplicateIndexAdvisor struct {
+}
+
+// Check checks for no duplicate index.
+func (*IndexNoDuplicateIndexAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &indexNoDuplicateIndexListener{
+		level:         level,
+		title:         string(ctx.Rule.Type),
+		currentSchema: ctx.CurrentSchema,
+		catalog:       ctx.Catalog,
+		tables:        make(map[string][]*indexDefinition),
+		droppedIndex:  make(map[string]bool),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// indexDefinition is the index in the original schema or created by the statements.
+type indexDefinition struct {
+	// name is empty for the unnamed constraint.
+	name       string
+	columns    []string
+	constraint bool
+	unique     bool
+	primary    bool
+}
+
+// indexNoDuplicateIndexListener is the listener for no duplicate index.
+type indexNoDuplicateIndexListener struct {
+	*parser.BasePlSqlParserListener
+
+	level         advisor.Status
+	title         string
+	currentSchema string
+	catalog       *catalog.Finder
+	// tables is the map from SCHEMA.TABLE to the indexes of the table.
+	tables map[string][]*indexDefinition
+	// droppedIndex is the set of the dropped indexes in SCHEMA.INDEX format.
+	droppedIndex map[string]bool
+	adviceList   []advisor.Advice
+}
+
+func (l *indexNoDuplicateIndexListener) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *indexNoDuplicateIndexListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	schemaName := l.currentSchema
+	if ctx.Schema_name() != nil {
+		schemaName = normalizeIdentifier(ctx.Schema_name(), l.currentSchema)
+	}
+	tableName := fmt.Sprintf("%s.%s", schemaName, normalizeIdentifier(ctx.Table_name(), schemaName))
+	l.tables[tableName] = nil
+	if ctx.Relational_table() == nil {
+		return
+	}
+	for _, property := range ctx.Relational_table().AllRelational_property() {
+		switch {
+		case property.Column_definition() != nil:
+			column := property.Column_definition()
+			for _, constraint := range column.AllInline_constraint() {
+				if constraint.PRIMARY() == nil && constraint.UNIQUE() == nil {
+					continue
+				}
+				_, name := plsqlparser.NormalizeConstraintName(constraint.Constraint_name())
+				l.addIndex(tableName, &indexDefinition{
+					name:       name,
+					columns:    []string{normalizeIdentifier(column.Column_name(), l.currentSchema)},
+					constraint: true,
+					unique:     true,
+					primary:    constraint.PRIMARY() != nil,
+				}, constraint.GetStart().GetLine())
+			}
+		case property.Out_of_line_constraint() != nil:
+			l.addConstraint(tableName, property.Out_of_line_constraint())
+		}
+	}
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *indexNoDuplicateIndexListener) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	l.tables[normalizeIdentifier(ctx.Tableview_name(), l.currentSchema)] = nil
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *indexNoDuplicateIndexListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	clause := ctx.Table_index_clause()
+	if clause == nil {
+		return
+	}
+	tableName := normalizeIdentifier(clause.Tableview_name(), l.currentSchema)
+	var columns []string
+	for _, expression := range clause.AllIndex_expr() {
+		if expression.Column_name() == nil {
+			// Skip the function-based index.
+			return
+		}
+		columns = append(columns, normalizeIdentifier(expression.Column_name(), l.currentSchema))
+	}
+	_, name := plsqlparser.NormalizeIndexName(ctx.Index_name())
+	l.addIndex(tableName, &indexDefinition{
+		name:    name,
+		columns: columns,
+		unique:  ctx.UNIQUE() != nil,
+	}, ctx.GetStart().GetLine())
+}
+
+// EnterDrop_index is called when production drop_index is entered.
+func (l *indexNoDuplicateIndexListener) EnterDrop_index(ctx *parser.Drop_indexContext) {
+	schemaName, name := plsqlparser.NormalizeIndexName(ctx.Index_name())
+	if schemaName == "" {
+		schemaName = l.currentSchema
+	}
+	l.droppedIndex[fmt.Sprintf("%s.%s", schemaName, name)] = true
+	for tableName, indexes := range l.tables {
+		if firstIdentifier(tableName) != schemaName {
+			continue
+		}
+		l.tables[tableName] = slices.DeleteFunc(indexes, func(index *indexDefinition) bool {
+			return index.name == name
+		})
+	}
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *indexNoDuplicateIndexListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	tableName := normalizeIdentifier(ctx.Tableview_name(), l.currentSchema)
+	var clauseList []parser.IConstraint_clausesContext
+	if ctx.Constraint_clauses() != nil {
+		clauseList = append(clauseList, ctx.Constraint_clauses())
+	}
+	if ctx.Column_clauses() != nil && ctx.Column_clauses().Add_modify_drop_column_clauses() != nil {
+		clauseList = append(clauseList, ctx.Column_clauses().Add_modify_drop_column_clauses().AllConstraint_clauses()...)
+	}
+	for _, clauses := range clauseList {
+		if clauses.ADD() != nil {
+			for _, constraint := range clauses.AllOut_of_line_constraint() {
+				l.addConstraint(tableName, constraint)
+			}
+			continue
+		}
+		for _, drop := range clauses.AllDrop_constraint_clause() {
+			clause := drop.Drop_primary_key_or_unique_or_generic_clause()
+			if clause == nil {
+				continue
+			}
+			var match func(index *indexDefinition) bool
+			switch {
+			case clause.PRIMARY() != nil:
+				match = func(index *indexDefinition) bool {
+					return index.primary
+				}
+			case clause.UNIQUE() != nil:
+				var columns []string
+				for _, column := range clause.AllColumn_name() {
+					columns = append(columns, normalizeIdentifier(column, l.currentSchema))
+				}
+				match = func(index *indexDefinition) bool {
+					return index.constraint && index.unique && !index.primary && slices.Equal(index.columns, columns)
+				}
+			default:
+				_, name := plsqlparser.NormalizeConstraintName(clause.Constraint_name())
+				match = func(index *indexDefinition) bool {
+					return index.constraint && index.name == name
+				}
+			}
+			l.tables[tableName] = slices.DeleteFunc(l.indexes(tableName), match)
+		}
+	}
+}
+
+func (l *indexNoDuplicateIndexListener) addConstraint(tableName string, constraint parser.IOut_of_line_constraintContext) {
+	if constraint.PRIMARY() == nil && constraint.UNIQUE() == nil {
+		return
+	}
+	var columns []string
+	for _, column := range constraint.AllColumn_name() {
+		columns = append(columns, normalizeIdentifier(column, l.currentSchema))
+	}
+	_, name := plsqlparser.NormalizeConstraintName(constraint.Constraint_name())
+	l.addIndex(tableName, &indexDefinition{
+		name:       name,
+		columns:    columns,
+		constraint: true,
+		unique:     true,
+		primary:    constraint.PRIMARY() != nil,
+	}, constraint.GetStart().GetLine())
+}
+
+// addIndex adds the index to the table and reports it if it duplicates an existing index.
+// Oracle rejects the index on the already indexed column list, and the primary key or unique constraint
+// on the column list which is already a primary key or unique constraint.
+func (l *indexNoDuplicateIndexListener) addIndex(tableName string, index *indexDefinition, line int) {
+	indexes := l.indexes(tableName)
+	for _, existing := range indexes {
+		if !slices.Equal(existing.columns, index.columns) {
+			continue
+		}
+		if index.constraint && !existing.unique {
+			// The constraint uses the existing index.
+			continue
+		}
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status: l.level,
+			Code:   advisor.DuplicateIndex,
+			Title:  l.title,
+			Content: fmt.Sprintf("%s on table %s duplicates %s with the same columns (%s)",
+				index.description(), normalizeTableName(tableName), existing.description(), strings.Join(index.columns, ", ")),
+			Line: line,
+		})
+		break
+	}
+	l.tables[tableName] = append(indexes, index)
+}
+
+// indexes returns the indexes of the table, loading the indexes in the original schema on first access.
+func (l *indexNoDuplicateIndexListener) indexes(tableName string) []*indexDefinition {
+	if indexes, exists := l.tables[tableName]; exists {
+		return indexes
+	}
+	var indexes []*indexDefinition
+	schemaName := firstIdentifier(tableName)
+	indexMap := l.catalog.Origin.Index(&catalog.TableIndexFind{
+		SchemaName: schemaName,
+		TableName:  lastIdentifier(tableName),
+	})
+	if indexMap != nil {
+		for name, index := range *indexMap {
+			if l.droppedIndex[fmt.Sprintf("%s.%s", schemaName, name)] {
+				continue
+			}
+			indexes = append(indexes, &indexDefinition{
+				name:       name,
+				columns:    index.ExpressionList(),
+				constraint: index.Unique(),
+				unique:     index.Unique(),
+				primary:    index.Primary(),
+			})
+		}
+		// Keep the advice stable.
+		slices.SortFunc(indexes, func(a, b *indexDefinition) int {
+			return strings.Compare(a.name, b.name)
+		})
+	}
+	l.tables[tableName] = indexes
+	return indexes
+}
+
+func (index *indexDefinition) description() string {
+	kind := "index"
+	switch {
+	case index.primary:
+		kind = "primary key"
+	case index.constraint:
+		kind = "unique constraint"
+	case index.unique:
+		kind = "unique index"
+	}
+	if index.name == "" {
+		return kind
+	}
+	return fmt.Sprintf("%s %q", kind, index.name)
+}
@@ -0,0 +1,252 @@
+// Package oracle is the advisor for oracle database.
+package oracle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/plsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	plsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/plsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*MigrationCompatibilityAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_ORACLE, advisor.OracleMigrationCompatibility, &MigrationCompatibilityAdvisor{})
+	advisor.Register(storepb.Engine_OCEANBASE_ORACLE, advisor.OracleMigrationCompatibility, &MigrationCompatibilityAdvisor{})
+}
+
+// MigrationCompatibilityAdvisor is the advisor checking for migration compatibility.
+type MigrationCompatibilityAdvisor struct {
+}
+
+// Check checks for migration compatibility.
+func (*MigrationCompatibilityAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &migrationCompatibilityListener{
+		level:         level,
+		title:         string(ctx.Rule.Type),
+		currentSchema: ctx.CurrentSchema,
+		catalog:       ctx.Catalog,
+		newTables:     make(map[string]bool),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// migrationCompatibilityListener is the listener for migration compatibility.
+type migrationCompatibilityListener struct {
+	*parser.BasePlSqlParserListener
+
+	level         advisor.Status
+	title         string
+	currentSchema string
+	catalog       *catalog.Finder
+	// newTables is the set of the tables created in the statements, in SCHEMA.TABLE format.
+	newTables  map[string]bool
+	text       string
+	adviceList []advisor.Advice
+}
+
+func (l *migrationCompatibilityListener) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterUnit_statement is called when production unit_statement is entered.
+func (l *migrationCompatibilityListener) EnterUnit_statement(ctx *parser.Unit_statementContext) {
+	text := ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx)
+	l.text = strings.TrimSuffix(strings.TrimSpace(text), ";")
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *migrationCompatibilityListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	schemaName := l.currentSchema
+	if ctx.Schema_name() != nil {
+		schemaName = normalizeIdentifier(ctx.Schema_name(), l.currentSchema)
+	}
+	l.newTables[fmt.Sprintf("%s.%s", schemaName, normalizeIdentifier(ctx.Table_name(), schemaName))] = true
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *migrationCompatibilityListener) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	tableName := normalizeIdentifier(ctx.Tableview_name(), l.currentSchema)
+	if l.existingTable(tableName) {
+		l.addAdvice(advisor.CompatibilityDropTable, ctx.GetStart().GetLine())
+	}
+	delete(l.newTables, tableName)
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *migrationCompatibilityListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	if ctx.UNIQUE() == nil || ctx.Table_index_clause() == nil {
+		return
+	}
+	tableName := normalizeIdentifier(ctx.Table_index_clause().Tableview_name(), l.currentSchema)
+	if l.existingTable(tableName) {
+		l.addAdvice(advisor.CompatibilityAddUniqueKey, ctx.GetStart().GetLine())
+	}
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *migrationCompatibilityListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	tableName := normalizeIdentifier(ctx.Tableview_name(), l.currentSchema)
+	if !l.existingTable(tableName) {
+		return
+	}
+	line := ctx.GetStart().GetLine()
+
+	if properties := ctx.Alter_table_properties(); properties != nil && properties.RENAME() != nil {
+		l.addAdvice(advisor.CompatibilityRenameTable, line)
+		return
+	}
+	if clauses := ctx.Constraint_clauses(); clauses != nil {
+		l.checkConstraintClauses(clauses, line)
+		return
+	}
+	columnClauses := ctx.Column_clauses()
+	if columnClauses == nil {
+		return
+	}
+	if columnClauses.Rename_column_clause() != nil {
+		l.addAdvice(advisor.CompatibilityRenameColumn, line)
+		return
+	}
+	clauses := columnClauses.Add_modify_drop_column_clauses()
+	if clauses == nil {
+		return
+	}
+	if len(clauses.AllDrop_column_clause()) > 0 {
+		l.addAdvice(advisor.CompatibilityDropColumn, line)
+		return
+	}
+	if l.originRowCount(tableName) > 0 {
+		for _, add := range clauses.AllAdd_column_clause() {
+			for _, column := range add.AllColumn_definition() {
+				if isNotNullWithoutDefault(column) {
+					l.addAdvice(advisor.CompatibilityAddNotNullColumn, line)
+					return
+				}
+			}
+		}
+	}
+	for _, constraintClauses := range clauses.AllConstraint_clauses() {
+		l.checkConstraintClauses(constraintClauses, line)
+	}
+	for _, modify := range clauses.AllModify_column_clauses() {
+		for _, property := range modify.AllModify_col_properties() {
+			if l.typeChanged(tableName, property) {
+				l.addAdvice(advisor.CompatibilityAlterColumn, line)
+				return
+			}
+		}
+	}
+}
+
+func (l *migrationCompatibilityListener) checkConstraintClauses(ctx parser.IConstraint_clausesContext, line int) {
+	if ctx.ADD() == nil {
+		return
+	}
+	for _, constraint := range ctx.AllOut_of_line_constraint() {
+		switch {
+		case constraint.PRIMARY() != nil:
+			l.addAdvice(advisor.CompatibilityAddPrimaryKey, line)
+		case constraint.UNIQUE() != nil:
+			l.addAdvice(advisor.CompatibilityAddUniqueKey, line)
+		case constraint.Foreign_key_clause() != nil:
+			l.addAdvice(advisor.CompatibilityAddForeignKey, line)
+		case constraint.CHECK() != nil:
+			l.addAdvice(advisor.CompatibilityAddCheck, line)
+		}
+	}
+}
+
+// typeChanged returns true if MODIFY changes the type of the column in the original schema.
+func (l *migrationCompatibilityListener) typeChanged(tableName string, ctx parser.IModify_col_propertiesContext) bool {
+	if ctx.Datatype() == nil {
+		return false
+	}
+	column := l.catalog.Origin.FindColumn(&catalog.ColumnFind{
+		SchemaName: firstIdentifier(tableName),
+		TableName:  lastIdentifier(tableName),
+		ColumnName: normalizeIdentifier(ctx.Column_name(), l.currentSchema),
+	})
+	if column == nil {
+		return false
+	}
+	equivalent, err := plsqlparser.EquivalentType(ctx.Datatype(), column.Type())
+	return err != nil || !equivalent
+}
+
+// existingTable returns true if the table exists in the original schema and is not re-created in the statements.
+func (l *migrationCompatibilityListener) existingTable(tableName string) bool {
+	if l.newTables[tableName] {
+		return false
+	}
+	return l.catalog.Origin.FindTable(&catalog.TableFind{
+		SchemaName: firstIdentifier(tableName),
+		TableName:  lastIdentifier(tableName),
+	}) != nil
+}
+
+// originRowCount returns the row count of the table in the original schema.
+func (l *migrationCompatibilityListener) originRowCount(tableName string) int64 {
+	table := l.catalog.Origin.FindTable(&catalog.TableFind{
+		SchemaName: firstIdentifier(tableName),
+		TableName:  lastIdentifier(tableName),
+	})
+	if table == nil {
+		return 0
+	}
+	return table.RowCount()
+}
+
+// isNotNullWithoutDefault returns true if the column is NOT NULL without the default value,
+// which fails for the tables with rows.
+func isNotNullWithoutDefault(ctx parser.IColumn_definitionContext) bool {
+	if ctx.DEFAULT() != nil {
+		return false
+	}
+	for _, constraint := range ctx.AllInline_constraint() {
+		if (constraint.NULL_() != nil && constraint.NOT() != nil) || constraint.PRIMARY() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *migrationCompatibilityListener) addAdvice(code advisor.Code, line int) {
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    code,
+		Title:   l.title,
+		Content: fmt.Sprintf("\"%s\" may cause incompatibility with the existing data and code", l.text),
+		Line:    line,
+	})
+}
@@ -28,6 +28,9 @@ func TestOracleRules(t *testing.T) {
 		advisor.SchemaRuleTableNameNoKeyword,
 		advisor.SchemaRuleIdentifierNoKeyword,
 		advisor.SchemaRuleIdentifierCase,
+		advisor.SchemaRuleSchemaBackwardCompatibility,
+		advisor.SchemaRuleColumnDisallowDropInIndex,
+		advisor.SchemaRuleIndexNoDuplicateIndex,
 	}
 
 	for _, rule := range oracleRules {
@@ -79,3 +79,8 @@ func lastIdentifier(name string) string {
 	list := strings.Split(name, ".")
 	return list[len(list)-1]
 }
+
+func firstIdentifier(name string) string {
+	list := strings.Split(name, ".")
+	return list[0]
+}
@@ -0,0 +1,98 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnDisallowRenameReferencedByViewAdvisor)(nil)
+	_ ast.Visitor     = (*columnDisallowRenameReferencedByViewChecker)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_POSTGRES, advisor.PostgreSQLColumnDisallowRenameReferencedByView, &ColumnDisallowRenameReferencedByViewAdvisor{})
+}
+
+// ColumnDisallowRenameReferencedByViewAdvisor is the advisor checking for disallow renaming the column referenced by views.
+type ColumnDisallowRenameReferencedByViewAdvisor struct {
+}
+
+// Check checks for disallow renaming the column referenced by views.
+func (*ColumnDisallowRenameReferencedByViewAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, ok := ctx.AST.([]ast.Node)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Node")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnDisallowRenameReferencedByViewChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		catalog: ctx.Catalog,
+	}
+
+	for _, stmt := range stmtList {
+		checker.line = stmt.LastLine()
+		ast.Walk(checker, stmt)
+		if checker.err != nil {
+			return nil, checker.err
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type columnDisallowRenameReferencedByViewChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	line       int
+	catalog    *catalog.Finder
+	err        error
+}
+
+// Visit implements ast.Visitor interface.
+func (checker *columnDisallowRenameReferencedByViewChecker) Visit(in ast.Node) ast.Visitor {
+	node, ok := in.(*ast.RenameColumnStmt)
+	if !ok || node.ColumnName == node.NewName {
+		return checker
+	}
+	viewList, err := checker.catalog.Origin.FindColumnDependentView(&catalog.ColumnFind{
+		SchemaName: normalizeSchemaName(node.Table.Schema),
+		TableName:  node.Table.Name,
+		ColumnName: node.ColumnName,
+	})
+	if err != nil {
+		checker.err = err
+		return nil
+	}
+	if len(viewList) > 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  checker.level,
+			Code:    advisor.ColumnIsReferencedByView,
+			Title:   checker.title,
+			Content: fmt.Sprintf("Column \"%s\" in table %s is referenced by view: %s, renaming it makes the view output column name diverge from the table column", node.ColumnName, normalizeTableName(node.Table, PostgreSQLPublicSchema), strings.Join(viewList, ", ")),
+			Line:    checker.line,
+		})
+	}
+	return checker
+}
@@ -79,6 +79,7 @@ type columnRequirementChecker struct {
 func (checker *columnRequirementChecker) Visit(node ast.Node) ast.Visitor {
 	var table *ast.TableDef
 	var missingColumns []string
+	var createTable *ast.CreateTableStmt
 	switch n := node.(type) {
 	// CREATE TABLE
 	case *ast.CreateTableStmt:
@@ -87,6 +88,7 @@ func (checker *columnRequirementChecker) Visit(node ast.Node) ast.Visitor {
 		}
 		if len(checker.requiredColumns) > 0 {
 			table = n.Name
+			createTable = n
 			for column := range checker.requiredColumns {
 				missingColumns = append(missingColumns, column)
 			}
@@ -108,13 +110,17 @@ func (checker *columnRequirementChecker) Visit(node ast.Node) ast.Visitor {
 	if len(missingColumns) > 0 {
 		// Order it cause the random iteration order in Go, see https://go.dev/blog/maps
 		sort.Strings(missingColumns)
-		checker.adviceList = append(checker.adviceList, advisor.Advice{
+		advice := advisor.Advice{
 			Status:  checker.level,
 			Code:    advisor.NoRequiredColumn,
 			Title:   checker.title,
 			Content: fmt.Sprintf("Table %q requires columns: %s", table.Name, strings.Join(missingColumns, ", ")),
 			Line:    node.LastLine(),
-		})
+		}
+		if createTable != nil {
+			advice.Fix = newRequiredColumnsFix(createTable, missingColumns)
+		}
+		checker.adviceList = append(checker.adviceList, advice)
 	}
 
 	return checker
@@ -0,0 +1,39 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*CustomRuleAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_POSTGRES, advisor.PostgreSQLCustomRule, &CustomRuleAdvisor{})
+}
+
+// CustomRuleAdvisor is the advisor checking for the custom rule in CEL.
+type CustomRuleAdvisor struct {
+}
+
+// Check checks for the custom rule in CEL.
+func (*CustomRuleAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	modelList, err := catalog.BuildStatementModels(storepb.Engine_POSTGRES, statement, ctx.Catalog)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Driver != nil {
+		advisor.FillAffectedRows(modelList, func(text string) (int64, error) {
+			res, err := advisor.Query(ctx.Context, ctx.Driver, fmt.Sprintf("EXPLAIN %s", text))
+			if err != nil {
+				return 0, err
+			}
+			return getAffectedRows(res)
+		})
+	}
+	return advisor.CheckCustomRule(ctx, modelList)
+}
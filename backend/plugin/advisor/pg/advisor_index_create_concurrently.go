@@ -70,6 +70,7 @@ func (checker *indexCreateConcurrentlyChecker) Visit(in ast.Node) ast.Visitor {
 				Title:   checker.title,
 				Content: "Creating indexes will block writes on the table, unless use CONCURRENTLY",
 				Line:    in.LastLine(),
+				Fix:     newCreateIndexConcurrentlyFix(in.Text()),
 			})
 		}
 	}
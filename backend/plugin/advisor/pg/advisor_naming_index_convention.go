@@ -98,6 +98,7 @@ func (checker *namingIndexConventionChecker) Visit(node ast.Node) ast.Visitor {
 				Title:   checker.title,
 				Content: fmt.Sprintf("Index in table %q mismatches the naming convention, expect %q but found %q", indexData.tableName, regex, indexData.indexName),
 				Line:    node.LastLine(),
+				Fix:     checker.newRenameFix(node, indexData),
 			})
 		}
 		if checker.maxLength > 0 && len(indexData.indexName) > checker.maxLength {
@@ -154,3 +155,11 @@ func (checker *namingIndexConventionChecker) getMetaDataList(in ast.Node) []*ind
 
 	return res
 }
+
+func (checker *namingIndexConventionChecker) newRenameFix(node ast.Node, indexData *indexMetaData) *advisor.Fix {
+	name, ok := getTemplateName(checker.format, checker.templateList, indexData.metaData)
+	if !ok || (checker.maxLength > 0 && len(name) > checker.maxLength) {
+		return nil
+	}
+	return newRenameFix(node.Text(), indexData.indexName, name)
+}
@@ -40,6 +40,7 @@ func (*StatementAddCheckNotValidAdvisor) Check(ctx advisor.Context, _ string) ([
 	}
 
 	for _, stmt := range stmtList {
+		checker.stmt = stmt
 		checker.line = stmt.LastLine()
 		ast.Walk(checker, stmt)
 	}
@@ -59,6 +60,7 @@ type statementAddCheckNotValidChecker struct {
 	adviceList []advisor.Advice
 	level      advisor.Status
 	title      string
+	stmt       ast.Node
 	line       int
 }
 
@@ -72,6 +74,7 @@ func (checker *statementAddCheckNotValidChecker) Visit(in ast.Node) ast.Visitor
 				Title:   checker.title,
 				Content: "Adding check constraints with validation will block reads and writes. You can add check constraints not valid and then validate separately",
 				Line:    checker.line,
+				Fix:     newNotValidFix(checker.stmt),
 			})
 		}
 	}
@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementAddFKNotValidAdvisor)(nil)
+	_ ast.Visitor     = (*statementAddFKNotValidChecker)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_POSTGRES, advisor.PostgreSQLAddFKNotValid, &StatementAddFKNotValidAdvisor{})
+}
+
+// StatementAddFKNotValidAdvisor is the advisor checking for to add foreign key not valid.
+type StatementAddFKNotValidAdvisor struct {
+}
+
+// Check checks for to add foreign key not valid.
+func (*StatementAddFKNotValidAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, ok := ctx.AST.([]ast.Node)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Node")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &statementAddFKNotValidChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		catalog: ctx.Catalog,
+	}
+
+	for _, stmt := range stmtList {
+		checker.stmt = stmt
+		checker.line = stmt.LastLine()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type statementAddFKNotValidChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	stmt       ast.Node
+	line       int
+	catalog    *catalog.Finder
+}
+
+// Visit implements ast.Visitor interface.
+func (checker *statementAddFKNotValidChecker) Visit(in ast.Node) ast.Visitor {
+	node, ok := in.(*ast.AddConstraintStmt)
+	if !ok || node.Constraint.Type != ast.ConstraintTypeForeign || node.Constraint.SkipValidation {
+		return checker
+	}
+	// The table created in the same script has no rows to validate.
+	table := checker.catalog.Origin.FindTable(&catalog.TableFind{
+		SchemaName: normalizeSchemaName(node.Table.Schema),
+		TableName:  node.Table.Name,
+	})
+	if table == nil {
+		return checker
+	}
+	checker.adviceList = append(checker.adviceList, advisor.Advice{
+		Status:  checker.level,
+		Code:    advisor.StatementAddFKWithValidation,
+		Title:   checker.title,
+		Content: fmt.Sprintf("Adding foreign keys with validation will block writes on table %s and the referenced table %s. You can add foreign keys not valid and then validate separately", normalizeTableName(node.Table, PostgreSQLPublicSchema), normalizeTableName(node.Constraint.Foreign.Table, PostgreSQLPublicSchema)),
+		Line:    checker.line,
+		Fix:     newNotValidFix(checker.stmt),
+	})
+
+	return checker
+}
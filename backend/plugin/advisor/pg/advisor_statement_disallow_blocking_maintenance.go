@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDisallowBlockingMaintenanceAdvisor)(nil)
+	_ ast.Visitor     = (*statementDisallowBlockingMaintenanceChecker)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_POSTGRES, advisor.PostgreSQLDisallowBlockingMaintenance, &StatementDisallowBlockingMaintenanceAdvisor{})
+}
+
+// StatementDisallowBlockingMaintenanceAdvisor is the advisor checking for disallow VACUUM FULL, CLUSTER and REINDEX without CONCURRENTLY.
+type StatementDisallowBlockingMaintenanceAdvisor struct {
+}
+
+// Check checks for disallow VACUUM FULL, CLUSTER and REINDEX without CONCURRENTLY.
+func (*StatementDisallowBlockingMaintenanceAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, ok := ctx.AST.([]ast.Node)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Node")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &statementDisallowBlockingMaintenanceChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	for _, stmt := range stmtList {
+		checker.text = stmt.Text()
+		checker.line = stmt.LastLine()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type statementDisallowBlockingMaintenanceChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	text       string
+	line       int
+}
+
+// Visit implements ast.Visitor interface.
+func (checker *statementDisallowBlockingMaintenanceChecker) Visit(in ast.Node) ast.Visitor {
+	content := ""
+	switch node := in.(type) {
+	case *ast.VacuumStmt:
+		if node.Full {
+			content = fmt.Sprintf("VACUUM FULL rewrites the table with an ACCESS EXCLUSIVE lock, the statement \"%s\" will block reads and writes. You can use pg_repack instead", checker.text)
+		}
+	case *ast.ClusterStmt:
+		content = fmt.Sprintf("CLUSTER rewrites the table with an ACCESS EXCLUSIVE lock, the statement \"%s\" will block reads and writes. You can use pg_repack instead", checker.text)
+	case *ast.ReindexStmt:
+		if !node.Concurrently {
+			content = fmt.Sprintf("REINDEX without CONCURRENTLY will block writes, the statement \"%s\" should use REINDEX CONCURRENTLY", checker.text)
+		}
+	}
+
+	if content != "" {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  checker.level,
+			Code:    advisor.StatementBlockingMaintenance,
+			Title:   checker.title,
+			Content: content,
+			Line:    checker.line,
+		})
+	}
+
+	return checker
+}
@@ -0,0 +1,189 @@
+package pg
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	pgrawparser "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDisallowTableRewriteAdvisor)(nil)
+	_ ast.Visitor     = (*statementDisallowTableRewriteChecker)(nil)
+
+	columnTypePattern = regexp.MustCompile(`^(.+?)\s*(?:\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\))?$`)
+	columnTypeAliases = map[string]string{
+		"varchar":     "character varying",
+		"int":         "integer",
+		"int4":        "integer",
+		"int8":        "bigint",
+		"int2":        "smallint",
+		"decimal":     "numeric",
+		"bool":        "boolean",
+		"float8":      "double precision",
+		"float4":      "real",
+		"timestamptz": "timestamp with time zone",
+		"timestamp":   "timestamp without time zone",
+	}
+)
+
+func init() {
+	advisor.Register(storepb.Engine_POSTGRES, advisor.PostgreSQLDisallowTableRewrite, &StatementDisallowTableRewriteAdvisor{})
+}
+
+// StatementDisallowTableRewriteAdvisor is the advisor checking for disallow ALTER COLUMN TYPE rewriting the table.
+type StatementDisallowTableRewriteAdvisor struct {
+}
+
+// Check checks for disallow ALTER COLUMN TYPE rewriting the table.
+func (*StatementDisallowTableRewriteAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, ok := ctx.AST.([]ast.Node)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Node")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &statementDisallowTableRewriteChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		catalog: ctx.Catalog,
+	}
+
+	for _, stmt := range stmtList {
+		checker.text = stmt.Text()
+		checker.line = stmt.LastLine()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type statementDisallowTableRewriteChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	text       string
+	line       int
+	catalog    *catalog.Finder
+}
+
+// Visit implements ast.Visitor interface.
+func (checker *statementDisallowTableRewriteChecker) Visit(in ast.Node) ast.Visitor {
+	node, ok := in.(*ast.AlterColumnTypeStmt)
+	if !ok {
+		return checker
+	}
+	// Only the column in the original schema has the data to rewrite.
+	column := checker.catalog.Origin.FindColumn(&catalog.ColumnFind{
+		SchemaName: normalizeSchemaName(node.Table.Schema),
+		TableName:  node.Table.Name,
+		ColumnName: node.ColumnName,
+	})
+	if column == nil || column.Type() == "" {
+		return checker
+	}
+	newType, err := pgrawparser.Deparse(pgrawparser.DeparseContext{}, node.Type)
+	if err != nil {
+		// Cannot tell whether the new type rewrites the table.
+		return checker
+	}
+	if !isColumnTypeChangeRewriteFree(column.Type(), newType) {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  checker.level,
+			Code:    advisor.StatementTableRewrite,
+			Title:   checker.title,
+			Content: fmt.Sprintf("Changing the type of column \"%s\" in table %s from %s to %s rewrites the table with an ACCESS EXCLUSIVE lock, the statement \"%s\" will block reads and writes", node.ColumnName, normalizeTableName(node.Table, PostgreSQLPublicSchema), column.Type(), newType, checker.text),
+			Line:    checker.line,
+		})
+	}
+	return checker
+}
+
+// isColumnTypeChangeRewriteFree returns whether PostgreSQL can change the column type without rewriting the table.
+// See https://www.postgresql.org/docs/current/sql-altertable.html#SQL-ALTERTABLE-NOTES.
+func isColumnTypeChangeRewriteFree(oldType, newType string) bool {
+	oldName, oldModifiers := parseColumnType(oldType)
+	newName, newModifiers := parseColumnType(newType)
+	if oldName == newName && slices.Equal(oldModifiers, newModifiers) {
+		return true
+	}
+	switch newName {
+	case "text":
+		return oldName == "text" || oldName == "character varying"
+	case "character varying":
+		switch {
+		case len(newModifiers) == 0:
+			// The unlimited varchar is binary coercible from text and varchar.
+			return oldName == "text" || oldName == "character varying"
+		case oldName == "character varying" && len(oldModifiers) == 1:
+			// Increasing the length limit only.
+			return newModifiers[0] >= oldModifiers[0]
+		}
+	case "numeric":
+		if oldName != "numeric" {
+			return false
+		}
+		switch {
+		case len(newModifiers) == 0:
+			return true
+		case len(oldModifiers) == 0:
+			return false
+		}
+		// Increasing the precision with the same scale only.
+		return newModifiers[0] >= oldModifiers[0] && numericScale(newModifiers) == numericScale(oldModifiers)
+	}
+	return false
+}
+
+// parseColumnType parses the column type such as "character varying(20)" and "numeric(10, 2)"
+// into the canonical type name and the type modifiers.
+func parseColumnType(tp string) (string, []int) {
+	tp = strings.ToLower(strings.TrimSpace(tp))
+	match := columnTypePattern.FindStringSubmatch(tp)
+	if match == nil {
+		return tp, nil
+	}
+	name := match[1]
+	if alias, ok := columnTypeAliases[name]; ok {
+		name = alias
+	}
+	var modifiers []int
+	for _, text := range match[2:] {
+		if text == "" {
+			continue
+		}
+		modifier, err := strconv.Atoi(text)
+		if err != nil {
+			return tp, nil
+		}
+		modifiers = append(modifiers, modifier)
+	}
+	return name, modifiers
+}
+
+func numericScale(modifiers []int) int {
+	if len(modifiers) < 2 {
+		return 0
+	}
+	return modifiers[1]
+}
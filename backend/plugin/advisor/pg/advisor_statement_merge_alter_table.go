@@ -27,7 +27,7 @@ type StatementMergeAlterTableAdvisor struct {
 }
 
 // Check checks for no redundant ALTER TABLE statements.
-func (*StatementMergeAlterTableAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+func (*StatementMergeAlterTableAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
 	stmtList, ok := ctx.AST.([]ast.Node)
 	if !ok {
 		return nil, errors.Errorf("failed to convert to Node")
@@ -38,12 +38,14 @@ func (*StatementMergeAlterTableAdvisor) Check(ctx advisor.Context, _ string) ([]
 		return nil, err
 	}
 	checker := &statementMergeAlterTableChecker{
-		level:    level,
-		title:    string(ctx.Rule.Type),
-		tableMap: make(tableMap),
+		level:      level,
+		title:      string(ctx.Rule.Type),
+		statements: statement,
+		tableMap:   make(tableMap),
 	}
 
-	for _, stmt := range stmtList {
+	for i, stmt := range stmtList {
+		checker.index = i
 		ast.Walk(checker, stmt)
 	}
 
@@ -66,6 +68,7 @@ func (checker *statementMergeAlterTableChecker) generateAdvice() []advisor.Advic
 				Title:   checker.title,
 				Content: fmt.Sprintf("There are %d statements to modify table `%s`", table.count, table.name),
 				Line:    table.line,
+				Fix:     newMergeAlterTableFix(checker.statements, table.alterList),
 			})
 		}
 	}
@@ -85,6 +88,8 @@ type statementMergeAlterTableChecker struct {
 	adviceList []advisor.Advice
 	level      advisor.Status
 	title      string
+	statements string
+	index      int
 	tableMap   tableMap
 }
 
@@ -94,6 +99,9 @@ type tableStatement struct {
 	name   string
 	count  int
 	line   int
+	// alterList are the last adjacent ALTER TABLE statements, which can be merged by the fix.
+	alterList []*ast.AlterTableStmt
+	lastIndex int
 }
 
 func (m tableMap) set(schema string, table string, line int) {
@@ -106,10 +114,15 @@ func (m tableMap) set(schema string, table string, line int) {
 	m[t.key()] = t
 }
 
-func (m tableMap) add(schema string, table string, line int) {
+func (m tableMap) add(schema string, table string, line int, stmt *ast.AlterTableStmt, index int) {
 	if t, exists := m[fmt.Sprintf("%s.%s", schema, table)]; exists {
 		t.count++
 		t.line = line
+		if len(t.alterList) == 0 || t.lastIndex != index-1 {
+			t.alterList = nil
+		}
+		t.alterList = append(t.alterList, stmt)
+		t.lastIndex = index
 		m[t.key()] = t
 	}
 }
@@ -124,7 +137,7 @@ func (checker *statementMergeAlterTableChecker) Visit(in ast.Node) ast.Visitor {
 	case *ast.CreateTableStmt:
 		checker.tableMap.set(node.Name.Schema, node.Name.Name, node.LastLine())
 	case *ast.AlterTableStmt:
-		checker.tableMap.add(node.Table.Schema, node.Table.Name, node.LastLine())
+		checker.tableMap.add(node.Table.Schema, node.Table.Name, node.LastLine(), node, checker.index)
 	}
 
 	return checker
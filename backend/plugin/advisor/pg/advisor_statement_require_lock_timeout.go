@@ -0,0 +1,138 @@
+package pg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementRequireLockTimeoutAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_POSTGRES, advisor.PostgreSQLRequireLockTimeout, &StatementRequireLockTimeoutAdvisor{})
+}
+
+// StatementRequireLockTimeoutAdvisor is the advisor checking for setting lock_timeout or statement_timeout before DDL.
+type StatementRequireLockTimeoutAdvisor struct {
+}
+
+// Check checks for setting lock_timeout or statement_timeout before DDL.
+func (*StatementRequireLockTimeoutAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, ok := ctx.AST.([]ast.Node)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Node")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	// The enabled state of lock_timeout and statement_timeout as of the current statement.
+	timeouts := make(map[string]bool)
+	for _, stmt := range stmtList {
+		if node, ok := stmt.(*ast.VariableSetStmt); ok {
+			if name, enabled, ok := getTimeoutVariableSet(node); ok {
+				timeouts[name] = enabled
+			}
+			continue
+		}
+		if timeouts["lock_timeout"] || timeouts["statement_timeout"] {
+			continue
+		}
+		if !isLockingDDL(stmt) {
+			continue
+		}
+		// Report the first DDL only, the following DDL runs without timeout for the same reason.
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  level,
+			Code:    advisor.StatementNoLockTimeout,
+			Title:   string(ctx.Rule.Type),
+			Content: fmt.Sprintf("The statement \"%s\" may wait for the lock and block the following queries forever. You should SET lock_timeout or statement_timeout before DDL", stmt.Text()),
+			Line:    stmt.LastLine(),
+		})
+		break
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
+
+// getTimeoutVariableSet returns the lock_timeout or statement_timeout set by the statement and whether the timeout is enabled.
+// RESET and SET ... TO DEFAULT restore the default, which disables the timeout.
+func getTimeoutVariableSet(node *ast.VariableSetStmt) (string, bool, bool) {
+	name := strings.ToLower(node.Name)
+	switch name {
+	case "lock_timeout", "statement_timeout":
+	default:
+		return "", false, false
+	}
+	if node.Value == "" {
+		return name, false, true
+	}
+	timeout, ok := parseTimeoutValue(node.Value)
+	// The timeout is in milliseconds, and the zero value disables the timeout.
+	return name, ok && timeout >= time.Millisecond, true
+}
+
+// timeoutUnits are the time units of the PostgreSQL configuration parameters.
+var timeoutUnits = map[string]time.Duration{
+	"us":  time.Microsecond,
+	"ms":  time.Millisecond,
+	"s":   time.Second,
+	"min": time.Minute,
+	"h":   time.Hour,
+	"d":   24 * time.Hour,
+}
+
+// parseTimeoutValue parses the value of lock_timeout or statement_timeout, e.g. 5000, '3s' and '1.5 min'.
+// The value without the unit is in milliseconds.
+func parseTimeoutValue(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	i := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	number, unit := value, "ms"
+	if i >= 0 {
+		number, unit = value[:i], strings.TrimSpace(value[i:])
+	}
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, false
+	}
+	scale, ok := timeoutUnits[unit]
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(n * float64(scale)), true
+}
+
+// isLockingDDL returns whether the statement locks the existing objects.
+func isLockingDDL(node ast.Node) bool {
+	switch node.(type) {
+	case *ast.CreateTableStmt, *ast.CreateSchemaStmt, *ast.CreateDatabaseStmt, *ast.CreateSequenceStmt,
+		*ast.CreateTypeStmt, *ast.CreateFunctionStmt, *ast.CreateExtensionStmt:
+		// Creating new objects does not wait for the lock on the existing objects.
+		return false
+	case ast.DDLNode, *ast.VacuumStmt, *ast.ClusterStmt, *ast.ReindexStmt:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package pg
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementSetNotNullRequireCheckAdvisor)(nil)
+	_ ast.Visitor     = (*statementSetNotNullRequireCheckChecker)(nil)
+
+	notNullCheckPattern = regexp.MustCompile(`(?i)^\(*\s*"?([^"\s()]+)"?\s+IS\s+NOT\s+NULL\s*\)*$`)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_POSTGRES, advisor.PostgreSQLSetNotNullRequireCheck, &StatementSetNotNullRequireCheckAdvisor{})
+}
+
+// StatementSetNotNullRequireCheckAdvisor is the advisor checking for SET NOT NULL requiring a validated CHECK constraint.
+type StatementSetNotNullRequireCheckAdvisor struct {
+}
+
+// Check checks for SET NOT NULL requiring a validated CHECK constraint.
+func (*StatementSetNotNullRequireCheckAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, ok := ctx.AST.([]ast.Node)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Node")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &statementSetNotNullRequireCheckChecker{
+		level:       level,
+		title:       string(ctx.Rule.Type),
+		catalog:     ctx.Catalog,
+		checkMap:    make(map[string]columnName),
+		validColumn: make(map[columnName]bool),
+	}
+
+	for _, stmt := range stmtList {
+		checker.text = stmt.Text()
+		checker.line = stmt.LastLine()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type statementSetNotNullRequireCheckChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	text       string
+	line       int
+	catalog    *catalog.Finder
+	// checkMap is the map from the NOT VALID CHECK (column IS NOT NULL) constraint name to the column.
+	checkMap map[string]columnName
+	// validColumn is the set of the columns having a validated CHECK (column IS NOT NULL) constraint.
+	validColumn map[columnName]bool
+}
+
+// Visit implements ast.Visitor interface.
+func (checker *statementSetNotNullRequireCheckChecker) Visit(in ast.Node) ast.Visitor {
+	switch node := in.(type) {
+	case *ast.AddConstraintStmt:
+		if node.Constraint.Type != ast.ConstraintTypeCheck || node.Constraint.Expression == nil {
+			break
+		}
+		match := notNullCheckPattern.FindStringSubmatch(node.Constraint.Expression.Text())
+		if match == nil {
+			break
+		}
+		column := columnName{
+			schema: normalizeSchemaName(node.Table.Schema),
+			table:  node.Table.Name,
+			column: match[1],
+		}
+		if node.Constraint.SkipValidation {
+			checker.checkMap[checkConstraintKey(node.Table, node.Constraint.Name)] = column
+		} else {
+			checker.validColumn[column] = true
+		}
+	case *ast.ValidateConstraintStmt:
+		if column, exists := checker.checkMap[checkConstraintKey(node.Table, node.ConstraintName)]; exists {
+			checker.validColumn[column] = true
+		}
+	case *ast.SetNotNullStmt:
+		column := columnName{
+			schema: normalizeSchemaName(node.Table.Schema),
+			table:  node.Table.Name,
+			column: node.ColumnName,
+		}
+		if checker.validColumn[column] {
+			break
+		}
+		// Only the nullable column in the original schema needs to scan the existing rows.
+		columnState := checker.catalog.Origin.FindColumn(&catalog.ColumnFind{
+			SchemaName: column.schema,
+			TableName:  column.table,
+			ColumnName: column.column,
+		})
+		if columnState == nil || !columnState.Nullable() {
+			break
+		}
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  checker.level,
+			Code:    advisor.StatementSetNotNullWithoutCheck,
+			Title:   checker.title,
+			Content: fmt.Sprintf("Setting NOT NULL on column \"%s\" in table %s scans the table with an ACCESS EXCLUSIVE lock. You can add CHECK (\"%s\" IS NOT NULL) NOT VALID and validate it before the statement \"%s\"", column.column, column.normalizeTableName(), column.column, checker.text),
+			Line:    checker.line,
+		})
+	}
+
+	return checker
+}
+
+func checkConstraintKey(table *ast.TableDef, constraintName string) string {
+	return fmt.Sprintf("%s.%s", normalizeTableName(table, PostgreSQLPublicSchema), constraintName)
+}
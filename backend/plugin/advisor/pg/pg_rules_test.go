@@ -47,6 +47,13 @@ func TestPostgreSQLRules(t *testing.T) {
 		advisor.SchemaRuleCreateIndexConcurrently,
 		advisor.SchemaRuleStatementAddCheckNotValid,
 		advisor.SchemaRuleStatementDisallowAddNotNull,
+		advisor.SchemaRuleStatementDisallowTableRewrite,
+		advisor.SchemaRuleStatementSetNotNullRequireCheck,
+		advisor.SchemaRuleStatementAddFKNotValid,
+		advisor.SchemaRuleStatementDisallowBlockingMaintenance,
+		advisor.SchemaRuleStatementRequireLockTimeout,
+		advisor.SchemaRuleColumnDisallowRenameReferencedByView,
+		advisor.SchemaRuleCustom,
 	}
 
 	for _, rule := range pgRules {
@@ -3,7 +3,11 @@ package pg
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
 
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 )
 
@@ -46,3 +50,156 @@ func normalizeTableName(table *ast.TableDef, defaultSchema string) string {
 	}
 	return fmt.Sprintf("%q.%q", schema, table.Name)
 }
+
+var createIndexPattern = regexp.MustCompile(`(?i)^(CREATE\s+(?:UNIQUE\s+)?INDEX)\b`)
+
+// newCreateIndexConcurrentlyFix returns the fix adding CONCURRENTLY to the CREATE INDEX statement.
+func newCreateIndexConcurrentlyFix(text string) *advisor.Fix {
+	if !createIndexPattern.MatchString(text) {
+		return nil
+	}
+	return advisor.NewFix("Add CONCURRENTLY", text, createIndexPattern.ReplaceAllString(text, "${1} CONCURRENTLY"))
+}
+
+// newNotValidFix returns the fix appending NOT VALID to the ALTER TABLE statement.
+// Only the statement adding a single constraint can be fixed by appending.
+func newNotValidFix(stmt ast.Node) *advisor.Fix {
+	node, ok := stmt.(*ast.AlterTableStmt)
+	if !ok || len(node.AlterItemList) != 1 {
+		return nil
+	}
+	text := stmt.Text()
+	body := strings.TrimRightFunc(strings.TrimSuffix(text, ";"), unicode.IsSpace)
+	return advisor.NewFix("Add NOT VALID", text, body+" NOT VALID"+text[len(body):])
+}
+
+// getTemplateName returns the literal name expected by the naming template, e.g. "^idx_{{table}}_{{column_list}}$" expects "idx_tech_book_id_name".
+// It returns false if the template is a pattern rather than a literal name.
+func getTemplateName(template string, templateList []string, tokens map[string]string) (string, bool) {
+	for _, key := range templateList {
+		if token, ok := tokens[key]; ok {
+			template = strings.ReplaceAll(template, key, token)
+		}
+	}
+	// The template may allow the empty name, such as "^$|^idx_{{table}}_{{column_list}}$".
+	for _, alternative := range strings.Split(template, "|") {
+		name := strings.TrimSuffix(strings.TrimPrefix(alternative, "^"), "$")
+		if name == "" {
+			continue
+		}
+		if regexp.QuoteMeta(name) != name {
+			return "", false
+		}
+		return name, true
+	}
+	return "", false
+}
+
+// newRenameFix returns the fix renaming the only occurrence of the name in the statement text.
+func newRenameFix(text, name, newName string) *advisor.Fix {
+	if name == "" || newName == "" {
+		return nil
+	}
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+	locList := pattern.FindAllStringIndex(text, -1)
+	if len(locList) != 1 {
+		return nil
+	}
+	loc := locList[0]
+	return advisor.NewFix(fmt.Sprintf("Rename to %q", newName), text, text[:loc[0]]+newName+text[loc[1]:])
+}
+
+var simpleIdentifierPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// quoteIdentifier quotes the identifier unless it's a simple lower case one.
+func quoteIdentifier(name string) string {
+	if simpleIdentifierPattern.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+// getRequiredColumnType returns the column type for adding the required column, which is guessed from the column name.
+func getRequiredColumnType(column string) string {
+	column = strings.ToLower(column)
+	switch {
+	case column == "id" || strings.HasSuffix(column, "_id"):
+		return "BIGINT NOT NULL"
+	case strings.HasSuffix(column, "_ts") || strings.HasSuffix(column, "_at") || strings.HasSuffix(column, "_time"):
+		return "TIMESTAMPTZ NOT NULL DEFAULT now()"
+	default:
+		return "TEXT"
+	}
+}
+
+// newRequiredColumnsFix returns the fix adding the missing columns to the end of the column list of the CREATE TABLE statement.
+func newRequiredColumnsFix(stmt *ast.CreateTableStmt, missingColumns []string) *advisor.Fix {
+	if len(stmt.ColumnList) == 0 {
+		return nil
+	}
+	text := stmt.Text()
+	end := findColumnListEnd(text)
+	if end < 0 {
+		return nil
+	}
+	var buf strings.Builder
+	for _, column := range missingColumns {
+		_, _ = fmt.Fprintf(&buf, ", %s %s", quoteIdentifier(column), getRequiredColumnType(column))
+	}
+	// Keep the whitespaces before the closing parenthesis after the added columns.
+	body := strings.TrimRightFunc(text[:end], unicode.IsSpace)
+	return advisor.NewFix("Add required columns", text, body+buf.String()+text[len(body):])
+}
+
+// findColumnListEnd returns the offset of the parenthesis closing the column list of the CREATE TABLE statement, or -1 if not found.
+func findColumnListEnd(text string) int {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\'', '"':
+			// Skip the string literal or the quoted identifier, the escaped quote is two quotes.
+			quote := text[i]
+			for i++; i < len(text) && text[i] != quote; i++ {
+			}
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+var alterTablePattern = regexp.MustCompile(`(?is)^(ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?(?:"(?:[^"]|"")+"|[^\s."]+)(?:\.(?:"(?:[^"]|"")+"|[^\s."]+))?)\s+(.+?)[\s;]*$`)
+
+// newMergeAlterTableFix returns the fix merging the adjacent ALTER TABLE statements into one.
+// The actions which PostgreSQL cannot combine, such as RENAME, SET SCHEMA and ATTACH PARTITION, are not merged.
+func newMergeAlterTableFix(statements string, stmtList []*ast.AlterTableStmt) *advisor.Fix {
+	if len(stmtList) < 2 {
+		return nil
+	}
+	var prefix string
+	var textList, itemList []string
+	for i, stmt := range stmtList {
+		for _, item := range stmt.AlterItemList {
+			switch item.(type) {
+			case *ast.RenameTableStmt, *ast.RenameColumnStmt, *ast.RenameConstraintStmt, *ast.SetSchemaStmt, *ast.AttachPartitionStmt:
+				return nil
+			}
+		}
+		text := strings.TrimRight(strings.TrimSpace(stmt.Text()), "; \t\r\n")
+		matches := alterTablePattern.FindStringSubmatch(text)
+		if len(matches) != 3 {
+			return nil
+		}
+		if i == 0 {
+			prefix = matches[1]
+		}
+		textList = append(textList, text)
+		itemList = append(itemList, matches[2])
+	}
+	return advisor.NewMergeFix("Merge ALTER TABLE statements", statements, textList, stmtList[0].LastLine(), fmt.Sprintf("%s %s", prefix, strings.Join(itemList, ", ")))
+}
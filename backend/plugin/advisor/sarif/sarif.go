@@ -0,0 +1,247 @@
+// Package sarif converts the SQL review advice to the SARIF 2.1.0 log.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+// Only the properties consumed by the common SARIF viewers, such as GitHub code scanning, are defined.
+package sarif
+
+import (
+	"strconv"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+)
+
+const (
+	sarifVersion  = "2.1.0"
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName = "Bytebase SQL Review"
+	sarifToolURI  = "https://www.bytebase.com/docs/sql-review/review-rules"
+)
+
+// Log is the SARIF log.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []*Run `json:"runs"`
+}
+
+// Run is a run of the SQL review.
+type Run struct {
+	Tool    Tool      `json:"tool"`
+	Results []*Result `json:"results"`
+}
+
+// Tool is the tool of a run.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver is the tool component.
+type Driver struct {
+	Name           string  `json:"name"`
+	Version        string  `json:"version,omitempty"`
+	InformationURI string  `json:"informationUri"`
+	Rules          []*Rule `json:"rules"`
+}
+
+// Rule is the SQL review rule reported by the results.
+type Rule struct {
+	ID               string  `json:"id"`
+	ShortDescription Message `json:"shortDescription"`
+	HelpURI          string  `json:"helpUri,omitempty"`
+}
+
+// Result is a problem found by the SQL review.
+type Result struct {
+	RuleID     string         `json:"ruleId"`
+	RuleIndex  int            `json:"ruleIndex"`
+	Level      string         `json:"level"`
+	Message    Message        `json:"message"`
+	Locations  []*Location    `json:"locations,omitempty"`
+	Fixes      []*Fix         `json:"fixes,omitempty"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Message is a plain text message.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Location is the location of a result.
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation is the location in a file.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+// ArtifactLocation is the file location.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region is the region in a file, lines and columns are 1-based.
+// The end column is the column of the character following the region.
+type Region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// Fix is the proposed fix of a result.
+type Fix struct {
+	Description     Message           `json:"description"`
+	ArtifactChanges []*ArtifactChange `json:"artifactChanges"`
+}
+
+// ArtifactChange is the change of a file.
+type ArtifactChange struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Replacements     []*Replacement   `json:"replacements"`
+}
+
+// Replacement replaces the deleted region with the inserted content.
+type Replacement struct {
+	DeletedRegion   Region          `json:"deletedRegion"`
+	InsertedContent ArtifactContent `json:"insertedContent"`
+}
+
+// ArtifactContent is the text content.
+type ArtifactContent struct {
+	Text string `json:"text"`
+}
+
+// FileAdvice is the advice of a checked file.
+type FileAdvice struct {
+	// Path is the path of the file, the location of the advice is omitted if it is empty.
+	Path    string           `json:"path"`
+	Advices []advisor.Advice `json:"advices"`
+}
+
+// Convert converts the advice of the checked files to a SARIF log.
+// The successful advice is skipped because SARIF only reports the problems.
+func Convert(version string, results []*FileAdvice) *Log {
+	run := &Run{
+		Tool: Tool{
+			Driver: Driver{
+				Name:           sarifToolName,
+				Version:        version,
+				InformationURI: sarifToolURI,
+				Rules:          []*Rule{},
+			},
+		},
+		Results: []*Result{},
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, result := range results {
+		for _, advice := range result.Advices {
+			if advice.Status == advisor.Success {
+				continue
+			}
+			ruleID, description := getRule(advice)
+			index, ok := ruleIndex[ruleID]
+			if !ok {
+				index = len(run.Tool.Driver.Rules)
+				ruleIndex[ruleID] = index
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, &Rule{
+					ID:               ruleID,
+					ShortDescription: Message{Text: description},
+					HelpURI:          sarifToolURI,
+				})
+			}
+
+			item := &Result{
+				RuleID:    ruleID,
+				RuleIndex: index,
+				Level:     convertToLevel(advice.Status),
+				Message:   Message{Text: advice.Content},
+				Properties: map[string]any{
+					"code": advice.Code,
+				},
+			}
+			if item.Message.Text == "" {
+				item.Message.Text = advice.Title
+			}
+			if result.Path != "" {
+				region := &Region{StartLine: advice.Line}
+				if region.StartLine < 1 {
+					region.StartLine = 1
+				}
+				// The advice columns are 0-based as the fix columns, and SARIF columns are 1-based.
+				// The column 0 is left out as SARIF defaults to the first column.
+				if advice.Column > 0 {
+					region.StartColumn = advice.Column + 1
+				}
+				item.Locations = []*Location{
+					{
+						PhysicalLocation: PhysicalLocation{
+							ArtifactLocation: ArtifactLocation{URI: result.Path},
+							Region:           region,
+						},
+					},
+				}
+				if advice.Fix != nil {
+					item.Fixes = []*Fix{convertFix(result.Path, advice.Fix)}
+				}
+			}
+			run.Results = append(run.Results, item)
+		}
+	}
+
+	return &Log{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []*Run{run},
+	}
+}
+
+// getRule returns the rule ID and the description of the advice.
+// The rule ID is the stable rule type, the titles of the advice such as the custom rule titles are not.
+// The advice not reported by a rule, e.g. the syntax error, falls back to its code.
+func getRule(advice advisor.Advice) (string, string) {
+	if advice.RuleType != "" {
+		return advice.RuleType, advice.RuleType
+	}
+	description := advice.Title
+	if description == "" {
+		description = advice.Content
+	}
+	return strconv.Itoa(int(advice.Code)), description
+}
+
+func convertFix(path string, fix *advisor.Fix) *Fix {
+	return &Fix{
+		Description: Message{Text: fix.Description},
+		ArtifactChanges: []*ArtifactChange{
+			{
+				ArtifactLocation: ArtifactLocation{URI: path},
+				Replacements: []*Replacement{
+					{
+						// The fix columns are 0-based.
+						DeletedRegion: Region{
+							StartLine:   fix.StartLine,
+							StartColumn: fix.StartColumn + 1,
+							EndLine:     fix.EndLine,
+							EndColumn:   fix.EndColumn + 1,
+						},
+						InsertedContent: ArtifactContent{Text: fix.NewText},
+					},
+				},
+			},
+		},
+	}
+}
+
+func convertToLevel(status advisor.Status) string {
+	switch status {
+	case advisor.Error:
+		return "error"
+	case advisor.Warn:
+		return "warning"
+	default:
+		return "note"
+	}
+}
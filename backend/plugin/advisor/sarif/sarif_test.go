@@ -0,0 +1,137 @@
+package sarif
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+)
+
+func TestConvert(t *testing.T) {
+	a := require.New(t)
+
+	log := Convert("2.12.0", []*FileAdvice{
+		{
+			Path: "migrations/0001.sql",
+			Advices: []advisor.Advice{
+				{Status: advisor.Warn, Code: advisor.StatementSelectAll, Title: "statement.select.no-select-all", RuleType: "statement.select.no-select-all", Content: "\"SELECT *\" uses SELECT all", Line: 3},
+				{Status: advisor.Error, Code: advisor.StatementNoWhere, Title: "statement.where.require", RuleType: "statement.where.require", Content: "\"DELETE FROM t\" requires WHERE clause", Line: 0},
+				{Status: advisor.Warn, Code: advisor.CreateIndexUnconcurrently, Title: "index.create-concurrently", RuleType: "index.create-concurrently", Content: "Creating indexes will block writes on the table, unless use CONCURRENTLY", Line: 5, Fix: &advisor.Fix{
+					Description: "Add CONCURRENTLY",
+					StartLine:   5,
+					StartColumn: 12,
+					EndLine:     5,
+					EndColumn:   12,
+					NewText:     " CONCURRENTLY",
+				}},
+			},
+		},
+		{
+			Path: "migrations/0002.sql",
+			Advices: []advisor.Advice{
+				{Status: advisor.Success, Code: advisor.Ok, Title: "OK"},
+				{Status: advisor.Warn, Code: advisor.StatementSelectAll, Title: "statement.select.no-select-all", RuleType: "statement.select.no-select-all", Content: "\"SELECT *\" uses SELECT all", Line: 1, Column: 5},
+			},
+		},
+	})
+
+	a.Equal("2.1.0", log.Version)
+	a.Len(log.Runs, 1)
+	run := log.Runs[0]
+	a.Equal("2.12.0", run.Tool.Driver.Version)
+	a.Len(run.Tool.Driver.Rules, 3)
+	a.Len(run.Results, 4)
+
+	a.Equal("warning", run.Results[0].Level)
+	a.Equal(0, run.Results[0].RuleIndex)
+	a.Equal("migrations/0001.sql", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	a.Equal(3, run.Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+
+	a.Equal("error", run.Results[1].Level)
+	a.Equal(1, run.Results[1].RuleIndex)
+	a.Equal(1, run.Results[1].Locations[0].PhysicalLocation.Region.StartLine)
+
+	a.Nil(run.Results[1].Fixes)
+
+	a.Equal(2, run.Results[2].RuleIndex)
+	a.Len(run.Results[2].Fixes, 1)
+	replacement := run.Results[2].Fixes[0].ArtifactChanges[0].Replacements[0]
+	a.Equal(Region{StartLine: 5, StartColumn: 13, EndLine: 5, EndColumn: 13}, replacement.DeletedRegion)
+	a.Equal(" CONCURRENTLY", replacement.InsertedContent.Text)
+
+	a.Equal(0, run.Results[3].RuleIndex)
+	a.Equal(6, run.Results[3].Locations[0].PhysicalLocation.Region.StartColumn)
+}
+
+func TestConvertColumns(t *testing.T) {
+	a := require.New(t)
+
+	// The advice and its fix point at the same 0-based column, e.g. the syntax error listener and the fix range of the advisors.
+	log := Convert("2.12.0", []*FileAdvice{
+		{
+			Path: "migrations/0001.sql",
+			Advices: []advisor.Advice{
+				{Status: advisor.Warn, Code: advisor.CreateIndexUnconcurrently, Title: "index.create-concurrently", RuleType: "index.create-concurrently", Content: "Creating indexes will block writes on the table, unless use CONCURRENTLY", Line: 2, Column: 12, Fix: &advisor.Fix{
+					Description: "Add CONCURRENTLY",
+					StartLine:   2,
+					StartColumn: 12,
+					EndLine:     2,
+					EndColumn:   12,
+					NewText:     " CONCURRENTLY",
+				}},
+				{Status: advisor.Warn, Code: advisor.CreateIndexUnconcurrently, Title: "index.create-concurrently", RuleType: "index.create-concurrently", Content: "Creating indexes will block writes on the table, unless use CONCURRENTLY", Line: 4, Column: 0, Fix: &advisor.Fix{
+					Description: "Add CONCURRENTLY",
+					StartLine:   4,
+					StartColumn: 0,
+					EndLine:     4,
+					EndColumn:   6,
+					NewText:     "CREATE INDEX CONCURRENTLY",
+				}},
+			},
+		},
+	})
+
+	results := log.Runs[0].Results
+	a.Len(results, 2)
+	for _, result := range results {
+		region := result.Locations[0].PhysicalLocation.Region
+		deleted := result.Fixes[0].ArtifactChanges[0].Replacements[0].DeletedRegion
+		a.Equal(deleted.StartLine, region.StartLine)
+		// The omitted SARIF start column defaults to 1.
+		startColumn := region.StartColumn
+		if startColumn == 0 {
+			startColumn = 1
+		}
+		a.Equal(deleted.StartColumn, startColumn)
+	}
+	a.Equal(13, results[0].Locations[0].PhysicalLocation.Region.StartColumn)
+	a.Equal(1, results[1].Fixes[0].ArtifactChanges[0].Replacements[0].DeletedRegion.StartColumn)
+}
+
+func TestConvertRuleID(t *testing.T) {
+	a := require.New(t)
+
+	log := Convert("2.12.0", []*FileAdvice{
+		{
+			Path: "migrations/0001.sql",
+			Advices: []advisor.Advice{
+				{Status: advisor.Error, Code: advisor.StatementSyntaxError, Title: advisor.SyntaxErrorTitle, Content: "Syntax error at line 1", Line: 1},
+				// The custom rules are reported with their own titles.
+				{Status: advisor.Warn, Code: advisor.CustomRuleViolation, Title: "custom.no-delete", RuleType: "custom.cel", Content: "DELETE is not allowed", Line: 2},
+				{Status: advisor.Warn, Code: advisor.CustomRuleViolation, Title: "custom.no-update", RuleType: "custom.cel", Content: "UPDATE is not allowed", Line: 3},
+			},
+		},
+	})
+
+	run := log.Runs[0]
+	a.Len(run.Tool.Driver.Rules, 2)
+	// The advice not reported by a rule falls back to its code.
+	a.Equal(strconv.Itoa(int(advisor.StatementSyntaxError)), run.Results[0].RuleID)
+	a.Equal(advisor.SyntaxErrorTitle, run.Tool.Driver.Rules[0].ShortDescription.Text)
+	// The rule ID is the rule type, whatever the title is.
+	a.Equal("custom.cel", run.Results[1].RuleID)
+	a.Equal("custom.cel", run.Results[2].RuleID)
+	a.Equal(1, run.Results[2].RuleIndex)
+}
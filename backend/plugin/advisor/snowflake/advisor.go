@@ -1,6 +1,15 @@
 // Package snowflake is the advisor for snowflake database.
 package snowflake
 
+import (
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+
+	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+)
+
 // currentConstraintAction is the action of current constraint.
 type currentConstraintAction int
 
@@ -9,3 +18,84 @@ const (
 	currentConstraintActionAdd
 	currentConstraintActionDrop
 )
+
+// normalizeColumnList returns the normalized column names in the column list.
+func normalizeColumnList(ctx parser.IColumn_list_in_parenthesesContext) []string {
+	if ctx == nil || ctx.Column_list() == nil {
+		return nil
+	}
+	var columns []string
+	for _, column := range ctx.Column_list().AllColumn_name() {
+		columns = append(columns, snowsqlparser.NormalizeSnowSQLObjectNamePart(column.Id_()))
+	}
+	return columns
+}
+
+// normalizeColumnType returns the upper case column type without the optional spaces, e.g. "NUMBER(10,2)" for "number(10, 2)".
+func normalizeColumnType(ctx parser.IData_typeContext) string {
+	text := ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx)
+	return columnTypeSpaceReplacer.Replace(strings.ToUpper(strings.Join(strings.Fields(text), " ")))
+}
+
+var columnTypeSpaceReplacer = strings.NewReplacer(" (", "(", "( ", "(", " )", ")", " ,", ",", ", ", ",")
+
+// baseColumnType returns the column type without the length, precision and scale, e.g. "NUMBER" for "NUMBER(10,2)".
+func baseColumnType(tp string) string {
+	if i := strings.Index(tp, "("); i > 0 {
+		return tp[:i]
+	}
+	return tp
+}
+
+// unquoteString returns the content of the string literal with the doubled single quotes unescaped.
+func unquoteString(ctx parser.IStringContext) string {
+	if ctx == nil {
+		return ""
+	}
+	text := ctx.GetText()
+	if len(text) >= 2 && text[0] == '\'' && text[len(text)-1] == '\'' {
+		text = strings.ReplaceAll(text[1:len(text)-1], "''", "'")
+	}
+	return text
+}
+
+// statementText returns the text of the statement without the trailing semicolon.
+func statementText(p antlr.Parser, ctx antlr.RuleContext) string {
+	return strings.TrimRight(p.GetTokenStream().GetTextFromRuleContext(ctx), " \t\r\n;")
+}
+
+// columnTypeSet is a set of upper case column types.
+type columnTypeSet map[string]bool
+
+func newColumnTypeSet(list []string) columnTypeSet {
+	set := make(columnTypeSet)
+	for _, tp := range list {
+		set[columnTypeSpaceReplacer.Replace(strings.ToUpper(strings.Join(strings.Fields(tp), " ")))] = true
+	}
+	return set
+}
+
+// contains returns true if the set contains the column type or the column type without the length, precision and scale.
+func (s columnTypeSet) contains(tp string) bool {
+	return s[tp] || s[baseColumnType(tp)]
+}
+
+// normalizeFullColumnName returns the normalized table name in the form of "DB.SCHEMA.TABLE" and the normalized column name,
+// it is consistent with snowsqlparser.NormalizeSnowSQLObjectName with the fallback schema "PUBLIC".
+func normalizeFullColumnName(ctx parser.IFull_column_nameContext) (string, string) {
+	var database string
+	if ctx.GetDb_name() != nil {
+		database = snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.GetDb_name())
+	}
+	schema := "PUBLIC"
+	if ctx.GetSchema() != nil {
+		if s := snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.GetSchema()); s != "" {
+			schema = s
+		}
+	}
+	parts := []string{database, schema}
+	if ctx.GetTab_name() != nil {
+		parts = append(parts, snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.GetTab_name()))
+	}
+	return strings.Join(parts, "."), snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.GetCol_name())
+}
@@ -0,0 +1,230 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnCommentConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakeColumnCommentConvention, &ColumnCommentConventionAdvisor{})
+}
+
+// ColumnCommentConventionAdvisor is the advisor checking for column comment convention.
+type ColumnCommentConventionAdvisor struct {
+}
+
+// Check checks for column comment convention.
+func (*ColumnCommentConventionAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalCommentConventionRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &columnCommentConventionChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		payload:   payload,
+		commented: make(map[columnReference]bool),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// columnReference is the column identified by the normalized table name and the normalized column name.
+type columnReference struct {
+	normalizedTableName string
+	columnName          string
+}
+
+// createdColumn is the column created by CREATE TABLE or ALTER TABLE ... ADD COLUMN.
+type createdColumn struct {
+	column            columnReference
+	originalTableName string
+	line              int
+}
+
+// columnCommentConventionChecker is the listener for column comment convention.
+// The comment of the created column can be given by the COMMENT clause, COMMENT ON COLUMN or ALTER TABLE ... ALTER COLUMN ... COMMENT.
+type columnCommentConventionChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level   advisor.Status
+	title   string
+	payload *advisor.CommentConventionRulePayload
+	// currentNormalizedTableName and currentOriginalTableName are set when entering create_table, alter_table and alter_table_alter_column.
+	currentNormalizedTableName string
+	currentOriginalTableName   string
+	// createdColumnList is the list of the created columns in order.
+	createdColumnList []createdColumn
+	// commented is the set of the columns with the comment.
+	commented map[columnReference]bool
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *columnCommentConventionChecker) generateAdvice() ([]advisor.Advice, error) {
+	if l.payload.Required {
+		for _, created := range l.createdColumnList {
+			if l.commented[created.column] {
+				continue
+			}
+			l.adviceList = append(l.adviceList, advisor.Advice{
+				Status:  l.level,
+				Code:    advisor.NoColumnComment,
+				Title:   l.title,
+				Content: fmt.Sprintf("Column %s in table %s requires comments", created.column.columnName, created.originalTableName),
+				Line:    created.line,
+			})
+		}
+	}
+
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *columnCommentConventionChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.enterTable(ctx.Object_name())
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *columnCommentConventionChecker) ExitCreate_table(*parser.Create_tableContext) {
+	l.exitTable()
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *columnCommentConventionChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	l.enterTable(ctx.Object_name(0))
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *columnCommentConventionChecker) ExitAlter_table(*parser.Alter_tableContext) {
+	l.exitTable()
+}
+
+// EnterAlter_table_alter_column is called when production alter_table_alter_column is entered.
+func (l *columnCommentConventionChecker) EnterAlter_table_alter_column(ctx *parser.Alter_table_alter_columnContext) {
+	l.enterTable(ctx.Object_name())
+}
+
+// ExitAlter_table_alter_column is called when production alter_table_alter_column is exited.
+func (l *columnCommentConventionChecker) ExitAlter_table_alter_column(*parser.Alter_table_alter_columnContext) {
+	l.exitTable()
+}
+
+// EnterFull_col_decl is called when production full_col_decl is entered.
+func (l *columnCommentConventionChecker) EnterFull_col_decl(ctx *parser.Full_col_declContext) {
+	if l.currentNormalizedTableName == "" {
+		return
+	}
+	column := l.addCreatedColumn(ctx.Col_decl().Column_name().Id_(), ctx.GetStart().GetLine())
+	if ctx.COMMENT() != nil {
+		l.addComment(column, l.currentOriginalTableName, ctx.String_(), ctx.GetStart().GetLine())
+	}
+}
+
+// EnterTable_column_action is called when production table_column_action is entered.
+func (l *columnCommentConventionChecker) EnterTable_column_action(ctx *parser.Table_column_actionContext) {
+	if l.currentNormalizedTableName == "" || ctx.ADD() == nil || ctx.Data_type() == nil {
+		return
+	}
+	// ADD COLUMN has no COMMENT clause, the comment has to be given by the following statements.
+	l.addCreatedColumn(ctx.Column_name(0).Id_(), ctx.GetStart().GetLine())
+}
+
+// EnterAlter_column_decl is called when production alter_column_decl is entered.
+func (l *columnCommentConventionChecker) EnterAlter_column_decl(ctx *parser.Alter_column_declContext) {
+	if l.currentNormalizedTableName == "" || ctx.Alter_column_opts().Comment_clause() == nil {
+		return
+	}
+	column := columnReference{
+		normalizedTableName: l.currentNormalizedTableName,
+		columnName:          snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.Column_name().Id_()),
+	}
+	l.addComment(column, l.currentOriginalTableName, ctx.Alter_column_opts().Comment_clause().String_(), ctx.GetStart().GetLine())
+}
+
+// EnterComment is called when production comment is entered.
+func (l *columnCommentConventionChecker) EnterComment(ctx *parser.CommentContext) {
+	if ctx.COLUMN() == nil || ctx.Full_column_name() == nil {
+		return
+	}
+	normalizedTableName, columnName := normalizeFullColumnName(ctx.Full_column_name())
+	column := columnReference{
+		normalizedTableName: normalizedTableName,
+		columnName:          columnName,
+	}
+	originalTableName := ""
+	if tableName := ctx.Full_column_name().GetTab_name(); tableName != nil {
+		originalTableName = tableName.GetText()
+	}
+	l.addComment(column, originalTableName, ctx.String_(), ctx.GetStart().GetLine())
+}
+
+func (l *columnCommentConventionChecker) enterTable(objectName parser.IObject_nameContext) {
+	l.currentNormalizedTableName = snowsqlparser.NormalizeSnowSQLObjectName(objectName, "", "PUBLIC")
+	l.currentOriginalTableName = objectName.GetText()
+}
+
+func (l *columnCommentConventionChecker) exitTable() {
+	l.currentNormalizedTableName = ""
+	l.currentOriginalTableName = ""
+}
+
+func (l *columnCommentConventionChecker) addCreatedColumn(columnName parser.IId_Context, line int) columnReference {
+	column := columnReference{
+		normalizedTableName: l.currentNormalizedTableName,
+		columnName:          snowsqlparser.NormalizeSnowSQLObjectNamePart(columnName),
+	}
+	l.createdColumnList = append(l.createdColumnList, createdColumn{
+		column:            column,
+		originalTableName: l.currentOriginalTableName,
+		line:              line,
+	})
+	return column
+}
+
+func (l *columnCommentConventionChecker) addComment(column columnReference, originalTableName string, comment parser.IStringContext, line int) {
+	l.commented[column] = true
+	if l.payload.MaxLength >= 0 && utf8.RuneCountInString(unquoteString(comment)) > l.payload.MaxLength {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.ColumnCommentTooLong,
+			Title:   l.title,
+			Content: fmt.Sprintf("The length of column %s in table %s comment should be within %d characters", column.columnName, originalTableName, l.payload.MaxLength),
+			Line:    line,
+		})
+	}
+}
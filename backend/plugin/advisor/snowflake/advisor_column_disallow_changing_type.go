@@ -0,0 +1,98 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnDisallowChangingTypeAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakeColumnDisallowChangingType, &ColumnDisallowChangingTypeAdvisor{})
+}
+
+// ColumnDisallowChangingTypeAdvisor is the advisor checking for disallow changing column type.
+type ColumnDisallowChangingTypeAdvisor struct {
+}
+
+// Check checks for disallow changing column type.
+func (*ColumnDisallowChangingTypeAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &columnDisallowChangingTypeChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// columnDisallowChangingTypeChecker is the listener for disallow changing column type.
+// Snowflake has no catalog for the existing column types, so every ALTER COLUMN ... SET DATA TYPE is reported.
+type columnDisallowChangingTypeChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level advisor.Status
+	title string
+	// currentOriginalTableName is set when entering alter_table_alter_column.
+	currentOriginalTableName string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *columnDisallowChangingTypeChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterAlter_table_alter_column is called when production alter_table_alter_column is entered.
+func (l *columnDisallowChangingTypeChecker) EnterAlter_table_alter_column(ctx *parser.Alter_table_alter_columnContext) {
+	l.currentOriginalTableName = ctx.Object_name().GetText()
+}
+
+// ExitAlter_table_alter_column is called when production alter_table_alter_column is exited.
+func (l *columnDisallowChangingTypeChecker) ExitAlter_table_alter_column(*parser.Alter_table_alter_columnContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterAlter_column_decl is called when production alter_column_decl is entered.
+func (l *columnDisallowChangingTypeChecker) EnterAlter_column_decl(ctx *parser.Alter_column_declContext) {
+	if l.currentOriginalTableName == "" || ctx.Alter_column_opts().Data_type() == nil {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.ChangeColumnType,
+		Title:   l.title,
+		Content: fmt.Sprintf("The type of column %s in table %s is changed to %s", snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.Column_name().Id_()), l.currentOriginalTableName, normalizeColumnType(ctx.Alter_column_opts().Data_type())),
+		Line:    ctx.GetStart().GetLine(),
+	})
+}
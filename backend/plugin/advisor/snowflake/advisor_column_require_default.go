@@ -0,0 +1,131 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*RequireColumnDefaultAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakeRequireColumnDefault, &RequireColumnDefaultAdvisor{})
+}
+
+// RequireColumnDefaultAdvisor is the advisor checking for column default requirement.
+type RequireColumnDefaultAdvisor struct {
+}
+
+// Check checks for column default requirement.
+func (*RequireColumnDefaultAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &requireColumnDefaultChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// requireColumnDefaultChecker is the listener for column default requirement.
+type requireColumnDefaultChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level advisor.Status
+	title string
+	// currentOriginalTableName is set when entering create_table and alter_table.
+	currentOriginalTableName string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *requireColumnDefaultChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *requireColumnDefaultChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.currentOriginalTableName = ctx.Object_name().GetText()
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *requireColumnDefaultChecker) ExitCreate_table(*parser.Create_tableContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *requireColumnDefaultChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	l.currentOriginalTableName = ctx.Object_name(0).GetText()
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *requireColumnDefaultChecker) ExitAlter_table(*parser.Alter_tableContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterFull_col_decl is called when production full_col_decl is entered.
+func (l *requireColumnDefaultChecker) EnterFull_col_decl(ctx *parser.Full_col_declContext) {
+	if l.currentOriginalTableName == "" || len(ctx.AllDefault_value()) > 0 {
+		return
+	}
+	// The primary key column is not required to have a default value.
+	for _, constraint := range ctx.AllInline_constraint() {
+		if constraint.PRIMARY() != nil {
+			return
+		}
+	}
+	l.addAdvice(snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.Col_decl().Column_name().Id_()), ctx.GetStart().GetLine())
+}
+
+// EnterTable_column_action is called when production table_column_action is entered.
+func (l *requireColumnDefaultChecker) EnterTable_column_action(ctx *parser.Table_column_actionContext) {
+	if l.currentOriginalTableName == "" || ctx.ADD() == nil || ctx.Data_type() == nil {
+		return
+	}
+	if len(ctx.AllDEFAULT()) > 0 || ctx.AUTOINCREMENT() != nil || ctx.IDENTITY() != nil {
+		return
+	}
+	if ctx.Inline_constraint() != nil && ctx.Inline_constraint().PRIMARY() != nil {
+		return
+	}
+	l.addAdvice(snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.Column_name(0).Id_()), ctx.GetStart().GetLine())
+}
+
+func (l *requireColumnDefaultChecker) addAdvice(columnName string, line int) {
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.NoDefault,
+		Title:   l.title,
+		Content: fmt.Sprintf("Column %s in table %s doesn't have DEFAULT.", columnName, l.currentOriginalTableName),
+		Line:    line,
+	})
+}
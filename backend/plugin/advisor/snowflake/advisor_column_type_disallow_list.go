@@ -0,0 +1,147 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnTypeDisallowListAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakeColumnTypeDisallowList, &ColumnTypeDisallowListAdvisor{})
+}
+
+// ColumnTypeDisallowListAdvisor is the advisor checking for column type disallow list.
+type ColumnTypeDisallowListAdvisor struct {
+}
+
+// Check checks for column type disallow list.
+func (*ColumnTypeDisallowListAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalStringArrayTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &columnTypeDisallowListChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		disallowList: newColumnTypeSet(payload.List),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// columnTypeDisallowListChecker is the listener for column type disallow list.
+type columnTypeDisallowListChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level        advisor.Status
+	title        string
+	disallowList columnTypeSet
+	// currentOriginalTableName is set when entering create_table, alter_table and alter_table_alter_column.
+	currentOriginalTableName string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *columnTypeDisallowListChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *columnTypeDisallowListChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.currentOriginalTableName = ctx.Object_name().GetText()
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *columnTypeDisallowListChecker) ExitCreate_table(*parser.Create_tableContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *columnTypeDisallowListChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	l.currentOriginalTableName = ctx.Object_name(0).GetText()
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *columnTypeDisallowListChecker) ExitAlter_table(*parser.Alter_tableContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterAlter_table_alter_column is called when production alter_table_alter_column is entered.
+func (l *columnTypeDisallowListChecker) EnterAlter_table_alter_column(ctx *parser.Alter_table_alter_columnContext) {
+	l.currentOriginalTableName = ctx.Object_name().GetText()
+}
+
+// ExitAlter_table_alter_column is called when production alter_table_alter_column is exited.
+func (l *columnTypeDisallowListChecker) ExitAlter_table_alter_column(*parser.Alter_table_alter_columnContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterCol_decl is called when production col_decl is entered.
+func (l *columnTypeDisallowListChecker) EnterCol_decl(ctx *parser.Col_declContext) {
+	if l.currentOriginalTableName == "" || ctx.Data_type() == nil {
+		return
+	}
+	l.checkColumnType(ctx.Column_name().Id_(), ctx.Data_type(), ctx.GetStart().GetLine())
+}
+
+// EnterTable_column_action is called when production table_column_action is entered.
+func (l *columnTypeDisallowListChecker) EnterTable_column_action(ctx *parser.Table_column_actionContext) {
+	if l.currentOriginalTableName == "" || ctx.ADD() == nil || ctx.Data_type() == nil {
+		return
+	}
+	l.checkColumnType(ctx.Column_name(0).Id_(), ctx.Data_type(), ctx.GetStart().GetLine())
+}
+
+// EnterAlter_column_decl is called when production alter_column_decl is entered.
+func (l *columnTypeDisallowListChecker) EnterAlter_column_decl(ctx *parser.Alter_column_declContext) {
+	if l.currentOriginalTableName == "" || ctx.Alter_column_opts().Data_type() == nil {
+		return
+	}
+	l.checkColumnType(ctx.Column_name().Id_(), ctx.Alter_column_opts().Data_type(), ctx.GetStart().GetLine())
+}
+
+func (l *columnTypeDisallowListChecker) checkColumnType(columnName parser.IId_Context, dataType parser.IData_typeContext, line int) {
+	columnType := normalizeColumnType(dataType)
+	if !l.disallowList.contains(columnType) {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.DisabledColumnType,
+		Title:   l.title,
+		Content: fmt.Sprintf("Disallow column type %s but column %s in table %s is", columnType, snowsqlparser.NormalizeSnowSQLObjectNamePart(columnName), l.currentOriginalTableName),
+		Line:    line,
+	})
+}
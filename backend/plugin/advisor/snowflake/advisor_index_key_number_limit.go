@@ -0,0 +1,133 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*IndexKeyNumberLimitAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakeIndexKeyNumberLimit, &IndexKeyNumberLimitAdvisor{})
+}
+
+// IndexKeyNumberLimitAdvisor is the advisor checking for index key number limit.
+type IndexKeyNumberLimitAdvisor struct {
+}
+
+// Check checks for index key number limit.
+func (*IndexKeyNumberLimitAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &indexKeyNumberLimitChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+		max:   payload.Number,
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// indexKeyNumberLimitChecker is the listener for index key number limit.
+// Snowflake has no index, the keys of the PRIMARY KEY and UNIQUE constraints are checked.
+type indexKeyNumberLimitChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level advisor.Status
+	title string
+	max   int
+	// currentOriginalTableName is set when entering create_table and alter_table.
+	currentOriginalTableName string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *indexKeyNumberLimitChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *indexKeyNumberLimitChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.currentOriginalTableName = ctx.Object_name().GetText()
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *indexKeyNumberLimitChecker) ExitCreate_table(*parser.Create_tableContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *indexKeyNumberLimitChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	l.currentOriginalTableName = ctx.Object_name(0).GetText()
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *indexKeyNumberLimitChecker) ExitAlter_table(*parser.Alter_tableContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterOut_of_line_constraint is called when production out_of_line_constraint is entered.
+func (l *indexKeyNumberLimitChecker) EnterOut_of_line_constraint(ctx *parser.Out_of_line_constraintContext) {
+	if l.currentOriginalTableName == "" || (ctx.PRIMARY() == nil && ctx.UNIQUE() == nil) {
+		return
+	}
+	columns := normalizeColumnList(ctx.Column_list_in_parentheses(0))
+	if l.max <= 0 || len(columns) <= l.max {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.IndexKeyNumberExceedsLimit,
+		Title:   l.title,
+		Content: fmt.Sprintf("The number of keys of %s in table %s should be not greater than %d, but found %d", constraintDescription(ctx), l.currentOriginalTableName, l.max, len(columns)),
+		Line:    ctx.GetStart().GetLine(),
+	})
+}
+
+// constraintDescription returns the description of the out of line constraint, e.g. "primary key PK_T".
+func constraintDescription(ctx parser.IOut_of_line_constraintContext) string {
+	kind := "foreign key"
+	switch {
+	case ctx.PRIMARY() != nil:
+		kind = "primary key"
+	case ctx.UNIQUE() != nil:
+		kind = "unique key"
+	}
+	if ctx.Id_() == nil {
+		return kind
+	}
+	return fmt.Sprintf("%s %s", kind, snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.Id_()))
+}
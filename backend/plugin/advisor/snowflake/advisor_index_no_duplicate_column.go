@@ -0,0 +1,124 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*IndexNoDuplicateColumnAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakeIndexNoDuplicateColumn, &IndexNoDuplicateColumnAdvisor{})
+}
+
+// IndexNoDuplicateColumnAdvisor is the advisor checking for no duplicate columns in index.
+type IndexNoDuplicateColumnAdvisor struct {
+}
+
+// Check checks for no duplicate columns in index.
+func (*IndexNoDuplicateColumnAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &indexNoDuplicateColumnChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// indexNoDuplicateColumnChecker is the listener for no duplicate columns in index.
+// Snowflake has no index, the keys of the PRIMARY KEY, UNIQUE and FOREIGN KEY constraints are checked.
+type indexNoDuplicateColumnChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level advisor.Status
+	title string
+	// currentOriginalTableName is set when entering create_table and alter_table.
+	currentOriginalTableName string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *indexNoDuplicateColumnChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *indexNoDuplicateColumnChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.currentOriginalTableName = ctx.Object_name().GetText()
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *indexNoDuplicateColumnChecker) ExitCreate_table(*parser.Create_tableContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *indexNoDuplicateColumnChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	l.currentOriginalTableName = ctx.Object_name(0).GetText()
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *indexNoDuplicateColumnChecker) ExitAlter_table(*parser.Alter_tableContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterOut_of_line_constraint is called when production out_of_line_constraint is entered.
+func (l *indexNoDuplicateColumnChecker) EnterOut_of_line_constraint(ctx *parser.Out_of_line_constraintContext) {
+	if l.currentOriginalTableName == "" {
+		return
+	}
+	// The foreign key has the referenced column list as the second one, which is not checked.
+	column, duplicate := duplicateColumn(normalizeColumnList(ctx.Column_list_in_parentheses(0)))
+	if !duplicate {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.DuplicateColumnInIndex,
+		Title:   l.title,
+		Content: fmt.Sprintf("The %s in table %s has duplicate column %s", constraintDescription(ctx), l.currentOriginalTableName, column),
+		Line:    ctx.GetStart().GetLine(),
+	})
+}
+
+// duplicateColumn returns the first duplicate column in the column list.
+func duplicateColumn(columns []string) (string, bool) {
+	seen := make(map[string]bool)
+	for _, column := range columns {
+		if seen[column] {
+			return column, true
+		}
+		seen[column] = true
+	}
+	return "", false
+}
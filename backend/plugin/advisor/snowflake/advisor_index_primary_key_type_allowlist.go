@@ -0,0 +1,156 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*PrimaryKeyTypeAllowlistAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakePrimaryKeyTypeAllowlist, &PrimaryKeyTypeAllowlistAdvisor{})
+}
+
+// PrimaryKeyTypeAllowlistAdvisor is the advisor checking for primary key type allowlist.
+type PrimaryKeyTypeAllowlistAdvisor struct {
+}
+
+// Check checks for primary key type allowlist.
+func (*PrimaryKeyTypeAllowlistAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalStringArrayTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &primaryKeyTypeAllowlistChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		allowlist: newColumnTypeSet(payload.List),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// primaryKeyTypeAllowlistChecker is the listener for primary key type allowlist.
+type primaryKeyTypeAllowlistChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level     advisor.Status
+	title     string
+	allowlist columnTypeSet
+	// currentOriginalTableName is set when entering create_table and alter_table.
+	currentOriginalTableName string
+	// currentColumnType is a map of normalized column name to column type in the creating table.
+	currentColumnType map[string]string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *primaryKeyTypeAllowlistChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *primaryKeyTypeAllowlistChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.currentOriginalTableName = ctx.Object_name().GetText()
+	l.currentColumnType = make(map[string]string)
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *primaryKeyTypeAllowlistChecker) ExitCreate_table(*parser.Create_tableContext) {
+	l.currentOriginalTableName = ""
+	l.currentColumnType = nil
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *primaryKeyTypeAllowlistChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	l.currentOriginalTableName = ctx.Object_name(0).GetText()
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *primaryKeyTypeAllowlistChecker) ExitAlter_table(*parser.Alter_tableContext) {
+	l.currentOriginalTableName = ""
+}
+
+// EnterFull_col_decl is called when production full_col_decl is entered.
+func (l *primaryKeyTypeAllowlistChecker) EnterFull_col_decl(ctx *parser.Full_col_declContext) {
+	if l.currentColumnType == nil || ctx.Col_decl().Data_type() == nil {
+		return
+	}
+	columnName := snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.Col_decl().Column_name().Id_())
+	columnType := normalizeColumnType(ctx.Col_decl().Data_type())
+	l.currentColumnType[columnName] = columnType
+	for _, constraint := range ctx.AllInline_constraint() {
+		if constraint.PRIMARY() != nil {
+			l.checkColumnType(columnName, columnType, ctx.GetStart().GetLine())
+		}
+	}
+}
+
+// EnterTable_column_action is called when production table_column_action is entered.
+func (l *primaryKeyTypeAllowlistChecker) EnterTable_column_action(ctx *parser.Table_column_actionContext) {
+	if l.currentOriginalTableName == "" || ctx.ADD() == nil || ctx.Data_type() == nil {
+		return
+	}
+	if ctx.Inline_constraint() == nil || ctx.Inline_constraint().PRIMARY() == nil {
+		return
+	}
+	columnName := snowsqlparser.NormalizeSnowSQLObjectNamePart(ctx.Column_name(0).Id_())
+	l.checkColumnType(columnName, normalizeColumnType(ctx.Data_type()), ctx.GetStart().GetLine())
+}
+
+// EnterOut_of_line_constraint is called when production out_of_line_constraint is entered.
+func (l *primaryKeyTypeAllowlistChecker) EnterOut_of_line_constraint(ctx *parser.Out_of_line_constraintContext) {
+	// The column types of the existing tables are unknown, only the primary key in CREATE TABLE is checked.
+	if l.currentColumnType == nil || ctx.PRIMARY() == nil {
+		return
+	}
+	for _, columnName := range normalizeColumnList(ctx.Column_list_in_parentheses(0)) {
+		if columnType, ok := l.currentColumnType[columnName]; ok {
+			l.checkColumnType(columnName, columnType, ctx.GetStart().GetLine())
+		}
+	}
+}
+
+func (l *primaryKeyTypeAllowlistChecker) checkColumnType(columnName, columnType string, line int) {
+	if l.allowlist.contains(columnType) {
+		return
+	}
+	l.adviceList = append(l.adviceList, advisor.Advice{
+		Status:  l.level,
+		Code:    advisor.IndexPKType,
+		Title:   l.title,
+		Content: fmt.Sprintf("The column %s in table %s is one of the primary key, but its type \"%s\" is not in allowlist", columnName, l.currentOriginalTableName, columnType),
+		Line:    line,
+	})
+}
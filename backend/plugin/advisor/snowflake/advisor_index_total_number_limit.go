@@ -0,0 +1,170 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*IndexTotalNumberLimitAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakeIndexTotalNumberLimit, &IndexTotalNumberLimitAdvisor{})
+}
+
+// IndexTotalNumberLimitAdvisor is the advisor checking for index total number limit.
+type IndexTotalNumberLimitAdvisor struct {
+}
+
+// Check checks for index total number limit.
+func (*IndexTotalNumberLimitAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &indexTotalNumberLimitChecker{
+		level:             level,
+		title:             string(ctx.Rule.Type),
+		max:               payload.Number,
+		tableCount:        make(map[string]int),
+		tableLine:         make(map[string]int),
+		tableOriginalName: make(map[string]string),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// indexTotalNumberLimitChecker is the listener for index total number limit.
+// Snowflake has no index, the PRIMARY KEY and UNIQUE constraints are counted.
+// The constraints of the existing tables are unknown, so only the constraints added by the statements are counted.
+type indexTotalNumberLimitChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level advisor.Status
+	title string
+	max   int
+	// currentNormalizedTableName is set when entering create_table and alter_table.
+	currentNormalizedTableName string
+	// tableCount is a map of normalized table name to the count of the constraints.
+	tableCount map[string]int
+	// tableLine is a map of normalized table name to the line of the last constraint.
+	tableLine map[string]int
+	// tableOriginalName is a map of normalized table name to original table name.
+	tableOriginalName map[string]string
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *indexTotalNumberLimitChecker) generateAdvice() ([]advisor.Advice, error) {
+	var tableList []string
+	for tableName := range l.tableCount {
+		tableList = append(tableList, tableName)
+	}
+	slices.SortFunc(tableList, func(a, b string) int {
+		return l.tableLine[a] - l.tableLine[b]
+	})
+	for _, tableName := range tableList {
+		if l.max <= 0 || l.tableCount[tableName] <= l.max {
+			continue
+		}
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.IndexCountExceedsLimit,
+			Title:   l.title,
+			Content: fmt.Sprintf("The count of index in table %s should be no more than %d, but found %d", l.tableOriginalName[tableName], l.max, l.tableCount[tableName]),
+			Line:    l.tableLine[tableName],
+		})
+	}
+
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *indexTotalNumberLimitChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.enterTable(ctx.Object_name())
+	l.tableCount[l.currentNormalizedTableName] = 0
+	l.tableLine[l.currentNormalizedTableName] = ctx.GetStart().GetLine()
+}
+
+// ExitCreate_table is called when production create_table is exited.
+func (l *indexTotalNumberLimitChecker) ExitCreate_table(*parser.Create_tableContext) {
+	l.currentNormalizedTableName = ""
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *indexTotalNumberLimitChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	l.enterTable(ctx.Object_name(0))
+}
+
+// ExitAlter_table is called when production alter_table is exited.
+func (l *indexTotalNumberLimitChecker) ExitAlter_table(*parser.Alter_tableContext) {
+	l.currentNormalizedTableName = ""
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *indexTotalNumberLimitChecker) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	normalizedTableName := snowsqlparser.NormalizeSnowSQLObjectName(ctx.Object_name(), "", "PUBLIC")
+	delete(l.tableCount, normalizedTableName)
+	delete(l.tableLine, normalizedTableName)
+}
+
+// EnterInline_constraint is called when production inline_constraint is entered.
+func (l *indexTotalNumberLimitChecker) EnterInline_constraint(ctx *parser.Inline_constraintContext) {
+	if ctx.PRIMARY() == nil && ctx.UNIQUE() == nil {
+		return
+	}
+	l.addConstraint(ctx.GetStart().GetLine())
+}
+
+// EnterOut_of_line_constraint is called when production out_of_line_constraint is entered.
+func (l *indexTotalNumberLimitChecker) EnterOut_of_line_constraint(ctx *parser.Out_of_line_constraintContext) {
+	if ctx.PRIMARY() == nil && ctx.UNIQUE() == nil {
+		return
+	}
+	// DROP CONSTRAINT does not reach here, it is not an out_of_line_constraint.
+	l.addConstraint(ctx.GetStart().GetLine())
+}
+
+func (l *indexTotalNumberLimitChecker) enterTable(objectName parser.IObject_nameContext) {
+	l.currentNormalizedTableName = snowsqlparser.NormalizeSnowSQLObjectName(objectName, "", "PUBLIC")
+	l.tableOriginalName[l.currentNormalizedTableName] = objectName.GetText()
+}
+
+func (l *indexTotalNumberLimitChecker) addConstraint(line int) {
+	if l.currentNormalizedTableName == "" {
+		return
+	}
+	l.tableCount[l.currentNormalizedTableName]++
+	l.tableLine[l.currentNormalizedTableName] = line
+}
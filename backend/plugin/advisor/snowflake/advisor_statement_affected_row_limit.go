@@ -0,0 +1,192 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementAffectedRowLimitAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakeStatementAffectedRowLimit, &StatementAffectedRowLimitAdvisor{})
+}
+
+// StatementAffectedRowLimitAdvisor is the advisor checking for UPDATE/DELETE affected row limit.
+type StatementAffectedRowLimitAdvisor struct {
+}
+
+// Check checks for UPDATE/DELETE affected row limit.
+func (*StatementAffectedRowLimitAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &statementAffectedRowLimitChecker{
+		level:  level,
+		title:  string(ctx.Rule.Type),
+		maxRow: payload.Number,
+		driver: ctx.Driver,
+		ctx:    ctx.Context,
+	}
+
+	if listener.driver != nil {
+		antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	}
+
+	return listener.generateAdvice()
+}
+
+// statementAffectedRowLimitChecker is the listener for UPDATE/DELETE affected row limit.
+// Snowflake EXPLAIN has no row estimation, the affected rows are counted by the SELECT COUNT(*) rewritten from the statement.
+type statementAffectedRowLimitChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level  advisor.Status
+	title  string
+	maxRow int
+	driver *sql.DB
+	ctx    context.Context
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *statementAffectedRowLimitChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterUpdate_statement is called when production update_statement is entered.
+func (l *statementAffectedRowLimitChecker) EnterUpdate_statement(ctx *parser.Update_statementContext) {
+	p := ctx.GetParser()
+	sources := []string{statementText(p, ctx.Object_name())}
+	if ctx.Table_sources() != nil {
+		sources = append(sources, statementText(p, ctx.Table_sources()))
+	}
+	var condition string
+	if ctx.Search_condition() != nil {
+		condition = statementText(p, ctx.Search_condition())
+	}
+	l.handleStmt(statementText(p, ctx), countStatement(sources, condition), ctx.GetStart().GetLine())
+}
+
+// EnterDelete_statement is called when production delete_statement is entered.
+func (l *statementAffectedRowLimitChecker) EnterDelete_statement(ctx *parser.Delete_statementContext) {
+	p := ctx.GetParser()
+	sources := []string{statementText(p, ctx.Object_name())}
+	for _, source := range ctx.AllTable_or_query() {
+		sources = append(sources, statementText(p, source))
+	}
+	var condition string
+	if ctx.Search_condition() != nil {
+		condition = statementText(p, ctx.Search_condition())
+	}
+	l.handleStmt(statementText(p, ctx), countStatement(sources, condition), ctx.GetStart().GetLine())
+}
+
+func (l *statementAffectedRowLimitChecker) handleStmt(text, countStmt string, line int) {
+	res, err := advisor.Query(l.ctx, l.driver, countStmt)
+	if err != nil {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.StatementAffectedRowExceedsLimit,
+			Title:   l.title,
+			Content: fmt.Sprintf("\"%s\" dry runs failed: %s", text, err.Error()),
+			Line:    line,
+		})
+		return
+	}
+	rowCount, err := getCount(res)
+	if err != nil {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.Internal,
+			Title:   l.title,
+			Content: fmt.Sprintf("failed to get row count for \"%s\": %s", text, err.Error()),
+			Line:    line,
+		})
+		return
+	}
+	if rowCount > int64(l.maxRow) {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.StatementAffectedRowExceedsLimit,
+			Title:   l.title,
+			Content: fmt.Sprintf("\"%s\" affected %d rows. The count exceeds %d.", text, rowCount, l.maxRow),
+			Line:    line,
+		})
+	}
+}
+
+// countStatement returns the SELECT COUNT(*) statement over the sources with the condition.
+func countStatement(sources []string, condition string) string {
+	stmt := fmt.Sprintf("SELECT COUNT(*) FROM %s", strings.Join(sources, ", "))
+	if condition != "" {
+		stmt = fmt.Sprintf("%s WHERE %s", stmt, condition)
+	}
+	return stmt
+}
+
+func getCount(res []any) (int64, error) {
+	// the res struct is []any{columnName, columnTable, rowDataList}
+	if len(res) != 3 {
+		return 0, errors.Errorf("expected 3 but got %d", len(res))
+	}
+	rowList, ok := res[2].([]any)
+	if !ok {
+		return 0, errors.Errorf("expected []any but got %T", res[2])
+	}
+	if len(rowList) != 1 {
+		return 0, errors.Errorf("expected 1 row but got %d", len(rowList))
+	}
+	row, ok := rowList[0].([]any)
+	if !ok || len(row) != 1 {
+		return 0, errors.Errorf("expected []any with 1 column but got %v", rowList[0])
+	}
+	switch col := row[0].(type) {
+	case int:
+		return int64(col), nil
+	case int32:
+		return int64(col), nil
+	case int64:
+		return col, nil
+	case string:
+		v, err := strconv.ParseInt(col, 10, 64)
+		if err != nil {
+			return 0, errors.Errorf("expected int or int64 but got string(%s)", col)
+		}
+		return v, nil
+	default:
+		return 0, errors.Errorf("expected int or int64 but got %T", col)
+	}
+}
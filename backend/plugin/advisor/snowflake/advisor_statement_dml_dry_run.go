@@ -0,0 +1,111 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDMLDryRunAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakeStatementDMLDryRun, &StatementDMLDryRunAdvisor{})
+}
+
+// StatementDMLDryRunAdvisor is the advisor checking for DML dry run.
+type StatementDMLDryRunAdvisor struct {
+}
+
+// Check checks for DML dry run.
+func (*StatementDMLDryRunAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &statementDMLDryRunChecker{
+		level:  level,
+		title:  string(ctx.Rule.Type),
+		driver: ctx.Driver,
+		ctx:    ctx.Context,
+	}
+
+	if listener.driver != nil {
+		antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	}
+
+	return listener.generateAdvice()
+}
+
+// statementDMLDryRunChecker is the listener for DML dry run.
+// Snowflake EXPLAIN compiles the statement without executing it.
+type statementDMLDryRunChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level  advisor.Status
+	title  string
+	driver *sql.DB
+	ctx    context.Context
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *statementDMLDryRunChecker) generateAdvice() ([]advisor.Advice, error) {
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterInsert_statement is called when production insert_statement is entered.
+func (l *statementDMLDryRunChecker) EnterInsert_statement(ctx *parser.Insert_statementContext) {
+	l.dryRun(statementText(ctx.GetParser(), ctx), ctx.GetStart().GetLine())
+}
+
+// EnterUpdate_statement is called when production update_statement is entered.
+func (l *statementDMLDryRunChecker) EnterUpdate_statement(ctx *parser.Update_statementContext) {
+	l.dryRun(statementText(ctx.GetParser(), ctx), ctx.GetStart().GetLine())
+}
+
+// EnterDelete_statement is called when production delete_statement is entered.
+func (l *statementDMLDryRunChecker) EnterDelete_statement(ctx *parser.Delete_statementContext) {
+	l.dryRun(statementText(ctx.GetParser(), ctx), ctx.GetStart().GetLine())
+}
+
+// EnterMerge_statement is called when production merge_statement is entered.
+func (l *statementDMLDryRunChecker) EnterMerge_statement(ctx *parser.Merge_statementContext) {
+	l.dryRun(statementText(ctx.GetParser(), ctx), ctx.GetStart().GetLine())
+}
+
+func (l *statementDMLDryRunChecker) dryRun(text string, line int) {
+	if _, err := advisor.Query(l.ctx, l.driver, fmt.Sprintf("EXPLAIN %s", text)); err != nil {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.StatementDMLDryRunFailed,
+			Title:   l.title,
+			Content: fmt.Sprintf("\"%s\" dry runs failed: %s", text, err.Error()),
+			Line:    line,
+		})
+	}
+}
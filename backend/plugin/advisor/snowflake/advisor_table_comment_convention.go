@@ -0,0 +1,152 @@
+// Package snowflake is the advisor for snowflake database.
+package snowflake
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*TableCommentConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SNOWFLAKE, advisor.SnowflakeTableCommentConvention, &TableCommentConventionAdvisor{})
+}
+
+// TableCommentConventionAdvisor is the advisor checking for table comment convention.
+type TableCommentConventionAdvisor struct {
+}
+
+// Check checks for table comment convention.
+func (*TableCommentConventionAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	tree, ok := ctx.AST.(antlr.Tree)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Tree")
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalCommentConventionRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &tableCommentConventionChecker{
+		level:             level,
+		title:             string(ctx.Rule.Type),
+		payload:           payload,
+		lineForTable:      make(map[string]int),
+		tableOriginalName: make(map[string]string),
+		commented:         make(map[string]bool),
+	}
+
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+
+	return listener.generateAdvice()
+}
+
+// tableCommentConventionChecker is the listener for table comment convention.
+// The comment of the created table can be given by the COMMENT clause, COMMENT ON TABLE or ALTER TABLE ... SET COMMENT.
+type tableCommentConventionChecker struct {
+	*parser.BaseSnowflakeParserListener
+
+	level   advisor.Status
+	title   string
+	payload *advisor.CommentConventionRulePayload
+	// lineForTable is a map of normalized created table name to the line of the CREATE TABLE statement.
+	lineForTable map[string]int
+	// tableOriginalName is a map of normalized created table name to original table name.
+	tableOriginalName map[string]string
+	// commented is the set of the normalized table names with the comment.
+	commented map[string]bool
+
+	adviceList []advisor.Advice
+}
+
+// generateAdvice returns the advices generated by the listener, the advices must not be empty.
+func (l *tableCommentConventionChecker) generateAdvice() ([]advisor.Advice, error) {
+	if l.payload.Required {
+		var tableList []string
+		for tableName := range l.lineForTable {
+			tableList = append(tableList, tableName)
+		}
+		slices.SortFunc(tableList, func(a, b string) int {
+			return l.lineForTable[a] - l.lineForTable[b]
+		})
+		for _, tableName := range tableList {
+			if l.commented[tableName] {
+				continue
+			}
+			l.adviceList = append(l.adviceList, advisor.Advice{
+				Status:  l.level,
+				Code:    advisor.NoTableComment,
+				Title:   l.title,
+				Content: fmt.Sprintf("Table %s requires comments", l.tableOriginalName[tableName]),
+				Line:    l.lineForTable[tableName],
+			})
+		}
+	}
+
+	if len(l.adviceList) == 0 {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return l.adviceList, nil
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *tableCommentConventionChecker) EnterCreate_table(ctx *parser.Create_tableContext) {
+	normalizedTableName := snowsqlparser.NormalizeSnowSQLObjectName(ctx.Object_name(), "", "PUBLIC")
+	l.lineForTable[normalizedTableName] = ctx.GetStart().GetLine()
+	l.tableOriginalName[normalizedTableName] = ctx.Object_name().GetText()
+	if ctx.Comment_clause() != nil {
+		l.addComment(normalizedTableName, ctx.Object_name().GetText(), ctx.Comment_clause().String_(), ctx.Comment_clause().GetStart().GetLine())
+	}
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *tableCommentConventionChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if ctx.SET() == nil || ctx.Comment_clause() == nil {
+		return
+	}
+	normalizedTableName := snowsqlparser.NormalizeSnowSQLObjectName(ctx.Object_name(0), "", "PUBLIC")
+	l.addComment(normalizedTableName, ctx.Object_name(0).GetText(), ctx.Comment_clause().String_(), ctx.GetStart().GetLine())
+}
+
+// EnterComment is called when production comment is entered.
+func (l *tableCommentConventionChecker) EnterComment(ctx *parser.CommentContext) {
+	if ctx.Object_type_name() == nil || ctx.Object_type_name().TABLE() == nil {
+		return
+	}
+	normalizedTableName := snowsqlparser.NormalizeSnowSQLObjectName(ctx.Object_name(), "", "PUBLIC")
+	l.addComment(normalizedTableName, ctx.Object_name().GetText(), ctx.String_(), ctx.GetStart().GetLine())
+}
+
+func (l *tableCommentConventionChecker) addComment(normalizedTableName, originalTableName string, comment parser.IStringContext, line int) {
+	l.commented[normalizedTableName] = true
+	if l.payload.MaxLength >= 0 && utf8.RuneCountInString(unquoteString(comment)) > l.payload.MaxLength {
+		l.adviceList = append(l.adviceList, advisor.Advice{
+			Status:  l.level,
+			Code:    advisor.TableCommentTooLong,
+			Title:   l.title,
+			Content: fmt.Sprintf("The length of table %s comment should be within %d characters", originalTableName, l.payload.MaxLength),
+			Line:    line,
+		})
+	}
+}
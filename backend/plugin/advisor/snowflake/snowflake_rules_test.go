@@ -23,9 +23,28 @@ func TestSnowflakeRules(t *testing.T) {
 		advisor.SchemaRuleStatementNoSelectAll,
 		advisor.SchemaRuleTableDropNamingConvention,
 		advisor.SchemaRuleSchemaBackwardCompatibility,
+		advisor.SchemaRuleIndexKeyNumberLimit,
+		advisor.SchemaRuleIndexTotalNumberLimit,
+		advisor.SchemaRuleIndexNoDuplicateColumn,
+		advisor.SchemaRuleIndexPrimaryKeyTypeAllowlist,
+		advisor.SchemaRuleColumnRequireDefault,
+		advisor.SchemaRuleColumnTypeDisallowList,
+		advisor.SchemaRuleColumnCommentConvention,
+		advisor.SchemaRuleColumnDisallowChangeType,
+		advisor.SchemaRuleStatementInsertMustSpecifyColumn,
+		advisor.SchemaRuleTableCommentConvention,
 	}
 
 	for _, rule := range snowflakeRules {
 		advisor.RunSQLReviewRuleTest(t, rule, storepb.Engine_SNOWFLAKE, false /* record */)
 	}
 }
+
+func TestSnowflakeDriverRules(t *testing.T) {
+	// The affected row limit and DML dry run rules query the database, they share the test cases on the mock database.
+	driverRules := []advisor.SQLReviewRuleType{
+		advisor.SchemaRuleStatementAffectedRowLimit,
+		advisor.SchemaRuleStatementDMLDryRun,
+	}
+	advisor.RunSQLReviewRuleDriverTest(t, "statement_dml_driver", driverRules, storepb.Engine_SNOWFLAKE, false /* record */)
+}
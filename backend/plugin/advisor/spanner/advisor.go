@@ -0,0 +1,49 @@
+// Package spanner is the advisor for Cloud Spanner database.
+package spanner
+
+import (
+	"cloud.google.com/go/spanner/spansql"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	spannerparser "github.com/bytebase/bytebase/backend/plugin/parser/spanner"
+)
+
+// getStatementList returns the statements parsed by the syntax check.
+func getStatementList(ast any) ([]*spannerparser.Statement, error) {
+	stmtList, ok := ast.([]*spannerparser.Statement)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Spanner statement list")
+	}
+	return stmtList, nil
+}
+
+// generateAdvice returns the advice list, the advice list must not be empty.
+func generateAdvice(adviceList []advisor.Advice) []advisor.Advice {
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList
+}
+
+// getLine returns the line of the position in the original statement.
+func getLine(stmt *spannerparser.Statement, position spansql.Position) int {
+	if !position.IsValid() {
+		return stmt.LastLine
+	}
+	return stmt.BaseLine + position.Line - 1
+}
+
+// isAlwaysTrue returns whether the WHERE expression is empty or a constant true.
+func isAlwaysTrue(where spansql.BoolExpr) bool {
+	if where == nil {
+		return true
+	}
+	literal, ok := where.(spansql.BoolLiteral)
+	return ok && bool(literal)
+}
@@ -0,0 +1,73 @@
+package spanner
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/spanner/spansql"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*PrimaryKeyDisallowMonotonicAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SPANNER, advisor.SpannerPrimaryKeyDisallowMonotonic, &PrimaryKeyDisallowMonotonicAdvisor{})
+}
+
+// PrimaryKeyDisallowMonotonicAdvisor is the advisor checking for the monotonically increasing primary key.
+// Spanner splits the rows by the key ranges, so the monotonically increasing leading key column makes all the writes go to the last split.
+type PrimaryKeyDisallowMonotonicAdvisor struct {
+}
+
+// Check checks for the monotonically increasing primary key.
+func (*PrimaryKeyDisallowMonotonicAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, err := getStatementList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, stmt := range stmtList {
+		create, ok := stmt.Node.(*spansql.CreateTable)
+		if !ok || len(create.PrimaryKey) == 0 {
+			continue
+		}
+		// The interleaved table is prefixed by the parent key, only the leading key column of the root table matters.
+		if create.Interleave != nil {
+			continue
+		}
+		keyColumn := create.PrimaryKey[0].Column
+		for _, column := range create.Columns {
+			if column.Name != keyColumn || !isMonotonicColumn(column) {
+				continue
+			}
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.PrimaryKeyMonotonic,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("The leading primary key column %s.%s is monotonically increasing and causes the write hotspot, use UUID or bit-reversed sequence instead.", create.Name, column.Name),
+				Line:    getLine(stmt, column.Position),
+			})
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
+
+// isMonotonicColumn returns whether the column is the timestamp or the date, including the commit timestamp column.
+func isMonotonicColumn(column spansql.ColumnDef) bool {
+	if column.Type.Array {
+		return false
+	}
+	if column.Type.Base == spansql.Timestamp || column.Type.Base == spansql.Date {
+		return true
+	}
+	return column.Options.AllowCommitTimestamp != nil && *column.Options.AllowCommitTimestamp
+}
@@ -0,0 +1,75 @@
+package spanner
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/spanner/spansql"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*NamingColumnAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SPANNER, advisor.SpannerNamingColumnConvention, &NamingColumnAdvisor{})
+}
+
+// NamingColumnAdvisor is the advisor checking for column naming convention.
+type NamingColumnAdvisor struct {
+}
+
+// Check checks for column naming convention.
+func (*NamingColumnAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, err := getStatementList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, maxLength, err := advisor.UnmarshalNamingRulePayloadAsRegexp(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	check := func(tableName spansql.ID, column spansql.ColumnDef, line int) {
+		if !format.MatchString(string(column.Name)) {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingColumnConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("`%s`.`%s` mismatches column naming convention, naming format should be %q", tableName, column.Name, format),
+				Line:    line,
+			})
+		}
+		if maxLength > 0 && len(column.Name) > maxLength {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingColumnConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("`%s`.`%s` mismatches column naming convention, its length should be within %d characters", tableName, column.Name, maxLength),
+				Line:    line,
+			})
+		}
+	}
+
+	for _, stmt := range stmtList {
+		switch node := stmt.Node.(type) {
+		case *spansql.CreateTable:
+			for _, column := range node.Columns {
+				check(node.Name, column, getLine(stmt, column.Position))
+			}
+		case *spansql.AlterTable:
+			if addColumn, ok := node.Alteration.(spansql.AddColumn); ok {
+				check(node.Name, addColumn.Def, getLine(stmt, addColumn.Def.Position))
+			}
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
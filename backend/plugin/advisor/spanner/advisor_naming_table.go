@@ -0,0 +1,67 @@
+package spanner
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/spanner/spansql"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*NamingTableAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SPANNER, advisor.SpannerNamingTableConvention, &NamingTableAdvisor{})
+}
+
+// NamingTableAdvisor is the advisor checking for table naming convention.
+type NamingTableAdvisor struct {
+}
+
+// Check checks for table naming convention.
+func (*NamingTableAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, err := getStatementList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, maxLength, err := advisor.UnmarshalNamingRulePayloadAsRegexp(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, stmt := range stmtList {
+		create, ok := stmt.Node.(*spansql.CreateTable)
+		if !ok {
+			continue
+		}
+		tableName := string(create.Name)
+		if !format.MatchString(tableName) {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingTableConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf(`"%s" mismatches table naming convention, naming format should be %q`, tableName, format),
+				Line:    getLine(stmt, create.Position),
+			})
+		}
+		if maxLength > 0 && len(tableName) > maxLength {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.NamingTableConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("\"%s\" mismatches table naming convention, its length should be within %d characters", tableName, maxLength),
+				Line:    getLine(stmt, create.Position),
+			})
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
@@ -0,0 +1,54 @@
+package spanner
+
+import (
+	"cloud.google.com/go/spanner/spansql"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*SelectNoSelectAllAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SPANNER, advisor.SpannerNoSelectAll, &SelectNoSelectAllAdvisor{})
+}
+
+// SelectNoSelectAllAdvisor is the advisor checking for no "select *".
+type SelectNoSelectAllAdvisor struct {
+}
+
+// Check checks for no "select *".
+func (*SelectNoSelectAllAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, err := getStatementList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, stmt := range stmtList {
+		query, ok := stmt.Node.(*spansql.Query)
+		if !ok {
+			continue
+		}
+		for _, expr := range query.Select.List {
+			if _, ok := expr.(spansql.StarExpr); ok {
+				adviceList = append(adviceList, advisor.Advice{
+					Status:  level,
+					Code:    advisor.StatementSelectAll,
+					Title:   string(ctx.Rule.Type),
+					Content: "Avoid using SELECT *.",
+					Line:    stmt.LastLine,
+				})
+				break
+			}
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
@@ -0,0 +1,57 @@
+package spanner
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/spanner/spansql"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*TableDropNamingConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SPANNER, advisor.SpannerTableDropNamingConvention, &TableDropNamingConventionAdvisor{})
+}
+
+// TableDropNamingConventionAdvisor is the advisor checking for table drop with naming convention.
+type TableDropNamingConventionAdvisor struct {
+}
+
+// Check checks for table drop with naming convention.
+func (*TableDropNamingConventionAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, err := getStatementList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, _, err := advisor.UnmarshalNamingRulePayloadAsRegexp(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, stmt := range stmtList {
+		drop, ok := stmt.Node.(*spansql.DropTable)
+		if !ok {
+			continue
+		}
+		if !format.MatchString(string(drop.Name)) {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.TableDropNamingConventionMismatch,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("%q mismatches drop table naming convention, naming format should be %q", drop.Name, format),
+				Line:    stmt.LastLine,
+			})
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
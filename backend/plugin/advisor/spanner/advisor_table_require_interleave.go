@@ -0,0 +1,72 @@
+package spanner
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/spanner/spansql"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*TableRequireInterleaveAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SPANNER, advisor.SpannerTableRequireInterleave, &TableRequireInterleaveAdvisor{})
+}
+
+// TableRequireInterleaveAdvisor is the advisor checking for the child table not interleaved in its parent table.
+// The table is regarded as the child table if it has the foreign key on the leading primary key columns,
+// the interleaved table is co-located with the parent rows and avoids the distributed joins.
+type TableRequireInterleaveAdvisor struct {
+}
+
+// Check checks for the child table not interleaved in its parent table.
+func (*TableRequireInterleaveAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, err := getStatementList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, stmt := range stmtList {
+		create, ok := stmt.Node.(*spansql.CreateTable)
+		if !ok || create.Interleave != nil {
+			continue
+		}
+		for _, constraint := range create.Constraints {
+			fk, ok := constraint.Constraint.(spansql.ForeignKey)
+			if !ok || !isLeadingPrimaryKey(fk.Columns, create.PrimaryKey) {
+				continue
+			}
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.TableNotInterleaved,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("Table %s references %s by the leading primary key columns, use INTERLEAVE IN PARENT %s instead of the foreign key.", create.Name, fk.RefTable, fk.RefTable),
+				Line:    getLine(stmt, constraint.Position),
+			})
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
+
+// isLeadingPrimaryKey returns whether the columns are the proper prefix of the primary key.
+func isLeadingPrimaryKey(columnList []spansql.ID, primaryKey []spansql.KeyPart) bool {
+	if len(columnList) == 0 || len(columnList) >= len(primaryKey) {
+		return false
+	}
+	for i, column := range columnList {
+		if column != primaryKey[i].Column {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package spanner
+
+import (
+	"fmt"
+	"regexp"
+
+	"cloud.google.com/go/spanner/spansql"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*TableRequireInterleaveOnDeleteAdvisor)(nil)
+
+	// spansql uses ON DELETE NO ACTION as the default, so we look for the explicit clause in the text.
+	interleaveOnDeleteRegexp = regexp.MustCompile("(?is)INTERLEAVE\\s+IN\\s+PARENT\\s+(`[^`]*`|[\\w.]+)\\s+ON\\s+DELETE")
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SPANNER, advisor.SpannerTableRequireInterleaveOnDelete, &TableRequireInterleaveOnDeleteAdvisor{})
+}
+
+// TableRequireInterleaveOnDeleteAdvisor is the advisor checking for the interleaved table without the explicit ON DELETE action.
+type TableRequireInterleaveOnDeleteAdvisor struct {
+}
+
+// Check checks for the interleaved table without the explicit ON DELETE action.
+func (*TableRequireInterleaveOnDeleteAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, err := getStatementList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, stmt := range stmtList {
+		create, ok := stmt.Node.(*spansql.CreateTable)
+		if !ok || create.Interleave == nil {
+			continue
+		}
+		if interleaveOnDeleteRegexp.MatchString(stmt.Text) {
+			continue
+		}
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  level,
+			Code:    advisor.InterleaveWithoutOnDelete,
+			Title:   string(ctx.Rule.Type),
+			Content: fmt.Sprintf("Table %s is interleaved in %s without ON DELETE, specify ON DELETE CASCADE or ON DELETE NO ACTION explicitly.", create.Name, create.Interleave.Parent),
+			Line:    stmt.LastLine,
+		})
+	}
+
+	return generateAdvice(adviceList), nil
+}
@@ -0,0 +1,52 @@
+package spanner
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/spanner/spansql"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*TableRequirePKAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SPANNER, advisor.SpannerTableRequirePK, &TableRequirePKAdvisor{})
+}
+
+// TableRequirePKAdvisor is the advisor checking table requires PK.
+// The table with the empty PRIMARY KEY () can hold only one row.
+type TableRequirePKAdvisor struct {
+}
+
+// Check checks table requires PK.
+func (*TableRequirePKAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, err := getStatementList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, stmt := range stmtList {
+		create, ok := stmt.Node.(*spansql.CreateTable)
+		if !ok || len(create.PrimaryKey) > 0 {
+			continue
+		}
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  level,
+			Code:    advisor.TableNoPK,
+			Title:   string(ctx.Rule.Type),
+			Content: fmt.Sprintf("Table %s requires PRIMARY KEY.", create.Name),
+			Line:    stmt.LastLine,
+		})
+	}
+
+	return generateAdvice(adviceList), nil
+}
@@ -0,0 +1,63 @@
+package spanner
+
+import (
+	"cloud.google.com/go/spanner/spansql"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*WhereRequireAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_SPANNER, advisor.SpannerWhereRequirement, &WhereRequireAdvisor{})
+}
+
+// WhereRequireAdvisor is the advisor checking for WHERE clause requirement.
+// Spanner requires the WHERE clause for UPDATE and DELETE, so "WHERE true" is reported as well.
+type WhereRequireAdvisor struct {
+}
+
+// Check checks for WHERE clause requirement.
+func (*WhereRequireAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, err := getStatementList(ctx.AST)
+	if err != nil {
+		return nil, err
+	}
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	add := func(content string, line int) {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  level,
+			Code:    advisor.StatementNoWhere,
+			Title:   string(ctx.Rule.Type),
+			Content: content,
+			Line:    line,
+		})
+	}
+
+	for _, stmt := range stmtList {
+		switch node := stmt.Node.(type) {
+		case *spansql.Query:
+			if len(node.Select.From) > 0 && node.Select.Where == nil {
+				add("WHERE clause is required for SELECT statement.", stmt.LastLine)
+			}
+		case *spansql.Update:
+			if isAlwaysTrue(node.Where) {
+				add("WHERE clause is required for UPDATE statement.", stmt.LastLine)
+			}
+		case *spansql.Delete:
+			if isAlwaysTrue(node.Where) {
+				add("WHERE clause is required for DELETE statement.", stmt.LastLine)
+			}
+		}
+	}
+
+	return generateAdvice(adviceList), nil
+}
@@ -0,0 +1,26 @@
+package spanner
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestSpannerRules(t *testing.T) {
+	spannerRules := []advisor.SQLReviewRuleType{
+		advisor.SchemaRuleTableNaming,
+		advisor.SchemaRuleColumnNaming,
+		advisor.SchemaRuleTableRequirePK,
+		advisor.SchemaRuleStatementNoSelectAll,
+		advisor.SchemaRuleStatementRequireWhere,
+		advisor.SchemaRuleTableDropNamingConvention,
+		advisor.SchemaRuleTableRequireInterleave,
+		advisor.SchemaRuleTableRequireInterleaveOnDelete,
+		advisor.SchemaRuleIndexPrimaryKeyDisallowMonotonic,
+	}
+
+	for _, rule := range spannerRules {
+		advisor.RunSQLReviewRuleTest(t, rule, storepb.Engine_SPANNER, false /* record */)
+	}
+}
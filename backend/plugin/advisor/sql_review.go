@@ -19,9 +19,11 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
 	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	clickhouseparser "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
 	mysqlparser "github.com/bytebase/bytebase/backend/plugin/parser/mysql"
 	plsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/plsql"
 	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+	spannerparser "github.com/bytebase/bytebase/backend/plugin/parser/spanner"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 	pgrawparser "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
 	tidbbbparser "github.com/bytebase/bytebase/backend/plugin/parser/tidb"
@@ -93,6 +95,18 @@ const (
 	SchemaRuleStatementAddCheckNotValid = "statement.add-check-not-valid"
 	// SchemaRuleStatementDisallowAddNotNull disallow to add NOT NULL.
 	SchemaRuleStatementDisallowAddNotNull = "statement.disallow-add-not-null"
+	// SchemaRuleStatementDisallowTableRewrite disallow ALTER COLUMN TYPE rewriting the table.
+	SchemaRuleStatementDisallowTableRewrite SQLReviewRuleType = "statement.disallow-table-rewrite"
+	// SchemaRuleStatementSetNotNullRequireCheck require a validated CHECK (column IS NOT NULL) constraint before SET NOT NULL.
+	SchemaRuleStatementSetNotNullRequireCheck SQLReviewRuleType = "statement.set-not-null-require-check"
+	// SchemaRuleStatementAddFKNotValid require add foreign keys not valid.
+	SchemaRuleStatementAddFKNotValid SQLReviewRuleType = "statement.add-fk-not-valid"
+	// SchemaRuleStatementDisallowBlockingMaintenance disallow VACUUM FULL, CLUSTER and REINDEX without CONCURRENTLY.
+	SchemaRuleStatementDisallowBlockingMaintenance SQLReviewRuleType = "statement.disallow-blocking-maintenance"
+	// SchemaRuleStatementRequireLockTimeout require setting lock_timeout or statement_timeout before DDL.
+	SchemaRuleStatementRequireLockTimeout SQLReviewRuleType = "statement.require-lock-timeout"
+	// SchemaRuleStatementDisallowMutation disallow the ALTER TABLE ... UPDATE/DELETE mutations.
+	SchemaRuleStatementDisallowMutation SQLReviewRuleType = "statement.disallow-mutation"
 
 	// SchemaRuleTableRequirePK require the table to have a primary key.
 	SchemaRuleTableRequirePK SQLReviewRuleType = "table.require-pk"
@@ -104,6 +118,12 @@ const (
 	SchemaRuleTableCommentConvention SQLReviewRuleType = "table.comment"
 	// SchemaRuleTableDisallowPartition disallow the table partition.
 	SchemaRuleTableDisallowPartition SQLReviewRuleType = "table.disallow-partition"
+	// SchemaRuleTableRequireTTL require the table to have a TTL.
+	SchemaRuleTableRequireTTL SQLReviewRuleType = "table.require-ttl"
+	// SchemaRuleTableRequireInterleave require the child table to be interleaved in its parent table.
+	SchemaRuleTableRequireInterleave SQLReviewRuleType = "table.require-interleave"
+	// SchemaRuleTableRequireInterleaveOnDelete require the interleaved table to specify the ON DELETE action.
+	SchemaRuleTableRequireInterleaveOnDelete SQLReviewRuleType = "table.require-interleave-on-delete"
 
 	// SchemaRuleRequiredColumn enforce the required columns in each table.
 	SchemaRuleRequiredColumn SQLReviewRuleType = "column.required"
@@ -119,6 +139,8 @@ const (
 	SchemaRuleColumnDisallowChangingOrder SQLReviewRuleType = "column.disallow-changing-order"
 	// SchemaRuleColumnDisallowDropInIndex disallow index column.
 	SchemaRuleColumnDisallowDropInIndex SQLReviewRuleType = "column.disallow-drop-in-index"
+	// SchemaRuleColumnDisallowRenameReferencedByView disallow renaming the column referenced by views.
+	SchemaRuleColumnDisallowRenameReferencedByView SQLReviewRuleType = "column.disallow-rename-referenced-by-view"
 	// SchemaRuleColumnCommentConvention enforce the column comment convention.
 	SchemaRuleColumnCommentConvention SQLReviewRuleType = "column.comment"
 	// SchemaRuleColumnAutoIncrementMustInteger require the auto-increment column to be integer.
@@ -141,6 +163,8 @@ const (
 	SchemaRuleColumnRequireDefault SQLReviewRuleType = "column.require-default"
 	// SchemaRuleAddNotNullColumnRequireDefault enforce the adding not null column requires default.
 	SchemaRuleAddNotNullColumnRequireDefault SQLReviewRuleType = "column.add-not-null-require-default"
+	// SchemaRuleColumnDisallowNullableInSortingKey disallow the nullable column in the sorting key.
+	SchemaRuleColumnDisallowNullableInSortingKey SQLReviewRuleType = "column.disallow-nullable-in-sorting-key"
 
 	// SchemaRuleSchemaBackwardCompatibility enforce the MySQL and TiDB support check whether the schema change is backward compatible.
 	SchemaRuleSchemaBackwardCompatibility SQLReviewRuleType = "schema.backward-compatibility"
@@ -150,6 +174,8 @@ const (
 
 	// SchemaRuleIndexNoDuplicateColumn require the index no duplicate column.
 	SchemaRuleIndexNoDuplicateColumn SQLReviewRuleType = "index.no-duplicate-column"
+	// SchemaRuleIndexNoDuplicateIndex disallow the index with the same key columns as an existing index.
+	SchemaRuleIndexNoDuplicateIndex SQLReviewRuleType = "index.no-duplicate-index"
 	// SchemaRuleIndexKeyNumberLimit enforce the index key number limit.
 	SchemaRuleIndexKeyNumberLimit SQLReviewRuleType = "index.key-number-limit"
 	// SchemaRuleIndexPKTypeLimit enforce the type restriction of columns in primary key.
@@ -162,6 +188,8 @@ const (
 	SchemaRuleIndexPrimaryKeyTypeAllowlist SQLReviewRuleType = "index.primary-key-type-allowlist"
 	// SchemaRuleCreateIndexConcurrently require creating indexes concurrently.
 	SchemaRuleCreateIndexConcurrently SQLReviewRuleType = "index.create-concurrently"
+	// SchemaRuleIndexPrimaryKeyDisallowMonotonic disallow the monotonically increasing primary key.
+	SchemaRuleIndexPrimaryKeyDisallowMonotonic SQLReviewRuleType = "index.primary-key-disallow-monotonic"
 
 	// SchemaRuleCharsetAllowlist enforce the charset allowlist.
 	SchemaRuleCharsetAllowlist SQLReviewRuleType = "system.charset.allowlist"
@@ -172,6 +200,9 @@ const (
 	// SchemaRuleCommentLength limit comment length.
 	SchemaRuleCommentLength SQLReviewRuleType = "system.comment.length"
 
+	// SchemaRuleCustom is the user-defined rule in CEL over the statement model.
+	SchemaRuleCustom SQLReviewRuleType = "custom.cel"
+
 	// TableNameTemplateToken is the token for table name.
 	TableNameTemplateToken = "{{table}}"
 	// ColumnListTemplateToken is the token for column name list.
@@ -249,6 +280,17 @@ type NamingCaseRulePayload struct {
 	Upper bool `json:"upper"`
 }
 
+// CustomRulePayload is the payload for custom rule.
+type CustomRulePayload struct {
+	// Title is the title of the advice, it distinguishes the custom rules from each other.
+	Title string `json:"title"`
+	// Expression is the CEL expression over the `statement` variable.
+	// The statement violates the rule if the expression evaluates to true.
+	Expression string `json:"expression"`
+	// Message is the content of the advice for the violation.
+	Message string `json:"message"`
+}
+
 // UnmarshalNamingRulePayloadAsRegexp will unmarshal payload to NamingRulePayload and compile it as regular expression.
 func UnmarshalNamingRulePayloadAsRegexp(payload string) (*regexp.Regexp, int, error) {
 	var nr NamingRulePayload
@@ -386,6 +428,18 @@ func UnmarshalNamingCaseRulePayload(payload string) (*NamingCaseRulePayload, err
 	return &ncr, nil
 }
 
+// UnmarshalCustomRulePayload will unmarshal payload to CustomRulePayload and validate the expression.
+func UnmarshalCustomRulePayload(payload string) (*CustomRulePayload, error) {
+	var cr CustomRulePayload
+	if err := json.Unmarshal([]byte(payload), &cr); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal custom rule payload %q", payload)
+	}
+	if _, err := CompileCustomRuleExpression(cr.Expression); err != nil {
+		return nil, err
+	}
+	return &cr, nil
+}
+
 // SQLReviewCheckContext is the context for SQL review check.
 type SQLReviewCheckContext struct {
 	Charset   string
@@ -409,12 +463,16 @@ func syntaxCheck(statement string, checkContext SQLReviewCheckContext) (any, []A
 		return mysqlSyntaxCheck(statement)
 	case storepb.Engine_POSTGRES:
 		return postgresSyntaxCheck(statement)
-	case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+	case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 		return oracleSyntaxCheck(statement)
 	case storepb.Engine_SNOWFLAKE:
 		return snowflakeSyntaxCheck(statement)
 	case storepb.Engine_MSSQL:
 		return mssqlSyntaxCheck(statement)
+	case storepb.Engine_CLICKHOUSE:
+		return clickhouseSyntaxCheck(statement)
+	case storepb.Engine_SPANNER:
+		return spannerSyntaxCheck(statement)
 	}
 	return nil, []Advice{
 		{
@@ -492,6 +550,64 @@ func snowflakeSyntaxCheck(statement string) (any, []Advice) {
 	return result.Tree, nil
 }
 
+func clickhouseSyntaxCheck(statement string) (any, []Advice) {
+	nodes, err := clickhouseparser.ParseClickHouseSQL(statement)
+	if err != nil {
+		if syntaxErr, ok := err.(*base.SyntaxError); ok {
+			return nil, []Advice{
+				{
+					Status:  Warn,
+					Code:    StatementSyntaxError,
+					Title:   SyntaxErrorTitle,
+					Content: syntaxErr.Message,
+					Line:    syntaxErr.Line,
+					Column:  syntaxErr.Column,
+				},
+			}
+		}
+		return nil, []Advice{
+			{
+				Status:  Warn,
+				Code:    Internal,
+				Title:   "Parse error",
+				Content: err.Error(),
+				Line:    1,
+			},
+		}
+	}
+
+	return nodes, nil
+}
+
+func spannerSyntaxCheck(statement string) (any, []Advice) {
+	nodes, err := spannerparser.ParseSpannerSQL(statement)
+	if err != nil {
+		if syntaxErr, ok := err.(*base.SyntaxError); ok {
+			return nil, []Advice{
+				{
+					Status:  Warn,
+					Code:    StatementSyntaxError,
+					Title:   SyntaxErrorTitle,
+					Content: syntaxErr.Message,
+					Line:    syntaxErr.Line,
+					Column:  syntaxErr.Column,
+				},
+			}
+		}
+		return nil, []Advice{
+			{
+				Status:  Warn,
+				Code:    Internal,
+				Title:   "Parse error",
+				Content: err.Error(),
+				Line:    1,
+			},
+		}
+	}
+
+	return nodes, nil
+}
+
 func oracleSyntaxCheck(statement string) (any, []Advice) {
 	tree, _, err := plsqlparser.ParsePLSQL(statement + ";")
 	if err != nil {
@@ -672,10 +788,23 @@ func SQLReviewCheck(statements string, ruleList []*storepb.SQLReviewRule, checkC
 	}
 
 	finder := checkContext.Catalog.GetFinder()
-	switch checkContext.DbType {
-	case storepb.Engine_TIDB, storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_POSTGRES, storepb.Engine_OCEANBASE:
+	if checkContext.CurrentSchema != "" {
+		finder.SetCurrentSchema(checkContext.CurrentSchema)
+	}
+	if catalog.IsWalkThroughSupported(checkContext.DbType) {
 		if err := finder.WalkThrough(statements); err != nil {
-			return convertWalkThroughErrorToAdvice(checkContext, err)
+			walkThroughAdviceList, err := convertWalkThroughErrorToAdvice(checkContext, err)
+			if err != nil {
+				return nil, err
+			}
+			switch checkContext.DbType {
+			case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_MSSQL:
+				// The walk-through error doesn't stop the rules for Oracle and SQL Server,
+				// we report it as one advice along with the advice of the rules.
+				result = append(result, walkThroughAdviceList...)
+			default:
+				return walkThroughAdviceList, nil
+			}
 		}
 	}
 
@@ -714,6 +843,10 @@ func SQLReviewCheck(statements string, ruleList []*storepb.SQLReviewRule, checkC
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to check statement")
 		}
+		resolveFixRange(statements, adviceList)
+		for i := range adviceList {
+			adviceList[i].RuleType = rule.Type
+		}
 
 		result = append(result, adviceList...)
 	}
@@ -1002,12 +1135,16 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLWhereRequirement, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLWhereRequirement, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleWhereRequirement, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeWhereRequirement, nil
 		case storepb.Engine_MSSQL:
 			return MSSQLWhereRequirement, nil
+		case storepb.Engine_CLICKHOUSE:
+			return ClickHouseWhereRequirement, nil
+		case storepb.Engine_SPANNER:
+			return SpannerWhereRequirement, nil
 		}
 	case SchemaRuleStatementNoLeadingWildcardLike:
 		switch engine {
@@ -1015,7 +1152,7 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLNoLeadingWildcardLike, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLNoLeadingWildcardLike, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleNoLeadingWildcardLike, nil
 		}
 	case SchemaRuleStatementNoSelectAll:
@@ -1024,12 +1161,16 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLNoSelectAll, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLNoSelectAll, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleNoSelectAll, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeNoSelectAll, nil
 		case storepb.Engine_MSSQL:
 			return MSSQLNoSelectAll, nil
+		case storepb.Engine_CLICKHOUSE:
+			return ClickHouseNoSelectAll, nil
+		case storepb.Engine_SPANNER:
+			return SpannerNoSelectAll, nil
 		}
 	case SchemaRuleSchemaBackwardCompatibility:
 		switch engine {
@@ -1041,6 +1182,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return SnowflakeMigrationCompatibility, nil
 		case storepb.Engine_MSSQL:
 			return MSSQLMigrationCompatibility, nil
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+			return OracleMigrationCompatibility, nil
 		}
 	case SchemaRuleTableNaming:
 		switch engine {
@@ -1048,12 +1191,16 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLNamingTableConvention, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLNamingTableConvention, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleNamingTableConvention, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeNamingTableConvention, nil
 		case storepb.Engine_MSSQL:
 			return MSSQLNamingTableConvention, nil
+		case storepb.Engine_CLICKHOUSE:
+			return ClickHouseNamingTableConvention, nil
+		case storepb.Engine_SPANNER:
+			return SpannerNamingTableConvention, nil
 		}
 	case SchemaRuleIDXNaming:
 		switch engine {
@@ -1086,6 +1233,10 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLNamingColumnConvention, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLNamingColumnConvention, nil
+		case storepb.Engine_CLICKHOUSE:
+			return ClickHouseNamingColumnConvention, nil
+		case storepb.Engine_SPANNER:
+			return SpannerNamingColumnConvention, nil
 		}
 	case SchemaRuleAutoIncrementColumnNaming:
 		switch engine {
@@ -1094,7 +1245,7 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 		}
 	case SchemaRuleTableNameNoKeyword:
 		switch engine {
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleTableNamingNoKeyword, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeTableNamingNoKeyword, nil
@@ -1103,7 +1254,7 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 		}
 	case SchemaRuleIdentifierNoKeyword:
 		switch engine {
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleIdentifierNamingNoKeyword, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeIdentifierNamingNoKeyword, nil
@@ -1112,7 +1263,7 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 		}
 	case SchemaRuleIdentifierCase:
 		switch engine {
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleIdentifierCase, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeIdentifierCase, nil
@@ -1123,7 +1274,7 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLColumnRequirement, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLColumnRequirement, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleColumnRequirement, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeColumnRequirement, nil
@@ -1136,7 +1287,7 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLColumnNoNull, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLColumnNoNull, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleColumnNoNull, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeColumnNoNull, nil
@@ -1149,6 +1300,10 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLColumnDisallowChangingType, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLColumnDisallowChangingType, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeColumnDisallowChangingType, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLColumnDisallowChangingType, nil
 		}
 	case SchemaRuleColumnSetDefaultForNotNull:
 		switch engine {
@@ -1169,11 +1324,19 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 		switch engine {
 		case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
 			return MySQLColumnDisallowDropInIndex, nil
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+			return OracleColumnDisallowDropInIndex, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLColumnDisallowDropInIndex, nil
 		}
 	case SchemaRuleColumnCommentConvention:
 		switch engine {
 		case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
 			return MySQLColumnCommentConvention, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeColumnCommentConvention, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLColumnCommentConvention, nil
 		}
 	case SchemaRuleColumnAutoIncrementMustInteger:
 		switch engine {
@@ -1186,8 +1349,12 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLColumnTypeRestriction, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLColumnTypeDisallowList, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleColumnTypeDisallowList, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeColumnTypeDisallowList, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLColumnTypeDisallowList, nil
 		}
 	case SchemaRuleColumnDisallowSetCharset:
 		switch engine {
@@ -1200,12 +1367,12 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLColumnMaximumCharacterLength, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLColumnMaximumCharacterLength, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleColumnMaximumCharacterLength, nil
 		}
 	case SchemaRuleColumnMaximumVarcharLength:
 		switch engine {
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleColumnMaximumVarcharLength, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeColumnMaximumVarcharLength, nil
@@ -1233,11 +1400,15 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLRequireColumnDefault, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLRequireColumnDefault, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleRequireColumnDefault, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeRequireColumnDefault, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLRequireColumnDefault, nil
 		}
 	case SchemaRuleAddNotNullColumnRequireDefault:
-		if engine == storepb.Engine_ORACLE {
+		if engine == storepb.Engine_ORACLE || engine == storepb.Engine_DM {
 			return OracleAddNotNullColumnRequireDefault, nil
 		}
 	case SchemaRuleTableRequirePK:
@@ -1246,12 +1417,16 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLTableRequirePK, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLTableRequirePK, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleTableRequirePK, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeTableRequirePK, nil
 		case storepb.Engine_MSSQL:
 			return MSSQLTableRequirePK, nil
+		case storepb.Engine_CLICKHOUSE:
+			return ClickHouseTableRequirePK, nil
+		case storepb.Engine_SPANNER:
+			return SpannerTableRequirePK, nil
 		}
 	case SchemaRuleTableNoFK:
 		switch engine {
@@ -1259,7 +1434,7 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLTableNoFK, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLTableNoFK, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleTableNoFK, nil
 		case storepb.Engine_SNOWFLAKE:
 			return SnowflakeTableNoFK, nil
@@ -1276,11 +1451,19 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return SnowflakeTableDropNamingConvention, nil
 		case storepb.Engine_MSSQL:
 			return MSSQLTableDropNamingConvention, nil
+		case storepb.Engine_CLICKHOUSE:
+			return ClickHouseTableDropNamingConvention, nil
+		case storepb.Engine_SPANNER:
+			return SpannerTableDropNamingConvention, nil
 		}
 	case SchemaRuleTableCommentConvention:
 		switch engine {
 		case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
 			return MySQLTableCommentConvention, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeTableCommentConvention, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLTableCommentConvention, nil
 		}
 	case SchemaRuleTableDisallowPartition:
 		switch engine {
@@ -1305,6 +1488,17 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLIndexNoDuplicateColumn, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLIndexNoDuplicateColumn, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeIndexNoDuplicateColumn, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLIndexNoDuplicateColumn, nil
+		}
+	case SchemaRuleIndexNoDuplicateIndex:
+		switch engine {
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+			return OracleIndexNoDuplicateIndex, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLIndexNoDuplicateIndex, nil
 		}
 	case SchemaRuleIndexKeyNumberLimit:
 		switch engine {
@@ -1312,8 +1506,12 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLIndexKeyNumberLimit, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLIndexKeyNumberLimit, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleIndexKeyNumberLimit, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeIndexKeyNumberLimit, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLIndexKeyNumberLimit, nil
 		}
 	case SchemaRuleIndexTotalNumberLimit:
 		switch engine {
@@ -1321,6 +1519,10 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLIndexTotalNumberLimit, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLIndexTotalNumberLimit, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeIndexTotalNumberLimit, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLIndexTotalNumberLimit, nil
 		}
 	case SchemaRuleStatementDisallowCommit:
 		switch engine {
@@ -1359,6 +1561,10 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLPrimaryKeyTypeAllowlist, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLPrimaryKeyTypeAllowlist, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakePrimaryKeyTypeAllowlist, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLPrimaryKeyTypeAllowlist, nil
 		}
 	case SchemaRuleCreateIndexConcurrently:
 		if engine == storepb.Engine_POSTGRES {
@@ -1377,8 +1583,12 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLInsertMustSpecifyColumn, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLInsertMustSpecifyColumn, nil
-		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE:
+		case storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_DM:
 			return OracleInsertMustSpecifyColumn, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeInsertMustSpecifyColumn, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLInsertMustSpecifyColumn, nil
 		}
 	case SchemaRuleStatementInsertDisallowOrderByRand:
 		switch engine {
@@ -1410,6 +1620,10 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLStatementAffectedRowLimit, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLStatementAffectedRowLimit, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeStatementAffectedRowLimit, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLStatementAffectedRowLimit, nil
 		}
 	case SchemaRuleStatementDMLDryRun:
 		switch engine {
@@ -1417,6 +1631,10 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 			return MySQLStatementDMLDryRun, nil
 		case storepb.Engine_POSTGRES:
 			return PostgreSQLStatementDMLDryRun, nil
+		case storepb.Engine_SNOWFLAKE:
+			return SnowflakeStatementDMLDryRun, nil
+		case storepb.Engine_MSSQL:
+			return MSSQLStatementDMLDryRun, nil
 		}
 	case SchemaRuleStatementDisallowAddColumnWithDefault:
 		if engine == storepb.Engine_POSTGRES {
@@ -1430,10 +1648,65 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine storepb.Engine) (Ty
 		if engine == storepb.Engine_POSTGRES {
 			return PostgreSQLDisallowAddNotNull, nil
 		}
+	case SchemaRuleStatementDisallowTableRewrite:
+		if engine == storepb.Engine_POSTGRES {
+			return PostgreSQLDisallowTableRewrite, nil
+		}
+	case SchemaRuleStatementSetNotNullRequireCheck:
+		if engine == storepb.Engine_POSTGRES {
+			return PostgreSQLSetNotNullRequireCheck, nil
+		}
+	case SchemaRuleStatementAddFKNotValid:
+		if engine == storepb.Engine_POSTGRES {
+			return PostgreSQLAddFKNotValid, nil
+		}
+	case SchemaRuleStatementDisallowBlockingMaintenance:
+		if engine == storepb.Engine_POSTGRES {
+			return PostgreSQLDisallowBlockingMaintenance, nil
+		}
+	case SchemaRuleStatementRequireLockTimeout:
+		if engine == storepb.Engine_POSTGRES {
+			return PostgreSQLRequireLockTimeout, nil
+		}
+	case SchemaRuleStatementDisallowMutation:
+		if engine == storepb.Engine_CLICKHOUSE {
+			return ClickHouseDisallowMutation, nil
+		}
+	case SchemaRuleTableRequireTTL:
+		if engine == storepb.Engine_CLICKHOUSE {
+			return ClickHouseTableRequireTTL, nil
+		}
+	case SchemaRuleColumnDisallowNullableInSortingKey:
+		if engine == storepb.Engine_CLICKHOUSE {
+			return ClickHouseColumnDisallowNullableInSortingKey, nil
+		}
+	case SchemaRuleTableRequireInterleave:
+		if engine == storepb.Engine_SPANNER {
+			return SpannerTableRequireInterleave, nil
+		}
+	case SchemaRuleTableRequireInterleaveOnDelete:
+		if engine == storepb.Engine_SPANNER {
+			return SpannerTableRequireInterleaveOnDelete, nil
+		}
+	case SchemaRuleIndexPrimaryKeyDisallowMonotonic:
+		if engine == storepb.Engine_SPANNER {
+			return SpannerPrimaryKeyDisallowMonotonic, nil
+		}
+	case SchemaRuleColumnDisallowRenameReferencedByView:
+		if engine == storepb.Engine_POSTGRES {
+			return PostgreSQLColumnDisallowRenameReferencedByView, nil
+		}
 	case SchemaRuleCommentLength:
 		if engine == storepb.Engine_POSTGRES {
 			return PostgreSQLCommentConvention, nil
 		}
+	case SchemaRuleCustom:
+		switch engine {
+		case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
+			return MySQLCustomRule, nil
+		case storepb.Engine_POSTGRES:
+			return PostgreSQLCustomRule, nil
+		}
 	}
 	return Fake, errors.Errorf("unknown SQL review rule type %v for %v", ruleType, engine)
 }
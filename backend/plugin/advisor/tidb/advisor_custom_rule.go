@@ -0,0 +1,28 @@
+package tidb
+
+import (
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	_ advisor.Advisor = (*CustomRuleAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(storepb.Engine_TIDB, advisor.MySQLCustomRule, &CustomRuleAdvisor{})
+}
+
+// CustomRuleAdvisor is the advisor checking for the custom rule in CEL.
+type CustomRuleAdvisor struct {
+}
+
+// Check checks for the custom rule in CEL.
+func (*CustomRuleAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	modelList, err := catalog.BuildStatementModels(storepb.Engine_TIDB, statement, ctx.Catalog)
+	if err != nil {
+		return nil, err
+	}
+	return advisor.CheckCustomRule(ctx, modelList)
+}
@@ -3,12 +3,14 @@ package advisor
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -35,6 +37,10 @@ const (
 	MockOldMySQLPKName = "PRIMARY"
 	// MockOldPostgreSQLPKName is the mock old primary key for PostgreSQL test.
 	MockOldPostgreSQLPKName = "old_pk"
+	// MockOldOraclePKName is the mock old primary key for Oracle test.
+	MockOldOraclePKName = "OLD_PK"
+	// MockOldMSSQLPKName is the mock old primary key for SQL Server test.
+	MockOldMSSQLPKName = "old_pk"
 	// MockTableName is the mock table for test.
 	MockTableName = "tech_book"
 )
@@ -113,6 +119,97 @@ var (
 							},
 						},
 					},
+					{
+						Name: "author",
+						Columns: []*storepb.ColumnMetadata{
+							{Name: "id", Type: "integer"},
+							{Name: "name", Type: "character varying(20)", Nullable: true},
+							{Name: "price", Type: "numeric(10, 2)", Nullable: true},
+							{Name: "bio", Type: "text", Nullable: true},
+							{Name: "nickname", Type: "text", Nullable: true},
+						},
+					},
+				},
+				Views: []*storepb.ViewMetadata{
+					{
+						Name:       "author_view",
+						Definition: "SELECT author.nickname FROM author",
+						DependentColumns: []*storepb.DependentColumn{
+							{Schema: "public", Table: "author", Column: "nickname"},
+						},
+					},
+				},
+			},
+		},
+	}
+	// MockOracleDatabase is the mock Oracle database for test.
+	MockOracleDatabase = &storepb.DatabaseSchemaMetadata{
+		Name: "TEST_DB",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "SYS",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name:     "EMPLOYEE",
+						RowCount: 10,
+						Columns: []*storepb.ColumnMetadata{
+							{Name: "ID", Type: "NUMBER"},
+							{Name: "NAME", Type: "VARCHAR2(255)"},
+						},
+						Indexes: []*storepb.IndexMetadata{
+							{
+								Name:        MockOldOraclePKName,
+								Expressions: []string{"ID", "NAME"},
+								Unique:      true,
+								Primary:     true,
+							},
+							{
+								Name:        "OLD_UK",
+								Expressions: []string{"NAME", "ID"},
+								Unique:      true,
+							},
+							{
+								Name:        "OLD_INDEX",
+								Expressions: []string{"NAME"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	// MockMSSQLDatabase is the mock SQL Server database for test.
+	MockMSSQLDatabase = &storepb.DatabaseSchemaMetadata{
+		Name: "TEST_DB",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "dbo",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name:     MockTableName,
+						RowCount: 10,
+						Columns: []*storepb.ColumnMetadata{
+							{Name: "id", Type: "int"},
+							{Name: "name", Type: "varchar(255)"},
+						},
+						Indexes: []*storepb.IndexMetadata{
+							{
+								Name:        MockOldMSSQLPKName,
+								Expressions: []string{"id", "name"},
+								Unique:      true,
+								Primary:     true,
+							},
+							{
+								Name:        MockOldUKName,
+								Expressions: []string{"name", "id"},
+								Unique:      true,
+							},
+							{
+								Name:        MockOldIndexName,
+								Expressions: []string{"name"},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -156,54 +253,126 @@ func RunSQLReviewRuleTest(t *testing.T, rule SQLReviewRuleType, dbType storepb.E
 	require.NoError(t, err, rule)
 
 	for i, tc := range tests {
-		database := MockMySQLDatabase
-		if dbType == storepb.Engine_POSTGRES {
-			database = MockPostgreSQLDatabase
+		adviceList := checkSQLReviewRule(t, rule, dbType, tc.Statement, nil)
+		if record {
+			tests[i].Want = adviceList
+		} else {
+			require.Equalf(t, tc.Want, adviceList, "rule: %s, statements: %s", rule, tc.Statement)
 		}
-		finder := catalog.NewFinder(database, &catalog.FinderContext{CheckIntegrity: true, EngineType: dbType})
+	}
 
-		payload, err := SetDefaultSQLReviewRulePayload(rule, dbType)
+	if record {
+		err := yamlFile.Close()
+		require.NoError(t, err)
+		byteValue, err := yaml.Marshal(tests)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath, byteValue, 0644)
 		require.NoError(t, err)
+	}
+}
 
-		ruleList := []*storepb.SQLReviewRule{
-			{
-				Type:    string(rule),
-				Level:   storepb.SQLReviewRuleLevel_WARNING,
-				Payload: string(payload),
-			},
-		}
+// checkSQLReviewRule checks the statement with the rule against the mock database of the engine.
+func checkSQLReviewRule(t *testing.T, rule SQLReviewRuleType, dbType storepb.Engine, statement string, db *sql.DB) []Advice {
+	database := MockMySQLDatabase
+	checkIntegrity := true
+	switch dbType {
+	case storepb.Engine_POSTGRES:
+		database = MockPostgreSQLDatabase
+	case storepb.Engine_ORACLE:
+		database = MockOracleDatabase
+		// The Oracle and SQL Server test cases are not bound to the mock database,
+		// so we walk through them without the integrity check.
+		checkIntegrity = false
+	case storepb.Engine_MSSQL:
+		database = MockMSSQLDatabase
+		checkIntegrity = false
+	}
+	finder := catalog.NewFinder(database, &catalog.FinderContext{CheckIntegrity: checkIntegrity, EngineType: dbType})
+
+	payload, err := SetDefaultSQLReviewRulePayload(rule, dbType)
+	require.NoError(t, err)
 
-		ctx := SQLReviewCheckContext{
-			Charset:         "",
-			Collation:       "",
-			DbType:          dbType,
-			Catalog:         &testCatalog{finder: finder},
-			Driver:          nil,
-			Context:         context.Background(),
-			CurrentSchema:   "SYS",
-			CurrentDatabase: "TEST_DB",
+	ruleList := []*storepb.SQLReviewRule{
+		{
+			Type:    string(rule),
+			Level:   storepb.SQLReviewRuleLevel_WARNING,
+			Payload: string(payload),
+		},
+	}
+
+	ctx := SQLReviewCheckContext{
+		Charset:         "",
+		Collation:       "",
+		DbType:          dbType,
+		Catalog:         &testCatalog{finder: finder},
+		Driver:          db,
+		Context:         context.Background(),
+		CurrentSchema:   "SYS",
+		CurrentDatabase: "TEST_DB",
+	}
+
+	adviceList, err := SQLReviewCheck(statement, ruleList, ctx)
+	// Sort adviceList by (line, content)
+	sort.Slice(adviceList, func(i, j int) bool {
+		if adviceList[i].Line != adviceList[j].Line {
+			return adviceList[i].Line < adviceList[j].Line
 		}
+		return adviceList[i].Content < adviceList[j].Content
+	})
 
-		adviceList, err := SQLReviewCheck(tc.Statement, ruleList, ctx)
-		// Sort adviceList by (line, content)
-		sort.Slice(adviceList, func(i, j int) bool {
-			if adviceList[i].Line != adviceList[j].Line {
-				return adviceList[i].Line < adviceList[j].Line
-			}
-			return adviceList[i].Content < adviceList[j].Content
-		})
+	require.NoError(t, err)
+	// The rule type is the same for all the advices of the rule, it's not recorded in the test cases.
+	for i := range adviceList {
+		adviceList[i].RuleType = ""
+	}
+	return adviceList
+}
 
-		require.NoError(t, err)
+// DriverTestCase is the test case shared by the rules querying the database.
+type DriverTestCase struct {
+	Statement string `yaml:"statement"`
+	// Rows is the estimated rows returned by the mock database.
+	Rows int64 `yaml:"rows"`
+	// Error fails the queries of the statement on the mock database if it's not empty.
+	Error string                         `yaml:"error,omitempty"`
+	Want  map[SQLReviewRuleType][]Advice `yaml:"want"`
+}
+
+// RunSQLReviewRuleDriverTest helps to test the SQL review rules querying the database with the mock database.
+// The rules share the test cases in test/{fileName}.yaml.
+func RunSQLReviewRuleDriverTest(t *testing.T, fileName string, rules []SQLReviewRuleType, dbType storepb.Engine, record bool) {
+	var tests []DriverTestCase
+
+	filepath := filepath.Join("test", fileName+".yaml")
+	byteValue, err := os.ReadFile(filepath)
+	require.NoError(t, err)
+	err = yaml.Unmarshal(byteValue, &tests)
+	require.NoError(t, err, fileName)
+
+	for i, tc := range tests {
 		if record {
-			tests[i].Want = adviceList
-		} else {
-			require.Equalf(t, tc.Want, adviceList, "rule: %s, statements: %s", rule, tc.Statement)
+			tests[i].Want = make(map[SQLReviewRuleType][]Advice)
+		}
+		for _, rule := range rules {
+			connector := &mockQueryConnector{rows: tc.Rows, err: tc.Error}
+			db := sql.OpenDB(connector)
+			adviceList := checkSQLReviewRule(t, rule, dbType, tc.Statement, db)
+			require.NoError(t, db.Close())
+			connector.mu.Lock()
+			conns := connector.conns
+			connector.mu.Unlock()
+			for _, conn := range conns {
+				require.Falsef(t, conn.showPlan, "rule: %s, statements: %s, the connection is left with SHOWPLAN_ALL on", rule, tc.Statement)
+			}
+			if record {
+				tests[i].Want[rule] = adviceList
+			} else {
+				require.Equalf(t, tc.Want[rule], adviceList, "rule: %s, statements: %s", rule, tc.Statement)
+			}
 		}
 	}
 
 	if record {
-		err := yamlFile.Close()
-		require.NoError(t, err)
 		byteValue, err := yaml.Marshal(tests)
 		require.NoError(t, err)
 		err = os.WriteFile(filepath, byteValue, 0644)
@@ -211,6 +380,110 @@ func RunSQLReviewRuleTest(t *testing.T, rule SQLReviewRuleType, dbType storepb.E
 	}
 }
 
+// mockQueryConnector is the mock database answering the queries estimating and dry running the statements,
+// i.e. EXPLAIN, SELECT COUNT(*) and the statements compiled with SQL Server SHOWPLAN_ALL.
+// The other statements fail, so that the statements are never executed.
+type mockQueryConnector struct {
+	rows int64
+	err  string
+
+	mu    sync.Mutex
+	conns []*mockQueryConn
+}
+
+func (c *mockQueryConnector) Connect(context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	conn := &mockQueryConn{connector: c}
+	c.conns = append(c.conns, conn)
+	return conn, nil
+}
+
+func (c *mockQueryConnector) Driver() driver.Driver {
+	return nil
+}
+
+type mockQueryConn struct {
+	connector *mockQueryConnector
+	// showPlan is the SQL Server session option SHOWPLAN_ALL of the connection.
+	showPlan bool
+}
+
+func (*mockQueryConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (*mockQueryConn) Close() error {
+	return nil
+}
+
+func (c *mockQueryConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (*mockQueryConn) Commit() error {
+	return nil
+}
+
+func (*mockQueryConn) Rollback() error {
+	return nil
+}
+
+func (c *mockQueryConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	switch query {
+	case "SET SHOWPLAN_ALL ON":
+		c.showPlan = true
+	case "SET SHOWPLAN_ALL OFF":
+		c.showPlan = false
+	default:
+		return nil, errors.Errorf("unexpected statement %q", query)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *mockQueryConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if c.connector.err != "" {
+		return nil, errors.New(c.connector.err)
+	}
+	switch {
+	case strings.HasPrefix(query, "SELECT COUNT(*)"):
+		return &mockQueryRows{columns: []string{"COUNT(*)"}, types: []string{"INT"}, values: [][]driver.Value{{c.connector.rows}}}, nil
+	case strings.HasPrefix(query, "EXPLAIN"):
+		return &mockQueryRows{columns: []string{"operation"}, types: []string{"TEXT"}, values: [][]driver.Value{{"Result"}}}, nil
+	case c.showPlan:
+		return &mockQueryRows{columns: []string{"StmtText", "EstimateRows"}, types: []string{"NVARCHAR", "FLOAT"}, values: [][]driver.Value{{query, float64(c.connector.rows)}}}, nil
+	default:
+		return nil, errors.Errorf("statement %q is executed", query)
+	}
+}
+
+type mockQueryRows struct {
+	columns []string
+	types   []string
+	values  [][]driver.Value
+}
+
+func (r *mockQueryRows) Columns() []string {
+	return r.columns
+}
+
+func (r *mockQueryRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.types[index]
+}
+
+func (*mockQueryRows) Close() error {
+	return nil
+}
+
+func (r *mockQueryRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
 // MockDriver is the driver for test only.
 type MockDriver struct {
 }
@@ -344,11 +617,24 @@ func SetDefaultSQLReviewRulePayload(ruleTp SQLReviewRuleType, dbType storepb.Eng
 		SchemaRuleSchemaBackwardCompatibility,
 		SchemaRuleDropEmptyDatabase,
 		SchemaRuleIndexNoDuplicateColumn,
+		SchemaRuleIndexNoDuplicateIndex,
 		SchemaRuleIndexPKTypeLimit,
 		SchemaRuleStatementDisallowAddColumnWithDefault,
 		SchemaRuleCreateIndexConcurrently,
 		SchemaRuleStatementAddCheckNotValid,
 		SchemaRuleStatementDisallowAddNotNull,
+		SchemaRuleStatementDisallowTableRewrite,
+		SchemaRuleStatementSetNotNullRequireCheck,
+		SchemaRuleStatementAddFKNotValid,
+		SchemaRuleStatementDisallowBlockingMaintenance,
+		SchemaRuleStatementRequireLockTimeout,
+		SchemaRuleStatementDisallowMutation,
+		SchemaRuleTableRequireTTL,
+		SchemaRuleTableRequireInterleave,
+		SchemaRuleTableRequireInterleaveOnDelete,
+		SchemaRuleColumnDisallowNullableInSortingKey,
+		SchemaRuleIndexPrimaryKeyDisallowMonotonic,
+		SchemaRuleColumnDisallowRenameReferencedByView,
 		SchemaRuleIndexTypeNoBlob,
 		SchemaRuleIdentifierNoKeyword,
 		SchemaRuleTableNameNoKeyword:
@@ -365,6 +651,8 @@ func SetDefaultSQLReviewRulePayload(ruleTp SQLReviewRuleType, dbType storepb.Eng
 			format = "^[A-Z]+(_[A-Z]+)*$"
 		} else if dbType == storepb.Engine_MSSQL {
 			format = "^[A-Z]([_A-Za-z])*$"
+		} else if dbType == storepb.Engine_SPANNER {
+			format = "^[A-Z][A-Za-z0-9]*$"
 		}
 		payload, err = json.Marshal(NamingRulePayload{
 			Format:    format,
@@ -415,8 +703,14 @@ func SetDefaultSQLReviewRulePayload(ruleTp SQLReviewRuleType, dbType storepb.Eng
 			},
 		})
 	case SchemaRuleColumnTypeDisallowList:
+		list := []string{"JSON", "BINARY_FLOAT"}
+		if dbType == storepb.Engine_MSSQL {
+			list = []string{"TEXT", "IMAGE"}
+		} else if dbType == storepb.Engine_SNOWFLAKE {
+			list = []string{"VARIANT", "BINARY"}
+		}
 		payload, err = json.Marshal(StringArrayTypeRulePayload{
-			List: []string{"JSON", "BINARY_FLOAT"},
+			List: list,
 		})
 	case SchemaRuleColumnMaximumCharacterLength:
 		payload, err = json.Marshal(NumberTypeRulePayload{
@@ -454,6 +748,12 @@ func SetDefaultSQLReviewRulePayload(ruleTp SQLReviewRuleType, dbType storepb.Eng
 		payload, err = json.Marshal(NamingCaseRulePayload{
 			Upper: true,
 		})
+	case SchemaRuleCustom:
+		payload, err = json.Marshal(CustomRulePayload{
+			Title:      "custom.not-null-column-require-default",
+			Expression: `statement.added_columns.exists(c, !c.nullable && !c.has_default) || (statement.type in ["UPDATE", "DELETE"] && !statement.has_where) || statement.affected_rows > 2 || (statement.type == "CREATE_INDEX" && statement.target_tables.exists(t, t.exists && t.indexes.size() > 3))`,
+			Message:    "Disallow adding NOT NULL columns without default, UPDATE/DELETE without WHERE, inserting more than 2 rows, and indexing a table with more than 3 indexes",
+		})
 	default:
 		return "", errors.Errorf("unknown SQL review type for default payload: %s", ruleTp)
 	}
@@ -41,6 +41,7 @@ var (
 
 func init() {
 	db.Register(storepb.Engine_CLICKHOUSE, newDriver)
+	db.RegisterCapability(storepb.Engine_CLICKHOUSE, db.Capability{Dump: true, Restore: true})
 }
 
 // Driver is the ClickHouse driver.
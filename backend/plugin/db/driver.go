@@ -14,6 +14,7 @@ import (
 	"unicode"
 
 	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
 
 	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 	"github.com/bytebase/bytebase/backend/store/model"
@@ -59,10 +60,20 @@ type IndexKey struct {
 }
 
 var (
-	driversMu sync.RWMutex
-	drivers   = make(map[storepb.Engine]driverFunc)
+	driversMu    sync.RWMutex
+	drivers      = make(map[storepb.Engine]driverFunc)
+	capabilities = make(map[storepb.Engine]Capability)
 )
 
+// Capability is the dump and restore capability of the database driver.
+type Capability struct {
+	// Dump is true if the driver dumps the schema, and DumpData is true if the dump contains the data besides the schema.
+	Dump     bool
+	DumpData bool
+	// Restore is true if the driver restores the dump by executing it.
+	Restore bool
+}
+
 // DriverConfig is the driver configuration.
 type DriverConfig struct {
 	// The directiory contains db specific utilites (e.g. mysqldump for MySQL, pg_dump for PostgreSQL, mongosh for MongoDB).
@@ -446,6 +457,36 @@ type Driver interface {
 	Restore(ctx context.Context, src io.Reader) error
 }
 
+// DumpOptions is the options to dump a part of the database.
+type DumpOptions struct {
+	// SchemaOnly dumps the schema without the data.
+	SchemaOnly bool
+	// DataOnly dumps the data without the schema.
+	DataOnly bool
+	// IncludeTables are the tables to dump, all tables are dumped if empty.
+	IncludeTables []string
+	// ExcludeTables are the tables not to dump.
+	ExcludeTables []string
+	// Parallel is the maximum number of the tables whose data is dumped at the same time.
+	// The tables are not dumped in the same snapshot if it's greater than 1.
+	Parallel int
+}
+
+// ShouldDumpTable returns true if the table is dumped with the options.
+func (o DumpOptions) ShouldDumpTable(table string) bool {
+	if len(o.IncludeTables) > 0 && !slices.Contains(o.IncludeTables, table) {
+		return false
+	}
+	return !slices.Contains(o.ExcludeTables, table)
+}
+
+// TableDumper is the optional interface of the drivers which can dump a part of the database.
+type TableDumper interface {
+	// DumpWithOptions dumps the database with the options.
+	// Unlike Dump, it doesn't lock the tables for the backup payload.
+	DumpWithOptions(ctx context.Context, out io.Writer, opts DumpOptions) error
+}
+
 // Register makes a database driver available by the provided type.
 // If Register is called twice with the same name or if driver is nil,
 // it panics.
@@ -461,6 +502,24 @@ func Register(dbType storepb.Engine, f driverFunc) {
 	drivers[dbType] = f
 }
 
+// RegisterCapability registers the dump and restore capability of the database driver.
+// If RegisterCapability is called twice with the same type, it panics.
+func RegisterCapability(dbType storepb.Engine, capability Capability) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	if _, dup := capabilities[dbType]; dup {
+		panic(fmt.Sprintf("db: RegisterCapability called twice for driver %s", dbType))
+	}
+	capabilities[dbType] = capability
+}
+
+// GetCapability returns the dump and restore capability of the database driver, or the zero capability if it's not registered.
+func GetCapability(dbType storepb.Engine) Capability {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	return capabilities[dbType]
+}
+
 // Open opens a database specified by its database driver type and connection config without verifying the connection.
 func Open(ctx context.Context, dbType storepb.Engine, driverConfig DriverConfig, connectionConfig ConnectionConfig, connCtx ConnectionContext) (Driver, error) {
 	driversMu.RLock()
@@ -487,6 +546,8 @@ type ExecuteOptions struct {
 	// For both cases, we will use one transaction to wrap the statements.
 	ChunkedSubmission     bool
 	UpdateExecutionStatus func(*v1pb.TaskRun_ExecutionDetail)
+	// NoticeFunc receives the notices and warnings reported by the database while executing the statement.
+	NoticeFunc func(notice string)
 }
 
 // ErrorWithPosition is the error with the position information.
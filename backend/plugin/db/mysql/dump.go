@@ -18,6 +18,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
 	"github.com/bytebase/bytebase/backend/resources/mysqlutil"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -142,7 +143,7 @@ func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool)
 	defer txn.Rollback()
 
 	slog.Debug("begin to dump database", slog.String("database", driver.databaseName), slog.Bool("schemaOnly", schemaOnly))
-	if err := dumpTxn(txn, driver.dbType, driver.databaseName, out, schemaOnly); err != nil {
+	if err := dumpTxn(txn, driver.dbType, driver.databaseName, out, db.DumpOptions{SchemaOnly: schemaOnly}, nil /* dataFiles */); err != nil {
 		return "", err
 	}
 
@@ -153,6 +154,81 @@ func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool)
 	return string(payloadBytes), nil
 }
 
+// DumpWithOptions dumps the database with the options.
+func (driver *Driver) DumpWithOptions(ctx context.Context, out io.Writer, opts db.DumpOptions) error {
+	if driver.databaseName == "" {
+		return errors.Errorf("MySQL can dump one database only at a time with the options")
+	}
+
+	var dataFiles *util.TableDumpFiles
+	if opts.Parallel > 1 && !opts.SchemaOnly {
+		tables, err := driver.listDataTables(ctx, opts)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list tables of database %q", driver.databaseName)
+		}
+		dataFiles, err = util.DumpTablesInParallel(ctx, tables, opts.Parallel, driver.exportTableDataInTxn)
+		if err != nil {
+			return err
+		}
+		defer dataFiles.Close()
+	}
+
+	options := sql.TxOptions{}
+	// TiDB does not support readonly, so we only set for MySQL and OceanBase.
+	if driver.dbType == storepb.Engine_MYSQL || driver.dbType == storepb.Engine_MARIADB || driver.dbType == storepb.Engine_OCEANBASE {
+		options.ReadOnly = true
+	}
+	txn, err := driver.db.BeginTx(ctx, &options)
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	slog.Debug("begin to dump database with options", slog.String("database", driver.databaseName), slog.Bool("schemaOnly", opts.SchemaOnly), slog.Bool("dataOnly", opts.DataOnly))
+	if err := dumpTxn(txn, driver.dbType, driver.databaseName, out, opts, dataFiles); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// exportTableDataInTxn exports the data of a table in a separate transaction.
+func (driver *Driver) exportTableDataInTxn(ctx context.Context, tblName string, out io.Writer) error {
+	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: driver.dbType != storepb.Engine_TIDB})
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+	if err := exportTableData(txn, driver.databaseName, tblName, out); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// listDataTables lists the base tables whose data is dumped with the options.
+func (driver *Driver) listDataTables(ctx context.Context, opts db.DumpOptions) ([]string, error) {
+	query := fmt.Sprintf("SHOW FULL TABLES FROM `%s`;", driver.databaseName)
+	rows, err := driver.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name, tableType string
+		if err := rows.Scan(&name, &tableType); err != nil {
+			return nil, err
+		}
+		if tableType == baseTableType && opts.ShouldDumpTable(name) {
+			tables = append(tables, name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return tables, nil
+}
+
 // FlushTablesWithReadLock runs FLUSH TABLES table1, table2, ... WITH READ LOCK for all the tables in the database.
 func FlushTablesWithReadLock(ctx context.Context, dbType storepb.Engine, conn *sql.Conn, database string) error {
 	// The lock acquiring could take a long time if there are concurrent exclusive locks on the tables.
@@ -189,7 +265,9 @@ func FlushTablesWithReadLock(ctx context.Context, dbType storepb.Engine, conn *s
 	return txn.Commit()
 }
 
-func dumpTxn(txn *sql.Tx, dbType storepb.Engine, database string, out io.Writer, schemaOnly bool) error {
+// dumpTxn dumps the database, the routines and the events are dumped only if all tables are dumped.
+// The data of the tables is copied from dataFiles if it's not nil.
+func dumpTxn(txn *sql.Tx, dbType storepb.Engine, database string, out io.Writer, opts db.DumpOptions, dataFiles *util.TableDumpFiles) error {
 	// Disable foreign key check.
 	// mysqldump uses the same mechanism. When there is any schema or data dependency, we have to disable
 	// the unique and foreign key check so that the restoring will not fail.
@@ -199,10 +277,18 @@ func dumpTxn(txn *sql.Tx, dbType storepb.Engine, database string, out io.Writer,
 
 	// Table and view statement.
 	// We have to dump the table before views because of the structure dependency.
-	tables, err := getTablesTx(txn, dbType, database)
+	allTables, err := getTablesTx(txn, dbType, database)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get tables of database %q", database)
 	}
+	var tables []*TableSchema
+	for _, tbl := range allTables {
+		if opts.ShouldDumpTable(tbl.Name) {
+			tables = append(tables, tbl)
+		}
+	}
+	dumpSchema := !opts.DataOnly
+	dumpAll := len(tables) == len(allTables) && len(opts.IncludeTables) == 0
 	// Construct temporal views.
 	// Create a temporary view with the same name as the view and with columns of
 	// the same name in order to satisfy views that depend on this view.
@@ -214,7 +300,7 @@ func dumpTxn(txn *sql.Tx, dbType storepb.Engine, database string, out io.Writer,
 	// between views and can simply dump them in the appropriate order.
 	// https://sourcegraph.com/github.com/mysql/mysql-server/-/blob/client/mysqldump.cc?L2781
 	for _, tbl := range tables {
-		if tbl.TableType != viewTableType {
+		if !dumpSchema || tbl.TableType != viewTableType {
 			continue
 		}
 		if tbl.InvalidView != "" {
@@ -233,18 +319,32 @@ func dumpTxn(txn *sql.Tx, dbType storepb.Engine, database string, out io.Writer,
 		if tbl.TableType == viewTableType {
 			continue
 		}
-		if schemaOnly {
-			tbl.Statement = excludeSchemaAutoValues(tbl.Statement)
-		}
-		if _, err := io.WriteString(out, fmt.Sprintf("%s\n", tbl.Statement)); err != nil {
-			return err
+		if dumpSchema {
+			if opts.SchemaOnly {
+				tbl.Statement = excludeSchemaAutoValues(tbl.Statement)
+			}
+			if _, err := io.WriteString(out, fmt.Sprintf("%s\n", tbl.Statement)); err != nil {
+				return err
+			}
 		}
-		if !schemaOnly && tbl.TableType == baseTableType {
-			if err := exportTableData(txn, database, tbl.Name, out); err != nil {
+		if !opts.SchemaOnly && tbl.TableType == baseTableType {
+			if dataFiles != nil {
+				if err := dataFiles.Copy(tbl.Name, out); err != nil {
+					return err
+				}
+			} else if err := exportTableData(txn, database, tbl.Name, out); err != nil {
 				return err
 			}
 		}
 	}
+	if !dumpSchema {
+		// Restore foreign key check.
+		if _, err := io.WriteString(out, restoreUniqueAndForeignKeyCheckStmt); err != nil {
+			return err
+		}
+		return nil
+	}
+
 	// Construct final views.
 	for _, tbl := range tables {
 		if tbl.TableType != viewTableType {
@@ -260,19 +360,21 @@ func dumpTxn(txn *sql.Tx, dbType storepb.Engine, database string, out io.Writer,
 		}
 	}
 
-	// Procedure and function (routine) statements.
-	routines, err := getRoutines(txn, dbType, database)
-	if err != nil {
-		return errors.Wrapf(err, "failed to get routines of database %q", database)
-	}
-	for _, rt := range routines {
-		if _, err := io.WriteString(out, fmt.Sprintf("%s\n", rt.statement)); err != nil {
-			return err
+	if dumpAll {
+		// Procedure and function (routine) statements.
+		routines, err := getRoutines(txn, dbType, database)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get routines of database %q", database)
+		}
+		for _, rt := range routines {
+			if _, err := io.WriteString(out, fmt.Sprintf("%s\n", rt.statement)); err != nil {
+				return err
+			}
 		}
 	}
 
 	// OceanBase doesn't support "Event Scheduler"
-	if dbType != storepb.Engine_OCEANBASE {
+	if dumpAll && dbType != storepb.Engine_OCEANBASE {
 		// Event statements.
 		events, err := getEvents(txn, database)
 		if err != nil {
@@ -291,6 +393,9 @@ func dumpTxn(txn *sql.Tx, dbType storepb.Engine, database string, out io.Writer,
 		return errors.Wrapf(err, "failed to get triggers of database %q", database)
 	}
 	for _, tr := range triggers {
+		if !opts.ShouldDumpTable(tr.table) {
+			continue
+		}
 		if _, err := io.WriteString(out, fmt.Sprintf("%s\n", tr.statement)); err != nil {
 			return err
 		}
@@ -412,6 +517,7 @@ type eventSchema struct {
 // triggerSchema describes the schema of a trigger.
 type triggerSchema struct {
 	name      string
+	table     string
 	statement string
 }
 
@@ -773,6 +879,7 @@ func getTriggers(txn *sql.Tx, dbType storepb.Engine, dbName string) ([]*triggerS
 			return nil, err
 		}
 		tr.name = fmt.Sprintf("%s", *values[0].(*any))
+		tr.table = fmt.Sprintf("%s", *values[2].(*any))
 		triggers = append(triggers, &tr)
 	}
 	if err := rows.Err(); err != nil {
@@ -41,6 +41,9 @@ func init() {
 	db.Register(storepb.Engine_TIDB, newDriver)
 	db.Register(storepb.Engine_MARIADB, newDriver)
 	db.Register(storepb.Engine_OCEANBASE, newDriver)
+	db.RegisterCapability(storepb.Engine_MYSQL, db.Capability{Dump: true, DumpData: true, Restore: true})
+	db.RegisterCapability(storepb.Engine_TIDB, db.Capability{Dump: true, DumpData: true, Restore: true})
+	db.RegisterCapability(storepb.Engine_MARIADB, db.Capability{Dump: true, DumpData: true, Restore: true})
 }
 
 // Driver is the MySQL driver.
@@ -283,6 +286,9 @@ func (driver *Driver) Execute(ctx context.Context, statement string, _ bool, opt
 		}
 		totalRowsAffected += rowsAffected
 		currentIndex += len(chunk)
+		if opts.NoticeFunc != nil {
+			reportWarnings(ctx, tx, opts.NoticeFunc)
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -292,6 +298,28 @@ func (driver *Driver) Execute(ctx context.Context, statement string, _ bool, opt
 	return totalRowsAffected, nil
 }
 
+// reportWarnings reports the warnings of the last statement executed in the transaction.
+func reportWarnings(ctx context.Context, tx *sql.Tx, noticeFunc func(string)) {
+	rows, err := tx.QueryContext(ctx, "SHOW WARNINGS")
+	if err != nil {
+		slog.Debug("failed to show warnings", log.BBError(err))
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var level, message string
+		var code int
+		if err := rows.Scan(&level, &code, &message); err != nil {
+			slog.Debug("failed to scan warnings", log.BBError(err))
+			return
+		}
+		noticeFunc(fmt.Sprintf("%s %d: %s", level, code, message))
+	}
+	if err := rows.Err(); err != nil {
+		slog.Debug("failed to show warnings", log.BBError(err))
+	}
+}
+
 func (driver *Driver) TiDBExecute(ctx context.Context, statement string, _ bool, opts db.ExecuteOptions) (int64, error) {
 	conn, err := driver.db.Conn(ctx)
 	if err != nil {
@@ -33,6 +33,7 @@ const dbVersion12 = 12
 
 func init() {
 	db.Register(storepb.Engine_ORACLE, newDriver)
+	db.RegisterCapability(storepb.Engine_ORACLE, db.Capability{Dump: true})
 }
 
 // Driver is the Oracle driver.
@@ -16,6 +16,7 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
 	pgparser "github.com/bytebase/bytebase/backend/plugin/parser/pg"
 )
@@ -58,7 +59,7 @@ func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool)
 	}
 
 	for _, dbName := range dumpableDbNames {
-		if err := driver.dumpOneDatabaseWithPgDump(ctx, dbName, out, schemaOnly); err != nil {
+		if err := driver.dumpOneDatabaseWithPgDump(ctx, dbName, out, db.DumpOptions{SchemaOnly: schemaOnly}); err != nil {
 			return "", err
 		}
 	}
@@ -66,7 +67,130 @@ func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool)
 	return "", nil
 }
 
-func (driver *Driver) dumpOneDatabaseWithPgDump(ctx context.Context, database string, out io.Writer, schemaOnly bool) error {
+// DumpWithOptions dumps the database with the options.
+// The tables in the options are qualified with the schema, e.g. public.users.
+func (driver *Driver) DumpWithOptions(ctx context.Context, out io.Writer, opts db.DumpOptions) error {
+	if driver.databaseName == "" {
+		return errors.Errorf("PostgreSQL can dump one database only at a time with the options")
+	}
+	return driver.dumpOneDatabaseWithPgDump(ctx, driver.databaseName, out, opts)
+}
+
+// listDataTables lists the tables whose data is dumped with the options, the tables are qualified with the schema.
+func (driver *Driver) listDataTables(ctx context.Context, opts db.DumpOptions) ([]string, error) {
+	query := fmt.Sprintf(`
+	SELECT schemaname, tablename
+	FROM pg_catalog.pg_tables
+	WHERE schemaname NOT IN (%s)
+	ORDER BY schemaname, tablename;`, pgparser.SystemSchemaWhereClause)
+	rows, err := driver.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var schemaName, tableName string
+		if err := rows.Scan(&schemaName, &tableName); err != nil {
+			return nil, err
+		}
+		table := fmt.Sprintf("%s.%s", schemaName, tableName)
+		if opts.ShouldDumpTable(table) {
+			tables = append(tables, table)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return tables, nil
+}
+
+// getTablePattern returns the exact pg_dump pattern of the table qualified with the schema.
+func getTablePattern(table string) string {
+	schemaName, tableName, ok := strings.Cut(table, ".")
+	if !ok {
+		return quoteIdentifier(table)
+	}
+	return fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(tableName))
+}
+
+func quoteIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
+
+func (driver *Driver) dumpOneDatabaseWithPgDump(ctx context.Context, database string, out io.Writer, opts db.DumpOptions) error {
+	if opts.Parallel > 1 && !opts.SchemaOnly {
+		return driver.dumpOneDatabaseInParallel(ctx, database, out, opts)
+	}
+
+	var args []string
+	if opts.SchemaOnly {
+		args = append(args, "--schema-only")
+	}
+	if opts.DataOnly {
+		args = append(args, "--data-only")
+	}
+	args = append(args, getTableFilterArgs(opts)...)
+	return driver.execPgDumpWithSslCAs(ctx, database, out, args)
+}
+
+// dumpOneDatabaseInParallel dumps the pre-data section, the data of each table in parallel, the data of the sequences and the post-data section in order.
+func (driver *Driver) dumpOneDatabaseInParallel(ctx context.Context, database string, out io.Writer, opts db.DumpOptions) error {
+	tables, err := driver.listDataTables(ctx, opts)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list tables of database %q", database)
+	}
+	filterArgs := getTableFilterArgs(opts)
+	if !opts.DataOnly {
+		if err := driver.execPgDumpWithSslCAs(ctx, database, out, append([]string{"--section=pre-data"}, filterArgs...)); err != nil {
+			return err
+		}
+	}
+
+	dataFiles, err := util.DumpTablesInParallel(ctx, tables, opts.Parallel, func(ctx context.Context, table string, out io.Writer) error {
+		return driver.execPgDumpWithSslCAs(ctx, database, out, []string{"--data-only", fmt.Sprintf("--table=%s", getTablePattern(table))})
+	})
+	if err != nil {
+		return err
+	}
+	defer dataFiles.Close()
+	for _, table := range tables {
+		if err := dataFiles.Copy(table, out); err != nil {
+			return err
+		}
+	}
+
+	// Dump the rest of the data section, e.g. the values of the sequences.
+	restArgs := append([]string{"--data-only"}, filterArgs...)
+	for _, table := range tables {
+		restArgs = append(restArgs, fmt.Sprintf("--exclude-table-data=%s", getTablePattern(table)))
+	}
+	if err := driver.execPgDumpWithSslCAs(ctx, database, out, restArgs); err != nil {
+		return err
+	}
+
+	if !opts.DataOnly {
+		if err := driver.execPgDumpWithSslCAs(ctx, database, out, append([]string{"--section=post-data"}, filterArgs...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getTableFilterArgs(opts db.DumpOptions) []string {
+	var args []string
+	for _, table := range opts.IncludeTables {
+		args = append(args, fmt.Sprintf("--table=%s", getTablePattern(table)))
+	}
+	for _, table := range opts.ExcludeTables {
+		args = append(args, fmt.Sprintf("--exclude-table=%s", getTablePattern(table)))
+	}
+	return args
+}
+
+// execPgDumpWithSslCAs runs pg_dump with the options in args, and retries with each block of the SSL CA.
+func (driver *Driver) execPgDumpWithSslCAs(ctx context.Context, database string, out io.Writer, optionArgs []string) error {
 	var args []string
 	args = append(args, fmt.Sprintf("--username=%s", driver.config.Username))
 	if driver.config.Password == "" {
@@ -90,9 +214,7 @@ func (driver *Driver) dumpOneDatabaseWithPgDump(ctx context.Context, database st
 		databaseAddress := fmt.Sprintf("%s:%s", driver.config.Host, driver.config.Port)
 		go util.ProxyConnection(driver.sshClient, listener, databaseAddress)
 	}
-	if schemaOnly {
-		args = append(args, "--schema-only")
-	}
+	args = append(args, optionArgs...)
 	args = append(args, "--inserts")
 	args = append(args, "--use-set-session-authorization")
 	// Avoid pg_dump v15 generate "ALTER SCHEMA public OWNER TO" statement.
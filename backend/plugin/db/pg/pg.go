@@ -10,11 +10,13 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	// Import pg driver.
 	// init() in pgx/v5/stdlib will register it's pgx driver.
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
@@ -40,6 +42,7 @@ var (
 
 func init() {
 	db.Register(storepb.Engine_POSTGRES, newDriver)
+	db.RegisterCapability(storepb.Engine_POSTGRES, db.Capability{Dump: true, DumpData: true, Restore: true})
 }
 
 // Driver is the Postgres driver.
@@ -54,6 +57,8 @@ type Driver struct {
 	connectionString string
 	databaseName     string
 	connectionCtx    db.ConnectionContext
+	// noticeFunc receives the notices of the executing statement, see db.ExecuteOptions.NoticeFunc.
+	noticeFunc atomic.Pointer[func(string)]
 }
 
 func newDriver(config db.DriverConfig) db.Driver {
@@ -121,6 +126,11 @@ func (driver *Driver) Open(_ context.Context, _ storepb.Engine, config db.Connec
 	if config.ReadOnly {
 		connConfig.RuntimeParams["default_transaction_read_only"] = "true"
 	}
+	connConfig.OnNotice = func(_ *pgconn.PgConn, notice *pgconn.Notice) {
+		if f := driver.noticeFunc.Load(); f != nil {
+			(*f)(fmt.Sprintf("%s: %s", notice.Severity, notice.Message))
+		}
+	}
 
 	driver.databaseName = config.Database
 	if config.Database == "" {
@@ -262,7 +272,11 @@ func (driver *Driver) getPGStatStatementsVersion(ctx context.Context) (string, e
 
 // Execute will execute the statement. For CREATE DATABASE statement, some types of databases such as Postgres
 // will not use transactions to execute the statement but will still use transactions to execute the rest of statements.
-func (driver *Driver) Execute(ctx context.Context, statement string, createDatabase bool, _ db.ExecuteOptions) (int64, error) {
+func (driver *Driver) Execute(ctx context.Context, statement string, createDatabase bool, opts db.ExecuteOptions) (int64, error) {
+	if opts.NoticeFunc != nil {
+		driver.noticeFunc.Store(&opts.NoticeFunc)
+		defer driver.noticeFunc.Store(nil)
+	}
 	if createDatabase {
 		databases, err := driver.getDatabases(ctx)
 		if err != nil {
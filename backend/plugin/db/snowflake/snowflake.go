@@ -31,6 +31,7 @@ var (
 
 func init() {
 	db.Register(storepb.Engine_SNOWFLAKE, newDriver)
+	db.RegisterCapability(storepb.Engine_SNOWFLAKE, db.Capability{Dump: true, Restore: true})
 }
 
 // Driver is the Snowflake driver.
@@ -37,6 +37,7 @@ var (
 
 func init() {
 	db.Register(storepb.Engine_SPANNER, newDriver)
+	db.RegisterCapability(storepb.Engine_SPANNER, db.Capability{Dump: true})
 }
 
 // Driver is the Spanner driver.
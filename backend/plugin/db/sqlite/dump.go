@@ -9,56 +9,62 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
 )
 
 // Dump dumps the database.
 func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool) (string, error) {
+	if err := driver.DumpWithOptions(ctx, out, db.DumpOptions{SchemaOnly: schemaOnly}); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// DumpWithOptions dumps the database with the options.
+func (driver *Driver) DumpWithOptions(ctx context.Context, out io.Writer, opts db.DumpOptions) error {
+	if opts.Parallel > 1 {
+		return errors.Errorf("SQLite doesn't support the parallel dump")
+	}
+	if err := driver.checkDumpDatabase(); err != nil {
+		return err
+	}
+	return driver.dumpOneDatabase(ctx, out, opts)
+}
+
+// checkDumpDatabase makes sure the existence of the database to be dumped.
+func (driver *Driver) checkDumpDatabase() error {
 	if driver.databaseName == "" {
-		return "", errors.Errorf("SQLite can dump one database only at a time")
+		return errors.Errorf("SQLite can dump one database only at a time")
 	}
 
 	// Find all dumpable databases and make sure the existence of the database to be dumped.
 	databases, err := driver.getDatabases()
 	if err != nil {
-		return "", errors.Wrap(err, "failed to get databases")
+		return errors.Wrap(err, "failed to get databases")
 	}
-	exist := false
 	for _, n := range databases {
 		if n == driver.databaseName {
-			exist = true
-			break
+			return nil
 		}
 	}
-	if !exist {
-		return "", errors.Errorf("database %s not found", driver.databaseName)
-	}
-
-	if err := driver.dumpOneDatabase(ctx, out, schemaOnly); err != nil {
-		return "", err
-	}
-
-	return "", nil
+	return errors.Errorf("database %s not found", driver.databaseName)
 }
 
 type sqliteSchema struct {
 	schemaType string
 	name       string
-	statement  string
+	// tableName is the table of the index and the trigger, or the name of the table and the view.
+	tableName string
+	statement string
 }
 
-func (driver *Driver) dumpOneDatabase(ctx context.Context, out io.Writer, schemaOnly bool) error {
-	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
-	if err != nil {
-		return err
-	}
-	defer txn.Rollback()
-
-	// Get all schemas.
-	query := "SELECT type, name, sql FROM sqlite_schema;"
+// getSchemas gets all schemas in the database.
+func getSchemas(ctx context.Context, txn *sql.Tx) ([]sqliteSchema, error) {
+	query := "SELECT type, name, tbl_name, sql FROM sqlite_schema;"
 	rows, err := txn.QueryContext(ctx, query)
 	if err != nil {
-		return util.FormatErrorWithQuery(err, query)
+		return nil, util.FormatErrorWithQuery(err, query)
 	}
 	defer rows.Close()
 
@@ -68,13 +74,28 @@ func (driver *Driver) dumpOneDatabase(ctx context.Context, out io.Writer, schema
 		if err := rows.Scan(
 			&s.schemaType,
 			&s.name,
+			&s.tableName,
 			&s.statement,
 		); err != nil {
-			return err
+			return nil, err
 		}
 		sqliteSchemas = append(sqliteSchemas, s)
 	}
 	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sqliteSchemas, nil
+}
+
+func (driver *Driver) dumpOneDatabase(ctx context.Context, out io.Writer, opts db.DumpOptions) error {
+	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	sqliteSchemas, err := getSchemas(ctx, txn)
+	if err != nil {
 		return err
 	}
 
@@ -83,12 +104,17 @@ func (driver *Driver) dumpOneDatabase(ctx context.Context, out io.Writer, schema
 		if s.name == "sqlite_sequence" {
 			continue
 		}
-		if _, err := io.WriteString(out, fmt.Sprintf("%s;\n", s.statement)); err != nil {
-			return err
+		if !opts.ShouldDumpTable(s.tableName) {
+			continue
+		}
+		if !opts.DataOnly {
+			if _, err := io.WriteString(out, fmt.Sprintf("%s;\n", s.statement)); err != nil {
+				return err
+			}
 		}
 
 		// Dump table data.
-		if !schemaOnly && s.schemaType == "table" {
+		if !opts.SchemaOnly && s.schemaType == "table" {
 			if err := exportTableData(txn, s.name, out); err != nil {
 				return err
 			}
@@ -31,6 +31,7 @@ var (
 
 func init() {
 	db.Register(storepb.Engine_SQLITE, newDriver)
+	db.RegisterCapability(storepb.Engine_SQLITE, db.Capability{Dump: true, DumpData: true, Restore: true})
 }
 
 // Driver is the SQLite driver.
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -52,3 +53,41 @@ func ConcatChunk(chunk []base.SingleSQL) (string, error) {
 	}
 	return chunkBuf.String(), nil
 }
+
+// PrimaryKeyChunkedDML is a single-table UPDATE or DELETE statement run in chunks of the primary key ranges,
+// so that each chunk locks a limited number of rows and commits separately.
+type PrimaryKeyChunkedDML struct {
+	// Statement is the statement without the WHERE clause, e.g. "DELETE FROM t".
+	Statement string
+	// Where is the condition of the WHERE clause, or empty if there is none.
+	Where string
+	// Table and PrimaryKey are the quoted names of the table and its single-column primary key.
+	Table      string
+	PrimaryKey string
+	// Placeholder returns the placeholder of the i-th argument starting from 1, e.g. "?" for MySQL and "$1" for PostgreSQL.
+	Placeholder func(i int) string
+}
+
+// GetFirstKeyQuery returns the query of the smallest primary key, which is the lower bound of the first chunk.
+func (c *PrimaryKeyChunkedDML) GetFirstKeyQuery() string {
+	return fmt.Sprintf("SELECT MIN(%s) FROM %s", c.PrimaryKey, c.Table)
+}
+
+// GetNextKeyQuery returns the query of the primary key n rows after the lower bound argument,
+// which is the exclusive upper bound of the chunk. The query returns no row for the last chunk.
+func (c *PrimaryKeyChunkedDML) GetNextKeyQuery(n int64) string {
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s >= %s ORDER BY %s LIMIT 1 OFFSET %d", c.PrimaryKey, c.Table, c.PrimaryKey, c.Placeholder(1), c.PrimaryKey, n)
+}
+
+// GetChunkStatement returns the statement of the chunk from the lower bound argument (inclusive) to the upper bound argument (exclusive).
+// The last chunk has no upper bound argument.
+func (c *PrimaryKeyChunkedDML) GetChunkStatement(last bool) string {
+	keyRange := fmt.Sprintf("%s >= %s", c.PrimaryKey, c.Placeholder(1))
+	if !last {
+		keyRange += fmt.Sprintf(" AND %s < %s", c.PrimaryKey, c.Placeholder(2))
+	}
+	if c.Where == "" {
+		return fmt.Sprintf("%s WHERE %s", c.Statement, keyRange)
+	}
+	return fmt.Sprintf("%s WHERE (%s) AND %s", c.Statement, c.Where, keyRange)
+}
@@ -245,3 +245,27 @@ func TestRandomChunkedSQLScript(t *testing.T) {
 	}
 	require.Equalf(t, len(script), id, "length %d with maxChunksCount %d", length, maxChunksCount)
 }
+
+func TestPrimaryKeyChunkedDML(t *testing.T) {
+	a := require.New(t)
+	mysqlChunk := &PrimaryKeyChunkedDML{
+		Statement:   "DELETE FROM `t`",
+		Where:       "a = 1 OR b = 2",
+		Table:       "`t`",
+		PrimaryKey:  "`id`",
+		Placeholder: func(int) string { return "?" },
+	}
+	a.Equal("SELECT MIN(`id`) FROM `t`", mysqlChunk.GetFirstKeyQuery())
+	a.Equal("SELECT `id` FROM `t` WHERE `id` >= ? ORDER BY `id` LIMIT 1 OFFSET 1000", mysqlChunk.GetNextKeyQuery(1000))
+	a.Equal("DELETE FROM `t` WHERE (a = 1 OR b = 2) AND `id` >= ? AND `id` < ?", mysqlChunk.GetChunkStatement(false))
+	a.Equal("DELETE FROM `t` WHERE (a = 1 OR b = 2) AND `id` >= ?", mysqlChunk.GetChunkStatement(true))
+
+	pgChunk := &PrimaryKeyChunkedDML{
+		Statement:   `UPDATE "s"."t" SET a = 1`,
+		Table:       `"s"."t"`,
+		PrimaryKey:  `"id"`,
+		Placeholder: func(i int) string { return fmt.Sprintf("$%d", i) },
+	}
+	a.Equal(`UPDATE "s"."t" SET a = 1 WHERE "id" >= $1 AND "id" < $2`, pgChunk.GetChunkStatement(false))
+	a.Equal(`SELECT "id" FROM "s"."t" WHERE "id" >= $1 ORDER BY "id" LIMIT 1 OFFSET 10`, pgChunk.GetNextKeyQuery(10))
+}
@@ -0,0 +1,92 @@
+package util
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// TableDumpFiles is the dump of the tables in the temporary files.
+type TableDumpFiles struct {
+	files map[string]*os.File
+}
+
+// DumpTablesInParallel dumps each table into a temporary file with at most parallel dumps running at the same time.
+// The caller must close the returned files after use.
+func DumpTablesInParallel(ctx context.Context, tables []string, parallel int, dump func(ctx context.Context, table string, out io.Writer) error) (*TableDumpFiles, error) {
+	result := &TableDumpFiles{files: make(map[string]*os.File)}
+	for _, table := range tables {
+		f, err := os.CreateTemp("", "bytebase-dump-*.sql")
+		if err != nil {
+			_ = result.Close()
+			return nil, errors.Wrap(err, "failed to create temporary dump file")
+		}
+		result.files[table] = f
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+	)
+	tokens := make(chan struct{}, parallel)
+	for _, table := range tables {
+		tokens <- struct{}{}
+		if ctx.Err() != nil {
+			// Stop dumping the remaining tables after the failure.
+			<-tokens
+			break
+		}
+		wg.Add(1)
+		go func(table string) {
+			defer func() {
+				<-tokens
+				wg.Done()
+			}()
+			if err := dump(ctx, table, result.files[table]); err != nil {
+				mu.Lock()
+				errs = multierr.Append(errs, errors.Wrapf(err, "failed to dump table %q", table))
+				mu.Unlock()
+				cancel()
+			}
+		}(table)
+	}
+	wg.Wait()
+	if errs == nil && ctx.Err() != nil {
+		errs = ctx.Err()
+	}
+	if errs != nil {
+		_ = result.Close()
+		return nil, errs
+	}
+	return result, nil
+}
+
+// Copy writes the dump of the table to out.
+func (f *TableDumpFiles) Copy(table string, out io.Writer) error {
+	file, ok := f.files[table]
+	if !ok {
+		return errors.Errorf("table %q is not dumped", table)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := io.Copy(out, file)
+	return err
+}
+
+// Close closes and removes the temporary files.
+func (f *TableDumpFiles) Close() error {
+	var errs error
+	for _, file := range f.files {
+		errs = multierr.Append(errs, file.Close())
+		errs = multierr.Append(errs, os.Remove(file.Name()))
+	}
+	return errs
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpTablesInParallel(t *testing.T) {
+	a := require.New(t)
+	tables := []string{"t1", "t2", "t3", "t4", "t5"}
+	dump := func(_ context.Context, table string, out io.Writer) error {
+		_, err := fmt.Fprintf(out, "INSERT INTO %s VALUES (1);\n", table)
+		return err
+	}
+
+	files, err := DumpTablesInParallel(context.Background(), tables, 2, dump)
+	a.NoError(err)
+	defer files.Close()
+	var buf bytes.Buffer
+	for i := len(tables) - 1; i >= 0; i-- {
+		a.NoError(files.Copy(tables[i], &buf))
+	}
+	a.Equal("INSERT INTO t5 VALUES (1);\nINSERT INTO t4 VALUES (1);\nINSERT INTO t3 VALUES (1);\nINSERT INTO t2 VALUES (1);\nINSERT INTO t1 VALUES (1);\n", buf.String())
+	a.Error(files.Copy("t6", &buf))
+
+	failed := func(ctx context.Context, table string, out io.Writer) error {
+		if table == "t3" {
+			return errors.New("boom")
+		}
+		return dump(ctx, table, out)
+	}
+	_, err = DumpTablesInParallel(context.Background(), tables, 2, failed)
+	a.ErrorContains(err, `failed to dump table "t3": boom`)
+}
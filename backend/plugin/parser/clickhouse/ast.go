@@ -0,0 +1,227 @@
+package clickhouse
+
+import (
+	"strings"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/tokenizer"
+)
+
+// Node is the statement parsed by ParseClickHouseSQL.
+type Node interface {
+	// Text returns the original text of the statement.
+	Text() string
+	// LastLine returns the 1-based last line of the statement.
+	LastLine() int
+}
+
+type node struct {
+	text     string
+	lastLine int
+}
+
+// Text implements the Node interface.
+func (n *node) Text() string {
+	return n.text
+}
+
+// LastLine implements the Node interface.
+func (n *node) LastLine() int {
+	return n.lastLine
+}
+
+func (n *node) setNode(text string, lastLine int) {
+	n.text = text
+	n.lastLine = lastLine
+}
+
+// TableName is the table name with the optional database.
+type TableName struct {
+	Database string
+	Name     string
+}
+
+// String returns the table name as "database.name" or "name".
+func (t TableName) String() string {
+	if t.Database == "" {
+		return t.Name
+	}
+	return t.Database + "." + t.Name
+}
+
+// ColumnDef is the column definition.
+type ColumnDef struct {
+	Name string
+	// Type is the original text of the column type, such as "LowCardinality(Nullable(String))".
+	Type string
+	// Null is true if the column is declared with the NULL modifier, such as "a Int32 NULL".
+	Null bool
+	// TTL is the column TTL expression.
+	TTL string
+	// PrimaryKey is true if the column is declared with the PRIMARY KEY modifier.
+	PrimaryKey bool
+	Line       int
+}
+
+// IsNullable returns whether the column is nullable.
+func (c *ColumnDef) IsNullable() bool {
+	return c.Null || strings.Contains(strings.ToLower(c.Type), "nullable(")
+}
+
+// CreateTableStmt is the CREATE TABLE statement.
+type CreateTableStmt struct {
+	node
+
+	Table       TableName
+	IfNotExists bool
+	ColumnList  []*ColumnDef
+	// Engine is the table engine name, such as "ReplicatedMergeTree".
+	Engine string
+	// OrderBy, PrimaryKey and TTL are the original text of the table clauses.
+	OrderBy    string
+	PrimaryKey string
+	TTL        string
+	// As is true for CREATE TABLE ... AS other_table or CREATE TABLE ... AS SELECT.
+	As bool
+}
+
+// IsMergeTree returns whether the table engine is in the MergeTree family.
+func (s *CreateTableStmt) IsMergeTree() bool {
+	return strings.HasSuffix(s.Engine, "MergeTree")
+}
+
+// SortingKey returns the expressions in the sorting key, it is the ORDER BY clause or the PRIMARY KEY clause if ORDER BY is absent.
+func (s *CreateTableStmt) SortingKey() []string {
+	key := s.OrderBy
+	if key == "" {
+		key = s.PrimaryKey
+	}
+	if key == "" {
+		var columnList []string
+		for _, column := range s.ColumnList {
+			if column.PrimaryKey {
+				columnList = append(columnList, column.Name)
+			}
+		}
+		return columnList
+	}
+	return SplitTupleExpression(key)
+}
+
+// FindColumn returns the column definition by name.
+func (s *CreateTableStmt) FindColumn(name string) *ColumnDef {
+	for _, column := range s.ColumnList {
+		if column.Name == name {
+			return column
+		}
+	}
+	return nil
+}
+
+// AlterCommandType is the type of the ALTER TABLE command.
+type AlterCommandType int
+
+const (
+	// AlterCommandOther is the command not recognized by the parser.
+	AlterCommandOther AlterCommandType = iota
+	// AlterCommandUpdate is the ALTER TABLE ... UPDATE mutation.
+	AlterCommandUpdate
+	// AlterCommandDelete is the ALTER TABLE ... DELETE mutation.
+	AlterCommandDelete
+	// AlterCommandAddColumn is the ALTER TABLE ... ADD COLUMN command.
+	AlterCommandAddColumn
+	// AlterCommandDropColumn is the ALTER TABLE ... DROP COLUMN command.
+	AlterCommandDropColumn
+	// AlterCommandModifyColumn is the ALTER TABLE ... MODIFY COLUMN command.
+	AlterCommandModifyColumn
+	// AlterCommandRenameColumn is the ALTER TABLE ... RENAME COLUMN command.
+	AlterCommandRenameColumn
+)
+
+// AlterCommand is the command in the ALTER TABLE statement.
+type AlterCommand struct {
+	Type AlterCommandType
+	// Column is the column definition for ADD COLUMN and MODIFY COLUMN.
+	Column *ColumnDef
+	// ColumnName is the column name for DROP COLUMN and RENAME COLUMN.
+	ColumnName string
+	// NewColumnName is the new column name for RENAME COLUMN.
+	NewColumnName string
+	// Where is the WHERE expression of the UPDATE and DELETE mutations.
+	Where string
+}
+
+// AlterTableStmt is the ALTER TABLE statement.
+type AlterTableStmt struct {
+	node
+
+	Table       TableName
+	CommandList []*AlterCommand
+}
+
+// DropTableStmt is the DROP TABLE statement.
+type DropTableStmt struct {
+	node
+
+	TableList []TableName
+	IfExists  bool
+}
+
+// RenameTableStmt is the RENAME TABLE statement.
+type RenameTableStmt struct {
+	node
+
+	// Pairs is the list of the old and new table names.
+	Pairs [][2]TableName
+}
+
+// SelectStmt is the SELECT statement.
+type SelectStmt struct {
+	node
+
+	// SelectAll is true if the top level select list contains "*" or "t.*".
+	SelectAll bool
+	HasFrom   bool
+	Where     string
+}
+
+// DeleteStmt is the lightweight DELETE statement.
+type DeleteStmt struct {
+	node
+
+	Table TableName
+	Where string
+}
+
+// UpdateStmt is the lightweight UPDATE statement.
+type UpdateStmt struct {
+	node
+
+	Table TableName
+	Where string
+}
+
+// UnknownStmt is the statement not recognized by the parser.
+type UnknownStmt struct {
+	node
+}
+
+// SplitTupleExpression splits the tuple expression "(a, b)" into ["a", "b"].
+// The "tuple()" expression is the empty tuple, and the other expression is the tuple with one element.
+func SplitTupleExpression(expression string) []string {
+	tokens, err := tokenizer.NewTokenizer(expression).Tokens(multiCharPunctuationList)
+	if err != nil || len(tokens) == 0 {
+		return []string{expression}
+	}
+	if len(tokens) >= 2 && strings.EqualFold(tokens[0].Text, "tuple") && tokens[1].Text == "(" {
+		tokens = tokens[1:]
+	}
+	if tokens[0].Text != "(" || findClosingParen(tokens, 0) != len(tokens)-1 {
+		return []string{expression}
+	}
+	runes := []rune(expression)
+	var result []string
+	for _, item := range splitByComma(tokens[1 : len(tokens)-1]) {
+		result = append(result, tokensText(runes, item))
+	}
+	return result
+}
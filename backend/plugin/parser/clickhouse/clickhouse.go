@@ -0,0 +1,580 @@
+// Package clickhouse provides the ClickHouse statement parser for SQL review.
+// It recognizes the statements and clauses checked by the SQL review rules, the other statements are kept as UnknownStmt.
+package clickhouse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	"github.com/bytebase/bytebase/backend/plugin/parser/tokenizer"
+)
+
+// multiCharPunctuationList is the operators of multiple characters.
+var multiCharPunctuationList = []string{"::", "->", "<=", ">=", "!=", "<>", "==", "||"}
+
+// ParseClickHouseSQL parses the statements separated by semicolons.
+func ParseClickHouseSQL(statement string) ([]Node, error) {
+	tokens, err := tokenizer.NewTokenizer(statement).Tokens(multiCharPunctuationList)
+	if err != nil {
+		return nil, err
+	}
+	// The token offsets are in runes.
+	runes := []rune(statement)
+
+	var result []Node
+	start := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && !(tokens[i].Type == tokenizer.TokenPunctuation && tokens[i].Text == ";") {
+			continue
+		}
+		if i > start {
+			// The text includes the trailing semicolon if any.
+			last := i - 1
+			if i < len(tokens) {
+				last = i
+			}
+			p := &parser{statement: runes, tokens: tokens[start:i]}
+			n, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			lastToken := tokens[last]
+			n.setNode(string(runes[tokens[start].Start:lastToken.End]), lastToken.Line+strings.Count(string(runes[lastToken.Start:lastToken.End]), "\n"))
+			result = append(result, n)
+		}
+		start = i + 1
+	}
+	return result, nil
+}
+
+type nodeSetter interface {
+	Node
+	setNode(text string, lastLine int)
+}
+
+type parser struct {
+	statement []rune
+	tokens    []tokenizer.Token
+	pos       int
+}
+
+// columnModifierList is the keywords ending the column type.
+var columnModifierList = []string{"NULL", "NOT", "DEFAULT", "MATERIALIZED", "ALIAS", "EPHEMERAL", "CODEC", "TTL", "COMMENT", "PRIMARY", "SETTINGS", "STATISTICS", "AFTER", "FIRST", "REMOVE", "MODIFY", "RESET"}
+
+// createTableClauseList is the keywords starting the CREATE TABLE clauses after the column list.
+var createTableClauseList = []string{"ENGINE", "ORDER", "PARTITION", "PRIMARY", "SAMPLE", "TTL", "SETTINGS", "COMMENT", "AS", "EMPTY"}
+
+// selectClauseList is the keywords ending the WHERE clause of the SELECT statement.
+var selectClauseList = []string{"GROUP", "HAVING", "WINDOW", "QUALIFY", "ORDER", "LIMIT", "OFFSET", "SETTINGS", "FORMAT", "UNION", "EXCEPT", "INTERSECT", "INTO"}
+
+func (p *parser) parse() (nodeSetter, error) {
+	switch {
+	case p.peekKeyword(0, "CREATE"):
+		p.pos++
+		p.acceptKeywords("OR", "REPLACE")
+		p.acceptKeywords("TEMPORARY")
+		if p.acceptKeywords("TABLE") {
+			return p.parseCreateTable()
+		}
+	case p.peekKeyword(0, "ALTER") && p.peekKeyword(1, "TABLE"):
+		p.pos += 2
+		return p.parseAlterTable()
+	case p.peekKeyword(0, "DROP"):
+		p.pos++
+		p.acceptKeywords("TEMPORARY")
+		if p.acceptKeywords("TABLE") {
+			return p.parseDropTable()
+		}
+	case p.peekKeyword(0, "RENAME") && p.peekKeyword(1, "TABLE"):
+		p.pos += 2
+		return p.parseRenameTable()
+	case p.peekKeyword(0, "SELECT"), p.peekKeyword(0, "WITH"):
+		return p.parseSelect(), nil
+	case p.peekKeyword(0, "DELETE") && p.peekKeyword(1, "FROM"):
+		p.pos += 2
+		return p.parseDelete()
+	case p.peekKeyword(0, "UPDATE"):
+		p.pos++
+		return p.parseUpdate()
+	}
+	return &UnknownStmt{}, nil
+}
+
+func (p *parser) parseCreateTable() (nodeSetter, error) {
+	stmt := &CreateTableStmt{}
+	stmt.IfNotExists = p.acceptKeywords("IF", "NOT", "EXISTS")
+	table, err := p.parseTableName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Table = table
+	if p.acceptKeywords("UUID") {
+		p.pos++
+	}
+	p.skipOnCluster()
+
+	if p.acceptKeywords("AS") {
+		stmt.As = true
+		if p.pos >= len(p.tokens) || p.peekKeyword(0, "SELECT") || p.peekKeyword(0, "WITH") || p.tokens[p.pos].Text == "(" {
+			return stmt, nil
+		}
+		// CREATE TABLE t AS other_table or table function.
+		if _, err := p.parseTableName(); err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.tokens) && p.tokens[p.pos].Text == "(" {
+			if p.pos = findClosingParen(p.tokens, p.pos) + 1; p.pos == 0 {
+				return nil, p.errorf("unclosed parenthesis")
+			}
+		}
+	} else if p.pos < len(p.tokens) && p.tokens[p.pos].Text == "(" {
+		closing := findClosingParen(p.tokens, p.pos)
+		if closing < 0 {
+			return nil, p.errorf("unclosed parenthesis")
+		}
+		for _, element := range splitByComma(p.tokens[p.pos+1 : closing]) {
+			if len(element) == 0 {
+				continue
+			}
+			switch {
+			case element[0].IsKeyword("INDEX"), element[0].IsKeyword("PROJECTION"), element[0].IsKeyword("CONSTRAINT"):
+			case element[0].IsKeyword("PRIMARY") && len(element) > 1 && element[1].IsKeyword("KEY"):
+				stmt.PrimaryKey = tokensText(p.statement, element[2:])
+			default:
+				column, err := p.parseColumnDef(element)
+				if err != nil {
+					return nil, err
+				}
+				stmt.ColumnList = append(stmt.ColumnList, column)
+			}
+		}
+		p.pos = closing + 1
+	}
+
+	for _, clause := range p.splitClauses(p.tokens[p.pos:], createTableClauseList) {
+		switch {
+		case clause[0].IsKeyword("ENGINE"):
+			body := clause[1:]
+			if len(body) > 0 && body[0].Text == "=" {
+				body = body[1:]
+			}
+			if len(body) > 0 {
+				stmt.Engine = body[0].Text
+			}
+		case clause[0].IsKeyword("ORDER") && len(clause) > 1 && clause[1].IsKeyword("BY"):
+			stmt.OrderBy = tokensText(p.statement, clause[2:])
+		case clause[0].IsKeyword("PRIMARY") && len(clause) > 1 && clause[1].IsKeyword("KEY"):
+			stmt.PrimaryKey = tokensText(p.statement, clause[2:])
+		case clause[0].IsKeyword("TTL"):
+			stmt.TTL = tokensText(p.statement, clause[1:])
+		case clause[0].IsKeyword("AS"):
+			stmt.As = true
+		}
+		if stmt.As {
+			// The rest is the query.
+			break
+		}
+	}
+	return stmt, nil
+}
+
+// parseColumnDef parses the column definition in the tokens.
+func (p *parser) parseColumnDef(tokens []tokenizer.Token) (*ColumnDef, error) {
+	if len(tokens) == 0 || !tokens[0].IsIdentifier() {
+		return nil, p.errorAt(tokens, "expect column name")
+	}
+	column := &ColumnDef{
+		Name: tokens[0].Text,
+		Line: tokens[0].Line,
+	}
+	clauses := p.splitClauses(tokens[1:], columnModifierList)
+	if len(clauses) > 0 && !isKeywordIn(clauses[0][0], columnModifierList) {
+		column.Type = tokensText(p.statement, clauses[0])
+		clauses = clauses[1:]
+	}
+	for i, clause := range clauses {
+		switch {
+		case clause[0].IsKeyword("NULL") && i == 0:
+			column.Null = true
+		case clause[0].IsKeyword("TTL"):
+			column.TTL = tokensText(p.statement, clause[1:])
+		case clause[0].IsKeyword("PRIMARY"):
+			column.PrimaryKey = true
+		}
+	}
+	return column, nil
+}
+
+func (p *parser) parseAlterTable() (nodeSetter, error) {
+	stmt := &AlterTableStmt{}
+	table, err := p.parseTableName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Table = table
+	p.skipOnCluster()
+
+	for p.pos < len(p.tokens) {
+		command := &AlterCommand{Type: AlterCommandOther}
+		switch {
+		case p.acceptKeywords("UPDATE"):
+			command.Type = AlterCommandUpdate
+			// The assignments are separated by commas, skip to the WHERE clause.
+			for p.pos < len(p.tokens) && !p.tokens[p.pos].IsKeyword("WHERE") {
+				p.skipToken()
+			}
+			command.Where = p.parseWhere()
+			p.nextCommand()
+		case p.acceptKeywords("DELETE"):
+			command.Type = AlterCommandDelete
+			for p.pos < len(p.tokens) && !p.tokens[p.pos].IsKeyword("WHERE") && p.tokens[p.pos].Text != "," {
+				p.skipToken()
+			}
+			command.Where = p.parseWhere()
+			p.nextCommand()
+		case p.acceptKeywords("ADD", "COLUMN"):
+			command.Type = AlterCommandAddColumn
+			p.acceptKeywords("IF", "NOT", "EXISTS")
+			column, err := p.parseColumnDef(p.nextCommand())
+			if err != nil {
+				return nil, err
+			}
+			command.Column = column
+		case p.acceptKeywords("MODIFY", "COLUMN"):
+			command.Type = AlterCommandModifyColumn
+			p.acceptKeywords("IF", "EXISTS")
+			column, err := p.parseColumnDef(p.nextCommand())
+			if err != nil {
+				return nil, err
+			}
+			command.Column = column
+		case p.acceptKeywords("DROP", "COLUMN"):
+			command.Type = AlterCommandDropColumn
+			p.acceptKeywords("IF", "EXISTS")
+			if tokens := p.nextCommand(); len(tokens) > 0 {
+				command.ColumnName = tokens[0].Text
+			}
+		case p.acceptKeywords("RENAME", "COLUMN"):
+			command.Type = AlterCommandRenameColumn
+			p.acceptKeywords("IF", "EXISTS")
+			tokens := p.nextCommand()
+			if len(tokens) != 3 || !tokens[1].IsKeyword("TO") {
+				return nil, p.errorAt(tokens, "expect RENAME COLUMN old_name TO new_name")
+			}
+			command.ColumnName, command.NewColumnName = tokens[0].Text, tokens[2].Text
+		default:
+			p.nextCommand()
+		}
+		stmt.CommandList = append(stmt.CommandList, command)
+	}
+	return stmt, nil
+}
+
+// nextCommand returns the tokens until the next top level comma, and skips the comma.
+func (p *parser) nextCommand() []tokenizer.Token {
+	start := p.pos
+	for p.pos < len(p.tokens) && p.tokens[p.pos].Text != "," {
+		p.skipToken()
+	}
+	tokens := p.tokens[start:p.pos]
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return tokens
+}
+
+// parseWhere parses the WHERE expression until the next top level comma.
+func (p *parser) parseWhere() string {
+	if !p.acceptKeywords("WHERE") {
+		return ""
+	}
+	start := p.pos
+	for p.pos < len(p.tokens) && p.tokens[p.pos].Text != "," {
+		p.skipToken()
+	}
+	return tokensText(p.statement, p.tokens[start:p.pos])
+}
+
+func (p *parser) parseDropTable() (nodeSetter, error) {
+	stmt := &DropTableStmt{}
+	stmt.IfExists = p.acceptKeywords("IF", "EXISTS")
+	for {
+		table, err := p.parseTableName()
+		if err != nil {
+			return nil, err
+		}
+		stmt.TableList = append(stmt.TableList, table)
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].Text != "," {
+			break
+		}
+		p.pos++
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseRenameTable() (nodeSetter, error) {
+	stmt := &RenameTableStmt{}
+	for {
+		from, err := p.parseTableName()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptKeywords("TO") {
+			return nil, p.errorf("expect TO")
+		}
+		to, err := p.parseTableName()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Pairs = append(stmt.Pairs, [2]TableName{from, to})
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].Text != "," {
+			break
+		}
+		p.pos++
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseSelect() nodeSetter {
+	stmt := &SelectStmt{}
+	// Skip the WITH clause, the common table expressions are in parentheses.
+	for p.pos < len(p.tokens) && !p.tokens[p.pos].IsKeyword("SELECT") {
+		p.skipToken()
+	}
+	if !p.acceptKeywords("SELECT") {
+		return stmt
+	}
+	p.acceptKeywords("DISTINCT")
+	p.acceptKeywords("ALL")
+
+	clauses := p.splitClauses(p.tokens[p.pos:], append([]string{"FROM", "WHERE", "PREWHERE"}, selectClauseList...))
+	if len(clauses) > 0 && !isKeywordIn(clauses[0][0], []string{"FROM", "WHERE", "PREWHERE"}) {
+		for _, item := range splitByComma(clauses[0]) {
+			if len(item) == 0 {
+				continue
+			}
+			// "*", "* EXCEPT (a)" and "t.*".
+			if item[0].Text == "*" || (len(item) >= 3 && item[1].Text == "." && item[2].Text == "*") {
+				stmt.SelectAll = true
+			}
+		}
+		clauses = clauses[1:]
+	}
+	for _, clause := range clauses {
+		switch {
+		case clause[0].IsKeyword("FROM"):
+			stmt.HasFrom = true
+		case clause[0].IsKeyword("WHERE"):
+			stmt.Where = tokensText(p.statement, clause[1:])
+		case clause[0].IsKeyword("UNION"), clause[0].IsKeyword("EXCEPT"), clause[0].IsKeyword("INTERSECT"):
+			// Only the first SELECT is checked.
+			return stmt
+		}
+	}
+	return stmt
+}
+
+func (p *parser) parseDelete() (nodeSetter, error) {
+	stmt := &DeleteStmt{}
+	table, err := p.parseTableName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Table = table
+	for p.pos < len(p.tokens) && !p.tokens[p.pos].IsKeyword("WHERE") {
+		p.skipToken()
+	}
+	if p.acceptKeywords("WHERE") {
+		stmt.Where = tokensText(p.statement, p.tokens[p.pos:])
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseUpdate() (nodeSetter, error) {
+	stmt := &UpdateStmt{}
+	table, err := p.parseTableName()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Table = table
+	for p.pos < len(p.tokens) && !p.tokens[p.pos].IsKeyword("WHERE") {
+		p.skipToken()
+	}
+	if p.acceptKeywords("WHERE") {
+		stmt.Where = tokensText(p.statement, p.tokens[p.pos:])
+	}
+	return stmt, nil
+}
+
+// splitClauses splits the tokens by the top level keywords in the list, each clause starts with the keyword except the first one.
+func (p *parser) splitClauses(tokens []tokenizer.Token, keywordList []string) [][]tokenizer.Token {
+	var result [][]tokenizer.Token
+	depth, start := 0, 0
+	for i, t := range tokens {
+		if t.Type == tokenizer.TokenPunctuation {
+			switch t.Text {
+			case "(", "[":
+				depth++
+			case ")", "]":
+				depth--
+			}
+			continue
+		}
+		if depth == 0 && i > start && isKeywordIn(t, keywordList) {
+			result = append(result, tokens[start:i])
+			start = i
+		}
+	}
+	if start < len(tokens) {
+		result = append(result, tokens[start:])
+	}
+	return result
+}
+
+func (p *parser) parseTableName() (TableName, error) {
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return TableName{}, err
+	}
+	if p.pos < len(p.tokens) && p.tokens[p.pos].Text == "." {
+		p.pos++
+		table, err := p.parseIdentifier()
+		if err != nil {
+			return TableName{}, err
+		}
+		return TableName{Database: name, Name: table}, nil
+	}
+	return TableName{Name: name}, nil
+}
+
+func (p *parser) parseIdentifier() (string, error) {
+	if p.pos >= len(p.tokens) || !p.tokens[p.pos].IsIdentifier() {
+		return "", p.errorf("expect identifier")
+	}
+	p.pos++
+	return p.tokens[p.pos-1].Text, nil
+}
+
+func (p *parser) skipOnCluster() {
+	if p.acceptKeywords("ON", "CLUSTER") {
+		p.pos++
+	}
+}
+
+// skipToken skips the token, or the parenthesized tokens as a whole.
+func (p *parser) skipToken() {
+	if t := p.tokens[p.pos]; t.Type == tokenizer.TokenPunctuation && (t.Text == "(" || t.Text == "[") {
+		if closing := findClosingParen(p.tokens, p.pos); closing > 0 {
+			p.pos = closing + 1
+			return
+		}
+	}
+	p.pos++
+}
+
+func (p *parser) peekKeyword(offset int, keyword string) bool {
+	return p.pos+offset < len(p.tokens) && p.tokens[p.pos+offset].IsKeyword(keyword)
+}
+
+// acceptKeywords consumes the keyword sequence if all of them match.
+func (p *parser) acceptKeywords(keywordList ...string) bool {
+	for i, keyword := range keywordList {
+		if !p.peekKeyword(i, keyword) {
+			return false
+		}
+	}
+	p.pos += len(keywordList)
+	return true
+}
+
+func (p *parser) errorf(message string) error {
+	if p.pos < len(p.tokens) {
+		return p.errorAt(p.tokens[p.pos:], message)
+	}
+	return p.errorAt(nil, message)
+}
+
+// errorAt returns the syntax error at the first token, or at the end of the statement if the tokens are empty.
+func (p *parser) errorAt(tokens []tokenizer.Token, message string) error {
+	t := p.tokens[len(p.tokens)-1]
+	near := "end of statement"
+	pos := t.End
+	if len(tokens) > 0 {
+		t = tokens[0]
+		near = fmt.Sprintf("%q", t.Text)
+		pos = t.Start
+	}
+	lineStart := pos
+	for lineStart > 0 && p.statement[lineStart-1] != '\n' {
+		lineStart--
+	}
+	return &base.SyntaxError{
+		Line:    t.Line,
+		Column:  pos - lineStart,
+		Message: fmt.Sprintf("Syntax error at line %d near %s: %s", t.Line, near, message),
+	}
+}
+
+func isKeywordIn(t tokenizer.Token, keywordList []string) bool {
+	for _, keyword := range keywordList {
+		if t.IsKeyword(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// findClosingParen returns the index of the parenthesis closing the one at index start, or -1 if it is not closed.
+func findClosingParen(tokens []tokenizer.Token, start int) int {
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		if tokens[i].Type != tokenizer.TokenPunctuation {
+			continue
+		}
+		switch tokens[i].Text {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitByComma splits the tokens by the top level commas.
+func splitByComma(tokens []tokenizer.Token) [][]tokenizer.Token {
+	var result [][]tokenizer.Token
+	depth, start := 0, 0
+	for i, t := range tokens {
+		if t.Type != tokenizer.TokenPunctuation {
+			continue
+		}
+		switch t.Text {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+		case ",":
+			if depth == 0 {
+				result = append(result, tokens[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(tokens) {
+		result = append(result, tokens[start:])
+	}
+	return result
+}
+
+// tokensText returns the original text covered by the tokens.
+func tokensText(statement []rune, tokens []tokenizer.Token) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	return string(statement[tokens[0].Start:tokens[len(tokens)-1].End])
+}
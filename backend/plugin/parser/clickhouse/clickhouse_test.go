@@ -0,0 +1,138 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestParseClickHouseSQL(t *testing.T) {
+	a := require.New(t)
+
+	statement := `-- Create the events table.
+CREATE TABLE IF NOT EXISTS analytics.events ON CLUSTER '{cluster}' (
+  id UInt64,
+  name LowCardinality(Nullable(String)),
+  ` + "`created_at`" + ` DateTime DEFAULT now() TTL created_at + INTERVAL 1 DAY,
+  score Int32 NULL COMMENT 'the score, maybe null',
+  INDEX idx_name name TYPE bloom_filter GRANULARITY 4
+)
+ENGINE = ReplicatedMergeTree('/clickhouse/{shard}/events', '{replica}')
+PARTITION BY toYYYYMM(created_at)
+ORDER BY (id, name)
+TTL created_at + INTERVAL 30 DAY
+SETTINGS index_granularity = 8192;
+ALTER TABLE events UPDATE name = 'a', score = 1 WHERE id IN (1, 2), DELETE WHERE score < 0, ADD COLUMN IF NOT EXISTS note String AFTER name, DROP COLUMN score;
+SELECT *, e.* FROM events AS e WHERE id = 1 ORDER BY id;
+WITH t AS (SELECT id FROM events WHERE id > 1) SELECT id FROM t;
+DROP TABLE IF EXISTS events_old, events_tmp SYNC;
+RENAME TABLE a TO b, db.c TO db.d;
+DELETE FROM events WHERE id = 1;
+SHOW TABLES`
+
+	list, err := ParseClickHouseSQL(statement)
+	a.NoError(err)
+	a.Len(list, 8)
+
+	create, ok := list[0].(*CreateTableStmt)
+	a.True(ok)
+	a.Equal(TableName{Database: "analytics", Name: "events"}, create.Table)
+	a.True(create.IfNotExists)
+	a.Equal("ReplicatedMergeTree", create.Engine)
+	a.True(create.IsMergeTree())
+	a.Equal("(id, name)", create.OrderBy)
+	a.Equal([]string{"id", "name"}, create.SortingKey())
+	a.Equal("created_at + INTERVAL 30 DAY", create.TTL)
+	a.Equal(13, create.LastLine())
+	a.Len(create.ColumnList, 4)
+	a.Equal(&ColumnDef{Name: "id", Type: "UInt64", Line: 3}, create.ColumnList[0])
+	a.True(create.ColumnList[1].IsNullable())
+	a.Equal(&ColumnDef{Name: "created_at", Type: "DateTime", TTL: "created_at + INTERVAL 1 DAY", Line: 5}, create.ColumnList[2])
+	a.True(create.ColumnList[3].IsNullable())
+
+	alter, ok := list[1].(*AlterTableStmt)
+	a.True(ok)
+	a.Equal(14, alter.LastLine())
+	a.Len(alter.CommandList, 4)
+	a.Equal(&AlterCommand{Type: AlterCommandUpdate, Where: "id IN (1, 2)"}, alter.CommandList[0])
+	a.Equal(&AlterCommand{Type: AlterCommandDelete, Where: "score < 0"}, alter.CommandList[1])
+	a.Equal(AlterCommandAddColumn, alter.CommandList[2].Type)
+	a.Equal(&ColumnDef{Name: "note", Type: "String", Line: 14}, alter.CommandList[2].Column)
+	a.Equal(&AlterCommand{Type: AlterCommandDropColumn, ColumnName: "score"}, alter.CommandList[3])
+
+	selectStmt, ok := list[2].(*SelectStmt)
+	a.True(ok)
+	a.True(selectStmt.SelectAll)
+	a.True(selectStmt.HasFrom)
+	a.Equal("id = 1", selectStmt.Where)
+
+	selectStmt, ok = list[3].(*SelectStmt)
+	a.True(ok)
+	a.False(selectStmt.SelectAll)
+	a.True(selectStmt.HasFrom)
+	a.Equal("", selectStmt.Where)
+
+	drop, ok := list[4].(*DropTableStmt)
+	a.True(ok)
+	a.True(drop.IfExists)
+	a.Equal([]TableName{{Name: "events_old"}, {Name: "events_tmp"}}, drop.TableList)
+
+	rename, ok := list[5].(*RenameTableStmt)
+	a.True(ok)
+	a.Equal([][2]TableName{{{Name: "a"}, {Name: "b"}}, {{Database: "db", Name: "c"}, {Database: "db", Name: "d"}}}, rename.Pairs)
+
+	deleteStmt, ok := list[6].(*DeleteStmt)
+	a.True(ok)
+	a.Equal("id = 1", deleteStmt.Where)
+
+	_, ok = list[7].(*UnknownStmt)
+	a.True(ok)
+	a.Equal("SHOW TABLES", list[7].Text())
+	a.Equal(20, list[7].LastLine())
+}
+
+func TestParseClickHouseSQLSyntaxError(t *testing.T) {
+	a := require.New(t)
+
+	_, err := ParseClickHouseSQL("SELECT 1;\nSELECT 'a")
+	a.Equal(&base.SyntaxError{Line: 2, Column: 7, Message: "unterminated quoted string"}, err)
+
+	_, err = ParseClickHouseSQL("CREATE TABLE t (1 Int32) ENGINE = Memory")
+	a.Equal(&base.SyntaxError{Line: 1, Column: 16, Message: `Syntax error at line 1 near "1": expect column name`}, err)
+}
+
+func TestSplitTupleExpression(t *testing.T) {
+	a := require.New(t)
+
+	a.Equal([]string{"a", "toDate(b)"}, SplitTupleExpression("(a, toDate(b))"))
+	a.Equal([]string{"a"}, SplitTupleExpression("a"))
+	a.Equal([]string(nil), SplitTupleExpression("tuple()"))
+	a.Equal([]string{"(a, b) + c"}, SplitTupleExpression("(a, b) + c"))
+}
+
+func TestParseClickHouseSQLQuotedText(t *testing.T) {
+	a := require.New(t)
+
+	statement := "CREATE TABLE `tab``le` (\"列\" String COMMENT 'it''s \\'quoted\\'', b String) ENGINE = Memory;\nSELECT '多字节' FROM t WHERE a = 'x'"
+	list, err := ParseClickHouseSQL(statement)
+	a.NoError(err)
+	a.Len(list, 2)
+
+	create, ok := list[0].(*CreateTableStmt)
+	a.True(ok)
+	a.Equal(TableName{Name: "tab`le"}, create.Table)
+	a.Len(create.ColumnList, 2)
+	a.Equal("列", create.ColumnList[0].Name)
+	a.Equal("String", create.ColumnList[0].Type)
+
+	selectStmt, ok := list[1].(*SelectStmt)
+	a.True(ok)
+	a.Equal("a = 'x'", selectStmt.Where)
+	a.Equal("SELECT '多字节' FROM t WHERE a = 'x'", selectStmt.Text())
+	a.Equal(2, selectStmt.LastLine())
+
+	_, err = ParseClickHouseSQL("SELECT '多字节';\nCREATE TABLE t (列 Int32, 1 Int32) ENGINE = Memory")
+	a.Equal(&base.SyntaxError{Line: 2, Column: 25, Message: `Syntax error at line 2 near "1": expect column name`}, err)
+}
@@ -0,0 +1,100 @@
+// Package spanner is the parser for Cloud Spanner statements.
+package spanner
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/spanner/spansql"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	"github.com/bytebase/bytebase/backend/plugin/parser/tokenizer"
+)
+
+var (
+	leadingKeywordRegexp = regexp.MustCompile(`(?s)^\s*(?:(?:--[^\n]*|#[^\n]*|/\*.*?\*/)\s*)*(\w+)`)
+	// parseErrorRegexp matches the spansql parse error, it is "-:LINE: message" or "-:1.OFFSET: message" for the first line.
+	parseErrorRegexp = regexp.MustCompile(`(?s)^-:(\d+)(?:\.(\d+))?: (.*)$`)
+)
+
+// Statement is the Spanner statement parsed by ParseSpannerSQL.
+type Statement struct {
+	// Node is the spansql.DDLStmt, spansql.DMLStmt or *spansql.Query.
+	Node any
+	// Text is the original text of the statement.
+	Text string
+	// BaseLine is the 1-based line of the first line of Text.
+	BaseLine int
+	// LastLine is the 1-based last line of the statement.
+	LastLine int
+}
+
+// ParseSpannerSQL splits the statement and parses each one by spansql.
+// The returned error is *base.SyntaxError if the statement is invalid.
+func ParseSpannerSQL(statement string) ([]*Statement, error) {
+	list, err := tokenizer.NewTokenizer(statement).SplitStandardMultiSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Statement
+	for _, sql := range list {
+		if sql.Empty {
+			continue
+		}
+		stmt := &Statement{
+			Text:     sql.Text,
+			BaseLine: sql.LastLine - strings.Count(strings.TrimRight(sql.Text, " \t\r\n"), "\n"),
+			LastLine: sql.LastLine,
+		}
+		node, err := parseStatement(strings.TrimRight(sql.Text, " \t\r\n;"))
+		if err != nil {
+			return nil, convertParseError(err, stmt)
+		}
+		stmt.Node = node
+		result = append(result, stmt)
+	}
+	return result, nil
+}
+
+func parseStatement(text string) (any, error) {
+	keyword := ""
+	if matches := leadingKeywordRegexp.FindStringSubmatch(text); matches != nil {
+		keyword = strings.ToUpper(matches[1])
+	}
+	switch keyword {
+	case "SELECT", "WITH":
+		query, err := spansql.ParseQuery(text)
+		if err != nil {
+			return nil, err
+		}
+		return &query, nil
+	case "INSERT", "UPDATE", "DELETE":
+		return spansql.ParseDMLStmt(text)
+	default:
+		return spansql.ParseDDLStmt(text)
+	}
+}
+
+func convertParseError(err error, stmt *Statement) error {
+	matches := parseErrorRegexp.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return &base.SyntaxError{
+			Line:    stmt.LastLine,
+			Message: fmt.Sprintf("Syntax error at line %d: %s", stmt.LastLine, err.Error()),
+		}
+	}
+	line, _ := strconv.Atoi(matches[1])
+	line += stmt.BaseLine - 1
+	column := 0
+	if matches[2] != "" {
+		column, _ = strconv.Atoi(matches[2])
+	}
+	return &base.SyntaxError{
+		Line:    line,
+		Column:  column,
+		Message: fmt.Sprintf("Syntax error at line %d: %s", line, matches[3]),
+	}
+}
@@ -0,0 +1,63 @@
+package spanner
+
+import (
+	"testing"
+
+	"cloud.google.com/go/spanner/spansql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestParseSpannerSQL(t *testing.T) {
+	a := require.New(t)
+
+	statement := `-- Create the singers table.
+CREATE TABLE Singers (
+  SingerId INT64 NOT NULL,
+  Name STRING(MAX),
+) PRIMARY KEY (SingerId);
+
+CREATE TABLE Albums (
+  SingerId INT64 NOT NULL,
+  AlbumId INT64 NOT NULL,
+) PRIMARY KEY (SingerId, AlbumId),
+  INTERLEAVE IN PARENT Singers ON DELETE CASCADE;
+SELECT * FROM Singers WHERE SingerId = 1;
+DELETE FROM Albums WHERE true`
+
+	list, err := ParseSpannerSQL(statement)
+	a.NoError(err)
+	a.Len(list, 4)
+
+	create, ok := list[0].Node.(*spansql.CreateTable)
+	a.True(ok)
+	a.Equal(spansql.ID("Singers"), create.Name)
+	a.Equal(1, list[0].BaseLine)
+	a.Equal(5, list[0].LastLine)
+
+	create, ok = list[1].Node.(*spansql.CreateTable)
+	a.True(ok)
+	a.Equal(spansql.ID("Singers"), create.Interleave.Parent)
+	a.Equal(7, list[1].BaseLine)
+	a.Equal(11, list[1].LastLine)
+
+	query, ok := list[2].Node.(*spansql.Query)
+	a.True(ok)
+	a.Equal([]spansql.Expr{spansql.Star}, query.Select.List)
+	a.Equal(12, list[2].LastLine)
+
+	deleteStmt, ok := list[3].Node.(*spansql.Delete)
+	a.True(ok)
+	a.Equal(spansql.True, deleteStmt.Where)
+	a.Equal(13, list[3].LastLine)
+}
+
+func TestParseSpannerSQLSyntaxError(t *testing.T) {
+	a := require.New(t)
+
+	_, err := ParseSpannerSQL("SELECT 1;\nCREATE TABLE t (\n  a INT64,\n  b UNKNOWN,\n) PRIMARY KEY (a);")
+	syntaxError, ok := err.(*base.SyntaxError)
+	a.True(ok)
+	a.Equal(4, syntaxError.Line)
+}
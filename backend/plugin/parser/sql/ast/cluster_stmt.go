@@ -0,0 +1,10 @@
+package ast
+
+// ClusterStmt is the struct for cluster statement.
+type ClusterStmt struct {
+	node
+
+	// Table is nil if the statement re-clusters all previously clustered tables.
+	Table     *TableDef
+	IndexName string
+}
@@ -0,0 +1,33 @@
+package ast
+
+// ReindexType is the type of the object to reindex.
+type ReindexType int
+
+const (
+	// ReindexTypeUndefined is the undefined type.
+	ReindexTypeUndefined ReindexType = iota
+	// ReindexTypeIndex is the REINDEX INDEX statement.
+	ReindexTypeIndex
+	// ReindexTypeTable is the REINDEX TABLE statement.
+	ReindexTypeTable
+	// ReindexTypeSchema is the REINDEX SCHEMA statement.
+	ReindexTypeSchema
+	// ReindexTypeSystem is the REINDEX SYSTEM statement.
+	ReindexTypeSystem
+	// ReindexTypeDatabase is the REINDEX DATABASE statement.
+	ReindexTypeDatabase
+)
+
+// ReindexStmt is the struct for reindex statement.
+type ReindexStmt struct {
+	node
+
+	Type ReindexType
+	// Table is the table for REINDEX TABLE.
+	Table *TableDef
+	// Index is the index for REINDEX INDEX.
+	Index *IndexDef
+	// Name is the schema or database name for REINDEX SCHEMA, SYSTEM and DATABASE.
+	Name         string
+	Concurrently bool
+}
@@ -40,6 +40,10 @@ func Walk(v Visitor, node Node) {
 		if n.Column != nil {
 			Walk(v, n.Column)
 		}
+	case *ClusterStmt:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
 	case *ColumnDef:
 		if n.Type != nil {
 			Walk(v, n.Type)
@@ -140,6 +144,13 @@ func Walk(v Visitor, node Node) {
 		if n.Pattern != nil {
 			Walk(v, n.Pattern)
 		}
+	case *ReindexStmt:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+		if n.Index != nil {
+			Walk(v, n.Index)
+		}
 	case *RenameColumnStmt:
 		if n.Table != nil {
 			Walk(v, n.Table)
@@ -208,5 +219,15 @@ func Walk(v Visitor, node Node) {
 		for _, subquery := range n.SubqueryList {
 			Walk(v, subquery)
 		}
+	case *VacuumStmt:
+		for _, tableDef := range n.TableList {
+			Walk(v, tableDef)
+		}
+	case *ValidateConstraintStmt:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+	case *VariableSetStmt:
+		// No members to walk through.
 	}
 }
@@ -0,0 +1,11 @@
+package ast
+
+// VacuumStmt is the struct for vacuum statement.
+type VacuumStmt struct {
+	node
+
+	// Full is true for VACUUM FULL, which rewrites the table with an ACCESS EXCLUSIVE lock.
+	Full bool
+	// TableList is empty if the statement vacuums all tables in the current database.
+	TableList []*TableDef
+}
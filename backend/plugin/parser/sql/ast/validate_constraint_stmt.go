@@ -0,0 +1,10 @@
+package ast
+
+// ValidateConstraintStmt is the struct for validate constraint statement.
+// For PostgreSQL dialect is the ALTER TABLE VALIDATE CONSTRAINT.
+type ValidateConstraintStmt struct {
+	node
+
+	Table          *TableDef
+	ConstraintName string
+}
@@ -0,0 +1,11 @@
+package ast
+
+// VariableSetStmt is the struct for SET and RESET statement.
+type VariableSetStmt struct {
+	node
+
+	Name string
+	// Value is empty for RESET and SET ... TO DEFAULT.
+	Value   string
+	IsLocal bool
+}
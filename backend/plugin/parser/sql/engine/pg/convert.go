@@ -158,6 +158,13 @@ func convert(node *pgquery.Node, statement base.SingleSQL) (res ast.Node, err er
 
 						alterTable.AlterItemList = append(alterTable.AlterItemList, setDefault)
 					}
+				case pgquery.AlterTableType_AT_ValidateConstraint:
+					validateConstraint := &ast.ValidateConstraintStmt{
+						Table:          alterTable.Table,
+						ConstraintName: alterCmd.Name,
+					}
+
+					alterTable.AlterItemList = append(alterTable.AlterItemList, validateConstraint)
 				case pgquery.AlterTableType_AT_AttachPartition:
 					attachPartition := &ast.AttachPartitionStmt{
 						Table: alterTable.Table,
@@ -820,6 +827,77 @@ func convert(node *pgquery.Node, statement base.SingleSQL) (res ast.Node, err er
 		if in.TransactionStmt.Kind == pgquery.TransactionStmtKind_TRANS_STMT_COMMIT {
 			return &ast.CommitStmt{}, nil
 		}
+	case *pgquery.Node_VacuumStmt:
+		if !in.VacuumStmt.IsVacuumcmd {
+			// ANALYZE shares the VacuumStmt node with VACUUM.
+			return &ast.UnconvertedStmt{}, nil
+		}
+		vacuum := &ast.VacuumStmt{}
+		for _, option := range in.VacuumStmt.Options {
+			if defElem, ok := option.Node.(*pgquery.Node_DefElem); ok && defElem.DefElem.Defname == "full" {
+				vacuum.Full = isDefElemEnabled(defElem.DefElem)
+			}
+		}
+		for _, rel := range in.VacuumStmt.Rels {
+			if relation, ok := rel.Node.(*pgquery.Node_VacuumRelation); ok && relation.VacuumRelation.Relation != nil {
+				vacuum.TableList = append(vacuum.TableList, convertRangeVarToTableName(relation.VacuumRelation.Relation, ast.TableTypeBaseTable))
+			}
+		}
+		return vacuum, nil
+	case *pgquery.Node_ClusterStmt:
+		cluster := &ast.ClusterStmt{
+			IndexName: in.ClusterStmt.Indexname,
+		}
+		if in.ClusterStmt.Relation != nil {
+			cluster.Table = convertRangeVarToTableName(in.ClusterStmt.Relation, ast.TableTypeBaseTable)
+		}
+		return cluster, nil
+	case *pgquery.Node_ReindexStmt:
+		reindex := &ast.ReindexStmt{
+			Name: in.ReindexStmt.Name,
+		}
+		switch in.ReindexStmt.Kind {
+		case pgquery.ReindexObjectType_REINDEX_OBJECT_INDEX:
+			reindex.Type = ast.ReindexTypeIndex
+			if in.ReindexStmt.Relation != nil {
+				reindex.Index = &ast.IndexDef{
+					Table: convertRangeVarToIndexTableName(in.ReindexStmt.Relation, ast.TableTypeUnknown),
+					Name:  in.ReindexStmt.Relation.Relname,
+				}
+			}
+		case pgquery.ReindexObjectType_REINDEX_OBJECT_TABLE:
+			reindex.Type = ast.ReindexTypeTable
+			if in.ReindexStmt.Relation != nil {
+				reindex.Table = convertRangeVarToTableName(in.ReindexStmt.Relation, ast.TableTypeBaseTable)
+			}
+		case pgquery.ReindexObjectType_REINDEX_OBJECT_SCHEMA:
+			reindex.Type = ast.ReindexTypeSchema
+		case pgquery.ReindexObjectType_REINDEX_OBJECT_SYSTEM:
+			reindex.Type = ast.ReindexTypeSystem
+		case pgquery.ReindexObjectType_REINDEX_OBJECT_DATABASE:
+			reindex.Type = ast.ReindexTypeDatabase
+		}
+		for _, param := range in.ReindexStmt.Params {
+			if defElem, ok := param.Node.(*pgquery.Node_DefElem); ok && defElem.DefElem.Defname == "concurrently" {
+				reindex.Concurrently = isDefElemEnabled(defElem.DefElem)
+			}
+		}
+		return reindex, nil
+	case *pgquery.Node_VariableSetStmt:
+		switch in.VariableSetStmt.Kind {
+		case pgquery.VariableSetKind_VAR_SET_VALUE, pgquery.VariableSetKind_VAR_SET_DEFAULT, pgquery.VariableSetKind_VAR_RESET:
+			value, err := convertVariableSetValue(in.VariableSetStmt.Args)
+			if err != nil {
+				return nil, err
+			}
+			return &ast.VariableSetStmt{
+				Name:    in.VariableSetStmt.Name,
+				Value:   value,
+				IsLocal: in.VariableSetStmt.IsLocal,
+			}, nil
+		default:
+			return &ast.UnconvertedStmt{}, nil
+		}
 	default:
 		return &ast.UnconvertedStmt{}, nil
 	}
@@ -1422,6 +1500,46 @@ func convertListToStringList(in *pgquery.Node_List) ([]string, error) {
 	return res, nil
 }
 
+// isDefElemEnabled returns whether the boolean option such as FULL in VACUUM (FULL) is enabled.
+// The option without argument is enabled.
+func isDefElemEnabled(in *pgquery.DefElem) bool {
+	if in.Arg == nil {
+		return true
+	}
+	switch arg := in.Arg.Node.(type) {
+	case *pgquery.Node_Boolean:
+		return arg.Boolean.Boolval
+	case *pgquery.Node_Integer:
+		return arg.Integer.Ival != 0
+	case *pgquery.Node_String_:
+		value := strings.ToLower(arg.String_.Sval)
+		return value != "false" && value != "off" && value != "0"
+	}
+	return true
+}
+
+func convertVariableSetValue(args []*pgquery.Node) (string, error) {
+	var values []string
+	for _, arg := range args {
+		if constant, ok := arg.Node.(*pgquery.Node_AConst); ok {
+			switch val := constant.AConst.Val.(type) {
+			case *pgquery.A_Const_Sval:
+				values = append(values, val.Sval.Sval)
+				continue
+			case *pgquery.A_Const_Ival:
+				values = append(values, strconv.Itoa(int(val.Ival.Ival)))
+				continue
+			}
+		}
+		text, err := pgquery.DeparseNode(pgquery.DeparseTypeExpr, arg)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, text)
+	}
+	return strings.Join(values, ", "), nil
+}
+
 func convertRangeVarToTableName(in *pgquery.RangeVar, tableType ast.TableType) *ast.TableDef {
 	return &ast.TableDef{
 		Type:     tableType,
@@ -3216,6 +3216,176 @@ func TestRenameSchema(t *testing.T) {
 	runTests(t, tests)
 }
 
+func TestMaintenanceStmt(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: `VACUUM (FULL, ANALYZE) public.t, t2`,
+			want: []ast.Node{
+				&ast.VacuumStmt{
+					Full: true,
+					TableList: []*ast.TableDef{
+						{Type: ast.TableTypeBaseTable, Schema: "public", Name: "t"},
+						{Type: ast.TableTypeBaseTable, Name: "t2"},
+					},
+				},
+			},
+			statementList: []base.SingleSQL{
+				{
+					Text:     `VACUUM (FULL, ANALYZE) public.t, t2`,
+					LastLine: 1,
+				},
+			},
+		},
+		{
+			stmt: `VACUUM (FULL false) t`,
+			want: []ast.Node{
+				&ast.VacuumStmt{
+					TableList: []*ast.TableDef{
+						{Type: ast.TableTypeBaseTable, Name: "t"},
+					},
+				},
+			},
+			statementList: []base.SingleSQL{
+				{
+					Text:     `VACUUM (FULL false) t`,
+					LastLine: 1,
+				},
+			},
+		},
+		{
+			stmt: `CLUSTER t USING idx`,
+			want: []ast.Node{
+				&ast.ClusterStmt{
+					Table:     &ast.TableDef{Type: ast.TableTypeBaseTable, Name: "t"},
+					IndexName: "idx",
+				},
+			},
+			statementList: []base.SingleSQL{
+				{
+					Text:     `CLUSTER t USING idx`,
+					LastLine: 1,
+				},
+			},
+		},
+		{
+			stmt: `REINDEX INDEX CONCURRENTLY public.idx`,
+			want: []ast.Node{
+				&ast.ReindexStmt{
+					Type: ast.ReindexTypeIndex,
+					Index: &ast.IndexDef{
+						Table: &ast.TableDef{Schema: "public"},
+						Name:  "idx",
+					},
+					Concurrently: true,
+				},
+			},
+			statementList: []base.SingleSQL{
+				{
+					Text:     `REINDEX INDEX CONCURRENTLY public.idx`,
+					LastLine: 1,
+				},
+			},
+		},
+		{
+			stmt: `REINDEX TABLE t`,
+			want: []ast.Node{
+				&ast.ReindexStmt{
+					Type:  ast.ReindexTypeTable,
+					Table: &ast.TableDef{Type: ast.TableTypeBaseTable, Name: "t"},
+				},
+			},
+			statementList: []base.SingleSQL{
+				{
+					Text:     `REINDEX TABLE t`,
+					LastLine: 1,
+				},
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func TestVariableSet(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: `SET lock_timeout = '3s'`,
+			want: []ast.Node{
+				&ast.VariableSetStmt{
+					Name:  "lock_timeout",
+					Value: "3s",
+				},
+			},
+			statementList: []base.SingleSQL{
+				{
+					Text:     `SET lock_timeout = '3s'`,
+					LastLine: 1,
+				},
+			},
+		},
+		{
+			stmt: `SET LOCAL statement_timeout TO 5000`,
+			want: []ast.Node{
+				&ast.VariableSetStmt{
+					Name:    "statement_timeout",
+					Value:   "5000",
+					IsLocal: true,
+				},
+			},
+			statementList: []base.SingleSQL{
+				{
+					Text:     `SET LOCAL statement_timeout TO 5000`,
+					LastLine: 1,
+				},
+			},
+		},
+		{
+			stmt: `RESET lock_timeout`,
+			want: []ast.Node{
+				&ast.VariableSetStmt{
+					Name: "lock_timeout",
+				},
+			},
+			statementList: []base.SingleSQL{
+				{
+					Text:     `RESET lock_timeout`,
+					LastLine: 1,
+				},
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func TestValidateConstraint(t *testing.T) {
+	table := &ast.TableDef{Type: ast.TableTypeBaseTable, Name: "t"}
+	tests := []testData{
+		{
+			stmt: `ALTER TABLE t VALIDATE CONSTRAINT t_check`,
+			want: []ast.Node{
+				&ast.AlterTableStmt{
+					Table: table,
+					AlterItemList: []ast.Node{
+						&ast.ValidateConstraintStmt{
+							Table:          table,
+							ConstraintName: "t_check",
+						},
+					},
+				},
+			},
+			statementList: []base.SingleSQL{
+				{
+					Text:     `ALTER TABLE t VALIDATE CONSTRAINT t_check`,
+					LastLine: 1,
+				},
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
 type setLineTestData struct {
 	statement          string
 	columnLineList     []int
@@ -0,0 +1,169 @@
+package schematree
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	"github.com/bytebase/bytebase/backend/plugin/parser/plsql"
+)
+
+var (
+	// createRegexp matches the head of the CREATE statement up to the object name, e.g.
+	//
+	//	CREATE OR REPLACE FORCE EDITIONABLE VIEW
+	//	create TABLE IF NOT EXISTS
+	createRegexp = regexp.MustCompile(`(?is)^CREATE\s+(?:OR\s+REPLACE\s+)?(?:(?:EDITIONABLE|NONEDITIONABLE|FORCE|SECURE|RECURSIVE|MATERIALIZED|TEMPORARY|TEMP|TRANSIENT|VOLATILE|GLOBAL|LOCAL|UNIQUE|BITMAP|NULL_FILTERED)\s+)*(TABLE|VIEW|FUNCTION|PROCEDURE|SEQUENCE|TRIGGER|INDEX|SCHEMA)\s+(?:IF\s+NOT\s+EXISTS\s+)?`)
+	// alterTableRegexp matches the head of the ALTER TABLE statement up to the table name.
+	alterTableRegexp = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:ONLY\s+)?(?:IF\s+EXISTS\s+)?`)
+	// commentRegexp matches the head of the COMMENT ON statement up to the table or column name.
+	commentRegexp = regexp.MustCompile(`(?is)^COMMENT\s+ON\s+(TABLE|COLUMN)\s+`)
+	// onRegexp matches the ON keyword before the table of the index or trigger.
+	onRegexp = regexp.MustCompile(`(?is)\bON\s+`)
+)
+
+// exportGeneric classifies the statements of the dump by the object names following the CREATE, ALTER TABLE
+// and COMMENT ON keywords, for the engines whose dump has no object headers, e.g. Oracle and Snowflake.
+// The indexes, constraints and comments go with their tables. The qualifier of the name is used as the schema
+// if the engine has schemas, otherwise it's the database and dropped.
+func exportGeneric(dump string, withSchema bool, split func(string) ([]base.SingleSQL, error)) ([]*File, error) {
+	list, err := split(dump)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split SQL")
+	}
+
+	newObject := func(objectType ObjectType, names []string) object {
+		o := object{objectType: objectType, name: names[len(names)-1]}
+		if withSchema && len(names) > 1 {
+			o.schema = names[len(names)-2]
+		}
+		return o
+	}
+
+	b := newTreeBuilder()
+	for _, stmt := range list {
+		if stmt.Empty {
+			continue
+		}
+		text := trimLeadingComments(stmt.Text)
+		if text == "" {
+			continue
+		}
+		filePath := miscFile
+		if match := createRegexp.FindStringSubmatch(text); match != nil {
+			names, rest := readQualifiedName(text[len(match[0]):])
+			if len(names) > 0 {
+				switch kind := strings.ToUpper(match[1]); kind {
+				case "SCHEMA":
+					if withSchema {
+						filePath = object{schema: names[len(names)-1]}.filePath()
+					}
+				case "INDEX":
+					// CREATE INDEX name ON table, the index goes with the table.
+					if loc := onRegexp.FindStringIndex(rest); loc != nil && strings.TrimSpace(rest[:loc[0]]) == "" {
+						if table, _ := readQualifiedName(rest[loc[1]:]); len(table) > 0 {
+							filePath = newObject(ObjectTypeTable, table).filePath()
+						}
+					}
+				case "TRIGGER":
+					// CREATE TRIGGER name BEFORE INSERT ON table, the trigger is named by the table and the trigger.
+					if loc := onRegexp.FindStringIndex(rest); loc != nil {
+						if table, _ := readQualifiedName(rest[loc[1]:]); len(table) > 0 {
+							o := newObject(ObjectTypeTrigger, table)
+							o.name = o.name + "." + names[len(names)-1]
+							filePath = o.filePath()
+						}
+					}
+				default:
+					filePath = newObject(getGenericObjectType(kind), names).filePath()
+				}
+			}
+		} else if loc := alterTableRegexp.FindStringIndex(text); loc != nil {
+			if names, _ := readQualifiedName(text[loc[1]:]); len(names) > 0 {
+				filePath = newObject(ObjectTypeTable, names).filePath()
+			}
+		} else if match := commentRegexp.FindStringSubmatch(text); match != nil {
+			names, _ := readQualifiedName(text[len(match[0]):])
+			// The column name is [schema.]table.column.
+			if strings.EqualFold(match[1], "COLUMN") && len(names) > 0 {
+				names = names[:len(names)-1]
+			}
+			if len(names) > 0 {
+				filePath = newObject(ObjectTypeTable, names).filePath()
+			}
+		}
+		b.add(filePath, text)
+	}
+	return b.files(), nil
+}
+
+func getGenericObjectType(kind string) ObjectType {
+	switch kind {
+	case "VIEW":
+		return ObjectTypeView
+	case "FUNCTION":
+		return ObjectTypeFunction
+	case "PROCEDURE":
+		return ObjectTypeProcedure
+	case "SEQUENCE":
+		return ObjectTypeSequence
+	default:
+		return ObjectTypeTable
+	}
+}
+
+// readQualifiedName reads the dot separated name at the beginning of the text, e.g. "HR"."EMP", `db`.t or [dbo].[t],
+// and returns the unquoted parts of the name and the rest of the text.
+func readQualifiedName(text string) ([]string, string) {
+	var names []string
+	for {
+		var name string
+		switch {
+		case text == "":
+			return nil, text
+		case text[0] == '"' || text[0] == '`' || text[0] == '[':
+			quote := text[0]
+			if quote == '[' {
+				quote = ']'
+			}
+			end := strings.IndexByte(text[1:], quote)
+			if end < 0 {
+				return nil, text
+			}
+			name, text = text[1:end+1], text[end+2:]
+		default:
+			end := strings.IndexFunc(text, func(r rune) bool {
+				return !(r == '_' || r == '$' || r == '#' || '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || r > 0x7f)
+			})
+			if end == 0 {
+				return nil, text
+			}
+			if end < 0 {
+				end = len(text)
+			}
+			name, text = text[:end], text[end:]
+		}
+		names = append(names, name)
+		if !strings.HasPrefix(text, ".") {
+			return names, text
+		}
+		text = text[1:]
+	}
+}
+
+// splitOracle splits the Oracle dump, and adds back the semicolon which the PL/SQL splitter trims for the driver.
+func splitOracle(dump string) ([]base.SingleSQL, error) {
+	list, err := plsql.SplitSQL(dump)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		text := strings.TrimSpace(list[i].Text)
+		if text != "" && !strings.HasSuffix(text, ";") {
+			list[i].Text = text + ";"
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,71 @@
+package schematree
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// mysqlHeaderRegexp matches the object headers written by the MySQL dump, e.g.
+	//
+	//	--
+	//	-- Table structure for `t`
+	//	--
+	mysqlHeaderRegexp = regexp.MustCompile("(?m)^--\n-- (Table|View|Temporary view|Sequence|Function|Procedure|Event|Trigger) structure for `((?:[^`]|``)*)`\n")
+	// mysqlDropViewRegexp matches the statement dropping the temporary view before creating the view.
+	mysqlDropViewRegexp = regexp.MustCompile("(?m)^DROP VIEW IF EXISTS `(?:[^`]|``)*`;\n")
+	// mysqlChecksStmts are the statements toggling the unique and foreign key checks for restoring the dump.
+	mysqlChecksStmts = []string{
+		"SET @OLD_UNIQUE_CHECKS=@@UNIQUE_CHECKS, UNIQUE_CHECKS=0;\n",
+		"SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0;\n",
+		"SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS;\n",
+		"SET UNIQUE_CHECKS=@OLD_UNIQUE_CHECKS;\n",
+	}
+)
+
+// exportMySQL splits the dump by the object headers of the MySQL dump.
+// The temporary views are dropped since every view has its own file.
+func exportMySQL(dump string) ([]*File, error) {
+	for _, stmt := range mysqlChecksStmts {
+		dump = strings.ReplaceAll(dump, stmt, "")
+	}
+
+	b := newTreeBuilder()
+	matches := mysqlHeaderRegexp.FindAllStringSubmatchIndex(dump, -1)
+	if len(matches) == 0 {
+		b.add(miscFile, dump)
+		return b.files(), nil
+	}
+	b.add(miscFile, dump[:matches[0][0]])
+	for i, match := range matches {
+		end := len(dump)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		kind := dump[match[2]:match[3]]
+		name := strings.ReplaceAll(dump[match[4]:match[5]], "``", "`")
+		var objectType ObjectType
+		switch kind {
+		case "Table":
+			objectType = ObjectTypeTable
+		case "View":
+			objectType = ObjectTypeView
+		case "Sequence":
+			objectType = ObjectTypeSequence
+		case "Function":
+			objectType = ObjectTypeFunction
+		case "Procedure":
+			objectType = ObjectTypeProcedure
+		case "Event":
+			objectType = ObjectTypeEvent
+		case "Trigger":
+			objectType = ObjectTypeTrigger
+		default:
+			continue
+		}
+		content := mysqlDropViewRegexp.ReplaceAllString(dump[match[1]:end], "")
+		content = strings.TrimPrefix(content, "--\n")
+		b.add(object{objectType: objectType, name: name}.filePath(), content)
+	}
+	return b.files(), nil
+}
@@ -0,0 +1,221 @@
+package schematree
+
+import (
+	"strings"
+
+	pgquery "github.com/pganalyze/pg_query_go/v4"
+	"github.com/pkg/errors"
+
+	pgparser "github.com/bytebase/bytebase/backend/plugin/parser/pg"
+)
+
+const pgDefaultSchema = "public"
+
+// exportPostgres classifies the statements of the pg_dump output by the objects they create or alter.
+// The indexes, constraints, owned sequences and comments go with their tables.
+func exportPostgres(dump string) ([]*File, error) {
+	list, err := pgparser.SplitSQL(dump)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split SQL")
+	}
+
+	type statement struct {
+		text string
+		node *pgquery.Node
+	}
+	var statements []statement
+	// ownedSequences maps the sequence owned by a column to the table file, e.g. the sequence of the serial column.
+	ownedSequences := make(map[string]string)
+	for _, stmt := range list {
+		if stmt.Empty {
+			continue
+		}
+		text := trimLeadingComments(stmt.Text)
+		if text == "" {
+			continue
+		}
+		res, err := pgquery.Parse(text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse statement at line %d", stmt.FirstStatementLine+1)
+		}
+		if len(res.Stmts) != 1 {
+			return nil, errors.Errorf("expect one statement at line %d but found %d", stmt.FirstStatementLine+1, len(res.Stmts))
+		}
+		node := res.Stmts[0].Stmt
+		statements = append(statements, statement{text: text, node: node})
+		if alter := node.GetAlterSeqStmt(); alter != nil {
+			if table, ok := getSequenceOwner(alter); ok {
+				ownedSequences[relationObject(ObjectTypeSequence, alter.Sequence).filePath()] = table.filePath()
+			}
+		}
+	}
+
+	b := newTreeBuilder()
+	// indexTables maps the index to the table file, for the comments on the indexes.
+	indexTables := make(map[string]string)
+	for _, stmt := range statements {
+		filePath := miscFile
+		switch node := stmt.node.Node.(type) {
+		case *pgquery.Node_VariableSetStmt, *pgquery.Node_SelectStmt:
+			// The session settings of pg_dump, e.g. SET and SELECT pg_catalog.set_config(...).
+			continue
+		case *pgquery.Node_CreateSchemaStmt:
+			filePath = object{schema: node.CreateSchemaStmt.Schemaname}.filePath()
+		case *pgquery.Node_CreateStmt:
+			filePath = relationObject(ObjectTypeTable, node.CreateStmt.Relation).filePath()
+		case *pgquery.Node_ViewStmt:
+			filePath = relationObject(ObjectTypeView, node.ViewStmt.View).filePath()
+		case *pgquery.Node_CreateTableAsStmt:
+			if node.CreateTableAsStmt.Objtype == pgquery.ObjectType_OBJECT_MATVIEW && node.CreateTableAsStmt.Into != nil {
+				filePath = relationObject(ObjectTypeView, node.CreateTableAsStmt.Into.Rel).filePath()
+			}
+		case *pgquery.Node_CreateFunctionStmt:
+			objectType := ObjectTypeFunction
+			if node.CreateFunctionStmt.IsProcedure {
+				objectType = ObjectTypeProcedure
+			}
+			if o, ok := nameListObject(objectType, node.CreateFunctionStmt.Funcname); ok {
+				filePath = o.filePath()
+			}
+		case *pgquery.Node_CreateSeqStmt:
+			filePath = relationObject(ObjectTypeSequence, node.CreateSeqStmt.Sequence).filePath()
+		case *pgquery.Node_AlterSeqStmt:
+			filePath = relationObject(ObjectTypeSequence, node.AlterSeqStmt.Sequence).filePath()
+		case *pgquery.Node_CreateTrigStmt:
+			o := relationObject(ObjectTypeTrigger, node.CreateTrigStmt.Relation)
+			o.name = o.name + "." + node.CreateTrigStmt.Trigname
+			filePath = o.filePath()
+		case *pgquery.Node_IndexStmt:
+			o := relationObject(ObjectTypeTable, node.IndexStmt.Relation)
+			filePath = o.filePath()
+			indexTables[o.schema+"."+node.IndexStmt.Idxname] = filePath
+		case *pgquery.Node_AlterTableStmt:
+			if objectType, ok := getRelationObjectType(node.AlterTableStmt.Objtype); ok {
+				filePath = relationObject(objectType, node.AlterTableStmt.Relation).filePath()
+			}
+		case *pgquery.Node_AlterOwnerStmt:
+			if o, ok := getObject(node.AlterOwnerStmt.ObjectType, node.AlterOwnerStmt.Object, indexTables); ok {
+				filePath = o
+			}
+		case *pgquery.Node_CommentStmt:
+			if o, ok := getObject(node.CommentStmt.Objtype, node.CommentStmt.Object, indexTables); ok {
+				filePath = o
+			}
+		}
+		if table, ok := ownedSequences[filePath]; ok {
+			filePath = table
+		}
+		b.add(filePath, stmt.text)
+	}
+	return b.files(), nil
+}
+
+// getSequenceOwner returns the table of the OWNED BY option of the ALTER SEQUENCE statement.
+func getSequenceOwner(alter *pgquery.AlterSeqStmt) (object, bool) {
+	for _, option := range alter.Options {
+		def := option.GetDefElem()
+		if def == nil || def.Defname != "owned_by" {
+			continue
+		}
+		// The list is [schema.]table.column, or NONE.
+		items := def.Arg.GetList().GetItems()
+		if len(items) < 2 {
+			return object{}, false
+		}
+		return nameListObject(ObjectTypeTable, items[:len(items)-1])
+	}
+	return object{}, false
+}
+
+// trimLeadingComments removes the leading comment lines of the statement, e.g. "-- Name: t; Type: TABLE; Schema: public".
+func trimLeadingComments(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for len(lines) > 0 {
+		line := strings.TrimSpace(lines[0])
+		if line != "" && !strings.HasPrefix(line, "--") {
+			break
+		}
+		lines = lines[1:]
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func relationObject(objectType ObjectType, relation *pgquery.RangeVar) object {
+	schema := relation.GetSchemaname()
+	if schema == "" {
+		schema = pgDefaultSchema
+	}
+	return object{schema: schema, objectType: objectType, name: relation.GetRelname()}
+}
+
+// nameListObject returns the object of the qualified name, e.g. [schema, name].
+func nameListObject(objectType ObjectType, names []*pgquery.Node) (object, bool) {
+	switch len(names) {
+	case 1:
+		return object{schema: pgDefaultSchema, objectType: objectType, name: names[0].GetString_().GetSval()}, true
+	case 2:
+		return object{schema: names[0].GetString_().GetSval(), objectType: objectType, name: names[1].GetString_().GetSval()}, true
+	default:
+		return object{}, false
+	}
+}
+
+func getRelationObjectType(objectType pgquery.ObjectType) (ObjectType, bool) {
+	switch objectType {
+	case pgquery.ObjectType_OBJECT_TABLE, pgquery.ObjectType_OBJECT_FOREIGN_TABLE:
+		return ObjectTypeTable, true
+	case pgquery.ObjectType_OBJECT_VIEW, pgquery.ObjectType_OBJECT_MATVIEW:
+		return ObjectTypeView, true
+	case pgquery.ObjectType_OBJECT_SEQUENCE:
+		return ObjectTypeSequence, true
+	default:
+		return "", false
+	}
+}
+
+// getObject returns the file of the object referenced by the COMMENT ON or ALTER ... OWNER TO statement.
+func getObject(objectType pgquery.ObjectType, node *pgquery.Node, indexTables map[string]string) (string, bool) {
+	if relationType, ok := getRelationObjectType(objectType); ok {
+		o, ok := nameListObject(relationType, node.GetList().GetItems())
+		return o.filePath(), ok
+	}
+	switch objectType {
+	case pgquery.ObjectType_OBJECT_SCHEMA:
+		return object{schema: node.GetString_().GetSval()}.filePath(), true
+	case pgquery.ObjectType_OBJECT_FUNCTION, pgquery.ObjectType_OBJECT_PROCEDURE:
+		t := ObjectTypeFunction
+		if objectType == pgquery.ObjectType_OBJECT_PROCEDURE {
+			t = ObjectTypeProcedure
+		}
+		o, ok := nameListObject(t, node.GetObjectWithArgs().GetObjname())
+		return o.filePath(), ok
+	case pgquery.ObjectType_OBJECT_COLUMN, pgquery.ObjectType_OBJECT_TABCONSTRAINT:
+		// The list is [schema.]table.column or [schema.]table.constraint.
+		items := node.GetList().GetItems()
+		if len(items) < 2 {
+			return "", false
+		}
+		o, ok := nameListObject(ObjectTypeTable, items[:len(items)-1])
+		return o.filePath(), ok
+	case pgquery.ObjectType_OBJECT_TRIGGER:
+		items := node.GetList().GetItems()
+		if len(items) < 2 {
+			return "", false
+		}
+		o, ok := nameListObject(ObjectTypeTrigger, items[:len(items)-1])
+		if !ok {
+			return "", false
+		}
+		o.name = o.name + "." + items[len(items)-1].GetString_().GetSval()
+		return o.filePath(), true
+	case pgquery.ObjectType_OBJECT_INDEX:
+		o, ok := nameListObject(ObjectTypeTable, node.GetList().GetItems())
+		if !ok {
+			return "", false
+		}
+		filePath, ok := indexTables[o.schema+"."+o.name]
+		return filePath, ok
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,281 @@
+// Package schematree converts between a schema dump and a directory tree with one file per schema object.
+//
+// The layout of the tree is
+//
+//	[<schema>/]schema.sql
+//	[<schema>/]{tables,views,functions,procedures,sequences,triggers,events}/<name>.sql
+//	misc.sql
+//
+// The schema directory is only used by the engines with schemas, e.g. PostgreSQL and Oracle.
+// The statements which don't belong to any object are kept in misc.sql.
+// The paths are case-folded so that the tree can be checked out on the case-insensitive file systems,
+// and the paths only differing in case are suffixed by "~" and the hash of the original path.
+package schematree
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/transform"
+	"github.com/bytebase/bytebase/backend/plugin/parser/standard"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	schemaFile = "schema.sql"
+	miscFile   = "misc.sql"
+	fileExt    = ".sql"
+)
+
+// ObjectType is the type of the schema object.
+type ObjectType string
+
+const (
+	// ObjectTypeTable is the table, with its indexes, constraints and comments.
+	ObjectTypeTable ObjectType = "tables"
+	// ObjectTypeView is the view or the materialized view.
+	ObjectTypeView ObjectType = "views"
+	// ObjectTypeFunction is the function, the overloaded functions share one file.
+	ObjectTypeFunction ObjectType = "functions"
+	// ObjectTypeProcedure is the stored procedure.
+	ObjectTypeProcedure ObjectType = "procedures"
+	// ObjectTypeSequence is the sequence.
+	ObjectTypeSequence ObjectType = "sequences"
+	// ObjectTypeTrigger is the trigger.
+	ObjectTypeTrigger ObjectType = "triggers"
+	// ObjectTypeEvent is the MySQL event.
+	ObjectTypeEvent ObjectType = "events"
+)
+
+// assembleOrder is the order of the object types in the assembled schema,
+// the objects are created after the objects they may depend on.
+var assembleOrder = []ObjectType{
+	ObjectTypeSequence,
+	ObjectTypeTable,
+	ObjectTypeFunction,
+	ObjectTypeProcedure,
+	ObjectTypeView,
+	ObjectTypeTrigger,
+	ObjectTypeEvent,
+}
+
+// File is a file in the schema tree.
+type File struct {
+	// Path is the slash separated path relative to the root of the tree.
+	Path string
+	// Content is the SQL statements of the file.
+	Content string
+}
+
+// object identifies the file of a schema object.
+type object struct {
+	schema     string
+	objectType ObjectType
+	name       string
+}
+
+// filePath returns the path of the file of the object.
+func (o object) filePath() string {
+	var elems []string
+	if o.schema != "" {
+		elems = append(elems, escapeName(o.schema))
+	}
+	if o.objectType == "" {
+		elems = append(elems, schemaFile)
+	} else {
+		elems = append(elems, string(o.objectType), escapeName(o.name)+fileExt)
+	}
+	return path.Join(elems...)
+}
+
+// treeBuilder collects the statements of the objects in the order of appearance.
+type treeBuilder struct {
+	order    []string
+	contents map[string]*strings.Builder
+}
+
+func newTreeBuilder() *treeBuilder {
+	return &treeBuilder{contents: make(map[string]*strings.Builder)}
+}
+
+func (b *treeBuilder) add(filePath string, statement string) {
+	statement = strings.TrimSpace(statement)
+	if statement == "" {
+		return
+	}
+	content, ok := b.contents[filePath]
+	if !ok {
+		content = &strings.Builder{}
+		b.contents[filePath] = content
+		b.order = append(b.order, filePath)
+	} else {
+		_, _ = content.WriteString("\n")
+	}
+	_, _ = content.WriteString(statement)
+	_, _ = content.WriteString("\n")
+}
+
+func (b *treeBuilder) files() []*File {
+	// foldedPaths maps the case-folded path to the original paths.
+	foldedPaths := make(map[string][]string)
+	for _, filePath := range b.order {
+		folded := strings.ToLower(filePath)
+		foldedPaths[folded] = append(foldedPaths[folded], filePath)
+	}
+	var files []*File
+	for _, filePath := range b.order {
+		files = append(files, &File{Path: foldPath(filePath, len(foldedPaths[strings.ToLower(filePath)]) > 1), Content: b.contents[filePath].String()})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+// foldPath returns the lower case path. If the path collides with other paths in lower case, the path is suffixed by
+// the hash of the original path unless it's already in lower case, so the suffix is stable across the exports.
+func foldPath(filePath string, collided bool) string {
+	folded := strings.ToLower(filePath)
+	if !collided || folded == filePath {
+		return folded
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(filePath))
+	return fmt.Sprintf("%s~%08x%s", strings.TrimSuffix(folded, fileExt), h.Sum32(), path.Ext(folded))
+}
+
+// Export splits the schema dump of the database into the files of the schema objects.
+// The files are sorted by path, and the content of a file is in the order of the dump,
+// so exporting the same schema always produces the same tree.
+func Export(engine storepb.Engine, dump string) ([]*File, error) {
+	switch engine {
+	case storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_TIDB, storepb.Engine_OCEANBASE:
+		return exportMySQL(dump)
+	case storepb.Engine_POSTGRES:
+		return exportPostgres(dump)
+	case storepb.Engine_ORACLE, storepb.Engine_DM, storepb.Engine_OCEANBASE_ORACLE:
+		return exportGeneric(dump, true /* withSchema */, splitOracle)
+	case storepb.Engine_SNOWFLAKE:
+		return exportGeneric(dump, true /* withSchema */, standard.SplitSQL)
+	case storepb.Engine_CLICKHOUSE, storepb.Engine_SQLITE, storepb.Engine_SPANNER:
+		return exportGeneric(dump, false /* withSchema */, standard.SplitSQL)
+	default:
+		return nil, errors.Errorf("schema export is not supported for engine %v", engine)
+	}
+}
+
+// Assemble concatenates the files of the schema tree into the SDL schema for the schema migration.
+func Assemble(engine storepb.Engine, files []*File) (string, error) {
+	var withSchema bool
+	switch engine {
+	case storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_TIDB, storepb.Engine_OCEANBASE:
+	case storepb.Engine_POSTGRES, storepb.Engine_ORACLE, storepb.Engine_DM, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_SNOWFLAKE:
+		withSchema = true
+	case storepb.Engine_CLICKHOUSE, storepb.Engine_SQLITE, storepb.Engine_SPANNER:
+	default:
+		return "", errors.Errorf("schema assemble is not supported for engine %v", engine)
+	}
+
+	// The files of a category are assembled in the order of the path.
+	sorted := make([]*File, len(files))
+	copy(sorted, files)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Path < sorted[j].Path
+	})
+	var schemaFiles, miscFiles []*File
+	objectFiles := make(map[ObjectType][]*File)
+	for _, file := range sorted {
+		objectType, err := getFileCategory(file.Path, withSchema)
+		if err != nil {
+			return "", err
+		}
+		switch objectType {
+		case "":
+			if path.Base(file.Path) == miscFile {
+				miscFiles = append(miscFiles, file)
+			} else {
+				schemaFiles = append(schemaFiles, file)
+			}
+		default:
+			objectFiles[objectType] = append(objectFiles[objectType], file)
+		}
+	}
+
+	var buf strings.Builder
+	write := func(files []*File) {
+		for _, file := range files {
+			content := strings.TrimSpace(file.Content)
+			if content == "" {
+				continue
+			}
+			_, _ = buf.WriteString(content)
+			_, _ = buf.WriteString("\n\n")
+		}
+	}
+	write(schemaFiles)
+	write(miscFiles)
+	for _, objectType := range assembleOrder {
+		write(objectFiles[objectType])
+	}
+
+	switch engine {
+	case storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_TIDB, storepb.Engine_OCEANBASE:
+	default:
+		return buf.String(), nil
+	}
+	sdl, err := transform.SchemaTransform(storepb.Engine_MYSQL, buf.String())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to transform the assembled schema to SDL format")
+	}
+	return sdl, nil
+}
+
+// getFileCategory returns the object type of the file in the tree, or empty for the schema file and misc.sql.
+// For the engines with schemas, the objects without the schema qualifier in the dump are at the root of the tree.
+func getFileCategory(filePath string, withSchema bool) (ObjectType, error) {
+	if filePath == miscFile {
+		return "", nil
+	}
+	elems := strings.Split(filePath, "/")
+	if withSchema {
+		if len(elems) == 2 && elems[1] == schemaFile {
+			return "", nil
+		}
+		if len(elems) == 3 {
+			elems = elems[1:]
+		}
+	}
+	if len(elems) == 2 && strings.HasSuffix(elems[1], fileExt) {
+		for _, objectType := range assembleOrder {
+			if elems[0] == string(objectType) {
+				return objectType, nil
+			}
+		}
+	}
+	return "", errors.Errorf("unexpected file %q in the schema tree", filePath)
+}
+
+// escapeName escapes the object name to a file name by percent-encoding the bytes
+// other than letters, digits and "_.$-", so the names are kept readable in most cases.
+// The case is kept here and folded with the whole path by the tree builder.
+func escapeName(name string) string {
+	var buf strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '_', c == '$', c == '-':
+			_ = buf.WriteByte(c)
+		case c == '.' && i > 0:
+			// A leading dot would make the file hidden.
+			_ = buf.WriteByte(c)
+		default:
+			_, _ = buf.WriteString(fmt.Sprintf("%%%02x", c))
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,329 @@
+package schematree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	// Register the MySQL schema transformer for assembling.
+	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/transform/mysql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestExportMySQL(t *testing.T) {
+	dump := "" +
+		"SET @OLD_UNIQUE_CHECKS=@@UNIQUE_CHECKS, UNIQUE_CHECKS=0;\n" +
+		"SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0;\n" +
+		"--\n" +
+		"-- Table structure for `t1`\n" +
+		"--\n" +
+		"CREATE TABLE `t1` (\n" +
+		"  `id` int NOT NULL,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n" +
+		"\n" +
+		"--\n" +
+		"-- Temporary view structure for `v1`\n" +
+		"--\n" +
+		"CREATE VIEW `v1` AS SELECT \n" +
+		"  1 AS `id`;\n" +
+		"\n" +
+		"--\n" +
+		"-- View structure for `v1`\n" +
+		"--\n" +
+		"DROP VIEW IF EXISTS `v1`;\n" +
+		"CREATE ALGORITHM=UNDEFINED VIEW `v1` AS select `t1`.`id` AS `id` from `t1`;\n" +
+		"\n" +
+		"--\n" +
+		"-- Procedure structure for `p/1`\n" +
+		"--\n" +
+		"DELIMITER ;;\n" +
+		"CREATE PROCEDURE `p/1`() SELECT 1 ;;\n" +
+		"DELIMITER ;\n" +
+		"\n" +
+		"SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS;\n" +
+		"SET UNIQUE_CHECKS=@OLD_UNIQUE_CHECKS;\n"
+
+	a := require.New(t)
+	files, err := Export(storepb.Engine_MYSQL, dump)
+	a.NoError(err)
+	a.Equal([]*File{
+		{
+			Path:    "procedures/p%2f1.sql",
+			Content: "DELIMITER ;;\nCREATE PROCEDURE `p/1`() SELECT 1 ;;\nDELIMITER ;\n",
+		},
+		{
+			Path:    "tables/t1.sql",
+			Content: "CREATE TABLE `t1` (\n  `id` int NOT NULL,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n",
+		},
+		{
+			Path:    "views/v1.sql",
+			Content: "CREATE ALGORITHM=UNDEFINED VIEW `v1` AS select `t1`.`id` AS `id` from `t1`;\n",
+		},
+	}, files)
+
+	sdl, err := Assemble(storepb.Engine_MYSQL, files)
+	a.NoError(err)
+	a.Contains(sdl, "CREATE TABLE `t1`")
+	a.Less(indexOf(sdl, "CREATE TABLE `t1`"), indexOf(sdl, "CREATE PROCEDURE"))
+	a.Less(indexOf(sdl, "CREATE PROCEDURE"), indexOf(sdl, "VIEW `v1`"))
+}
+
+func TestExportPostgres(t *testing.T) {
+	dump := `
+SET statement_timeout = 0;
+SELECT pg_catalog.set_config('search_path', '', false);
+
+--
+-- Name: s1; Type: SCHEMA; Schema: -; Owner: -
+--
+
+CREATE SCHEMA s1;
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto WITH SCHEMA public;
+
+CREATE FUNCTION public.f(a integer) RETURNS integer
+    LANGUAGE sql
+    AS $$ SELECT a; $$;
+
+CREATE FUNCTION public.f(a text) RETURNS text
+    LANGUAGE sql
+    AS $$ SELECT a; $$;
+
+CREATE PROCEDURE s1.p() LANGUAGE sql AS $$ SELECT 1; $$;
+
+CREATE TABLE public.t (
+    id integer NOT NULL,
+    name text
+);
+
+COMMENT ON COLUMN public.t.name IS 'the name';
+
+CREATE SEQUENCE public.t_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1;
+
+ALTER SEQUENCE public.t_id_seq OWNED BY public.t.id;
+
+CREATE SEQUENCE s1.seq;
+
+CREATE VIEW public.v AS
+ SELECT t.id
+   FROM public.t;
+
+ALTER TABLE ONLY public.t ALTER COLUMN id SET DEFAULT nextval('public.t_id_seq'::regclass);
+
+ALTER TABLE ONLY public.t
+    ADD CONSTRAINT t_pkey PRIMARY KEY (id);
+
+CREATE INDEX idx_name ON public.t USING btree (name);
+
+COMMENT ON INDEX public.idx_name IS 'index on name';
+
+CREATE TRIGGER trg BEFORE INSERT ON public.t FOR EACH ROW EXECUTE FUNCTION public.f();
+`
+
+	a := require.New(t)
+	files, err := Export(storepb.Engine_POSTGRES, dump)
+	a.NoError(err)
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	a.Equal([]string{
+		"misc.sql",
+		"public/functions/f.sql",
+		"public/tables/t.sql",
+		"public/triggers/t.trg.sql",
+		"public/views/v.sql",
+		"s1/procedures/p.sql",
+		"s1/schema.sql",
+		"s1/sequences/seq.sql",
+	}, paths)
+	a.Equal("CREATE EXTENSION IF NOT EXISTS pgcrypto WITH SCHEMA public;\n", files[0].Content)
+	a.Equal(""+
+		"CREATE TABLE public.t (\n"+
+		"    id integer NOT NULL,\n"+
+		"    name text\n"+
+		");\n"+
+		"\n"+
+		"COMMENT ON COLUMN public.t.name IS 'the name';\n"+
+		"\n"+
+		"CREATE SEQUENCE public.t_id_seq\n"+
+		"    AS integer\n"+
+		"    START WITH 1\n"+
+		"    INCREMENT BY 1;\n"+
+		"\n"+
+		"ALTER SEQUENCE public.t_id_seq OWNED BY public.t.id;\n"+
+		"\n"+
+		"ALTER TABLE ONLY public.t ALTER COLUMN id SET DEFAULT nextval('public.t_id_seq'::regclass);\n"+
+		"\n"+
+		"ALTER TABLE ONLY public.t\n"+
+		"    ADD CONSTRAINT t_pkey PRIMARY KEY (id);\n"+
+		"\n"+
+		"CREATE INDEX idx_name ON public.t USING btree (name);\n"+
+		"\n"+
+		"COMMENT ON INDEX public.idx_name IS 'index on name';\n", files[2].Content)
+
+	sdl, err := Assemble(storepb.Engine_POSTGRES, files)
+	a.NoError(err)
+	a.Less(indexOf(sdl, "CREATE SCHEMA s1;"), indexOf(sdl, "CREATE EXTENSION"))
+	a.Less(indexOf(sdl, "CREATE SEQUENCE s1.seq;"), indexOf(sdl, "CREATE TABLE public.t"))
+	a.Less(indexOf(sdl, "CREATE TABLE public.t"), indexOf(sdl, "CREATE FUNCTION public.f(a integer)"))
+	a.Less(indexOf(sdl, "CREATE PROCEDURE"), indexOf(sdl, "CREATE VIEW public.v"))
+	a.Less(indexOf(sdl, "CREATE VIEW public.v"), indexOf(sdl, "CREATE TRIGGER trg"))
+
+	_, err = Assemble(storepb.Engine_POSTGRES, append(files, &File{Path: "public/indexes/idx.sql"}))
+	a.ErrorContains(err, `unexpected file "public/indexes/idx.sql"`)
+}
+
+func TestExportOracle(t *testing.T) {
+	dump := `CREATE TABLE "HR"."EMP" (
+  "ID" NUMBER NOT NULL,
+  "NAME" VARCHAR2(100)
+);
+
+ALTER TABLE "HR"."EMP" ADD CONSTRAINT "EMP_PK" PRIMARY KEY ("ID");
+
+COMMENT ON COLUMN "HR"."EMP"."NAME" IS 'the name';
+
+CREATE UNIQUE INDEX "HR"."IDX_NAME" ON "HR"."EMP" ("NAME");
+
+CREATE SEQUENCE "HR"."EMP_SEQ" START WITH 1 INCREMENT BY 1;
+
+CREATE OR REPLACE FORCE EDITIONABLE VIEW "SALES"."V" AS SELECT "ID" FROM "HR"."EMP";
+
+GRANT SELECT ON "HR"."EMP" TO "SALES";
+`
+
+	a := require.New(t)
+	files, err := Export(storepb.Engine_ORACLE, dump)
+	a.NoError(err)
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	a.Equal([]string{
+		"hr/sequences/emp_seq.sql",
+		"hr/tables/emp.sql",
+		"misc.sql",
+		"sales/views/v.sql",
+	}, paths)
+	a.Equal(""+
+		"CREATE TABLE \"HR\".\"EMP\" (\n"+
+		"  \"ID\" NUMBER NOT NULL,\n"+
+		"  \"NAME\" VARCHAR2(100)\n"+
+		");\n"+
+		"\n"+
+		"ALTER TABLE \"HR\".\"EMP\" ADD CONSTRAINT \"EMP_PK\" PRIMARY KEY (\"ID\");\n"+
+		"\n"+
+		"COMMENT ON COLUMN \"HR\".\"EMP\".\"NAME\" IS 'the name';\n"+
+		"\n"+
+		"CREATE UNIQUE INDEX \"HR\".\"IDX_NAME\" ON \"HR\".\"EMP\" (\"NAME\");\n", files[1].Content)
+	a.Equal("GRANT SELECT ON \"HR\".\"EMP\" TO \"SALES\";\n", files[2].Content)
+
+	schema, err := Assemble(storepb.Engine_ORACLE, files)
+	a.NoError(err)
+	a.Less(indexOf(schema, "GRANT SELECT"), indexOf(schema, "CREATE SEQUENCE"))
+	a.Less(indexOf(schema, "CREATE SEQUENCE"), indexOf(schema, "CREATE TABLE"))
+	a.Less(indexOf(schema, "CREATE TABLE"), indexOf(schema, "VIEW \"SALES\".\"V\""))
+}
+
+func TestExportSQLite(t *testing.T) {
+	dump := "" +
+		"CREATE TABLE t (id INTEGER PRIMARY KEY, name TEXT);\n" +
+		"CREATE INDEX idx_name ON t(name);\n" +
+		"CREATE VIEW v AS SELECT id FROM t;\n" +
+		"CREATE TRIGGER trg AFTER INSERT ON t BEGIN SELECT 1; END;\n"
+
+	a := require.New(t)
+	files, err := Export(storepb.Engine_SQLITE, dump)
+	a.NoError(err)
+	a.Equal([]*File{
+		{Path: "tables/t.sql", Content: "CREATE TABLE t (id INTEGER PRIMARY KEY, name TEXT);\n\nCREATE INDEX idx_name ON t(name);\n"},
+		{Path: "triggers/t.trg.sql", Content: "CREATE TRIGGER trg AFTER INSERT ON t BEGIN SELECT 1; END;\n"},
+		{Path: "views/v.sql", Content: "CREATE VIEW v AS SELECT id FROM t;\n"},
+	}, files)
+
+	schema, err := Assemble(storepb.Engine_SQLITE, files)
+	a.NoError(err)
+	a.Less(indexOf(schema, "CREATE TABLE t"), indexOf(schema, "CREATE VIEW v"))
+	a.Less(indexOf(schema, "CREATE VIEW v"), indexOf(schema, "CREATE TRIGGER trg"))
+}
+
+func TestExportCaseCollision(t *testing.T) {
+	dump := `
+CREATE TABLE public."Users" (id integer);
+
+CREATE TABLE public.users (id integer);
+
+CREATE TABLE public."USERS" (id integer);
+
+CREATE TABLE public."Orders" (id integer);
+`
+
+	a := require.New(t)
+	files, err := Export(storepb.Engine_POSTGRES, dump)
+	a.NoError(err)
+	contents := make(map[string]string)
+	for _, file := range files {
+		contents[file.Path] = file.Content
+	}
+	a.Len(contents, 4)
+	// The lower case name keeps the plain path, and the others are suffixed by the hash of the original path.
+	a.Equal("CREATE TABLE public.users (id integer);\n", contents["public/tables/users.sql"])
+	a.Equal("CREATE TABLE public.\"Users\" (id integer);\n", contents[foldPath("public/tables/Users.sql", true)])
+	a.Equal("CREATE TABLE public.\"USERS\" (id integer);\n", contents[foldPath("public/tables/USERS.sql", true)])
+	// The name without collision is only case-folded.
+	a.Equal("CREATE TABLE public.\"Orders\" (id integer);\n", contents["public/tables/orders.sql"])
+	a.Regexp(`^public/tables/users~[0-9a-f]{8}\.sql$`, foldPath("public/tables/Users.sql", true))
+	a.NotEqual(foldPath("public/tables/Users.sql", true), foldPath("public/tables/USERS.sql", true))
+}
+
+func TestReadQualifiedName(t *testing.T) {
+	tests := []struct {
+		text  string
+		names []string
+		rest  string
+	}{
+		{text: `"HR"."EMP" (`, names: []string{"HR", "EMP"}, rest: " ("},
+		{text: "`db`.t ENGINE", names: []string{"db", "t"}, rest: " ENGINE"},
+		{text: "[dbo].[Order Items](", names: []string{"dbo", "Order Items"}, rest: "("},
+		{text: "t", names: []string{"t"}, rest: ""},
+		{text: "(id)", names: nil, rest: "(id)"},
+		{text: `"unterminated`, names: nil, rest: `"unterminated`},
+	}
+	a := require.New(t)
+	for _, test := range tests {
+		names, rest := readQualifiedName(test.text)
+		a.Equal(test.names, names, test.text)
+		a.Equal(test.rest, rest, test.text)
+	}
+}
+
+func TestEscapeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "users", want: "users"},
+		{name: "Order Items", want: "Order%20Items"},
+		{name: "a/b", want: "a%2fb"},
+		{name: ".hidden", want: "%2ehidden"},
+		{name: "t.trg", want: "t.trg"},
+	}
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, escapeName(test.name))
+	}
+}
+
+func indexOf(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			return i
+		}
+	}
+	return -1
+}
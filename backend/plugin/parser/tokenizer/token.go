@@ -0,0 +1,135 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+// TokenType is the type of the token.
+type TokenType int
+
+const (
+	// TokenIdentifier is the unquoted identifier, including the keyword.
+	TokenIdentifier TokenType = iota
+	// TokenQuotedIdentifier is the identifier quoted by the backtick or double quote.
+	TokenQuotedIdentifier
+	// TokenString is the single-quoted string.
+	TokenString
+	// TokenNumber is the number.
+	TokenNumber
+	// TokenPunctuation is the operator or punctuation.
+	TokenPunctuation
+)
+
+// Token is the token of the statement.
+type Token struct {
+	Type TokenType
+	// Text is the unquoted text for the quoted identifier and the original text for the others.
+	Text string
+	// Start and End are the rune offsets in the statement.
+	Start int
+	End   int
+	// Line is the 1-based line of the token.
+	Line int
+}
+
+// IsKeyword returns whether the token is the unquoted keyword, case-insensitively.
+func (t Token) IsKeyword(keyword string) bool {
+	return t.Type == TokenIdentifier && strings.EqualFold(t.Text, keyword)
+}
+
+// IsIdentifier returns whether the token is the unquoted or quoted identifier.
+func (t Token) IsIdentifier() bool {
+	return t.Type == TokenIdentifier || t.Type == TokenQuotedIdentifier
+}
+
+// Tokens splits the statement into the tokens, the blanks and comments are skipped.
+// We mainly considered the MySQL style lexical structure which ClickHouse also follows:
+//
+//	comments
+//	- style /* comments */
+//	- style -- comments
+//	- style # comments
+//	string
+//	- style 'string'
+//	identifier
+//	- style `identifier`
+//	- style "identifier"
+//
+// The quote in the quoted text is escaped by the backslash or by doubling the quote.
+// The punctuations in multiCharPunctuationList are returned as one token, and the other punctuations are one token per rune.
+func (t *Tokenizer) Tokens(multiCharPunctuationList []string) ([]Token, error) {
+	var tokens []Token
+	for {
+		t.skipBlank()
+		start, line := t.pos(), t.line
+		switch {
+		case t.char(0) == eofRune:
+			return tokens, nil
+		case t.char(0) == '/' && t.char(1) == '*', t.char(0) == '-' && t.char(1) == '-', t.char(0) == '#':
+			if err := t.scanComment(); err != nil {
+				return nil, t.newSyntaxError(start, line, "unterminated comment")
+			}
+		case t.char(0) == '\'' || t.char(0) == '`' || t.char(0) == '"':
+			quote := t.char(0)
+			// The doubled quote escapes the quote, so the adjacent quoted texts are one token.
+			for t.char(0) == quote {
+				if err := t.scanString(quote); err != nil {
+					return nil, t.newSyntaxError(start, line, "unterminated quoted string")
+				}
+			}
+			token := Token{Type: TokenString, Text: t.getString(start, t.pos()-start), Start: int(start), End: int(t.pos()), Line: line}
+			if quote != '\'' {
+				token.Type = TokenQuotedIdentifier
+				token.Text = unquote(token.Text)
+			}
+			tokens = append(tokens, token)
+		case t.char(0) >= '0' && t.char(0) <= '9':
+			for isIdentifierRune(t.char(0)) || t.char(0) == '.' {
+				t.skip(1)
+			}
+			tokens = append(tokens, Token{Type: TokenNumber, Text: t.getString(start, t.pos()-start), Start: int(start), End: int(t.pos()), Line: line})
+		case t.char(0) == '_' || unicode.IsLetter(t.char(0)):
+			for isIdentifierRune(t.char(0)) || t.char(0) == '$' {
+				t.skip(1)
+			}
+			tokens = append(tokens, Token{Type: TokenIdentifier, Text: t.getString(start, t.pos()-start), Start: int(start), End: int(t.pos()), Line: line})
+		default:
+			size := uint(1)
+			for _, punctuation := range multiCharPunctuationList {
+				if t.equalWordCaseInsensitive([]rune(punctuation)) {
+					size = uint(len([]rune(punctuation)))
+					break
+				}
+			}
+			t.skip(size)
+			tokens = append(tokens, Token{Type: TokenPunctuation, Text: t.getString(start, size), Start: int(start), End: int(t.pos()), Line: line})
+		}
+	}
+}
+
+func (t *Tokenizer) newSyntaxError(pos uint, line int, message string) error {
+	lineStart := pos
+	for lineStart > 0 && t.buffer[lineStart-1] != '\n' {
+		lineStart--
+	}
+	return &base.SyntaxError{
+		Line:    line,
+		Column:  int(pos - lineStart),
+		Message: message,
+	}
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// unquote removes the quotes and unescapes the quote in the quoted text.
+func unquote(text string) string {
+	quote := text[:1]
+	text = text[1 : len(text)-1]
+	text = strings.ReplaceAll(text, quote+quote, quote)
+	return strings.ReplaceAll(text, `\`+quote, quote)
+}
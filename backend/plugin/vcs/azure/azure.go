@@ -1165,6 +1165,13 @@ func (p *Provider) ListPullRequestFile(ctx context.Context, oauthCtx *common.Oau
 	return files, nil
 }
 
+// CreatePullRequestSuggestions creates the review comments with the suggested changes in the pull request.
+//
+// WARNING: This is not supported in Azure DevOps.
+func (*Provider) CreatePullRequestSuggestions(context.Context, *common.OauthContext, string, string, string, []*vcs.PullRequestSuggestion) error {
+	return errors.New("not supported")
+}
+
 // CreatePullRequest creates the pull request in the repository.
 //
 // Docs: https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-requests/create?view=azure-devops-rest-7.1&tabs=HTTP
@@ -783,6 +783,13 @@ type pullRequestCreate struct {
 	Destination       pullRequestCreateTarget `json:"destination"`
 }
 
+// CreatePullRequestSuggestions creates the review comments with the suggested changes in the pull request.
+//
+// WARNING: This is not supported in Bitbucket Cloud.
+func (*Provider) CreatePullRequestSuggestions(context.Context, *common.OauthContext, string, string, string, []*vcs.PullRequestSuggestion) error {
+	return errors.New("not supported")
+}
+
 // CreatePullRequest creates the pull request in the repository.
 //
 // Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-post
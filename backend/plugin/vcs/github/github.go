@@ -1020,6 +1020,81 @@ func (p *Provider) CreatePullRequest(ctx context.Context, oauthCtx *common.Oauth
 	}, nil
 }
 
+// PullRequestReviewCreate is the API message to create the pull request review.
+type PullRequestReviewCreate struct {
+	CommitID string                     `json:"commit_id"`
+	Event    string                     `json:"event"`
+	Comments []PullRequestReviewComment `json:"comments"`
+}
+
+// PullRequestReviewComment is the API message for the review comment on the lines of a file.
+type PullRequestReviewComment struct {
+	Path      string `json:"path"`
+	Body      string `json:"body"`
+	Side      string `json:"side"`
+	Line      int    `json:"line"`
+	StartSide string `json:"start_side,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+}
+
+// CreatePullRequestSuggestions creates a review with the suggested changes as the review comments in the pull request.
+//
+// Docs: https://docs.github.com/en/rest/pulls/reviews#create-a-review-for-a-pull-request
+func (p *Provider) CreatePullRequestSuggestions(ctx context.Context, oauthCtx *common.OauthContext, instanceURL, repositoryID, pullRequestID string, suggestions []*vcs.PullRequestSuggestion) error {
+	if len(suggestions) == 0 {
+		return nil
+	}
+	review := PullRequestReviewCreate{
+		CommitID: suggestions[0].CommitID,
+		Event:    "COMMENT",
+	}
+	for _, suggestion := range suggestions {
+		comment := PullRequestReviewComment{
+			Path: suggestion.Path,
+			Body: vcs.FormatSuggestion(suggestion.Comment, "suggestion", suggestion.NewText),
+			Side: "RIGHT",
+			Line: suggestion.EndLine,
+		}
+		if suggestion.StartLine < suggestion.EndLine {
+			comment.StartSide, comment.StartLine = "RIGHT", suggestion.StartLine
+		}
+		review.Comments = append(review.Comments, comment)
+	}
+	body, err := json.Marshal(review)
+	if err != nil {
+		return errors.Wrap(err, "marshal pull request review create")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/pulls/%s/reviews", p.APIURL(instanceURL), repositoryID, pullRequestID)
+	code, _, resp, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(body),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+	if code >= 300 {
+		return errors.Errorf("failed to create pull request review from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			resp,
+		)
+	}
+	return nil
+}
+
 // RepositorySecretUpdate is the API message to update the repository secret.
 type RepositorySecretUpdate struct {
 	EncryptedValue string `json:"encrypted_value"`
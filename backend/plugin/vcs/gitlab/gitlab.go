@@ -798,7 +798,96 @@ func (p *Provider) CreateBranch(ctx context.Context, oauthCtx *common.OauthConte
 
 // MergeRequest is the API message for GitLab merge request.
 type MergeRequest struct {
-	WebURL string `json:"web_url"`
+	WebURL   string   `json:"web_url"`
+	DiffRefs DiffRefs `json:"diff_refs"`
+}
+
+// DiffRefs is the API message for the commits of the merge request diff.
+type DiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+// MergeRequestDiscussionCreate is the API message to create the merge request discussion.
+type MergeRequestDiscussionCreate struct {
+	Body     string                   `json:"body"`
+	Position MergeRequestDiffPosition `json:"position"`
+}
+
+// MergeRequestDiffPosition is the API message for the position of the discussion in the merge request diff.
+type MergeRequestDiffPosition struct {
+	PositionType string `json:"position_type"`
+	BaseSHA      string `json:"base_sha"`
+	HeadSHA      string `json:"head_sha"`
+	StartSHA     string `json:"start_sha"`
+	NewPath      string `json:"new_path"`
+	NewLine      int    `json:"new_line"`
+}
+
+// CreatePullRequestSuggestions creates the discussions with the suggested changes on the lines of the merge request diff.
+//
+// Docs: https://docs.gitlab.com/ee/api/discussions.html#create-new-merge-request-thread
+func (p *Provider) CreatePullRequestSuggestions(ctx context.Context, oauthCtx *common.OauthContext, instanceURL, repositoryID, pullRequestID string, suggestions []*vcs.PullRequestSuggestion) error {
+	if len(suggestions) == 0 {
+		return nil
+	}
+	refresher := tokenRefresher(
+		instanceURL,
+		oauthContext{
+			ClientID:     oauthCtx.ClientID,
+			ClientSecret: oauthCtx.ClientSecret,
+			RefreshToken: oauthCtx.RefreshToken,
+		},
+		oauthCtx.Refresher,
+	)
+	url := fmt.Sprintf("%s/projects/%s/merge_requests/%s", p.APIURL(instanceURL), repositoryID, pullRequestID)
+	code, _, body, err := oauth.Get(ctx, p.client, url, &oauthCtx.AccessToken, refresher)
+	if err != nil {
+		return errors.Wrapf(err, "GET %s", url)
+	}
+	if code >= 300 {
+		return errors.Errorf("failed to get merge request from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	var mr MergeRequest
+	if err := json.Unmarshal([]byte(body), &mr); err != nil {
+		return err
+	}
+
+	url = fmt.Sprintf("%s/projects/%s/merge_requests/%s/discussions", p.APIURL(instanceURL), repositoryID, pullRequestID)
+	for _, suggestion := range suggestions {
+		// The suggestion replaces the lines below the commented line.
+		discussion, err := json.Marshal(MergeRequestDiscussionCreate{
+			Body: vcs.FormatSuggestion(suggestion.Comment, fmt.Sprintf("suggestion:-0+%d", suggestion.EndLine-suggestion.StartLine), suggestion.NewText),
+			Position: MergeRequestDiffPosition{
+				PositionType: "text",
+				BaseSHA:      mr.DiffRefs.BaseSHA,
+				HeadSHA:      mr.DiffRefs.HeadSHA,
+				StartSHA:     mr.DiffRefs.StartSHA,
+				NewPath:      suggestion.Path,
+				NewLine:      suggestion.StartLine,
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "marshal merge request discussion create")
+		}
+		code, _, resp, err := oauth.Post(ctx, p.client, url, &oauthCtx.AccessToken, bytes.NewReader(discussion), refresher)
+		if err != nil {
+			return errors.Wrapf(err, "POST %s", url)
+		}
+		if code >= 300 {
+			return errors.Errorf("failed to create merge request discussion from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				resp,
+			)
+		}
+	}
+	return nil
 }
 
 // CreatePullRequest creates the pull request in the repository.
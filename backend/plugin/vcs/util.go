@@ -2,6 +2,7 @@
 package vcs
 
 import (
+	"fmt"
 	"log/slog"
 	"strings"
 
@@ -110,3 +111,13 @@ func (p PushEvent) GetDistinctFileList() []DistinctFileItem {
 	}
 	return distinctFileList
 }
+
+// FormatSuggestion formats the suggested change in the markdown of the review comment, e.g. "```suggestion".
+// The code fence is longer than the backtick runs in the new text.
+func FormatSuggestion(comment, info, newText string) string {
+	fence := "```"
+	for strings.Contains(newText, fence) {
+		fence += "`"
+	}
+	return fmt.Sprintf("%s\n%s%s\n%s\n%s", comment, fence, info, newText, fence)
+}
@@ -478,3 +478,9 @@ func TestGetDistinctFileList(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatSuggestion(t *testing.T) {
+	a := require.New(t)
+	a.Equal("Add CONCURRENTLY\n```suggestion\nCREATE INDEX CONCURRENTLY idx ON t(a);\n```", FormatSuggestion("Add CONCURRENTLY", "suggestion", "CREATE INDEX CONCURRENTLY idx ON t(a);"))
+	a.Equal("Comment\n````suggestion:-0+1\n-- ```\nSELECT 1;\n````", FormatSuggestion("Comment", "suggestion:-0+1", "-- ```\nSELECT 1;"))
+}
@@ -212,6 +212,21 @@ type PullRequest struct {
 	URL string `json:"url"`
 }
 
+// PullRequestSuggestion is the API message for the suggested change of a file in the pull request.
+type PullRequestSuggestion struct {
+	// Path is the file path in the repository.
+	Path string
+	// CommitID is the commit of the file on which the change is suggested.
+	CommitID string
+	// StartLine and EndLine are the one-based lines replaced by the suggested change.
+	StartLine int
+	EndLine   int
+	// Comment is the comment above the suggested change.
+	Comment string
+	// NewText is the text replacing the lines.
+	NewText string
+}
+
 // Provider is the interface for VCS provider.
 type Provider interface {
 	// Returns the API URL for a given VCS instance URL
@@ -305,6 +320,15 @@ type Provider interface {
 	ListPullRequestFile(ctx context.Context, oauthCtx *common.OauthContext, instanceURL, repositoryID, pullRequestID string) ([]*PullRequestFile, error)
 	// pullRequestCreate: the new pull request info
 	CreatePullRequest(ctx context.Context, oauthCtx *common.OauthContext, instanceURL, repositoryID string, pullRequestCreate *PullRequestCreate) (*PullRequest, error)
+	// CreatePullRequestSuggestions creates the review comments with the suggested changes in the pull request,
+	// which the pull request author can apply.
+	//
+	// oauthCtx: OAuth context to create the webhook
+	// instanceURL: VCS instance URL
+	// repositoryID: the repository ID from the external VCS system (note this is NOT the ID of Bytebase's own repository resource)
+	// pullRequestID: the pull request id
+	// suggestions: the suggested changes
+	CreatePullRequestSuggestions(ctx context.Context, oauthCtx *common.OauthContext, instanceURL, repositoryID, pullRequestID string, suggestions []*PullRequestSuggestion) error
 	// UpsertEnvironmentVariable creates or updates the environment variable in the repository.
 	//
 	// oauthCtx: OAuth context to create the webhook
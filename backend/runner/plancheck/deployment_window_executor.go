@@ -0,0 +1,83 @@
+package plancheck
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/deploymentwindow"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var _ Executor = (*DeploymentWindowExecutor)(nil)
+
+// NewDeploymentWindowExecutor creates a deployment window check executor.
+func NewDeploymentWindowExecutor(store *store.Store) Executor {
+	return &DeploymentWindowExecutor{
+		store: store,
+	}
+}
+
+// DeploymentWindowExecutor checks if the deployment window policy of the database environment allows the tasks to run now.
+// The tasks outside the deployment window are queued until the next window rather than rejected, so the check only warns.
+type DeploymentWindowExecutor struct {
+	store *store.Store
+}
+
+// Run runs the executor.
+func (e *DeploymentWindowExecutor) Run(ctx context.Context, config *storepb.PlanCheckRunConfig) ([]*storepb.PlanCheckRunResult_Result, error) {
+	instanceUID := int(config.InstanceUid)
+	instance, err := e.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &instanceUID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get instance UID %v", instanceUID)
+	}
+	if instance == nil {
+		return nil, errors.Errorf("instance not found UID %v", instanceUID)
+	}
+
+	database, err := e.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{InstanceID: &instance.ResourceID, DatabaseName: &config.DatabaseName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get database %q", config.DatabaseName)
+	}
+	if database == nil {
+		return nil, errors.Errorf("database not found %q", config.DatabaseName)
+	}
+
+	environment, err := e.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{ResourceID: &database.EffectiveEnvironmentID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get environment %q", database.EffectiveEnvironmentID)
+	}
+	if environment == nil {
+		return nil, errors.Errorf("environment %q not found", database.EffectiveEnvironmentID)
+	}
+
+	policy, err := e.store.GetDeploymentWindowPolicy(ctx, environment.UID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get deployment window policy for environment %q", environment.ResourceID)
+	}
+	block, err := deploymentwindow.Check(policy, time.Now())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid deployment window policy for environment %q", environment.ResourceID)
+	}
+	if block != nil {
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_WARNING,
+				Code:    common.TaskTimingNotAllowed.Int32(),
+				Title:   "Outside the deployment window",
+				Content: block.String(),
+			},
+		}, nil
+	}
+	return []*storepb.PlanCheckRunResult_Result{
+		{
+			Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+			Code:    common.Ok.Int32(),
+			Title:   "OK",
+			Content: "The deployment window allows the tasks to run now",
+		},
+	}, nil
+}
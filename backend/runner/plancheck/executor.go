@@ -42,7 +42,7 @@ func isStatementTypeCheckSupported(dbType storepb.Engine) bool {
 
 func isStatementAdviseSupported(dbType storepb.Engine) bool {
 	switch dbType {
-	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_POSTGRES, storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_OCEANBASE, storepb.Engine_SNOWFLAKE, storepb.Engine_MSSQL:
+	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_POSTGRES, storepb.Engine_ORACLE, storepb.Engine_OCEANBASE_ORACLE, storepb.Engine_OCEANBASE, storepb.Engine_SNOWFLAKE, storepb.Engine_MSSQL, storepb.Engine_CLICKHOUSE, storepb.Engine_SPANNER:
 		return true
 	default:
 		return false
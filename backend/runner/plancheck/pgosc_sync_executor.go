@@ -0,0 +1,107 @@
+package plancheck
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// NewPGOSCSyncExecutor creates a PostgreSQL online schema change sync check executor.
+func NewPGOSCSyncExecutor(store *store.Store, dbFactory *dbfactory.DBFactory) Executor {
+	return &PGOSCSyncExecutor{
+		store:     store,
+		dbFactory: dbFactory,
+	}
+}
+
+// PGOSCSyncExecutor is the PostgreSQL online schema change sync check executor.
+type PGOSCSyncExecutor struct {
+	store     *store.Store
+	dbFactory *dbfactory.DBFactory
+}
+
+// Run runs the PostgreSQL online schema change sync check executor.
+// It validates the statement and the table, and creates the shadow table in a transaction which is rolled back.
+func (e *PGOSCSyncExecutor) Run(ctx context.Context, config *storepb.PlanCheckRunConfig) ([]*storepb.PlanCheckRunResult_Result, error) {
+	if config.DatabaseGroupUid != nil {
+		return nil, errors.Errorf("database group is not supported")
+	}
+
+	instanceUID := int(config.InstanceUid)
+	instance, err := e.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &instanceUID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get instance UID %v", instanceUID)
+	}
+	if instance == nil {
+		return nil, errors.Errorf("instance not found UID %v", instanceUID)
+	}
+
+	database, err := e.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{InstanceID: &instance.ResourceID, DatabaseName: &config.DatabaseName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get database %q", config.DatabaseName)
+	}
+	if database == nil {
+		return nil, errors.Errorf("database not found %q", config.DatabaseName)
+	}
+
+	sheetUID := int(config.SheetUid)
+	sheet, err := e.store.GetSheet(ctx, &store.FindSheetMessage{UID: &sheetUID}, api.SystemBotID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get sheet %d", sheetUID)
+	}
+	if sheet == nil {
+		return nil, errors.Errorf("sheet %d not found", sheetUID)
+	}
+	statement, err := e.store.GetSheetStatementByID(ctx, sheetUID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get sheet statement %d", sheetUID)
+	}
+
+	materials := utils.GetSecretMapFromDatabaseMessage(database)
+	renderedStatement := utils.RenderStatement(statement, materials)
+
+	if err := func() error {
+		parsedStatement, err := pgosc.ParseStatement(renderedStatement)
+		if err != nil {
+			return err
+		}
+		driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+		if err != nil {
+			return err
+		}
+		defer driver.Close(ctx)
+		migrator, err := pgosc.NewMigrator(ctx, driver.GetDB(), parsedStatement, config.GhostFlags)
+		if err != nil {
+			return err
+		}
+		return migrator.Check(ctx)
+	}(); err != nil {
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_ERROR,
+				Title:   "Online schema change dry run failed",
+				Content: err.Error(),
+				Code:    common.Internal.Int32(),
+				Report:  nil,
+			},
+		}, nil
+	}
+
+	return []*storepb.PlanCheckRunResult_Result{
+		{
+			Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+			Title:   "OK",
+			Content: "Online schema change dry run succeeded",
+			Code:    common.Ok.Int32(),
+			Report:  nil,
+		},
+	}, nil
+}
@@ -0,0 +1,585 @@
+package plancheck
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/replica"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	mysqlparser "github.com/bytebase/bytebase/backend/plugin/parser/mysql"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	pgrawparser "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	defaultLongTransactionThreshold = 1 * time.Minute
+	defaultMaxReplicaLag            = 10 * time.Second
+	// maxReportedQueryLength is the max number of characters of the query of a blocking transaction in the check result.
+	maxReportedQueryLength = 200
+)
+
+var _ Executor = (*ProductionSafetyExecutor)(nil)
+
+// NewProductionSafetyExecutor creates a production safety check executor.
+func NewProductionSafetyExecutor(store *store.Store, dbFactory *dbfactory.DBFactory) Executor {
+	return &ProductionSafetyExecutor{
+		store:     store,
+		dbFactory: dbFactory,
+	}
+}
+
+// ProductionSafetyExecutor checks the live state of the database before the change runs,
+// including the long-running transactions and the locks blocking the tables to be altered,
+// the replica lag, and the free disk space needed by the table rewrites.
+// The risks are reported as warnings or errors per the production safety policy of the database environment.
+type ProductionSafetyExecutor struct {
+	store     *store.Store
+	dbFactory *dbfactory.DBFactory
+}
+
+// alteredTable is a table changed by the statement.
+type alteredTable struct {
+	schema string
+	table  string
+	// rewritten is true if the statement may rebuild the table, which needs as much free disk space as the table size.
+	rewritten bool
+	// lockModes are the PostgreSQL table lock modes taken by the statement, named as the modes in pg_locks.
+	lockModes []string
+}
+
+// postgresLockConflicts are the lock modes conflicting with each PostgreSQL table lock mode.
+// See https://www.postgresql.org/docs/current/explicit-locking.html#LOCKING-TABLES.
+var postgresLockConflicts = map[string][]string{
+	"AccessShareLock":          {"AccessExclusiveLock"},
+	"RowShareLock":             {"ExclusiveLock", "AccessExclusiveLock"},
+	"RowExclusiveLock":         {"ShareLock", "ShareRowExclusiveLock", "ExclusiveLock", "AccessExclusiveLock"},
+	"ShareUpdateExclusiveLock": {"ShareUpdateExclusiveLock", "ShareLock", "ShareRowExclusiveLock", "ExclusiveLock", "AccessExclusiveLock"},
+	"ShareLock":                {"RowExclusiveLock", "ShareUpdateExclusiveLock", "ShareRowExclusiveLock", "ExclusiveLock", "AccessExclusiveLock"},
+	"ShareRowExclusiveLock":    {"RowExclusiveLock", "ShareUpdateExclusiveLock", "ShareLock", "ShareRowExclusiveLock", "ExclusiveLock", "AccessExclusiveLock"},
+	"ExclusiveLock":            {"RowShareLock", "RowExclusiveLock", "ShareUpdateExclusiveLock", "ShareLock", "ShareRowExclusiveLock", "ExclusiveLock", "AccessExclusiveLock"},
+	"AccessExclusiveLock":      {"AccessShareLock", "RowShareLock", "RowExclusiveLock", "ShareUpdateExclusiveLock", "ShareLock", "ShareRowExclusiveLock", "ExclusiveLock", "AccessExclusiveLock"},
+}
+
+// getConflictingLockMode returns the lock mode of the statement conflicting with the lock mode held or requested by another session.
+func (t alteredTable) getConflictingLockMode(mode string) (string, bool) {
+	for _, lockMode := range t.lockModes {
+		if slices.Contains(postgresLockConflicts[lockMode], mode) {
+			return lockMode, true
+		}
+	}
+	return "", false
+}
+
+func (t alteredTable) String() string {
+	if t.schema == "" {
+		return t.table
+	}
+	return fmt.Sprintf("%s.%s", t.schema, t.table)
+}
+
+// Run runs the executor.
+func (e *ProductionSafetyExecutor) Run(ctx context.Context, config *storepb.PlanCheckRunConfig) ([]*storepb.PlanCheckRunResult_Result, error) {
+	instanceUID := int(config.InstanceUid)
+	instance, err := e.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &instanceUID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get instance UID %v", instanceUID)
+	}
+	if instance == nil {
+		return nil, errors.Errorf("instance not found UID %v", instanceUID)
+	}
+	switch instance.Engine {
+	case storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_POSTGRES:
+	default:
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+				Code:    common.Ok.Int32(),
+				Title:   "Not available",
+				Content: fmt.Sprintf("Production safety check is not supported for %s", instance.Engine),
+			},
+		}, nil
+	}
+
+	database, err := e.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{InstanceID: &instance.ResourceID, DatabaseName: &config.DatabaseName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get database %q", config.DatabaseName)
+	}
+	if database == nil {
+		return nil, errors.Errorf("database not found %q", config.DatabaseName)
+	}
+	environment, err := e.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{ResourceID: &database.EffectiveEnvironmentID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get environment %q", database.EffectiveEnvironmentID)
+	}
+	if environment == nil {
+		return nil, errors.Errorf("environment %q not found", database.EffectiveEnvironmentID)
+	}
+	policy, err := e.store.GetProductionSafetyPolicy(ctx, environment.UID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get production safety policy for environment %q", environment.ResourceID)
+	}
+
+	tables, err := e.getAlteredTables(ctx, instance.Engine, database, int(config.SheetUid))
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get driver for database %q", database.DatabaseName)
+	}
+	defer driver.Close(ctx)
+	sqlDB := driver.GetDB()
+	if instance.Engine == storepb.Engine_POSTGRES {
+		if tables, err = e.resolvePostgresSchemas(ctx, sqlDB, database, tables); err != nil {
+			return nil, err
+		}
+	}
+
+	var results []*storepb.PlanCheckRunResult_Result
+	if policy.LockCheckLevel != storepb.ProductionSafetyPolicy_DISABLED {
+		threshold := defaultLongTransactionThreshold
+		if policy.LongTransactionThreshold != nil {
+			threshold = policy.LongTransactionThreshold.AsDuration()
+		}
+		findings, err := getBlockingTransactions(ctx, instance.Engine, sqlDB, database.DatabaseName, tables, threshold)
+		results = append(results, buildResult(policy.LockCheckLevel, common.DbBlockingTransaction, "Blocking transactions and locks", "No blocking transactions or locks", findings, err))
+	}
+	if policy.ReplicaLagCheckLevel != storepb.ProductionSafetyPolicy_DISABLED {
+		maxLag := defaultMaxReplicaLag
+		if policy.MaxReplicaLag != nil {
+			maxLag = policy.MaxReplicaLag.AsDuration()
+		}
+		findings, err := e.getLaggingReplicas(ctx, instance, database, sqlDB, maxLag)
+		results = append(results, buildResult(policy.ReplicaLagCheckLevel, common.DbReplicaLag, "Replica lag", "No replica lags behind", findings, err))
+	}
+	// The disk space check is threshold-only for the databases not reporting the free disk space, so it's skipped without the max rewrite size.
+	if policy.DiskSpaceCheckLevel != storepb.ProductionSafetyPolicy_DISABLED && (policy.MaxRewriteSize > 0 || instance.Engine == storepb.Engine_MARIADB) {
+		findings, err := e.getDiskSpaceShortage(ctx, instance.Engine, sqlDB, database, tables, policy.MaxRewriteSize)
+		results = append(results, buildResult(policy.DiskSpaceCheckLevel, common.DbInsufficientDiskSpace, "Free disk space for table rewrites", "The table rewrites fit in the free disk space", findings, err))
+	}
+	if len(results) == 0 {
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+				Code:    common.Ok.Int32(),
+				Title:   "OK",
+				Content: "The production safety checks are disabled by the environment policy",
+			},
+		}, nil
+	}
+	return results, nil
+}
+
+// buildResult builds the result of a check. The failure of the check itself is reported as a warning rather than blocking the rollout.
+func buildResult(level storepb.ProductionSafetyPolicy_Level, code common.Code, title, okContent string, findings []string, err error) *storepb.PlanCheckRunResult_Result {
+	if err != nil {
+		return &storepb.PlanCheckRunResult_Result{
+			Status:  storepb.PlanCheckRunResult_Result_WARNING,
+			Code:    common.DbExecutionError.Int32(),
+			Title:   fmt.Sprintf("Failed to check %s", strings.ToLower(title)),
+			Content: err.Error(),
+		}
+	}
+	if len(findings) == 0 {
+		return &storepb.PlanCheckRunResult_Result{
+			Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+			Code:    common.Ok.Int32(),
+			Title:   "OK",
+			Content: okContent,
+		}
+	}
+	status := storepb.PlanCheckRunResult_Result_WARNING
+	if level == storepb.ProductionSafetyPolicy_ERROR {
+		status = storepb.PlanCheckRunResult_Result_ERROR
+	}
+	return &storepb.PlanCheckRunResult_Result{
+		Status:  status,
+		Code:    code.Int32(),
+		Title:   title,
+		Content: strings.Join(findings, "\n"),
+	}
+}
+
+func (e *ProductionSafetyExecutor) getAlteredTables(ctx context.Context, engine storepb.Engine, database *store.DatabaseMessage, sheetUID int) ([]alteredTable, error) {
+	sheet, err := e.store.GetSheet(ctx, &store.FindSheetMessage{UID: &sheetUID}, api.SystemBotID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get sheet %d", sheetUID)
+	}
+	if sheet == nil {
+		return nil, errors.Errorf("sheet %d not found", sheetUID)
+	}
+	// The tables of the large statement are not analyzed, and only the database-wide checks apply.
+	if sheet.Size > common.MaxSheetCheckSize {
+		return nil, nil
+	}
+	statement, err := e.store.GetSheetStatementByID(ctx, sheetUID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get sheet statement %d", sheetUID)
+	}
+	materials := utils.GetSecretMapFromDatabaseMessage(database)
+	renderedStatement := utils.RenderStatement(statement, materials)
+
+	tables, err := extractAlteredTables(engine, database.DatabaseName, renderedStatement)
+	if err != nil {
+		// The syntax error is reported by the statement advise check.
+		slog.Debug("failed to extract altered tables", slog.String("database", database.DatabaseName), log.BBError(err))
+		return nil, nil
+	}
+	return tables, nil
+}
+
+// extractAlteredTables extracts the tables changed by the DDL statements.
+func extractAlteredTables(engine storepb.Engine, databaseName string, statement string) ([]alteredTable, error) {
+	var tables []alteredTable
+	switch engine {
+	case storepb.Engine_MYSQL, storepb.Engine_MARIADB:
+		singleSQLs, err := mysqlparser.SplitSQL(statement)
+		if err != nil {
+			return nil, err
+		}
+		for _, singleSQL := range base.FilterEmptySQL(singleSQLs) {
+			stmts, err := mysqlparser.ParseMySQL(singleSQL.Text)
+			if err != nil {
+				return nil, err
+			}
+			if len(stmts) != 1 {
+				continue
+			}
+			// InnoDB rebuilds the table for most ALTER TABLE operations, and gh-ost always copies the table.
+			rewritten := mysqlparser.GetStatementType(stmts[0]) == "ALTER_TABLE"
+			resources, err := base.ExtractChangedResources(storepb.Engine_MYSQL, databaseName, "" /* currentSchema */, singleSQL.Text)
+			if err != nil {
+				return nil, err
+			}
+			for _, resource := range resources {
+				// The tables in other databases are not checked.
+				if resource.Database != "" && resource.Database != databaseName {
+					continue
+				}
+				tables = addAlteredTable(tables, alteredTable{table: resource.Table, rewritten: rewritten})
+			}
+		}
+	case storepb.Engine_POSTGRES:
+		nodes, err := pgrawparser.Parse(pgrawparser.ParseContext{}, statement)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			var table *ast.TableDef
+			rewritten := false
+			var lockMode string
+			switch node := node.(type) {
+			case *ast.AlterTableStmt:
+				table = node.Table
+				// Most forms of ALTER TABLE take the ACCESS EXCLUSIVE lock.
+				lockMode = "AccessExclusiveLock"
+				for _, item := range node.AlterItemList {
+					// Changing the column type rewrites the table in most cases.
+					if _, ok := item.(*ast.AlterColumnTypeStmt); ok {
+						rewritten = true
+					}
+				}
+			case *ast.CreateIndexStmt:
+				if !node.Concurrently && node.Index != nil {
+					table = node.Index.Table
+					lockMode = "ShareLock"
+				}
+			case *ast.DropTableStmt:
+				for _, t := range node.TableList {
+					tables = addAlteredTable(tables, alteredTable{schema: t.Schema, table: t.Name, lockModes: []string{"AccessExclusiveLock"}})
+				}
+			}
+			if table == nil || table.Type == ast.TableTypeView {
+				continue
+			}
+			// The schema of the unqualified table is resolved with the search path later.
+			tables = addAlteredTable(tables, alteredTable{schema: table.Schema, table: table.Name, rewritten: rewritten, lockModes: []string{lockMode}})
+		}
+	}
+	return tables, nil
+}
+
+// addAlteredTable adds the table to the tables, the same table is merged.
+func addAlteredTable(tables []alteredTable, table alteredTable) []alteredTable {
+	for i := range tables {
+		if tables[i].schema != table.schema || tables[i].table != table.table {
+			continue
+		}
+		tables[i].rewritten = tables[i].rewritten || table.rewritten
+		for _, lockMode := range table.lockModes {
+			if !slices.Contains(tables[i].lockModes, lockMode) {
+				tables[i].lockModes = append(tables[i].lockModes, lockMode)
+			}
+		}
+		return tables
+	}
+	return append(tables, table)
+}
+
+// resolvePostgresSchemas resolves the schemas of the unqualified tables with the search path of the connection, as the statement does when it runs.
+func (e *ProductionSafetyExecutor) resolvePostgresSchemas(ctx context.Context, sqlDB *sql.DB, database *store.DatabaseMessage, tables []alteredTable) ([]alteredTable, error) {
+	if !slices.ContainsFunc(tables, func(table alteredTable) bool { return table.schema == "" }) {
+		return tables, nil
+	}
+	searchPath, err := utils.GetPostgreSQLSearchPath(ctx, sqlDB)
+	if err != nil {
+		return nil, err
+	}
+	dbSchema, err := e.store.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get database schema")
+	}
+	var metadata *storepb.DatabaseSchemaMetadata
+	if dbSchema != nil {
+		metadata = dbSchema.GetMetadata()
+	}
+	return resolvePostgresTableSchemas(metadata, searchPath, tables), nil
+}
+
+// resolvePostgresTableSchemas resolves the schema of each unqualified table to the first schema of the search path having the table.
+// The table not found, e.g. created by the statement, is in the first schema of the search path.
+func resolvePostgresTableSchemas(metadata *storepb.DatabaseSchemaMetadata, searchPath []string, tables []alteredTable) []alteredTable {
+	var resolved []alteredTable
+	for _, table := range tables {
+		if table.schema == "" {
+			table.schema = utils.GetPostgreSQLTableSchema(metadata, searchPath, table.table)
+			if table.schema == "" && len(searchPath) > 0 {
+				table.schema = searchPath[0]
+			}
+		}
+		resolved = addAlteredTable(resolved, table)
+	}
+	return resolved
+}
+
+// getBlockingTransactions returns the transactions running longer than the threshold and the locks held by other sessions on the tables.
+func getBlockingTransactions(ctx context.Context, engine storepb.Engine, sqlDB *sql.DB, databaseName string, tables []alteredTable, threshold time.Duration) ([]string, error) {
+	tableMap := make(map[string]alteredTable)
+	for _, table := range tables {
+		tableMap[table.String()] = table
+	}
+
+	var findings []string
+	switch engine {
+	case storepb.Engine_MYSQL, storepb.Engine_MARIADB:
+		// The transactions of the sessions using other databases are not reported, and the sessions without a database may use any.
+		rows, err := sqlDB.QueryContext(ctx, `
+			SELECT t.trx_mysql_thread_id, TIMESTAMPDIFF(SECOND, t.trx_started, NOW()), COALESCE(t.trx_query, '')
+			FROM information_schema.INNODB_TRX t
+			LEFT JOIN information_schema.PROCESSLIST p ON p.ID = t.trx_mysql_thread_id
+			WHERE t.trx_started < NOW() - INTERVAL ? SECOND AND t.trx_mysql_thread_id <> CONNECTION_ID() AND (p.DB IS NULL OR p.DB = ?)
+			ORDER BY t.trx_started`, int64(threshold.Seconds()), databaseName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list long-running transactions")
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var threadID, seconds int64
+			var query string
+			if err := rows.Scan(&threadID, &seconds, &query); err != nil {
+				return nil, err
+			}
+			findings = append(findings, formatLongTransaction(fmt.Sprintf("Thread %d", threadID), time.Duration(seconds)*time.Second, query))
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		if len(tableMap) == 0 {
+			return findings, nil
+		}
+
+		// The metadata locks are instrumented in the performance schema since MySQL 5.7.
+		lockRows, err := sqlDB.QueryContext(ctx, `
+			SELECT m.OBJECT_NAME, m.LOCK_TYPE, m.LOCK_STATUS, COALESCE(t.PROCESSLIST_ID, 0)
+			FROM performance_schema.metadata_locks m
+			LEFT JOIN performance_schema.threads t ON m.OWNER_THREAD_ID = t.THREAD_ID
+			WHERE m.OBJECT_TYPE = 'TABLE' AND m.OBJECT_SCHEMA = ? AND (t.PROCESSLIST_ID IS NULL OR t.PROCESSLIST_ID <> CONNECTION_ID())`, databaseName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list metadata locks")
+		}
+		defer lockRows.Close()
+		for lockRows.Next() {
+			var table, lockType, lockStatus string
+			var threadID int64
+			if err := lockRows.Scan(&table, &lockType, &lockStatus, &threadID); err != nil {
+				return nil, err
+			}
+			if _, ok := tableMap[table]; !ok {
+				continue
+			}
+			findings = append(findings, fmt.Sprintf("Thread %d has %s metadata lock %s on table %q", threadID, strings.ToLower(lockStatus), lockType, table))
+		}
+		if err := lockRows.Err(); err != nil {
+			return nil, err
+		}
+	case storepb.Engine_POSTGRES:
+		rows, err := sqlDB.QueryContext(ctx, `
+			SELECT pid, EXTRACT(EPOCH FROM now() - xact_start)::BIGINT, COALESCE(query, '')
+			FROM pg_stat_activity
+			WHERE datname = current_database() AND pid <> pg_backend_pid() AND xact_start < now() - make_interval(secs => $1)
+			ORDER BY xact_start`, threshold.Seconds())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list long-running transactions")
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var pid, seconds int64
+			var query string
+			if err := rows.Scan(&pid, &seconds, &query); err != nil {
+				return nil, err
+			}
+			findings = append(findings, formatLongTransaction(fmt.Sprintf("Process %d", pid), time.Duration(seconds)*time.Second, query))
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		if len(tableMap) == 0 {
+			return findings, nil
+		}
+
+		// Only the locks conflicting with the locks of the statement block it.
+		lockRows, err := sqlDB.QueryContext(ctx, `
+			SELECT l.pid, n.nspname, c.relname, l.mode, l.granted
+			FROM pg_locks l
+			JOIN pg_class c ON c.oid = l.relation
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE l.database = (SELECT oid FROM pg_database WHERE datname = current_database()) AND l.pid <> pg_backend_pid()`)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list locks")
+		}
+		defer lockRows.Close()
+		for lockRows.Next() {
+			var pid int64
+			var schema, table, mode string
+			var granted bool
+			if err := lockRows.Scan(&pid, &schema, &table, &mode, &granted); err != nil {
+				return nil, err
+			}
+			name := alteredTable{schema: schema, table: table}.String()
+			altered, ok := tableMap[name]
+			if !ok {
+				continue
+			}
+			lockMode, ok := altered.getConflictingLockMode(mode)
+			if !ok {
+				continue
+			}
+			status := "holds"
+			if !granted {
+				status = "waits for"
+			}
+			findings = append(findings, fmt.Sprintf("Process %d %s %s on table %q, conflicting with %s of the statement", pid, status, mode, name, lockMode))
+		}
+		if err := lockRows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return findings, nil
+}
+
+func formatLongTransaction(session string, duration time.Duration, query string) string {
+	query, truncated := common.TruncateString(strings.Join(strings.Fields(query), " "), maxReportedQueryLength)
+	if truncated {
+		query += "..."
+	}
+	if query == "" {
+		return fmt.Sprintf("%s has been running a transaction for %s", session, duration)
+	}
+	return fmt.Sprintf("%s has been running a transaction for %s: %s", session, duration, query)
+}
+
+// getLaggingReplicas returns the replicas lagging behind more than the max lag.
+func (e *ProductionSafetyExecutor) getLaggingReplicas(ctx context.Context, instance *store.InstanceMessage, database *store.DatabaseMessage, sqlDB *sql.DB, maxLag time.Duration) ([]string, error) {
+	lags, err := replica.GetLags(ctx, e.dbFactory, instance, database, sqlDB)
+	if err != nil {
+		return nil, err
+	}
+	var findings []string
+	for _, lag := range lags {
+		if lag.Stopped {
+			findings = append(findings, lag.String())
+		} else if lag.Lag > maxLag {
+			findings = append(findings, fmt.Sprintf("%s, exceeding %s", lag, maxLag))
+		}
+	}
+	return findings, nil
+}
+
+// getDiskSpaceShortage returns the shortage if the table rewrites need more disk space than the max rewrite size,
+// or than the free disk space if the database reports it.
+func (e *ProductionSafetyExecutor) getDiskSpaceShortage(ctx context.Context, engine storepb.Engine, sqlDB *sql.DB, database *store.DatabaseMessage, tables []alteredTable, maxRewriteSize int64) ([]string, error) {
+	dbSchema, err := e.store.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		return nil, err
+	}
+	if dbSchema == nil {
+		return nil, nil
+	}
+	rewriteSize := getRewriteSize(dbSchema.GetMetadata(), tables)
+	if rewriteSize == 0 {
+		return nil, nil
+	}
+
+	var findings []string
+	if maxRewriteSize > 0 && rewriteSize > maxRewriteSize {
+		findings = append(findings, fmt.Sprintf("The table rewrites need about %d bytes of disk space, exceeding the max rewrite size of %d bytes", rewriteSize, maxRewriteSize))
+	}
+	if freeSize, ok := getFreeDiskSpace(ctx, engine, sqlDB); ok && rewriteSize > freeSize {
+		findings = append(findings, fmt.Sprintf("The table rewrites need about %d bytes of disk space, but only %d bytes are free", rewriteSize, freeSize))
+	}
+	return findings, nil
+}
+
+// getRewriteSize returns the total data and index size of the rewritten tables, which is roughly the disk space needed to copy them.
+func getRewriteSize(metadata *storepb.DatabaseSchemaMetadata, tables []alteredTable) int64 {
+	var size int64
+	for _, table := range tables {
+		if !table.rewritten {
+			continue
+		}
+		for _, schema := range metadata.GetSchemas() {
+			if schema.Name != table.schema {
+				continue
+			}
+			for _, t := range schema.Tables {
+				if t.Name == table.table {
+					size += t.DataSize + t.IndexSize
+				}
+			}
+		}
+	}
+	return size
+}
+
+// getFreeDiskSpace returns the free disk space of the database server if the database reports it.
+// MariaDB reports it through the DISKS plugin, and MySQL and PostgreSQL don't report it.
+func getFreeDiskSpace(ctx context.Context, engine storepb.Engine, sqlDB *sql.DB) (int64, bool) {
+	if engine != storepb.Engine_MARIADB {
+		return 0, false
+	}
+	var available sql.NullInt64
+	// The DISKS table reports the sizes in kilobytes, and the minimum of the disks is used as the data directory location is unknown.
+	if err := sqlDB.QueryRowContext(ctx, "SELECT MIN(Available) FROM information_schema.DISKS").Scan(&available); err != nil {
+		slog.Debug("failed to get free disk space", log.BBError(err))
+		return 0, false
+	}
+	if !available.Valid {
+		return 0, false
+	}
+	return available.Int64 * 1024, true
+}
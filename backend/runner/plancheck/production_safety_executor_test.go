@@ -0,0 +1,104 @@
+package plancheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestExtractAlteredTables(t *testing.T) {
+	tests := []struct {
+		engine    storepb.Engine
+		statement string
+		want      []alteredTable
+	}{
+		{
+			engine:    storepb.Engine_MYSQL,
+			statement: "ALTER TABLE t1 ADD COLUMN c INT; CREATE TABLE t2 (id INT); INSERT INTO t1 VALUES (1); ALTER TABLE db.t1 DROP COLUMN d; DROP TABLE other.t3;",
+			want: []alteredTable{
+				{table: "t1", rewritten: true},
+				{table: "t2"},
+			},
+		},
+		{
+			engine:    storepb.Engine_POSTGRES,
+			statement: "ALTER TABLE t1 ADD COLUMN c INT; ALTER TABLE s.t2 ALTER COLUMN c TYPE BIGINT; CREATE INDEX idx ON t3 (c); CREATE INDEX CONCURRENTLY idx2 ON t4 (c); UPDATE t5 SET c = 1; CREATE INDEX idx3 ON t1 (c); DROP TABLE s.t6;",
+			want: []alteredTable{
+				{table: "t1", lockModes: []string{"AccessExclusiveLock", "ShareLock"}},
+				{schema: "s", table: "t2", rewritten: true, lockModes: []string{"AccessExclusiveLock"}},
+				{table: "t3", lockModes: []string{"ShareLock"}},
+				{schema: "s", table: "t6", lockModes: []string{"AccessExclusiveLock"}},
+			},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		tables, err := extractAlteredTables(test.engine, "db", test.statement)
+		a.NoError(err)
+		a.Equal(test.want, tables, test.statement)
+	}
+}
+
+func TestResolvePostgresTableSchemas(t *testing.T) {
+	a := require.New(t)
+	metadata := &storepb.DatabaseSchemaMetadata{
+		Schemas: []*storepb.SchemaMetadata{
+			{Name: "public", Tables: []*storepb.TableMetadata{{Name: "t1"}, {Name: "t2"}}},
+			{Name: "app", Tables: []*storepb.TableMetadata{{Name: "t1"}}},
+		},
+	}
+	tables := []alteredTable{
+		{table: "t1", lockModes: []string{"ShareLock"}},
+		{table: "t2", rewritten: true, lockModes: []string{"AccessExclusiveLock"}},
+		{schema: "app", table: "t1", lockModes: []string{"AccessExclusiveLock"}},
+		// The table created by the statement.
+		{table: "t3", lockModes: []string{"AccessExclusiveLock"}},
+	}
+	a.Equal([]alteredTable{
+		{schema: "app", table: "t1", lockModes: []string{"ShareLock", "AccessExclusiveLock"}},
+		{schema: "public", table: "t2", rewritten: true, lockModes: []string{"AccessExclusiveLock"}},
+		{schema: "app", table: "t3", lockModes: []string{"AccessExclusiveLock"}},
+	}, resolvePostgresTableSchemas(metadata, []string{"app", "public"}, tables))
+}
+
+func TestGetConflictingLockMode(t *testing.T) {
+	a := require.New(t)
+	createIndex := alteredTable{table: "t1", lockModes: []string{"ShareLock"}}
+	_, ok := createIndex.getConflictingLockMode("AccessShareLock")
+	a.False(ok)
+	_, ok = createIndex.getConflictingLockMode("ShareLock")
+	a.False(ok)
+	lockMode, ok := createIndex.getConflictingLockMode("RowExclusiveLock")
+	a.True(ok)
+	a.Equal("ShareLock", lockMode)
+
+	alterTable := alteredTable{table: "t1", lockModes: []string{"ShareLock", "AccessExclusiveLock"}}
+	lockMode, ok = alterTable.getConflictingLockMode("AccessShareLock")
+	a.True(ok)
+	a.Equal("AccessExclusiveLock", lockMode)
+}
+
+func TestGetRewriteSize(t *testing.T) {
+	a := require.New(t)
+	metadata := &storepb.DatabaseSchemaMetadata{
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "public",
+				Tables: []*storepb.TableMetadata{
+					{Name: "t1", DataSize: 100, IndexSize: 20},
+					{Name: "t2", DataSize: 1000, IndexSize: 200},
+				},
+			},
+		},
+	}
+	tables := []alteredTable{
+		{schema: "public", table: "t1", rewritten: true},
+		{schema: "public", table: "t2"},
+		{schema: "public", table: "t3", rewritten: true},
+	}
+	a.Equal(int64(120), getRewriteSize(metadata, tables))
+	a.Equal(int64(0), getRewriteSize(nil, tables))
+}
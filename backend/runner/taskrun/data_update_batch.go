@@ -0,0 +1,581 @@
+package taskrun
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/antlr4-go/antlr/v4"
+	mysql "github.com/bytebase/mysql-parser"
+	postgresql "github.com/bytebase/postgresql-parser"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/replica"
+	"github.com/bytebase/bytebase/backend/component/state"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	mysqlparser "github.com/bytebase/bytebase/backend/plugin/parser/mysql"
+	pgparser "github.com/bytebase/bytebase/backend/plugin/parser/pg"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const (
+	// batchStatusInterval is the interval of recording the batch status line in the task run log.
+	batchStatusInterval = 30 * time.Second
+	// batchWaitInterval is the interval of checking if the paused or throttled batches can continue.
+	batchWaitInterval = 1 * time.Second
+)
+
+// batchedDataUpdate runs the DATA task statements, and runs the eligible single-table UPDATE and DELETE statements in batches of the primary key ranges.
+type batchedDataUpdate struct {
+	stores     *store.Store
+	dbFactory  *dbfactory.DBFactory
+	stateCfg   *state.State
+	logger     *taskRunLogger
+	driver     db.Driver
+	instance   *store.InstanceMessage
+	database   *store.DatabaseMessage
+	taskRunUID int
+	control    *state.BatchDataUpdateControl
+	// maxReplicaLag throttles the batches while the replica lag exceeds it. Zero disables the throttling.
+	maxReplicaLag time.Duration
+	// previousResults are the statement results of the previous failed or canceled task run.
+	// The statements and batches committed by it are not executed again.
+	previousResults []*storepb.TaskRunResult_StatementResult
+	skipFailed      bool
+	// results are the statement results of the task run.
+	results []*storepb.TaskRunResult_StatementResult
+
+	lastLagCheckTime time.Time
+	lastThrottle     string
+}
+
+// isBatchSupported returns true if the DATA task of the engine can run in batches.
+func isBatchSupported(engine storepb.Engine) bool {
+	switch engine {
+	case storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_POSTGRES:
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *batchedDataUpdate) execute(ctx context.Context, statement string) error {
+	statements, err := base.SplitMultiSQL(b.instance.Engine, statement)
+	if err != nil {
+		return errors.Wrapf(err, "failed to split statements")
+	}
+	statements = base.FilterEmptySQL(statements)
+
+	dbSchema, err := b.stores.GetDBSchema(ctx, b.database.UID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get database schema")
+	}
+	var metadata *storepb.DatabaseSchemaMetadata
+	if dbSchema != nil {
+		metadata = dbSchema.GetMetadata()
+	}
+	var searchPath []string
+	if b.instance.Engine == storepb.Engine_POSTGRES {
+		if searchPath, err = utils.GetPostgreSQLSearchPath(ctx, b.driver.GetDB()); err != nil {
+			return err
+		}
+	}
+
+	// Each statement and batch is committed on its own, so the retry resumes from the failed statement and batch.
+	b.results = buildStatementResults(statements, b.previousResults, b.skipFailed)
+	for i, singleSQL := range statements {
+		result := b.results[i]
+		if result.Status != storepb.TaskRunResult_StatementResult_PENDING {
+			continue
+		}
+		chunkedDML, err := getPrimaryKeyChunkedDML(b.instance.Engine, b.database.DatabaseName, searchPath, metadata, singleSQL.Text)
+		if err != nil {
+			// The statement is executed as is, and the database reports the error if any.
+			slog.Debug("failed to parse statement for batches", slog.String("database", b.database.DatabaseName), log.BBError(err))
+		}
+		if chunkedDML == nil {
+			if _, err := executeWithLog(ctx, b.driver, b.logger, i, singleSQL.Text, db.ExecuteOptions{}); err != nil {
+				result.Status = storepb.TaskRunResult_StatementResult_FAILED
+				result.Error = err.Error()
+				return &statementExecutionError{
+					err:     errors.Wrapf(err, "failed to execute statement %d", i+1),
+					failed:  result,
+					results: b.results,
+				}
+			}
+			result.Status = storepb.TaskRunResult_StatementResult_DONE
+			continue
+		}
+		if err := b.executeInBatches(ctx, i, singleSQL.Text, chunkedDML, result); err != nil {
+			result.Status = storepb.TaskRunResult_StatementResult_FAILED
+			result.Error = err.Error()
+			return &statementExecutionError{
+				err:     errors.Wrapf(err, "failed to execute statement %d in batches", i+1),
+				failed:  result,
+				results: b.results,
+			}
+		}
+		result.Status = storepb.TaskRunResult_StatementResult_DONE
+		result.BatchResumeKey = ""
+	}
+	return nil
+}
+
+func (b *batchedDataUpdate) executeInBatches(ctx context.Context, index int, statement string, chunkedDML *util.PrimaryKeyChunkedDML, result *storepb.TaskRunResult_StatementResult) error {
+	statementRecord, _ := common.TruncateString(statement, maxLogStatementSize)
+	b.logger.log(&storepb.TaskRunLog{
+		Type:           storepb.TaskRunLog_STATEMENT_START,
+		StatementIndex: int32(index),
+		Statement:      statementRecord,
+	})
+	start := time.Now()
+	progress := &v1pb.TaskRun_ExecutionDetail_BatchProgress{StatementIndex: int32(index)}
+	err := b.runBatches(ctx, chunkedDML, progress, result)
+	end := &storepb.TaskRunLog{
+		Type:           storepb.TaskRunLog_STATEMENT_END,
+		StatementIndex: int32(index),
+		Duration:       durationpb.New(time.Since(start)),
+		AffectedRows:   progress.AffectedRows,
+	}
+	if err != nil {
+		end.Error = err.Error()
+	}
+	b.logger.log(end)
+	return err
+}
+
+// runBatches runs the batches from the batch resume key of the statement result, or from the first primary key if there is none.
+// The batch resume key is updated to the lower bound of the next batch after each batch is committed.
+// The batches run on a single connection so that they share the session, e.g. the time zone converting the key values.
+func (b *batchedDataUpdate) runBatches(ctx context.Context, chunkedDML *util.PrimaryKeyChunkedDML, progress *v1pb.TaskRun_ExecutionDetail_BatchProgress, result *storepb.TaskRunResult_StatementResult) error {
+	conn, err := b.driver.GetDB().Conn(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get connection")
+	}
+	defer conn.Close()
+
+	var lower any
+	if result.BatchResumeKey != "" {
+		lower = result.BatchResumeKey
+		b.logger.log(&storepb.TaskRunLog{
+			Type:           storepb.TaskRunLog_BATCH_STATUS,
+			StatementIndex: progress.StatementIndex,
+			Message:        fmt.Sprintf("Resume the batches from the primary key %s committed by the previous task run", result.BatchResumeKey),
+		})
+	} else {
+		if err := conn.QueryRowContext(ctx, chunkedDML.GetFirstKeyQuery()).Scan(&lower); err != nil {
+			return errors.Wrapf(err, "failed to get the first primary key")
+		}
+		// The table is empty.
+		if lower == nil {
+			return nil
+		}
+		result.BatchResumeKey = getBatchResumeKey(b.instance.Engine, lower)
+	}
+
+	lastStatusTime := time.Now()
+	for {
+		if err := b.wait(ctx, progress); err != nil {
+			return err
+		}
+		_, batchSize, sleepInterval := b.control.Get()
+
+		var upper any
+		last := false
+		if err := conn.QueryRowContext(ctx, chunkedDML.GetNextKeyQuery(batchSize), lower).Scan(&upper); err != nil {
+			if err != sql.ErrNoRows {
+				return errors.Wrapf(err, "failed to get the upper bound of batch %d", progress.BatchesCompleted+1)
+			}
+			last = true
+		}
+		args := []any{lower}
+		if !last {
+			args = append(args, upper)
+		}
+		sqlResult, err := conn.ExecContext(ctx, chunkedDML.GetChunkStatement(last), args...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to execute batch %d", progress.BatchesCompleted+1)
+		}
+		affectedRows, err := sqlResult.RowsAffected()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the affected rows of batch %d", progress.BatchesCompleted+1)
+		}
+		progress.BatchesCompleted++
+		progress.AffectedRows += affectedRows
+		b.reportProgress(progress)
+		if time.Since(lastStatusTime) >= batchStatusInterval {
+			lastStatusTime = time.Now()
+			b.logStatus(progress)
+		}
+
+		if last {
+			return nil
+		}
+		lower = upper
+		result.BatchResumeKey = getBatchResumeKey(b.instance.Engine, lower)
+		if sleepInterval > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(sleepInterval):
+			}
+		}
+	}
+}
+
+// getBatchResumeKey returns the primary key value as the literal of the engine, which the databases convert to the key column type in the comparison.
+// The time is formatted in its wall clock, which is the location the driver scans it in and formats it back in.
+func getBatchResumeKey(engine storepb.Engine, key any) string {
+	switch v := key.(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		switch engine {
+		case storepb.Engine_POSTGRES:
+			// PostgreSQL ignores the offset for timestamp without time zone, and applies it for timestamp with time zone.
+			return v.Format("2006-01-02 15:04:05.999999-07:00")
+		default:
+			// MySQL rejects the offset before 8.0.19 and the RFC 3339 "T" and "Z".
+			return v.Format("2006-01-02 15:04:05.999999")
+		}
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// wait waits until the batches are neither paused by the users nor throttled by the replica lag.
+func (b *batchedDataUpdate) wait(ctx context.Context, progress *v1pb.TaskRun_ExecutionDetail_BatchProgress) error {
+	logged := false
+	for {
+		paused, _, _ := b.control.Get()
+		throttle := ""
+		if !paused {
+			throttle = b.getThrottle(ctx)
+		}
+		progress.Paused = paused
+		progress.Throttled = throttle != ""
+		progress.ThrottleReason = throttle
+		b.reportProgress(progress)
+		if !paused && throttle == "" {
+			if logged {
+				b.logStatus(progress)
+			}
+			return nil
+		}
+		if !logged {
+			logged = true
+			b.logStatus(progress)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(batchWaitInterval):
+		}
+	}
+}
+
+// getThrottle returns the reason to throttle the batches, or empty if the replicas catch up.
+// The replica lag is checked at most once per batchWaitInterval.
+func (b *batchedDataUpdate) getThrottle(ctx context.Context) string {
+	if b.maxReplicaLag <= 0 {
+		return ""
+	}
+	if time.Since(b.lastLagCheckTime) < batchWaitInterval {
+		return b.lastThrottle
+	}
+	b.lastLagCheckTime = time.Now()
+	b.lastThrottle = ""
+	lags, err := replica.GetLags(ctx, b.dbFactory, b.instance, b.database, b.driver.GetDB())
+	if err != nil {
+		// Keep running the batches if the replica lag is unknown rather than blocking them forever.
+		slog.Warn("failed to get replica lag", slog.String("instance", b.instance.ResourceID), log.BBError(err))
+		return ""
+	}
+	for _, lag := range lags {
+		if lag.Stopped || lag.Lag > b.maxReplicaLag {
+			b.lastThrottle = lag.String()
+			break
+		}
+	}
+	return b.lastThrottle
+}
+
+func (b *batchedDataUpdate) reportProgress(progress *v1pb.TaskRun_ExecutionDetail_BatchProgress) {
+	b.control.SetProgress(progress)
+	b.stateCfg.TaskRunExecutionStatuses.Store(b.taskRunUID,
+		state.TaskRunExecutionStatus{
+			ExecutionStatus: v1pb.TaskRun_EXECUTING,
+			ExecutionDetail: &v1pb.TaskRun_ExecutionDetail{
+				BatchProgress: b.control.GetProgress(),
+			},
+			UpdateTime: time.Now(),
+		})
+}
+
+func (b *batchedDataUpdate) logStatus(progress *v1pb.TaskRun_ExecutionDetail_BatchProgress) {
+	b.logger.log(&storepb.TaskRunLog{
+		Type:           storepb.TaskRunLog_BATCH_STATUS,
+		StatementIndex: progress.StatementIndex,
+		Message:        getBatchStatusLine(b.control.GetProgress()),
+	})
+}
+
+// getBatchStatusLine returns the status line of the batched data update.
+func getBatchStatusLine(progress *v1pb.TaskRun_ExecutionDetail_BatchProgress) string {
+	state := "running"
+	if progress.Paused {
+		state = "paused"
+	} else if progress.Throttled {
+		state = fmt.Sprintf("throttled, %s", progress.ThrottleReason)
+	}
+	return fmt.Sprintf("Batches: %d; Affected rows: %d; Batch size: %d; Sleep: %s; State: %s",
+		progress.BatchesCompleted, progress.AffectedRows, progress.BatchSize, progress.SleepInterval.AsDuration(), state)
+}
+
+// getPrimaryKeyChunkedDML returns the statement run in batches if the statement is a single-table UPDATE or DELETE
+// without ORDER BY or LIMIT on the table with a single-column primary key, or nil otherwise.
+// The UPDATE assigning the primary key runs as is because the rows moved into the later batches would be updated again.
+// The unqualified PostgreSQL table is looked up in the schemas of the search path in order, as the database does.
+func getPrimaryKeyChunkedDML(engine storepb.Engine, databaseName string, searchPath []string, metadata *storepb.DatabaseSchemaMetadata, statement string) (*util.PrimaryKeyChunkedDML, error) {
+	var dml *singleTableDML
+	var err error
+	switch engine {
+	case storepb.Engine_MYSQL, storepb.Engine_MARIADB:
+		dml, err = getMySQLSingleTableDML(databaseName, statement)
+	case storepb.Engine_POSTGRES:
+		dml, err = getPostgreSQLSingleTableDML(statement)
+	}
+	if err != nil || dml == nil {
+		return nil, err
+	}
+	if engine == storepb.Engine_POSTGRES && dml.schema == "" {
+		dml.schema = utils.GetPostgreSQLTableSchema(metadata, searchPath, dml.table)
+		if dml.schema == "" {
+			return nil, nil
+		}
+	}
+	primaryKey := getSingleColumnPrimaryKey(metadata, dml.schema, dml.table)
+	if primaryKey == "" {
+		return nil, nil
+	}
+	for _, column := range dml.setColumns {
+		if strings.EqualFold(column, primaryKey) {
+			return nil, nil
+		}
+	}
+
+	chunkedDML := &util.PrimaryKeyChunkedDML{
+		Statement: dml.statement,
+		Where:     dml.where,
+	}
+	switch engine {
+	case storepb.Engine_POSTGRES:
+		chunkedDML.Table = fmt.Sprintf("%s.%s", quotePostgreSQLIdentifier(dml.schema), quotePostgreSQLIdentifier(dml.table))
+		chunkedDML.PrimaryKey = quotePostgreSQLIdentifier(primaryKey)
+		chunkedDML.Placeholder = func(i int) string { return fmt.Sprintf("$%d", i) }
+	default:
+		chunkedDML.Table = quoteMySQLIdentifier(dml.table)
+		chunkedDML.PrimaryKey = quoteMySQLIdentifier(primaryKey)
+		chunkedDML.Placeholder = func(int) string { return "?" }
+	}
+	return chunkedDML, nil
+}
+
+// singleTableDML is a single-table UPDATE or DELETE statement split at the WHERE clause.
+type singleTableDML struct {
+	schema string
+	table  string
+	// statement is the statement without the WHERE clause.
+	statement string
+	// where is the condition of the WHERE clause, or empty if there is none.
+	where string
+	// setColumns are the columns assigned by the UPDATE statement.
+	setColumns []string
+}
+
+func newSingleTableDML(tokens antlr.TokenStream, ctx antlr.ParserRuleContext, where antlr.ParserRuleContext, condition antlr.ParserRuleContext) *singleTableDML {
+	dml := &singleTableDML{}
+	if where == nil {
+		dml.statement = tokens.GetTextFromRuleContext(ctx)
+		return dml
+	}
+	dml.statement = strings.TrimSpace(tokens.GetTextFromInterval(antlr.NewInterval(ctx.GetStart().GetTokenIndex(), where.GetStart().GetTokenIndex()-1)))
+	dml.where = tokens.GetTextFromRuleContext(condition)
+	return dml
+}
+
+func getMySQLSingleTableDML(databaseName string, statement string) (*singleTableDML, error) {
+	list, err := mysqlparser.ParseMySQL(statement)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) != 1 {
+		return nil, nil
+	}
+	query, ok := list[0].Tree.(*mysql.ScriptContext)
+	if !ok || len(query.AllQuery()) != 1 || query.Query(0).SimpleStatement() == nil {
+		return nil, nil
+	}
+	simpleStatement := query.Query(0).SimpleStatement()
+	tokens := list[0].Tokens
+
+	var dml *singleTableDML
+	var tableRef mysql.ITableRefContext
+	if ctx := simpleStatement.DeleteStatement(); ctx != nil {
+		if ctx.WithClause() != nil || ctx.TableRef() == nil || ctx.PartitionDelete() != nil || ctx.OrderClause() != nil || ctx.SimpleLimitClause() != nil {
+			return nil, nil
+		}
+		tableRef = ctx.TableRef()
+		if ctx.WhereClause() != nil {
+			dml = newSingleTableDML(tokens, ctx, ctx.WhereClause(), ctx.WhereClause().Expr())
+		} else {
+			dml = newSingleTableDML(tokens, ctx, nil, nil)
+		}
+	} else if ctx := simpleStatement.UpdateStatement(); ctx != nil {
+		if ctx.WithClause() != nil || ctx.OrderClause() != nil || ctx.SimpleLimitClause() != nil {
+			return nil, nil
+		}
+		references := ctx.TableReferenceList().AllTableReference()
+		if len(references) != 1 || len(references[0].AllJoinedTable()) != 0 || references[0].TableFactor() == nil || references[0].TableFactor().SingleTable() == nil {
+			return nil, nil
+		}
+		tableRef = references[0].TableFactor().SingleTable().TableRef()
+		if ctx.WhereClause() != nil {
+			dml = newSingleTableDML(tokens, ctx, ctx.WhereClause(), ctx.WhereClause().Expr())
+		} else {
+			dml = newSingleTableDML(tokens, ctx, nil, nil)
+		}
+		for _, element := range ctx.UpdateList().AllUpdateElement() {
+			_, _, column := mysqlparser.NormalizeMySQLFieldIdentifier(element.ColumnRef().FieldIdentifier())
+			dml.setColumns = append(dml.setColumns, column)
+		}
+	} else {
+		return nil, nil
+	}
+
+	database, table := mysqlparser.NormalizeMySQLTableRef(tableRef)
+	// The statement on the tables in other databases runs as is.
+	if database != "" && database != databaseName {
+		return nil, nil
+	}
+	dml.table = table
+	return dml, nil
+}
+
+func getPostgreSQLSingleTableDML(statement string) (*singleTableDML, error) {
+	result, err := pgparser.ParsePostgreSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := result.Tree.(*postgresql.RootContext)
+	if !ok || root.Stmtblock() == nil || root.Stmtblock().Stmtmulti() == nil || len(root.Stmtblock().Stmtmulti().AllStmt()) != 1 {
+		return nil, nil
+	}
+	stmt := root.Stmtblock().Stmtmulti().Stmt(0)
+	tokens := result.Tokens
+
+	// The optional clauses of the grammar match the empty input, so their presence is checked by the keywords.
+	var relation postgresql.IRelation_expr_opt_aliasContext
+	var where postgresql.IWhere_or_current_clauseContext
+	var ctx antlr.ParserRuleContext
+	var setColumns []string
+	if deleteStmt := stmt.Deletestmt(); deleteStmt != nil {
+		if deleteStmt.Opt_with_clause().With_clause() != nil || deleteStmt.Using_clause().USING() != nil || deleteStmt.Returning_clause().RETURNING() != nil {
+			return nil, nil
+		}
+		ctx, relation, where = deleteStmt, deleteStmt.Relation_expr_opt_alias(), deleteStmt.Where_or_current_clause()
+	} else if updateStmt := stmt.Updatestmt(); updateStmt != nil {
+		if updateStmt.Opt_with_clause().With_clause() != nil || updateStmt.From_clause().FROM() != nil || updateStmt.Returning_clause().RETURNING() != nil {
+			return nil, nil
+		}
+		ctx, relation, where = updateStmt, updateStmt.Relation_expr_opt_alias(), updateStmt.Where_or_current_clause()
+		for _, clause := range updateStmt.Set_clause_list().AllSet_clause() {
+			targets := []postgresql.ISet_targetContext{clause.Set_target()}
+			if clause.Set_target_list() != nil {
+				targets = clause.Set_target_list().AllSet_target()
+			}
+			for _, target := range targets {
+				if target != nil {
+					setColumns = append(setColumns, pgparser.NormalizePostgreSQLColid(target.Colid()))
+				}
+			}
+		}
+	} else {
+		return nil, nil
+	}
+
+	var dml *singleTableDML
+	switch {
+	case where.WHERE() == nil:
+		dml = newSingleTableDML(tokens, ctx, nil, nil)
+	case where.A_expr() != nil:
+		dml = newSingleTableDML(tokens, ctx, where, where.A_expr())
+	default:
+		// WHERE CURRENT OF cursor can't run in batches.
+		return nil, nil
+	}
+
+	schema, table, err := pgparser.NormalizePostgreSQLQualifiedNameAsTableName(relation.Relation_expr().Qualified_name())
+	if err != nil {
+		return nil, err
+	}
+	dml.schema, dml.table, dml.setColumns = schema, table, setColumns
+	return dml, nil
+}
+
+// getSingleColumnPrimaryKey returns the column of the primary key, or empty if the table doesn't have a single-column primary key.
+func getSingleColumnPrimaryKey(metadata *storepb.DatabaseSchemaMetadata, schemaName string, tableName string) string {
+	for _, schema := range metadata.GetSchemas() {
+		if schema.Name != schemaName {
+			continue
+		}
+		for _, table := range schema.Tables {
+			if table.Name != tableName {
+				continue
+			}
+			for _, index := range table.Indexes {
+				if index.Primary && len(index.Expressions) == 1 {
+					return index.Expressions[0]
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func quoteMySQLIdentifier(name string) string {
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
+}
+
+func quotePostgreSQLIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
+
+// getDataUpdateBatchConfig returns the batch config of the DATA task, or nil if the task doesn't run in batches.
+func getDataUpdateBatchConfig(task *store.TaskMessage, engine storepb.Engine) (*api.DataUpdateBatchConfig, error) {
+	if task.Type != api.TaskDatabaseDataUpdate || !isBatchSupported(engine) {
+		return nil, nil
+	}
+	payload := &api.TaskDatabaseDataUpdatePayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return nil, errors.Wrap(err, "invalid database data update payload")
+	}
+	if payload.BatchConfig == nil || payload.BatchConfig.BatchSize <= 0 {
+		return nil, nil
+	}
+	return payload.BatchConfig, nil
+}
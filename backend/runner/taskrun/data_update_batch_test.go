@@ -0,0 +1,153 @@
+package taskrun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestGetPrimaryKeyChunkedDML(t *testing.T) {
+	newMetadata := func(schema string) *storepb.DatabaseSchemaMetadata {
+		return &storepb.DatabaseSchemaMetadata{
+			Schemas: []*storepb.SchemaMetadata{
+				{
+					Name: schema,
+					Tables: []*storepb.TableMetadata{
+						{Name: "t", Indexes: []*storepb.IndexMetadata{{Name: "PRIMARY", Expressions: []string{"id"}, Primary: true}}},
+						{Name: "composite", Indexes: []*storepb.IndexMetadata{{Name: "PRIMARY", Expressions: []string{"a", "b"}, Primary: true}}},
+						{Name: "no_pk"},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		engine    storepb.Engine
+		statement string
+		// want is the chunk statement, or empty if the statement can't run in batches.
+		want string
+	}{
+		{storepb.Engine_MYSQL, "DELETE FROM t WHERE created_at < '2020-01-01' OR a = 1", "DELETE FROM t WHERE (created_at < '2020-01-01' OR a = 1) AND `id` >= ? AND `id` < ?"},
+		{storepb.Engine_MYSQL, "UPDATE db.t SET a = a + 1", "UPDATE db.t SET a = a + 1 WHERE `id` >= ? AND `id` < ?"},
+		{storepb.Engine_MYSQL, "UPDATE t AS x SET x.a = 1 WHERE x.b = 2", "UPDATE t AS x SET x.a = 1 WHERE (x.b = 2) AND `id` >= ? AND `id` < ?"},
+		{storepb.Engine_MYSQL, "DELETE FROM t WHERE a = 1 LIMIT 10", ""},
+		{storepb.Engine_MYSQL, "DELETE FROM other.t WHERE a = 1", ""},
+		{storepb.Engine_MYSQL, "UPDATE t JOIN t2 ON t.id = t2.id SET t.a = 1", ""},
+		{storepb.Engine_MYSQL, "DELETE t, t2 FROM t JOIN t2 ON t.id = t2.id", ""},
+		{storepb.Engine_MYSQL, "DELETE FROM composite WHERE a = 1", ""},
+		{storepb.Engine_MYSQL, "DELETE FROM no_pk", ""},
+		{storepb.Engine_MYSQL, "INSERT INTO t VALUES (1)", ""},
+		{storepb.Engine_MYSQL, "UPDATE t SET a = 1, ID = id + 1000", ""},
+		{storepb.Engine_MYSQL, "UPDATE t AS x SET x.id = x.id + 1000 WHERE a = 1", ""},
+		{storepb.Engine_POSTGRES, "DELETE FROM t WHERE a = 1", `DELETE FROM t WHERE (a = 1) AND "id" >= $1 AND "id" < $2`},
+		{storepb.Engine_POSTGRES, "UPDATE public.t SET a = 1", `UPDATE public.t SET a = 1 WHERE "id" >= $1 AND "id" < $2`},
+		{storepb.Engine_POSTGRES, "DELETE FROM t WHERE a = 1 RETURNING id", ""},
+		{storepb.Engine_POSTGRES, "UPDATE t SET a = t2.a FROM t2 WHERE t.id = t2.id", ""},
+		{storepb.Engine_POSTGRES, "DELETE FROM s.t WHERE a = 1", ""},
+		{storepb.Engine_POSTGRES, "UPDATE t SET id = id + 1000", ""},
+		{storepb.Engine_POSTGRES, "UPDATE t SET (a, id) = (1, id + 1000) WHERE a = 2", ""},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		metadata := newMetadata("")
+		if test.engine == storepb.Engine_POSTGRES {
+			metadata = newMetadata("public")
+		}
+		chunkedDML, err := getPrimaryKeyChunkedDML(test.engine, "db", []string{"public"}, metadata, test.statement)
+		a.NoError(err, test.statement)
+		if test.want == "" {
+			a.Nil(chunkedDML, test.statement)
+			continue
+		}
+		a.NotNil(chunkedDML, test.statement)
+		a.Equal(test.want, chunkedDML.GetChunkStatement(false), test.statement)
+	}
+}
+
+func TestGetPrimaryKeyChunkedDMLSearchPath(t *testing.T) {
+	metadata := &storepb.DatabaseSchemaMetadata{
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name:   "public",
+				Tables: []*storepb.TableMetadata{{Name: "t", Indexes: []*storepb.IndexMetadata{{Name: "t_pkey", Expressions: []string{"id"}, Primary: true}}}},
+			},
+			{
+				Name: "app",
+				Tables: []*storepb.TableMetadata{
+					{Name: "t", Indexes: []*storepb.IndexMetadata{{Name: "t_pkey", Expressions: []string{"uid"}, Primary: true}}},
+					{Name: "no_pk"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		searchPath []string
+		statement  string
+		// want is the table and primary key of the batches, or empty if the statement can't run in batches.
+		want []string
+	}{
+		{[]string{"app", "public"}, "DELETE FROM t WHERE a = 1", []string{`"app"."t"`, `"uid"`}},
+		{[]string{"public", "app"}, "DELETE FROM t WHERE a = 1", []string{`"public"."t"`, `"id"`}},
+		// The first schema of the search path doesn't have the table.
+		{[]string{"audit", "public"}, "DELETE FROM t WHERE a = 1", []string{`"public"."t"`, `"id"`}},
+		{[]string{"app", "public"}, "DELETE FROM public.t WHERE a = 1", []string{`"public"."t"`, `"id"`}},
+		{[]string{"audit"}, "DELETE FROM t WHERE a = 1", nil},
+		{[]string{"app", "public"}, "DELETE FROM no_pk WHERE a = 1", nil},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		chunkedDML, err := getPrimaryKeyChunkedDML(storepb.Engine_POSTGRES, "db", test.searchPath, metadata, test.statement)
+		a.NoError(err, test.statement)
+		if test.want == nil {
+			a.Nil(chunkedDML, "%v %s", test.searchPath, test.statement)
+			continue
+		}
+		a.NotNil(chunkedDML, "%v %s", test.searchPath, test.statement)
+		a.Equal(test.want, []string{chunkedDML.Table, chunkedDML.PrimaryKey}, "%v %s", test.searchPath, test.statement)
+	}
+}
+
+func TestGetBatchStatusLine(t *testing.T) {
+	a := require.New(t)
+	progress := &v1pb.TaskRun_ExecutionDetail_BatchProgress{
+		BatchesCompleted: 3,
+		AffectedRows:     2500,
+		BatchSize:        1000,
+		SleepInterval:    durationpb.New(500 * time.Millisecond),
+	}
+	a.Equal("Batches: 3; Affected rows: 2500; Batch size: 1000; Sleep: 500ms; State: running", getBatchStatusLine(progress))
+
+	progress.Throttled = true
+	progress.ThrottleReason = `Replica of data source "ro" lags behind by 30s`
+	a.Equal(`Batches: 3; Affected rows: 2500; Batch size: 1000; Sleep: 500ms; State: throttled, Replica of data source "ro" lags behind by 30s`, getBatchStatusLine(progress))
+
+	progress.Paused = true
+	a.Equal("Batches: 3; Affected rows: 2500; Batch size: 1000; Sleep: 500ms; State: paused", getBatchStatusLine(progress))
+}
+
+func TestGetBatchResumeKey(t *testing.T) {
+	a := require.New(t)
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.FixedZone("UTC+8", 8*3600))
+	tests := []struct {
+		engine storepb.Engine
+		key    any
+		want   string
+	}{
+		{storepb.Engine_MYSQL, int64(42), "42"},
+		{storepb.Engine_MYSQL, []byte("abc"), "abc"},
+		{storepb.Engine_MYSQL, ts, "2024-01-02 03:04:05.123456"},
+		{storepb.Engine_MYSQL, ts.Add(-123456000), "2024-01-02 03:04:05"},
+		{storepb.Engine_POSTGRES, ts, "2024-01-02 03:04:05.123456+08:00"},
+		{storepb.Engine_POSTGRES, ts.UTC(), "2024-01-01 19:04:05.123456+00:00"},
+	}
+	for _, test := range tests {
+		a.Equal(test.want, getBatchResumeKey(test.engine, test.key), "%v %v", test.engine, test.key)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/transform"
 	vcsplugin "github.com/bytebase/bytebase/backend/plugin/vcs"
 	"github.com/bytebase/bytebase/backend/store"
@@ -186,21 +187,23 @@ func executeMigration(
 	statement string,
 	sheetID *int,
 	mi *db.MigrationInfo,
-	license enterprise.LicenseService) (string, string, error) {
+	license enterprise.LicenseService) (string, string, []*storepb.TaskRunResult_StatementResult, error) {
 	instance, err := stores.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
 	if err != nil {
-		return "", "", err
+		return "", "", nil, err
 	}
 	database, err := stores.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
 	if err != nil {
-		return "", "", err
+		return "", "", nil, err
 	}
 
 	driver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
 	if err != nil {
-		return "", "", errors.Wrapf(err, "failed to get driver connection for instance %q", instance.ResourceID)
+		return "", "", nil, errors.Wrapf(err, "failed to get driver connection for instance %q", instance.ResourceID)
 	}
 	defer driver.Close(ctx)
+	logger := newTaskRunLogger(stores, taskRunUID, database)
+	defer logger.close()
 
 	statementRecord, _ := common.TruncateString(statement, common.MaxSheetSize)
 	slog.Debug("Start migration...",
@@ -248,20 +251,73 @@ func executeMigration(
 		}
 	}
 
-	migrationID, schema, err := utils.ExecuteMigrationDefault(ctx, driverCtx, stores, stateCfg, taskRunUID, driver, mi, statement, sheetID, opts)
+	batchConfig, err := getDataUpdateBatchConfig(task, instance.Engine)
 	if err != nil {
-		return "", "", err
+		return "", "", nil, err
+	}
+
+	var schema string
+	var statementResults []*storepb.TaskRunResult_StatementResult
+	if batchConfig != nil && stateCfg != nil {
+		previousResults, skipFailed, err := getPreviousStatementResults(ctx, stores, task, taskRunUID)
+		if err != nil {
+			return "", "", nil, err
+		}
+		control := state.NewBatchDataUpdateControl(batchConfig.BatchSize, time.Duration(batchConfig.SleepIntervalMs)*time.Millisecond)
+		stateCfg.BatchDataUpdateControls.Store(task.ID, control)
+		defer stateCfg.BatchDataUpdateControls.Delete(task.ID)
+		batched := &batchedDataUpdate{
+			stores:        stores,
+			dbFactory:     dbFactory,
+			stateCfg:      stateCfg,
+			logger:        logger,
+			driver:        driver,
+			instance:      instance,
+			database:      database,
+			taskRunUID:    taskRunUID,
+			control:       control,
+			maxReplicaLag: time.Duration(batchConfig.MaxReplicaLagMs) * time.Millisecond,
+
+			previousResults: previousResults,
+			skipFailed:      skipFailed,
+		}
+		migrationID, schema, err = utils.ExecuteMigrationWithFunc(ctx, driverCtx, stores, stateCfg, taskRunUID, driver, mi, statement, sheetID, batched.execute)
+		statementResults = batched.results
+	} else if isStatementResumable(task, instance.Engine, statement) {
+		previousResults, skipFailed, err := getPreviousStatementResults(ctx, stores, task, taskRunUID)
+		if err != nil {
+			return "", "", nil, err
+		}
+		execFunc := func(ctx context.Context, execStatement string) error {
+			statements, err := base.SplitMultiSQL(instance.Engine, execStatement)
+			if err != nil {
+				return errors.Wrapf(err, "failed to split statements")
+			}
+			statements = base.FilterEmptySQL(statements)
+			statementResults = buildStatementResults(statements, previousResults, skipFailed)
+			return executeStatements(ctx, driver, stateCfg, logger, taskRunUID, statements, statementResults, opts)
+		}
+		migrationID, schema, err = utils.ExecuteMigrationWithFunc(ctx, driverCtx, stores, stateCfg, taskRunUID, driver, mi, statement, sheetID, execFunc)
+	} else {
+		execFunc := func(ctx context.Context, execStatement string) error {
+			_, err := executeWithLog(ctx, driver, logger, 0, execStatement, opts)
+			return err
+		}
+		migrationID, schema, err = utils.ExecuteMigrationWithFunc(ctx, driverCtx, stores, stateCfg, taskRunUID, driver, mi, statement, sheetID, execFunc)
+	}
+	if err != nil {
+		return "", "", nil, err
 	}
 
 	// If the migration is a data migration, enable the rollback SQL generation and the type of the driver is Oracle, we need to get the rollback SQL before the transaction is committed.
 	if task.Type == api.TaskDatabaseDataUpdate && instance.Engine == storepb.Engine_ORACLE {
 		updatedTask, err := stores.GetTaskV2ByID(ctx, task.ID)
 		if err != nil {
-			return "", "", errors.Wrapf(err, "cannot get task by id %d", task.ID)
+			return "", "", nil, errors.Wrapf(err, "cannot get task by id %d", task.ID)
 		}
 		payload := &api.TaskDatabaseDataUpdatePayload{}
 		if err := json.Unmarshal([]byte(updatedTask.Payload), payload); err != nil {
-			return "", "", errors.Wrap(err, "invalid database data update payload")
+			return "", "", nil, errors.Wrap(err, "invalid database data update payload")
 		}
 		if payload.RollbackEnabled {
 			// The runner will periodically scan the map to generate rollback SQL asynchronously.
@@ -272,17 +328,17 @@ func executeMigration(
 	if task.Type == api.TaskDatabaseDataUpdate && (instance.Engine == storepb.Engine_MYSQL || instance.Engine == storepb.Engine_MARIADB) {
 		conn, err := driver.GetDB().Conn(ctx)
 		if err != nil {
-			return "", "", errors.Wrap(err, "failed to create connection")
+			return "", "", nil, errors.Wrap(err, "failed to create connection")
 		}
 		defer conn.Close()
 		updatedTask, err := setMigrationIDAndEndBinlogCoordinate(ctx, conn, task, stores, migrationID)
 		if err != nil {
-			return "", "", errors.Wrap(err, "failed to update the task payload for MySQL rollback SQL")
+			return "", "", nil, errors.Wrap(err, "failed to update the task payload for MySQL rollback SQL")
 		}
 
 		payload := &api.TaskDatabaseDataUpdatePayload{}
 		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
-			return "", "", errors.Wrap(err, "invalid database data update payload")
+			return "", "", nil, errors.Wrap(err, "invalid database data update payload")
 		}
 		if payload.RollbackEnabled {
 			// The runner will periodically scan the map to generate rollback SQL asynchronously.
@@ -290,7 +346,7 @@ func executeMigration(
 		}
 	}
 
-	return migrationID, schema, nil
+	return migrationID, schema, statementResults, nil
 }
 
 func getSetOracleTransactionIDFunc(ctx context.Context, task *store.TaskMessage, store *store.Store) func(tx *sql.Tx) error {
@@ -598,7 +654,7 @@ func postMigration(ctx context.Context, stores *store.Store, activityManager *ac
 }
 
 func isWriteBack(ctx context.Context, stores *store.Store, license enterprise.LicenseService, project *store.ProjectMessage, repo *store.RepositoryMessage, task *store.TaskMessage) (string, error) {
-	if task.Type != api.TaskDatabaseSchemaBaseline && task.Type != api.TaskDatabaseSchemaUpdate && task.Type != api.TaskDatabaseSchemaUpdateGhostCutover {
+	if task.Type != api.TaskDatabaseSchemaBaseline && task.Type != api.TaskDatabaseSchemaUpdate && task.Type != api.TaskDatabaseSchemaUpdateGhostCutover && task.Type != api.TaskDatabaseSchemaUpdatePGOSCCutover {
 		return "", nil
 	}
 	if repo == nil || repo.SchemaPathTemplate == "" {
@@ -666,11 +722,15 @@ func runMigration(ctx context.Context, driverCtx context.Context, store *store.S
 		return true, nil, err
 	}
 
-	migrationID, schema, err := executeMigration(ctx, driverCtx, store, dbFactory, stateCfg, profile, task, taskRunUID, statement, sheetID, mi, license)
+	migrationID, schema, statementResults, err := executeMigration(ctx, driverCtx, store, dbFactory, stateCfg, profile, task, taskRunUID, statement, sheetID, mi, license)
 	if err != nil {
 		return true, nil, err
 	}
-	return postMigration(ctx, store, activityManager, license, task, mi, migrationID, schema, sheetID)
+	terminated, result, err = postMigration(ctx, store, activityManager, license, task, mi, migrationID, schema, sheetID)
+	if result != nil {
+		result.StatementResults = statementResults
+	}
+	return terminated, result, err
 }
 
 // Writes back the latest schema to the repository after migration.
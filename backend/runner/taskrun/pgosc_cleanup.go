@@ -0,0 +1,129 @@
+package taskrun
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+)
+
+// ListenPGOSCCleanup cleans up the shadow tables and the triggers of the abandoned PostgreSQL online schema changes.
+// The triggers of the synced shadow table slow down the writes of the original table until the cutover,
+// so they are dropped once the cutover won't run.
+func (s *SchedulerV2) ListenPGOSCCleanup(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = errors.Errorf("%v", r)
+			}
+			slog.Error("ListenPGOSCCleanup PANIC RECOVER", log.BBError(err), log.BBStack("panic-stack"))
+		}
+	}()
+	for {
+		select {
+		case pipelineUID := <-s.stateCfg.PGOSCCleanupChan:
+			if err := s.cleanupAbandonedPGOSC(ctx, pipelineUID); err != nil {
+				slog.Error("failed to clean up the abandoned online schema changes", slog.Int("pipeline", pipelineUID), log.BBError(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// cleanupAbandonedPGOSC cleans up the online schema changes of the pipeline whose cutover tasks are not done and won't run,
+// i.e. the sync or the cutover task is skipped, the cutover task run is canceled, or the issue is closed.
+func (s *SchedulerV2) cleanupAbandonedPGOSC(ctx context.Context, pipelineUID int) error {
+	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &pipelineUID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get issue")
+	}
+	tasks, err := s.store.ListTasks(ctx, &api.TaskFind{
+		PipelineID: &pipelineUID,
+		TypeList:   &[]api.TaskType{api.TaskDatabaseSchemaUpdatePGOSCSync, api.TaskDatabaseSchemaUpdatePGOSCCutover},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list tasks")
+	}
+	taskByID := make(map[int]*store.TaskMessage)
+	for _, task := range tasks {
+		taskByID[task.ID] = task
+	}
+	for _, task := range tasks {
+		if task.Type != api.TaskDatabaseSchemaUpdatePGOSCCutover || task.LatestTaskRunStatus == api.TaskRunDone || len(task.BlockedBy) != 1 {
+			continue
+		}
+		syncTask, ok := taskByID[task.BlockedBy[0]]
+		if !ok {
+			continue
+		}
+		abandoned := (issue != nil && issue.Status != api.IssueOpen) || task.LatestTaskRunStatus == api.TaskRunCanceled
+		for _, t := range []*store.TaskMessage{task, syncTask} {
+			skipped, err := utils.GetTaskSkipped(t)
+			if err != nil {
+				return err
+			}
+			abandoned = abandoned || skipped
+		}
+		if !abandoned {
+			continue
+		}
+		if err := s.cleanupPGOSC(ctx, syncTask); err != nil {
+			slog.Error("failed to clean up the online schema change", slog.Int("task", syncTask.ID), log.BBError(err))
+			continue
+		}
+		slog.Info("cleaned up the abandoned online schema change", slog.Int("task", syncTask.ID))
+	}
+	return nil
+}
+
+// cleanupPGOSC drops the shadow table and the triggers created by the sync task.
+func (s *SchedulerV2) cleanupPGOSC(ctx context.Context, syncTask *store.TaskMessage) error {
+	payload := &api.TaskDatabaseSchemaUpdatePGOSCSyncPayload{}
+	if err := json.Unmarshal([]byte(syncTask.Payload), payload); err != nil {
+		return errors.Wrap(err, "invalid database schema update online schema change sync payload")
+	}
+	statement, err := s.store.GetSheetStatementByID(ctx, payload.SheetID)
+	if err != nil {
+		return err
+	}
+	instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &syncTask.InstanceID})
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return errors.Errorf("instance %d not found", syncTask.InstanceID)
+	}
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: syncTask.DatabaseID})
+	if err != nil {
+		return err
+	}
+	if database == nil {
+		return errors.Errorf("database not found")
+	}
+
+	materials := utils.GetSecretMapFromDatabaseMessage(database)
+	parsedStatement, err := pgosc.ParseStatement(utils.RenderStatement(strings.TrimSpace(statement), materials))
+	if err != nil {
+		return err
+	}
+	driver, err := s.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	migrator, err := pgosc.NewMigrator(ctx, driver.GetDB(), parsedStatement, payload.Flags)
+	if err != nil {
+		return err
+	}
+	return migrator.Cleanup(ctx)
+}
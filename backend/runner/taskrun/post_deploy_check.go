@@ -0,0 +1,279 @@
+package taskrun
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	runnerutils "github.com/bytebase/bytebase/backend/runner/utils"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// startPostDeployCheck returns the running post-deployment check of the task after the change is applied, or nil if the task has none.
+// The sync tasks of the online schema change are checked by their cutover tasks.
+func (s *SchedulerV2) startPostDeployCheck(ctx context.Context, task *store.TaskMessage) *storepb.TaskRunResult_CheckResult {
+	if task.Type == api.TaskDatabaseSchemaUpdateGhostSync || task.Type == api.TaskDatabaseSchemaUpdatePGOSCSync {
+		return nil
+	}
+	if task.DatabaseID == nil {
+		return nil
+	}
+	check, err := s.getPostDeployCheck(ctx, task)
+	if err != nil {
+		result := getFailedCheckResult(errors.Wrapf(err, "failed to get the post-deployment check"))
+		result.StartTime = timestamppb.Now()
+		return result
+	}
+	if check == nil {
+		return nil
+	}
+	return &storepb.TaskRunResult_CheckResult{
+		Status:    storepb.TaskRunResult_CheckResult_RUNNING,
+		StartTime: timestamppb.Now(),
+	}
+}
+
+// schedulePostDeployChecks runs the running post-deployment checks of the done task runs after their anomaly windows.
+// The checks run in the background so that neither the anomaly windows nor the checks hold the scheduler.
+func (s *SchedulerV2) schedulePostDeployChecks(ctx context.Context) error {
+	doneStatus := []api.TaskRunStatus{api.TaskRunDone}
+	runningStatus := storepb.TaskRunResult_CheckResult_RUNNING
+	taskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{
+		Status:                &doneStatus,
+		PostDeployCheckStatus: &runningStatus,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list task runs")
+	}
+	for _, taskRun := range taskRuns {
+		if _, ok := s.runningPostDeployChecks.Load(taskRun.ID); ok {
+			continue
+		}
+		task, err := s.store.GetTaskV2ByID(ctx, taskRun.TaskUID)
+		if err != nil {
+			slog.Error("failed to get task", slog.Int("task", taskRun.TaskUID), log.BBError(err))
+			continue
+		}
+		check, err := s.getPostDeployCheck(ctx, task)
+		if err != nil {
+			slog.Error("failed to get the post-deployment check", slog.Int("task", task.ID), log.BBError(err))
+			continue
+		}
+		startTime := taskRun.ResultProto.GetPostDeployCheck().GetStartTime()
+		if check != nil && time.Now().Before(startTime.AsTime().Add(check.AnomalyWindow.AsDuration())) {
+			continue
+		}
+
+		s.runningPostDeployChecks.Store(taskRun.ID, true)
+		go func(taskRunUID int, task *store.TaskMessage, check *storepb.PlanConfig_ChangeDatabaseConfig_PostDeployCheck, startTime *timestamppb.Timestamp) {
+			defer s.runningPostDeployChecks.Delete(taskRunUID)
+			result := &storepb.TaskRunResult_CheckResult{
+				Status:    storepb.TaskRunResult_CheckResult_PASSED,
+				StartTime: startTime,
+			}
+			// The check passes if it's removed from the plan spec.
+			if check != nil {
+				if err := s.runPostDeployCheck(ctx, task, check, startTime.AsTime()); err != nil {
+					result.Status = storepb.TaskRunResult_CheckResult_FAILED
+					result.Detail = err.Error()
+				}
+			}
+			// The check overridden while running keeps the overridden result.
+			if err := s.store.UpdateTaskRunPostDeployCheck(ctx, taskRunUID, []storepb.TaskRunResult_CheckResult_Status{storepb.TaskRunResult_CheckResult_RUNNING}, result); err != nil && common.ErrorCode(err) != common.Conflict {
+				slog.Error("failed to update the post-deployment check result", slog.Int("taskRun", taskRunUID), log.BBError(err))
+			}
+		}(taskRun.ID, task, check, startTime)
+	}
+	return nil
+}
+
+// runPostDeployCheck runs the post-deployment check on the task database changed at the start time.
+// It returns the error if the check fails.
+func (s *SchedulerV2) runPostDeployCheck(ctx context.Context, task *store.TaskMessage, check *storepb.PlanConfig_ChangeDatabaseConfig_PostDeployCheck, startTime time.Time) error {
+	instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return err
+	}
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return err
+	}
+	if instance == nil || database == nil {
+		return errors.Errorf("database %d not found for task %d", *task.DatabaseID, task.ID)
+	}
+
+	if check.VerificationStatement != "" {
+		rows, err := s.queryVerificationStatement(ctx, instance, database, check.VerificationStatement)
+		if err != nil {
+			return errors.Wrapf(err, "the verification statement failed")
+		}
+		if len(rows) == 0 {
+			return errors.Errorf("the verification statement returned no rows")
+		}
+		if check.ExpectedValue != "" {
+			var value string
+			if len(rows[0].Values) > 0 {
+				value = getRowValueString(rows[0].Values[0])
+			}
+			if value != check.ExpectedValue {
+				return errors.Errorf("the verification statement returned %q, expected %q", value, check.ExpectedValue)
+			}
+		}
+	}
+
+	if check.ExpectedSdl != "" {
+		diff, err := runnerutils.ComputeDatabaseSchemaDiff(ctx, instance, database, s.dbFactory, check.ExpectedSdl)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compute the schema difference from the expected SDL")
+		}
+		if strings.TrimSpace(diff) != "" {
+			return errors.Errorf("the schema differs from the expected SDL:\n%s", diff)
+		}
+	}
+
+	if window := check.AnomalyWindow.AsDuration(); window > 0 {
+		rowStatus := api.Normal
+		anomalies, err := s.store.ListAnomalyV2(ctx, &store.ListAnomalyMessage{
+			RowStatus:  &rowStatus,
+			InstanceID: &instance.ResourceID,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to list anomalies")
+		}
+		for _, anomaly := range anomalies {
+			if anomaly.CreatedTs < startTime.Unix() {
+				continue
+			}
+			// The instance anomalies affect the database too.
+			if anomaly.DatabaseUID != nil && *anomaly.DatabaseUID != database.UID {
+				continue
+			}
+			return errors.Errorf("the anomaly %q is found within %s after the change", anomaly.Type, window)
+		}
+	}
+	return nil
+}
+
+// getPostDeployCheck gets the post-deployment check of the plan spec creating the task, or nil if there is none.
+func (s *SchedulerV2) getPostDeployCheck(ctx context.Context, task *store.TaskMessage) (*storepb.PlanConfig_ChangeDatabaseConfig_PostDeployCheck, error) {
+	var payload struct {
+		SpecID string `json:"specId"`
+	}
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal payload")
+	}
+	if payload.SpecID == "" {
+		return nil, nil
+	}
+	plan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{PipelineID: &task.PipelineID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get plan")
+	}
+	if plan == nil {
+		return nil, nil
+	}
+	for _, step := range plan.Config.Steps {
+		for _, spec := range step.Specs {
+			if spec.Id == payload.SpecID {
+				return spec.GetChangeDatabaseConfig().GetPostDeployCheck(), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// isHaltedByPostDeployCheck returns whether the rollout halts the stage because the post-deployment check of a task in the previous stages is running or failed.
+// The rollout resumes after the check passes or is overridden, or the change is rolled back.
+func (ps *pipelineState) isHaltedByPostDeployCheck(stageID int) bool {
+	if halted, ok := ps.haltedStages[stageID]; ok {
+		return halted
+	}
+	halted := false
+	for taskID, taskRun := range ps.latestTaskRuns {
+		// The stages are created in order.
+		if taskRun.StageUID >= stageID || taskRun.Status != api.TaskRunDone || ps.rolledBackTasks[taskID] {
+			continue
+		}
+		switch taskRun.ResultProto.GetPostDeployCheck().GetStatus() {
+		case storepb.TaskRunResult_CheckResult_RUNNING, storepb.TaskRunResult_CheckResult_FAILED:
+			halted = true
+		}
+	}
+	ps.haltedStages[stageID] = halted
+	return halted
+}
+
+// getStageRollbackTasks returns the tasks rolling back the other tasks of the stage, mapped to the tasks they roll back.
+func getStageRollbackTasks(tasks []*store.TaskMessage) (map[int]int, error) {
+	taskIDs := map[int]bool{}
+	for _, task := range tasks {
+		taskIDs[task.ID] = true
+	}
+	rollbackTasks := map[int]int{}
+	for _, task := range tasks {
+		var payload struct {
+			RollbackFromTaskID int `json:"rollbackFromTaskId"`
+		}
+		if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal payload")
+		}
+		if taskIDs[payload.RollbackFromTaskID] {
+			rollbackTasks[task.ID] = payload.RollbackFromTaskID
+		}
+	}
+	return rollbackTasks, nil
+}
+
+// getRolledBackTasks returns the IDs of the tasks whose rollback tasks are all done.
+func getRolledBackTasks(tasks []*store.TaskMessage, rollbackTasks map[int]int) map[int]bool {
+	rolledBackTasks := map[int]bool{}
+	for _, task := range tasks {
+		if rolledBackTaskID, ok := rollbackTasks[task.ID]; ok && task.LatestTaskRunStatus == api.TaskRunDone {
+			rolledBackTasks[rolledBackTaskID] = true
+		}
+	}
+	for _, task := range tasks {
+		if rolledBackTaskID, ok := rollbackTasks[task.ID]; ok && task.LatestTaskRunStatus != api.TaskRunDone {
+			delete(rolledBackTasks, rolledBackTaskID)
+		}
+	}
+	return rolledBackTasks
+}
+
+func getRowValueString(value *v1pb.RowValue) string {
+	switch value.Kind.(type) {
+	case *v1pb.RowValue_StringValue:
+		return value.GetStringValue()
+	case *v1pb.RowValue_Int32Value:
+		return strconv.FormatInt(int64(value.GetInt32Value()), 10)
+	case *v1pb.RowValue_Int64Value:
+		return strconv.FormatInt(value.GetInt64Value(), 10)
+	case *v1pb.RowValue_Uint32Value:
+		return strconv.FormatUint(uint64(value.GetUint32Value()), 10)
+	case *v1pb.RowValue_Uint64Value:
+		return strconv.FormatUint(value.GetUint64Value(), 10)
+	case *v1pb.RowValue_FloatValue:
+		return strconv.FormatFloat(float64(value.GetFloatValue()), 'f', -1, 32)
+	case *v1pb.RowValue_DoubleValue:
+		return strconv.FormatFloat(value.GetDoubleValue(), 'f', -1, 64)
+	case *v1pb.RowValue_BoolValue:
+		return strconv.FormatBool(value.GetBoolValue())
+	case *v1pb.RowValue_BytesValue:
+		return string(value.GetBytesValue())
+	case *v1pb.RowValue_ValueValue:
+		return value.GetValueValue().GetStringValue()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,97 @@
+package taskrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestGetStageRollbackTasks(t *testing.T) {
+	a := require.New(t)
+	tasks := []*store.TaskMessage{
+		{ID: 1, Payload: `{"specId":"a"}`},
+		{ID: 2, Payload: `{"rollbackFromTaskId":1}`},
+		// The task rolled back by task 3 is in another stage.
+		{ID: 3, Payload: `{"rollbackFromTaskId":9}`},
+		{ID: 4, Payload: `{}`},
+	}
+	rollbackTasks, err := getStageRollbackTasks(tasks)
+	a.NoError(err)
+	a.Equal(map[int]int{2: 1}, rollbackTasks)
+
+	_, err = getStageRollbackTasks([]*store.TaskMessage{{ID: 1, Payload: "invalid"}})
+	a.Error(err)
+}
+
+func TestGetRolledBackTasks(t *testing.T) {
+	a := require.New(t)
+	tasks := []*store.TaskMessage{
+		{ID: 1, LatestTaskRunStatus: api.TaskRunDone},
+		{ID: 2, LatestTaskRunStatus: api.TaskRunDone},
+		{ID: 3, LatestTaskRunStatus: api.TaskRunDone},
+		// Task 2 is rolled back by the restore task 4 and the cutover task 5.
+		{ID: 4, LatestTaskRunStatus: api.TaskRunDone},
+		{ID: 5, LatestTaskRunStatus: api.TaskRunRunning},
+		{ID: 6, LatestTaskRunStatus: api.TaskRunDone},
+	}
+	rollbackTasks := map[int]int{4: 2, 5: 2, 6: 3}
+	a.Equal(map[int]bool{3: true}, getRolledBackTasks(tasks, rollbackTasks))
+
+	tasks[4].LatestTaskRunStatus = api.TaskRunDone
+	a.Equal(map[int]bool{2: true, 3: true}, getRolledBackTasks(tasks, rollbackTasks))
+}
+
+func TestGetRowValueString(t *testing.T) {
+	tests := []struct {
+		value *v1pb.RowValue
+		want  string
+	}{
+		{&v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: "ok"}}, "ok"},
+		{&v1pb.RowValue{Kind: &v1pb.RowValue_Int64Value{Int64Value: -42}}, "-42"},
+		{&v1pb.RowValue{Kind: &v1pb.RowValue_Uint32Value{Uint32Value: 7}}, "7"},
+		{&v1pb.RowValue{Kind: &v1pb.RowValue_DoubleValue{DoubleValue: 1.5}}, "1.5"},
+		{&v1pb.RowValue{Kind: &v1pb.RowValue_BoolValue{BoolValue: true}}, "true"},
+		{&v1pb.RowValue{Kind: &v1pb.RowValue_BytesValue{BytesValue: []byte("abc")}}, "abc"},
+		{&v1pb.RowValue{Kind: &v1pb.RowValue_ValueValue{ValueValue: structpb.NewStringValue("json")}}, "json"},
+		{&v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{}}, ""},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, getRowValueString(test.value))
+	}
+}
+
+func TestIsHaltedByPostDeployCheck(t *testing.T) {
+	a := require.New(t)
+	checkResult := func(status storepb.TaskRunResult_CheckResult_Status) *storepb.TaskRunResult {
+		return &storepb.TaskRunResult{PostDeployCheck: &storepb.TaskRunResult_CheckResult{Status: status}}
+	}
+	ps := &pipelineState{
+		latestTaskRuns: map[int]*store.TaskRunMessage{
+			1: {TaskUID: 1, StageUID: 101, Status: api.TaskRunDone, ResultProto: checkResult(storepb.TaskRunResult_CheckResult_PASSED)},
+			2: {TaskUID: 2, StageUID: 102, Status: api.TaskRunDone, ResultProto: checkResult(storepb.TaskRunResult_CheckResult_FAILED)},
+			3: {TaskUID: 3, StageUID: 103, Status: api.TaskRunDone, ResultProto: checkResult(storepb.TaskRunResult_CheckResult_RUNNING)},
+		},
+		rolledBackTasks: map[int]bool{},
+		haltedStages:    map[int]bool{},
+	}
+	a.False(ps.isHaltedByPostDeployCheck(102))
+	a.True(ps.isHaltedByPostDeployCheck(103))
+	a.True(ps.isHaltedByPostDeployCheck(104))
+
+	// The rolled back change doesn't halt the rollout.
+	ps = &pipelineState{
+		latestTaskRuns:  ps.latestTaskRuns,
+		rolledBackTasks: map[int]bool{2: true},
+		haltedStages:    map[int]bool{},
+	}
+	a.False(ps.isHaltedByPostDeployCheck(103))
+	a.True(ps.isHaltedByPostDeployCheck(104))
+}
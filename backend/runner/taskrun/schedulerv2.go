@@ -2,6 +2,7 @@ package taskrun
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -9,21 +10,28 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/deploymentwindow"
+	"github.com/bytebase/bytebase/backend/component/rolloutstrategy"
 	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
 const (
 	taskSchedulerInterval = 5 * time.Second
+	// productionSafetyCheckTTL is the age after which the production safety check is re-run before the task is rolled out automatically.
+	productionSafetyCheckTTL = 10 * time.Minute
 )
 
 // SchedulerV2 is the V2 scheduler for task run.
@@ -31,15 +39,21 @@ type SchedulerV2 struct {
 	store           *store.Store
 	stateCfg        *state.State
 	activityManager *activity.Manager
+	dbFactory       *dbfactory.DBFactory
 	executorMap     map[api.TaskType]Executor
+	// runningPostDeployChecks are the IDs of the task runs whose post-deployment checks are running.
+	runningPostDeployChecks sync.Map
+	// lastTaskRunLogPurgeTime is the last time the outdated task run logs are deleted.
+	lastTaskRunLogPurgeTime time.Time
 }
 
 // NewSchedulerV2 will create a new scheduler.
-func NewSchedulerV2(store *store.Store, stateCfg *state.State, activityManager *activity.Manager) *SchedulerV2 {
+func NewSchedulerV2(store *store.Store, stateCfg *state.State, activityManager *activity.Manager, dbFactory *dbfactory.DBFactory) *SchedulerV2 {
 	return &SchedulerV2{
 		store:           store,
 		stateCfg:        stateCfg,
 		activityManager: activityManager,
+		dbFactory:       dbFactory,
 		executorMap:     map[api.TaskType]Executor{},
 	}
 }
@@ -58,6 +72,7 @@ func (s *SchedulerV2) Register(taskType api.TaskType, executorGetter Executor) {
 // Run will start the scheduler.
 func (s *SchedulerV2) Run(ctx context.Context, wg *sync.WaitGroup) {
 	go s.ListenTaskSkippedOrDone(ctx)
+	go s.ListenPGOSCCleanup(ctx)
 
 	ticker := time.NewTicker(taskSchedulerInterval)
 	defer ticker.Stop()
@@ -97,6 +112,14 @@ func (s *SchedulerV2) runOnce(ctx context.Context) {
 	if err := s.scheduleRunningTaskRuns(ctx); err != nil {
 		slog.Error("failed to schedule running task runs", log.BBError(err))
 	}
+
+	if err := s.schedulePostDeployChecks(ctx); err != nil {
+		slog.Error("failed to schedule post-deployment checks", log.BBError(err))
+	}
+
+	if err := s.purgeTaskRunLogs(ctx); err != nil {
+		slog.Error("failed to purge task run logs", log.BBError(err))
+	}
 }
 
 func (s *SchedulerV2) scheduleAutoRolloutTasks(ctx context.Context) error {
@@ -173,33 +196,26 @@ func (s *SchedulerV2) scheduleAutoRolloutTask(ctx context.Context, taskUID int)
 		if err != nil {
 			return false, errors.Wrapf(err, "failed to list plan check runs")
 		}
-		type key struct {
-			instanceUID  int
-			databaseName string
-			checkType    store.PlanCheckRunType
-		}
-		latestRun := map[key]*store.PlanCheckRunMessage{}
-		for _, run := range planCheckRuns {
-			k := key{
-				instanceUID:  int(run.Config.InstanceUid),
-				databaseName: run.Config.DatabaseName,
-				checkType:    run.Type,
-			}
-			if latest, ok := latestRun[k]; !ok || latest.UID < run.UID {
-				latestRun[k] = run
-			}
-		}
-		for _, run := range latestRun {
-			if run.Status != store.PlanCheckRunStatusDone {
-				return false, nil
+		pass, staleRuns := checkPlanCheckRuns(planCheckRuns, task, time.Now())
+		if len(staleRuns) > 0 {
+			var creates []*store.PlanCheckRunMessage
+			for _, run := range staleRuns {
+				creates = append(creates, &store.PlanCheckRunMessage{
+					CreatorUID: api.SystemBotID,
+					UpdaterUID: api.SystemBotID,
+					PlanUID:    run.PlanUID,
+					Status:     store.PlanCheckRunStatusRunning,
+					Type:       run.Type,
+					Config:     run.Config,
+				})
 			}
-			for _, result := range run.Result.Results {
-				if result.Status != storepb.PlanCheckRunResult_Result_SUCCESS {
-					return false, nil
-				}
+			if err := s.store.CreatePlanCheckRuns(ctx, creates...); err != nil {
+				return false, errors.Wrapf(err, "failed to create plan check runs")
 			}
+			s.stateCfg.PlanCheckTickleChan <- 0
+			return false, nil
 		}
-		return true, nil
+		return pass, nil
 	}()
 	if err != nil {
 		return errors.Wrapf(err, "failed to check if plan check passes")
@@ -225,6 +241,56 @@ func (s *SchedulerV2) scheduleAutoRolloutTask(ctx context.Context, taskUID int)
 	return nil
 }
 
+// checkPlanCheckRuns returns true if the latest plan check runs pass for the task.
+// The production safety check reports the live state of the database, so only the check of the task database applies,
+// and it's returned to re-run if it was done longer than productionSafetyCheckTTL ago.
+func checkPlanCheckRuns(planCheckRuns []*store.PlanCheckRunMessage, task *store.TaskMessage, now time.Time) (bool, []*store.PlanCheckRunMessage) {
+	type key struct {
+		instanceUID  int
+		databaseName string
+		checkType    store.PlanCheckRunType
+	}
+	latestRun := map[key]*store.PlanCheckRunMessage{}
+	for _, run := range planCheckRuns {
+		k := key{
+			instanceUID:  int(run.Config.InstanceUid),
+			databaseName: run.Config.DatabaseName,
+			checkType:    run.Type,
+		}
+		if latest, ok := latestRun[k]; !ok || latest.UID < run.UID {
+			latestRun[k] = run
+		}
+	}
+	pass := true
+	var staleRuns []*store.PlanCheckRunMessage
+	for k, run := range latestRun {
+		// The deployment window is enforced when the task runs are scheduled.
+		if run.Type == store.PlanCheckDatabaseDeploymentWindow {
+			continue
+		}
+		if run.Type == store.PlanCheckDatabaseProductionSafety {
+			if k.instanceUID != task.InstanceID || k.databaseName != task.DatabaseName {
+				continue
+			}
+			if run.Status == store.PlanCheckRunStatusDone && now.Sub(time.Unix(run.UpdatedTs, 0)) > productionSafetyCheckTTL {
+				staleRuns = append(staleRuns, run)
+				pass = false
+				continue
+			}
+		}
+		if run.Status != store.PlanCheckRunStatusDone {
+			pass = false
+			continue
+		}
+		for _, result := range run.Result.Results {
+			if result.Status != storepb.PlanCheckRunResult_Result_SUCCESS {
+				pass = false
+			}
+		}
+	}
+	return pass, staleRuns
+}
+
 func (s *SchedulerV2) schedulePendingTaskRuns(ctx context.Context) error {
 	taskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{
 		Status: &[]api.TaskRunStatus{api.TaskRunPending},
@@ -232,8 +298,10 @@ func (s *SchedulerV2) schedulePendingTaskRuns(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrapf(err, "failed to list pending tasks")
 	}
+	// The pipelines are loaded once per tick for their pending task runs.
+	pipelineStates := map[int]*pipelineState{}
 	for _, taskRun := range taskRuns {
-		if err := s.schedulePendingTaskRun(ctx, taskRun); err != nil {
+		if err := s.schedulePendingTaskRun(ctx, taskRun, pipelineStates); err != nil {
 			slog.Error("failed to schedule pending task run", log.BBError(err))
 		}
 	}
@@ -241,7 +309,7 @@ func (s *SchedulerV2) schedulePendingTaskRuns(ctx context.Context) error {
 	return nil
 }
 
-func (s *SchedulerV2) schedulePendingTaskRun(ctx context.Context, taskRun *store.TaskRunMessage) error {
+func (s *SchedulerV2) schedulePendingTaskRun(ctx context.Context, taskRun *store.TaskRunMessage, pipelineStates map[int]*pipelineState) error {
 	task, err := s.store.GetTaskV2ByID(ctx, taskRun.TaskUID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get task")
@@ -249,6 +317,17 @@ func (s *SchedulerV2) schedulePendingTaskRun(ctx context.Context, taskRun *store
 	if task.EarliestAllowedTs != 0 && time.Now().Before(time.Unix(task.EarliestAllowedTs, 0)) {
 		return nil
 	}
+	stage, err := s.getTaskStage(ctx, task)
+	if err != nil {
+		return err
+	}
+	allowed, err := s.isDeploymentWindowAllowed(ctx, task, taskRun, stage)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check deployment window")
+	}
+	if !allowed {
+		return nil
+	}
 	for _, blockingTaskUID := range task.BlockedBy {
 		blockingTask, err := s.store.GetTaskV2ByID(ctx, blockingTaskUID)
 		if err != nil {
@@ -269,6 +348,20 @@ func (s *SchedulerV2) schedulePendingTaskRun(ctx context.Context, taskRun *store
 			return nil
 		}
 	}
+	ps, err := s.getPipelineState(ctx, task.PipelineID, pipelineStates)
+	if err != nil {
+		return err
+	}
+	allowed, err = ps.isRolloutStrategyAllowed(task, stage.Payload.GetRolloutStrategy())
+	if err != nil {
+		return errors.Wrapf(err, "failed to check rollout strategy")
+	}
+	if !allowed {
+		return nil
+	}
+	if ps.isHaltedByPostDeployCheck(task.StageID) {
+		return nil
+	}
 
 	if _, err := s.store.UpdateTaskRunStatus(ctx, &store.TaskRunStatusPatch{
 		ID:        taskRun.ID,
@@ -277,9 +370,295 @@ func (s *SchedulerV2) schedulePendingTaskRun(ctx context.Context, taskRun *store
 	}); err != nil {
 		return errors.Wrapf(err, "failed to update task run status to running")
 	}
+	ps.setTaskRunning(task)
 	return nil
 }
 
+// isDeploymentWindowAllowed returns whether the deployment window policy of the task environment allows the task to run now.
+// The task runs outside the deployment window stay pending until the next window unless the window is overridden for the task run.
+func (s *SchedulerV2) isDeploymentWindowAllowed(ctx context.Context, task *store.TaskMessage, taskRun *store.TaskRunMessage, stage *store.StageMessage) (bool, error) {
+	override := struct {
+		DeploymentWindowOverrideTaskRun int `json:"deploymentWindowOverrideTaskRun"`
+	}{}
+	if err := json.Unmarshal([]byte(task.Payload), &override); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal payload")
+	}
+	if override.DeploymentWindowOverrideTaskRun == taskRun.ID {
+		return true, nil
+	}
+
+	policy, err := s.store.GetDeploymentWindowPolicy(ctx, stage.EnvironmentID)
+	if err != nil {
+		return false, err
+	}
+	block, err := deploymentwindow.Check(policy, time.Now())
+	if err != nil {
+		return false, err
+	}
+	return block == nil, nil
+}
+
+// isRolloutStrategyAllowed returns whether the rollout strategy of the stage allows the task to run now.
+// The task runs stay pending until the previous waves finish, the databases rolling out are under the concurrency limit,
+// and the failed databases are under the failure threshold.
+func (ps *pipelineState) isRolloutStrategyAllowed(task *store.TaskMessage, strategy *storepb.RolloutStrategy) (bool, error) {
+	if strategy == nil {
+		return true, nil
+	}
+	// The tasks rolling back the changes of the stage are not rolled out by the strategy.
+	if _, ok := ps.rollbackTasks[task.ID]; ok {
+		return true, nil
+	}
+	rollout, err := ps.getStageRollout(task.StageID, strategy)
+	if err != nil {
+		return false, err
+	}
+	index, ok := rollout.indexes[task.ID]
+	if !ok {
+		return false, errors.Errorf("task %d not found in stage %d", task.ID, task.StageID)
+	}
+	statuses := slices.Clone(rollout.statuses)
+	// The pending task itself hasn't started yet.
+	statuses[index] = rolloutstrategy.TaskPending
+	if reason := rolloutstrategy.Check(strategy, statuses, index); reason != "" {
+		slog.Debug("task run is held by the rollout strategy", slog.Int("task", task.ID), slog.String("reason", reason))
+		return false, nil
+	}
+	return true, nil
+}
+
+// stageRollout is the rollout status of the databases of a stage.
+type stageRollout struct {
+	// statuses are the statuses of the databases ordered by their first tasks.
+	statuses []rolloutstrategy.TaskStatus
+	// indexes are the indexes of the task databases in statuses.
+	indexes map[int]int
+}
+
+// getStageRollout returns the rollout status of the databases of the stage, and computes it on the first call in the tick.
+// The tasks without a database are rolled out on their own.
+// The done tasks failing the verification count as failed until their changes are rolled back.
+func (ps *pipelineState) getStageRollout(stageID int, strategy *storepb.RolloutStrategy) (*stageRollout, error) {
+	if rollout, ok := ps.stageRollouts[stageID]; ok {
+		return rollout, nil
+	}
+	type target struct {
+		databaseID int
+		taskID     int
+	}
+	rollout := &stageRollout{indexes: map[int]int{}}
+	targetIndexes := map[target]int{}
+	var targetStatuses [][]rolloutstrategy.TaskStatus
+	for _, t := range ps.tasks {
+		if t.StageID != stageID {
+			continue
+		}
+		if _, ok := ps.rollbackTasks[t.ID]; ok {
+			continue
+		}
+		key := target{taskID: t.ID}
+		if t.DatabaseID != nil {
+			key = target{databaseID: *t.DatabaseID}
+		}
+		index, ok := targetIndexes[key]
+		if !ok {
+			index = len(targetStatuses)
+			targetIndexes[key] = index
+			targetStatuses = append(targetStatuses, nil)
+		}
+		rollout.indexes[t.ID] = index
+
+		skipped, err := utils.GetTaskSkipped(t)
+		if err != nil {
+			return nil, err
+		}
+		var status rolloutstrategy.TaskStatus
+		switch {
+		case strategy.VerificationStatement != "" && ps.isUnhealthy(t.ID):
+			status = rolloutstrategy.TaskFailed
+		case skipped, t.LatestTaskRunStatus == api.TaskRunDone:
+			status = rolloutstrategy.TaskDone
+		case t.LatestTaskRunStatus == api.TaskRunRunning:
+			status = rolloutstrategy.TaskRunning
+		case t.LatestTaskRunStatus == api.TaskRunFailed:
+			status = rolloutstrategy.TaskFailed
+		default:
+			status = rolloutstrategy.TaskPending
+		}
+		targetStatuses[index] = append(targetStatuses[index], status)
+	}
+	for _, statuses := range targetStatuses {
+		rollout.statuses = append(rollout.statuses, rolloutstrategy.MergeStatuses(statuses))
+	}
+	ps.stageRollouts[stageID] = rollout
+	return rollout, nil
+}
+
+// isUnhealthy returns whether the done task fails the verification of the rollout strategy and isn't rolled back.
+func (ps *pipelineState) isUnhealthy(taskID int) bool {
+	taskRun, ok := ps.latestTaskRuns[taskID]
+	if !ok {
+		return false
+	}
+	return taskRun.Status == api.TaskRunDone && taskRun.ResultProto.GetRolloutVerification().GetStatus() == storepb.TaskRunResult_CheckResult_FAILED && !ps.rolledBackTasks[taskID]
+}
+
+// setTaskRunning records the started task in the stage rollout computed in the tick.
+func (ps *pipelineState) setTaskRunning(task *store.TaskMessage) {
+	rollout, ok := ps.stageRollouts[task.StageID]
+	if !ok {
+		return
+	}
+	if index, ok := rollout.indexes[task.ID]; ok {
+		rollout.statuses[index] = rolloutstrategy.TaskRunning
+	}
+}
+
+// verifyTask runs the verification statement of the stage rollout strategy on the task database, and returns the verification result.
+// It returns nil if the stage doesn't verify the task.
+// The sync tasks of the online schema change are verified by their cutover tasks.
+func (s *SchedulerV2) verifyTask(ctx context.Context, task *store.TaskMessage) *storepb.TaskRunResult_CheckResult {
+	if task.Type == api.TaskDatabaseSchemaUpdateGhostSync || task.Type == api.TaskDatabaseSchemaUpdatePGOSCSync {
+		return nil
+	}
+	if task.DatabaseID == nil {
+		return nil
+	}
+	stage, err := s.getTaskStage(ctx, task)
+	if err != nil {
+		return getFailedCheckResult(err)
+	}
+	statement := stage.Payload.GetRolloutStrategy().GetVerificationStatement()
+	if statement == "" {
+		return nil
+	}
+	instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return getFailedCheckResult(err)
+	}
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return getFailedCheckResult(err)
+	}
+	if instance == nil || database == nil {
+		return getFailedCheckResult(errors.Errorf("database %d not found for task %d", *task.DatabaseID, task.ID))
+	}
+	rows, err := s.queryVerificationStatement(ctx, instance, database, statement)
+	if err != nil {
+		return getFailedCheckResult(errors.Wrapf(err, "the verification statement failed"))
+	}
+	if len(rows) == 0 {
+		return getFailedCheckResult(errors.Errorf("the verification statement returned no rows"))
+	}
+	return &storepb.TaskRunResult_CheckResult{Status: storepb.TaskRunResult_CheckResult_PASSED}
+}
+
+func getFailedCheckResult(err error) *storepb.TaskRunResult_CheckResult {
+	return &storepb.TaskRunResult_CheckResult{
+		Status: storepb.TaskRunResult_CheckResult_FAILED,
+		Detail: err.Error(),
+	}
+}
+
+// getLatestTaskRuns returns the latest task run of each task.
+func getLatestTaskRuns(taskRuns []*store.TaskRunMessage) map[int]*store.TaskRunMessage {
+	latestTaskRuns := map[int]*store.TaskRunMessage{}
+	for _, taskRun := range taskRuns {
+		if latest, ok := latestTaskRuns[taskRun.TaskUID]; !ok || latest.ID < taskRun.ID {
+			latestTaskRuns[taskRun.TaskUID] = taskRun
+		}
+	}
+	return latestTaskRuns
+}
+
+// pipelineState is the tasks and the latest task runs of a pipeline.
+// It's loaded once per scheduling tick, and shared by the pending task runs of the pipeline.
+type pipelineState struct {
+	tasks           []*store.TaskMessage
+	latestTaskRuns  map[int]*store.TaskRunMessage
+	rollbackTasks   map[int]int
+	rolledBackTasks map[int]bool
+	// haltedStages caches whether the post-deployment checks halt the stages.
+	haltedStages map[int]bool
+	// stageRollouts caches the rollout status of the stages with the rollout strategy.
+	stageRollouts map[int]*stageRollout
+}
+
+// getPipelineState returns the state of the pipeline loaded in the tick, and loads it on the first call.
+func (s *SchedulerV2) getPipelineState(ctx context.Context, pipelineID int, pipelineStates map[int]*pipelineState) (*pipelineState, error) {
+	if ps, ok := pipelineStates[pipelineID]; ok {
+		return ps, nil
+	}
+	tasks, err := s.store.ListTasks(ctx, &api.TaskFind{PipelineID: &pipelineID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list tasks")
+	}
+	taskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{PipelineUID: &pipelineID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list task runs")
+	}
+	// The tasks roll back the tasks in their own stages.
+	rollbackTasks, err := getStageRollbackTasks(tasks)
+	if err != nil {
+		return nil, err
+	}
+	ps := &pipelineState{
+		tasks:           tasks,
+		latestTaskRuns:  getLatestTaskRuns(taskRuns),
+		rollbackTasks:   rollbackTasks,
+		rolledBackTasks: getRolledBackTasks(tasks, rollbackTasks),
+		haltedStages:    map[int]bool{},
+		stageRollouts:   map[int]*stageRollout{},
+	}
+	pipelineStates[pipelineID] = ps
+	return ps, nil
+}
+
+// queryVerificationStatement runs the verification statement on the database read-only, and returns the rows of the last result limited to one row.
+func (s *SchedulerV2) queryVerificationStatement(ctx context.Context, instance *store.InstanceMessage, database *store.DatabaseMessage, statement string) ([]*v1pb.QueryRow, error) {
+	driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, database, "" /* dataSourceID */)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+
+	sqlDB := driver.GetDB()
+	var conn *sql.Conn
+	if sqlDB != nil {
+		conn, err = sqlDB.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+	}
+	results, err := driver.QueryConn(ctx, conn, statement, &db.QueryContext{ReadOnly: true, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if result.Error != "" {
+			return nil, errors.New(result.Error)
+		}
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+	return results[len(results)-1].Rows, nil
+}
+
+func (s *SchedulerV2) getTaskStage(ctx context.Context, task *store.TaskMessage) (*store.StageMessage, error) {
+	stages, err := s.store.ListStageV2(ctx, task.PipelineID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list stages")
+	}
+	for _, stage := range stages {
+		if stage.ID == task.StageID {
+			return stage, nil
+		}
+	}
+	return nil, errors.Errorf("stage %d not found for task %d", task.StageID, task.ID)
+}
+
 func (s *SchedulerV2) scheduleRunningTaskRuns(ctx context.Context) error {
 	taskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{
 		Status: &[]api.TaskRunStatus{api.TaskRunRunning},
@@ -365,6 +744,13 @@ func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRun
 	s.stateCfg.RunningTaskRunsCancelFunc.Store(taskRun.ID, cancel)
 
 	done, result, err := RunExecutorOnce(ctx, driverCtx, executor, task, taskRun.ID)
+	var verification, postDeployCheck *storepb.TaskRunResult_CheckResult
+	if done && err == nil {
+		// The task run is done even if the database is unhealthy after the change, because the applied change can't run again.
+		// The failed verification holds the stage rollout, and the post-deployment check halts the later stages instead.
+		verification = s.verifyTask(driverCtx, task)
+		postDeployCheck = s.startPostDeployCheck(driverCtx, task)
+	}
 
 	if !done && err != nil {
 		slog.Debug("Encountered transient error running task, will retry",
@@ -383,11 +769,16 @@ func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRun
 			slog.String("type", string(task.Type)),
 			log.BBError(err),
 		)
-		resultBytes, marshalErr := protojson.Marshal(&storepb.TaskRunResult{
+		taskRunResult := &storepb.TaskRunResult{
 			Detail:        "The task run is canceled",
 			ChangeHistory: "",
 			Version:       "",
-		})
+		}
+		var errWithStatements *statementExecutionError
+		if errors.As(err, &errWithStatements) {
+			taskRunResult.StatementResults = errWithStatements.results
+		}
+		resultBytes, marshalErr := protojson.Marshal(taskRunResult)
 		if marshalErr != nil {
 			slog.Error("Failed to marshal task run result",
 				slog.Int("task_id", task.ID),
@@ -436,6 +827,12 @@ func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRun
 			taskRunResult.StartPosition = errWithPosition.Start
 			taskRunResult.EndPosition = errWithPosition.End
 		}
+		var errWithStatements *statementExecutionError
+		if errors.As(err, &errWithStatements) {
+			taskRunResult.StartPosition = errWithStatements.failed.StartPosition
+			taskRunResult.EndPosition = errWithStatements.failed.EndPosition
+			taskRunResult.StatementResults = errWithStatements.results
+		}
 
 		resultBytes, marshalErr := protojson.Marshal(taskRunResult)
 		if marshalErr != nil {
@@ -470,9 +867,13 @@ func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRun
 
 	if done && err == nil {
 		resultBytes, marshalErr := protojson.Marshal(&storepb.TaskRunResult{
-			Detail:        result.Detail,
-			ChangeHistory: result.ChangeHistory,
-			Version:       result.Version,
+			Detail:           result.Detail,
+			ChangeHistory:    result.ChangeHistory,
+			Version:          result.Version,
+			StatementResults: result.StatementResults,
+
+			RolloutVerification: verification,
+			PostDeployCheck:     postDeployCheck,
 		})
 		if marshalErr != nil {
 			slog.Error("Failed to marshal task run result",
@@ -0,0 +1,101 @@
+package taskrun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestIsRolloutStrategyAllowed(t *testing.T) {
+	a := require.New(t)
+	db1, db2, db3 := 1, 2, 3
+	// The online schema changes create the sync and the cutover tasks for each database.
+	tasks := []*store.TaskMessage{
+		{ID: 1, StageID: 101, DatabaseID: &db1, Payload: "{}", LatestTaskRunStatus: api.TaskRunDone},
+		{ID: 2, StageID: 101, DatabaseID: &db1, Payload: "{}", LatestTaskRunStatus: api.TaskRunPending},
+		{ID: 3, StageID: 101, DatabaseID: &db2, Payload: "{}", LatestTaskRunStatus: api.TaskRunPending},
+		{ID: 4, StageID: 101, DatabaseID: &db2, Payload: "{}", LatestTaskRunStatus: api.TaskRunNotStarted},
+		{ID: 5, StageID: 101, DatabaseID: &db3, Payload: "{}", LatestTaskRunStatus: api.TaskRunPending},
+		{ID: 6, StageID: 101, DatabaseID: &db3, Payload: "{}", LatestTaskRunStatus: api.TaskRunNotStarted},
+	}
+	newPipelineState := func() *pipelineState {
+		return &pipelineState{
+			tasks:          tasks,
+			latestTaskRuns: map[int]*store.TaskRunMessage{},
+			rollbackTasks:  map[int]int{},
+			haltedStages:   map[int]bool{},
+			stageRollouts:  map[int]*stageRollout{},
+		}
+	}
+
+	// The canary is the first database rather than the first task.
+	strategy := &storepb.RolloutStrategy{CanaryCount: 1}
+	ps := newPipelineState()
+	allowed, err := ps.isRolloutStrategyAllowed(tasks[1], strategy)
+	a.NoError(err)
+	a.True(allowed)
+	allowed, err = ps.isRolloutStrategyAllowed(tasks[2], strategy)
+	a.NoError(err)
+	a.False(allowed)
+	a.Len(ps.stageRollouts[101].statuses, 3)
+
+	// The database with the done sync task and the pending cutover task is rolling out.
+	strategy = &storepb.RolloutStrategy{MaxConcurrency: 2}
+	ps = newPipelineState()
+	allowed, err = ps.isRolloutStrategyAllowed(tasks[2], strategy)
+	a.NoError(err)
+	a.True(allowed)
+	// The started task is counted in the same tick.
+	ps.setTaskRunning(tasks[2])
+	allowed, err = ps.isRolloutStrategyAllowed(tasks[4], strategy)
+	a.NoError(err)
+	a.False(allowed)
+}
+
+func TestCheckPlanCheckRuns(t *testing.T) {
+	a := require.New(t)
+	now := time.Unix(1700000000, 0)
+	task := &store.TaskMessage{InstanceID: 1, DatabaseName: "db1"}
+	newRun := func(uid int, checkType store.PlanCheckRunType, databaseName string, status storepb.PlanCheckRunResult_Result_Status, updated time.Time) *store.PlanCheckRunMessage {
+		return &store.PlanCheckRunMessage{
+			UID:       uid,
+			Type:      checkType,
+			Status:    store.PlanCheckRunStatusDone,
+			UpdatedTs: updated.Unix(),
+			Config:    &storepb.PlanCheckRunConfig{InstanceUid: 1, DatabaseName: databaseName},
+			Result:    &storepb.PlanCheckRunResult{Results: []*storepb.PlanCheckRunResult_Result{{Status: status}}},
+		}
+	}
+	success, failed := storepb.PlanCheckRunResult_Result_SUCCESS, storepb.PlanCheckRunResult_Result_ERROR
+
+	// The latest run of each check applies.
+	pass, stale := checkPlanCheckRuns([]*store.PlanCheckRunMessage{
+		newRun(1, store.PlanCheckDatabaseStatementAdvise, "db1", failed, now),
+		newRun(2, store.PlanCheckDatabaseStatementAdvise, "db1", success, now),
+		newRun(3, store.PlanCheckDatabaseProductionSafety, "db1", success, now.Add(-time.Minute)),
+	}, task, now)
+	a.True(pass)
+	a.Empty(stale)
+
+	// The production safety check of the other databases doesn't apply.
+	pass, stale = checkPlanCheckRuns([]*store.PlanCheckRunMessage{
+		newRun(1, store.PlanCheckDatabaseStatementAdvise, "db2", failed, now),
+		newRun(2, store.PlanCheckDatabaseProductionSafety, "db2", failed, now.Add(-time.Hour)),
+	}, task, now)
+	a.False(pass)
+	a.Empty(stale)
+
+	// The outdated production safety check is re-run even if it passed.
+	pass, stale = checkPlanCheckRuns([]*store.PlanCheckRunMessage{
+		newRun(1, store.PlanCheckDatabaseProductionSafety, "db1", success, now.Add(-time.Hour)),
+		newRun(2, store.PlanCheckDatabaseProductionSafety, "db2", success, now.Add(-time.Hour)),
+	}, task, now)
+	a.False(pass)
+	a.Len(stale, 1)
+	a.Equal(1, stale[0].UID)
+}
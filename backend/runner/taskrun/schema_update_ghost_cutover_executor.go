@@ -11,6 +11,7 @@ import (
 
 	"github.com/github/gh-ost/go/base"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
@@ -54,8 +55,9 @@ type SchemaUpdateGhostCutoverExecutor struct {
 }
 
 // RunOnce will run SchemaUpdateGhostCutover task once.
-// TODO: support cancellation.
-func (e *SchemaUpdateGhostCutoverExecutor) RunOnce(ctx context.Context, _ context.Context, task *store.TaskMessage, taskRunUID int) (bool, *api.TaskRunResultPayload, error) {
+// If the gh-ost flags specify a cutover window, the cutover is postponed until the window opens.
+// TODO: support cancellation after the cutover starts.
+func (e *SchemaUpdateGhostCutoverExecutor) RunOnce(ctx context.Context, driverCtx context.Context, task *store.TaskMessage, taskRunUID int) (bool, *api.TaskRunResultPayload, error) {
 	e.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
 		state.TaskRunExecutionStatus{
 			ExecutionStatus: v1pb.TaskRun_PRE_EXECUTING,
@@ -66,7 +68,6 @@ func (e *SchemaUpdateGhostCutoverExecutor) RunOnce(ctx context.Context, _ contex
 		return true, nil, errors.Errorf("failed to find task dag for ToTask %v", task.ID)
 	}
 	syncTaskID := task.BlockedBy[0]
-	defer e.stateCfg.GhostTaskState.Delete(syncTaskID)
 
 	instance, err := e.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
 	if err != nil {
@@ -109,6 +110,18 @@ func (e *SchemaUpdateGhostCutoverExecutor) RunOnce(ctx context.Context, _ contex
 		return true, nil, errors.Errorf("failed to convert shared gh-ost state")
 	}
 
+	cutoverWindow, err := ghost.GetCutoverWindow(payload.Flags)
+	if err != nil {
+		return true, nil, err
+	}
+	if cutoverWindow != nil {
+		// Keep the gh-ost state if the waiting is canceled so that the cutover task can be rerun.
+		if err := waitForCutoverWindow(driverCtx, e.stateCfg, taskRunUID, cutoverWindow, sharedGhost.migrationContext); err != nil {
+			return true, nil, err
+		}
+	}
+	defer e.stateCfg.GhostTaskState.Delete(syncTaskID)
+
 	// not using the rendered statement here because we want to avoid leaking the rendered statement
 	version := model.Version{Version: payload.SchemaVersion}
 	terminated, result, err := cutover(ctx, e.store, e.dbFactory, e.activityManager, e.stateCfg, e.license, e.profile, task, taskRunUID, statement, payload.SheetID, version, postponeFilename, sharedGhost.migrationContext, sharedGhost.errCh)
@@ -167,6 +180,38 @@ func cutover(ctx context.Context, stores *store.Store, dbFactory *dbfactory.DBFa
 	return postMigration(ctx, stores, activityManager, license, task, mi, migrationID, schema, &sheetID)
 }
 
+func waitForCutoverWindow(ctx context.Context, stateCfg *state.State, taskRunUID int, cutoverWindow *ghost.CutoverWindow, migrationContext *base.MigrationContext) error {
+	if !cutoverWindow.Contains(time.Now()) {
+		slog.Info("gh-ost cutover is postponed until the cutover window opens",
+			slog.Int("taskRun", taskRunUID),
+			slog.String("cutoverWindow", cutoverWindow.String()),
+		)
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		if cutoverWindow.Contains(now) {
+			return nil
+		}
+		progress := ghost.GetProgress(migrationContext)
+		progress.CutoverPostponedUntil = timestamppb.New(cutoverWindow.NextStart(now))
+		stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
+			state.TaskRunExecutionStatus{
+				ExecutionStatus: v1pb.TaskRun_PRE_EXECUTING,
+				ExecutionDetail: &v1pb.TaskRun_ExecutionDetail{
+					GhostProgress: progress,
+				},
+				UpdateTime: now,
+			})
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "task canceled while waiting for the cutover window")
+		}
+	}
+}
+
 func waitForCutover(ctx context.Context, migrationContext *base.MigrationContext) bool {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
@@ -22,6 +22,9 @@ import (
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
+// ghostStatusInterval is the interval of recording the gh-ost status line in the task run log.
+const ghostStatusInterval = 30 * time.Second
+
 // NewSchemaUpdateGhostSyncExecutor creates a schema update (gh-ost) sync task executor.
 func NewSchemaUpdateGhostSyncExecutor(store *store.Store, stateCfg *state.State, secret string) Executor {
 	return &SchemaUpdateGhostSyncExecutor{
@@ -56,7 +59,7 @@ func (exec *SchemaUpdateGhostSyncExecutor) RunOnce(ctx context.Context, _ contex
 		return true, nil, err
 	}
 
-	return exec.runGhostMigration(ctx, task, statement, payload.Flags)
+	return exec.runGhostMigration(ctx, task, taskRunUID, statement, payload.Flags)
 }
 
 type sharedGhostState struct {
@@ -64,7 +67,7 @@ type sharedGhostState struct {
 	errCh            <-chan error
 }
 
-func (exec *SchemaUpdateGhostSyncExecutor) runGhostMigration(ctx context.Context, task *store.TaskMessage, statement string, flags map[string]string) (terminated bool, result *api.TaskRunResultPayload, err error) {
+func (exec *SchemaUpdateGhostSyncExecutor) runGhostMigration(ctx context.Context, task *store.TaskMessage, taskRunUID int, statement string, flags map[string]string) (terminated bool, result *api.TaskRunResultPayload, err error) {
 	syncDone := make(chan struct{})
 	// set buffer size to 1 to unblock the sender because there is no listner if the task is canceled.
 	// see PR #2919.
@@ -107,6 +110,8 @@ func (exec *SchemaUpdateGhostSyncExecutor) runGhostMigration(ctx context.Context
 	}
 
 	migrator := logic.NewMigrator(migrationContext, "bb")
+	logger := newTaskRunLogger(exec.store, taskRunUID, database)
+	defer logger.close()
 
 	childCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -114,6 +119,7 @@ func (exec *SchemaUpdateGhostSyncExecutor) runGhostMigration(ctx context.Context
 		ticker := time.NewTicker(1 * time.Second)
 		defer ticker.Stop()
 		createdTs := time.Now().Unix()
+		var lastStatusTime time.Time
 		for {
 			select {
 			case <-ticker.C:
@@ -128,6 +134,19 @@ func (exec *SchemaUpdateGhostSyncExecutor) runGhostMigration(ctx context.Context
 					CreatedTs:     createdTs,
 					UpdatedTs:     updatedTs,
 				})
+				progress := ghost.GetProgress(migrationContext)
+				exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
+					state.TaskRunExecutionStatus{
+						ExecutionStatus: v1pb.TaskRun_EXECUTING,
+						ExecutionDetail: &v1pb.TaskRun_ExecutionDetail{
+							GhostProgress: progress,
+						},
+						UpdateTime: time.Now(),
+					})
+				if time.Since(lastStatusTime) >= ghostStatusInterval {
+					logger.ghostStatus(ghost.GetStatusLine(progress))
+					lastStatusTime = time.Now()
+				}
 				// Since we are using postpone flag file to postpone cutover, it's gh-ost mechanism to set migrationContext.IsPostponingCutOver to 1 after synced and before postpone flag file is removed. We utilize this mechanism here to check if synced.
 				if atomic.LoadInt64(&migrationContext.IsPostponingCutOver) > 0 {
 					close(syncDone)
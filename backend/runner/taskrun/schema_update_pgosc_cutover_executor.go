@@ -0,0 +1,125 @@
+package taskrun
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/activity"
+	"github.com/bytebase/bytebase/backend/component/config"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
+	"github.com/bytebase/bytebase/backend/component/state"
+	enterprise "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/runner/schemasync"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/store/model"
+	"github.com/bytebase/bytebase/backend/utils"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// NewSchemaUpdatePGOSCCutoverExecutor creates a schema update (PostgreSQL online schema change) cutover task executor.
+func NewSchemaUpdatePGOSCCutoverExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, activityManager *activity.Manager, license enterprise.LicenseService, stateCfg *state.State, schemaSyncer *schemasync.Syncer, profile config.Profile) Executor {
+	return &SchemaUpdatePGOSCCutoverExecutor{
+		store:           store,
+		dbFactory:       dbFactory,
+		activityManager: activityManager,
+		license:         license,
+		stateCfg:        stateCfg,
+		schemaSyncer:    schemaSyncer,
+		profile:         profile,
+	}
+}
+
+// SchemaUpdatePGOSCCutoverExecutor is the schema update (PostgreSQL online schema change) cutover task executor.
+// It replaces the original table with the shadow table synced by the sync task and records the migration history.
+type SchemaUpdatePGOSCCutoverExecutor struct {
+	store           *store.Store
+	dbFactory       *dbfactory.DBFactory
+	activityManager *activity.Manager
+	license         enterprise.LicenseService
+	stateCfg        *state.State
+	schemaSyncer    *schemasync.Syncer
+	profile         config.Profile
+}
+
+// RunOnce will run SchemaUpdatePGOSCCutover task once.
+func (e *SchemaUpdatePGOSCCutoverExecutor) RunOnce(ctx context.Context, driverCtx context.Context, task *store.TaskMessage, taskRunUID int) (bool, *api.TaskRunResultPayload, error) {
+	e.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
+		state.TaskRunExecutionStatus{
+			ExecutionStatus: v1pb.TaskRun_PRE_EXECUTING,
+			UpdateTime:      time.Now(),
+		})
+
+	if len(task.BlockedBy) != 1 {
+		return true, nil, errors.Errorf("failed to find task dag for ToTask %v", task.ID)
+	}
+	syncTaskID := task.BlockedBy[0]
+	defer e.stateCfg.TaskProgress.Delete(syncTaskID)
+
+	instance, err := e.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return true, nil, err
+	}
+	database, err := e.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return true, nil, err
+	}
+
+	syncTask, err := e.store.GetTaskV2ByID(ctx, syncTaskID)
+	if err != nil {
+		return true, nil, errors.Wrap(err, "failed to get schema update online schema change sync task for cutover task")
+	}
+	payload := &api.TaskDatabaseSchemaUpdatePGOSCSyncPayload{}
+	if err := json.Unmarshal([]byte(syncTask.Payload), payload); err != nil {
+		return true, nil, errors.Wrap(err, "invalid database schema update online schema change sync payload")
+	}
+	statement, err := e.store.GetSheetStatementByID(ctx, payload.SheetID)
+	if err != nil {
+		return true, nil, errors.Wrapf(err, "failed to get sheet statement by id: %d", payload.SheetID)
+	}
+	statement = strings.TrimSpace(statement)
+
+	driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return true, nil, err
+	}
+	defer driver.Close(ctx)
+
+	// not using the rendered statement here because we want to avoid leaking the rendered statement
+	mi, err := getMigrationInfo(ctx, e.store, e.profile, task, db.Migrate, statement, model.Version{Version: payload.SchemaVersion})
+	if err != nil {
+		return true, nil, err
+	}
+	execFunc := func(execCtx context.Context, renderedStatement string) error {
+		parsedStatement, err := pgosc.ParseStatement(renderedStatement)
+		if err != nil {
+			return err
+		}
+		migrator, err := pgosc.NewMigrator(execCtx, driver.GetDB(), parsedStatement, payload.Flags)
+		if err != nil {
+			return err
+		}
+		return migrator.Cutover(execCtx)
+	}
+	migrationID, schema, err := utils.ExecuteMigrationWithFunc(ctx, driverCtx, e.store, e.stateCfg, taskRunUID, driver, mi, statement, &payload.SheetID, execFunc)
+	if err := e.schemaSyncer.SyncDatabaseSchema(ctx, database, true /* force */); err != nil {
+		slog.Error("failed to sync database schema",
+			slog.String("instanceName", instance.ResourceID),
+			slog.String("databaseName", database.DatabaseName),
+			log.BBError(err),
+		)
+	}
+	if err != nil {
+		return true, nil, err
+	}
+
+	return postMigration(ctx, e.store, e.activityManager, e.license, task, mi, migrationID, schema, &payload.SheetID)
+}
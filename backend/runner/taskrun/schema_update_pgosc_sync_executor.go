@@ -0,0 +1,117 @@
+package taskrun
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
+	"github.com/bytebase/bytebase/backend/component/state"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// NewSchemaUpdatePGOSCSyncExecutor creates a schema update (PostgreSQL online schema change) sync task executor.
+func NewSchemaUpdatePGOSCSyncExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State) Executor {
+	return &SchemaUpdatePGOSCSyncExecutor{
+		store:     store,
+		dbFactory: dbFactory,
+		stateCfg:  stateCfg,
+	}
+}
+
+// SchemaUpdatePGOSCSyncExecutor is the schema update (PostgreSQL online schema change) sync task executor.
+// It creates the shadow table with the triggers and copies the rows of the original table to it.
+// The triggers keep the shadow table in sync until the cutover task replaces the original table.
+type SchemaUpdatePGOSCSyncExecutor struct {
+	store     *store.Store
+	dbFactory *dbfactory.DBFactory
+	stateCfg  *state.State
+}
+
+// RunOnce will run SchemaUpdatePGOSCSync task once.
+func (exec *SchemaUpdatePGOSCSyncExecutor) RunOnce(ctx context.Context, driverCtx context.Context, task *store.TaskMessage, taskRunUID int) (terminated bool, result *api.TaskRunResultPayload, err error) {
+	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
+		state.TaskRunExecutionStatus{
+			ExecutionStatus: v1pb.TaskRun_EXECUTING,
+			UpdateTime:      time.Now(),
+		})
+
+	payload := &api.TaskDatabaseSchemaUpdatePGOSCSyncPayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return true, nil, errors.Wrap(err, "invalid database schema update online schema change sync payload")
+	}
+	statement, err := exec.store.GetSheetStatementByID(ctx, payload.SheetID)
+	if err != nil {
+		return true, nil, err
+	}
+
+	instance, err := exec.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return true, nil, err
+	}
+	if instance == nil {
+		return true, nil, errors.Errorf("instance %d not found", task.InstanceID)
+	}
+	database, err := exec.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return true, nil, err
+	}
+	if database == nil {
+		return true, nil, errors.Errorf("database not found")
+	}
+
+	materials := utils.GetSecretMapFromDatabaseMessage(database)
+	// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
+	renderedStatement := utils.RenderStatement(strings.TrimSpace(statement), materials)
+	parsedStatement, err := pgosc.ParseStatement(renderedStatement)
+	if err != nil {
+		return true, nil, err
+	}
+
+	driver, err := exec.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return true, nil, err
+	}
+	defer driver.Close(ctx)
+	migrator, err := pgosc.NewMigrator(ctx, driver.GetDB(), parsedStatement, payload.Flags)
+	if err != nil {
+		return true, nil, err
+	}
+
+	createdTs := time.Now().Unix()
+	if err := func() error {
+		if err := migrator.Prepare(driverCtx); err != nil {
+			return err
+		}
+		return migrator.Backfill(driverCtx, func(copied, total int64) {
+			exec.stateCfg.TaskProgress.Store(task.ID, api.Progress{
+				TotalUnit:     total,
+				CompletedUnit: copied,
+				CreatedTs:     createdTs,
+				UpdatedTs:     time.Now().Unix(),
+			})
+		})
+	}(); err != nil {
+		// The shadow table is of no use after the sync fails or is canceled.
+		if cleanupErr := migrator.Cleanup(ctx); cleanupErr != nil {
+			slog.Error("failed to clean up the online schema change",
+				slog.Int("task", task.ID),
+				log.BBError(cleanupErr),
+			)
+		}
+		if driverCtx.Err() != nil {
+			return true, nil, errors.Wrap(driverCtx.Err(), "task canceled")
+		}
+		return true, nil, err
+	}
+	return true, &api.TaskRunResultPayload{Detail: "sync done"}, nil
+}
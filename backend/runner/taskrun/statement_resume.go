@@ -0,0 +1,173 @@
+package taskrun
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/state"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// resumableEngines are the engines that commit each DDL statement implicitly.
+// The statements done before the failed one can't be rolled back, so the retry resumes from the failed statement.
+var resumableEngines = map[storepb.Engine]bool{
+	storepb.Engine_MYSQL:     true,
+	storepb.Engine_MARIADB:   true,
+	storepb.Engine_TIDB:      true,
+	storepb.Engine_OCEANBASE: true,
+	storepb.Engine_ORACLE:    true,
+}
+
+// statementExecutionError is the error of executing the statements one by one, which carries the results of the statements.
+type statementExecutionError struct {
+	err     error
+	failed  *storepb.TaskRunResult_StatementResult
+	results []*storepb.TaskRunResult_StatementResult
+}
+
+func (e *statementExecutionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statementExecutionError) Unwrap() error {
+	return e.err
+}
+
+// isStatementResumable returns whether the task executes the statements one by one and resumes from the failed statement.
+// The data updates are executed in a transaction, so a failed data update is rolled back and reruns from the beginning.
+func isStatementResumable(task *store.TaskMessage, engine storepb.Engine, statement string) bool {
+	return task.Type == api.TaskDatabaseSchemaUpdate && resumableEngines[engine] && len(statement) <= common.MaxSheetCheckSize
+}
+
+func getStatementChecksum(statement string) string {
+	h := sha256.Sum256([]byte(statement))
+	return hex.EncodeToString(h[:])
+}
+
+// getPreviousStatementResults returns the statement results of the task run before the given one if it failed or was canceled,
+// and whether the failed statement should be skipped.
+func getPreviousStatementResults(ctx context.Context, stores *store.Store, task *store.TaskMessage, taskRunUID int) ([]*storepb.TaskRunResult_StatementResult, bool, error) {
+	taskRuns, err := stores.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{TaskUID: &task.ID})
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to list task runs")
+	}
+	var previous *store.TaskRunMessage
+	for _, taskRun := range taskRuns {
+		if taskRun.ID < taskRunUID && (previous == nil || taskRun.ID > previous.ID) {
+			previous = taskRun
+		}
+	}
+	if previous == nil || (previous.Status != api.TaskRunFailed && previous.Status != api.TaskRunCanceled) {
+		return nil, false, nil
+	}
+
+	skip := struct {
+		SkipFailedStatementTaskRun int `json:"skipFailedStatementTaskRun"`
+	}{}
+	if err := json.Unmarshal([]byte(task.Payload), &skip); err != nil {
+		return nil, false, errors.Wrapf(err, "failed to unmarshal payload")
+	}
+	return previous.ResultProto.GetStatementResults(), skip.SkipFailedStatementTaskRun == previous.ID, nil
+}
+
+// buildStatementResults builds the pending results of the statements.
+// The leading statements done or skipped by the previous task run are resumed if their checksums don't change,
+// and the failed statement is skipped if skipFailed is true, or resumes its batches otherwise.
+func buildStatementResults(statements []base.SingleSQL, previous []*storepb.TaskRunResult_StatementResult, skipFailed bool) []*storepb.TaskRunResult_StatementResult {
+	var results []*storepb.TaskRunResult_StatementResult
+	resuming := true
+	for i, statement := range statements {
+		result := &storepb.TaskRunResult_StatementResult{
+			Index:    int32(i),
+			Checksum: getStatementChecksum(statement.Text),
+			Status:   storepb.TaskRunResult_StatementResult_PENDING,
+			StartPosition: &storepb.TaskRunResult_Position{
+				Line:   int32(statement.FirstStatementLine),
+				Column: int32(statement.FirstStatementColumn),
+			},
+			EndPosition: &storepb.TaskRunResult_Position{
+				Line:   int32(statement.LastLine),
+				Column: int32(statement.LastColumn),
+			},
+		}
+		if resuming && i < len(previous) && previous[i].Checksum == result.Checksum {
+			switch previous[i].Status {
+			case storepb.TaskRunResult_StatementResult_DONE, storepb.TaskRunResult_StatementResult_SKIPPED:
+				result.Status = previous[i].Status
+				result.Resumed = true
+			case storepb.TaskRunResult_StatementResult_FAILED:
+				if skipFailed {
+					result.Status = storepb.TaskRunResult_StatementResult_SKIPPED
+				} else {
+					result.BatchResumeKey = previous[i].BatchResumeKey
+				}
+				resuming = false
+			default:
+				resuming = false
+			}
+		} else {
+			resuming = false
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// executeStatements executes the pending statements one by one and records their results.
+// The execution status reported by the driver for a single statement is replaced by the one of the whole statements.
+func executeStatements(ctx context.Context, driver db.Driver, stateCfg *state.State, logger *taskRunLogger, taskRunUID int, statements []base.SingleSQL, results []*storepb.TaskRunResult_StatementResult, opts db.ExecuteOptions) error {
+	for i, statement := range statements {
+		result := results[i]
+		if result.Status != storepb.TaskRunResult_StatementResult_PENDING {
+			continue
+		}
+		detail := &v1pb.TaskRun_ExecutionDetail{
+			CommandsTotal:     int32(len(statements)),
+			CommandsCompleted: int32(i),
+			CommandStartPosition: &v1pb.TaskRun_ExecutionDetail_Position{
+				Line:   result.StartPosition.Line,
+				Column: result.StartPosition.Column,
+			},
+			CommandEndPosition: &v1pb.TaskRun_ExecutionDetail_Position{
+				Line:   result.EndPosition.Line,
+				Column: result.EndPosition.Column,
+			},
+		}
+		statementOpts := opts
+		if updateExecutionStatus := opts.UpdateExecutionStatus; updateExecutionStatus != nil {
+			updateExecutionStatus(detail)
+			statementOpts.UpdateExecutionStatus = func(*v1pb.TaskRun_ExecutionDetail) {
+				updateExecutionStatus(detail)
+			}
+		} else if stateCfg != nil {
+			stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
+				state.TaskRunExecutionStatus{
+					ExecutionStatus: v1pb.TaskRun_EXECUTING,
+					ExecutionDetail: detail,
+					UpdateTime:      time.Now(),
+				})
+		}
+		if _, err := executeWithLog(ctx, driver, logger, i, statement.Text, statementOpts); err != nil {
+			result.Status = storepb.TaskRunResult_StatementResult_FAILED
+			result.Error = err.Error()
+			return &statementExecutionError{
+				err:     errors.Wrapf(err, "failed to execute statement %d", i+1),
+				failed:  result,
+				results: results,
+			}
+		}
+		result.Status = storepb.TaskRunResult_StatementResult_DONE
+	}
+	return nil
+}
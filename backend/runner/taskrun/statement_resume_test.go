@@ -0,0 +1,71 @@
+package taskrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestBuildStatementResults(t *testing.T) {
+	a := require.New(t)
+	statements := []base.SingleSQL{
+		{Text: "CREATE TABLE t1 (id INT);"},
+		{Text: "CREATE TABLE t2 (id INT);", FirstStatementLine: 1, LastLine: 1},
+		{Text: "CREATE TABLE t3 (id INT);", FirstStatementLine: 2, LastLine: 2},
+	}
+	getStatuses := func(results []*storepb.TaskRunResult_StatementResult) []storepb.TaskRunResult_StatementResult_Status {
+		var statuses []storepb.TaskRunResult_StatementResult_Status
+		for _, result := range results {
+			statuses = append(statuses, result.Status)
+		}
+		return statuses
+	}
+
+	// The first run executes all statements.
+	results := buildStatementResults(statements, nil, false)
+	a.Len(results, 3)
+	a.Equal(int32(1), results[1].StartPosition.Line)
+	for _, result := range results {
+		a.Equal(storepb.TaskRunResult_StatementResult_PENDING, result.Status)
+		a.False(result.Resumed)
+	}
+
+	// The retry resumes from the failed statement.
+	results[0].Status = storepb.TaskRunResult_StatementResult_DONE
+	results[1].Status = storepb.TaskRunResult_StatementResult_FAILED
+	retry := buildStatementResults(statements, results, false)
+	a.Equal([]storepb.TaskRunResult_StatementResult_Status{
+		storepb.TaskRunResult_StatementResult_DONE,
+		storepb.TaskRunResult_StatementResult_PENDING,
+		storepb.TaskRunResult_StatementResult_PENDING,
+	}, getStatuses(retry))
+	a.True(retry[0].Resumed)
+
+	// The retry resumes the batches of the failed statement.
+	results[1].BatchResumeKey = "1001"
+	retry = buildStatementResults(statements, results, false)
+	a.Equal("1001", retry[1].BatchResumeKey)
+	a.Empty(retry[2].BatchResumeKey)
+
+	// The retry skips the failed statement.
+	retry = buildStatementResults(statements, results, true)
+	a.Equal([]storepb.TaskRunResult_StatementResult_Status{
+		storepb.TaskRunResult_StatementResult_DONE,
+		storepb.TaskRunResult_StatementResult_SKIPPED,
+		storepb.TaskRunResult_StatementResult_PENDING,
+	}, getStatuses(retry))
+	a.False(retry[1].Resumed)
+	a.Empty(retry[1].BatchResumeKey)
+
+	// The changed statements rerun.
+	changed := []base.SingleSQL{{Text: "CREATE TABLE t0 (id INT);"}, statements[1], statements[2]}
+	retry = buildStatementResults(changed, results, true)
+	a.Equal([]storepb.TaskRunResult_StatementResult_Status{
+		storepb.TaskRunResult_StatementResult_PENDING,
+		storepb.TaskRunResult_StatementResult_PENDING,
+		storepb.TaskRunResult_StatementResult_PENDING,
+	}, getStatuses(retry))
+}
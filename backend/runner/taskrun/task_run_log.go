@@ -0,0 +1,189 @@
+package taskrun
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// maxLogStatementSize is the max number of characters of the statement recorded in the task run log.
+const maxLogStatementSize = 1024
+
+// redactedSecret replaces the database secrets in the task run log.
+const redactedSecret = "******"
+
+// taskRunLogQueueSize is the max number of the log entries waiting to be recorded.
+// The entries are dropped if the queue is full, so that recording the log never holds the task run.
+const taskRunLogQueueSize = 1000
+
+// taskRunLogBatchSize is the max number of the log entries recorded in one insert.
+const taskRunLogBatchSize = 100
+
+// taskRunLogRetentionCycle is the number of days to keep the task run logs.
+const taskRunLogRetentionCycle = 30
+
+// purgeTaskRunLogs deletes the task run logs older than the retention cycle at most once a day.
+func (s *SchedulerV2) purgeTaskRunLogs(ctx context.Context) error {
+	if time.Since(s.lastTaskRunLogPurgeTime) < 24*time.Hour {
+		return nil
+	}
+	s.lastTaskRunLogPurgeTime = time.Now()
+	return s.store.DeleteOutdatedTaskRunLogs(ctx, time.Now().AddDate(0, 0, -taskRunLogRetentionCycle))
+}
+
+// taskRunLogger records the structured log of a task run, which can be tailed by the users.
+// The entries are queued and recorded in batches in the background until the logger is closed.
+// A nil logger records nothing.
+type taskRunLogger struct {
+	store      *store.Store
+	taskRunUID int
+	// secrets are the secret values of the database, which are rendered into the statement and must not be recorded.
+	secrets []string
+
+	mu      sync.Mutex
+	closed  bool
+	dropped int
+	entries chan *storepb.TaskRunLog
+	done    chan struct{}
+}
+
+func newTaskRunLogger(store *store.Store, taskRunUID int, database *store.DatabaseMessage) *taskRunLogger {
+	var secrets []string
+	if database != nil {
+		for _, value := range utils.GetSecretMapFromDatabaseMessage(database) {
+			if value != "" {
+				secrets = append(secrets, value)
+			}
+		}
+	}
+	l := &taskRunLogger{
+		store:      store,
+		taskRunUID: taskRunUID,
+		secrets:    secrets,
+		entries:    make(chan *storepb.TaskRunLog, taskRunLogQueueSize),
+		done:       make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// log queues the log entry. The entries logged after the logger is closed are dropped.
+func (l *taskRunLogger) log(entry *storepb.TaskRunLog) {
+	if l == nil {
+		return
+	}
+	entry.Statement = l.redact(entry.Statement)
+	entry.Error = l.redact(entry.Error)
+	entry.Message = l.redact(entry.Message)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+	select {
+	case l.entries <- entry:
+	default:
+		l.dropped++
+	}
+}
+
+// close records the queued log entries and stops the logger.
+func (l *taskRunLogger) close() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.entries)
+	}
+	dropped := l.dropped
+	l.mu.Unlock()
+	<-l.done
+	if dropped > 0 {
+		slog.Warn("dropped task run log entries because the queue is full", slog.Int("taskRun", l.taskRunUID), slog.Int("count", dropped))
+	}
+}
+
+// run records the queued log entries in batches until the logger is closed.
+// The entries are recorded even if the task run is canceled, and the failure of recording the entries doesn't fail the task run.
+func (l *taskRunLogger) run() {
+	defer close(l.done)
+	for entry := range l.entries {
+		creates := []*store.TaskRunLogMessage{{TaskRunUID: l.taskRunUID, Payload: entry}}
+		// Take the entries queued while recording the previous batch.
+	batch:
+		for len(creates) < taskRunLogBatchSize {
+			select {
+			case entry, ok := <-l.entries:
+				if !ok {
+					break batch
+				}
+				creates = append(creates, &store.TaskRunLogMessage{TaskRunUID: l.taskRunUID, Payload: entry})
+			default:
+				break batch
+			}
+		}
+		if err := l.store.CreateTaskRunLogs(context.Background(), creates); err != nil {
+			slog.Warn("failed to create task run logs", slog.Int("taskRun", l.taskRunUID), log.BBError(err))
+		}
+	}
+}
+
+func (l *taskRunLogger) redact(s string) string {
+	for _, secret := range l.secrets {
+		s = strings.ReplaceAll(s, secret, redactedSecret)
+	}
+	return s
+}
+
+func (l *taskRunLogger) ghostStatus(status string) {
+	l.log(&storepb.TaskRunLog{
+		Type:    storepb.TaskRunLog_GHOST_STATUS,
+		Message: status,
+	})
+}
+
+// executeWithLog executes the statement and records its start, end and the notices reported by the database in the task run log.
+func executeWithLog(ctx context.Context, driver db.Driver, logger *taskRunLogger, index int, statement string, opts db.ExecuteOptions) (int64, error) {
+	statementRecord, _ := common.TruncateString(statement, maxLogStatementSize)
+	logger.log(&storepb.TaskRunLog{
+		Type:           storepb.TaskRunLog_STATEMENT_START,
+		StatementIndex: int32(index),
+		Statement:      statementRecord,
+	})
+	if logger != nil {
+		opts.NoticeFunc = func(notice string) {
+			logger.log(&storepb.TaskRunLog{
+				Type:           storepb.TaskRunLog_DATABASE_NOTICE,
+				StatementIndex: int32(index),
+				Message:        notice,
+			})
+		}
+	}
+
+	start := time.Now()
+	affectedRows, err := driver.Execute(ctx, statement, false /* createDatabase */, opts)
+	end := &storepb.TaskRunLog{
+		Type:           storepb.TaskRunLog_STATEMENT_END,
+		StatementIndex: int32(index),
+		Duration:       durationpb.New(time.Since(start)),
+		AffectedRows:   affectedRows,
+	}
+	if err != nil {
+		end.Error = err.Error()
+	}
+	logger.log(end)
+	return affectedRows, err
+}
@@ -0,0 +1,37 @@
+package taskrun
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestTaskRunLoggerQueue(t *testing.T) {
+	a := require.New(t)
+	// The logger isn't running, so the queued entries stay in the queue.
+	l := &taskRunLogger{
+		secrets: []string{"s3cret"},
+		entries: make(chan *storepb.TaskRunLog, 1),
+		done:    make(chan struct{}),
+	}
+	l.log(&storepb.TaskRunLog{Type: storepb.TaskRunLog_STATEMENT_START, Statement: "SELECT 's3cret'"})
+	// The entry is dropped if the queue is full.
+	l.log(&storepb.TaskRunLog{Type: storepb.TaskRunLog_STATEMENT_END})
+	a.Equal(1, l.dropped)
+	entry := <-l.entries
+	a.Equal("SELECT '******'", entry.Statement)
+
+	close(l.done)
+	l.close()
+	// The entry is dropped after the logger is closed.
+	l.log(&storepb.TaskRunLog{Type: storepb.TaskRunLog_STATEMENT_START})
+	_, ok := <-l.entries
+	a.False(ok)
+
+	// The nil logger records nothing.
+	var nilLogger *taskRunLogger
+	nilLogger.log(&storepb.TaskRunLog{})
+	nilLogger.close()
+}
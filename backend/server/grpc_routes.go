@@ -59,30 +59,36 @@ func configureGrpcRouters(
 		profile,
 		metricReporter,
 		licenseService))
-	v1pb.RegisterEnvironmentServiceServer(grpcServer, apiv1.NewEnvironmentService(stores, licenseService))
-	v1pb.RegisterInstanceServiceServer(grpcServer, apiv1.NewInstanceService(
+	environmentService := apiv1.NewEnvironmentService(stores, licenseService)
+	v1pb.RegisterEnvironmentServiceServer(grpcServer, environmentService)
+	instanceService := apiv1.NewInstanceService(
 		stores,
 		licenseService,
 		metricReporter,
 		secret,
 		stateCfg,
 		dbFactory,
-		schemaSyncer))
-	v1pb.RegisterProjectServiceServer(grpcServer, apiv1.NewProjectService(stores, activityManager, licenseService))
+		schemaSyncer)
+	v1pb.RegisterInstanceServiceServer(grpcServer, instanceService)
+	projectService := apiv1.NewProjectService(stores, activityManager, licenseService)
+	v1pb.RegisterProjectServiceServer(grpcServer, projectService)
 	v1pb.RegisterDatabaseServiceServer(grpcServer, apiv1.NewDatabaseService(stores, backupRunner, schemaSyncer, licenseService, profile, iamManager))
 	v1pb.RegisterInstanceRoleServiceServer(grpcServer, apiv1.NewInstanceRoleService(stores, dbFactory))
-	v1pb.RegisterOrgPolicyServiceServer(grpcServer, apiv1.NewOrgPolicyService(stores, licenseService))
+	orgPolicyService := apiv1.NewOrgPolicyService(stores, licenseService)
+	v1pb.RegisterOrgPolicyServiceServer(grpcServer, orgPolicyService)
 	v1pb.RegisterIdentityProviderServiceServer(grpcServer, apiv1.NewIdentityProviderService(stores, licenseService))
-	v1pb.RegisterSettingServiceServer(grpcServer, apiv1.NewSettingService(stores, profile, licenseService, stateCfg))
+	settingService := apiv1.NewSettingService(stores, profile, licenseService, stateCfg)
+	v1pb.RegisterSettingServiceServer(grpcServer, settingService)
+	v1pb.RegisterWorkspaceConfigServiceServer(grpcServer, apiv1.NewWorkspaceConfigService(environmentService, instanceService, projectService, orgPolicyService, settingService))
 	v1pb.RegisterAnomalyServiceServer(grpcServer, apiv1.NewAnomalyService(stores))
 	v1pb.RegisterSQLServiceServer(grpcServer, apiv1.NewSQLService(stores, schemaSyncer, dbFactory, activityManager, licenseService))
 	v1pb.RegisterExternalVersionControlServiceServer(grpcServer, apiv1.NewExternalVersionControlService(stores))
 	v1pb.RegisterRiskServiceServer(grpcServer, apiv1.NewRiskService(stores, licenseService))
 	issueService := apiv1.NewIssueService(stores, activityManager, relayRunner, stateCfg, licenseService, metricReporter)
 	v1pb.RegisterIssueServiceServer(grpcServer, issueService)
-	rolloutService := apiv1.NewRolloutService(stores, licenseService, dbFactory, planCheckScheduler, stateCfg, activityManager)
+	rolloutService := apiv1.NewRolloutService(stores, licenseService, dbFactory, planCheckScheduler, stateCfg, activityManager, profile, iamManager)
 	v1pb.RegisterRolloutServiceServer(grpcServer, rolloutService)
-	v1pb.RegisterRoleServiceServer(grpcServer, apiv1.NewRoleService(stores, licenseService))
+	v1pb.RegisterRoleServiceServer(grpcServer, apiv1.NewRoleService(stores, iamManager, licenseService))
 	v1pb.RegisterSheetServiceServer(grpcServer, apiv1.NewSheetService(stores, licenseService))
 	v1pb.RegisterBranchServiceServer(grpcServer, apiv1.NewBranchService(stores, licenseService))
 	v1pb.RegisterCelServiceServer(grpcServer, apiv1.NewCelService())
@@ -160,5 +166,8 @@ func configureGrpcRouters(
 	if err := v1pb.RegisterChangelistServiceHandler(ctx, mux, grpcConn); err != nil {
 		return nil, nil, err
 	}
+	if err := v1pb.RegisterWorkspaceConfigServiceHandler(ctx, mux, grpcConn); err != nil {
+		return nil, nil, err
+	}
 	return rolloutService, issueService, nil
 }
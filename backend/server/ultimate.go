@@ -21,7 +21,9 @@ import (
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
 
 	// Advisors.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/clickhouse"
 	_ "github.com/bytebase/bytebase/backend/plugin/advisor/mssql"
 	_ "github.com/bytebase/bytebase/backend/plugin/advisor/oracle"
 	_ "github.com/bytebase/bytebase/backend/plugin/advisor/snowflake"
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/spanner"
 )
@@ -14,6 +14,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
 	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/sarif"
 	"github.com/bytebase/bytebase/backend/plugin/metric"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -27,10 +28,17 @@ type catalogService struct {
 	finder *catalog.Finder
 }
 
-func newCatalogService(dbType storepb.Engine) *catalogService {
-	return &catalogService{
-		finder: catalog.NewEmptyFinder(&catalog.FinderContext{CheckIntegrity: false, EngineType: dbType, IgnoreCaseSensitive: false}),
+func newCatalogService(dbType storepb.Engine, schema string) (*catalogService, error) {
+	if schema == "" {
+		return &catalogService{
+			finder: catalog.NewEmptyFinder(&catalog.FinderContext{CheckIntegrity: false, EngineType: dbType, IgnoreCaseSensitive: false}),
+		}, nil
 	}
+	finder, err := catalog.NewFinderWithSchema(dbType, "" /* currentSchema */, schema)
+	if err != nil {
+		return nil, err
+	}
+	return &catalogService{finder: finder}, nil
 }
 
 // GetDatabase is the API message in catalog.
@@ -38,11 +46,32 @@ func (c *catalogService) GetFinder() *catalog.Finder {
 	return c.finder
 }
 
+const (
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+)
+
 type sqlCheckRequestBody struct {
 	Statement    string `json:"statement"`
 	DatabaseType string `json:"databaseType"`
 	TemplateID   string `json:"templateId"`
 	Override     string `json:"override"`
+	// Schema is the schema dump of the database, the statements are checked against it if specified.
+	Schema string `json:"schema"`
+	// Files are checked in batch, the statement is ignored if files are specified.
+	// Each file is checked against the schema independently.
+	Files []*sqlCheckFile `json:"files"`
+	// Format is the response format, could be "json" (default) or "sarif".
+	Format string `json:"format"`
+	// CurrentDatabase is the current database for Snowflake.
+	CurrentDatabase string `json:"currentDatabase"`
+	// CurrentSchema is the current schema for Oracle.
+	CurrentSchema string `json:"currentSchema"`
+}
+
+type sqlCheckFile struct {
+	Path      string `json:"path"`
+	Statement string `json:"statement"`
 }
 
 func (s *Server) registerAdvisorRoutes(g *echo.Group) {
@@ -51,14 +80,18 @@ func (s *Server) registerAdvisorRoutes(g *echo.Group) {
 
 // sqlCheckController godoc
 // @Summary  Check the SQL statement.
-// @Description  Parse and check the SQL statement according to the SQL review rules.
+// @Description  Parse and check the SQL statements according to the SQL review rules.
+// @Description  The response is the advice list for a single statement, the file results for files, or a SARIF 2.1.0 log if the format is sarif.
 // @Accept  application/json
 // @Tags  SQL review
 // @Produce  json
-// @Param  statement     body  string  true   "The SQL statement."
-// @Param  databaseType  body  string  true   "The database type."  Enums(MYSQL, POSTGRES, TIDB, OCEANBASE, SNOWFLAKE, MSSQL)
+// @Param  statement     body  string  false  "The SQL statement. Required if the files are not specified."
+// @Param  files         body  array   false  "The files to check in batch, each has the path and the statement."
+// @Param  databaseType  body  string  true   "The database type."  Enums(MYSQL, MARIADB, POSTGRES, TIDB, OCEANBASE, ORACLE, OCEANBASE_ORACLE, DM, SNOWFLAKE, MSSQL, CLICKHOUSE, SPANNER)
 // @Param  templateId    body  string  false  "The SQL check template id. Required if the config is not specified." Enums(bb.sql-review.prod, bb.sql-review.dev)
 // @Param  override      body  string  false  "The SQL check config override string in YAML format. Check https://github.com/bytebase/bytebase/tree/main/backend/plugin/advisor/config/sql-review.override.yaml for example. Required if the template is not specified."
+// @Param  schema        body  string  false  "The schema dump of the database for catalog-aware checks. Supported for MYSQL, MARIADB, POSTGRES, TIDB, OCEANBASE, ORACLE, OCEANBASE_ORACLE and MSSQL."
+// @Param  format        body  string  false  "The response format." Enums(json, sarif)
 // @Success  200  {array}   advisor.Advice
 // @Failure  400  {object}  echo.HTTPError
 // @Failure  500  {object}  echo.HTTPError
@@ -73,19 +106,39 @@ func (s *Server) sqlCheckController(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Cannot format request body").SetInternal(err)
 	}
 
-	if request.Statement == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "Missing required SQL statement")
+	files := request.Files
+	if len(files) == 0 {
+		if request.Statement == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "Missing required SQL statement")
+		}
+		files = []*sqlCheckFile{{Statement: request.Statement}}
+	}
+	for _, file := range files {
+		if file.Statement == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Missing required SQL statement for file %q", file.Path))
+		}
 	}
 
 	if request.Override == "" && request.TemplateID == "" {
 		return echo.NewHTTPError(http.StatusBadRequest, "Missing required template or override")
 	}
 
+	format := request.Format
+	if format == "" {
+		format = formatJSON
+	}
+	if format != formatJSON && format != formatSARIF {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Format %s is not support", request.Format))
+	}
+
 	engineTypeValue, ok := storepb.Engine_value[request.DatabaseType]
-	if !ok {
+	if !ok || !advisor.IsSupported(storepb.Engine(engineTypeValue)) {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Database %s is not support", request.DatabaseType))
 	}
 	engineType := storepb.Engine(engineTypeValue)
+	if request.Schema != "" && !catalog.IsWalkThroughSupported(engineType) {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Schema is not support for database %s", request.DatabaseType))
+	}
 
 	ruleOverride := &advisor.SQLReviewConfigOverride{}
 	if request.Override != "" {
@@ -104,16 +157,29 @@ func (s *Server) sqlCheckController(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Cannot merge the config for template: %s", ruleOverride.Template)).SetInternal(err)
 	}
 
-	adviceList, err := sqlCheck(
-		engineType,
-		"utf8mb4",
-		"utf8mb4_general_ci",
-		request.Statement,
-		ruleList,
-		newCatalogService(engineType),
-	)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to run sql check").SetInternal(err)
+	var results []*sarif.FileAdvice
+	for _, file := range files {
+		catalogService, err := newCatalogService(engineType, request.Schema)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid schema: %v", err)).SetInternal(err)
+		}
+		adviceList, err := sqlCheck(
+			engineType,
+			"utf8mb4",
+			"utf8mb4_general_ci",
+			file.Statement,
+			ruleList,
+			catalogService,
+			request.CurrentDatabase,
+			request.CurrentSchema,
+		)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to run sql check for file %q", file.Path)).SetInternal(err)
+		}
+		results = append(results, &sarif.FileAdvice{
+			Path:    file.Path,
+			Advices: adviceList,
+		})
 	}
 
 	s.metricReporter.Report(&metric.Metric{
@@ -129,7 +195,14 @@ func (s *Server) sqlCheckController(c echo.Context) error {
 		},
 	})
 
-	return c.JSON(http.StatusOK, adviceList)
+	if format == formatSARIF {
+		return c.JSON(http.StatusOK, sarif.Convert(s.profile.Version, results))
+	}
+	// Keep the response of a single statement compatible.
+	if len(request.Files) == 0 {
+		return c.JSON(http.StatusOK, results[0].Advices)
+	}
+	return c.JSON(http.StatusOK, results)
 }
 
 func sqlCheck(
@@ -139,6 +212,8 @@ func sqlCheck(
 	statement string,
 	ruleList []*storepb.SQLReviewRule,
 	catalog catalog.Catalog,
+	currentDatabase string,
+	currentSchema string,
 ) ([]advisor.Advice, error) {
 	var adviceList []advisor.Advice
 
@@ -149,6 +224,9 @@ func sqlCheck(
 		Catalog:   catalog,
 		Driver:    nil,
 		Context:   context.Background(),
+
+		CurrentDatabase: currentDatabase,
+		CurrentSchema:   currentSchema,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,60 @@
+package sqlserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/mysql"
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/oracle"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestSQLCheckWithSchema(t *testing.T) {
+	a := require.New(t)
+
+	ruleList, err := advisor.MergeSQLReviewRules(&advisor.SQLReviewConfigOverride{Template: "bb.sql-review.dev"})
+	a.NoError(err)
+	schema := "CREATE TABLE t (id INT PRIMARY KEY);"
+
+	catalogService, err := newCatalogService(storepb.Engine_MYSQL, schema)
+	a.NoError(err)
+	adviceList, err := sqlCheck(storepb.Engine_MYSQL, "utf8mb4", "utf8mb4_general_ci", "ALTER TABLE t2 ADD COLUMN a INT;", ruleList, catalogService, "", "")
+	a.NoError(err)
+	a.Len(adviceList, 1)
+	a.Equal(advisor.Error, adviceList[0].Status)
+	a.Equal(advisor.TableNotExists, adviceList[0].Code)
+
+	catalogService, err = newCatalogService(storepb.Engine_MYSQL, schema)
+	a.NoError(err)
+	adviceList, err = sqlCheck(storepb.Engine_MYSQL, "utf8mb4", "utf8mb4_general_ci", "DROP TABLE t;", ruleList, catalogService, "", "")
+	a.NoError(err)
+	for _, advice := range adviceList {
+		a.NotEqual(advisor.TableNotExists, advice.Code)
+	}
+
+	_, err = newCatalogService(storepb.Engine_SNOWFLAKE, schema)
+	a.Error(err)
+}
+
+func TestSQLCheckDM(t *testing.T) {
+	a := require.New(t)
+
+	// The DM advisors are registered by the Oracle advisor package.
+	a.True(advisor.IsSupported(storepb.Engine_DM))
+	a.False(advisor.IsSupported(storepb.Engine_REDIS))
+
+	ruleList, err := advisor.MergeSQLReviewRules(&advisor.SQLReviewConfigOverride{Template: "bb.sql-review.dev"})
+	a.NoError(err)
+	catalogService, err := newCatalogService(storepb.Engine_DM, "")
+	a.NoError(err)
+	adviceList, err := sqlCheck(storepb.Engine_DM, "", "", "CREATE TABLE t (a INT);", ruleList, catalogService, "", "")
+	a.NoError(err)
+	var codes []advisor.Code
+	for _, advice := range adviceList {
+		codes = append(codes, advice.Code)
+	}
+	a.Contains(codes, advisor.TableNoPK)
+	a.NotContains(codes, advisor.Unsupported)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
 // DeploymentConfigMessage is the message for deployment config.
@@ -49,6 +50,7 @@ func (s *Schedule) toAPIDeploymentSchedule() *api.DeploymentSchedule {
 			Name: d.Name,
 			Spec: &api.DeploymentSpec{
 				Selector: d.Spec.Selector.toAPILabelSelector(),
+				Rollout:  d.Spec.Rollout,
 			},
 		})
 	}
@@ -66,6 +68,8 @@ type Deployment struct {
 // DeploymentSpec is the message for deployment specification.
 type DeploymentSpec struct {
 	Selector *LabelSelector `json:"selector"`
+	// Rollout is the rollout strategy of the stage, or nil to roll out all databases in the stage at once.
+	Rollout *storepb.RolloutStrategy `json:"rollout,omitempty"`
 }
 
 // LabelSelector is the message for label selector.
@@ -0,0 +1,196 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/bytebase/bytebase/backend/common"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// PersonalAccessTokenMessage is the message for a personal access token.
+type PersonalAccessTokenMessage struct {
+	PrincipalID int
+	Name        string
+	// TokenHash is the hex-encoded SHA-256 hash of the token.
+	TokenHash string
+	// ExpireTime is nil if the token never expires.
+	ExpireTime *time.Time
+	Payload    *storepb.PersonalAccessTokenPayload
+
+	// Output only fields
+	UID          int
+	CreatedTime  time.Time
+	LastUsedTime *time.Time
+}
+
+// FindPersonalAccessTokenMessage is the message for finding personal access tokens.
+type FindPersonalAccessTokenMessage struct {
+	UID         *int
+	PrincipalID *int
+	TokenHash   *string
+}
+
+// IsExpired returns true if the token is expired.
+func (t *PersonalAccessTokenMessage) IsExpired() bool {
+	return t.ExpireTime != nil && time.Now().After(*t.ExpireTime)
+}
+
+// GetPersonalAccessToken gets a personal access token.
+func (s *Store) GetPersonalAccessToken(ctx context.Context, find *FindPersonalAccessTokenMessage) (*PersonalAccessTokenMessage, error) {
+	tokens, err := s.ListPersonalAccessTokens(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	if len(tokens) > 1 {
+		return nil, errors.Errorf("expected 1 personal access token, got %d", len(tokens))
+	}
+	return tokens[0], nil
+}
+
+// ListPersonalAccessTokens lists personal access tokens.
+func (s *Store) ListPersonalAccessTokens(ctx context.Context, find *FindPersonalAccessTokenMessage) ([]*PersonalAccessTokenMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.UID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.PrincipalID; v != nil {
+		where, args = append(where, fmt.Sprintf("principal_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.TokenHash; v != nil {
+		where, args = append(where, fmt.Sprintf("token_hash = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := s.db.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT
+			id,
+			principal_id,
+			created_ts,
+			name,
+			token_hash,
+			expire_ts,
+			last_used_ts,
+			payload
+		FROM personal_access_token
+		WHERE %s
+		ORDER BY id ASC`, strings.Join(where, " AND ")),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*PersonalAccessTokenMessage
+	for rows.Next() {
+		var token PersonalAccessTokenMessage
+		var createdTs, expireTs, lastUsedTs int64
+		var payload []byte
+		if err := rows.Scan(
+			&token.UID,
+			&token.PrincipalID,
+			&createdTs,
+			&token.Name,
+			&token.TokenHash,
+			&expireTs,
+			&lastUsedTs,
+			&payload,
+		); err != nil {
+			return nil, err
+		}
+		tokenPayload := &storepb.PersonalAccessTokenPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payload, tokenPayload); err != nil {
+			return nil, err
+		}
+		token.Payload = tokenPayload
+		token.CreatedTime = time.Unix(createdTs, 0)
+		if expireTs != 0 {
+			expireTime := time.Unix(expireTs, 0)
+			token.ExpireTime = &expireTime
+		}
+		if lastUsedTs != 0 {
+			lastUsedTime := time.Unix(lastUsedTs, 0)
+			token.LastUsedTime = &lastUsedTime
+		}
+		tokens = append(tokens, &token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// CreatePersonalAccessToken creates a personal access token.
+func (s *Store) CreatePersonalAccessToken(ctx context.Context, create *PersonalAccessTokenMessage) (*PersonalAccessTokenMessage, error) {
+	payload, err := protojson.Marshal(create.Payload)
+	if err != nil {
+		return nil, err
+	}
+	var expireTs int64
+	if create.ExpireTime != nil {
+		expireTs = create.ExpireTime.Unix()
+	}
+
+	query := `
+		INSERT INTO personal_access_token (
+			principal_id,
+			name,
+			token_hash,
+			expire_ts,
+			payload
+		)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_ts
+	`
+	var id int
+	var createdTs int64
+	if err := s.db.db.QueryRowContext(ctx, query,
+		create.PrincipalID,
+		create.Name,
+		create.TokenHash,
+		expireTs,
+		payload,
+	).Scan(&id, &createdTs); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, err
+	}
+
+	create.UID = id
+	create.CreatedTime = time.Unix(createdTs, 0)
+	return create, nil
+}
+
+// UpdatePersonalAccessTokenLastUsedTime records the last time the personal access token is used.
+func (s *Store) UpdatePersonalAccessTokenLastUsedTime(ctx context.Context, uid int, lastUsedTime time.Time) error {
+	if _, err := s.db.db.ExecContext(ctx, `
+		UPDATE personal_access_token
+		SET last_used_ts = $1
+		WHERE id = $2
+	`, lastUsedTime.Unix(), uid); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeletePersonalAccessToken deletes a personal access token.
+func (s *Store) DeletePersonalAccessToken(ctx context.Context, uid int) error {
+	if _, err := s.db.db.ExecContext(ctx, `
+		DELETE FROM personal_access_token
+		WHERE id = $1
+	`, uid); err != nil {
+		return err
+	}
+	return nil
+}
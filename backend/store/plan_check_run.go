@@ -29,8 +29,14 @@ const (
 	PlanCheckDatabaseConnect PlanCheckRunType = "bb.plan-check.database.connect"
 	// PlanCheckDatabaseGhostSync is the plan check type for the gh-ost sync task.
 	PlanCheckDatabaseGhostSync PlanCheckRunType = "bb.plan-check.database.ghost.sync"
+	// PlanCheckDatabasePGOSCSync is the plan check type for the PostgreSQL online schema change sync task.
+	PlanCheckDatabasePGOSCSync PlanCheckRunType = "bb.plan-check.database.pgosc.sync"
 	// PlanCheckDatabasePITRMySQL is the plan check type for MySQL PITR.
 	PlanCheckDatabasePITRMySQL PlanCheckRunType = "bb.plan-check.database.pitr.mysql"
+	// PlanCheckDatabaseDeploymentWindow is the plan check type for the deployment window policy of the database environment.
+	PlanCheckDatabaseDeploymentWindow PlanCheckRunType = "bb.plan-check.database.deployment-window"
+	// PlanCheckDatabaseProductionSafety is the plan check type for the live state of the database, e.g. the locks, replica lag and disk space.
+	PlanCheckDatabaseProductionSafety PlanCheckRunType = "bb.plan-check.database.production-safety"
 )
 
 // PlanCheckRunStatus is the status of a plan check run.
@@ -59,6 +59,55 @@ func (s *Store) GetRolloutPolicy(ctx context.Context, environmentID int) (*store
 	return p, nil
 }
 
+// GetDeploymentWindowPolicy gets the deployment window policy for an environment.
+// It returns nil if the environment has no deployment window policy.
+func (s *Store) GetDeploymentWindowPolicy(ctx context.Context, environmentID int) (*storepb.DeploymentWindowPolicy, error) {
+	resourceType := api.PolicyResourceTypeEnvironment
+	pType := api.PolicyTypeDeploymentWindow
+	policy, err := s.GetPolicyV2(ctx, &FindPolicyMessage{
+		ResourceType: &resourceType,
+		ResourceUID:  &environmentID,
+		Type:         &pType,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get policy")
+	}
+	if policy == nil {
+		return nil, nil
+	}
+
+	p := &storepb.DeploymentWindowPolicy{}
+	if err := protojson.Unmarshal([]byte(policy.Payload), p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal deployment window policy")
+	}
+
+	return p, nil
+}
+
+// GetProductionSafetyPolicy gets the production safety policy for an environment.
+// It returns an empty policy if the environment has no production safety policy, so the checks use the default thresholds.
+func (s *Store) GetProductionSafetyPolicy(ctx context.Context, environmentID int) (*storepb.ProductionSafetyPolicy, error) {
+	resourceType := api.PolicyResourceTypeEnvironment
+	pType := api.PolicyTypeProductionSafety
+	policy, err := s.GetPolicyV2(ctx, &FindPolicyMessage{
+		ResourceType: &resourceType,
+		ResourceUID:  &environmentID,
+		Type:         &pType,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get policy")
+	}
+	p := &storepb.ProductionSafetyPolicy{}
+	if policy == nil {
+		return p, nil
+	}
+	if err := protojson.Unmarshal([]byte(policy.Payload), p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal production safety policy")
+	}
+
+	return p, nil
+}
+
 // GetSQLReviewPolicy will get the SQL review policy for an environment.
 func (s *Store) GetSQLReviewPolicy(ctx context.Context, environmentID int) (*storepb.SQLReviewPolicy, error) {
 	resourceType := api.PolicyResourceTypeEnvironment
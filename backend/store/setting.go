@@ -152,6 +152,26 @@ func (s *Store) GetMaskingAlgorithmSetting(ctx context.Context) (*storepb.Maskin
 	return payload, nil
 }
 
+// GetWorkloadIdentitySetting gets the workload identity setting.
+func (s *Store) GetWorkloadIdentitySetting(ctx context.Context) (*storepb.WorkloadIdentitySetting, error) {
+	settingName := api.SettingWorkloadIdentity
+	setting, err := s.GetSettingV2(ctx, &FindSettingMessage{
+		Name: &settingName,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get setting %s", settingName)
+	}
+	if setting == nil {
+		return &storepb.WorkloadIdentitySetting{}, nil
+	}
+
+	payload := new(storepb.WorkloadIdentitySetting)
+	if err := protojson.Unmarshal([]byte(setting.Value), payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
 // GetSemanticTypesSetting gets the semantic types setting.
 func (s *Store) GetSemanticTypesSetting(ctx context.Context) (*storepb.SemanticTypeSetting, error) {
 	settingName := api.SettingSemanticTypes
@@ -5,6 +5,11 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
 // StageMessage is the message for stage.
@@ -12,6 +17,7 @@ type StageMessage struct {
 	Name          string
 	EnvironmentID int
 	PipelineID    int
+	Payload       *storepb.StagePayload
 	TaskList      []*TaskMessage
 
 	// Output only.
@@ -41,15 +47,24 @@ func (s *Store) CreateStageV2(ctx context.Context, stagesCreate []*StageMessage,
 	var valueStr []string
 	var values []any
 	for i, create := range stagesCreate {
+		payload := create.Payload
+		if payload == nil {
+			payload = &storepb.StagePayload{}
+		}
+		payloadBytes, err := protojson.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal stage payload")
+		}
 		values = append(values,
 			creatorID,
 			creatorID,
 			create.PipelineID,
 			create.EnvironmentID,
 			create.Name,
+			payloadBytes,
 		)
-		const count = 5
-		valueStr = append(valueStr, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d)", i*count+1, i*count+2, i*count+3, i*count+4, i*count+5))
+		const count = 6
+		valueStr = append(valueStr, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d)", i*count+1, i*count+2, i*count+3, i*count+4, i*count+5, i*count+6))
 	}
 
 	query := fmt.Sprintf(`
@@ -59,9 +74,10 @@ func (s *Store) CreateStageV2(ctx context.Context, stagesCreate []*StageMessage,
 	  		updater_id,
 	  		pipeline_id,
 	  		environment_id,
-	  		name
+	  		name,
+	  		payload
 	  	) VALUES %s
-	  	RETURNING id, pipeline_id, environment_id, name
+	  	RETURNING id, pipeline_id, environment_id, name, payload
     ) SELECT * FROM inserted ORDER BY id ASC
     `, strings.Join(valueStr, ","))
 	rows, err := tx.QueryContext(ctx, query, values...)
@@ -72,15 +88,22 @@ func (s *Store) CreateStageV2(ctx context.Context, stagesCreate []*StageMessage,
 
 	var stages []*StageMessage
 	for rows.Next() {
-		var stage StageMessage
+		stage := StageMessage{
+			Payload: &storepb.StagePayload{},
+		}
+		var payload []byte
 		if err := rows.Scan(
 			&stage.ID,
 			&stage.PipelineID,
 			&stage.EnvironmentID,
 			&stage.Name,
+			&payload,
 		); err != nil {
 			return nil, err
 		}
+		if err := protojson.Unmarshal(payload, stage.Payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal stage payload")
+		}
 		stages = append(stages, &stage)
 	}
 	if err := rows.Err(); err != nil {
@@ -111,6 +134,7 @@ func (s *Store) ListStageV2(ctx context.Context, pipelineUID int) ([]*StageMessa
 			stage.pipeline_id,
 			stage.environment_id,
 			stage.name,
+			stage.payload,
 			(
 				SELECT EXISTS (
 					SELECT 1 FROM task
@@ -144,16 +168,23 @@ func (s *Store) ListStageV2(ctx context.Context, pipelineUID int) ([]*StageMessa
 
 	var stages []*StageMessage
 	for rows.Next() {
-		var stage StageMessage
+		stage := StageMessage{
+			Payload: &storepb.StagePayload{},
+		}
+		var payload []byte
 		if err := rows.Scan(
 			&stage.ID,
 			&stage.PipelineID,
 			&stage.EnvironmentID,
 			&stage.Name,
+			&payload,
 			&stage.Active,
 		); err != nil {
 			return nil, err
 		}
+		if err := protojson.Unmarshal(payload, stage.Payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal stage payload")
+		}
 
 		stages = append(stages, &stage)
 	}
@@ -8,6 +8,7 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgtype"
 	"github.com/pkg/errors"
@@ -66,6 +67,81 @@ func (s *Store) GetTaskV2ByID(ctx context.Context, id int) (*TaskMessage, error)
 
 // CreateTasksV2 creates a new task.
 func (s *Store) CreateTasksV2(ctx context.Context, creates ...*TaskMessage) ([]*TaskMessage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	tasks, err := s.createTasksTx(ctx, tx, creates)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CreateRollbackTasks creates the tasks rolling back the change of task rollbackFromTaskID in its stage,
+// the task DAGs between them and their pending task runs in one transaction.
+// The rolled back task is locked, so the concurrent calls for the same task create the rollback tasks only once.
+// It fails with the Conflict code if the task has a rollback task which is not skipped.
+func (s *Store) CreateRollbackTasks(ctx context.Context, rollbackFromTaskID int, creates []*TaskMessage, taskIndexDAGs []TaskIndexDAG, creatorID int) ([]*TaskMessage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var pipelineID int
+	if err := tx.QueryRowContext(ctx, `SELECT pipeline_id FROM task WHERE id = $1 FOR UPDATE`, rollbackFromTaskID).Scan(&pipelineID); err != nil {
+		return nil, errors.Wrapf(err, "failed to lock task %d", rollbackFromTaskID)
+	}
+	var rollbackTaskID int
+	if err := tx.QueryRowContext(ctx, `
+		SELECT id FROM task
+		WHERE pipeline_id = $1 AND payload->>'rollbackFromTaskId' = $2 AND NOT COALESCE((payload->>'skipped')::BOOLEAN, FALSE)
+		LIMIT 1`, pipelineID, fmt.Sprintf("%d", rollbackFromTaskID)).Scan(&rollbackTaskID); err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "failed to find the rollback tasks of task %d", rollbackFromTaskID)
+	} else if err == nil {
+		return nil, common.Errorf(common.Conflict, "task %d is already rolled back by task %d", rollbackFromTaskID, rollbackTaskID)
+	}
+
+	tasks, err := s.createTasksTx(ctx, tx, creates)
+	if err != nil {
+		return nil, err
+	}
+	for _, indexDAG := range taskIndexDAGs {
+		if err := s.createTaskDAGTx(ctx, tx, &TaskDAGMessage{
+			FromTaskID: tasks[indexDAG.FromIndex].ID,
+			ToTaskID:   tasks[indexDAG.ToIndex].ID,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	var taskRunCreates []*TaskRunMessage
+	var attempts []int
+	for _, task := range tasks {
+		taskRunCreates = append(taskRunCreates, &TaskRunMessage{
+			TaskUID:   task.ID,
+			Name:      fmt.Sprintf("%s %d", task.Name, time.Now().Unix()),
+			CreatorID: creatorID,
+		})
+		attempts = append(attempts, 0)
+	}
+	if err := s.createPendingTaskRunsTx(ctx, tx, attempts, taskRunCreates); err != nil {
+		return nil, errors.Wrapf(err, "failed to create pending task runs")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (*Store) createTasksTx(ctx context.Context, tx *Tx, creates []*TaskMessage) ([]*TaskMessage, error) {
 	var query strings.Builder
 	var values []any
 	var queryValues []string
@@ -115,12 +191,6 @@ func (s *Store) CreateTasksV2(ctx context.Context, creates ...*TaskMessage) ([]*
 		return nil, err
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer tx.Rollback()
-
 	var tasks []*TaskMessage
 	rows, err := tx.QueryContext(ctx, query.String(), values...)
 	if err != nil {
@@ -157,10 +227,6 @@ func (s *Store) CreateTasksV2(ctx context.Context, creates ...*TaskMessage) ([]*
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
 	return tasks, nil
 }
 
@@ -306,8 +372,8 @@ func (s *Store) UpdateTaskV2(ctx context.Context, patch *api.TaskPatch) (*TaskMe
 	if (patch.SchemaVersion != nil || patch.SheetID != nil) && patch.Payload != nil {
 		return nil, errors.Errorf("cannot set both sheetID/schemaVersion and payload for TaskPatch")
 	}
-	if (patch.RollbackEnabled != nil || patch.RollbackSQLStatus != nil || patch.RollbackSheetID != nil || patch.RollbackError != nil || patch.Flags != nil) && patch.Payload != nil {
-		return nil, errors.Errorf("cannot set both rollbackEnabled/rollbackSQLStatus/rollbackSheetID/rollbackError/flags payload for TaskPatch")
+	if (patch.RollbackEnabled != nil || patch.RollbackSQLStatus != nil || patch.RollbackSheetID != nil || patch.RollbackError != nil || patch.Flags != nil || patch.BatchConfig != nil) && patch.Payload != nil {
+		return nil, errors.Errorf("cannot set both rollbackEnabled/rollbackSQLStatus/rollbackSheetID/rollbackError/flags/batchConfig payload for TaskPatch")
 	}
 	var payloadSet []string
 	if v := patch.SheetID; v != nil {
@@ -335,6 +401,13 @@ func (s *Store) UpdateTaskV2(ctx context.Context, patch *api.TaskPatch) (*TaskMe
 		}
 		payloadSet, args = append(payloadSet, fmt.Sprintf(`jsonb_build_object('flags', $%d::JSONB)`, len(args)+1)), append(args, jsonb)
 	}
+	if v := patch.BatchConfig; v != nil {
+		jsonb, err := json.Marshal(*v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal batch config")
+		}
+		payloadSet, args = append(payloadSet, fmt.Sprintf(`jsonb_build_object('batchConfig', $%d::JSONB)`, len(args)+1)), append(args, jsonb)
+	}
 	if len(payloadSet) != 0 {
 		set = append(set, fmt.Sprintf(`payload = payload || %s`, strings.Join(payloadSet, "||")))
 	}
@@ -409,6 +482,41 @@ func (s *Store) BatchSkipTasks(ctx context.Context, taskUIDs []int, comment stri
 	return nil
 }
 
+// batchOverrideDeploymentWindowTx lets the latest task runs of the tasks run regardless of the deployment window policy.
+// The later task runs of the tasks are still subject to the policy.
+func (*Store) batchOverrideDeploymentWindowTx(ctx context.Context, tx *Tx, taskUIDs []int, reason string, updaterUID int) error {
+	query := `
+	UPDATE task
+	SET updater_id = $1, payload = payload || jsonb_build_object('deploymentWindowOverrideTaskRun', (
+		SELECT task_run.id FROM task_run WHERE task_run.task_id = task.id ORDER BY task_run.id DESC LIMIT 1
+	)) || jsonb_build_object('deploymentWindowOverrideReason', to_jsonb($2::TEXT))
+	WHERE id = ANY($3)`
+	args := []any{updaterUID, reason, taskUIDs}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrapf(err, "failed to batch override deployment window")
+	}
+
+	return nil
+}
+
+// BatchSkipFailedStatement lets the retries of the tasks skip the failed statement of their latest task runs.
+func (s *Store) BatchSkipFailedStatement(ctx context.Context, taskUIDs []int, updaterUID int) error {
+	query := `
+	UPDATE task
+	SET updater_id = $1, payload = payload || jsonb_build_object('skipFailedStatementTaskRun', (
+		SELECT task_run.id FROM task_run WHERE task_run.task_id = task.id ORDER BY task_run.id DESC LIMIT 1
+	))
+	WHERE id = ANY($2)`
+	args := []any{updaterUID, taskUIDs}
+
+	if _, err := s.db.db.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrapf(err, "failed to batch skip failed statement")
+	}
+
+	return nil
+}
+
 // ListTasksToAutoRollout returns tasks that
 // 1. have no task runs
 // 2. are not skipped
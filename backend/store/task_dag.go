@@ -27,6 +27,14 @@ func (s *Store) CreateTaskDAGV2(ctx context.Context, create *TaskDAGMessage) err
 	}
 	defer tx.Rollback()
 
+	if err := s.createTaskDAGTx(ctx, tx, create); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (*Store) createTaskDAGTx(ctx context.Context, tx *Tx, create *TaskDAGMessage) error {
 	query := `
 		INSERT INTO task_dag (
 			from_task_id,
@@ -34,21 +42,15 @@ func (s *Store) CreateTaskDAGV2(ctx context.Context, create *TaskDAGMessage) err
 			payload
 		)
 		VALUES ($1, $2, $3)
-		RETURNING from_task_id, to_task_id
 	`
-	var taskDAG TaskDAGMessage
-	if err := tx.QueryRowContext(ctx, query,
+	if _, err := tx.ExecContext(ctx, query,
 		create.FromTaskID,
 		create.ToTaskID,
 		"{}", /* payload */
-	).Scan(
-		&taskDAG.FromTaskID,
-		&taskDAG.ToTaskID,
 	); err != nil {
 		return err
 	}
-
-	return tx.Commit()
+	return nil
 }
 
 // ListTaskDags lists task dags.
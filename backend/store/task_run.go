@@ -46,6 +46,8 @@ type FindTaskRunMessage struct {
 	StageUID    *int
 	PipelineUID *int
 	Status      *[]api.TaskRunStatus
+	// PostDeployCheckStatus finds the task runs with the post-deployment check in the status.
+	PostDeployCheckStatus *storepb.TaskRunResult_CheckResult_Status
 }
 
 // TaskRunFind is the API message for finding task runs.
@@ -98,6 +100,9 @@ func (s *Store) ListTaskRunsV2(ctx context.Context, find *FindTaskRunMessage) ([
 		}
 		where = append(where, fmt.Sprintf("task_run.status in (%s)", strings.Join(list, ",")))
 	}
+	if v := find.PostDeployCheckStatus; v != nil {
+		where, args = append(where, fmt.Sprintf("task_run.result->'postDeployCheck'->>'status' = $%d", len(args)+1)), append(args, v.String())
+	}
 
 	rows, err := s.db.db.QueryContext(ctx, fmt.Sprintf(`
 		SELECT
@@ -199,8 +204,52 @@ func (s *Store) UpdateTaskRunStatus(ctx context.Context, patch *TaskRunStatusPat
 	return taskRun, nil
 }
 
+// UpdateTaskRunPostDeployCheck updates the post-deployment check result of the done task run if the check is in one of the statuses.
+// It returns a Conflict error if the check is not in the statuses, e.g. the check is overridden while running.
+func (s *Store) UpdateTaskRunPostDeployCheck(ctx context.Context, taskRunUID int, fromStatuses []storepb.TaskRunResult_CheckResult_Status, check *storepb.TaskRunResult_CheckResult) error {
+	checkBytes, err := protojson.Marshal(check)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal post-deployment check result")
+	}
+	var statuses []string
+	for _, status := range fromStatuses {
+		statuses = append(statuses, status.String())
+	}
+	result, err := s.db.db.ExecContext(ctx, `
+	UPDATE task_run
+	SET result = jsonb_set(result, '{postDeployCheck}', $1::JSONB)
+	WHERE id = $2 AND result->'postDeployCheck'->>'status' = ANY($3)`, checkBytes, taskRunUID, statuses)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update post-deployment check result")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return common.Errorf(common.Conflict, "the post-deployment check of task run %d is not in the statuses %v", taskRunUID, statuses)
+	}
+	return nil
+}
+
 // CreatePendingTaskRuns creates pending task runs.
 func (s *Store) CreatePendingTaskRuns(ctx context.Context, creates ...*TaskRunMessage) error {
+	return s.createPendingTaskRuns(ctx, nil, creates)
+}
+
+// DeploymentWindowOverride is the override of the deployment window policy for the task runs.
+type DeploymentWindowOverride struct {
+	Reason    string
+	UpdaterID int
+}
+
+// CreatePendingTaskRunsOverridingDeploymentWindow creates pending task runs which run regardless of the deployment window policy.
+// The task runs and the override are written in one transaction, so the scheduler never sees the task runs without the override.
+func (s *Store) CreatePendingTaskRunsOverridingDeploymentWindow(ctx context.Context, override *DeploymentWindowOverride, creates ...*TaskRunMessage) error {
+	return s.createPendingTaskRuns(ctx, override, creates)
+}
+
+func (s *Store) createPendingTaskRuns(ctx context.Context, override *DeploymentWindowOverride, creates []*TaskRunMessage) error {
 	if len(creates) == 0 {
 		return nil
 	}
@@ -237,6 +286,12 @@ func (s *Store) CreatePendingTaskRuns(ctx context.Context, creates ...*TaskRunMe
 		return errors.Wrapf(err, "failed to create pending task runs")
 	}
 
+	if override != nil {
+		if err := s.batchOverrideDeploymentWindowTx(ctx, tx, taskIDs, override.Reason, override.UpdaterID); err != nil {
+			return err
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return errors.Wrapf(err, "failed to commit tx")
 	}
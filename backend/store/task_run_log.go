@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// TaskRunLogMessage is the message for a task run log entry.
+type TaskRunLogMessage struct {
+	TaskRunUID int
+	Payload    *storepb.TaskRunLog
+
+	// Output only fields
+	UID         int64
+	CreatedTime time.Time
+}
+
+// FindTaskRunLogMessage is the message for finding task run log entries.
+type FindTaskRunLogMessage struct {
+	TaskRunUID int
+	// AfterUID lists the log entries after the entry with the UID.
+	AfterUID *int64
+}
+
+// CreateTaskRunLogs creates the task run log entries in one insert.
+func (s *Store) CreateTaskRunLogs(ctx context.Context, creates []*TaskRunLogMessage) error {
+	if len(creates) == 0 {
+		return nil
+	}
+	var valueStr []string
+	var values []any
+	for i, create := range creates {
+		payload, err := protojson.Marshal(create.Payload)
+		if err != nil {
+			return err
+		}
+		values = append(values, create.TaskRunUID, payload)
+		valueStr = append(valueStr, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
+	}
+	if _, err := s.db.db.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO task_run_log (
+			task_run_id,
+			payload
+		)
+		VALUES %s
+	`, strings.Join(valueStr, ", ")), values...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteOutdatedTaskRunLogs deletes the task run log entries created before the time.
+func (s *Store) DeleteOutdatedTaskRunLogs(ctx context.Context, before time.Time) error {
+	if _, err := s.db.db.ExecContext(ctx, `DELETE FROM task_run_log WHERE created_ts < $1`, before.Unix()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListTaskRunLogs lists the log entries of a task run in the order of creation.
+func (s *Store) ListTaskRunLogs(ctx context.Context, find *FindTaskRunLogMessage) ([]*TaskRunLogMessage, error) {
+	where, args := []string{"task_run_id = $1"}, []any{find.TaskRunUID}
+	if v := find.AfterUID; v != nil {
+		where, args = append(where, fmt.Sprintf("id > $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := s.db.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT
+			id,
+			task_run_id,
+			created_ts,
+			payload
+		FROM task_run_log
+		WHERE %s
+		ORDER BY id ASC`, strings.Join(where, " AND ")),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []*TaskRunLogMessage
+	for rows.Next() {
+		var log TaskRunLogMessage
+		var createdTs int64
+		var payload []byte
+		if err := rows.Scan(
+			&log.UID,
+			&log.TaskRunUID,
+			&createdTs,
+			&payload,
+		); err != nil {
+			return nil, err
+		}
+		logPayload := &storepb.TaskRunLog{}
+		if err := protojsonUnmarshaler.Unmarshal(payload, logPayload); err != nil {
+			return nil, err
+		}
+		log.Payload = logPayload
+		log.CreatedTime = time.Unix(createdTs, 0)
+		logs = append(logs, &log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedDriver is a database/sql driver recording the statements of the connections,
+// it fails the statements containing failOn.
+type scriptedDriver struct {
+	mu         sync.Mutex
+	statements []string
+	failOn     string
+}
+
+func (d *scriptedDriver) Open(string) (driver.Conn, error) {
+	return &scriptedConn{d: d}, nil
+}
+
+func (d *scriptedDriver) record(statement string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.statements = append(d.statements, statement)
+	if d.failOn != "" && strings.Contains(statement, d.failOn) {
+		return errors.Errorf("failed to run %q", d.failOn)
+	}
+	return nil
+}
+
+type scriptedConn struct {
+	d *scriptedDriver
+}
+
+func (*scriptedConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (*scriptedConn) Close() error {
+	return nil
+}
+
+func (c *scriptedConn) Begin() (driver.Tx, error) {
+	if err := c.d.record("BEGIN"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *scriptedConn) Commit() error {
+	return c.d.record("COMMIT")
+}
+
+func (c *scriptedConn) Rollback() error {
+	return c.d.record("ROLLBACK")
+}
+
+func (*scriptedConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (c *scriptedConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if err := c.d.record(query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *scriptedConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if err := c.d.record(query); err != nil {
+		return nil, err
+	}
+	switch {
+	case strings.Contains(query, "SELECT EXISTS"):
+		return &scriptedRows{values: [][]driver.Value{{false}}}, nil
+	case strings.Contains(query, "MAX(attempt)"):
+		return &scriptedRows{values: [][]driver.Value{{int64(1)}}}, nil
+	default:
+		return nil, errors.Errorf("unexpected query %q", query)
+	}
+}
+
+type scriptedRows struct {
+	values [][]driver.Value
+}
+
+func (*scriptedRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (*scriptedRows) Close() error {
+	return nil
+}
+
+func (r *scriptedRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestCreatePendingTaskRunsOverridingDeploymentWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		failOn string
+		want   []string
+	}{
+		{
+			name: "commit",
+			want: []string{"BEGIN", "SELECT EXISTS", "MAX(attempt)", "INSERT INTO task_run", "deploymentWindowOverrideTaskRun", "COMMIT"},
+		},
+		{
+			// The task runs are rolled back with the failed override, so they are never blocked by the deployment window.
+			name:   "override failure",
+			failOn: "deploymentWindowOverrideTaskRun",
+			want:   []string{"BEGIN", "SELECT EXISTS", "MAX(attempt)", "INSERT INTO task_run", "deploymentWindowOverrideTaskRun", "ROLLBACK"},
+		},
+	}
+
+	for _, test := range tests {
+		a := require.New(t)
+		d := &scriptedDriver{failOn: test.failOn}
+		sql.Register("scripted-"+strings.ReplaceAll(test.name, " ", "-"), d)
+		db, err := sql.Open("scripted-"+strings.ReplaceAll(test.name, " ", "-"), "")
+		a.NoError(err)
+		s := &Store{db: &DB{db: db}}
+
+		err = s.CreatePendingTaskRunsOverridingDeploymentWindow(context.Background(), &DeploymentWindowOverride{Reason: "hotfix", UpdaterID: 101}, &TaskRunMessage{TaskUID: 1, Name: "task 1", CreatorID: 101})
+		if test.failOn != "" {
+			a.ErrorContains(err, "failed to batch override deployment window", test.name)
+		} else {
+			a.NoError(err, test.name)
+		}
+		a.NoError(db.Close())
+
+		a.Len(d.statements, len(test.want), test.name)
+		for i, want := range test.want {
+			a.Contains(d.statements[i], want, test.name)
+		}
+	}
+}
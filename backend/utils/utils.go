@@ -4,6 +4,7 @@ package utils
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -631,6 +632,44 @@ func RenderStatement(templateStatement string, secrets map[string]string) string
 	return templateStatement
 }
 
+// GetPostgreSQLSearchPath returns the schemas of the search path of the connection in order, which resolve the unqualified table names.
+func GetPostgreSQLSearchPath(ctx context.Context, sqlDB *sql.DB) ([]string, error) {
+	rows, err := sqlDB.QueryContext(ctx, "SELECT schema_name FROM unnest(current_schemas(false)) WITH ORDINALITY AS t(schema_name, position) ORDER BY position")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get search path")
+	}
+	defer rows.Close()
+	var searchPath []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan search path")
+		}
+		searchPath = append(searchPath, schema)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to scan search path")
+	}
+	return searchPath, nil
+}
+
+// GetPostgreSQLTableSchema returns the first schema of the search path having the table, or empty if there is none.
+func GetPostgreSQLTableSchema(metadata *storepb.DatabaseSchemaMetadata, searchPath []string, tableName string) string {
+	for _, schemaName := range searchPath {
+		for _, schema := range metadata.GetSchemas() {
+			if schema.Name != schemaName {
+				continue
+			}
+			for _, table := range schema.Tables {
+				if table.Name == tableName {
+					return schemaName
+				}
+			}
+		}
+	}
+	return ""
+}
+
 // GetSecretMapFromDatabaseMessage extracts the secret map from the given database message.
 func GetSecretMapFromDatabaseMessage(databaseMessage *store.DatabaseMessage) map[string]string {
 	materials := make(map[string]string)
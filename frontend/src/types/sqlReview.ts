@@ -103,6 +103,9 @@ export type RuleType =
   | "table.no-foreign-key"
   | "table.drop-naming-convention"
   | "table.disallow-partition"
+  | "table.require-ttl"
+  | "table.require-interleave"
+  | "table.require-interleave-on-delete"
   | "table.comment"
   | "naming.table"
   | "naming.column"
@@ -120,6 +123,8 @@ export type RuleType =
   | "column.type-disallow-list"
   | "column.disallow-change-type"
   | "column.disallow-drop-in-index"
+  | "column.disallow-rename-referenced-by-view"
+  | "column.disallow-nullable-in-sorting-key"
   | "column.set-default-for-not-null"
   | "column.disallow-change"
   | "column.disallow-changing-order"
@@ -146,17 +151,25 @@ export type RuleType =
   | "statement.disallow-add-column-with-default"
   | "statement.add-check-not-valid"
   | "statement.disallow-add-not-null"
+  | "statement.disallow-table-rewrite"
+  | "statement.set-not-null-require-check"
+  | "statement.add-fk-not-valid"
+  | "statement.disallow-blocking-maintenance"
+  | "statement.require-lock-timeout"
+  | "statement.disallow-mutation"
   | "schema.backward-compatibility"
   | "database.drop-empty-database"
   | "system.charset.allowlist"
   | "system.collation.allowlist"
   | "system.comment.length"
   | "index.no-duplicate-column"
+  | "index.no-duplicate-index"
   | "index.type-no-blob"
   | "index.key-number-limit"
   | "index.total-number-limit"
   | "index.primary-key-type-allowlist"
   | "index.create-concurrently"
+  | "index.primary-key-disallow-monotonic"
   | "index.pk-type-limit";
 
 // The naming format rule payload.
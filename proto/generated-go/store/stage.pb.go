@@ -0,0 +1,288 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: store/stage.proto
+
+package store
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StagePayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RolloutStrategy *RolloutStrategy `protobuf:"bytes,1,opt,name=rollout_strategy,json=rolloutStrategy,proto3" json:"rollout_strategy,omitempty"`
+	// The title of the deployment in the deployment config that the stage is created from.
+	Deployment string `protobuf:"bytes,2,opt,name=deployment,proto3" json:"deployment,omitempty"`
+}
+
+func (x *StagePayload) Reset() {
+	*x = StagePayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_stage_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StagePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StagePayload) ProtoMessage() {}
+
+func (x *StagePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_stage_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StagePayload.ProtoReflect.Descriptor instead.
+func (*StagePayload) Descriptor() ([]byte, []int) {
+	return file_store_stage_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StagePayload) GetRolloutStrategy() *RolloutStrategy {
+	if x != nil {
+		return x.RolloutStrategy
+	}
+	return nil
+}
+
+func (x *StagePayload) GetDeployment() string {
+	if x != nil {
+		return x.Deployment
+	}
+	return ""
+}
+
+// RolloutStrategy rolls out the tasks of a stage in waves.
+// The first wave is the canary, and each following wave starts after all tasks of the previous waves are finished.
+type RolloutStrategy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of databases in the canary wave.
+	// At most one of canary_count and canary_percent is set.
+	CanaryCount int32 `protobuf:"varint,1,opt,name=canary_count,json=canaryCount,proto3" json:"canary_count,omitempty"`
+	// The percentage of databases in the canary wave.
+	CanaryPercent int32 `protobuf:"varint,2,opt,name=canary_percent,json=canaryPercent,proto3" json:"canary_percent,omitempty"`
+	// The cumulative percentages of databases rolled out by the waves after the canary, in increasing order.
+	// For example, [10, 50] rolls out 10% of the databases, then 50%, then all.
+	// The last wave always covers all databases.
+	WavePercents []int32 `protobuf:"varint,3,rep,packed,name=wave_percents,json=wavePercents,proto3" json:"wave_percents,omitempty"`
+	// The maximum number of databases rolling out at the same time in the stage. 0 means no limit.
+	MaxConcurrency int32 `protobuf:"varint,4,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	// The rollout pauses if the failed databases exceed the percentage of the finished databases in the stage.
+	// 0 pauses the rollout on any failure.
+	MaxFailurePercent int32 `protobuf:"varint,5,opt,name=max_failure_percent,json=maxFailurePercent,proto3" json:"max_failure_percent,omitempty"`
+	// The statement to verify the health of the database after its task is done.
+	// The verification fails if the statement fails or returns no rows, and the failed verification counts as a failed task.
+	VerificationStatement string `protobuf:"bytes,6,opt,name=verification_statement,json=verificationStatement,proto3" json:"verification_statement,omitempty"`
+}
+
+func (x *RolloutStrategy) Reset() {
+	*x = RolloutStrategy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_stage_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RolloutStrategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolloutStrategy) ProtoMessage() {}
+
+func (x *RolloutStrategy) ProtoReflect() protoreflect.Message {
+	mi := &file_store_stage_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolloutStrategy.ProtoReflect.Descriptor instead.
+func (*RolloutStrategy) Descriptor() ([]byte, []int) {
+	return file_store_stage_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RolloutStrategy) GetCanaryCount() int32 {
+	if x != nil {
+		return x.CanaryCount
+	}
+	return 0
+}
+
+func (x *RolloutStrategy) GetCanaryPercent() int32 {
+	if x != nil {
+		return x.CanaryPercent
+	}
+	return 0
+}
+
+func (x *RolloutStrategy) GetWavePercents() []int32 {
+	if x != nil {
+		return x.WavePercents
+	}
+	return nil
+}
+
+func (x *RolloutStrategy) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *RolloutStrategy) GetMaxFailurePercent() int32 {
+	if x != nil {
+		return x.MaxFailurePercent
+	}
+	return 0
+}
+
+func (x *RolloutStrategy) GetVerificationStatement() string {
+	if x != nil {
+		return x.VerificationStatement
+	}
+	return ""
+}
+
+var File_store_stage_proto protoreflect.FileDescriptor
+
+var file_store_stage_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x22, 0x7a, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x67, 0x65, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x12, 0x4a, 0x0a, 0x10, 0x72, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x5f, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x52,
+	0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x0f,
+	0x72, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12,
+	0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x22,
+	0x90, 0x02, 0x0a, 0x0f, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x67, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x61, 0x6e, 0x61, 0x72,
+	0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79,
+	0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
+	0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x77, 0x61, 0x76, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x0c, 0x77, 0x61, 0x76, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78,
+	0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2e, 0x0a, 0x13, 0x6d,
+	0x61, 0x78, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x16, 0x76,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x42, 0x14, 0x5a, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2d,
+	0x67, 0x6f, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_store_stage_proto_rawDescOnce sync.Once
+	file_store_stage_proto_rawDescData = file_store_stage_proto_rawDesc
+)
+
+func file_store_stage_proto_rawDescGZIP() []byte {
+	file_store_stage_proto_rawDescOnce.Do(func() {
+		file_store_stage_proto_rawDescData = protoimpl.X.CompressGZIP(file_store_stage_proto_rawDescData)
+	})
+	return file_store_stage_proto_rawDescData
+}
+
+var file_store_stage_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_store_stage_proto_goTypes = []interface{}{
+	(*StagePayload)(nil),    // 0: bytebase.store.StagePayload
+	(*RolloutStrategy)(nil), // 1: bytebase.store.RolloutStrategy
+}
+var file_store_stage_proto_depIdxs = []int32{
+	1, // 0: bytebase.store.StagePayload.rollout_strategy:type_name -> bytebase.store.RolloutStrategy
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_store_stage_proto_init() }
+func file_store_stage_proto_init() {
+	if File_store_stage_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_store_stage_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StagePayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_stage_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RolloutStrategy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_store_stage_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_store_stage_proto_goTypes,
+		DependencyIndexes: file_store_stage_proto_depIdxs,
+		MessageInfos:      file_store_stage_proto_msgTypes,
+	}.Build()
+	File_store_stage_proto = out.File
+	file_store_stage_proto_rawDesc = nil
+	file_store_stage_proto_goTypes = nil
+	file_store_stage_proto_depIdxs = nil
+}
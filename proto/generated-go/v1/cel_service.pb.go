@@ -22,6 +22,53 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type BatchValidateRequest_Environment int32
+
+const (
+	BatchValidateRequest_ENVIRONMENT_UNSPECIFIED BatchValidateRequest_Environment = 0
+	// The custom SQL review rule, the expression is over the `statement` variable and returns bool.
+	BatchValidateRequest_SQL_REVIEW_CUSTOM_RULE BatchValidateRequest_Environment = 1
+)
+
+// Enum value maps for BatchValidateRequest_Environment.
+var (
+	BatchValidateRequest_Environment_name = map[int32]string{
+		0: "ENVIRONMENT_UNSPECIFIED",
+		1: "SQL_REVIEW_CUSTOM_RULE",
+	}
+	BatchValidateRequest_Environment_value = map[string]int32{
+		"ENVIRONMENT_UNSPECIFIED": 0,
+		"SQL_REVIEW_CUSTOM_RULE":  1,
+	}
+)
+
+func (x BatchValidateRequest_Environment) Enum() *BatchValidateRequest_Environment {
+	p := new(BatchValidateRequest_Environment)
+	*p = x
+	return p
+}
+
+func (x BatchValidateRequest_Environment) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchValidateRequest_Environment) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_cel_service_proto_enumTypes[0].Descriptor()
+}
+
+func (BatchValidateRequest_Environment) Type() protoreflect.EnumType {
+	return &file_v1_cel_service_proto_enumTypes[0]
+}
+
+func (x BatchValidateRequest_Environment) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchValidateRequest_Environment.Descriptor instead.
+func (BatchValidateRequest_Environment) EnumDescriptor() ([]byte, []int) {
+	return file_v1_cel_service_proto_rawDescGZIP(), []int{4, 0}
+}
+
 type BatchParseRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -210,6 +257,109 @@ func (x *BatchDeparseResponse) GetExpressions() []string {
 	return nil
 }
 
+type BatchValidateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Expressions []string                         `protobuf:"bytes,1,rep,name=expressions,proto3" json:"expressions,omitempty"`
+	Environment BatchValidateRequest_Environment `protobuf:"varint,2,opt,name=environment,proto3,enum=bytebase.v1.BatchValidateRequest_Environment" json:"environment,omitempty"`
+}
+
+func (x *BatchValidateRequest) Reset() {
+	*x = BatchValidateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_cel_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchValidateRequest) ProtoMessage() {}
+
+func (x *BatchValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_cel_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchValidateRequest.ProtoReflect.Descriptor instead.
+func (*BatchValidateRequest) Descriptor() ([]byte, []int) {
+	return file_v1_cel_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchValidateRequest) GetExpressions() []string {
+	if x != nil {
+		return x.Expressions
+	}
+	return nil
+}
+
+func (x *BatchValidateRequest) GetEnvironment() BatchValidateRequest_Environment {
+	if x != nil {
+		return x.Environment
+	}
+	return BatchValidateRequest_ENVIRONMENT_UNSPECIFIED
+}
+
+type BatchValidateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The validation errors in the same order as the expressions. The error is empty if the expression is valid.
+	Errors []string `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *BatchValidateResponse) Reset() {
+	*x = BatchValidateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_cel_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchValidateResponse) ProtoMessage() {}
+
+func (x *BatchValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_cel_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchValidateResponse.ProtoReflect.Descriptor instead.
+func (*BatchValidateResponse) Descriptor() ([]byte, []int) {
+	return file_v1_cel_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchValidateResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
 var File_v1_cel_service_proto protoreflect.FileDescriptor
 
 var file_v1_cel_service_proto_rawDesc = []byte{
@@ -238,24 +388,48 @@ var file_v1_cel_service_proto_rawDesc = []byte{
 	0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x70, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
 	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x32, 0xf0, 0x01, 0x0a, 0x0a, 0x43, 0x65, 0x6c, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6c, 0x0a, 0x0a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61,
-	0x72, 0x73, 0x65, 0x12, 0x1e, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x3a, 0x01, 0x2a, 0x22,
-	0x12, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x65, 0x6c, 0x2f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61,
-	0x72, 0x73, 0x65, 0x12, 0x74, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x70, 0x61,
-	0x72, 0x73, 0x65, 0x12, 0x20, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x70, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x70, 0x61, 0x72, 0x73, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19,
-	0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x65, 0x6c, 0x2f, 0x62, 0x61, 0x74,
-	0x63, 0x68, 0x44, 0x65, 0x70, 0x61, 0x72, 0x73, 0x65, 0x42, 0x11, 0x5a, 0x0f, 0x67, 0x65, 0x6e,
-	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2d, 0x67, 0x6f, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xd1, 0x01, 0x0a, 0x14, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x20, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x4f, 0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f,
+	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x22, 0x46, 0x0a, 0x0b, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x4e, 0x56, 0x49, 0x52, 0x4f, 0x4e, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a,
+	0x0a, 0x16, 0x53, 0x51, 0x4c, 0x5f, 0x52, 0x45, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x43, 0x55, 0x53,
+	0x54, 0x4f, 0x4d, 0x5f, 0x52, 0x55, 0x4c, 0x45, 0x10, 0x01, 0x22, 0x2f, 0x0a, 0x15, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x32, 0xea, 0x02, 0x0a, 0x0a,
+	0x43, 0x65, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6c, 0x0a, 0x0a, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x50, 0x61, 0x72, 0x73, 0x65, 0x12, 0x1e, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62,
+	0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x72, 0x73,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62,
+	0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x72, 0x73,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x17, 0x3a, 0x01, 0x2a, 0x22, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x65, 0x6c, 0x2f, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x50, 0x61, 0x72, 0x73, 0x65, 0x12, 0x74, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x44, 0x65, 0x70, 0x61, 0x72, 0x73, 0x65, 0x12, 0x20, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62,
+	0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x70, 0x61,
+	0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x79, 0x74,
+	0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65,
+	0x70, 0x61, 0x72, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x65,
+	0x6c, 0x2f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x70, 0x61, 0x72, 0x73, 0x65, 0x12, 0x78,
+	0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x21, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x3a, 0x01,
+	0x2a, 0x22, 0x15, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x65, 0x6c, 0x2f, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x42, 0x11, 0x5a, 0x0f, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x2d, 0x67, 0x6f, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -270,26 +444,33 @@ func file_v1_cel_service_proto_rawDescGZIP() []byte {
 	return file_v1_cel_service_proto_rawDescData
 }
 
-var file_v1_cel_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_v1_cel_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v1_cel_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_v1_cel_service_proto_goTypes = []interface{}{
-	(*BatchParseRequest)(nil),    // 0: bytebase.v1.BatchParseRequest
-	(*BatchParseResponse)(nil),   // 1: bytebase.v1.BatchParseResponse
-	(*BatchDeparseRequest)(nil),  // 2: bytebase.v1.BatchDeparseRequest
-	(*BatchDeparseResponse)(nil), // 3: bytebase.v1.BatchDeparseResponse
-	(*v1alpha1.ParsedExpr)(nil),  // 4: google.api.expr.v1alpha1.ParsedExpr
+	(BatchValidateRequest_Environment)(0), // 0: bytebase.v1.BatchValidateRequest.Environment
+	(*BatchParseRequest)(nil),             // 1: bytebase.v1.BatchParseRequest
+	(*BatchParseResponse)(nil),            // 2: bytebase.v1.BatchParseResponse
+	(*BatchDeparseRequest)(nil),           // 3: bytebase.v1.BatchDeparseRequest
+	(*BatchDeparseResponse)(nil),          // 4: bytebase.v1.BatchDeparseResponse
+	(*BatchValidateRequest)(nil),          // 5: bytebase.v1.BatchValidateRequest
+	(*BatchValidateResponse)(nil),         // 6: bytebase.v1.BatchValidateResponse
+	(*v1alpha1.ParsedExpr)(nil),           // 7: google.api.expr.v1alpha1.ParsedExpr
 }
 var file_v1_cel_service_proto_depIdxs = []int32{
-	4, // 0: bytebase.v1.BatchParseResponse.expressions:type_name -> google.api.expr.v1alpha1.ParsedExpr
-	4, // 1: bytebase.v1.BatchDeparseRequest.expressions:type_name -> google.api.expr.v1alpha1.ParsedExpr
-	0, // 2: bytebase.v1.CelService.BatchParse:input_type -> bytebase.v1.BatchParseRequest
-	2, // 3: bytebase.v1.CelService.BatchDeparse:input_type -> bytebase.v1.BatchDeparseRequest
-	1, // 4: bytebase.v1.CelService.BatchParse:output_type -> bytebase.v1.BatchParseResponse
-	3, // 5: bytebase.v1.CelService.BatchDeparse:output_type -> bytebase.v1.BatchDeparseResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	7, // 0: bytebase.v1.BatchParseResponse.expressions:type_name -> google.api.expr.v1alpha1.ParsedExpr
+	7, // 1: bytebase.v1.BatchDeparseRequest.expressions:type_name -> google.api.expr.v1alpha1.ParsedExpr
+	0, // 2: bytebase.v1.BatchValidateRequest.environment:type_name -> bytebase.v1.BatchValidateRequest.Environment
+	1, // 3: bytebase.v1.CelService.BatchParse:input_type -> bytebase.v1.BatchParseRequest
+	3, // 4: bytebase.v1.CelService.BatchDeparse:input_type -> bytebase.v1.BatchDeparseRequest
+	5, // 5: bytebase.v1.CelService.BatchValidate:input_type -> bytebase.v1.BatchValidateRequest
+	2, // 6: bytebase.v1.CelService.BatchParse:output_type -> bytebase.v1.BatchParseResponse
+	4, // 7: bytebase.v1.CelService.BatchDeparse:output_type -> bytebase.v1.BatchDeparseResponse
+	6, // 8: bytebase.v1.CelService.BatchValidate:output_type -> bytebase.v1.BatchValidateResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_v1_cel_service_proto_init() }
@@ -346,19 +527,44 @@ func file_v1_cel_service_proto_init() {
 				return nil
 			}
 		}
+		file_v1_cel_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchValidateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_cel_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchValidateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_cel_service_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_v1_cel_service_proto_goTypes,
 		DependencyIndexes: file_v1_cel_service_proto_depIdxs,
+		EnumInfos:         file_v1_cel_service_proto_enumTypes,
 		MessageInfos:      file_v1_cel_service_proto_msgTypes,
 	}.Build()
 	File_v1_cel_service_proto = out.File
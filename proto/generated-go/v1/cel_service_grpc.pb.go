@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	CelService_BatchParse_FullMethodName   = "/bytebase.v1.CelService/BatchParse"
-	CelService_BatchDeparse_FullMethodName = "/bytebase.v1.CelService/BatchDeparse"
+	CelService_BatchParse_FullMethodName    = "/bytebase.v1.CelService/BatchParse"
+	CelService_BatchDeparse_FullMethodName  = "/bytebase.v1.CelService/BatchDeparse"
+	CelService_BatchValidate_FullMethodName = "/bytebase.v1.CelService/BatchValidate"
 )
 
 // CelServiceClient is the client API for CelService service.
@@ -29,6 +30,7 @@ const (
 type CelServiceClient interface {
 	BatchParse(ctx context.Context, in *BatchParseRequest, opts ...grpc.CallOption) (*BatchParseResponse, error)
 	BatchDeparse(ctx context.Context, in *BatchDeparseRequest, opts ...grpc.CallOption) (*BatchDeparseResponse, error)
+	BatchValidate(ctx context.Context, in *BatchValidateRequest, opts ...grpc.CallOption) (*BatchValidateResponse, error)
 }
 
 type celServiceClient struct {
@@ -57,12 +59,22 @@ func (c *celServiceClient) BatchDeparse(ctx context.Context, in *BatchDeparseReq
 	return out, nil
 }
 
+func (c *celServiceClient) BatchValidate(ctx context.Context, in *BatchValidateRequest, opts ...grpc.CallOption) (*BatchValidateResponse, error) {
+	out := new(BatchValidateResponse)
+	err := c.cc.Invoke(ctx, CelService_BatchValidate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CelServiceServer is the server API for CelService service.
 // All implementations must embed UnimplementedCelServiceServer
 // for forward compatibility
 type CelServiceServer interface {
 	BatchParse(context.Context, *BatchParseRequest) (*BatchParseResponse, error)
 	BatchDeparse(context.Context, *BatchDeparseRequest) (*BatchDeparseResponse, error)
+	BatchValidate(context.Context, *BatchValidateRequest) (*BatchValidateResponse, error)
 	mustEmbedUnimplementedCelServiceServer()
 }
 
@@ -76,6 +88,9 @@ func (UnimplementedCelServiceServer) BatchParse(context.Context, *BatchParseRequ
 func (UnimplementedCelServiceServer) BatchDeparse(context.Context, *BatchDeparseRequest) (*BatchDeparseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchDeparse not implemented")
 }
+func (UnimplementedCelServiceServer) BatchValidate(context.Context, *BatchValidateRequest) (*BatchValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchValidate not implemented")
+}
 func (UnimplementedCelServiceServer) mustEmbedUnimplementedCelServiceServer() {}
 
 // UnsafeCelServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -125,6 +140,24 @@ func _CelService_BatchDeparse_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CelService_BatchValidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CelServiceServer).BatchValidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CelService_BatchValidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CelServiceServer).BatchValidate(ctx, req.(*BatchValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CelService_ServiceDesc is the grpc.ServiceDesc for CelService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,6 +173,10 @@ var CelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchDeparse",
 			Handler:    _CelService_BatchDeparse_Handler,
 		},
+		{
+			MethodName: "BatchValidate",
+			Handler:    _CelService_BatchValidate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/cel_service.proto",
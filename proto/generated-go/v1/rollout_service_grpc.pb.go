@@ -19,19 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	RolloutService_GetPlan_FullMethodName             = "/bytebase.v1.RolloutService/GetPlan"
-	RolloutService_ListPlans_FullMethodName           = "/bytebase.v1.RolloutService/ListPlans"
-	RolloutService_CreatePlan_FullMethodName          = "/bytebase.v1.RolloutService/CreatePlan"
-	RolloutService_UpdatePlan_FullMethodName          = "/bytebase.v1.RolloutService/UpdatePlan"
-	RolloutService_GetRollout_FullMethodName          = "/bytebase.v1.RolloutService/GetRollout"
-	RolloutService_CreateRollout_FullMethodName       = "/bytebase.v1.RolloutService/CreateRollout"
-	RolloutService_PreviewRollout_FullMethodName      = "/bytebase.v1.RolloutService/PreviewRollout"
-	RolloutService_ListTaskRuns_FullMethodName        = "/bytebase.v1.RolloutService/ListTaskRuns"
-	RolloutService_ListPlanCheckRuns_FullMethodName   = "/bytebase.v1.RolloutService/ListPlanCheckRuns"
-	RolloutService_RunPlanChecks_FullMethodName       = "/bytebase.v1.RolloutService/RunPlanChecks"
-	RolloutService_BatchRunTasks_FullMethodName       = "/bytebase.v1.RolloutService/BatchRunTasks"
-	RolloutService_BatchSkipTasks_FullMethodName      = "/bytebase.v1.RolloutService/BatchSkipTasks"
-	RolloutService_BatchCancelTaskRuns_FullMethodName = "/bytebase.v1.RolloutService/BatchCancelTaskRuns"
+	RolloutService_GetPlan_FullMethodName                 = "/bytebase.v1.RolloutService/GetPlan"
+	RolloutService_ListPlans_FullMethodName               = "/bytebase.v1.RolloutService/ListPlans"
+	RolloutService_CreatePlan_FullMethodName              = "/bytebase.v1.RolloutService/CreatePlan"
+	RolloutService_UpdatePlan_FullMethodName              = "/bytebase.v1.RolloutService/UpdatePlan"
+	RolloutService_GetRollout_FullMethodName              = "/bytebase.v1.RolloutService/GetRollout"
+	RolloutService_CreateRollout_FullMethodName           = "/bytebase.v1.RolloutService/CreateRollout"
+	RolloutService_PreviewRollout_FullMethodName          = "/bytebase.v1.RolloutService/PreviewRollout"
+	RolloutService_ListTaskRuns_FullMethodName            = "/bytebase.v1.RolloutService/ListTaskRuns"
+	RolloutService_ListPlanCheckRuns_FullMethodName       = "/bytebase.v1.RolloutService/ListPlanCheckRuns"
+	RolloutService_RunPlanChecks_FullMethodName           = "/bytebase.v1.RolloutService/RunPlanChecks"
+	RolloutService_BatchRunTasks_FullMethodName           = "/bytebase.v1.RolloutService/BatchRunTasks"
+	RolloutService_BatchSkipTasks_FullMethodName          = "/bytebase.v1.RolloutService/BatchSkipTasks"
+	RolloutService_BatchCancelTaskRuns_FullMethodName     = "/bytebase.v1.RolloutService/BatchCancelTaskRuns"
+	RolloutService_ControlGhostMigration_FullMethodName   = "/bytebase.v1.RolloutService/ControlGhostMigration"
+	RolloutService_ControlBatchDataUpdate_FullMethodName  = "/bytebase.v1.RolloutService/ControlBatchDataUpdate"
+	RolloutService_RollbackTask_FullMethodName            = "/bytebase.v1.RolloutService/RollbackTask"
+	RolloutService_OverridePostDeployCheck_FullMethodName = "/bytebase.v1.RolloutService/OverridePostDeployCheck"
+	RolloutService_TailTaskRunLogs_FullMethodName         = "/bytebase.v1.RolloutService/TailTaskRunLogs"
 )
 
 // RolloutServiceClient is the client API for RolloutService service.
@@ -51,6 +56,21 @@ type RolloutServiceClient interface {
 	BatchRunTasks(ctx context.Context, in *BatchRunTasksRequest, opts ...grpc.CallOption) (*BatchRunTasksResponse, error)
 	BatchSkipTasks(ctx context.Context, in *BatchSkipTasksRequest, opts ...grpc.CallOption) (*BatchSkipTasksResponse, error)
 	BatchCancelTaskRuns(ctx context.Context, in *BatchCancelTaskRunsRequest, opts ...grpc.CallOption) (*BatchCancelTaskRunsResponse, error)
+	// ControlGhostMigration adjusts a running gh-ost migration through its interactive socket.
+	ControlGhostMigration(ctx context.Context, in *ControlGhostMigrationRequest, opts ...grpc.CallOption) (*ControlGhostMigrationResponse, error)
+	// ControlBatchDataUpdate pauses, resumes or adjusts a running batched data change.
+	// The batched data change is canceled by BatchCancelTaskRuns.
+	ControlBatchDataUpdate(ctx context.Context, in *ControlBatchDataUpdateRequest, opts ...grpc.CallOption) (*ControlBatchDataUpdateResponse, error)
+	// RollbackTask creates the tasks rolling back the change of a task in the same stage, and runs them.
+	// The rollback uses the generated rollback statement of the DATA change,
+	// or restores the latest backup of the database taken before the change.
+	RollbackTask(ctx context.Context, in *RollbackTaskRequest, opts ...grpc.CallOption) (*RollbackTaskResponse, error)
+	// OverridePostDeployCheck reruns the failed post-deployment check of the latest task run of a task,
+	// or passes the running or failed check, which resumes the rollout halted by the check.
+	OverridePostDeployCheck(ctx context.Context, in *OverridePostDeployCheckRequest, opts ...grpc.CallOption) (*TaskRun, error)
+	// TailTaskRunLogs streams the log entries of a task run.
+	// If follow is set, the stream keeps sending the new entries until the task run finishes.
+	TailTaskRunLogs(ctx context.Context, in *TailTaskRunLogsRequest, opts ...grpc.CallOption) (RolloutService_TailTaskRunLogsClient, error)
 }
 
 type rolloutServiceClient struct {
@@ -178,6 +198,74 @@ func (c *rolloutServiceClient) BatchCancelTaskRuns(ctx context.Context, in *Batc
 	return out, nil
 }
 
+func (c *rolloutServiceClient) ControlGhostMigration(ctx context.Context, in *ControlGhostMigrationRequest, opts ...grpc.CallOption) (*ControlGhostMigrationResponse, error) {
+	out := new(ControlGhostMigrationResponse)
+	err := c.cc.Invoke(ctx, RolloutService_ControlGhostMigration_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rolloutServiceClient) ControlBatchDataUpdate(ctx context.Context, in *ControlBatchDataUpdateRequest, opts ...grpc.CallOption) (*ControlBatchDataUpdateResponse, error) {
+	out := new(ControlBatchDataUpdateResponse)
+	err := c.cc.Invoke(ctx, RolloutService_ControlBatchDataUpdate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rolloutServiceClient) RollbackTask(ctx context.Context, in *RollbackTaskRequest, opts ...grpc.CallOption) (*RollbackTaskResponse, error) {
+	out := new(RollbackTaskResponse)
+	err := c.cc.Invoke(ctx, RolloutService_RollbackTask_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rolloutServiceClient) OverridePostDeployCheck(ctx context.Context, in *OverridePostDeployCheckRequest, opts ...grpc.CallOption) (*TaskRun, error) {
+	out := new(TaskRun)
+	err := c.cc.Invoke(ctx, RolloutService_OverridePostDeployCheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rolloutServiceClient) TailTaskRunLogs(ctx context.Context, in *TailTaskRunLogsRequest, opts ...grpc.CallOption) (RolloutService_TailTaskRunLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RolloutService_ServiceDesc.Streams[0], RolloutService_TailTaskRunLogs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rolloutServiceTailTaskRunLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RolloutService_TailTaskRunLogsClient interface {
+	Recv() (*TaskRunLogEntry, error)
+	grpc.ClientStream
+}
+
+type rolloutServiceTailTaskRunLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *rolloutServiceTailTaskRunLogsClient) Recv() (*TaskRunLogEntry, error) {
+	m := new(TaskRunLogEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // RolloutServiceServer is the server API for RolloutService service.
 // All implementations must embed UnimplementedRolloutServiceServer
 // for forward compatibility
@@ -195,6 +283,21 @@ type RolloutServiceServer interface {
 	BatchRunTasks(context.Context, *BatchRunTasksRequest) (*BatchRunTasksResponse, error)
 	BatchSkipTasks(context.Context, *BatchSkipTasksRequest) (*BatchSkipTasksResponse, error)
 	BatchCancelTaskRuns(context.Context, *BatchCancelTaskRunsRequest) (*BatchCancelTaskRunsResponse, error)
+	// ControlGhostMigration adjusts a running gh-ost migration through its interactive socket.
+	ControlGhostMigration(context.Context, *ControlGhostMigrationRequest) (*ControlGhostMigrationResponse, error)
+	// ControlBatchDataUpdate pauses, resumes or adjusts a running batched data change.
+	// The batched data change is canceled by BatchCancelTaskRuns.
+	ControlBatchDataUpdate(context.Context, *ControlBatchDataUpdateRequest) (*ControlBatchDataUpdateResponse, error)
+	// RollbackTask creates the tasks rolling back the change of a task in the same stage, and runs them.
+	// The rollback uses the generated rollback statement of the DATA change,
+	// or restores the latest backup of the database taken before the change.
+	RollbackTask(context.Context, *RollbackTaskRequest) (*RollbackTaskResponse, error)
+	// OverridePostDeployCheck reruns the failed post-deployment check of the latest task run of a task,
+	// or passes the running or failed check, which resumes the rollout halted by the check.
+	OverridePostDeployCheck(context.Context, *OverridePostDeployCheckRequest) (*TaskRun, error)
+	// TailTaskRunLogs streams the log entries of a task run.
+	// If follow is set, the stream keeps sending the new entries until the task run finishes.
+	TailTaskRunLogs(*TailTaskRunLogsRequest, RolloutService_TailTaskRunLogsServer) error
 	mustEmbedUnimplementedRolloutServiceServer()
 }
 
@@ -241,6 +344,21 @@ func (UnimplementedRolloutServiceServer) BatchSkipTasks(context.Context, *BatchS
 func (UnimplementedRolloutServiceServer) BatchCancelTaskRuns(context.Context, *BatchCancelTaskRunsRequest) (*BatchCancelTaskRunsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchCancelTaskRuns not implemented")
 }
+func (UnimplementedRolloutServiceServer) ControlGhostMigration(context.Context, *ControlGhostMigrationRequest) (*ControlGhostMigrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ControlGhostMigration not implemented")
+}
+func (UnimplementedRolloutServiceServer) ControlBatchDataUpdate(context.Context, *ControlBatchDataUpdateRequest) (*ControlBatchDataUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ControlBatchDataUpdate not implemented")
+}
+func (UnimplementedRolloutServiceServer) RollbackTask(context.Context, *RollbackTaskRequest) (*RollbackTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackTask not implemented")
+}
+func (UnimplementedRolloutServiceServer) OverridePostDeployCheck(context.Context, *OverridePostDeployCheckRequest) (*TaskRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OverridePostDeployCheck not implemented")
+}
+func (UnimplementedRolloutServiceServer) TailTaskRunLogs(*TailTaskRunLogsRequest, RolloutService_TailTaskRunLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method TailTaskRunLogs not implemented")
+}
 func (UnimplementedRolloutServiceServer) mustEmbedUnimplementedRolloutServiceServer() {}
 
 // UnsafeRolloutServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -488,6 +606,99 @@ func _RolloutService_BatchCancelTaskRuns_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RolloutService_ControlGhostMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlGhostMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RolloutServiceServer).ControlGhostMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RolloutService_ControlGhostMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RolloutServiceServer).ControlGhostMigration(ctx, req.(*ControlGhostMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RolloutService_ControlBatchDataUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlBatchDataUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RolloutServiceServer).ControlBatchDataUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RolloutService_ControlBatchDataUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RolloutServiceServer).ControlBatchDataUpdate(ctx, req.(*ControlBatchDataUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RolloutService_RollbackTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RolloutServiceServer).RollbackTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RolloutService_RollbackTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RolloutServiceServer).RollbackTask(ctx, req.(*RollbackTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RolloutService_OverridePostDeployCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverridePostDeployCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RolloutServiceServer).OverridePostDeployCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RolloutService_OverridePostDeployCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RolloutServiceServer).OverridePostDeployCheck(ctx, req.(*OverridePostDeployCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RolloutService_TailTaskRunLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailTaskRunLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RolloutServiceServer).TailTaskRunLogs(m, &rolloutServiceTailTaskRunLogsServer{stream})
+}
+
+type RolloutService_TailTaskRunLogsServer interface {
+	Send(*TaskRunLogEntry) error
+	grpc.ServerStream
+}
+
+type rolloutServiceTailTaskRunLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *rolloutServiceTailTaskRunLogsServer) Send(m *TaskRunLogEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // RolloutService_ServiceDesc is the grpc.ServiceDesc for RolloutService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -547,7 +758,29 @@ var RolloutService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchCancelTaskRuns",
 			Handler:    _RolloutService_BatchCancelTaskRuns_Handler,
 		},
+		{
+			MethodName: "ControlGhostMigration",
+			Handler:    _RolloutService_ControlGhostMigration_Handler,
+		},
+		{
+			MethodName: "ControlBatchDataUpdate",
+			Handler:    _RolloutService_ControlBatchDataUpdate_Handler,
+		},
+		{
+			MethodName: "RollbackTask",
+			Handler:    _RolloutService_RollbackTask_Handler,
+		},
+		{
+			MethodName: "OverridePostDeployCheck",
+			Handler:    _RolloutService_OverridePostDeployCheck_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TailTaskRunLogs",
+			Handler:       _RolloutService_TailTaskRunLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/rollout_service.proto",
 }
@@ -0,0 +1,579 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: v1/workspace_config_service.proto
+
+package v1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WorkspaceConfigChange_Action int32
+
+const (
+	WorkspaceConfigChange_ACTION_UNSPECIFIED WorkspaceConfigChange_Action = 0
+	WorkspaceConfigChange_CREATE             WorkspaceConfigChange_Action = 1
+	WorkspaceConfigChange_UPDATE             WorkspaceConfigChange_Action = 2
+	WorkspaceConfigChange_DELETE             WorkspaceConfigChange_Action = 3
+	// Restores the deleted resource, the changed fields are updated afterwards.
+	WorkspaceConfigChange_UNDELETE WorkspaceConfigChange_Action = 4
+)
+
+// Enum value maps for WorkspaceConfigChange_Action.
+var (
+	WorkspaceConfigChange_Action_name = map[int32]string{
+		0: "ACTION_UNSPECIFIED",
+		1: "CREATE",
+		2: "UPDATE",
+		3: "DELETE",
+		4: "UNDELETE",
+	}
+	WorkspaceConfigChange_Action_value = map[string]int32{
+		"ACTION_UNSPECIFIED": 0,
+		"CREATE":             1,
+		"UPDATE":             2,
+		"DELETE":             3,
+		"UNDELETE":           4,
+	}
+)
+
+func (x WorkspaceConfigChange_Action) Enum() *WorkspaceConfigChange_Action {
+	p := new(WorkspaceConfigChange_Action)
+	*p = x
+	return p
+}
+
+func (x WorkspaceConfigChange_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceConfigChange_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_workspace_config_service_proto_enumTypes[0].Descriptor()
+}
+
+func (WorkspaceConfigChange_Action) Type() protoreflect.EnumType {
+	return &file_v1_workspace_config_service_proto_enumTypes[0]
+}
+
+func (x WorkspaceConfigChange_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceConfigChange_Action.Descriptor instead.
+func (WorkspaceConfigChange_Action) EnumDescriptor() ([]byte, []int) {
+	return file_v1_workspace_config_service_proto_rawDescGZIP(), []int{4, 0}
+}
+
+type PlanWorkspaceConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The workspace configuration in YAML.
+	//
+	// The top-level sections are environments, instances, projects, policies and settings.
+	// The entries are the resources in the JSON form of the v1 API, identified by the name,
+	// and the projects accept the extra iamPolicy field for the members.
+	// Only the fields specified in an entry are managed.
+	// The environments, instances and projects which are not in a specified section are deleted,
+	// the policies and the settings are never deleted.
+	Config string `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *PlanWorkspaceConfigRequest) Reset() {
+	*x = PlanWorkspaceConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_workspace_config_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanWorkspaceConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanWorkspaceConfigRequest) ProtoMessage() {}
+
+func (x *PlanWorkspaceConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_workspace_config_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanWorkspaceConfigRequest.ProtoReflect.Descriptor instead.
+func (*PlanWorkspaceConfigRequest) Descriptor() ([]byte, []int) {
+	return file_v1_workspace_config_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlanWorkspaceConfigRequest) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+type PlanWorkspaceConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The changes in the order to be applied.
+	Changes []*WorkspaceConfigChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	// The fingerprint of the configuration and the planned changes.
+	// Pass it to ApplyWorkspaceConfig to apply exactly the planned changes.
+	Fingerprint string `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+}
+
+func (x *PlanWorkspaceConfigResponse) Reset() {
+	*x = PlanWorkspaceConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_workspace_config_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanWorkspaceConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanWorkspaceConfigResponse) ProtoMessage() {}
+
+func (x *PlanWorkspaceConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_workspace_config_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanWorkspaceConfigResponse.ProtoReflect.Descriptor instead.
+func (*PlanWorkspaceConfigResponse) Descriptor() ([]byte, []int) {
+	return file_v1_workspace_config_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlanWorkspaceConfigResponse) GetChanges() []*WorkspaceConfigChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *PlanWorkspaceConfigResponse) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+type ApplyWorkspaceConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The workspace configuration in YAML, see PlanWorkspaceConfigRequest.
+	Config string `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	// The fingerprint returned by PlanWorkspaceConfig.
+	// The configuration is rejected if its plan no longer matches the fingerprint.
+	Fingerprint string `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// Whether to apply the planned deletes.
+	// The configuration is rejected if the plan deletes any resource and it is not set.
+	AllowDeletes bool `protobuf:"varint,3,opt,name=allow_deletes,json=allowDeletes,proto3" json:"allow_deletes,omitempty"`
+}
+
+func (x *ApplyWorkspaceConfigRequest) Reset() {
+	*x = ApplyWorkspaceConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_workspace_config_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyWorkspaceConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyWorkspaceConfigRequest) ProtoMessage() {}
+
+func (x *ApplyWorkspaceConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_workspace_config_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyWorkspaceConfigRequest.ProtoReflect.Descriptor instead.
+func (*ApplyWorkspaceConfigRequest) Descriptor() ([]byte, []int) {
+	return file_v1_workspace_config_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ApplyWorkspaceConfigRequest) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+func (x *ApplyWorkspaceConfigRequest) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *ApplyWorkspaceConfigRequest) GetAllowDeletes() bool {
+	if x != nil {
+		return x.AllowDeletes
+	}
+	return false
+}
+
+type ApplyWorkspaceConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The applied changes in order.
+	Changes []*WorkspaceConfigChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *ApplyWorkspaceConfigResponse) Reset() {
+	*x = ApplyWorkspaceConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_workspace_config_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyWorkspaceConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyWorkspaceConfigResponse) ProtoMessage() {}
+
+func (x *ApplyWorkspaceConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_workspace_config_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyWorkspaceConfigResponse.ProtoReflect.Descriptor instead.
+func (*ApplyWorkspaceConfigResponse) Descriptor() ([]byte, []int) {
+	return file_v1_workspace_config_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ApplyWorkspaceConfigResponse) GetChanges() []*WorkspaceConfigChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type WorkspaceConfigChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the changed resource.
+	// Format:
+	// environments/{environment}
+	// instances/{instance}
+	// instances/{instance}/dataSources/{data source}
+	// projects/{project}
+	// projects/{project}/iamPolicy
+	// {resource name}/policies/{policy type}
+	// settings/{setting}
+	Resource string                       `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Action   WorkspaceConfigChange_Action `protobuf:"varint,2,opt,name=action,proto3,enum=bytebase.v1.WorkspaceConfigChange_Action" json:"action,omitempty"`
+	// The changed fields of UPDATE and UNDELETE.
+	Fields []string `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *WorkspaceConfigChange) Reset() {
+	*x = WorkspaceConfigChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_workspace_config_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkspaceConfigChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceConfigChange) ProtoMessage() {}
+
+func (x *WorkspaceConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_workspace_config_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceConfigChange.ProtoReflect.Descriptor instead.
+func (*WorkspaceConfigChange) Descriptor() ([]byte, []int) {
+	return file_v1_workspace_config_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WorkspaceConfigChange) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *WorkspaceConfigChange) GetAction() WorkspaceConfigChange_Action {
+	if x != nil {
+		return x.Action
+	}
+	return WorkspaceConfigChange_ACTION_UNSPECIFIED
+}
+
+func (x *WorkspaceConfigChange) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+var File_v1_workspace_config_service_proto protoreflect.FileDescriptor
+
+var file_v1_workspace_config_service_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x76, 0x31, 0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31,
+	0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x5f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x39, 0x0a, 0x1a, 0x50, 0x6c, 0x61, 0x6e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0,
+	0x41, 0x02, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x7d, 0x0a, 0x1b, 0x50, 0x6c,
+	0x61, 0x6e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x79, 0x74,
+	0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69,
+	0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x22, 0x86, 0x01, 0x0a, 0x1b, 0x41, 0x70,
+	0x70, 0x6c, 0x79, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x06,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x25, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02,
+	0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x73, 0x22, 0x5c, 0x0a, 0x1c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x57, 0x6f, 0x72, 0x6b, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x22, 0xe2, 0x01, 0x0a, 0x15, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x22, 0x52, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x12, 0x41,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12,
+	0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44,
+	0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x55, 0x4e, 0x44, 0x45, 0x4c,
+	0x45, 0x54, 0x45, 0x10, 0x04, 0x32, 0xbc, 0x02, 0x0a, 0x16, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x8d, 0x01, 0x0a, 0x13, 0x50, 0x6c, 0x61, 0x6e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x27, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62,
+	0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x57, 0x6f, 0x72, 0x6b, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x28, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x6c, 0x61, 0x6e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a, 0x22, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x77, 0x6f, 0x72, 0x6b,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x3a, 0x70, 0x6c, 0x61, 0x6e,
+	0x12, 0x91, 0x01, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x28, 0x2e, 0x62, 0x79, 0x74, 0x65,
+	0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x57, 0x6f, 0x72,
+	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x3a, 0x01, 0x2a, 0x22, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x77,
+	0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x3a, 0x61,
+	0x70, 0x70, 0x6c, 0x79, 0x42, 0x11, 0x5a, 0x0f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x2d, 0x67, 0x6f, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_v1_workspace_config_service_proto_rawDescOnce sync.Once
+	file_v1_workspace_config_service_proto_rawDescData = file_v1_workspace_config_service_proto_rawDesc
+)
+
+func file_v1_workspace_config_service_proto_rawDescGZIP() []byte {
+	file_v1_workspace_config_service_proto_rawDescOnce.Do(func() {
+		file_v1_workspace_config_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_v1_workspace_config_service_proto_rawDescData)
+	})
+	return file_v1_workspace_config_service_proto_rawDescData
+}
+
+var file_v1_workspace_config_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v1_workspace_config_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_v1_workspace_config_service_proto_goTypes = []interface{}{
+	(WorkspaceConfigChange_Action)(0),    // 0: bytebase.v1.WorkspaceConfigChange.Action
+	(*PlanWorkspaceConfigRequest)(nil),   // 1: bytebase.v1.PlanWorkspaceConfigRequest
+	(*PlanWorkspaceConfigResponse)(nil),  // 2: bytebase.v1.PlanWorkspaceConfigResponse
+	(*ApplyWorkspaceConfigRequest)(nil),  // 3: bytebase.v1.ApplyWorkspaceConfigRequest
+	(*ApplyWorkspaceConfigResponse)(nil), // 4: bytebase.v1.ApplyWorkspaceConfigResponse
+	(*WorkspaceConfigChange)(nil),        // 5: bytebase.v1.WorkspaceConfigChange
+}
+var file_v1_workspace_config_service_proto_depIdxs = []int32{
+	5, // 0: bytebase.v1.PlanWorkspaceConfigResponse.changes:type_name -> bytebase.v1.WorkspaceConfigChange
+	5, // 1: bytebase.v1.ApplyWorkspaceConfigResponse.changes:type_name -> bytebase.v1.WorkspaceConfigChange
+	0, // 2: bytebase.v1.WorkspaceConfigChange.action:type_name -> bytebase.v1.WorkspaceConfigChange.Action
+	1, // 3: bytebase.v1.WorkspaceConfigService.PlanWorkspaceConfig:input_type -> bytebase.v1.PlanWorkspaceConfigRequest
+	3, // 4: bytebase.v1.WorkspaceConfigService.ApplyWorkspaceConfig:input_type -> bytebase.v1.ApplyWorkspaceConfigRequest
+	2, // 5: bytebase.v1.WorkspaceConfigService.PlanWorkspaceConfig:output_type -> bytebase.v1.PlanWorkspaceConfigResponse
+	4, // 6: bytebase.v1.WorkspaceConfigService.ApplyWorkspaceConfig:output_type -> bytebase.v1.ApplyWorkspaceConfigResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_v1_workspace_config_service_proto_init() }
+func file_v1_workspace_config_service_proto_init() {
+	if File_v1_workspace_config_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_v1_workspace_config_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanWorkspaceConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_workspace_config_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanWorkspaceConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_workspace_config_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyWorkspaceConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_workspace_config_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyWorkspaceConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_workspace_config_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkspaceConfigChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_v1_workspace_config_service_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v1_workspace_config_service_proto_goTypes,
+		DependencyIndexes: file_v1_workspace_config_service_proto_depIdxs,
+		EnumInfos:         file_v1_workspace_config_service_proto_enumTypes,
+		MessageInfos:      file_v1_workspace_config_service_proto_msgTypes,
+	}.Build()
+	File_v1_workspace_config_service_proto = out.File
+	file_v1_workspace_config_service_proto_rawDesc = nil
+	file_v1_workspace_config_service_proto_goTypes = nil
+	file_v1_workspace_config_service_proto_depIdxs = nil
+}
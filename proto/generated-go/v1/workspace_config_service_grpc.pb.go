@@ -0,0 +1,153 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: v1/workspace_config_service.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	WorkspaceConfigService_PlanWorkspaceConfig_FullMethodName  = "/bytebase.v1.WorkspaceConfigService/PlanWorkspaceConfig"
+	WorkspaceConfigService_ApplyWorkspaceConfig_FullMethodName = "/bytebase.v1.WorkspaceConfigService/ApplyWorkspaceConfig"
+)
+
+// WorkspaceConfigServiceClient is the client API for WorkspaceConfigService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WorkspaceConfigServiceClient interface {
+	// Computes the changes to sync the workspace to the configuration without applying them.
+	PlanWorkspaceConfig(ctx context.Context, in *PlanWorkspaceConfigRequest, opts ...grpc.CallOption) (*PlanWorkspaceConfigResponse, error)
+	// Applies the changes to sync the workspace to the configuration.
+	// Applying the same configuration again makes no changes.
+	ApplyWorkspaceConfig(ctx context.Context, in *ApplyWorkspaceConfigRequest, opts ...grpc.CallOption) (*ApplyWorkspaceConfigResponse, error)
+}
+
+type workspaceConfigServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkspaceConfigServiceClient(cc grpc.ClientConnInterface) WorkspaceConfigServiceClient {
+	return &workspaceConfigServiceClient{cc}
+}
+
+func (c *workspaceConfigServiceClient) PlanWorkspaceConfig(ctx context.Context, in *PlanWorkspaceConfigRequest, opts ...grpc.CallOption) (*PlanWorkspaceConfigResponse, error) {
+	out := new(PlanWorkspaceConfigResponse)
+	err := c.cc.Invoke(ctx, WorkspaceConfigService_PlanWorkspaceConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceConfigServiceClient) ApplyWorkspaceConfig(ctx context.Context, in *ApplyWorkspaceConfigRequest, opts ...grpc.CallOption) (*ApplyWorkspaceConfigResponse, error) {
+	out := new(ApplyWorkspaceConfigResponse)
+	err := c.cc.Invoke(ctx, WorkspaceConfigService_ApplyWorkspaceConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkspaceConfigServiceServer is the server API for WorkspaceConfigService service.
+// All implementations must embed UnimplementedWorkspaceConfigServiceServer
+// for forward compatibility
+type WorkspaceConfigServiceServer interface {
+	// Computes the changes to sync the workspace to the configuration without applying them.
+	PlanWorkspaceConfig(context.Context, *PlanWorkspaceConfigRequest) (*PlanWorkspaceConfigResponse, error)
+	// Applies the changes to sync the workspace to the configuration.
+	// Applying the same configuration again makes no changes.
+	ApplyWorkspaceConfig(context.Context, *ApplyWorkspaceConfigRequest) (*ApplyWorkspaceConfigResponse, error)
+	mustEmbedUnimplementedWorkspaceConfigServiceServer()
+}
+
+// UnimplementedWorkspaceConfigServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWorkspaceConfigServiceServer struct {
+}
+
+func (UnimplementedWorkspaceConfigServiceServer) PlanWorkspaceConfig(context.Context, *PlanWorkspaceConfigRequest) (*PlanWorkspaceConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanWorkspaceConfig not implemented")
+}
+func (UnimplementedWorkspaceConfigServiceServer) ApplyWorkspaceConfig(context.Context, *ApplyWorkspaceConfigRequest) (*ApplyWorkspaceConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyWorkspaceConfig not implemented")
+}
+func (UnimplementedWorkspaceConfigServiceServer) mustEmbedUnimplementedWorkspaceConfigServiceServer() {
+}
+
+// UnsafeWorkspaceConfigServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorkspaceConfigServiceServer will
+// result in compilation errors.
+type UnsafeWorkspaceConfigServiceServer interface {
+	mustEmbedUnimplementedWorkspaceConfigServiceServer()
+}
+
+func RegisterWorkspaceConfigServiceServer(s grpc.ServiceRegistrar, srv WorkspaceConfigServiceServer) {
+	s.RegisterService(&WorkspaceConfigService_ServiceDesc, srv)
+}
+
+func _WorkspaceConfigService_PlanWorkspaceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanWorkspaceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceConfigServiceServer).PlanWorkspaceConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceConfigService_PlanWorkspaceConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceConfigServiceServer).PlanWorkspaceConfig(ctx, req.(*PlanWorkspaceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceConfigService_ApplyWorkspaceConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyWorkspaceConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceConfigServiceServer).ApplyWorkspaceConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceConfigService_ApplyWorkspaceConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceConfigServiceServer).ApplyWorkspaceConfig(ctx, req.(*ApplyWorkspaceConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WorkspaceConfigService_ServiceDesc is the grpc.ServiceDesc for WorkspaceConfigService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WorkspaceConfigService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bytebase.v1.WorkspaceConfigService",
+	HandlerType: (*WorkspaceConfigServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlanWorkspaceConfig",
+			Handler:    _WorkspaceConfigService_PlanWorkspaceConfig_Handler,
+		},
+		{
+			MethodName: "ApplyWorkspaceConfig",
+			Handler:    _WorkspaceConfigService_ApplyWorkspaceConfig_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v1/workspace_config_service.proto",
+}